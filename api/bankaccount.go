@@ -4,10 +4,10 @@ import (
     "encoding/json"
     "github.com/go-chi/chi"
     "github.com/go-chi/render"
-    "github.com/go-playground/validator/v10"
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/pkg/constant"
     types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
     "github.com/pranayhere/simple-wallet/service"
     "github.com/pranayhere/simple-wallet/token"
     "net/http"
@@ -20,6 +20,7 @@ type BankAccountResource interface {
     VerificationFailed(w http.ResponseWriter, r *http.Request)
     Get(w http.ResponseWriter, r *http.Request)
     RegisterRoutes(r chi.Router)
+    RegisterAdminRoutes(r chi.Router)
 }
 
 type bankAccountResource struct {
@@ -35,8 +36,13 @@ func NewBankAccountResource(bankAcctSvc service.BankAccountSvc) BankAccountResou
 func (b *bankAccountResource) RegisterRoutes(r chi.Router) {
     r.Get("/bank-accounts/{bankAcctID}", b.Get)
     r.Post("/bank-accounts", b.Create)
-    r.Patch("/bank-accounts/{bankAcctID}/verification-success", b.VerificationSuccess)
-    r.Patch("/bank-accounts/{bankAcctID}/verification-failed", b.VerificationFailed)
+}
+
+// RegisterAdminRoutes registers the manual override of the penny drop verification, the router must only let admins
+// through.
+func (b *bankAccountResource) RegisterAdminRoutes(r chi.Router) {
+    r.Patch("/admin/bank-accounts/{bankAcctID}/verification-success", b.VerificationSuccess)
+    r.Patch("/admin/bank-accounts/{bankAcctID}/verification-failed", b.VerificationFailed)
 }
 
 func (b *bankAccountResource) Create(w http.ResponseWriter, r *http.Request) {
@@ -52,7 +58,7 @@ func (b *bankAccountResource) Create(w http.ResponseWriter, r *http.Request) {
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
@@ -2,17 +2,21 @@ package api_test
 
 import (
     "bytes"
+    "context"
     "database/sql"
     "encoding/json"
     "fmt"
     "github.com/go-chi/chi"
     "github.com/golang/mock/gomock"
     "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/middleware"
     "github.com/pranayhere/simple-wallet/pkg/constant"
     "github.com/pranayhere/simple-wallet/pkg/errors"
     mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
     "github.com/pranayhere/simple-wallet/token"
     "github.com/pranayhere/simple-wallet/util"
     "github.com/stretchr/testify/require"
@@ -30,6 +34,17 @@ func AddAuthorization(t *testing.T, request *http.Request, tokenMaker token.Make
     request.Header.Set(constant.AuthorizationHeaderKey, authorizationHeader)
 }
 
+// NewTestUserRepo returns a user repo whose users never changed their password, so that no token is revoked.
+func NewTestUserRepo(t *testing.T) store.UserRepo {
+    ctrl := gomock.NewController(t)
+    mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+    mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+        DoAndReturn(func(ctx context.Context, id int64) (domain.User, error) {
+            return domain.User{ID: id, Status: domain.UserStatusACTIVE}, nil
+        })
+    return mockUserRepo
+}
+
 func TestCreateBankAccount(t *testing.T) {
     createBankAccountDto := util.RandomCreateBankAccountDto("INR")
     bankAccount := util.RandomBankAccount(createBankAccountDto)
@@ -47,7 +62,6 @@ func TestCreateBankAccount(t *testing.T) {
             body: map[string]interface{}{
                 "account_no": createBankAccountDto.AccountNo,
                 "ifsc":       createBankAccountDto.Ifsc,
-                "bank_name":  createBankAccountDto.BankName,
                 "currency":   createBankAccountDto.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -65,7 +79,6 @@ func TestCreateBankAccount(t *testing.T) {
             body: map[string]interface{}{
                 "account_no": createBankAccountDto.AccountNo,
                 "ifsc":       createBankAccountDto.Ifsc,
-                "bank_name":  createBankAccountDto.BankName,
                 "currency":   createBankAccountDto.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -83,7 +96,6 @@ func TestCreateBankAccount(t *testing.T) {
             body: map[string]interface{}{
                 "account_no": 1,
                 "ifsc":       createBankAccountDto.Ifsc,
-                "bank_name":  createBankAccountDto.BankName,
                 "currency":   createBankAccountDto.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -99,9 +111,25 @@ func TestCreateBankAccount(t *testing.T) {
         {
             name: "ValidationError",
             body: map[string]interface{}{
-                "ifsc":      createBankAccountDto.Ifsc,
-                "bank_name": createBankAccountDto.BankName,
-                "currency":  createBankAccountDto.Currency,
+                "ifsc":     createBankAccountDto.Ifsc,
+                "currency": createBankAccountDto.Currency,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, bankAccount.UserID, time.Minute)
+            },
+            buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
+                mockBankAcctSvc.EXPECT().CreateBankAccount(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidIfsc",
+            body: map[string]interface{}{
+                "account_no": createBankAccountDto.AccountNo,
+                "ifsc":       "HDFC000076",
+                "currency":   createBankAccountDto.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
                 AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, bankAccount.UserID, time.Minute)
@@ -118,7 +146,6 @@ func TestCreateBankAccount(t *testing.T) {
             body: map[string]interface{}{
                 "account_no": createBankAccountDto.AccountNo,
                 "ifsc":       createBankAccountDto.Ifsc,
-                "bank_name":  createBankAccountDto.BankName,
                 "currency":   createBankAccountDto.Currency,
             },
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -143,7 +170,7 @@ func TestCreateBankAccount(t *testing.T) {
             tc.buildStub(mockBankAcctSvc)
 
             recorder := httptest.NewRecorder()
-            router := chi.NewRouter().With(middleware.Auth(tokenMaker))
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
 
             bankAcctApi := api.NewBankAccountResource(mockBankAcctSvc)
             bankAcctApi.RegisterRoutes(router)
@@ -253,7 +280,7 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
     }{
         {
             name: "Ok",
-            url:  fmt.Sprintf("/bank-accounts/%v/verification-success", 1),
+            url:  fmt.Sprintf("/admin/bank-accounts/%v/verification-success", 1),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationSuccess(gomock.Any(), gomock.Any()).Times(1).Return(bankAccountDto, nil)
             },
@@ -263,7 +290,7 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
         },
         {
             name: "InvalidBankAccountId",
-            url:  fmt.Sprintf("/bank-accounts/%s/verification-success", "invalid-id"),
+            url:  fmt.Sprintf("/admin/bank-accounts/%s/verification-success", "invalid-id"),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationSuccess(gomock.Any(), gomock.Any()).Times(0)
             },
@@ -273,7 +300,7 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
         },
         {
             name: "InternalServerError",
-            url:  fmt.Sprintf("/bank-accounts/%d/verification-success", 1),
+            url:  fmt.Sprintf("/admin/bank-accounts/%d/verification-success", 1),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationSuccess(gomock.Any(), gomock.Any()).Times(1).Return(dto.BankAccountDto{}, sql.ErrConnDone)
             },
@@ -283,7 +310,7 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
         },
         {
             name: "BankAccountNotFound",
-            url:  fmt.Sprintf("/bank-accounts/%d/verification-success", 1),
+            url:  fmt.Sprintf("/admin/bank-accounts/%d/verification-success", 1),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationSuccess(gomock.Any(), gomock.Any()).Times(1).Return(dto.BankAccountDto{}, errors.ErrBankAccountNotFound)
             },
@@ -305,7 +332,7 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
             router := chi.NewRouter()
 
             bankAcctApi := api.NewBankAccountResource(mockBankAcctSvc)
-            bankAcctApi.RegisterRoutes(router)
+            bankAcctApi.RegisterAdminRoutes(router)
 
             request, err := http.NewRequest(http.MethodPatch, tc.url, nil)
             require.NoError(t, err)
@@ -329,7 +356,7 @@ func TestBankAccountVerificationFailed(t *testing.T) {
     }{
         {
             name: "Ok",
-            url:  fmt.Sprintf("/bank-accounts/%v/verification-failed", 1),
+            url:  fmt.Sprintf("/admin/bank-accounts/%v/verification-failed", 1),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationFailed(gomock.Any(), gomock.Any()).Times(1).Return(bankAccountDto, nil)
             },
@@ -339,7 +366,7 @@ func TestBankAccountVerificationFailed(t *testing.T) {
         },
         {
             name: "InvalidBankAccountId",
-            url:  fmt.Sprintf("/bank-accounts/%s/verification-failed", "invalid-id"),
+            url:  fmt.Sprintf("/admin/bank-accounts/%s/verification-failed", "invalid-id"),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationFailed(gomock.Any(), gomock.Any()).Times(0)
             },
@@ -349,7 +376,7 @@ func TestBankAccountVerificationFailed(t *testing.T) {
         },
         {
             name: "InternalServerError",
-            url:  fmt.Sprintf("/bank-accounts/%d/verification-failed", 1),
+            url:  fmt.Sprintf("/admin/bank-accounts/%d/verification-failed", 1),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationFailed(gomock.Any(), gomock.Any()).Times(1).Return(dto.BankAccountDto{}, sql.ErrConnDone)
             },
@@ -359,7 +386,7 @@ func TestBankAccountVerificationFailed(t *testing.T) {
         },
         {
             name: "BankAccountNotFound",
-            url:  fmt.Sprintf("/bank-accounts/%d/verification-failed", 1),
+            url:  fmt.Sprintf("/admin/bank-accounts/%d/verification-failed", 1),
             buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
                 mockBankAcctSvc.EXPECT().VerificationFailed(gomock.Any(), gomock.Any()).Times(1).Return(dto.BankAccountDto{}, errors.ErrBankAccountNotFound)
             },
@@ -381,7 +408,7 @@ func TestBankAccountVerificationFailed(t *testing.T) {
             router := chi.NewRouter()
 
             bankAcctApi := api.NewBankAccountResource(mockBankAcctSvc)
-            bankAcctApi.RegisterRoutes(router)
+            bankAcctApi.RegisterAdminRoutes(router)
 
             request, err := http.NewRequest(http.MethodPatch, tc.url, nil)
             require.NoError(t, err)
@@ -0,0 +1,41 @@
+package api
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "net/http"
+)
+
+type BankDirectoryResource interface {
+    GetByIfsc(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type bankDirectoryResource struct {
+    bankDirectorySvc service.BankDirectorySvc
+}
+
+func NewBankDirectoryResource(bankDirectorySvc service.BankDirectorySvc) BankDirectoryResource {
+    return &bankDirectoryResource{
+        bankDirectorySvc: bankDirectorySvc,
+    }
+}
+
+func (b *bankDirectoryResource) RegisterRoutes(r chi.Router) {
+    r.Get("/banks/ifsc/{ifsc}", b.GetByIfsc)
+}
+
+func (b *bankDirectoryResource) GetByIfsc(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    ifsc := chi.URLParam(r, "ifsc")
+
+    res, err := b.bankDirectorySvc.GetBankBranch(ctx, ifsc)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
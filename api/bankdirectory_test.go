@@ -0,0 +1,86 @@
+package api_test
+
+import (
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestGetBankBranchByIfsc(t *testing.T) {
+    branchDto := dto.NewBankBranchDto(util.RandomBankBranch(util.RandomIfsc()))
+
+    testcases := []struct {
+        name      string
+        url       string
+        buildStub func(mockBankDirectorySvc *mocksvc.MockBankDirectorySvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            url:  fmt.Sprintf("/banks/ifsc/%s", branchDto.Ifsc),
+            buildStub: func(mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), branchDto.Ifsc).Times(1).Return(branchDto, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.BankBranchDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, branchDto, res)
+            },
+        },
+        {
+            name: "IfscNotFound",
+            url:  fmt.Sprintf("/banks/ifsc/%s", branchDto.Ifsc),
+            buildStub: func(mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), branchDto.Ifsc).Times(1).Return(dto.BankBranchDto{}, errors.ErrIfscNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name: "InternalServerError",
+            url:  fmt.Sprintf("/banks/ifsc/%s", branchDto.Ifsc),
+            buildStub: func(mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), branchDto.Ifsc).Times(1).Return(dto.BankBranchDto{}, sql.ErrConnDone)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusInternalServerError, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockBankDirectorySvc := mocksvc.NewMockBankDirectorySvc(ctrl)
+            tc.buildStub(mockBankDirectorySvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+
+            bankDirectoryApi := api.NewBankDirectoryResource(mockBankDirectorySvc)
+            bankDirectoryApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
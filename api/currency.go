@@ -4,9 +4,9 @@ import (
     "encoding/json"
     "github.com/go-chi/chi"
     "github.com/go-chi/render"
-    "github.com/go-playground/validator/v10"
     "github.com/pranayhere/simple-wallet/dto"
     types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
     "github.com/pranayhere/simple-wallet/service"
     "net/http"
 )
@@ -55,7 +55,7 @@ func (s *currencyResource) Create(w http.ResponseWriter, r *http.Request) {
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
@@ -0,0 +1,232 @@
+package api
+
+import (
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "net/http"
+    "strconv"
+)
+
+type EscrowResource interface {
+    Create(w http.ResponseWriter, r *http.Request)
+    Get(w http.ResponseWriter, r *http.Request)
+    Release(w http.ResponseWriter, r *http.Request)
+    Dispute(w http.ResponseWriter, r *http.Request)
+    Resolve(w http.ResponseWriter, r *http.Request)
+    List(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+    RegisterAdminRoutes(r chi.Router)
+}
+
+type escrowResource struct {
+    escrowSvc service.EscrowSvc
+}
+
+func NewEscrowResource(escrowSvc service.EscrowSvc) EscrowResource {
+    return &escrowResource{
+        escrowSvc: escrowSvc,
+    }
+}
+
+func (er *escrowResource) RegisterRoutes(r chi.Router) {
+    r.Post("/escrows", er.Create)
+    r.Get("/escrows/{escrowID}", er.Get)
+    r.Post("/escrows/{escrowID}/release", er.Release)
+    r.Post("/escrows/{escrowID}/dispute", er.Dispute)
+}
+
+// RegisterAdminRoutes registers the admin routes, the router must only let admins through.
+func (er *escrowResource) RegisterAdminRoutes(r chi.Router) {
+    r.Get("/admin/escrows", er.List)
+    r.Post("/admin/escrows/{escrowID}/resolve", er.Resolve)
+}
+
+func (er *escrowResource) Create(w http.ResponseWriter, r *http.Request) {
+    var req dto.CreateEscrowDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+    req.TOTPCode = r.Header.Get(constant.TOTPCodeHeaderKey)
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := er.escrowSvc.Create(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (er *escrowResource) Get(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "escrowID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := er.escrowSvc.Get(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (er *escrowResource) Release(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "escrowID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := er.escrowSvc.Release(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (er *escrowResource) Dispute(w http.ResponseWriter, r *http.Request) {
+    var req dto.DisputeEscrowDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "escrowID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.EscrowID = int64(id)
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := er.escrowSvc.Dispute(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (er *escrowResource) Resolve(w http.ResponseWriter, r *http.Request) {
+    var req dto.ResolveEscrowDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "escrowID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.EscrowID = int64(id)
+    req.AdminID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := er.escrowSvc.Resolve(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+// List defaults to the disputed escrows, the ones waiting for ops.
+func (er *escrowResource) List(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+
+    req := dto.ListEscrowsDto{
+        Status: domain.EscrowStatusDISPUTED,
+        Limit:  20,
+    }
+
+    query := r.URL.Query()
+    if v := query.Get("status"); v != "" {
+        req.Status = domain.EscrowStatus(v)
+    }
+
+    if v := query.Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := query.Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := er.escrowSvc.List(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
@@ -0,0 +1,209 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestEscrowApi(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    escrow := dto.EscrowDto{ID: 1, PayerWalletID: 1, PayeeWalletID: 2, Amount: 500, Status: domain.EscrowStatusFUNDED}
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        body      string
+        buildStub func(mockEscrowSvc *mocksvc.MockEscrowSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "Create",
+            method: http.MethodPost,
+            url:    "/escrows",
+            body:   `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "seller@my.wallet", "amount": 500, "release_after_seconds": 3600}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                arg := dto.CreateEscrowDto{UserID: userID, FromWalletAddress: "payer@my.wallet", ToWalletAddress: "seller@my.wallet", Amount: 500, ReleaseAfterSeconds: 3600}
+                mockEscrowSvc.EXPECT().Create(gomock.Any(), arg).Times(1).Return(dto.EscrowTransferResultDto{Escrow: escrow}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.EscrowTransferResultDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, escrow, res.Escrow)
+            },
+        },
+        {
+            name:   "CreateInvalidAmount",
+            method: http.MethodPost,
+            url:    "/escrows",
+            body:   `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "seller@my.wallet", "amount": -1}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CreateInsufficientBalance",
+            method: http.MethodPost,
+            url:    "/escrows",
+            body:   `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "seller@my.wallet", "amount": 500}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(dto.EscrowTransferResultDto{}, errors.ErrInsufficientBalance)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "GetNotFound",
+            method: http.MethodGet,
+            url:    "/escrows/1",
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Get(gomock.Any(), userID, int64(1)).Times(1).Return(dto.EscrowDto{}, errors.ErrEscrowNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "Release",
+            method: http.MethodPost,
+            url:    "/escrows/1/release",
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Release(gomock.Any(), userID, int64(1)).Times(1).Return(dto.EscrowTransferResultDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "ReleaseDisputed",
+            method: http.MethodPost,
+            url:    "/escrows/1/release",
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Release(gomock.Any(), userID, int64(1)).Times(1).Return(dto.EscrowTransferResultDto{}, errors.ErrEscrowNotFunded)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "Dispute",
+            method: http.MethodPost,
+            url:    "/escrows/1/dispute",
+            body:   `{"reason": "item not delivered"}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                arg := dto.DisputeEscrowDto{EscrowID: 1, UserID: userID, Reason: "item not delivered"}
+                mockEscrowSvc.EXPECT().Dispute(gomock.Any(), arg).Times(1).Return(dto.EscrowDto{ID: 1, Status: domain.EscrowStatusDISPUTED}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "DisputeWithoutReason",
+            method: http.MethodPost,
+            url:    "/escrows/1/dispute",
+            body:   `{}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Dispute(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "AdminResolve",
+            method: http.MethodPost,
+            url:    "/admin/escrows/1/resolve",
+            body:   `{"resolution": "REFUNDED"}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                arg := dto.ResolveEscrowDto{EscrowID: 1, AdminID: userID, Resolution: domain.EscrowStatusREFUNDED}
+                mockEscrowSvc.EXPECT().Resolve(gomock.Any(), arg).Times(1).Return(dto.EscrowTransferResultDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "AdminResolveInvalidResolution",
+            method: http.MethodPost,
+            url:    "/admin/escrows/1/resolve",
+            body:   `{"resolution": "DISPUTED"}`,
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().Resolve(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "AdminListDefaultsToDisputed",
+            method: http.MethodGet,
+            url:    "/admin/escrows",
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                arg := dto.ListEscrowsDto{Status: domain.EscrowStatusDISPUTED, Limit: 20}
+                mockEscrowSvc.EXPECT().List(gomock.Any(), arg).Times(1).Return([]dto.EscrowDto{escrow}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "AdminListInvalidStatus",
+            method: http.MethodGet,
+            url:    "/admin/escrows?status=PENDING",
+            buildStub: func(mockEscrowSvc *mocksvc.MockEscrowSvc) {
+                mockEscrowSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockEscrowSvc := mocksvc.NewMockEscrowSvc(ctrl)
+            tc.buildStub(mockEscrowSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            escrowApi := api.NewEscrowResource(mockEscrowSvc)
+            escrowApi.RegisterRoutes(router)
+            escrowApi.RegisterAdminRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/service"
+    "net/http"
+)
+
+type HealthResource interface {
+    Live(w http.ResponseWriter, r *http.Request)
+    Ready(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type healthResource struct {
+    healthSvc service.HealthSvc
+}
+
+func NewHealthResource(healthSvc service.HealthSvc) HealthResource {
+    return &healthResource{
+        healthSvc: healthSvc,
+    }
+}
+
+// RegisterRoutes registers the probes, they must stay out of the authentication and the rate limits.
+func (hr *healthResource) RegisterRoutes(r chi.Router) {
+    r.Get("/livez", hr.Live)
+    r.Get("/readyz", hr.Ready)
+}
+
+func (hr *healthResource) Live(w http.ResponseWriter, r *http.Request) {
+    render.JSON(w, r, hr.healthSvc.Live(r.Context()))
+}
+
+// Ready responds 503 with the failed checks when the service is not ready.
+func (hr *healthResource) Ready(w http.ResponseWriter, r *http.Request) {
+    res := hr.healthSvc.Ready(r.Context())
+    if !res.IsReady() {
+        render.Status(r, http.StatusServiceUnavailable)
+    }
+    render.JSON(w, r, res)
+}
@@ -0,0 +1,94 @@
+package api_test
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/dto"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestHealthApi(t *testing.T) {
+    ready := dto.ReadinessDto{
+        Status: dto.HealthStatusReady,
+        Checks: []dto.HealthCheckDto{{Name: "database", Status: dto.HealthStatusOK}},
+    }
+    notReady := dto.ReadinessDto{
+        Status: dto.HealthStatusNotReady,
+        Checks: []dto.HealthCheckDto{{Name: "shutdown", Status: dto.HealthStatusFailed, Error: "shutting down"}},
+    }
+
+    testcases := []struct {
+        name      string
+        url       string
+        buildStub func(mockHealthSvc *mocksvc.MockHealthSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Live",
+            url:  "/livez",
+            buildStub: func(mockHealthSvc *mocksvc.MockHealthSvc) {
+                mockHealthSvc.EXPECT().Live(gomock.Any()).Times(1).Return(dto.LivenessDto{Status: dto.HealthStatusOK})
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+            },
+        },
+        {
+            name: "Ready",
+            url:  "/readyz",
+            buildStub: func(mockHealthSvc *mocksvc.MockHealthSvc) {
+                mockHealthSvc.EXPECT().Ready(gomock.Any()).Times(1).Return(ready)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.ReadinessDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, ready, res)
+            },
+        },
+        {
+            name: "NotReady",
+            url:  "/readyz",
+            buildStub: func(mockHealthSvc *mocksvc.MockHealthSvc) {
+                mockHealthSvc.EXPECT().Ready(gomock.Any()).Times(1).Return(notReady)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+                var res dto.ReadinessDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, notReady, res)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockHealthSvc := mocksvc.NewMockHealthSvc(ctrl)
+            tc.buildStub(mockHealthSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+
+            healthApi := api.NewHealthResource(mockHealthSvc)
+            healthApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "io"
+    "net/http"
+    "strconv"
+)
+
+type HoldResource interface {
+    Authorize(w http.ResponseWriter, r *http.Request)
+    Capture(w http.ResponseWriter, r *http.Request)
+    Void(w http.ResponseWriter, r *http.Request)
+    Get(w http.ResponseWriter, r *http.Request)
+    List(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type holdResource struct {
+    holdSvc service.HoldSvc
+}
+
+func NewHoldResource(holdSvc service.HoldSvc) HoldResource {
+    return &holdResource{
+        holdSvc: holdSvc,
+    }
+}
+
+func (hr *holdResource) RegisterRoutes(r chi.Router) {
+    r.Post("/holds", hr.Authorize)
+    r.Get("/holds/{holdID}", hr.Get)
+    r.Post("/holds/{holdID}/capture", hr.Capture)
+    r.Post("/holds/{holdID}/void", hr.Void)
+    r.Get("/wallets/{walletID}/holds", hr.List)
+}
+
+func (hr *holdResource) Authorize(w http.ResponseWriter, r *http.Request) {
+    var req dto.AuthorizeHoldDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+    req.TOTPCode = r.Header.Get(constant.TOTPCodeHeaderKey)
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := hr.holdSvc.Authorize(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+// Capture takes an optional body, without an amount the whole hold is captured.
+func (hr *holdResource) Capture(w http.ResponseWriter, r *http.Request) {
+    var req dto.CaptureHoldDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "holdID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.HoldID = int64(id)
+    req.UserID = authPayload.UserID
+    req.TOTPCode = r.Header.Get(constant.TOTPCodeHeaderKey)
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := hr.holdSvc.Capture(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (hr *holdResource) Void(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "holdID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := hr.holdSvc.Void(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (hr *holdResource) Get(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "holdID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := hr.holdSvc.Get(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (hr *holdResource) List(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    walletID, err := strconv.Atoi(chi.URLParam(r, "walletID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    req := dto.ListHoldsDto{
+        WalletID: int64(walletID),
+        UserID:   authPayload.UserID,
+        Limit:    20,
+    }
+
+    query := r.URL.Query()
+    if v := query.Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := query.Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    res, err := hr.holdSvc.List(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
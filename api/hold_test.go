@@ -0,0 +1,194 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestHoldApi(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    hold := dto.HoldDto{ID: 1, WalletID: 1, ToWalletID: 2, Amount: 50, Status: domain.HoldStatusAUTHORIZED}
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        body      string
+        buildStub func(mockHoldSvc *mocksvc.MockHoldSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "Authorize",
+            method: http.MethodPost,
+            url:    "/holds",
+            body:   `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "merchant@my.wallet", "amount": 50, "ttl_seconds": 600}`,
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                arg := dto.AuthorizeHoldDto{UserID: userID, FromWalletAddress: "payer@my.wallet", ToWalletAddress: "merchant@my.wallet", Amount: 50, TTLSeconds: 600}
+                mockHoldSvc.EXPECT().Authorize(gomock.Any(), arg).Times(1).Return(hold, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.HoldDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, hold, res)
+            },
+        },
+        {
+            name:   "AuthorizeInvalidAmount",
+            method: http.MethodPost,
+            url:    "/holds",
+            body:   `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "merchant@my.wallet", "amount": 0}`,
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "AuthorizeInsufficientBalance",
+            method: http.MethodPost,
+            url:    "/holds",
+            body:   `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "merchant@my.wallet", "amount": 50}`,
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().Authorize(gomock.Any(), gomock.Any()).Times(1).Return(dto.HoldDto{}, errors.ErrInsufficientBalance)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "Capture",
+            method: http.MethodPost,
+            url:    "/holds/1/capture",
+            body:   `{"amount": 30}`,
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                arg := dto.CaptureHoldDto{HoldID: 1, UserID: userID, Amount: 30}
+                mockHoldSvc.EXPECT().Capture(gomock.Any(), arg).Times(1).Return(dto.CaptureHoldResultDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "CaptureWithoutBody",
+            method: http.MethodPost,
+            url:    "/holds/1/capture",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                arg := dto.CaptureHoldDto{HoldID: 1, UserID: userID}
+                mockHoldSvc.EXPECT().Capture(gomock.Any(), arg).Times(1).Return(dto.CaptureHoldResultDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "CaptureExpired",
+            method: http.MethodPost,
+            url:    "/holds/1/capture",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().Capture(gomock.Any(), gomock.Any()).Times(1).Return(dto.CaptureHoldResultDto{}, errors.ErrHoldExpired)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "Void",
+            method: http.MethodPost,
+            url:    "/holds/1/void",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().Void(gomock.Any(), userID, int64(1)).Times(1).Return(dto.HoldDto{ID: 1, Status: domain.HoldStatusVOIDED}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "VoidNotAuthorized",
+            method: http.MethodPost,
+            url:    "/holds/1/void",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().Void(gomock.Any(), userID, int64(1)).Times(1).Return(dto.HoldDto{}, errors.ErrHoldNotAuthorized)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "GetNotFound",
+            method: http.MethodGet,
+            url:    "/holds/1",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().Get(gomock.Any(), userID, int64(1)).Times(1).Return(dto.HoldDto{}, errors.ErrHoldNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "List",
+            method: http.MethodGet,
+            url:    "/wallets/1/holds?limit=5",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                arg := dto.ListHoldsDto{WalletID: 1, UserID: userID, Limit: 5}
+                mockHoldSvc.EXPECT().List(gomock.Any(), arg).Times(1).Return([]dto.HoldDto{hold}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "ListInvalidLimit",
+            method: http.MethodGet,
+            url:    "/wallets/1/holds?limit=1000",
+            buildStub: func(mockHoldSvc *mocksvc.MockHoldSvc) {
+                mockHoldSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockHoldSvc := mocksvc.NewMockHoldSvc(ctrl)
+            tc.buildStub(mockHoldSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            holdApi := api.NewHoldResource(mockHoldSvc)
+            holdApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
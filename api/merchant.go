@@ -0,0 +1,174 @@
+package api
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "net/http"
+    "strconv"
+)
+
+type MerchantResource interface {
+    Create(w http.ResponseWriter, r *http.Request)
+    Get(w http.ResponseWriter, r *http.Request)
+    Update(w http.ResponseWriter, r *http.Request)
+    CreateAPIKey(w http.ResponseWriter, r *http.Request)
+    ListAPIKeys(w http.ResponseWriter, r *http.Request)
+    RevokeAPIKey(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type merchantResource struct {
+    merchantSvc service.MerchantSvc
+}
+
+func NewMerchantResource(merchantSvc service.MerchantSvc) MerchantResource {
+    return &merchantResource{
+        merchantSvc: merchantSvc,
+    }
+}
+
+// RegisterRoutes registers the routes to manage the merchant profile and its api keys, no api key
+// can be scoped to them so they are only reachable with the token of the user.
+func (mr *merchantResource) RegisterRoutes(r chi.Router) {
+    r.Post("/merchants", mr.Create)
+    r.Get("/merchants/me", mr.Get)
+    r.Put("/merchants/me", mr.Update)
+    r.Post("/merchants/me/api-keys", mr.CreateAPIKey)
+    r.Get("/merchants/me/api-keys", mr.ListAPIKeys)
+    r.Delete("/merchants/me/api-keys/{keyID}", mr.RevokeAPIKey)
+}
+
+func (mr *merchantResource) Create(w http.ResponseWriter, r *http.Request) {
+    var req dto.CreateMerchantDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := mr.merchantSvc.Create(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (mr *merchantResource) Get(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    res, err := mr.merchantSvc.Get(ctx, authPayload.UserID)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (mr *merchantResource) Update(w http.ResponseWriter, r *http.Request) {
+    var req dto.UpdateMerchantDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := mr.merchantSvc.Update(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (mr *merchantResource) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+    var req dto.CreateAPIKeyDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := mr.merchantSvc.CreateAPIKey(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (mr *merchantResource) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    res, err := mr.merchantSvc.ListAPIKeys(ctx, authPayload.UserID)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (mr *merchantResource) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "keyID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := mr.merchantSvc.RevokeAPIKey(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
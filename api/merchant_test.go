@@ -0,0 +1,212 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestMerchantApi(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    merchant := dto.MerchantDto{ID: 1, UserID: userID, DisplayName: "Walter's Shop", Category: "RETAIL", SettlementWalletID: 2}
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        body      string
+        buildStub func(mockMerchantSvc *mocksvc.MockMerchantSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "Create",
+            method: http.MethodPost,
+            url:    "/merchants",
+            body:   `{"display_name": "Walter's Shop", "category": "RETAIL", "settlement_wallet_address": "shop@my.wallet"}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                arg := dto.CreateMerchantDto{UserID: userID, DisplayName: "Walter's Shop", Category: "RETAIL", SettlementWalletAddress: "shop@my.wallet"}
+                mockMerchantSvc.EXPECT().Create(gomock.Any(), arg).Times(1).Return(merchant, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.MerchantDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, merchant, res)
+            },
+        },
+        {
+            name:   "CreateInvalidCategory",
+            method: http.MethodPost,
+            url:    "/merchants",
+            body:   `{"display_name": "Walter's Shop", "category": "CASINO", "settlement_wallet_address": "shop@my.wallet"}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CreateInvalidCallbackUrl",
+            method: http.MethodPost,
+            url:    "/merchants",
+            body:   `{"display_name": "Walter's Shop", "category": "RETAIL", "settlement_wallet_address": "shop@my.wallet", "callback_url": "not a url"}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CreateAlreadyExist",
+            method: http.MethodPost,
+            url:    "/merchants",
+            body:   `{"display_name": "Walter's Shop", "category": "RETAIL", "settlement_wallet_address": "shop@my.wallet"}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(dto.MerchantDto{}, errors.ErrMerchantAlreadyExist)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "GetNotFound",
+            method: http.MethodGet,
+            url:    "/merchants/me",
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Get(gomock.Any(), userID).Times(1).Return(dto.MerchantDto{}, errors.ErrMerchantNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "Update",
+            method: http.MethodPut,
+            url:    "/merchants/me",
+            body:   `{"display_name": "Walter's Bakery", "category": "FOOD", "settlement_wallet_address": "shop@my.wallet", "callback_url": "https://bakery.example.com/cb"}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                arg := dto.UpdateMerchantDto{UserID: userID, DisplayName: "Walter's Bakery", Category: "FOOD", SettlementWalletAddress: "shop@my.wallet", CallbackUrl: "https://bakery.example.com/cb"}
+                mockMerchantSvc.EXPECT().Update(gomock.Any(), arg).Times(1).Return(merchant, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "CreateAPIKey",
+            method: http.MethodPost,
+            url:    "/merchants/me/api-keys",
+            body:   `{"name": "backend", "scopes": ["transfers:write", "wallets:read"]}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                arg := dto.CreateAPIKeyDto{UserID: userID, Name: "backend", Scopes: []string{"transfers:write", "wallets:read"}}
+                mockMerchantSvc.EXPECT().CreateAPIKey(gomock.Any(), arg).Times(1).Return(dto.APIKeyDto{ID: 1, Key: "sk_key", Status: domain.APIKeyStatusACTIVE}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.APIKeyDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, "sk_key", res.Key)
+            },
+        },
+        {
+            name:   "CreateAPIKeyInvalidScope",
+            method: http.MethodPost,
+            url:    "/merchants/me/api-keys",
+            body:   `{"name": "backend", "scopes": ["merchants:write"]}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CreateAPIKeyWithoutScopes",
+            method: http.MethodPost,
+            url:    "/merchants/me/api-keys",
+            body:   `{"name": "backend", "scopes": []}`,
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "ListAPIKeys",
+            method: http.MethodGet,
+            url:    "/merchants/me/api-keys",
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().ListAPIKeys(gomock.Any(), userID).Times(1).Return([]dto.APIKeyDto{{ID: 1}}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "RevokeAPIKey",
+            method: http.MethodDelete,
+            url:    "/merchants/me/api-keys/1",
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().RevokeAPIKey(gomock.Any(), userID, int64(1)).Times(1).Return(dto.APIKeyDto{ID: 1, Status: domain.APIKeyStatusREVOKED}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "RevokeAPIKeyNotFound",
+            method: http.MethodDelete,
+            url:    "/merchants/me/api-keys/1",
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().RevokeAPIKey(gomock.Any(), userID, int64(1)).Times(1).Return(dto.APIKeyDto{}, errors.ErrAPIKeyNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockMerchantSvc := mocksvc.NewMockMerchantSvc(ctrl)
+            tc.buildStub(mockMerchantSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            merchantApi := api.NewMerchantResource(mockMerchantSvc)
+            merchantApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "net/http"
+    "strconv"
+)
+
+type NotificationResource interface {
+    List(w http.ResponseWriter, r *http.Request)
+    MarkRead(w http.ResponseWriter, r *http.Request)
+    MarkUnread(w http.ResponseWriter, r *http.Request)
+    GetPreferences(w http.ResponseWriter, r *http.Request)
+    UpdatePreference(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type notificationResource struct {
+    notificationSvc service.NotificationSvc
+}
+
+func NewNotificationResource(notificationSvc service.NotificationSvc) NotificationResource {
+    return &notificationResource{
+        notificationSvc: notificationSvc,
+    }
+}
+
+func (n *notificationResource) RegisterRoutes(r chi.Router) {
+    r.Get("/notifications", n.List)
+    r.Patch("/notifications/{notificationID}/read", n.MarkRead)
+    r.Patch("/notifications/{notificationID}/unread", n.MarkUnread)
+    r.Get("/notifications/preferences", n.GetPreferences)
+    r.Put("/notifications/preferences", n.UpdatePreference)
+}
+
+func (n *notificationResource) List(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    req := dto.ListNotificationsDto{
+        UserID: authPayload.UserID,
+        Limit:  20,
+    }
+
+    query := r.URL.Query()
+    if v := query.Get("unread"); v != "" {
+        unread, err := strconv.ParseBool(v)
+        if err != nil {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid unread")))
+            return
+        }
+        req.UnreadOnly = unread
+    }
+
+    if v := query.Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := query.Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    res, err := n.notificationSvc.List(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (n *notificationResource) MarkRead(w http.ResponseWriter, r *http.Request) {
+    n.markRead(w, r, true)
+}
+
+func (n *notificationResource) MarkUnread(w http.ResponseWriter, r *http.Request) {
+    n.markRead(w, r, false)
+}
+
+func (n *notificationResource) markRead(w http.ResponseWriter, r *http.Request, read bool) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "notificationID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := n.notificationSvc.MarkRead(ctx, authPayload.UserID, int64(id), read)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (n *notificationResource) GetPreferences(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    res, err := n.notificationSvc.GetPreferences(ctx, authPayload.UserID)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (n *notificationResource) UpdatePreference(w http.ResponseWriter, r *http.Request) {
+    var req dto.NotificationPreferenceDto
+    ctx := r.Context()
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := n.notificationSvc.UpdatePreference(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
@@ -0,0 +1,259 @@
+package api_test
+
+import (
+    "bytes"
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestListNotifications(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+
+    testcases := []struct {
+        name      string
+        url       string
+        buildStub func(mockNotificationSvc *mocksvc.MockNotificationSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            url:  "/notifications?unread=true&limit=5&offset=10",
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                arg := dto.ListNotificationsDto{UserID: userID, UnreadOnly: true, Limit: 5, Offset: 10}
+                mockNotificationSvc.EXPECT().List(gomock.Any(), arg).Times(1).Return([]dto.NotificationDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "DefaultLimit",
+            url:  "/notifications",
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                arg := dto.ListNotificationsDto{UserID: userID, Limit: 20}
+                mockNotificationSvc.EXPECT().List(gomock.Any(), arg).Times(1).Return([]dto.NotificationDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidUnread",
+            url:  "/notifications?unread=maybe",
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidLimit",
+            url:  "/notifications?limit=1000",
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "InternalServerError",
+            url:  "/notifications",
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return([]dto.NotificationDto{}, sql.ErrConnDone)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusInternalServerError, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockNotificationSvc := mocksvc.NewMockNotificationSvc(ctrl)
+            tc.buildStub(mockNotificationSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            notificationApi := api.NewNotificationResource(mockNotificationSvc)
+            notificationApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    notificationID := util.RandomInt(1, 1000)
+
+    testcases := []struct {
+        name      string
+        url       string
+        buildStub func(mockNotificationSvc *mocksvc.MockNotificationSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Read",
+            url:  fmt.Sprintf("/notifications/%d/read", notificationID),
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().MarkRead(gomock.Any(), userID, notificationID, true).Times(1).Return(dto.NotificationDto{ID: notificationID, Read: true}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.NotificationDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.True(t, res.Read)
+            },
+        },
+        {
+            name: "Unread",
+            url:  fmt.Sprintf("/notifications/%d/unread", notificationID),
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().MarkRead(gomock.Any(), userID, notificationID, false).Times(1).Return(dto.NotificationDto{ID: notificationID}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "NotFound",
+            url:  fmt.Sprintf("/notifications/%d/read", notificationID),
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().MarkRead(gomock.Any(), userID, notificationID, true).Times(1).Return(dto.NotificationDto{}, errors.ErrNotificationNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidID",
+            url:  "/notifications/abc/read",
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().MarkRead(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockNotificationSvc := mocksvc.NewMockNotificationSvc(ctrl)
+            tc.buildStub(mockNotificationSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            notificationApi := api.NewNotificationResource(mockNotificationSvc)
+            notificationApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodPatch, tc.url, nil)
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestUpdateNotificationPreference(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+
+    testcases := []struct {
+        name      string
+        body      map[string]interface{}
+        buildStub func(mockNotificationSvc *mocksvc.MockNotificationSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            body: map[string]interface{}{
+                "channel": "EMAIL",
+                "enabled": false,
+            },
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                arg := dto.NotificationPreferenceDto{Channel: domain.NotificationChannelEMAIL, Enabled: false, UserID: userID}
+                mockNotificationSvc.EXPECT().UpdatePreference(gomock.Any(), arg).Times(1).Return(arg, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidChannel",
+            body: map[string]interface{}{
+                "channel": "SMS",
+                "enabled": true,
+            },
+            buildStub: func(mockNotificationSvc *mocksvc.MockNotificationSvc) {
+                mockNotificationSvc.EXPECT().UpdatePreference(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockNotificationSvc := mocksvc.NewMockNotificationSvc(ctrl)
+            tc.buildStub(mockNotificationSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            notificationApi := api.NewNotificationResource(mockNotificationSvc)
+            notificationApi.RegisterRoutes(router)
+
+            data, err := json.Marshal(tc.body)
+            require.NoError(t, err)
+
+            request, err := http.NewRequest(http.MethodPut, "/notifications/preferences", bytes.NewReader(data))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/pranayhere/simple-wallet/pkg/openapi"
+    "net/http"
+)
+
+type OpenAPIResource interface {
+    Document(w http.ResponseWriter, r *http.Request)
+    Docs(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type openAPIResource struct {
+    document []byte
+}
+
+// NewOpenAPIResource serves the document of spec, written once.
+func NewOpenAPIResource(spec *openapi.Spec) OpenAPIResource {
+    document, err := json.Marshal(spec.Document())
+    if err != nil {
+        panic(err)
+    }
+
+    return &openAPIResource{
+        document: document,
+    }
+}
+
+func (o *openAPIResource) RegisterRoutes(r chi.Router) {
+    r.Get("/openapi.json", o.Document)
+    r.Get("/docs", o.Docs)
+}
+
+func (o *openAPIResource) Document(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "application/json")
+    _, _ = w.Write(o.document)
+}
+
+// docsPage renders /openapi.json with Swagger UI.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Simple Wallet API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+func (o *openAPIResource) Docs(w http.ResponseWriter, r *http.Request) {
+    w.Header().Set("Content-Type", "text/html; charset=utf-8")
+    _, _ = w.Write([]byte(docsPage))
+}
@@ -0,0 +1,51 @@
+package api_test
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/pkg/openapi"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "regexp"
+    "testing"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+    router := chi.NewRouter()
+    api.NewOpenAPIResource(api.Spec()).RegisterRoutes(router)
+
+    recorder := httptest.NewRecorder()
+    router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+    require.Equal(t, http.StatusOK, recorder.Code)
+    require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+    var doc openapi.Document
+    require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &doc))
+    require.Equal(t, openapi.Version, doc.OpenAPI)
+
+    pay := doc.Paths["/v1/wallets/pay"]["post"]
+    require.NotNil(t, pay)
+    require.False(t, pay.Deprecated)
+    require.True(t, doc.Paths["/wallets/pay"]["post"].Deprecated)
+    require.Equal(t, "#/components/schemas/TransferMoneyDto", pay.RequestBody.Content["application/json"].Schema.Ref)
+    require.ElementsMatch(t, []string{"from_wallet_address", "to_wallet_address", "amount"}, doc.Components.Schemas["TransferMoneyDto"].Required)
+    require.Contains(t, pay.Responses, "401")
+    require.Contains(t, pay.Responses, "429")
+    require.NotContains(t, doc.Paths["/livez"]["get"].Responses, "429")
+
+    // the responses are dtos, not the domain types
+    require.Equal(t, "#/components/schemas/TransferDto", doc.Components.Schemas["WalletTransferResultDto"].Properties["transfer"].Ref)
+    require.NotContains(t, doc.Components.Schemas, "Transfer")
+
+    // every $ref resolves
+    for _, m := range regexp.MustCompile(`"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(recorder.Body.String(), -1) {
+        require.Contains(t, doc.Components.Schemas, m[1])
+    }
+
+    recorder = httptest.NewRecorder()
+    router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+    require.Equal(t, http.StatusOK, recorder.Code)
+    require.Contains(t, recorder.Body.String(), "/openapi.json")
+}
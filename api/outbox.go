@@ -0,0 +1,83 @@
+package api
+
+import (
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "net/http"
+    "strconv"
+)
+
+type OutboxResource interface {
+    ListDead(w http.ResponseWriter, r *http.Request)
+    RetryDead(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type outboxResource struct {
+    outboxRelaySvc service.OutboxRelaySvc
+}
+
+func NewOutboxResource(outboxRelaySvc service.OutboxRelaySvc) OutboxResource {
+    return &outboxResource{
+        outboxRelaySvc: outboxRelaySvc,
+    }
+}
+
+// RegisterRoutes registers the admin routes, the router must only let admins through.
+func (o *outboxResource) RegisterRoutes(r chi.Router) {
+    r.Get("/admin/outbox/dead", o.ListDead)
+    r.Post("/admin/outbox/{eventID}/retry", o.RetryDead)
+}
+
+func (o *outboxResource) ListDead(w http.ResponseWriter, r *http.Request) {
+    req := dto.ListDeadOutboxEventsDto{
+        Limit: 20,
+    }
+
+    query := r.URL.Query()
+    if v := query.Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := query.Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    res, err := o.outboxRelaySvc.ListDead(r.Context(), req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (o *outboxResource) RetryDead(w http.ResponseWriter, r *http.Request) {
+    id, err := strconv.Atoi(chi.URLParam(r, "eventID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := o.outboxRelaySvc.RetryDead(r.Context(), int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
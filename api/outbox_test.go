@@ -0,0 +1,122 @@
+package api_test
+
+import (
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestOutboxApi(t *testing.T) {
+    event := dto.OutboxEventDto{
+        ID:            1,
+        AggregateType: domain.AggregateTypeWALLET,
+        AggregateID:   7,
+        EventType:     domain.EventTypeTransferCreated,
+        Status:        domain.OutboxStatusDEAD,
+        Attempts:      10,
+        LastError:     sql.ErrConnDone.Error(),
+    }
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        buildStub func(mockOutboxRelaySvc *mocksvc.MockOutboxRelaySvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "ListDead",
+            method: http.MethodGet,
+            url:    "/admin/outbox/dead?limit=5&offset=5",
+            buildStub: func(mockOutboxRelaySvc *mocksvc.MockOutboxRelaySvc) {
+                arg := dto.ListDeadOutboxEventsDto{Limit: 5, Offset: 5}
+                mockOutboxRelaySvc.EXPECT().ListDead(gomock.Any(), arg).Times(1).Return([]dto.OutboxEventDto{event}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res []dto.OutboxEventDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, []dto.OutboxEventDto{event}, res)
+            },
+        },
+        {
+            name:   "ListDeadInvalidOffset",
+            method: http.MethodGet,
+            url:    "/admin/outbox/dead?offset=-1",
+            buildStub: func(mockOutboxRelaySvc *mocksvc.MockOutboxRelaySvc) {
+                mockOutboxRelaySvc.EXPECT().ListDead(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "RetryDead",
+            method: http.MethodPost,
+            url:    fmt.Sprintf("/admin/outbox/%d/retry", event.ID),
+            buildStub: func(mockOutboxRelaySvc *mocksvc.MockOutboxRelaySvc) {
+                retried := event
+                retried.Status = domain.OutboxStatusPENDING
+                mockOutboxRelaySvc.EXPECT().RetryDead(gomock.Any(), event.ID).Times(1).Return(retried, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "RetryDeadNotFound",
+            method: http.MethodPost,
+            url:    "/admin/outbox/99/retry",
+            buildStub: func(mockOutboxRelaySvc *mocksvc.MockOutboxRelaySvc) {
+                mockOutboxRelaySvc.EXPECT().RetryDead(gomock.Any(), int64(99)).Times(1).Return(dto.OutboxEventDto{}, errors.ErrDeadOutboxEventNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "RetryDeadInvalidID",
+            method: http.MethodPost,
+            url:    "/admin/outbox/abc/retry",
+            buildStub: func(mockOutboxRelaySvc *mocksvc.MockOutboxRelaySvc) {
+                mockOutboxRelaySvc.EXPECT().RetryDead(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockOutboxRelaySvc := mocksvc.NewMockOutboxRelaySvc(ctrl)
+            tc.buildStub(mockOutboxRelaySvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+            outboxApi := api.NewOutboxResource(mockOutboxRelaySvc)
+            outboxApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, nil)
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    log "github.com/sirupsen/logrus"
+    "net/http"
+)
+
+type PasswordResource interface {
+    Change(w http.ResponseWriter, r *http.Request)
+    Forgot(w http.ResponseWriter, r *http.Request)
+    Reset(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+    RegisterPublicRoutes(r chi.Router)
+}
+
+type passwordResource struct {
+    passwordSvc service.PasswordSvc
+}
+
+func NewPasswordResource(passwordSvc service.PasswordSvc) PasswordResource {
+    return &passwordResource{
+        passwordSvc: passwordSvc,
+    }
+}
+
+// RegisterRoutes registers the route to change the password, no api key can be scoped to it so it is only
+// reachable with the token of the user.
+func (p *passwordResource) RegisterRoutes(r chi.Router) {
+    r.Post("/users/me/password", p.Change)
+}
+
+// RegisterPublicRoutes registers the routes to reset a forgotten password, served without authentication.
+func (p *passwordResource) RegisterPublicRoutes(r chi.Router) {
+    r.Post("/users/password/forgot", p.Forgot)
+    r.Post("/users/password/reset", p.Reset)
+}
+
+func (p *passwordResource) Change(w http.ResponseWriter, r *http.Request) {
+    var req dto.ChangePasswordDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    loggedInUser, err := p.passwordSvc.ChangePassword(ctx, req)
+    if err != nil {
+        logging.FromContext(ctx).Warn("password change failed: ", err)
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    logging.FromContext(ctx).Info("password changed")
+
+    render.JSON(w, r, loggedInUser)
+}
+
+// Forgot answers 202 whether or not the email is the one of a user.
+func (p *passwordResource) Forgot(w http.ResponseWriter, r *http.Request) {
+    var req dto.ForgotPasswordDto
+    ctx := r.Context()
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    err := p.passwordSvc.ForgotPassword(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    w.WriteHeader(http.StatusAccepted)
+}
+
+func (p *passwordResource) Reset(w http.ResponseWriter, r *http.Request) {
+    var req dto.ResetPasswordDto
+    ctx := r.Context()
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    user, err := p.passwordSvc.ResetPassword(ctx, req)
+    if err != nil {
+        logging.FromContext(ctx).Warn("password reset failed: ", err)
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    logging.AddFields(ctx, log.Fields{"user_id": user.ID})
+    logging.FromContext(ctx).Info("password reset")
+
+    render.JSON(w, r, user)
+}
@@ -0,0 +1,182 @@
+package api_test
+
+import (
+    "bytes"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestChangePassword(t *testing.T) {
+    var userID int64 = 1
+
+    testcases := []struct {
+        name      string
+        body      string
+        buildStub func(mockPasswordSvc *mocksvc.MockPasswordSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            body: `{"current_password": "secret", "new_password": "new-secret"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                changePasswordDto := dto.ChangePasswordDto{UserID: userID, CurrentPassword: "secret", NewPassword: "new-secret"}
+                mockPasswordSvc.EXPECT().ChangePassword(gomock.Any(), changePasswordDto).Times(1).Return(dto.LoggedInUserDto{AccessToken: "token"}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "IncorrectPassword",
+            body: `{"current_password": "wrong", "new_password": "new-secret"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Times(1).Return(dto.LoggedInUserDto{}, errors.ErrIncorrectPassword)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "SamePassword",
+            body: `{"current_password": "secret", "new_password": "secret"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "TooShortPassword",
+            body: `{"current_password": "secret", "new_password": "123"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockPasswordSvc := mocksvc.NewMockPasswordSvc(ctrl)
+            tc.buildStub(mockPasswordSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+            api.NewPasswordResource(mockPasswordSvc).RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodPost, "/users/me/password", bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestForgotAndResetPassword(t *testing.T) {
+    testcases := []struct {
+        name      string
+        url       string
+        body      string
+        buildStub func(mockPasswordSvc *mocksvc.MockPasswordSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Forgot",
+            url:  "/users/password/forgot",
+            body: `{"email": "walter@example.com"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ForgotPassword(gomock.Any(), dto.ForgotPasswordDto{Email: "walter@example.com"}).Times(1).Return(nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusAccepted, recorder.Code)
+                require.Empty(t, recorder.Body.String())
+            },
+        },
+        {
+            name: "ForgotInvalidEmail",
+            url:  "/users/password/forgot",
+            body: `{"email": "walter"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ForgotPassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "Reset",
+            url:  "/users/password/reset",
+            body: `{"token": "reset-token", "new_password": "new-secret"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                resetPasswordDto := dto.ResetPasswordDto{Token: "reset-token", NewPassword: "new-secret"}
+                mockPasswordSvc.EXPECT().ResetPassword(gomock.Any(), resetPasswordDto).Times(1).Return(dto.UserDto{ID: 1}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "ResetInvalidToken",
+            url:  "/users/password/reset",
+            body: `{"token": "used-token", "new_password": "new-secret"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(1).Return(dto.UserDto{}, errors.ErrInvalidPasswordResetToken)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "ResetMissingToken",
+            url:  "/users/password/reset",
+            body: `{"new_password": "new-secret"}`,
+            buildStub: func(mockPasswordSvc *mocksvc.MockPasswordSvc) {
+                mockPasswordSvc.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockPasswordSvc := mocksvc.NewMockPasswordSvc(ctrl)
+            tc.buildStub(mockPasswordSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+            api.NewPasswordResource(mockPasswordSvc).RegisterPublicRoutes(router)
+
+            request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
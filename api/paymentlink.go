@@ -0,0 +1,211 @@
+package api
+
+import (
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "io"
+    "net/http"
+    "strconv"
+)
+
+type PaymentLinkResource interface {
+    Create(w http.ResponseWriter, r *http.Request)
+    List(w http.ResponseWriter, r *http.Request)
+    Disable(w http.ResponseWriter, r *http.Request)
+    GetPage(w http.ResponseWriter, r *http.Request)
+    StartCheckout(w http.ResponseWriter, r *http.Request)
+    CompleteCheckout(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+    RegisterPublicRoutes(r chi.Router)
+}
+
+type paymentLinkResource struct {
+    paymentLinkSvc service.PaymentLinkSvc
+}
+
+func NewPaymentLinkResource(paymentLinkSvc service.PaymentLinkSvc) PaymentLinkResource {
+    return &paymentLinkResource{
+        paymentLinkSvc: paymentLinkSvc,
+    }
+}
+
+func (pl *paymentLinkResource) RegisterRoutes(r chi.Router) {
+    r.Post("/payment-links", pl.Create)
+    r.Get("/payment-links", pl.List)
+    r.Delete("/payment-links/{linkID}", pl.Disable)
+    r.Post("/pay/{slug}/checkout", pl.StartCheckout)
+    r.Post("/checkout-sessions/{sessionID}/complete", pl.CompleteCheckout)
+}
+
+// RegisterPublicRoutes registers the routes open to anyone having the link.
+func (pl *paymentLinkResource) RegisterPublicRoutes(r chi.Router) {
+    r.Get("/pay/{slug}", pl.GetPage)
+}
+
+func (pl *paymentLinkResource) Create(w http.ResponseWriter, r *http.Request) {
+    var req dto.CreatePaymentLinkDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := pl.paymentLinkSvc.Create(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (pl *paymentLinkResource) List(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    req := dto.ListPaymentLinksDto{
+        UserID: authPayload.UserID,
+        Limit:  20,
+    }
+
+    if v := r.URL.Query().Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := r.URL.Query().Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    res, err := pl.paymentLinkSvc.List(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (pl *paymentLinkResource) Disable(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "linkID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := pl.paymentLinkSvc.Disable(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (pl *paymentLinkResource) GetPage(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+
+    res, err := pl.paymentLinkSvc.GetPage(ctx, chi.URLParam(r, "slug"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+// StartCheckout takes an optional body, the amount is only needed for the links with an open amount.
+func (pl *paymentLinkResource) StartCheckout(w http.ResponseWriter, r *http.Request) {
+    var req dto.StartCheckoutDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.Slug = chi.URLParam(r, "slug")
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := pl.paymentLinkSvc.StartCheckout(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (pl *paymentLinkResource) CompleteCheckout(w http.ResponseWriter, r *http.Request) {
+    var req dto.CompleteCheckoutDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "sessionID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.SessionID = int64(id)
+    req.UserID = authPayload.UserID
+    req.TOTPCode = r.Header.Get(constant.TOTPCodeHeaderKey)
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := pl.paymentLinkSvc.CompleteCheckout(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
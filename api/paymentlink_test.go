@@ -0,0 +1,275 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestPaymentLinkApi(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    link := dto.PaymentLinkDto{ID: 1, MerchantID: 2, Slug: "0a1b2c3d4e5f", Amount: 500, Currency: "INR", Mode: domain.PaymentLinkModeSINGLEUSE, Status: domain.PaymentLinkStatusACTIVE}
+    session := dto.CheckoutSessionDto{ID: 7, PaymentLinkID: link.ID, ToWalletID: 3, Amount: 500, Status: domain.CheckoutSessionStatusOPEN}
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        body      string
+        buildStub func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "Create",
+            method: http.MethodPost,
+            url:    "/payment-links",
+            body:   `{"amount": 500, "currency": "INR", "mode": "SINGLE_USE"}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                arg := dto.CreatePaymentLinkDto{UserID: userID, Amount: 500, Currency: "INR", Mode: domain.PaymentLinkModeSINGLEUSE}
+                mockPaymentLinkSvc.EXPECT().Create(gomock.Any(), arg).Times(1).Return(link, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.PaymentLinkDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, link, res)
+            },
+        },
+        {
+            name:   "CreateInvalidMode",
+            method: http.MethodPost,
+            url:    "/payment-links",
+            body:   `{"amount": 500, "currency": "INR", "mode": "FOREVER"}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CreateNotMerchant",
+            method: http.MethodPost,
+            url:    "/payment-links",
+            body:   `{"currency": "INR", "mode": "MULTI_USE"}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(dto.PaymentLinkDto{}, errors.ErrMerchantNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "List",
+            method: http.MethodGet,
+            url:    "/payment-links?limit=5&offset=10",
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                arg := dto.ListPaymentLinksDto{UserID: userID, Limit: 5, Offset: 10}
+                mockPaymentLinkSvc.EXPECT().List(gomock.Any(), arg).Times(1).Return([]dto.PaymentLinkDto{link}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "ListInvalidLimit",
+            method: http.MethodGet,
+            url:    "/payment-links?limit=500",
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "Disable",
+            method: http.MethodDelete,
+            url:    "/payment-links/1",
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                disabled := link
+                disabled.Status = domain.PaymentLinkStatusDISABLED
+                mockPaymentLinkSvc.EXPECT().Disable(gomock.Any(), userID, link.ID).Times(1).Return(disabled, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "StartCheckoutWithoutBody",
+            method: http.MethodPost,
+            url:    "/pay/" + link.Slug + "/checkout",
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                arg := dto.StartCheckoutDto{Slug: link.Slug, UserID: userID}
+                mockPaymentLinkSvc.EXPECT().StartCheckout(gomock.Any(), arg).Times(1).Return(session, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+            },
+        },
+        {
+            name:   "StartCheckoutInactive",
+            method: http.MethodPost,
+            url:    "/pay/" + link.Slug + "/checkout",
+            body:   `{"amount": 500}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().StartCheckout(gomock.Any(), gomock.Any()).Times(1).Return(dto.CheckoutSessionDto{}, errors.ErrPaymentLinkInactive)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "StartCheckoutAmountMismatch",
+            method: http.MethodPost,
+            url:    "/pay/" + link.Slug + "/checkout",
+            body:   `{"amount": 400}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().StartCheckout(gomock.Any(), gomock.Any()).Times(1).Return(dto.CheckoutSessionDto{}, errors.ErrCheckoutAmountMismatch)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CompleteCheckout",
+            method: http.MethodPost,
+            url:    "/checkout-sessions/7/complete",
+            body:   `{"from_wallet_address": "payer@my.wallet"}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                arg := dto.CompleteCheckoutDto{SessionID: session.ID, UserID: userID, FromWalletAddress: "payer@my.wallet"}
+                mockPaymentLinkSvc.EXPECT().CompleteCheckout(gomock.Any(), arg).Times(1).
+                    Return(dto.CheckoutResultDto{Session: session, RedirectUrl: "https://shop.example.com/thanks?checkout_session_id=7"}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.CheckoutResultDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, "https://shop.example.com/thanks?checkout_session_id=7", res.RedirectUrl)
+            },
+        },
+        {
+            name:   "CompleteCheckoutMissingWallet",
+            method: http.MethodPost,
+            url:    "/checkout-sessions/7/complete",
+            body:   `{}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().CompleteCheckout(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "CompleteCheckoutExpired",
+            method: http.MethodPost,
+            url:    "/checkout-sessions/7/complete",
+            body:   `{"from_wallet_address": "payer@my.wallet"}`,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().CompleteCheckout(gomock.Any(), gomock.Any()).Times(1).Return(dto.CheckoutResultDto{}, errors.ErrCheckoutSessionExpired)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockPaymentLinkSvc := mocksvc.NewMockPaymentLinkSvc(ctrl)
+            tc.buildStub(mockPaymentLinkSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            paymentLinkApi := api.NewPaymentLinkResource(mockPaymentLinkSvc)
+            paymentLinkApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestPaymentLinkPublicApi(t *testing.T) {
+    page := dto.PaymentLinkPageDto{Slug: "0a1b2c3d4e5f", MerchantName: "Walter's Shop", Amount: 500, Currency: "INR", Status: domain.PaymentLinkStatusACTIVE}
+
+    testcases := []struct {
+        name      string
+        slug      string
+        buildStub func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            slug: page.Slug,
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().GetPage(gomock.Any(), page.Slug).Times(1).Return(page, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.PaymentLinkPageDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, page, res)
+            },
+        },
+        {
+            name: "NotFound",
+            slug: "missing",
+            buildStub: func(mockPaymentLinkSvc *mocksvc.MockPaymentLinkSvc) {
+                mockPaymentLinkSvc.EXPECT().GetPage(gomock.Any(), "missing").Times(1).Return(dto.PaymentLinkPageDto{}, errors.ErrPaymentLinkNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockPaymentLinkSvc := mocksvc.NewMockPaymentLinkSvc(ctrl)
+            tc.buildStub(mockPaymentLinkSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+
+            paymentLinkApi := api.NewPaymentLinkResource(mockPaymentLinkSvc)
+            paymentLinkApi.RegisterPublicRoutes(router)
+
+            request, err := http.NewRequest(http.MethodGet, "/pay/"+tc.slug, nil)
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
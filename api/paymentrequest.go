@@ -4,9 +4,10 @@ import (
     "encoding/json"
     "github.com/go-chi/chi"
     "github.com/go-chi/render"
-    "github.com/go-playground/validator/v10"
     "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
     types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
     "github.com/pranayhere/simple-wallet/service"
     "net/http"
     "strconv"
@@ -45,7 +46,7 @@ func (p *paymentRequestResource) Charge(w http.ResponseWriter, r *http.Request)
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
@@ -69,7 +70,10 @@ func (p *paymentRequestResource) Approve(w http.ResponseWriter, r *http.Request)
         return
     }
 
-    res, err := p.payReqSvc.Approve(ctx, int64(id))
+    res, err := p.payReqSvc.Approve(ctx, dto.ApprovePaymentRequestDto{
+        ID:       int64(id),
+        TOTPCode: r.Header.Get(constant.TOTPCodeHeaderKey),
+    })
     if err != nil {
         _ = render.Render(w, r, types.ErrResponse(err))
         return
@@ -107,7 +111,7 @@ func (p *paymentRequestResource) List(w http.ResponseWriter, r *http.Request) {
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
@@ -0,0 +1,157 @@
+package api
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "io"
+    "net/http"
+    "strconv"
+)
+
+type QRResource interface {
+    WalletQR(w http.ResponseWriter, r *http.Request)
+    PaymentRequestQR(w http.ResponseWriter, r *http.Request)
+    Parse(w http.ResponseWriter, r *http.Request)
+    Pay(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type qrResource struct {
+    qrSvc service.QRSvc
+}
+
+func NewQRResource(qrSvc service.QRSvc) QRResource {
+    return &qrResource{
+        qrSvc: qrSvc,
+    }
+}
+
+func (q *qrResource) RegisterRoutes(r chi.Router) {
+    r.Get("/wallets/{walletID}/qr", q.WalletQR)
+    r.Get("/payment-req/{payReqID}/qr", q.PaymentRequestQR)
+    r.Post("/qr/parse", q.Parse)
+    r.Post("/qr/pay", q.Pay)
+}
+
+var qrContentTypes = map[string]string{
+    "png": "image/png",
+    "svg": "image/svg+xml",
+}
+
+func (q *qrResource) WalletQR(w http.ResponseWriter, r *http.Request) {
+    q.writeQR(w, r, "walletID", q.qrSvc.WalletQR)
+}
+
+func (q *qrResource) PaymentRequestQR(w http.ResponseWriter, r *http.Request) {
+    q.writeQR(w, r, "payReqID", q.qrSvc.PaymentRequestQR)
+}
+
+// writeQR reads the id from param, and the optional format and scale from the query.
+func (q *qrResource) writeQR(w http.ResponseWriter, r *http.Request, param string, export func(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, param))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    req := dto.GetQRDto{
+        ID:     int64(id),
+        UserID: authPayload.UserID,
+        Format: constant.QRDefaultFormat,
+        Scale:  constant.QRDefaultScale,
+    }
+
+    query := r.URL.Query()
+    if v := query.Get("format"); v != "" {
+        req.Format = v
+    }
+    if v := query.Get("scale"); v != "" {
+        scale, err := strconv.Atoi(v)
+        if err != nil {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid scale")))
+            return
+        }
+        req.Scale = scale
+    }
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    // the image is built before anything is written, so that an error still gets its status code
+    var buf bytes.Buffer
+    if err := export(ctx, req, &buf); err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    w.Header().Set("Content-Type", qrContentTypes[req.Format])
+    w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+    _, _ = buf.WriteTo(w)
+}
+
+func (q *qrResource) Parse(w http.ResponseWriter, r *http.Request) {
+    var req dto.ParseQRDto
+    ctx := r.Context()
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := q.qrSvc.Parse(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (q *qrResource) Pay(w http.ResponseWriter, r *http.Request) {
+    var req dto.QRPayDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+    req.TOTPCode = r.Header.Get(constant.TOTPCodeHeaderKey)
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := q.qrSvc.Pay(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
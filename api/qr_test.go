@@ -0,0 +1,190 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestQRApi(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        body      string
+        buildStub func(mockQRSvc *mocksvc.MockQRSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "WalletQRDefaults",
+            method: http.MethodGet,
+            url:    "/wallets/1/qr",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                arg := dto.GetQRDto{ID: 1, UserID: userID, Format: "png", Scale: constant.QRDefaultScale}
+                mockQRSvc.EXPECT().WalletQR(gomock.Any(), arg, gomock.Any()).Times(1).
+                    DoAndReturn(func(_ interface{}, _ dto.GetQRDto, w io.Writer) error {
+                        _, err := w.Write([]byte("png"))
+                        return err
+                    })
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+                require.Equal(t, "3", recorder.Header().Get("Content-Length"))
+                require.Equal(t, "png", recorder.Body.String())
+            },
+        },
+        {
+            name:   "WalletQRSvg",
+            method: http.MethodGet,
+            url:    "/wallets/1/qr?format=svg&scale=4",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                arg := dto.GetQRDto{ID: 1, UserID: userID, Format: "svg", Scale: 4}
+                mockQRSvc.EXPECT().WalletQR(gomock.Any(), arg, gomock.Any()).Times(1).Return(nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Equal(t, "image/svg+xml", recorder.Header().Get("Content-Type"))
+            },
+        },
+        {
+            name:   "WalletQRInvalidFormat",
+            method: http.MethodGet,
+            url:    "/wallets/1/qr?format=gif",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                mockQRSvc.EXPECT().WalletQR(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "WalletQRInvalidScale",
+            method: http.MethodGet,
+            url:    "/wallets/1/qr?scale=100",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                mockQRSvc.EXPECT().WalletQR(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "WalletQRNotFound",
+            method: http.MethodGet,
+            url:    "/wallets/1/qr",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                mockQRSvc.EXPECT().WalletQR(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrWalletNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "PaymentRequestQR",
+            method: http.MethodGet,
+            url:    "/payment-req/42/qr",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                arg := dto.GetQRDto{ID: 42, UserID: userID, Format: "png", Scale: constant.QRDefaultScale}
+                mockQRSvc.EXPECT().PaymentRequestQR(gomock.Any(), arg, gomock.Any()).Times(1).Return(nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "PaymentRequestQRNotPending",
+            method: http.MethodGet,
+            url:    "/payment-req/42/qr",
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                mockQRSvc.EXPECT().PaymentRequestQR(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrPaymentRequestNotPending)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "Parse",
+            method: http.MethodPost,
+            url:    "/qr/parse",
+            body:   `{"payload": "simplewallet://pay?address=walter%40my.wallet&currency=INR&checksum=00000000"}`,
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                mockQRSvc.EXPECT().Parse(gomock.Any(), gomock.Any()).Times(1).Return(dto.QRPayloadDto{}, errors.ErrInvalidQRPayload)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "Pay",
+            method: http.MethodPost,
+            url:    "/qr/pay",
+            body:   `{"payload": "simplewallet://pay", "from_wallet_address": "payer@my.wallet", "amount": 300}`,
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                arg := dto.QRPayDto{UserID: userID, Payload: "simplewallet://pay", FromWalletAddress: "payer@my.wallet", Amount: 300, TOTPCode: "123456"}
+                mockQRSvc.EXPECT().Pay(gomock.Any(), arg).Times(1).Return(dto.QRPayResultDto{Reference: "payment-req-42"}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.QRPayResultDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, "payment-req-42", res.Reference)
+            },
+        },
+        {
+            name:   "PayMissingWallet",
+            method: http.MethodPost,
+            url:    "/qr/pay",
+            body:   `{"payload": "simplewallet://pay"}`,
+            buildStub: func(mockQRSvc *mocksvc.MockQRSvc) {
+                mockQRSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockQRSvc := mocksvc.NewMockQRSvc(ctrl)
+            tc.buildStub(mockQRSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            qrApi := api.NewQRResource(mockQRSvc)
+            qrApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            request.Header.Set(constant.TOTPCodeHeaderKey, "123456")
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "net/http"
+    "strconv"
+)
+
+type ReconciliationResource interface {
+    Run(w http.ResponseWriter, r *http.Request)
+    Get(w http.ResponseWriter, r *http.Request)
+    List(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type reconciliationResource struct {
+    reconciliationSvc service.ReconciliationSvc
+}
+
+func NewReconciliationResource(reconciliationSvc service.ReconciliationSvc) ReconciliationResource {
+    return &reconciliationResource{
+        reconciliationSvc: reconciliationSvc,
+    }
+}
+
+// RegisterRoutes registers the admin routes, the router must only let admins through.
+func (rr *reconciliationResource) RegisterRoutes(r chi.Router) {
+    r.Post("/admin/reconciliations", rr.Run)
+    r.Get("/admin/reconciliations", rr.List)
+    r.Get("/admin/reconciliations/{reportID}", rr.Get)
+}
+
+func (rr *reconciliationResource) Run(w http.ResponseWriter, r *http.Request) {
+    res, err := rr.reconciliationSvc.Run(r.Context())
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (rr *reconciliationResource) Get(w http.ResponseWriter, r *http.Request) {
+    id, err := strconv.Atoi(chi.URLParam(r, "reportID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := rr.reconciliationSvc.Get(r.Context(), int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (rr *reconciliationResource) List(w http.ResponseWriter, r *http.Request) {
+    req := dto.ListReconciliationReportsDto{
+        Limit: 20,
+    }
+
+    query := r.URL.Query()
+    if v := query.Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := query.Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    res, err := rr.reconciliationSvc.List(r.Context(), req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
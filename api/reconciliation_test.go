@@ -0,0 +1,131 @@
+package api_test
+
+import (
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestReconciliationApi(t *testing.T) {
+    report := dto.ReconciliationReportDto{
+        ID:               1,
+        Status:           domain.ReconciliationStatusDISCREPANCIES,
+        DiscrepancyCount: 1,
+        Discrepancies: []dto.ReconciliationDiscrepancyDto{
+            {ID: 1, Kind: domain.DiscrepancyKindWALLETBALANCE, Currency: "INR", WalletID: 7, Expected: 100, Actual: 150},
+        },
+    }
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        buildStub func(mockReconciliationSvc *mocksvc.MockReconciliationSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "Run",
+            method: http.MethodPost,
+            url:    "/admin/reconciliations",
+            buildStub: func(mockReconciliationSvc *mocksvc.MockReconciliationSvc) {
+                mockReconciliationSvc.EXPECT().Run(gomock.Any()).Times(1).Return(report, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.ReconciliationReportDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, report, res)
+            },
+        },
+        {
+            name:   "RunErr",
+            method: http.MethodPost,
+            url:    "/admin/reconciliations",
+            buildStub: func(mockReconciliationSvc *mocksvc.MockReconciliationSvc) {
+                mockReconciliationSvc.EXPECT().Run(gomock.Any()).Times(1).Return(dto.ReconciliationReportDto{}, sql.ErrConnDone)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusInternalServerError, recorder.Code)
+            },
+        },
+        {
+            name:   "Get",
+            method: http.MethodGet,
+            url:    fmt.Sprintf("/admin/reconciliations/%d", report.ID),
+            buildStub: func(mockReconciliationSvc *mocksvc.MockReconciliationSvc) {
+                mockReconciliationSvc.EXPECT().Get(gomock.Any(), report.ID).Times(1).Return(report, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "GetNotFound",
+            method: http.MethodGet,
+            url:    "/admin/reconciliations/99",
+            buildStub: func(mockReconciliationSvc *mocksvc.MockReconciliationSvc) {
+                mockReconciliationSvc.EXPECT().Get(gomock.Any(), int64(99)).Times(1).Return(dto.ReconciliationReportDto{}, errors.ErrReconciliationNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name:   "List",
+            method: http.MethodGet,
+            url:    "/admin/reconciliations?limit=5&offset=5",
+            buildStub: func(mockReconciliationSvc *mocksvc.MockReconciliationSvc) {
+                arg := dto.ListReconciliationReportsDto{Limit: 5, Offset: 5}
+                mockReconciliationSvc.EXPECT().List(gomock.Any(), arg).Times(1).Return([]dto.ReconciliationReportDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "ListInvalidLimit",
+            method: http.MethodGet,
+            url:    "/admin/reconciliations?limit=0",
+            buildStub: func(mockReconciliationSvc *mocksvc.MockReconciliationSvc) {
+                mockReconciliationSvc.EXPECT().List(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockReconciliationSvc := mocksvc.NewMockReconciliationSvc(ctrl)
+            tc.buildStub(mockReconciliationSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+
+            reconciliationApi := api.NewReconciliationResource(mockReconciliationSvc)
+            reconciliationApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, nil)
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,281 @@
+package api
+
+import (
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/openapi"
+    "net/http"
+)
+
+// security of the routes: the public ones, the ones taking the token of a user or the api key of a merchant,
+// and the admin ones
+var (
+    public     []string
+    authorized = []string{openapi.BearerAuth, openapi.APIKeyAuth}
+    admin      = []string{openapi.BearerAuth}
+)
+
+func number(n float64) *float64 {
+    return &n
+}
+
+var pagination = []openapi.Parameter{
+    {Name: "limit", In: "query", Description: "20 by default", Schema: &openapi.Schema{Type: "integer", Minimum: number(1), Maximum: number(100)}},
+    {Name: "offset", In: "query", Schema: &openapi.Schema{Type: "integer", Minimum: number(0)}},
+}
+
+// totpCode is the TOTP code of the payments above the step-up threshold of their currency, by the users with two factor
+// authentication
+var totpCode = []openapi.Parameter{
+    {Name: constant.TOTPCodeHeaderKey, In: "header", Description: "TOTP code, required above the step-up threshold of the currency with two factor authentication", Schema: &openapi.Schema{Type: "string"}},
+}
+
+func queryParam(name, description string, schema *openapi.Schema) openapi.Parameter {
+    return openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// Spec describes every route of the api, a route registered without its description here fails the tests.
+// The routes of each version are described under its prefix, the ones of v1 also deprecated at the root where
+// they were served before the api was versioned.
+func Spec() *openapi.Spec {
+    spec := openapi.New(openapi.Info{
+        Title:       "Simple Wallet",
+        Description: "Wallets, transfers and payments. Authorize with the access token of POST /v1/users/login, or the api key of a merchant.",
+        Version:     "1.0.0",
+    }, types.Error{}, map[string]openapi.SecurityScheme{
+        openapi.BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+        openapi.APIKeyAuth: {Type: "apiKey", In: "header", Name: "X-Api-Key"},
+    })
+
+    v1 := openapi.RateLimit(v1Routes())
+    spec.Add(openapi.Mount("/v1", v1)...)
+    spec.Add(openapi.Deprecate(v1)...)
+
+    // operations, not versioned
+    spec.Add(
+        openapi.Route{Method: http.MethodGet, Pattern: "/health", Summary: "Answers ok", Tags: []string{"operations"},
+            Response: "", Security: public},
+        openapi.Route{Method: http.MethodGet, Pattern: "/livez", Summary: "Liveness probe", Tags: []string{"operations"},
+            Response: dto.LivenessDto{}, Security: public},
+        openapi.Route{Method: http.MethodGet, Pattern: "/readyz", Summary: "Readiness probe, 503 when a check fails", Tags: []string{"operations"},
+            Response: dto.ReadinessDto{}, Security: public},
+        openapi.Route{Method: http.MethodGet, Pattern: "/openapi.json", Summary: "This document", Tags: []string{"operations"},
+            Response: openapi.Content{"application/json"}, Security: public},
+        openapi.Route{Method: http.MethodGet, Pattern: "/docs", Summary: "The docs of this document", Tags: []string{"operations"},
+            Response: openapi.Content{"text/html"}, Security: public},
+    )
+
+    return spec
+}
+
+// v1Routes describes the routes of v1, without the prefix.
+func v1Routes() []openapi.Route {
+    var routes []openapi.Route
+
+    // users
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/users", Summary: "Sign up", Tags: []string{"users"},
+            Request: dto.CreateUserDto{}, Response: dto.UserDto{}, Security: public},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/login", Summary: "Log in, returns the access token, or a two factor token to verify with two factor authentication", Tags: []string{"users"},
+            Request: dto.LoginCredentialsDto{}, Response: dto.LoggedInUserDto{}, Security: public},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/me/password", Summary: "Change the password, revokes the tokens issued before and returns a new one", Tags: []string{"users"},
+            Request: dto.ChangePasswordDto{}, Response: dto.LoggedInUserDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/password/forgot", Summary: "Mail a single use password reset token, accepted whether or not the email is known", Tags: []string{"users"},
+            Request: dto.ForgotPasswordDto{}, Status: http.StatusAccepted, Security: public},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/password/reset", Summary: "Reset the password with the mailed token, revokes the tokens issued before", Tags: []string{"users"},
+            Request: dto.ResetPasswordDto{}, Response: dto.UserDto{}, Security: public},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/login/verify", Summary: "Exchange the two factor token of the login and a TOTP or recovery code for the access token", Tags: []string{"users"},
+            Request: dto.VerifyLoginDto{}, Response: dto.LoggedInUserDto{}, Security: public},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/me/totp", Summary: "Enroll an authenticator app, returns the secret and its QR code to confirm", Tags: []string{"users"},
+            Response: dto.TOTPEnrollmentDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/users/me/totp/confirm", Summary: "Enable two factor authentication with a first code, returns the recovery codes", Tags: []string{"users"},
+            Request: dto.ConfirmTOTPDto{}, Response: dto.TOTPRecoveryCodesDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodDelete, Pattern: "/users/me/totp", Summary: "Disable two factor authentication with the password and a code", Tags: []string{"users"},
+            Request: dto.DisableTOTPDto{}, Status: http.StatusNoContent, Security: authorized},
+    )
+
+    // bank accounts and the bank directory
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/bank-accounts", Summary: "Add a bank account, verified in the background", Tags: []string{"bank accounts"},
+            Request: dto.CreateBankAccountDto{}, Response: dto.BankAccountDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/bank-accounts/{bankAcctID}", Summary: "Get a bank account", Tags: []string{"bank accounts"},
+            Response: dto.BankAccountDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPatch, Pattern: "/admin/bank-accounts/{bankAcctID}/verification-success", Summary: "Mark a bank account verified, creates its wallet", Tags: []string{"admin"},
+            Response: dto.BankAccountDto{}, Security: admin},
+        openapi.Route{Method: http.MethodPatch, Pattern: "/admin/bank-accounts/{bankAcctID}/verification-failed", Summary: "Mark the verification of a bank account failed", Tags: []string{"admin"},
+            Response: dto.BankAccountDto{}, Security: admin},
+        openapi.Route{Method: http.MethodGet, Pattern: "/banks/ifsc/{ifsc}", Summary: "Look up a bank branch by IFSC", Tags: []string{"bank accounts"},
+            Response: dto.BankBranchDto{}, Security: authorized},
+    )
+
+    // currencies
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/currencies", Summary: "Add a currency", Tags: []string{"currencies"},
+            Request: dto.CurrencyDto{}, Response: dto.CurrencyDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/currencies/{currencyCode}", Summary: "Get a currency", Tags: []string{"currencies"},
+            Response: dto.CurrencyDto{}, Security: authorized},
+    )
+
+    // wallets
+    routes = append(routes,
+        openapi.Route{Method: http.MethodGet, Pattern: "/wallets/{walletID}", Summary: "Get a wallet", Tags: []string{"wallets"},
+            Response: dto.WalletDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/wallets/pay", Summary: "Pay from a wallet to another", Tags: []string{"wallets"},
+            Query: totpCode, Request: dto.TransferMoneyDto{}, Response: dto.WalletTransferResultDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/wallets/{walletID}/statements", Summary: "Download the monthly statement of a wallet", Tags: []string{"wallets"},
+            Query: []openapi.Parameter{
+                {Name: "month", In: "query", Required: true, Description: "YYYY-MM", Schema: &openapi.Schema{Type: "string", Pattern: "^[0-9]{4}-[0-9]{2}$"}},
+                queryParam("format", "csv by default", &openapi.Schema{Type: "string", Enum: []string{"csv", "pdf"}}),
+            },
+            Response: openapi.Content{"text/csv", "application/pdf"}, Security: authorized},
+    )
+
+    // transfers
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/transfers/{transferID}/refund", Summary: "Refund a payment, in full without an amount", Tags: []string{"transfers"},
+            Request: dto.RefundTransferDto{}, Response: dto.RefundResultDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/admin/transfers/{transferID}/reversal", Summary: "Reverse a transfer", Tags: []string{"admin"},
+            Request: dto.ReverseTransferDto{}, Response: dto.RefundResultDto{}, Status: http.StatusCreated, Security: admin},
+    )
+
+    // holds
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/holds", Summary: "Hold an amount of a wallet", Tags: []string{"holds"},
+            Query: totpCode, Request: dto.AuthorizeHoldDto{}, Response: dto.HoldDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/holds/{holdID}", Summary: "Get a hold", Tags: []string{"holds"},
+            Response: dto.HoldDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/holds/{holdID}/capture", Summary: "Capture a hold, in full without an amount", Tags: []string{"holds"},
+            Query: totpCode, Request: dto.CaptureHoldDto{}, Response: dto.CaptureHoldResultDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/holds/{holdID}/void", Summary: "Release a hold", Tags: []string{"holds"},
+            Response: dto.HoldDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/wallets/{walletID}/holds", Summary: "List the holds of a wallet", Tags: []string{"holds"},
+            Query: pagination, Response: []dto.HoldDto{}, Security: authorized},
+    )
+
+    // escrows
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/escrows", Summary: "Pay into escrow", Tags: []string{"escrows"},
+            Query: totpCode, Request: dto.CreateEscrowDto{}, Response: dto.EscrowTransferResultDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/escrows/{escrowID}", Summary: "Get an escrow", Tags: []string{"escrows"},
+            Response: dto.EscrowDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/escrows/{escrowID}/release", Summary: "Release an escrow to the payee", Tags: []string{"escrows"},
+            Response: dto.EscrowTransferResultDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/escrows/{escrowID}/dispute", Summary: "Dispute an escrow", Tags: []string{"escrows"},
+            Request: dto.DisputeEscrowDto{}, Response: dto.EscrowDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/admin/escrows", Summary: "List the escrows, the disputed ones by default", Tags: []string{"admin"},
+            Query:    append([]openapi.Parameter{queryParam("status", "DISPUTED by default", &openapi.Schema{Type: "string"})}, pagination...),
+            Response: []dto.EscrowDto{}, Security: admin},
+        openapi.Route{Method: http.MethodPost, Pattern: "/admin/escrows/{escrowID}/resolve", Summary: "Resolve a disputed escrow", Tags: []string{"admin"},
+            Request: dto.ResolveEscrowDto{}, Response: dto.EscrowTransferResultDto{}, Security: admin},
+    )
+
+    // payment requests
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/payment-req", Summary: "Request a payment", Tags: []string{"payment requests"},
+            Request: dto.PaymentRequestDto{}, Response: dto.PaymentRequestDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/payment-req", Summary: "List the payment requests of a wallet, the filter is sent in the body", Tags: []string{"payment requests"},
+            Request: dto.ListPaymentRequestsDto{}, Response: []dto.PaymentRequestDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPatch, Pattern: "/payment-req/{payReqID}/approve", Summary: "Approve and pay a payment request", Tags: []string{"payment requests"},
+            Query: totpCode, Response: dto.PaymentRequestDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPatch, Pattern: "/payment-req/{payReqID}/refuse", Summary: "Refuse a payment request", Tags: []string{"payment requests"},
+            Response: dto.PaymentRequestDto{}, Security: authorized},
+    )
+
+    // qr codes
+    qrQuery := []openapi.Parameter{
+        queryParam("format", "png by default", &openapi.Schema{Type: "string", Enum: []string{"png", "svg"}}),
+        queryParam("scale", "pixels per module of the png", &openapi.Schema{Type: "integer"}),
+    }
+    routes = append(routes,
+        openapi.Route{Method: http.MethodGet, Pattern: "/wallets/{walletID}/qr", Summary: "QR code to pay the wallet", Tags: []string{"qr"},
+            Query: qrQuery, Response: openapi.Content{"image/png", "image/svg+xml"}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/payment-req/{payReqID}/qr", Summary: "QR code to pay a payment request", Tags: []string{"qr"},
+            Query: qrQuery, Response: openapi.Content{"image/png", "image/svg+xml"}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/qr/parse", Summary: "Read the payload of a QR code", Tags: []string{"qr"},
+            Request: dto.ParseQRDto{}, Response: dto.QRPayloadDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/qr/pay", Summary: "Pay a QR code", Tags: []string{"qr"},
+            Query: totpCode, Request: dto.QRPayDto{}, Response: dto.QRPayResultDto{}, Security: authorized},
+    )
+
+    // webhooks
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/webhooks", Summary: "Register a webhook endpoint", Tags: []string{"webhooks"},
+            Request: dto.CreateWebhookEndpointDto{}, Response: dto.WebhookEndpointDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/webhooks", Summary: "List the webhook endpoints", Tags: []string{"webhooks"},
+            Response: []dto.WebhookEndpointDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodDelete, Pattern: "/webhooks/{webhookID}", Summary: "Disable a webhook endpoint", Tags: []string{"webhooks"},
+            Response: dto.WebhookEndpointDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/webhooks/{webhookID}/deliveries", Summary: "List the deliveries of a webhook endpoint", Tags: []string{"webhooks"},
+            Query: pagination, Response: []dto.WebhookDeliveryDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/webhooks/deliveries/{deliveryID}/redeliver", Summary: "Deliver an event again", Tags: []string{"webhooks"},
+            Response: dto.WebhookDeliveryDto{}, Security: authorized},
+    )
+
+    // notifications
+    routes = append(routes,
+        openapi.Route{Method: http.MethodGet, Pattern: "/notifications", Summary: "List the notifications", Tags: []string{"notifications"},
+            Query:    append([]openapi.Parameter{queryParam("unread", "only the unread ones", &openapi.Schema{Type: "boolean"})}, pagination...),
+            Response: []dto.NotificationDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPatch, Pattern: "/notifications/{notificationID}/read", Summary: "Mark a notification read", Tags: []string{"notifications"},
+            Response: dto.NotificationDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPatch, Pattern: "/notifications/{notificationID}/unread", Summary: "Mark a notification unread", Tags: []string{"notifications"},
+            Response: dto.NotificationDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/notifications/preferences", Summary: "List the notification preferences", Tags: []string{"notifications"},
+            Response: []dto.NotificationPreferenceDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPut, Pattern: "/notifications/preferences", Summary: "Set a notification preference", Tags: []string{"notifications"},
+            Request: dto.NotificationPreferenceDto{}, Response: dto.NotificationPreferenceDto{}, Security: authorized},
+    )
+
+    // merchants
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/merchants", Summary: "Become a merchant", Tags: []string{"merchants"},
+            Request: dto.CreateMerchantDto{}, Response: dto.MerchantDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/merchants/me", Summary: "Get the merchant of the user", Tags: []string{"merchants"},
+            Response: dto.MerchantDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPut, Pattern: "/merchants/me", Summary: "Update the merchant of the user", Tags: []string{"merchants"},
+            Request: dto.UpdateMerchantDto{}, Response: dto.MerchantDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/merchants/me/api-keys", Summary: "Create an api key, its secret is only returned now", Tags: []string{"merchants"},
+            Request: dto.CreateAPIKeyDto{}, Response: dto.APIKeyDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/merchants/me/api-keys", Summary: "List the api keys", Tags: []string{"merchants"},
+            Response: []dto.APIKeyDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodDelete, Pattern: "/merchants/me/api-keys/{keyID}", Summary: "Revoke an api key", Tags: []string{"merchants"},
+            Response: dto.APIKeyDto{}, Security: authorized},
+    )
+
+    // payment links
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/payment-links", Summary: "Create a payment link", Tags: []string{"payment links"},
+            Request: dto.CreatePaymentLinkDto{}, Response: dto.PaymentLinkDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/payment-links", Summary: "List the payment links", Tags: []string{"payment links"},
+            Query: pagination, Response: []dto.PaymentLinkDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodDelete, Pattern: "/payment-links/{linkID}", Summary: "Disable a payment link", Tags: []string{"payment links"},
+            Response: dto.PaymentLinkDto{}, Security: authorized},
+        openapi.Route{Method: http.MethodGet, Pattern: "/pay/{slug}", Summary: "Get the page of a payment link", Tags: []string{"payment links"},
+            Response: dto.PaymentLinkPageDto{}, Security: public},
+        openapi.Route{Method: http.MethodPost, Pattern: "/pay/{slug}/checkout", Summary: "Start the checkout of a payment link", Tags: []string{"payment links"},
+            Request: dto.StartCheckoutDto{}, Response: dto.CheckoutSessionDto{}, Status: http.StatusCreated, Security: authorized},
+        openapi.Route{Method: http.MethodPost, Pattern: "/checkout-sessions/{sessionID}/complete", Summary: "Pay a checkout session", Tags: []string{"payment links"},
+            Query: totpCode, Request: dto.CompleteCheckoutDto{}, Response: dto.CheckoutResultDto{}, Security: authorized},
+    )
+
+    // reconciliation
+    routes = append(routes,
+        openapi.Route{Method: http.MethodPost, Pattern: "/admin/reconciliations", Summary: "Reconcile the ledger now", Tags: []string{"admin"},
+            Response: dto.ReconciliationReportDto{}, Status: http.StatusCreated, Security: admin},
+        openapi.Route{Method: http.MethodGet, Pattern: "/admin/reconciliations", Summary: "List the reconciliation reports", Tags: []string{"admin"},
+            Query: pagination, Response: []dto.ReconciliationReportDto{}, Security: admin},
+        openapi.Route{Method: http.MethodGet, Pattern: "/admin/reconciliations/{reportID}", Summary: "Get a reconciliation report", Tags: []string{"admin"},
+            Response: dto.ReconciliationReportDto{}, Security: admin},
+    )
+
+    // outbox
+    routes = append(routes,
+        openapi.Route{Method: http.MethodGet, Pattern: "/admin/outbox/dead", Summary: "List the outbox events the relay gave up on", Tags: []string{"admin"},
+            Query: pagination, Response: []dto.OutboxEventDto{}, Security: admin},
+        openapi.Route{Method: http.MethodPost, Pattern: "/admin/outbox/{eventID}/retry", Summary: "Publish a dead outbox event again", Tags: []string{"admin"},
+            Response: dto.OutboxEventDto{}, Security: admin},
+    )
+
+    return routes
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+    "bytes"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "net/http"
+    "strconv"
+    "time"
+)
+
+type StatementResource interface {
+    Get(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type statementResource struct {
+    statementSvc service.StatementSvc
+}
+
+func NewStatementResource(statementSvc service.StatementSvc) StatementResource {
+    return &statementResource{
+        statementSvc: statementSvc,
+    }
+}
+
+func (s *statementResource) RegisterRoutes(r chi.Router) {
+    r.Get("/wallets/{walletID}/statements", s.Get)
+}
+
+var statementContentTypes = map[string]string{
+    "csv": "text/csv; charset=utf-8",
+    "pdf": "application/pdf",
+}
+
+func (s *statementResource) Get(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "walletID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    query := r.URL.Query()
+    month, err := time.Parse("2006-01", query.Get("month"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid month, expected YYYY-MM")))
+        return
+    }
+
+    if month.After(time.Now().UTC()) {
+        _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("month is in the future")))
+        return
+    }
+
+    req := dto.GetStatementDto{
+        WalletID: int64(id),
+        UserID:   authPayload.UserID,
+        Month:    month,
+        Format:   query.Get("format"),
+    }
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    // the statement is built before anything is written, so that an error still gets its status code
+    var buf bytes.Buffer
+    if err := s.statementSvc.Export(ctx, req, &buf); err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    filename := fmt.Sprintf("statement-%d-%s.%s", req.WalletID, month.Format("2006-01"), req.Format)
+    w.Header().Set("Content-Type", statementContentTypes[req.Format])
+    w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+    w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+    _, _ = buf.WriteTo(w)
+}
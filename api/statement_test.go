@@ -0,0 +1,131 @@
+package api_test
+
+import (
+    "context"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestGetStatement(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    walletID := util.RandomInt(1, 1000)
+    month := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+    testcases := []struct {
+        name      string
+        query     string
+        buildStub func(mockStatementSvc *mocksvc.MockStatementSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:  "Csv",
+            query: "month=2021-03&format=csv",
+            buildStub: func(mockStatementSvc *mocksvc.MockStatementSvc) {
+                arg := dto.GetStatementDto{WalletID: walletID, UserID: userID, Month: month, Format: "csv"}
+                mockStatementSvc.EXPECT().Export(gomock.Any(), arg, gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, getStatementDto dto.GetStatementDto, w io.Writer) error {
+                        _, err := io.WriteString(w, "date,transfer_id\n")
+                        return err
+                    })
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+                require.Equal(t, fmt.Sprintf(`attachment; filename="statement-%d-2021-03.csv"`, walletID), recorder.Header().Get("Content-Disposition"))
+                require.Equal(t, "date,transfer_id\n", recorder.Body.String())
+            },
+        },
+        {
+            name:  "Pdf",
+            query: "month=2021-03&format=pdf",
+            buildStub: func(mockStatementSvc *mocksvc.MockStatementSvc) {
+                arg := dto.GetStatementDto{WalletID: walletID, UserID: userID, Month: month, Format: "pdf"}
+                mockStatementSvc.EXPECT().Export(gomock.Any(), arg, gomock.Any()).Times(1).Return(nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+            },
+        },
+        {
+            name:  "InvalidMonth",
+            query: "month=2021-13&format=csv",
+            buildStub: func(mockStatementSvc *mocksvc.MockStatementSvc) {
+                mockStatementSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:  "FutureMonth",
+            query: fmt.Sprintf("month=%s&format=csv", time.Now().AddDate(0, 2, 0).Format("2006-01")),
+            buildStub: func(mockStatementSvc *mocksvc.MockStatementSvc) {
+                mockStatementSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:  "InvalidFormat",
+            query: "month=2021-03&format=xlsx",
+            buildStub: func(mockStatementSvc *mocksvc.MockStatementSvc) {
+                mockStatementSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:  "WalletNotFound",
+            query: "month=2021-03&format=csv",
+            buildStub: func(mockStatementSvc *mocksvc.MockStatementSvc) {
+                mockStatementSvc.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrWalletNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+                require.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockStatementSvc := mocksvc.NewMockStatementSvc(ctrl)
+            tc.buildStub(mockStatementSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            statementApi := api.NewStatementResource(mockStatementSvc)
+            statementApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/wallets/%d/statements?%s", walletID, tc.query), nil)
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,113 @@
+package api
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "io"
+    "net/http"
+    "strconv"
+)
+
+type TransferResource interface {
+    Refund(w http.ResponseWriter, r *http.Request)
+    Reverse(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+    RegisterAdminRoutes(r chi.Router)
+}
+
+type transferResource struct {
+    transferSvc service.TransferSvc
+}
+
+func NewTransferResource(transferSvc service.TransferSvc) TransferResource {
+    return &transferResource{
+        transferSvc: transferSvc,
+    }
+}
+
+func (tr *transferResource) RegisterRoutes(r chi.Router) {
+    r.Post("/transfers/{transferID}/refund", tr.Refund)
+}
+
+// RegisterAdminRoutes registers the admin routes, the router must only let admins through.
+func (tr *transferResource) RegisterAdminRoutes(r chi.Router) {
+    r.Post("/admin/transfers/{transferID}/reversal", tr.Reverse)
+}
+
+// Refund takes an optional body, without an amount what is left of the transfer is refunded.
+func (tr *transferResource) Refund(w http.ResponseWriter, r *http.Request) {
+    var req dto.RefundTransferDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "transferID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.TransferID = int64(id)
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := tr.transferSvc.Refund(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (tr *transferResource) Reverse(w http.ResponseWriter, r *http.Request) {
+    var req dto.ReverseTransferDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "transferID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.TransferID = int64(id)
+    req.AdminID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := tr.transferSvc.Reverse(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
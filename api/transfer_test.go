@@ -0,0 +1,162 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestTransferApi(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    originalTransferID := int64(1)
+    refund := dto.RefundResultDto{
+        Refund: dto.WalletTransferResultDto{
+            Transfer: dto.TransferDto{ID: 2, Amount: 40, Kind: domain.TransferKindREFUND, OriginalTransferID: &originalTransferID},
+        },
+        OriginalTransfer: dto.TransferDto{ID: 1, Amount: 100, Kind: domain.TransferKindPAYMENT, RefundedAmount: 40, RefundStatus: domain.RefundStatusPARTIALLYREFUNDED},
+    }
+
+    testcases := []struct {
+        name      string
+        url       string
+        body      string
+        buildStub func(mockTransferSvc *mocksvc.MockTransferSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "PartialRefund",
+            url:  "/transfers/1/refund",
+            body: `{"amount": 40}`,
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                arg := dto.RefundTransferDto{TransferID: 1, UserID: userID, Amount: 40}
+                mockTransferSvc.EXPECT().Refund(gomock.Any(), arg).Times(1).Return(refund, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.RefundResultDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, domain.RefundStatusPARTIALLYREFUNDED, res.OriginalTransfer.RefundStatus)
+                require.Equal(t, int64(40), res.Refund.Transfer.Amount)
+                require.Equal(t, originalTransferID, *res.Refund.Transfer.OriginalTransferID)
+                require.Nil(t, res.OriginalTransfer.OriginalTransferID)
+            },
+        },
+        {
+            name: "FullRefundWithoutBody",
+            url:  "/transfers/1/refund",
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                arg := dto.RefundTransferDto{TransferID: 1, UserID: userID}
+                mockTransferSvc.EXPECT().Refund(gomock.Any(), arg).Times(1).Return(refund, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+            },
+        },
+        {
+            name: "NegativeAmount",
+            url:  "/transfers/1/refund",
+            body: `{"amount": -1}`,
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                mockTransferSvc.EXPECT().Refund(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidTransferID",
+            url:  "/transfers/abc/refund",
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                mockTransferSvc.EXPECT().Refund(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "TransferNotFound",
+            url:  "/transfers/1/refund",
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                mockTransferSvc.EXPECT().Refund(gomock.Any(), gomock.Any()).Times(1).Return(dto.RefundResultDto{}, errors.ErrTransferNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+        {
+            name: "RefundExceedsTransfer",
+            url:  "/transfers/1/refund",
+            body: `{"amount": 1000}`,
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                mockTransferSvc.EXPECT().Refund(gomock.Any(), gomock.Any()).Times(1).Return(dto.RefundResultDto{}, errors.ErrRefundExceedsTransfer)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name: "Reversal",
+            url:  "/admin/transfers/1/reversal",
+            body: `{"amount": 40, "allow_negative_balance": true}`,
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                arg := dto.ReverseTransferDto{TransferID: 1, AdminID: userID, Amount: 40, AllowNegativeBalance: true}
+                mockTransferSvc.EXPECT().Reverse(gomock.Any(), arg).Times(1).Return(refund, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+            },
+        },
+        {
+            name: "ReversalInsufficientBalance",
+            url:  "/admin/transfers/1/reversal",
+            buildStub: func(mockTransferSvc *mocksvc.MockTransferSvc) {
+                arg := dto.ReverseTransferDto{TransferID: 1, AdminID: userID}
+                mockTransferSvc.EXPECT().Reverse(gomock.Any(), arg).Times(1).Return(dto.RefundResultDto{}, errors.ErrInsufficientBalance)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockTransferSvc := mocksvc.NewMockTransferSvc(ctrl)
+            tc.buildStub(mockTransferSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            transferApi := api.NewTransferResource(mockTransferSvc)
+            transferApi.RegisterRoutes(router)
+            transferApi.RegisterAdminRoutes(router)
+
+            request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
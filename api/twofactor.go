@@ -0,0 +1,147 @@
+package api
+
+import (
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    log "github.com/sirupsen/logrus"
+    "net/http"
+)
+
+type TwoFactorResource interface {
+    Enroll(w http.ResponseWriter, r *http.Request)
+    Confirm(w http.ResponseWriter, r *http.Request)
+    Disable(w http.ResponseWriter, r *http.Request)
+    VerifyLogin(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+    RegisterPublicRoutes(r chi.Router)
+}
+
+type twoFactorResource struct {
+    twoFactorSvc service.TwoFactorSvc
+}
+
+func NewTwoFactorResource(twoFactorSvc service.TwoFactorSvc) TwoFactorResource {
+    return &twoFactorResource{
+        twoFactorSvc: twoFactorSvc,
+    }
+}
+
+// RegisterRoutes registers the routes to manage two factor authentication, no api key can be scoped to them so
+// they are only reachable with the token of the user.
+func (t *twoFactorResource) RegisterRoutes(r chi.Router) {
+    r.Post("/users/me/totp", t.Enroll)
+    r.Post("/users/me/totp/confirm", t.Confirm)
+    r.Delete("/users/me/totp", t.Disable)
+}
+
+// RegisterPublicRoutes registers the second step of the login, served without authentication.
+func (t *twoFactorResource) RegisterPublicRoutes(r chi.Router) {
+    r.Post("/users/login/verify", t.VerifyLogin)
+}
+
+func (t *twoFactorResource) Enroll(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    res, err := t.twoFactorSvc.Enroll(ctx, authPayload.UserID)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (t *twoFactorResource) Confirm(w http.ResponseWriter, r *http.Request) {
+    var req dto.ConfirmTOTPDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := t.twoFactorSvc.Confirm(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    logging.FromContext(ctx).Info("two factor authentication enabled")
+
+    render.JSON(w, r, res)
+}
+
+func (t *twoFactorResource) Disable(w http.ResponseWriter, r *http.Request) {
+    var req dto.DisableTOTPDto
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    req.UserID = authPayload.UserID
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    err := t.twoFactorSvc.Disable(ctx, req)
+    if err != nil {
+        logging.FromContext(ctx).Warn("two factor authentication disable failed: ", err)
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    logging.FromContext(ctx).Info("two factor authentication disabled")
+
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (t *twoFactorResource) VerifyLogin(w http.ResponseWriter, r *http.Request) {
+    var req dto.VerifyLoginDto
+    ctx := r.Context()
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    loggedInUser, err := t.twoFactorSvc.VerifyLogin(ctx, req)
+    if err != nil {
+        logging.FromContext(ctx).Warn("two factor login failed: ", err)
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    logging.AddFields(ctx, log.Fields{"user_id": loggedInUser.User.ID})
+    logging.FromContext(ctx).Info("user logged in")
+
+    render.JSON(w, r, loggedInUser)
+}
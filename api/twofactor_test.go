@@ -0,0 +1,203 @@
+package api_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestManageTOTP(t *testing.T) {
+    var userID int64 = 1
+
+    testcases := []struct {
+        name      string
+        method    string
+        url       string
+        body      string
+        buildStub func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "Enroll",
+            method: http.MethodPost,
+            url:    "/users/me/totp",
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                enrollment := dto.TOTPEnrollmentDto{Secret: "SECRET", ProvisioningURI: "otpauth://totp/SimpleWallet:walter?secret=SECRET"}
+                mockTwoFactorSvc.EXPECT().Enroll(gomock.Any(), userID).Times(1).Return(enrollment, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.TOTPEnrollmentDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, "SECRET", res.Secret)
+            },
+        },
+        {
+            name:   "EnrollAlreadyEnabled",
+            method: http.MethodPost,
+            url:    "/users/me/totp",
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().Enroll(gomock.Any(), userID).Times(1).Return(dto.TOTPEnrollmentDto{}, errors.ErrTOTPAlreadyEnabled)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "Confirm",
+            method: http.MethodPost,
+            url:    "/users/me/totp/confirm",
+            body:   `{"code": "123456"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                recoveryCodes := dto.TOTPRecoveryCodesDto{RecoveryCodes: []string{"ABCDE-FGHIJ"}}
+                mockTwoFactorSvc.EXPECT().Confirm(gomock.Any(), dto.ConfirmTOTPDto{UserID: userID, Code: "123456"}).Times(1).Return(recoveryCodes, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.TOTPRecoveryCodesDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, []string{"ABCDE-FGHIJ"}, res.RecoveryCodes)
+            },
+        },
+        {
+            name:   "ConfirmInvalidCode",
+            method: http.MethodPost,
+            url:    "/users/me/totp/confirm",
+            body:   `{"code": "12ab"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().Confirm(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name:   "Disable",
+            method: http.MethodDelete,
+            url:    "/users/me/totp",
+            body:   `{"password": "secret", "code": "123456"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                disableTOTPDto := dto.DisableTOTPDto{UserID: userID, Password: "secret", Code: "123456"}
+                mockTwoFactorSvc.EXPECT().Disable(gomock.Any(), disableTOTPDto).Times(1).Return(nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNoContent, recorder.Code)
+            },
+        },
+        {
+            name:   "DisableIncorrectPassword",
+            method: http.MethodDelete,
+            url:    "/users/me/totp",
+            body:   `{"password": "wrong", "code": "123456"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().Disable(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrIncorrectPassword)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockTwoFactorSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+            api.NewTwoFactorResource(mockTwoFactorSvc).RegisterRoutes(router)
+
+            request, err := http.NewRequest(tc.method, tc.url, bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestVerifyLogin(t *testing.T) {
+    testcases := []struct {
+        name      string
+        body      string
+        buildStub func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            body: `{"two_factor_token": "two-factor-token", "code": "123456"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                verifyLoginDto := dto.VerifyLoginDto{TwoFactorToken: "two-factor-token", Code: "123456"}
+                loggedInUser := dto.LoggedInUserDto{AccessToken: "access-token", User: dto.UserDto{ID: 1}}
+                mockTwoFactorSvc.EXPECT().VerifyLogin(gomock.Any(), verifyLoginDto).Times(1).Return(loggedInUser, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.LoggedInUserDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, "access-token", res.AccessToken)
+            },
+        },
+        {
+            name: "InvalidCode",
+            body: `{"two_factor_token": "two-factor-token", "code": "000000"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().VerifyLogin(gomock.Any(), gomock.Any()).Times(1).Return(dto.LoggedInUserDto{}, errors.ErrInvalidTOTPCode)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "MissingToken",
+            body: `{"code": "123456"}`,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().VerifyLogin(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockTwoFactorSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+            api.NewTwoFactorResource(mockTwoFactorSvc).RegisterPublicRoutes(router)
+
+            request, err := http.NewRequest(http.MethodPost, "/users/login/verify", bytes.NewBufferString(tc.body))
+            require.NoError(t, err)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
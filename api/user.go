@@ -4,11 +4,12 @@ import (
     "encoding/json"
     "github.com/go-chi/chi"
     "github.com/go-chi/render"
-    "github.com/go-playground/validator/v10"
     "github.com/pranayhere/simple-wallet/dto"
     types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
     "github.com/pranayhere/simple-wallet/service"
-    "github.com/sirupsen/logrus"
+    log "github.com/sirupsen/logrus"
     "net/http"
 )
 
@@ -34,7 +35,6 @@ func (u *userResource) RegisterRoutes(r chi.Router) {
 }
 
 func (u *userResource) Create(w http.ResponseWriter, r *http.Request) {
-    logrus.Println("log create user")
     var req dto.CreateUserDto
     ctx := r.Context()
 
@@ -44,7 +44,7 @@ func (u *userResource) Create(w http.ResponseWriter, r *http.Request) {
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
@@ -55,6 +55,9 @@ func (u *userResource) Create(w http.ResponseWriter, r *http.Request) {
         return
     }
 
+    logging.AddFields(ctx, log.Fields{"user_id": user.ID})
+    logging.FromContext(ctx).Info("user created")
+
     render.JSON(w, r, user)
 }
 
@@ -68,16 +71,19 @@ func (u *userResource) Login(w http.ResponseWriter, r *http.Request) {
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
 
     loggedInUser, err := u.userSvc.LoginUser(ctx, req)
     if err != nil {
+        logging.FromContext(ctx).WithField("username", req.Username).Warn("login failed: ", err)
         _ = render.Render(w, r, types.ErrResponse(err))
         return
     }
 
+    logging.AddFields(ctx, log.Fields{"user_id": loggedInUser.User.ID})
+
     render.JSON(w, r, loggedInUser)
 }
@@ -4,9 +4,10 @@ import (
     "encoding/json"
     "github.com/go-chi/chi"
     "github.com/go-chi/render"
-    "github.com/go-playground/validator/v10"
     "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
     types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
     "github.com/pranayhere/simple-wallet/service"
     "net/http"
     "strconv"
@@ -62,11 +63,13 @@ func (wr *walletResource) Pay(w http.ResponseWriter, r *http.Request) {
     }
     defer r.Body.Close()
 
-    if err := validator.New().Struct(req); err != nil {
+    if err := validation.Struct(req); err != nil {
         _ = render.Render(w, r, types.ErrBadRequest(err))
         return
     }
 
+    req.TOTPCode = r.Header.Get(constant.TOTPCodeHeaderKey)
+
     res, err := wr.walletSvc.Pay(ctx, req)
     if err != nil {
         _ = render.Render(w, r, types.ErrResponse(err))
@@ -1,12 +1,14 @@
 package api_test
 
 import (
+    "bytes"
     "database/sql"
     "fmt"
     "github.com/go-chi/chi"
     "github.com/golang/mock/gomock"
     "github.com/pranayhere/simple-wallet/api"
     "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
     "github.com/pranayhere/simple-wallet/pkg/errors"
     mocksvc "github.com/pranayhere/simple-wallet/service/mock"
     "github.com/stretchr/testify/require"
@@ -89,3 +91,57 @@ func TestGetWallet(t *testing.T) {
         })
     }
 }
+
+func TestWalletPay(t *testing.T) {
+    body := `{"from_wallet_address": "payer@my.wallet", "to_wallet_address": "payee@my.wallet", "amount": 5000}`
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockWalletSvc *mocksvc.MockWalletSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                // the code of the header steps up the payment in the service
+                arg := dto.TransferMoneyDto{FromWalletAddress: "payer@my.wallet", ToWalletAddress: "payee@my.wallet", Amount: 5000, TOTPCode: "123456"}
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), arg).Times(1)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "TOTPRequired",
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(1).Return(dto.WalletTransferResultDto{}, errors.ErrTOTPRequired)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            tc.buildStub(mockWalletSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter()
+
+            walletApi := api.NewWalletResource(mockWalletSvc)
+            walletApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodPost, "/wallets/pay", bytes.NewBufferString(body))
+            require.NoError(t, err)
+            request.Header.Set(constant.TOTPCodeHeaderKey, "123456")
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    types "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+    "net/http"
+    "strconv"
+)
+
+type WebhookResource interface {
+    Create(w http.ResponseWriter, r *http.Request)
+    List(w http.ResponseWriter, r *http.Request)
+    Disable(w http.ResponseWriter, r *http.Request)
+    ListDeliveries(w http.ResponseWriter, r *http.Request)
+    Redeliver(w http.ResponseWriter, r *http.Request)
+    RegisterRoutes(r chi.Router)
+}
+
+type webhookResource struct {
+    webhookSvc service.WebhookSvc
+}
+
+func NewWebhookResource(webhookSvc service.WebhookSvc) WebhookResource {
+    return &webhookResource{
+        webhookSvc: webhookSvc,
+    }
+}
+
+func (wh *webhookResource) RegisterRoutes(r chi.Router) {
+    r.Post("/webhooks", wh.Create)
+    r.Get("/webhooks", wh.List)
+    r.Delete("/webhooks/{webhookID}", wh.Disable)
+    r.Get("/webhooks/{webhookID}/deliveries", wh.ListDeliveries)
+    r.Post("/webhooks/deliveries/{deliveryID}/redeliver", wh.Redeliver)
+}
+
+func (wh *webhookResource) Create(w http.ResponseWriter, r *http.Request) {
+    var req dto.CreateWebhookEndpointDto
+    ctx := r.Context()
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+    defer r.Body.Close()
+
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+    req.UserID = authPayload.UserID
+
+    if err := validation.Struct(req); err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := wh.webhookSvc.RegisterEndpoint(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.Status(r, http.StatusCreated)
+    render.JSON(w, r, res)
+}
+
+func (wh *webhookResource) List(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    res, err := wh.webhookSvc.ListEndpoints(ctx, authPayload.UserID)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (wh *webhookResource) Disable(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "webhookID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := wh.webhookSvc.DisableEndpoint(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (wh *webhookResource) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "webhookID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    req := dto.ListWebhookDeliveriesDto{
+        EndpointID: int64(id),
+        UserID:     authPayload.UserID,
+        Limit:      20,
+    }
+
+    if v := r.URL.Query().Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil || limit < 1 || limit > 100 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid limit")))
+            return
+        }
+        req.Limit = int32(limit)
+    }
+
+    if v := r.URL.Query().Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil || offset < 0 {
+            _ = render.Render(w, r, types.ErrBadRequest(fmt.Errorf("invalid offset")))
+            return
+        }
+        req.Offset = int32(offset)
+    }
+
+    res, err := wh.webhookSvc.ListDeliveries(ctx, req)
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
+
+func (wh *webhookResource) Redeliver(w http.ResponseWriter, r *http.Request) {
+    ctx := r.Context()
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    id, err := strconv.Atoi(chi.URLParam(r, "deliveryID"))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrBadRequest(err))
+        return
+    }
+
+    res, err := wh.webhookSvc.Redeliver(ctx, authPayload.UserID, int64(id))
+    if err != nil {
+        _ = render.Render(w, r, types.ErrResponse(err))
+        return
+    }
+
+    render.JSON(w, r, res)
+}
@@ -0,0 +1,297 @@
+package api_test
+
+import (
+    "bytes"
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestCreateWebhook(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    createDto := dto.CreateWebhookEndpointDto{
+        Url:        "https://partner.example.com/hooks",
+        EventTypes: []string{string(domain.EventTypeTransferCompleted)},
+        UserID:     userID,
+    }
+    endpointDto := dto.WebhookEndpointDto{
+        ID:         util.RandomInt(1, 1000),
+        UserID:     userID,
+        Url:        createDto.Url,
+        Secret:     "whsec_" + util.RandomString(16),
+        EventTypes: createDto.EventTypes,
+        Status:     domain.WebhookEndpointStatusACTIVE,
+    }
+
+    testcases := []struct {
+        name      string
+        body      map[string]interface{}
+        setupAuth func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+        buildStub func(mockWebhookSvc *mocksvc.MockWebhookSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            body: map[string]interface{}{
+                "url":         createDto.Url,
+                "event_types": createDto.EventTypes,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            },
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().RegisterEndpoint(gomock.Any(), createDto).Times(1).Return(endpointDto, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusCreated, recorder.Code)
+
+                var res dto.WebhookEndpointDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, endpointDto.Secret, res.Secret)
+            },
+        },
+        {
+            name: "NoAuthorization",
+            body: map[string]interface{}{
+                "url": createDto.Url,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+            },
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().RegisterEndpoint(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidUrl",
+            body: map[string]interface{}{
+                "url": "not a url",
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            },
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().RegisterEndpoint(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "UnknownEventType",
+            body: map[string]interface{}{
+                "url":         createDto.Url,
+                "event_types": []string{"wallet.deleted"},
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            },
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().RegisterEndpoint(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "InternalServerError",
+            body: map[string]interface{}{
+                "url":         createDto.Url,
+                "event_types": createDto.EventTypes,
+            },
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            },
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().RegisterEndpoint(gomock.Any(), createDto).Times(1).Return(dto.WebhookEndpointDto{}, sql.ErrConnDone)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusInternalServerError, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockWebhookSvc := mocksvc.NewMockWebhookSvc(ctrl)
+            tc.buildStub(mockWebhookSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            webhookApi := api.NewWebhookResource(mockWebhookSvc)
+            webhookApi.RegisterRoutes(router)
+
+            data, err := json.Marshal(tc.body)
+            require.NoError(t, err)
+
+            request, err := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader(data))
+            require.NoError(t, err)
+            tc.setupAuth(t, request, tokenMaker)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    endpointID := util.RandomInt(1, 1000)
+
+    testcases := []struct {
+        name      string
+        url       string
+        buildStub func(mockWebhookSvc *mocksvc.MockWebhookSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            url:  fmt.Sprintf("/webhooks/%d/deliveries?limit=5&offset=10", endpointID),
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                arg := dto.ListWebhookDeliveriesDto{EndpointID: endpointID, UserID: userID, Limit: 5, Offset: 10}
+                mockWebhookSvc.EXPECT().ListDeliveries(gomock.Any(), arg).Times(1).Return([]dto.WebhookDeliveryDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "DefaultLimit",
+            url:  fmt.Sprintf("/webhooks/%d/deliveries", endpointID),
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                arg := dto.ListWebhookDeliveriesDto{EndpointID: endpointID, UserID: userID, Limit: 20}
+                mockWebhookSvc.EXPECT().ListDeliveries(gomock.Any(), arg).Times(1).Return([]dto.WebhookDeliveryDto{}, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "InvalidLimit",
+            url:  fmt.Sprintf("/webhooks/%d/deliveries?limit=1000", endpointID),
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().ListDeliveries(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusBadRequest, recorder.Code)
+            },
+        },
+        {
+            name: "EndpointNotFound",
+            url:  fmt.Sprintf("/webhooks/%d/deliveries", endpointID),
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().ListDeliveries(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.ErrWebhookEndpointNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockWebhookSvc := mocksvc.NewMockWebhookSvc(ctrl)
+            tc.buildStub(mockWebhookSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            webhookApi := api.NewWebhookResource(mockWebhookSvc)
+            webhookApi.RegisterRoutes(router)
+
+            request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    deliveryID := util.RandomInt(1, 1000)
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockWebhookSvc *mocksvc.MockWebhookSvc)
+        checkResp func(recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                res := dto.WebhookDeliveryDto{ID: deliveryID, Status: domain.WebhookDeliveryStatusDELIVERED, Attempts: 1}
+                mockWebhookSvc.EXPECT().Redeliver(gomock.Any(), userID, deliveryID).Times(1).Return(res, nil)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+
+                var res dto.WebhookDeliveryDto
+                require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+                require.Equal(t, domain.WebhookDeliveryStatusDELIVERED, res.Status)
+            },
+        },
+        {
+            name: "DeliveryNotFound",
+            buildStub: func(mockWebhookSvc *mocksvc.MockWebhookSvc) {
+                mockWebhookSvc.EXPECT().Redeliver(gomock.Any(), userID, deliveryID).Times(1).Return(dto.WebhookDeliveryDto{}, errors.ErrWebhookDeliveryNotFound)
+            },
+            checkResp: func(recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusNotFound, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            tokenMaker, _ := token.NewJWTMaker(constant.SymmetricKey)
+            mockWebhookSvc := mocksvc.NewMockWebhookSvc(ctrl)
+            tc.buildStub(mockWebhookSvc)
+
+            recorder := httptest.NewRecorder()
+            router := chi.NewRouter().With(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+
+            webhookApi := api.NewWebhookResource(mockWebhookSvc)
+            webhookApi.RegisterRoutes(router)
+
+            url := fmt.Sprintf("/webhooks/deliveries/%d/redeliver", deliveryID)
+            request, err := http.NewRequest(http.MethodPost, url, nil)
+            require.NoError(t, err)
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+
+            router.ServeHTTP(recorder, request)
+            tc.checkResp(recorder)
+        })
+    }
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+    "time"
+)
+
+type mockServices struct {
+    organizationSvc   *mocksvc.MockOrganizationSvc
+    bankAccountSvc    *mocksvc.MockBankAccountSvc
+    userSvc           *mocksvc.MockUserSvc
+    walletSvc         *mocksvc.MockWalletSvc
+    statementSvc      *mocksvc.MockStatementSvc
+    reconciliationSvc *mocksvc.MockReconciliationSvc
+}
+
+func TestRunCommand(t *testing.T) {
+    wallet := dto.WalletDto{ID: 7, Address: "walter_1234@my.wallet", Status: domain.WalletStatusINACTIVE, UserID: 3, Currency: "INR", Balance: 1500}
+    user := dto.UserDto{ID: 3, Username: "walter", Status: string(domain.UserStatusBLOCKED), FullName: "Walter", Email: "walter@email.com"}
+
+    testcases := []struct {
+        name      string
+        args      []string
+        format    string
+        buildStub func(m mockServices)
+        checkResp func(t *testing.T, out string, err error)
+    }{
+        {
+            name:   "CurrencyCreate",
+            args:   []string{"currency", "create", "-code", "gbp", "-org-account", "1234567910", "-org-ifsc", "HDFC0000076", "-escrow-account", "1234567911", "-escrow-ifsc", "HDFC0000076"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.organizationSvc.EXPECT().CreateCurrency(gomock.Any(), dto.CreateOrganizationCurrencyDto{
+                    Code:                "gbp",
+                    Fraction:            2,
+                    OrganizationAccount: dto.OrganizationBankAccountDto{AccountNo: "1234567910", Ifsc: "HDFC0000076"},
+                    EscrowAccount:       dto.OrganizationBankAccountDto{AccountNo: "1234567911", Ifsc: "HDFC0000076"},
+                }).Times(1).Return(dto.OrganizationCurrencyDto{
+                    Currency:           dto.CurrencyDto{Code: "GBP", Fraction: 2},
+                    OrganizationWallet: dto.WalletDto{ID: 10, Address: "grabgbp@my.wallet", Currency: "GBP"},
+                    EscrowWallet:       dto.WalletDto{ID: 11, Address: "escrowgbp@my.wallet", Currency: "GBP"},
+                }, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+                lines := strings.Split(strings.TrimSpace(out), "\n")
+                require.Len(t, lines, 3)
+                require.True(t, strings.HasPrefix(lines[0], "CURRENCY"))
+                require.Contains(t, lines[1], "grabgbp@my.wallet")
+                require.Contains(t, lines[2], "escrowgbp@my.wallet")
+            },
+        },
+        {
+            name:   "CurrencyCreateInvalid",
+            args:   []string{"currency", "create", "-code", "gbp"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.organizationSvc.EXPECT().CreateCurrency(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.Error(t, err)
+                require.Empty(t, out)
+            },
+        },
+        {
+            name:   "BankAccountVerify",
+            args:   []string{"bankaccount", "verify", "5"},
+            format: formatJSON,
+            buildStub: func(m mockServices) {
+                m.bankAccountSvc.EXPECT().VerificationSuccess(gomock.Any(), dto.BankAccountVerificationDto{BankAccountID: 5}).Times(1).
+                    Return(dto.BankAccountDto{ID: 5, Status: domain.BankAccountStatusVERIFIED}, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+
+                var res dto.BankAccountDto
+                require.NoError(t, json.Unmarshal([]byte(out), &res))
+                require.Equal(t, domain.BankAccountStatusVERIFIED, res.Status)
+            },
+        },
+        {
+            name:   "BankAccountFail",
+            args:   []string{"bankaccount", "fail", "5"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.bankAccountSvc.EXPECT().VerificationFailed(gomock.Any(), dto.BankAccountVerificationDto{BankAccountID: 5}).Times(1).
+                    Return(dto.BankAccountDto{ID: 5, Status: domain.BankAccountStatusVERIFICATIONFAILED}, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+                require.Contains(t, out, string(domain.BankAccountStatusVERIFICATIONFAILED))
+            },
+        },
+        {
+            name:   "BankAccountInvalidID",
+            args:   []string{"bankaccount", "verify", "five"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.bankAccountSvc.EXPECT().VerificationSuccess(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.EqualError(t, err, `invalid id "five"`)
+            },
+        },
+        {
+            name:   "UserBlock",
+            args:   []string{"user", "block", "walter"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.userSvc.EXPECT().BlockUser(gomock.Any(), "walter").Times(1).Return(user, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+                require.Contains(t, out, "BLOCKED")
+            },
+        },
+        {
+            name:   "UserNotFound",
+            args:   []string{"user", "block", "walter"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.userSvc.EXPECT().BlockUser(gomock.Any(), "walter").Times(1).Return(dto.UserDto{}, errors.ErrUserNotFound)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.EqualError(t, err, errors.ErrUserNotFound.Error())
+            },
+        },
+        {
+            name:   "WalletFreeze",
+            args:   []string{"wallet", "freeze", "7"},
+            format: formatJSON,
+            buildStub: func(m mockServices) {
+                m.walletSvc.EXPECT().FreezeWallet(gomock.Any(), int64(7)).Times(1).Return(wallet, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+
+                var res dto.WalletDto
+                require.NoError(t, json.Unmarshal([]byte(out), &res))
+                require.Equal(t, wallet, res)
+            },
+        },
+        {
+            name:   "WalletLedger",
+            args:   []string{"wallet", "ledger", "-month", "2021-06", "7"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.walletSvc.EXPECT().GetWalletById(gomock.Any(), int64(7)).Times(1).Return(wallet, nil)
+                m.statementSvc.EXPECT().GetStatement(gomock.Any(), dto.GetStatementDto{
+                    WalletID: 7,
+                    UserID:   3,
+                    Month:    time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+                }).Times(1).Return(dto.StatementDto{
+                    WalletID:       7,
+                    OpeningBalance: 1000,
+                    ClosingBalance: 1500,
+                    Lines:          []dto.StatementLineDto{{TransferID: 42, Counterparty: "shop@my.wallet", Amount: 500, Balance: 1500}},
+                }, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+                lines := strings.Split(strings.TrimSpace(out), "\n")
+                require.Len(t, lines, 4)
+                require.Contains(t, lines[1], "opening balance")
+                require.Contains(t, lines[2], "shop@my.wallet")
+                require.Contains(t, lines[3], "closing balance")
+            },
+        },
+        {
+            name:   "Reconcile",
+            args:   []string{"reconcile"},
+            format: formatTable,
+            buildStub: func(m mockServices) {
+                m.reconciliationSvc.EXPECT().Run(gomock.Any()).Times(1).Return(dto.ReconciliationReportDto{
+                    ID:               1,
+                    Status:           domain.ReconciliationStatusDISCREPANCIES,
+                    DiscrepancyCount: 1,
+                    Discrepancies: []dto.ReconciliationDiscrepancyDto{{
+                        Kind:     domain.DiscrepancyKindWALLETBALANCE,
+                        Currency: "INR",
+                        WalletID: 7,
+                        Detail:   "wallet walter_1234@my.wallet has balance 1500, its entries add up to 1000",
+                    }},
+                }, nil)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+                require.Contains(t, out, "its entries add up to 1000")
+            },
+        },
+        {
+            name:      "Unknown",
+            args:      []string{"wallet", "delete", "7"},
+            format:    formatTable,
+            buildStub: func(m mockServices) {},
+            checkResp: func(t *testing.T, out string, err error) {
+                require.Equal(t, errUsage, err)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            m := mockServices{
+                organizationSvc:   mocksvc.NewMockOrganizationSvc(ctrl),
+                bankAccountSvc:    mocksvc.NewMockBankAccountSvc(ctrl),
+                userSvc:           mocksvc.NewMockUserSvc(ctrl),
+                walletSvc:         mocksvc.NewMockWalletSvc(ctrl),
+                statementSvc:      mocksvc.NewMockStatementSvc(ctrl),
+                reconciliationSvc: mocksvc.NewMockReconciliationSvc(ctrl),
+            }
+            tc.buildStub(m)
+
+            svc := services{
+                organizationSvc:   m.organizationSvc,
+                bankAccountSvc:    m.bankAccountSvc,
+                userSvc:           m.userSvc,
+                walletSvc:         m.walletSvc,
+                statementSvc:      m.statementSvc,
+                reconciliationSvc: m.reconciliationSvc,
+            }
+
+            var out bytes.Buffer
+            err := runCommand(context.Background(), svc, &printer{w: &out, format: tc.format}, tc.args)
+            tc.checkResp(t, out.String(), err)
+        })
+    }
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/dto"
+    "io"
+    "text/tabwriter"
+    "time"
+)
+
+const (
+    formatTable = "table"
+    formatJSON  = "json"
+)
+
+// printer writes the results of the commands as aligned tables, or as indented JSON of the dtos for scripts.
+type printer struct {
+    w      io.Writer
+    format string
+}
+
+// print writes v as JSON, or the rows as a table, the first row being the header.
+func (p *printer) print(v interface{}, rows [][]interface{}) error {
+    if p.format == formatJSON {
+        enc := json.NewEncoder(p.w)
+        enc.SetIndent("", "  ")
+        return enc.Encode(v)
+    }
+
+    tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+    for _, row := range rows {
+        for i, cell := range row {
+            if i > 0 {
+                fmt.Fprint(tw, "\t")
+            }
+            if t, ok := cell.(time.Time); ok {
+                cell = t.UTC().Format(time.RFC3339)
+            }
+            fmt.Fprint(tw, cell)
+        }
+        fmt.Fprint(tw, "\n")
+    }
+    return tw.Flush()
+}
+
+var walletHeader = []interface{}{"ID", "ADDRESS", "STATUS", "USER ID", "CURRENCY", "BALANCE", "HELD BALANCE", "UPDATED AT"}
+
+func walletRow(wallet dto.WalletDto) []interface{} {
+    return []interface{}{wallet.ID, wallet.Address, wallet.Status, wallet.UserID, wallet.Currency, wallet.Balance, wallet.HeldBalance, wallet.UpdatedAt}
+}
+
+func (p *printer) wallet(wallet dto.WalletDto) error {
+    return p.print(wallet, [][]interface{}{walletHeader, walletRow(wallet)})
+}
+
+func (p *printer) organizationCurrency(res dto.OrganizationCurrencyDto) error {
+    return p.print(res, [][]interface{}{
+        append([]interface{}{"CURRENCY", "FRACTION"}, walletHeader...),
+        append([]interface{}{res.Currency.Code, res.Currency.Fraction}, walletRow(res.OrganizationWallet)...),
+        append([]interface{}{res.Currency.Code, res.Currency.Fraction}, walletRow(res.EscrowWallet)...),
+    })
+}
+
+func (p *printer) bankAccount(bankAccount dto.BankAccountDto) error {
+    return p.print(bankAccount, [][]interface{}{
+        {"ID", "ACCOUNT NO", "IFSC", "BANK", "STATUS", "USER ID", "CURRENCY", "UPDATED AT"},
+        {bankAccount.ID, bankAccount.AccountNo, bankAccount.Ifsc, bankAccount.BankName, bankAccount.Status, bankAccount.UserID,
+            bankAccount.Currency, bankAccount.UpdatedAt},
+    })
+}
+
+func (p *printer) user(user dto.UserDto) error {
+    return p.print(user, [][]interface{}{
+        {"ID", "USERNAME", "STATUS", "FULL NAME", "EMAIL"},
+        {user.ID, user.Username, user.Status, user.FullName, user.Email},
+    })
+}
+
+// statement prints the entries between the opening and the closing balance.
+func (p *printer) statement(statement dto.StatementDto) error {
+    rows := [][]interface{}{
+        {"DATE", "TRANSFER ID", "COUNTERPARTY", "AMOUNT", "BALANCE"},
+        {statement.From, "", "opening balance", "", statement.OpeningBalance},
+    }
+    for _, line := range statement.Lines {
+        rows = append(rows, []interface{}{line.Date, line.TransferID, line.Counterparty, line.Amount, line.Balance})
+    }
+    rows = append(rows, []interface{}{statement.To, "", "closing balance", "", statement.ClosingBalance})
+
+    return p.print(statement, rows)
+}
+
+// reconciliationReport prints the discrepancies found, or the summary of the report when there is none.
+func (p *printer) reconciliationReport(report dto.ReconciliationReportDto) error {
+    rows := [][]interface{}{
+        {"REPORT ID", "STATUS", "WALLETS", "TRANSFERS", "CURRENCIES", "DISCREPANCIES"},
+        {report.ID, report.Status, report.WalletsChecked, report.TransfersChecked, report.CurrenciesChecked, report.DiscrepancyCount},
+    }
+    if len(report.Discrepancies) > 0 {
+        rows = append(rows, []interface{}{}, []interface{}{"KIND", "CURRENCY", "WALLET ID", "TRANSFER ID", "EXPECTED", "ACTUAL", "DETAIL"})
+        for _, d := range report.Discrepancies {
+            rows = append(rows, []interface{}{d.Kind, d.Currency, d.WalletID, d.TransferID, d.Expected, d.Actual, d.Detail})
+        }
+    }
+
+    return p.print(report, rows)
+}
@@ -1,5 +1,11 @@
+DROP TABLE IF EXISTS payment_requests;
 DROP TABLE IF EXISTS entries;
 DROP TABLE IF EXISTS transfers;
 DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS bank_accounts;
 DROP TABLE IF EXISTS users;
-DROP TABLE IF EXISTS bank_accounts;
\ No newline at end of file
+DROP TABLE IF EXISTS currencies;
+DROP TYPE IF EXISTS payment_request_status;
+DROP TYPE IF EXISTS bank_account_status;
+DROP TYPE IF EXISTS wallet_status;
+DROP TYPE IF EXISTS user_status;
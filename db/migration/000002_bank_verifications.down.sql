@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bank_verifications;
+DROP TYPE IF EXISTS bank_verification_status;
@@ -0,0 +1,25 @@
+CREATE TYPE "bank_verification_status" AS ENUM (
+  'PENDING',
+  'INITIATED',
+  'SUCCESS',
+  'FAILED'
+);
+
+CREATE TABLE "bank_verifications"
+(
+    "id"               bigserial PRIMARY KEY,
+    "bank_account_id"  bigint                   NOT NULL,
+    "reference_id"     varchar                  NOT NULL DEFAULT '',
+    "status"           bank_verification_status NOT NULL,
+    "beneficiary_name" varchar                  NOT NULL DEFAULT '',
+    "name_match_score" bigint                   NOT NULL DEFAULT 0,
+    "created_at"       timestamp                NOT NULL DEFAULT 'now()',
+    "updated_at"       timestamp                NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "bank_verifications"
+    ADD FOREIGN KEY ("bank_account_id") REFERENCES "bank_accounts" ("id");
+
+CREATE INDEX ON "bank_verifications" ("bank_account_id");
+
+CREATE INDEX ON "bank_verifications" ("status");
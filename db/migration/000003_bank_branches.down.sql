@@ -0,0 +1,6 @@
+UPDATE bank_accounts
+SET ifsc = 'HDFC000076'
+WHERE ifsc = 'HDFC0000076'
+  AND account_no IN ('1234567890', '1234567891', '1234567892');
+
+DROP TABLE IF EXISTS bank_branches;
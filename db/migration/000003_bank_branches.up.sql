@@ -0,0 +1,20 @@
+CREATE TABLE "bank_branches"
+(
+    "ifsc"       varchar PRIMARY KEY,
+    "bank_name"  varchar   NOT NULL,
+    "branch"     varchar   NOT NULL,
+    "address"    varchar   NOT NULL DEFAULT '',
+    "city"       varchar   NOT NULL DEFAULT '',
+    "state"      varchar   NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL DEFAULT 'now()',
+    "updated_at" timestamp NOT NULL DEFAULT 'now()'
+);
+
+INSERT INTO bank_branches (ifsc, bank_name, branch, address, city, state)
+VALUES ('HDFC0000076', 'HDFC BANK', 'MUMBAI - FORT', 'MANEKJI WADIA BUILDING, NANIK MOTWANI MARG, FORT', 'MUMBAI',
+        'MAHARASHTRA');
+
+-- the organization bank accounts were seeded with a malformed IFSC
+UPDATE bank_accounts
+SET ifsc = 'HDFC0000076'
+WHERE ifsc = 'HDFC000076';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
+DROP TYPE IF EXISTS webhook_delivery_status;
+DROP TYPE IF EXISTS webhook_endpoint_status;
@@ -0,0 +1,50 @@
+CREATE TYPE "webhook_endpoint_status" AS ENUM (
+  'ACTIVE',
+  'DISABLED'
+);
+
+CREATE TYPE "webhook_delivery_status" AS ENUM (
+  'PENDING',
+  'DELIVERED',
+  'FAILED'
+);
+
+CREATE TABLE "webhook_endpoints"
+(
+    "id"          bigserial PRIMARY KEY,
+    "user_id"     bigint                  NOT NULL,
+    "url"         varchar                 NOT NULL,
+    "secret"      varchar                 NOT NULL,
+    "event_types" varchar[]               NOT NULL DEFAULT '{}',
+    "status"      webhook_endpoint_status NOT NULL,
+    "created_at"  timestamp               NOT NULL DEFAULT 'now()',
+    "updated_at"  timestamp               NOT NULL DEFAULT 'now()'
+);
+
+CREATE TABLE "webhook_deliveries"
+(
+    "id"                 bigserial PRIMARY KEY,
+    "endpoint_id"        bigint                  NOT NULL,
+    "event_id"           varchar                 NOT NULL,
+    "event_type"         varchar                 NOT NULL,
+    "payload"            jsonb                   NOT NULL,
+    "status"             webhook_delivery_status NOT NULL,
+    "attempts"           bigint                  NOT NULL DEFAULT 0,
+    "next_attempt_at"    timestamp               NOT NULL DEFAULT 'now()',
+    "last_response_code" bigint                  NOT NULL DEFAULT 0,
+    "last_error"         varchar                 NOT NULL DEFAULT '',
+    "created_at"         timestamp               NOT NULL DEFAULT 'now()',
+    "updated_at"         timestamp               NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "webhook_endpoints"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "webhook_deliveries"
+    ADD FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id");
+
+CREATE INDEX ON "webhook_endpoints" ("user_id");
+
+CREATE INDEX ON "webhook_deliveries" ("endpoint_id");
+
+CREATE INDEX ON "webhook_deliveries" ("status", "next_attempt_at");
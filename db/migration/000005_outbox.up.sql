@@ -0,0 +1,14 @@
+CREATE TABLE "outbox"
+(
+    "id"             bigserial PRIMARY KEY,
+    "aggregate_type" varchar   NOT NULL,
+    "aggregate_id"   bigint    NOT NULL,
+    "event_type"     varchar   NOT NULL,
+    "payload"        jsonb     NOT NULL,
+    "created_at"     timestamp NOT NULL DEFAULT 'now()',
+    "published_at"   timestamp
+);
+
+CREATE INDEX ON "outbox" ("aggregate_type", "aggregate_id");
+
+CREATE INDEX ON "outbox" ("id") WHERE "published_at" IS NULL;
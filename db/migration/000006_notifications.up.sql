@@ -0,0 +1,32 @@
+CREATE TYPE "notification_channel" AS ENUM (
+  'IN_APP',
+  'EMAIL'
+);
+
+CREATE TABLE "notifications"
+(
+    "id"         bigserial PRIMARY KEY,
+    "user_id"    bigint    NOT NULL,
+    "event_type" varchar   NOT NULL,
+    "title"      varchar   NOT NULL,
+    "body"       varchar   NOT NULL,
+    "read_at"    timestamp,
+    "created_at" timestamp NOT NULL DEFAULT 'now()'
+);
+
+CREATE TABLE "notification_preferences"
+(
+    "user_id"    bigint               NOT NULL,
+    "channel"    notification_channel NOT NULL,
+    "enabled"    boolean              NOT NULL,
+    "updated_at" timestamp            NOT NULL DEFAULT 'now()',
+    PRIMARY KEY ("user_id", "channel")
+);
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "notification_preferences"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX ON "notifications" ("user_id", "id");
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS entries_transfer_id_idx;
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_reports;
+ALTER TABLE "wallets" DROP COLUMN IF EXISTS "opening_balance";
+DROP TYPE IF EXISTS discrepancy_kind;
+DROP TYPE IF EXISTS reconciliation_status;
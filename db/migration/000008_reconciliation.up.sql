@@ -0,0 +1,52 @@
+CREATE TYPE "reconciliation_status" AS ENUM (
+  'OK',
+  'DISCREPANCIES'
+);
+
+CREATE TYPE "discrepancy_kind" AS ENUM (
+  'WALLET_BALANCE',
+  'TRANSFER_ENTRIES',
+  'CURRENCY_TOTAL'
+);
+
+-- opening_balance is the money a wallet was created with outside of any transfer, the
+-- organization wallets were seeded with their float before the ledger existed.
+ALTER TABLE "wallets"
+    ADD COLUMN "opening_balance" bigint NOT NULL DEFAULT 0;
+
+UPDATE "wallets" w
+SET "opening_balance" = w."balance" - COALESCE((SELECT SUM(e."amount") FROM "entries" e WHERE e."wallet_id" = w."id"), 0)
+WHERE w."id" = w."organization_wallet_id";
+
+CREATE TABLE "reconciliation_reports"
+(
+    "id"                 bigserial PRIMARY KEY,
+    "status"             reconciliation_status NOT NULL,
+    "wallets_checked"    bigint                NOT NULL,
+    "transfers_checked"  bigint                NOT NULL,
+    "currencies_checked" bigint                NOT NULL,
+    "discrepancy_count"  bigint                NOT NULL,
+    "started_at"         timestamp             NOT NULL,
+    "finished_at"        timestamp             NOT NULL DEFAULT 'now()'
+);
+
+CREATE TABLE "reconciliation_discrepancies"
+(
+    "id"          bigserial PRIMARY KEY,
+    "report_id"   bigint           NOT NULL,
+    "kind"        discrepancy_kind NOT NULL,
+    "currency"    varchar          NOT NULL,
+    "wallet_id"   bigint,
+    "transfer_id" bigint,
+    "expected"    bigint           NOT NULL,
+    "actual"      bigint           NOT NULL,
+    "detail"      varchar          NOT NULL DEFAULT '',
+    "created_at"  timestamp        NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "reconciliation_discrepancies"
+    ADD FOREIGN KEY ("report_id") REFERENCES "reconciliation_reports" ("id");
+
+CREATE INDEX ON "reconciliation_discrepancies" ("report_id");
+
+CREATE INDEX ON "entries" ("transfer_id");
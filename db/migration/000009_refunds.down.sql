@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS transfers_original_transfer_id_idx;
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS transfers_refunded_amount_check;
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS transfers_original_transfer_id_fkey;
+ALTER TABLE "transfers"
+    DROP COLUMN IF EXISTS "refund_status",
+    DROP COLUMN IF EXISTS "refunded_amount",
+    DROP COLUMN IF EXISTS "original_transfer_id",
+    DROP COLUMN IF EXISTS "kind";
+DROP TYPE IF EXISTS refund_status;
+DROP TYPE IF EXISTS transfer_kind;
@@ -0,0 +1,27 @@
+CREATE TYPE "transfer_kind" AS ENUM (
+  'PAYMENT',
+  'REFUND',
+  'REVERSAL'
+);
+
+CREATE TYPE "refund_status" AS ENUM (
+  'NONE',
+  'PARTIALLY_REFUNDED',
+  'REFUNDED'
+);
+
+-- a refund or a reversal is a transfer of its own going back from the receiver to the sender,
+-- original_transfer_id links it to the payment it gives back and refunded_amount sums them up.
+ALTER TABLE "transfers"
+    ADD COLUMN "kind"                 transfer_kind NOT NULL DEFAULT 'PAYMENT',
+    ADD COLUMN "original_transfer_id" bigint,
+    ADD COLUMN "refunded_amount"      bigint        NOT NULL DEFAULT 0,
+    ADD COLUMN "refund_status"        refund_status NOT NULL DEFAULT 'NONE';
+
+ALTER TABLE "transfers"
+    ADD FOREIGN KEY ("original_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfers"
+    ADD CONSTRAINT "transfers_refunded_amount_check" CHECK ("refunded_amount" >= 0 AND "refunded_amount" <= "amount");
+
+CREATE INDEX ON "transfers" ("original_transfer_id");
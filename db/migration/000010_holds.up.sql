@@ -0,0 +1,40 @@
+CREATE TYPE "hold_status" AS ENUM (
+  'AUTHORIZED',
+  'CAPTURED',
+  'VOIDED',
+  'EXPIRED'
+);
+
+-- held_balance is the money reserved by the authorized holds of the wallet, the available balance
+-- is balance - held_balance.
+ALTER TABLE "wallets"
+    ADD COLUMN "held_balance" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE "holds"
+(
+    "id"              bigserial PRIMARY KEY,
+    "wallet_id"       bigint      NOT NULL,
+    "to_wallet_id"    bigint      NOT NULL,
+    "amount"          bigint      NOT NULL,
+    "captured_amount" bigint      NOT NULL DEFAULT 0,
+    "status"          hold_status NOT NULL DEFAULT 'AUTHORIZED',
+    "transfer_id"     bigint,
+    "expires_at"      timestamp   NOT NULL,
+    "created_at"      timestamp   NOT NULL DEFAULT 'now()',
+    "updated_at"      timestamp   NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("to_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "holds"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "holds" ("wallet_id");
+
+CREATE INDEX ON "holds" ("to_wallet_id");
+
+CREATE INDEX ON "holds" ("expires_at") WHERE "status" = 'AUTHORIZED';
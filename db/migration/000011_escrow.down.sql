@@ -0,0 +1,24 @@
+-- the escrow wallets can't be deleted with the escrows once they moved money: their transfers and entries are part of
+-- the balances of the payers and payees, deleting them would rewrite the ledger. The migration refuses to run then,
+-- nothing is reverted and the schema is left dirty at this version.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM "escrows")
+        OR EXISTS (SELECT 1
+                   FROM "transfers" t
+                            JOIN "wallets" w ON w."id" IN (t."from_wallet_id", t."to_wallet_id")
+                   WHERE w."address" IN ('escrowinr@my.wallet', 'escrowusd@my.wallet', 'escroweur@my.wallet'))
+        OR EXISTS (SELECT 1
+                   FROM "entries" e
+                            JOIN "wallets" w ON w."id" = e."wallet_id"
+                   WHERE w."address" IN ('escrowinr@my.wallet', 'escrowusd@my.wallet', 'escroweur@my.wallet')) THEN
+        RAISE EXCEPTION 'escrow activity exists, the escrow wallets can''t be deleted';
+    END IF;
+END
+$$;
+
+DROP TABLE IF EXISTS escrows;
+DELETE FROM wallets WHERE address IN ('escrowinr@my.wallet', 'escrowusd@my.wallet', 'escroweur@my.wallet');
+DELETE FROM bank_accounts WHERE account_no IN ('1234567900', '1234567901', '1234567902') AND ifsc = 'HDFC0000076';
+DELETE FROM users WHERE username IN ('escrowINRUser', 'escrowUSDUser', 'escrowEURUser');
+DROP TYPE IF EXISTS escrow_status;
@@ -0,0 +1,82 @@
+CREATE TYPE "escrow_status" AS ENUM (
+  'FUNDED',
+  'DISPUTED',
+  'RELEASED',
+  'REFUNDED'
+);
+
+-- the escrow wallets hold the money of the funded escrows, one per currency like the organization wallets
+INSERT INTO users (username, hashed_password, status, full_name, email)
+VALUES ('escrowINRUser', '$2a$10$N.kqx5ktzyNSQcmc.XjUTOWGwiJuhpjrh9KbO0kM9U61q0tiw7aBy', 'ACTIVE',
+        'My Wallet Escrow Acct INR', 'mywalletescrowinr@gmail.com');
+
+INSERT INTO bank_accounts (account_no, ifsc, bank_name, currency, user_id, status)
+VALUES ('1234567900', 'HDFC0000076', 'HDFC BANK', 'INR', (SELECT id FROM users WHERE username = 'escrowINRUser'), 'VERIFIED');
+
+INSERT INTO wallets (address, status, user_id, bank_account_id, organization_wallet_id, balance, currency)
+VALUES ('escrowinr@my.wallet', 'ACTIVE', (SELECT id FROM users WHERE username = 'escrowINRUser'),
+        (SELECT id FROM bank_accounts WHERE account_no = '1234567900' AND ifsc = 'HDFC0000076'),
+        (SELECT id FROM wallets WHERE address = 'grabinr@my.wallet'), 0, 'INR');
+
+INSERT INTO users (username, hashed_password, status, full_name, email)
+VALUES ('escrowUSDUser', '$2a$10$N.kqx5ktzyNSQcmc.XjUTOWGwiJuhpjrh9KbO0kM9U61q0tiw7aBy', 'ACTIVE',
+        'My Wallet Escrow Acct USD', 'mywalletescrowusd@gmail.com');
+
+INSERT INTO bank_accounts (account_no, ifsc, bank_name, currency, user_id, status)
+VALUES ('1234567901', 'HDFC0000076', 'HDFC BANK', 'USD', (SELECT id FROM users WHERE username = 'escrowUSDUser'), 'VERIFIED');
+
+INSERT INTO wallets (address, status, user_id, bank_account_id, organization_wallet_id, balance, currency)
+VALUES ('escrowusd@my.wallet', 'ACTIVE', (SELECT id FROM users WHERE username = 'escrowUSDUser'),
+        (SELECT id FROM bank_accounts WHERE account_no = '1234567901' AND ifsc = 'HDFC0000076'),
+        (SELECT id FROM wallets WHERE address = 'grabusd@my.wallet'), 0, 'USD');
+
+INSERT INTO users (username, hashed_password, status, full_name, email)
+VALUES ('escrowEURUser', '$2a$10$N.kqx5ktzyNSQcmc.XjUTOWGwiJuhpjrh9KbO0kM9U61q0tiw7aBy', 'ACTIVE',
+        'My Wallet Escrow Acct EUR', 'mywalletescroweur@gmail.com');
+
+INSERT INTO bank_accounts (account_no, ifsc, bank_name, currency, user_id, status)
+VALUES ('1234567902', 'HDFC0000076', 'HDFC BANK', 'EUR', (SELECT id FROM users WHERE username = 'escrowEURUser'), 'VERIFIED');
+
+INSERT INTO wallets (address, status, user_id, bank_account_id, organization_wallet_id, balance, currency)
+VALUES ('escroweur@my.wallet', 'ACTIVE', (SELECT id FROM users WHERE username = 'escrowEURUser'),
+        (SELECT id FROM bank_accounts WHERE account_no = '1234567902' AND ifsc = 'HDFC0000076'),
+        (SELECT id FROM wallets WHERE address = 'grabeur@my.wallet'), 0, 'EUR');
+
+CREATE TABLE "escrows"
+(
+    "id"                     bigserial PRIMARY KEY,
+    "payer_wallet_id"        bigint        NOT NULL,
+    "payee_wallet_id"        bigint        NOT NULL,
+    "escrow_wallet_id"       bigint        NOT NULL,
+    "amount"                 bigint        NOT NULL,
+    "status"                 escrow_status NOT NULL DEFAULT 'FUNDED',
+    "funding_transfer_id"    bigint        NOT NULL,
+    "settlement_transfer_id" bigint,
+    "dispute_reason"         varchar       NOT NULL DEFAULT '',
+    "release_after"          timestamp     NOT NULL,
+    "created_at"             timestamp     NOT NULL DEFAULT 'now()',
+    "updated_at"             timestamp     NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "escrows"
+    ADD FOREIGN KEY ("payer_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "escrows"
+    ADD FOREIGN KEY ("payee_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "escrows"
+    ADD FOREIGN KEY ("escrow_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "escrows"
+    ADD FOREIGN KEY ("funding_transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "escrows"
+    ADD FOREIGN KEY ("settlement_transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX ON "escrows" ("payer_wallet_id");
+
+CREATE INDEX ON "escrows" ("payee_wallet_id");
+
+CREATE INDEX ON "escrows" ("status");
+
+CREATE INDEX ON "escrows" ("release_after") WHERE "status" = 'FUNDED';
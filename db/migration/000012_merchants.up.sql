@@ -0,0 +1,44 @@
+CREATE TYPE "api_key_status" AS ENUM (
+  'ACTIVE',
+  'REVOKED'
+);
+
+CREATE TABLE "merchants"
+(
+    "id"                   bigserial PRIMARY KEY,
+    "user_id"              bigint    NOT NULL,
+    "display_name"         varchar   NOT NULL,
+    "category"             varchar   NOT NULL,
+    "settlement_wallet_id" bigint    NOT NULL,
+    "callback_url"         varchar   NOT NULL DEFAULT '',
+    "created_at"           timestamp NOT NULL DEFAULT 'now()',
+    "updated_at"           timestamp NOT NULL DEFAULT 'now()'
+);
+
+CREATE TABLE "api_keys"
+(
+    "id"          bigserial PRIMARY KEY,
+    "merchant_id" bigint         NOT NULL,
+    "name"        varchar        NOT NULL,
+    "prefix"      varchar        NOT NULL,
+    "hashed_key"  varchar        NOT NULL,
+    "scopes"      varchar[]      NOT NULL DEFAULT '{}',
+    "status"      api_key_status NOT NULL,
+    "created_at"  timestamp      NOT NULL DEFAULT 'now()',
+    "updated_at"  timestamp      NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "merchants"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "merchants"
+    ADD FOREIGN KEY ("settlement_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "api_keys"
+    ADD FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id");
+
+CREATE UNIQUE INDEX ON "merchants" ("user_id");
+
+CREATE UNIQUE INDEX ON "api_keys" ("prefix");
+
+CREATE INDEX ON "api_keys" ("merchant_id");
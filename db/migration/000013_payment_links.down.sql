@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS checkout_sessions;
+DROP TABLE IF EXISTS payment_links;
+DROP TYPE IF EXISTS checkout_session_status;
+DROP TYPE IF EXISTS payment_link_status;
+DROP TYPE IF EXISTS payment_link_mode;
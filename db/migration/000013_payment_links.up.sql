@@ -0,0 +1,76 @@
+CREATE TYPE "payment_link_mode" AS ENUM (
+  'SINGLE_USE',
+  'MULTI_USE'
+);
+
+CREATE TYPE "payment_link_status" AS ENUM (
+  'ACTIVE',
+  'COMPLETED',
+  'DISABLED'
+);
+
+CREATE TYPE "checkout_session_status" AS ENUM (
+  'OPEN',
+  'COMPLETED'
+);
+
+CREATE TABLE "payment_links"
+(
+    "id"          bigserial PRIMARY KEY,
+    "merchant_id" bigint              NOT NULL,
+    "slug"        varchar             NOT NULL,
+    "description" varchar             NOT NULL DEFAULT '',
+    "amount"      bigint              NOT NULL DEFAULT 0,
+    "currency"    varchar             NOT NULL,
+    "mode"        payment_link_mode   NOT NULL,
+    "status"      payment_link_status NOT NULL,
+    "success_url" varchar             NOT NULL DEFAULT '',
+    "uses"        bigint              NOT NULL DEFAULT 0,
+    "expires_at"  timestamp,
+    "created_at"  timestamp           NOT NULL DEFAULT 'now()',
+    "updated_at"  timestamp           NOT NULL DEFAULT 'now()',
+    CHECK ("amount" >= 0)
+);
+
+CREATE TABLE "checkout_sessions"
+(
+    "id"              bigserial PRIMARY KEY,
+    "payment_link_id" bigint                  NOT NULL,
+    "user_id"         bigint                  NOT NULL,
+    "to_wallet_id"    bigint                  NOT NULL,
+    "amount"          bigint                  NOT NULL,
+    "status"          checkout_session_status NOT NULL,
+    "from_wallet_id"  bigint,
+    "transfer_id"     bigint,
+    "expires_at"      timestamp               NOT NULL,
+    "created_at"      timestamp               NOT NULL DEFAULT 'now()',
+    "updated_at"      timestamp               NOT NULL DEFAULT 'now()',
+    CHECK ("amount" > 0)
+);
+
+ALTER TABLE "payment_links"
+    ADD FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id");
+
+ALTER TABLE "payment_links"
+    ADD FOREIGN KEY ("currency") REFERENCES "currencies" ("code");
+
+ALTER TABLE "checkout_sessions"
+    ADD FOREIGN KEY ("payment_link_id") REFERENCES "payment_links" ("id");
+
+ALTER TABLE "checkout_sessions"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "checkout_sessions"
+    ADD FOREIGN KEY ("to_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "checkout_sessions"
+    ADD FOREIGN KEY ("from_wallet_id") REFERENCES "wallets" ("id");
+
+ALTER TABLE "checkout_sessions"
+    ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE UNIQUE INDEX ON "payment_links" ("slug");
+
+CREATE INDEX ON "payment_links" ("merchant_id");
+
+CREATE INDEX ON "checkout_sessions" ("payment_link_id");
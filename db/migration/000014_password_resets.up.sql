@@ -0,0 +1,16 @@
+CREATE TABLE "password_resets"
+(
+    "id"           bigserial PRIMARY KEY,
+    "user_id"      bigint    NOT NULL,
+    "hashed_token" varchar   NOT NULL,
+    "expires_at"   timestamp NOT NULL,
+    "used_at"      timestamp,
+    "created_at"   timestamp NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "password_resets"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE UNIQUE INDEX ON "password_resets" ("hashed_token");
+
+CREATE INDEX ON "password_resets" ("user_id");
@@ -0,0 +1,25 @@
+CREATE TABLE "totp_credentials"
+(
+    "user_id"        bigint PRIMARY KEY,
+    "secret"         varchar   NOT NULL,
+    "confirmed_at"   timestamp,
+    "last_used_step" bigint    NOT NULL DEFAULT 0,
+    "created_at"     timestamp NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "totp_credentials"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE TABLE "totp_recovery_codes"
+(
+    "id"          bigserial PRIMARY KEY,
+    "user_id"     bigint    NOT NULL,
+    "hashed_code" varchar   NOT NULL,
+    "used_at"     timestamp,
+    "created_at"  timestamp NOT NULL DEFAULT 'now()'
+);
+
+ALTER TABLE "totp_recovery_codes"
+    ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE UNIQUE INDEX ON "totp_recovery_codes" ("user_id", "hashed_code");
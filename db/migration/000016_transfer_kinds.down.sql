@@ -0,0 +1,16 @@
+ALTER TYPE "transfer_kind" RENAME TO "transfer_kind_new";
+
+CREATE TYPE "transfer_kind" AS ENUM (
+  'PAYMENT',
+  'REFUND',
+  'REVERSAL'
+);
+
+ALTER TABLE "transfers"
+    ALTER COLUMN "kind" DROP DEFAULT,
+    ALTER COLUMN "kind" TYPE transfer_kind USING (CASE
+        WHEN "kind" IN ('ESCROW', 'CAPTURE', 'CHECKOUT') THEN 'PAYMENT'
+        ELSE "kind"::text END)::transfer_kind,
+    ALTER COLUMN "kind" SET DEFAULT 'PAYMENT';
+
+DROP TYPE "transfer_kind_new";
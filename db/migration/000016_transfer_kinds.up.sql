@@ -0,0 +1,32 @@
+-- the transfers of escrows, holds and checkouts get kinds of their own so they can't be refunded as payments.
+-- The enum is recreated rather than extended, a value added by ALTER TYPE can't be used in the same transaction.
+ALTER TYPE "transfer_kind" RENAME TO "transfer_kind_old";
+
+CREATE TYPE "transfer_kind" AS ENUM (
+  'PAYMENT',
+  'REFUND',
+  'REVERSAL',
+  'ESCROW',
+  'CAPTURE',
+  'CHECKOUT'
+);
+
+ALTER TABLE "transfers"
+    ALTER COLUMN "kind" DROP DEFAULT,
+    ALTER COLUMN "kind" TYPE transfer_kind USING "kind"::text::transfer_kind,
+    ALTER COLUMN "kind" SET DEFAULT 'PAYMENT';
+
+DROP TYPE "transfer_kind_old";
+
+UPDATE "transfers"
+SET "kind" = 'ESCROW'
+WHERE "id" IN (SELECT "funding_transfer_id" FROM "escrows")
+   OR "id" IN (SELECT "settlement_transfer_id" FROM "escrows" WHERE "settlement_transfer_id" IS NOT NULL);
+
+UPDATE "transfers"
+SET "kind" = 'CAPTURE'
+WHERE "id" IN (SELECT "transfer_id" FROM "holds" WHERE "transfer_id" IS NOT NULL);
+
+UPDATE "transfers"
+SET "kind" = 'CHECKOUT'
+WHERE "id" IN (SELECT "transfer_id" FROM "checkout_sessions" WHERE "transfer_id" IS NOT NULL);
@@ -0,0 +1,3 @@
+ALTER TABLE "totp_credentials"
+    DROP COLUMN "locked_until",
+    DROP COLUMN "failed_attempts";
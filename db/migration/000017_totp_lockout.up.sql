@@ -0,0 +1,4 @@
+-- the invalid two factor codes of a user are counted, the user is locked out of them for a while past a limit.
+ALTER TABLE "totp_credentials"
+    ADD COLUMN "failed_attempts" int NOT NULL DEFAULT 0,
+    ADD COLUMN "locked_until"    timestamp;
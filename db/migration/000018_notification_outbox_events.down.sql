@@ -0,0 +1,2 @@
+ALTER TABLE "notifications"
+    DROP COLUMN "outbox_event_id";
@@ -0,0 +1,8 @@
+-- the in-app notifications of an outbox event are stored once per user, the relay delivers the events again on failure.
+ALTER TABLE "notifications"
+    ADD COLUMN "outbox_event_id" bigint;
+
+ALTER TABLE "notifications"
+    ADD FOREIGN KEY ("outbox_event_id") REFERENCES "outbox" ("id");
+
+CREATE UNIQUE INDEX ON "notifications" ("outbox_event_id", "user_id");
@@ -0,0 +1,3 @@
+-- the webhooks are emitted from the outbox events, which the relay hands out at least once: an endpoint gets
+-- one delivery per event.
+CREATE UNIQUE INDEX ON "webhook_deliveries" ("endpoint_id", "event_id");
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS outbox_status_id_idx;
+
+ALTER TABLE "outbox"
+    DROP COLUMN "status",
+    DROP COLUMN "attempts",
+    DROP COLUMN "next_attempt_at",
+    DROP COLUMN "last_error";
+
+DROP TYPE IF EXISTS outbox_status;
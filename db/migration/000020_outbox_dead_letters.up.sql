@@ -0,0 +1,19 @@
+CREATE TYPE "outbox_status" AS ENUM (
+  'PENDING',
+  'PUBLISHED',
+  'DEAD'
+);
+
+-- an event whose handlers keep failing is published again with a backoff, then set aside as DEAD
+-- so it no longer holds back the later events of its aggregate. The admins list and retry them.
+ALTER TABLE "outbox"
+    ADD COLUMN "status"          outbox_status NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN "attempts"        bigint        NOT NULL DEFAULT 0,
+    ADD COLUMN "next_attempt_at" timestamp     NOT NULL DEFAULT 'now()',
+    ADD COLUMN "last_error"      varchar       NOT NULL DEFAULT '';
+
+UPDATE "outbox"
+SET "status" = 'PUBLISHED'
+WHERE "published_at" IS NOT NULL;
+
+CREATE INDEX ON "outbox" ("status", "id");
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS escrows_status_next_release_at_idx;
+
+ALTER TABLE "escrows"
+    DROP COLUMN "release_attempts",
+    DROP COLUMN "next_release_at",
+    DROP COLUMN "release_error";
@@ -0,0 +1,8 @@
+-- a due escrow failing to release, e.g. to a frozen wallet, is tried again after a backoff rather than at every
+-- run, so it doesn't keep the escrows behind it from their release.
+ALTER TABLE "escrows"
+    ADD COLUMN "release_attempts" bigint    NOT NULL DEFAULT 0,
+    ADD COLUMN "next_release_at"  timestamp NOT NULL DEFAULT 'now()',
+    ADD COLUMN "release_error"    varchar   NOT NULL DEFAULT '';
+
+CREATE INDEX ON "escrows" ("status", "next_release_at");
@@ -0,0 +1,4 @@
+UPDATE "wallets" w
+SET "opening_balance" = w."balance" - COALESCE((SELECT SUM(e."amount") FROM "entries" e WHERE e."wallet_id" = w."id"), 0)
+WHERE w."address" IN ('grabinr@my.wallet', 'grabusd@my.wallet', 'grabeur@my.wallet')
+  AND w."id" = w."organization_wallet_id";
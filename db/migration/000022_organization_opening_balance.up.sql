@@ -0,0 +1,6 @@
+-- 000008 derived the opening balance of the organization wallets from their balance and entries, which hides a
+-- balance that already drifted from its entries. It is the float they were seeded with in 000001.
+UPDATE "wallets"
+SET "opening_balance" = 100000
+WHERE "address" IN ('grabinr@my.wallet', 'grabusd@my.wallet', 'grabeur@my.wallet')
+  AND "id" = "organization_wallet_id";
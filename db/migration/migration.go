@@ -0,0 +1,271 @@
+// Package migration embeds the schema migrations of db/migration in the binary and applies them. The version is
+// kept in schema_migrations the way golang-migrate keeps it, so a database migrated by either can be migrated
+// by the other.
+package migration
+
+import (
+    "context"
+    "database/sql"
+    "embed"
+    "errors"
+    "fmt"
+    "io/fs"
+    "path"
+    "regexp"
+    "sort"
+    "strconv"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+var (
+    ErrDirty     = errors.New("the last migration failed, fix the schema by hand and set schema_migrations.dirty to false")
+    ErrNoVersion = errors.New("no migration applied")
+)
+
+// lockID is the key of the advisory lock serializing the migrations of the instances starting together.
+const lockID = 4138257104
+
+// Migration is one version of the schema, applied by its up script and reverted by its down script.
+type Migration struct {
+    Version int64
+    Name    string
+    Up      string
+    Down    string
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migrations returns the embedded migrations ordered by version.
+func Migrations() ([]Migration, error) {
+    return load(files)
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+    entries, err := fs.ReadDir(fsys, ".")
+    if err != nil {
+        return nil, err
+    }
+
+    byVersion := make(map[int64]*Migration)
+    for _, e := range entries {
+        m := fileName.FindStringSubmatch(e.Name())
+        if m == nil {
+            continue
+        }
+
+        version, err := strconv.ParseInt(m[1], 10, 64)
+        if err != nil {
+            return nil, err
+        }
+        b, err := fs.ReadFile(fsys, path.Join(".", e.Name()))
+        if err != nil {
+            return nil, err
+        }
+
+        mig, ok := byVersion[version]
+        if !ok {
+            mig = &Migration{Version: version, Name: m[2]}
+            byVersion[version] = mig
+        }
+        if m[3] == "up" {
+            mig.Up = string(b)
+        } else {
+            mig.Down = string(b)
+        }
+    }
+
+    res := make([]Migration, 0, len(byVersion))
+    for _, mig := range byVersion {
+        if mig.Up == "" || mig.Down == "" {
+            return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", mig.Version, mig.Name)
+        }
+        res = append(res, *mig)
+    }
+    sort.Slice(res, func(i, j int) bool {
+        return res[i].Version < res[j].Version
+    })
+    return res, nil
+}
+
+// LatestVersion is the version of the last embedded migration, the version the service runs on.
+func LatestVersion() int64 {
+    migrations, err := Migrations()
+    if err != nil || len(migrations) == 0 {
+        return 0
+    }
+    return migrations[len(migrations)-1].Version
+}
+
+// Migrator applies the migrations to db.
+type Migrator struct {
+    db         *sql.DB
+    migrations []Migration
+}
+
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+    migrations, err := Migrations()
+    if err != nil {
+        return nil, err
+    }
+    return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Status is the version of the schema and the migrations left to apply.
+type Status struct {
+    Version int64
+    Dirty   bool
+    Pending []Migration
+}
+
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+    var res Status
+
+    c, err := m.db.Conn(ctx)
+    if err != nil {
+        return res, err
+    }
+    defer c.Close()
+
+    if err := ensureVersionTable(ctx, c); err != nil {
+        return res, err
+    }
+
+    res.Version, res.Dirty, err = version(ctx, c)
+    if err != nil && err != ErrNoVersion {
+        return res, err
+    }
+    for _, mig := range m.migrations {
+        if mig.Version > res.Version {
+            res.Pending = append(res.Pending, mig)
+        }
+    }
+    return res, nil
+}
+
+// Up applies up to n migrations not applied yet, all of them when n is 0. It returns the migrations applied.
+func (m *Migrator) Up(ctx context.Context, n int) ([]Migration, error) {
+    var applied []Migration
+
+    err := m.locked(ctx, func(c *sql.Conn, current int64) error {
+        for _, mig := range m.migrations {
+            if mig.Version <= current {
+                continue
+            }
+            if n > 0 && len(applied) == n {
+                return nil
+            }
+
+            if err := apply(ctx, c, mig.Version, mig.Up, mig.Version); err != nil {
+                return fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+            }
+            applied = append(applied, mig)
+        }
+        return nil
+    })
+    return applied, err
+}
+
+// Down reverts the last n migrations applied, all of them when n is 0. It returns the migrations reverted.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+    var reverted []Migration
+
+    err := m.locked(ctx, func(c *sql.Conn, current int64) error {
+        for i := len(m.migrations) - 1; i >= 0; i-- {
+            mig := m.migrations[i]
+            if mig.Version > current {
+                continue
+            }
+            if n > 0 && len(reverted) == n {
+                return nil
+            }
+
+            // the version left is the one of the previous migration, none below the first one
+            previous := int64(-1)
+            if i > 0 {
+                previous = m.migrations[i-1].Version
+            }
+            if err := apply(ctx, c, mig.Version, mig.Down, previous); err != nil {
+                return fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+            }
+            reverted = append(reverted, mig)
+        }
+        return nil
+    })
+    return reverted, err
+}
+
+// locked runs fn holding the advisory lock, with the current version of a clean schema.
+func (m *Migrator) locked(ctx context.Context, fn func(c *sql.Conn, current int64) error) error {
+    c, err := m.db.Conn(ctx)
+    if err != nil {
+        return err
+    }
+    defer c.Close()
+
+    if _, err := c.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+        return err
+    }
+    defer c.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+    if err := ensureVersionTable(ctx, c); err != nil {
+        return err
+    }
+
+    current, dirty, err := version(ctx, c)
+    if err != nil && err != ErrNoVersion {
+        return err
+    }
+    if dirty {
+        return ErrDirty
+    }
+
+    return fn(c, current)
+}
+
+// apply runs script, with the schema marked dirty at version while it runs so that a failure half way is seen by
+// the next run. The schema is then clean at next, or has no version when next is -1. Like golang-migrate, the script
+// runs outside a transaction, a script needing one opens it.
+func apply(ctx context.Context, c *sql.Conn, version int64, script string, next int64) error {
+    if err := setVersion(ctx, c, version, true); err != nil {
+        return err
+    }
+    if _, err := c.ExecContext(ctx, script); err != nil {
+        return err
+    }
+    return setVersion(ctx, c, next, false)
+}
+
+func ensureVersionTable(ctx context.Context, c *sql.Conn) error {
+    _, err := c.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+    return err
+}
+
+func version(ctx context.Context, c *sql.Conn) (int64, bool, error) {
+    var v int64
+    var dirty bool
+    err := c.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&v, &dirty)
+    if err == sql.ErrNoRows {
+        return 0, false, ErrNoVersion
+    }
+    return v, dirty, err
+}
+
+func setVersion(ctx context.Context, c *sql.Conn, v int64, dirty bool) error {
+    tx, err := c.BeginTx(ctx, nil)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback()
+
+    if _, err := tx.ExecContext(ctx, `TRUNCATE schema_migrations`); err != nil {
+        return err
+    }
+    if v >= 0 {
+        if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, v, dirty); err != nil {
+            return err
+        }
+    }
+    return tx.Commit()
+}
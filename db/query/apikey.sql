@@ -0,0 +1,30 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (merchant_id,
+                      name,
+                      prefix,
+                      hashed_key,
+                      scopes,
+                      status)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at;
+
+-- name: GetAPIKey :one
+SELECT id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+FROM api_keys
+WHERE id = $1 LIMIT 1;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+FROM api_keys
+WHERE prefix = $1 LIMIT 1;
+
+-- name: ListAPIKeys :many
+SELECT id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+FROM api_keys
+WHERE merchant_id = $1
+ORDER BY id;
+
+-- name: UpdateAPIKeyStatus :one
+UPDATE api_keys
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1 RETURNING id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at;
@@ -20,6 +20,20 @@ set Status = $1
 where id = $2
 RETURNING *;
 
+-- name: CompleteBankAccountVerification :one
+UPDATE bank_accounts
+set Status = $1
+where id = $2
+  AND status = 'IN_VERIFICATION'
+RETURNING *;
+
+-- name: ActivateBankAccountWallet :one
+UPDATE wallets
+set Status = 'ACTIVE'
+where bank_account_id = $1
+  AND status = 'INACTIVE'
+RETURNING *;
+
 -- name: ListBankAccounts :many
 SELECT *
 FROM bank_accounts
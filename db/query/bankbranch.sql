@@ -0,0 +1,21 @@
+-- name: UpsertBankBranch :one
+INSERT INTO bank_branches (ifsc,
+                           bank_name,
+                           branch,
+                           address,
+                           city,
+                           state)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (ifsc) DO UPDATE SET bank_name  = $2,
+                                 branch     = $3,
+                                 address    = $4,
+                                 city       = $5,
+                                 state      = $6,
+                                 updated_at = now()
+RETURNING *;
+
+-- name: GetBankBranch :one
+SELECT *
+FROM bank_branches
+WHERE ifsc = $1
+LIMIT 1;
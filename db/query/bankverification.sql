@@ -0,0 +1,36 @@
+-- name: CreateBankVerification :one
+INSERT INTO bank_verifications (bank_account_id,
+                                status)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetBankVerification :one
+SELECT *
+FROM bank_verifications
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListBankVerificationsByStatus :many
+SELECT *
+FROM bank_verifications
+WHERE status = $1
+ORDER BY id
+LIMIT $2;
+
+-- name: UpdateBankVerification :one
+UPDATE bank_verifications
+set reference_id     = $1,
+    status           = $2,
+    beneficiary_name = $3,
+    name_match_score = $4,
+    updated_at       = now()
+where id = $5
+  AND status IN ('PENDING', 'INITIATED')
+RETURNING *;
+
+-- name: CloseBankVerifications :exec
+UPDATE bank_verifications
+set status     = $2,
+    updated_at = now()
+where bank_account_id = $1
+  AND status IN ('PENDING', 'INITIATED');
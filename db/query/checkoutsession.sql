@@ -0,0 +1,27 @@
+-- name: CreateCheckoutSession :one
+INSERT INTO checkout_sessions (payment_link_id,
+                               user_id,
+                               to_wallet_id,
+                               amount,
+                               status,
+                               expires_at)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at;
+
+-- name: GetCheckoutSession :one
+SELECT id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at
+FROM checkout_sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCheckoutSessionForUpdate :one
+SELECT id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at
+FROM checkout_sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateCheckoutSession :one
+UPDATE checkout_sessions
+SET status         = $2,
+    from_wallet_id = $3,
+    transfer_id    = $4,
+    updated_at     = now()
+WHERE id = $1 RETURNING id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at;
@@ -0,0 +1,52 @@
+-- name: CreateEscrow :one
+INSERT INTO escrows (payer_wallet_id,
+                     payee_wallet_id,
+                     escrow_wallet_id,
+                     amount,
+                     funding_transfer_id,
+                     release_after)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error;
+
+-- name: GetEscrow :one
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE id = $1 LIMIT 1;
+
+-- name: GetEscrowForUpdate :one
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateEscrowStatus :one
+UPDATE escrows
+SET status                 = $2,
+    settlement_transfer_id = $3,
+    dispute_reason         = $4,
+    updated_at             = now()
+WHERE id = $1 RETURNING id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error;
+
+-- name: ListEscrows :many
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE status = $1
+ORDER BY id LIMIT $2
+OFFSET $3;
+
+-- name: ListDueEscrows :many
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE status = 'FUNDED'
+  AND release_after <= now()
+  AND next_release_at <= now()
+ORDER BY release_after LIMIT $1;
+
+-- name: FailEscrowRelease :one
+UPDATE escrows
+SET release_attempts = release_attempts + 1,
+    release_error    = $2,
+    next_release_at  = $3,
+    updated_at       = now()
+WHERE id = $1
+  AND status = 'FUNDED'
+RETURNING *;
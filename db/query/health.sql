@@ -0,0 +1,13 @@
+-- name: GetSchemaVersion :one
+SELECT version, dirty
+FROM schema_migrations
+LIMIT 1;
+
+-- name: ListCurrenciesWithoutOrganizationWallet :many
+SELECT c.code
+FROM currencies c
+WHERE NOT EXISTS(SELECT 1
+                 FROM wallets w
+                 WHERE w.address = 'grab' || lower(c.code) || '@my.wallet'
+                   AND w.currency = c.code)
+ORDER BY c.code;
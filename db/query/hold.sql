@@ -0,0 +1,40 @@
+-- name: CreateHold :one
+INSERT INTO holds (wallet_id,
+                   to_wallet_id,
+                   amount,
+                   expires_at)
+VALUES ($1, $2, $3, $4) RETURNING id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at;
+
+-- name: GetHold :one
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE id = $1 LIMIT 1;
+
+-- name: GetHoldForUpdate :one
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateHold :one
+UPDATE holds
+SET status          = $2,
+    captured_amount = $3,
+    transfer_id     = $4,
+    updated_at      = now()
+WHERE id = $1 RETURNING id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at;
+
+-- name: ListHolds :many
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE wallet_id = $1
+   OR to_wallet_id = $1
+ORDER BY id DESC LIMIT $2
+OFFSET $3;
+
+-- name: ListExpiredHolds :many
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE status = 'AUTHORIZED'
+  AND expires_at <= now()
+ORDER BY expires_at LIMIT $1;
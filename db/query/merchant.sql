@@ -0,0 +1,26 @@
+-- name: CreateMerchant :one
+INSERT INTO merchants (user_id,
+                       display_name,
+                       category,
+                       settlement_wallet_id,
+                       callback_url)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at;
+
+-- name: GetMerchant :one
+SELECT id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at
+FROM merchants
+WHERE id = $1 LIMIT 1;
+
+-- name: GetMerchantByUserID :one
+SELECT id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at
+FROM merchants
+WHERE user_id = $1 LIMIT 1;
+
+-- name: UpdateMerchant :one
+UPDATE merchants
+SET display_name         = $2,
+    category             = $3,
+    settlement_wallet_id = $4,
+    callback_url         = $5,
+    updated_at           = now()
+WHERE id = $1 RETURNING id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at;
@@ -0,0 +1,44 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (user_id,
+                           event_type,
+                           title,
+                           body,
+                           outbox_event_id)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (outbox_event_id, user_id) DO NOTHING
+RETURNING *;
+
+-- name: GetNotification :one
+SELECT *
+FROM notifications
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListNotifications :many
+SELECT *
+FROM notifications
+WHERE user_id = $1
+  AND (NOT $2::boolean OR read_at IS NULL)
+ORDER BY id DESC
+LIMIT $3 OFFSET $4;
+
+-- name: UpdateNotificationRead :one
+UPDATE notifications
+SET read_at = CASE WHEN $1::boolean THEN COALESCE(read_at, now()) END
+WHERE id = $2
+RETURNING *;
+
+-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (user_id,
+                                      channel,
+                                      enabled)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, channel) DO UPDATE SET enabled    = $3,
+                                             updated_at = now()
+RETURNING *;
+
+-- name: ListNotificationPreferences :many
+SELECT *
+FROM notification_preferences
+WHERE user_id = $1
+ORDER BY channel;
@@ -0,0 +1,14 @@
+-- name: CreateOrganizationWallet :one
+WITH next AS (SELECT nextval(pg_get_serial_sequence('wallets', 'id')) AS id)
+INSERT
+INTO wallets (id,
+              address,
+              status,
+              user_id,
+              bank_account_id,
+              organization_wallet_id,
+              balance,
+              opening_balance,
+              currency)
+SELECT next.id, $1, $2, $3, $4, next.id, 0, 0, $5
+FROM next RETURNING *;
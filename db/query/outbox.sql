@@ -0,0 +1,55 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox (aggregate_type,
+                    aggregate_id,
+                    event_type,
+                    payload)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListUnpublishedOutboxEvents :many
+SELECT *
+FROM outbox
+WHERE status = 'PENDING'
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkOutboxEventPublished :one
+UPDATE outbox
+SET status       = 'PUBLISHED',
+    published_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: TryLockOutboxRelay :one
+SELECT pg_try_advisory_xact_lock($1);
+
+-- name: FailOutboxEvent :one
+UPDATE outbox
+SET attempts        = attempts + 1,
+    last_error      = $2,
+    next_attempt_at = $3,
+    status          = CASE WHEN attempts + 1 >= $4 THEN 'DEAD'::outbox_status ELSE status END
+WHERE id = $1
+RETURNING *;
+
+-- name: ListDeadOutboxEvents :many
+SELECT *
+FROM outbox
+WHERE status = 'DEAD'
+ORDER BY id
+LIMIT $1 OFFSET $2;
+
+-- name: CountDeadOutboxEvents :one
+SELECT count(*)
+FROM outbox
+WHERE status = 'DEAD';
+
+-- name: RetryOutboxEvent :one
+UPDATE outbox
+SET status          = 'PENDING',
+    attempts        = 0,
+    next_attempt_at = now(),
+    last_error      = ''
+WHERE id = $1
+  AND status = 'DEAD'
+RETURNING *;
@@ -0,0 +1,22 @@
+-- name: CreatePasswordReset :one
+INSERT INTO password_resets (user_id,
+                             hashed_token,
+                             expires_at)
+VALUES ($1, $2, $3) RETURNING id, user_id, hashed_token, expires_at, used_at, created_at;
+
+-- name: GetPasswordResetByHashedToken :one
+SELECT id, user_id, hashed_token, expires_at, used_at, created_at
+FROM password_resets
+WHERE hashed_token = $1 LIMIT 1;
+
+-- name: UsePasswordReset :one
+UPDATE password_resets
+SET used_at = $2
+WHERE id = $1
+  AND used_at IS NULL RETURNING id, user_id, hashed_token, expires_at, used_at, created_at;
+
+-- name: UsePasswordResetsOfUser :exec
+UPDATE password_resets
+SET used_at = $2
+WHERE user_id = $1
+  AND used_at IS NULL;
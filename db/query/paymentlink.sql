@@ -0,0 +1,47 @@
+-- name: CreatePaymentLink :one
+INSERT INTO payment_links (merchant_id,
+                           slug,
+                           description,
+                           amount,
+                           currency,
+                           mode,
+                           status,
+                           success_url,
+                           expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at;
+
+-- name: GetPaymentLink :one
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPaymentLinkBySlug :one
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE slug = $1 LIMIT 1;
+
+-- name: GetPaymentLinkForUpdate :one
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPaymentLinks :many
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE merchant_id = $1
+ORDER BY id DESC LIMIT $2
+OFFSET $3;
+
+-- name: UpdatePaymentLinkStatus :one
+UPDATE payment_links
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1 RETURNING id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at;
+
+-- name: AddPaymentLinkUse :one
+UPDATE payment_links
+SET uses       = uses + 1,
+    status     = CASE WHEN mode = 'SINGLE_USE' THEN 'COMPLETED'::payment_link_status ELSE status END,
+    updated_at = now()
+WHERE id = $1 RETURNING id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at;
@@ -0,0 +1,73 @@
+-- name: ListWalletBalanceMismatches :many
+SELECT w.id, w.address, w.currency, w.balance, (w.opening_balance + COALESCE(SUM(e.amount), 0))::bigint AS ledger_balance
+FROM wallets w
+         LEFT JOIN entries e ON e.wallet_id = w.id
+GROUP BY w.id
+HAVING w.balance <> w.opening_balance + COALESCE(SUM(e.amount), 0)
+ORDER BY w.id;
+
+-- name: ListUnbalancedTransfers :many
+SELECT t.id, t.from_wallet_id, t.to_wallet_id, t.amount, w.currency, COUNT(e.id) AS entry_count, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM transfers t
+         JOIN wallets w ON w.id = t.from_wallet_id
+         LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id, w.currency
+HAVING COUNT(e.id) <> 2
+    OR COUNT(e.id) FILTER (WHERE e.wallet_id = t.from_wallet_id AND e.amount = -t.amount) <> 1
+    OR COUNT(e.id) FILTER (WHERE e.wallet_id = t.to_wallet_id AND e.amount = t.amount) <> 1
+ORDER BY t.id;
+
+-- name: ListCurrencyTotals :many
+SELECT w.currency,
+       COUNT(*)                                                                        AS wallet_count,
+       COALESCE(SUM(w.balance) FILTER (WHERE w.id <> w.organization_wallet_id), 0)::bigint AS user_balance,
+       COALESCE(SUM(w.balance) FILTER (WHERE w.id = w.organization_wallet_id), 0)::bigint  AS organization_balance,
+       COALESCE(SUM(w.opening_balance), 0)::bigint                                        AS opening_balance,
+       (SELECT COALESCE(SUM(e.amount), 0)
+        FROM entries e
+                 JOIN wallets ew ON ew.id = e.wallet_id
+        WHERE ew.currency = w.currency)::bigint                                          AS entries_total
+FROM wallets w
+GROUP BY w.currency
+ORDER BY w.currency;
+
+-- name: CountTransfers :one
+SELECT COUNT(*)
+FROM transfers;
+
+-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (status,
+                                    wallets_checked,
+                                    transfers_checked,
+                                    currencies_checked,
+                                    discrepancy_count,
+                                    started_at)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, status, wallets_checked, transfers_checked, currencies_checked, discrepancy_count, started_at, finished_at;
+
+-- name: CreateReconciliationDiscrepancy :one
+INSERT INTO reconciliation_discrepancies (report_id,
+                                          kind,
+                                          currency,
+                                          wallet_id,
+                                          transfer_id,
+                                          expected,
+                                          actual,
+                                          detail)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, report_id, kind, currency, wallet_id, transfer_id, expected, actual, detail, created_at;
+
+-- name: GetReconciliationReport :one
+SELECT id, status, wallets_checked, transfers_checked, currencies_checked, discrepancy_count, started_at, finished_at
+FROM reconciliation_reports
+WHERE id = $1 LIMIT 1;
+
+-- name: ListReconciliationReports :many
+SELECT id, status, wallets_checked, transfers_checked, currencies_checked, discrepancy_count, started_at, finished_at
+FROM reconciliation_reports
+ORDER BY id DESC LIMIT $1
+OFFSET $2;
+
+-- name: ListReconciliationDiscrepancies :many
+SELECT id, report_id, kind, currency, wallet_id, transfer_id, expected, actual, detail, created_at
+FROM reconciliation_discrepancies
+WHERE report_id = $1
+ORDER BY id;
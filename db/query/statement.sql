@@ -0,0 +1,17 @@
+-- name: GetWalletBalanceAt :one
+SELECT (w.opening_balance + COALESCE((SELECT SUM(e.amount)
+                                      FROM entries e
+                                      WHERE e.wallet_id = w.id
+                                        AND e.created_at < $2), 0))::bigint
+FROM wallets w
+WHERE w.id = $1;
+
+-- name: ListStatementEntries :many
+SELECT e.id, e.transfer_id, e.amount, e.created_at, cw.address AS counterparty
+FROM entries e
+         JOIN transfers t ON t.id = e.transfer_id
+         JOIN wallets cw ON cw.id = CASE WHEN t.from_wallet_id = e.wallet_id THEN t.to_wallet_id ELSE t.from_wallet_id END
+WHERE e.wallet_id = $1
+  AND e.created_at >= $2
+  AND e.created_at < $3
+ORDER BY e.created_at, e.id;
@@ -0,0 +1,65 @@
+-- name: EnrollTOTP :one
+INSERT INTO totp_credentials (user_id,
+                              secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+    SET secret          = EXCLUDED.secret,
+        last_used_step  = 0,
+        failed_attempts = 0,
+        locked_until    = NULL,
+        created_at      = now()
+WHERE totp_credentials.confirmed_at IS NULL RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at;
+
+-- name: GetTOTPCredential :one
+SELECT user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+FROM totp_credentials
+WHERE user_id = $1 LIMIT 1;
+
+-- name: UseTOTPStep :one
+UPDATE totp_credentials
+SET last_used_step  = $2,
+    failed_attempts = 0
+WHERE user_id = $1
+  AND last_used_step < $2 RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at;
+
+-- name: RecordTOTPFailure :one
+UPDATE totp_credentials
+SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+    locked_until    = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+WHERE user_id = $1 RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at;
+
+-- name: ConfirmTOTPCredential :one
+UPDATE totp_credentials
+SET confirmed_at   = $2,
+    last_used_step = $3
+WHERE user_id = $1
+  AND confirmed_at IS NULL RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at;
+
+-- name: DeleteTOTPCredential :exec
+DELETE
+FROM totp_credentials
+WHERE user_id = $1;
+
+-- name: CreateTOTPRecoveryCode :exec
+INSERT INTO totp_recovery_codes (user_id,
+                                 hashed_code)
+VALUES ($1, $2);
+
+-- name: UseTOTPRecoveryCode :one
+WITH used AS (
+    UPDATE totp_recovery_codes
+        SET used_at = $3
+        WHERE user_id = $1
+            AND hashed_code = $2
+            AND used_at IS NULL RETURNING id, user_id, hashed_code, used_at, created_at),
+     reset AS (
+         UPDATE totp_credentials
+             SET failed_attempts = 0
+             WHERE user_id IN (SELECT user_id FROM used))
+SELECT id, user_id, hashed_code, used_at, created_at
+FROM used;
+
+-- name: DeleteTOTPRecoveryCodes :exec
+DELETE
+FROM totp_recovery_codes
+WHERE user_id = $1;
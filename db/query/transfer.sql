@@ -1,8 +1,10 @@
 -- name: CreateTransfer :one
 INSERT INTO transfers (from_wallet_id,
                        to_wallet_id,
-                       amount)
-VALUES ($1, $2, $3)
+                       amount,
+                       kind,
+                       original_transfer_id)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetTransfer :one
@@ -11,6 +13,22 @@ FROM transfers
 WHERE id = $1
 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT *
+FROM transfers
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddTransferRefundedAmount :one
+UPDATE transfers
+SET refunded_amount = refunded_amount + sqlc.arg(amount),
+    refund_status   = CASE
+                          WHEN refunded_amount + sqlc.arg(amount) >= amount THEN 'REFUNDED'::refund_status
+                          ELSE 'PARTIALLY_REFUNDED'::refund_status END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: ListTransfers :many
 SELECT *
 FROM transfers
@@ -17,4 +17,18 @@ LIMIT 1;
 UPDATE users
 set Status = $1
 where id = $2
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetUserByEmail :one
+SELECT *
+from users
+where email = $1
+LIMIT 1;
+
+-- name: UpdateUserPassword :one
+UPDATE users
+set hashed_password     = $1,
+    password_changed_at = $2,
+    updated_at          = now()
+where id = $3
+RETURNING *;
@@ -38,14 +38,15 @@ LIMIT 1 FOR NO KEY
     UPDATE;
 
 -- name: CreateWallet :one
-INSERT INTO wallets (name,
-                     address,
+INSERT INTO wallets (address,
                      status,
                      user_id,
                      bank_account_id,
+                     organization_wallet_id,
                      balance,
+                     opening_balance,
                      currency)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+VALUES ($1, $2, $3, $4, $5, $6, $6, $7)
 RETURNING *;
 
 -- name: ListWallets :many
@@ -61,6 +62,12 @@ SET balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
+-- name: AddWalletHeldBalance :one
+UPDATE wallets
+SET held_balance = held_balance + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: UpdateWalletStatus :one
 UPDATE wallets
 set Status = $1
@@ -0,0 +1,80 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (user_id,
+                               url,
+                               secret,
+                               event_types,
+                               status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT *
+FROM webhook_endpoints
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListWebhookEndpoints :many
+SELECT *
+FROM webhook_endpoints
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: ListActiveWebhookEndpointsForEvent :many
+SELECT *
+FROM webhook_endpoints
+WHERE user_id = $1
+  AND status = 'ACTIVE'
+  AND (cardinality(event_types) = 0 OR $2 = ANY (event_types))
+ORDER BY id;
+
+-- name: UpdateWebhookEndpointStatus :one
+UPDATE webhook_endpoints
+set status     = $1,
+    updated_at = now()
+where id = $2
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (endpoint_id,
+                                event_id,
+                                event_type,
+                                payload,
+                                status)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (endpoint_id, event_id) DO NOTHING
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT *
+FROM webhook_deliveries
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT *
+FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = now() + make_interval(secs => $2)
+WHERE id IN (SELECT id
+             FROM webhook_deliveries
+             WHERE status = 'PENDING'
+               AND next_attempt_at <= now()
+             ORDER BY next_attempt_at
+             LIMIT $1 FOR UPDATE SKIP LOCKED)
+RETURNING *;
+
+-- name: UpdateWebhookDelivery :one
+UPDATE webhook_deliveries
+set status             = $1,
+    attempts           = $2,
+    next_attempt_at    = $3,
+    last_response_code = $4,
+    last_error         = $5,
+    updated_at         = now()
+where id = $6
+RETURNING *;
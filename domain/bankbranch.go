@@ -0,0 +1,16 @@
+package domain
+
+import (
+    "time"
+)
+
+type BankBranch struct {
+    Ifsc      string    `json:"ifsc"`
+    BankName  string    `json:"bank_name"`
+    Branch    string    `json:"branch"`
+    Address   string    `json:"address"`
+    City      string    `json:"city"`
+    State     string    `json:"state"`
+    CreatedAt time.Time `json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+}
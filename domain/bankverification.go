@@ -0,0 +1,38 @@
+package domain
+
+import (
+    "fmt"
+    "time"
+)
+
+type BankVerificationStatus string
+
+const (
+    BankVerificationStatusPENDING   BankVerificationStatus = "PENDING"
+    BankVerificationStatusINITIATED BankVerificationStatus = "INITIATED"
+    BankVerificationStatusSUCCESS   BankVerificationStatus = "SUCCESS"
+    BankVerificationStatusFAILED    BankVerificationStatus = "FAILED"
+)
+
+type BankVerification struct {
+    ID              int64                  `json:"id"`
+    BankAccountID   int64                  `json:"bank_account_id"`
+    ReferenceID     string                 `json:"reference_id"`
+    Status          BankVerificationStatus `json:"status"`
+    BeneficiaryName string                 `json:"beneficiary_name"`
+    NameMatchScore  int64                  `json:"name_match_score"`
+    CreatedAt       time.Time              `json:"created_at"`
+    UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+func (e *BankVerificationStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = BankVerificationStatus(s)
+    case string:
+        *e = BankVerificationStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for BankVerificationStatus: %T", src)
+    }
+    return nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+type EscrowStatus string
+
+const (
+    EscrowStatusFUNDED   EscrowStatus = "FUNDED"
+    EscrowStatusDISPUTED EscrowStatus = "DISPUTED"
+    EscrowStatusRELEASED EscrowStatus = "RELEASED"
+    EscrowStatusREFUNDED EscrowStatus = "REFUNDED"
+)
+
+// Escrow keeps Amount of the payer in the escrow wallet of the currency. It is released to the payee when
+// the payer confirms or after ReleaseAfter, a dispute stops the release until ops resolve it by releasing
+// or refunding the money. A release that failed is tried again at NextReleaseAt, ReleaseError is its last error.
+type Escrow struct {
+    ID                   int64         `json:"id"`
+    PayerWalletID        int64         `json:"payer_wallet_id"`
+    PayeeWalletID        int64         `json:"payee_wallet_id"`
+    EscrowWalletID       int64         `json:"escrow_wallet_id"`
+    Amount               int64         `json:"amount"`
+    Status               EscrowStatus  `json:"status"`
+    FundingTransferID    int64         `json:"funding_transfer_id"`
+    SettlementTransferID sql.NullInt64 `json:"settlement_transfer_id"`
+    DisputeReason        string        `json:"dispute_reason"`
+    ReleaseAfter         time.Time     `json:"release_after"`
+    CreatedAt            time.Time     `json:"created_at"`
+    UpdatedAt            time.Time     `json:"updated_at"`
+    ReleaseAttempts      int64         `json:"release_attempts"`
+    NextReleaseAt        time.Time     `json:"next_release_at"`
+    ReleaseError         string        `json:"release_error"`
+}
+
+func (e *EscrowStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = EscrowStatus(s)
+    case string:
+        *e = EscrowStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for EscrowStatus: %T", src)
+    }
+    return nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+type EventType string
+
+const (
+    EventTypeTransferCompleted             EventType = "transfer.completed"
+    EventTypePaymentRequestStatusChanged   EventType = "payment_request.status_changed"
+    EventTypeBankAccountVerified           EventType = "bank_account.verified"
+    EventTypeBankAccountVerificationFailed EventType = "bank_account.verification_failed"
+    EventTypeTransferCreated               EventType = "transfer.created"
+    EventTypeWalletCreated                 EventType = "wallet.created"
+    EventTypeWalletActivated               EventType = "wallet.activated"
+    EventTypeTransferReceived              EventType = "transfer.received"
+    EventTypePaymentRequestCreated         EventType = "payment_request.created"
+    EventTypeCheckoutCompleted             EventType = "checkout.completed"
+)
+
+type AggregateType string
+
+const (
+    AggregateTypeWALLET          AggregateType = "wallet"
+    AggregateTypeBANKACCOUNT     AggregateType = "bank_account"
+    AggregateTypePAYMENTREQUEST  AggregateType = "payment_request"
+    AggregateTypeCHECKOUTSESSION AggregateType = "checkout_session"
+)
+
+type OutboxStatus string
+
+const (
+    OutboxStatusPENDING   OutboxStatus = "PENDING"
+    OutboxStatusPUBLISHED OutboxStatus = "PUBLISHED"
+    // OutboxStatusDEAD is an event that failed to publish too many times, the relay skips it
+    OutboxStatusDEAD OutboxStatus = "DEAD"
+)
+
+// OutboxEvent is a domain event recorded in the same transaction as the change it describes,
+// PublishedAt is set once the relay has handed it to the publisher.
+type OutboxEvent struct {
+    ID            int64         `json:"id"`
+    AggregateType AggregateType `json:"aggregate_type"`
+    AggregateID   int64         `json:"aggregate_id"`
+    EventType     EventType     `json:"event_type"`
+    Payload       string        `json:"payload"`
+    CreatedAt     time.Time     `json:"created_at"`
+    PublishedAt   sql.NullTime  `json:"published_at"`
+    Status        OutboxStatus  `json:"status"`
+    Attempts      int64         `json:"attempts"`
+    NextAttemptAt time.Time     `json:"next_attempt_at"`
+    LastError     string        `json:"last_error"`
+}
+
+func (e *OutboxStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = OutboxStatus(s)
+    case string:
+        *e = OutboxStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for OutboxStatus: %T", src)
+    }
+    return nil
+}
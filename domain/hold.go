@@ -0,0 +1,44 @@
+package domain
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+type HoldStatus string
+
+const (
+    HoldStatusAUTHORIZED HoldStatus = "AUTHORIZED"
+    HoldStatusCAPTURED   HoldStatus = "CAPTURED"
+    HoldStatusVOIDED     HoldStatus = "VOIDED"
+    HoldStatusEXPIRED    HoldStatus = "EXPIRED"
+)
+
+// Hold reserves Amount of the wallet for the merchant's wallet ToWalletID until ExpiresAt. While it is
+// AUTHORIZED the amount counts in the wallet's held balance, capturing it transfers up to Amount to the
+// merchant and releases the rest.
+type Hold struct {
+    ID             int64         `json:"id"`
+    WalletID       int64         `json:"wallet_id"`
+    ToWalletID     int64         `json:"to_wallet_id"`
+    Amount         int64         `json:"amount"`
+    CapturedAmount int64         `json:"captured_amount"`
+    Status         HoldStatus    `json:"status"`
+    TransferID     sql.NullInt64 `json:"transfer_id"`
+    ExpiresAt      time.Time     `json:"expires_at"`
+    CreatedAt      time.Time     `json:"created_at"`
+    UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+func (e *HoldStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = HoldStatus(s)
+    case string:
+        *e = HoldStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for HoldStatus: %T", src)
+    }
+    return nil
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+    "fmt"
+    "strings"
+    "time"
+)
+
+type APIKeyStatus string
+
+const (
+    APIKeyStatusACTIVE  APIKeyStatus = "ACTIVE"
+    APIKeyStatusREVOKED APIKeyStatus = "REVOKED"
+)
+
+// Merchant is the business profile of a user, the payments to the merchant settle in the settlement wallet.
+type Merchant struct {
+    ID                 int64     `json:"id"`
+    UserID             int64     `json:"user_id"`
+    DisplayName        string    `json:"display_name"`
+    Category           string    `json:"category"`
+    SettlementWalletID int64     `json:"settlement_wallet_id"`
+    CallbackUrl        string    `json:"callback_url"`
+    CreatedAt          time.Time `json:"created_at"`
+    UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// APIKey authenticates the servers of a merchant. Only the hash of the key is stored, the
+// prefix is kept in clear to look the key up.
+type APIKey struct {
+    ID         int64        `json:"id"`
+    MerchantID int64        `json:"merchant_id"`
+    Name       string       `json:"name"`
+    Prefix     string       `json:"prefix"`
+    HashedKey  string       `json:"hashed_key"`
+    Scopes     []string     `json:"scopes"`
+    Status     APIKeyStatus `json:"status"`
+    CreatedAt  time.Time    `json:"created_at"`
+    UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// HasScope check if the key grants the "<resource>:<read|write>" scope, a write scope grants
+// the read scope of the resource too.
+func (k APIKey) HasScope(scope string) bool {
+    for _, s := range k.Scopes {
+        if s == scope {
+            return true
+        }
+
+        if strings.HasSuffix(scope, ":read") && s == strings.TrimSuffix(scope, ":read")+":write" {
+            return true
+        }
+    }
+
+    return false
+}
+
+func (e *APIKeyStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = APIKeyStatus(s)
+    case string:
+        *e = APIKeyStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for APIKeyStatus: %T", src)
+    }
+    return nil
+}
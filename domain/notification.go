@@ -0,0 +1,48 @@
+package domain
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+type NotificationChannel string
+
+const (
+    NotificationChannelINAPP NotificationChannel = "IN_APP"
+    NotificationChannelEMAIL NotificationChannel = "EMAIL"
+)
+
+func (e *NotificationChannel) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = NotificationChannel(s)
+    case string:
+        *e = NotificationChannel(s)
+    default:
+        return fmt.Errorf("unsupported scan type for NotificationChannel: %T", src)
+    }
+    return nil
+}
+
+// Notification is an entry of the user's in-app inbox, it is unread until ReadAt is set.
+type Notification struct {
+    ID        int64        `json:"id"`
+    UserID    int64        `json:"user_id"`
+    EventType EventType    `json:"event_type"`
+    Title     string       `json:"title"`
+    Body      string       `json:"body"`
+    ReadAt    sql.NullTime `json:"read_at"`
+    CreatedAt time.Time    `json:"created_at"`
+    // OutboxEventID is the outbox event notified, the notifications sent by the services directly have none
+    OutboxEventID sql.NullInt64 `json:"outbox_event_id"`
+}
+
+// NotificationPreference turns a channel on or off for the user, channels without a
+// preference are enabled.
+type NotificationPreference struct {
+    UserID    int64               `json:"user_id"`
+    Channel   NotificationChannel `json:"channel"`
+    Enabled   bool                `json:"enabled"`
+    UpdatedAt time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+    "database/sql"
+    "time"
+)
+
+// PasswordReset lets the user reset a forgotten password once, before ExpiresAt. Only the hash of its token is
+// stored, the token itself is mailed to the user.
+type PasswordReset struct {
+    ID          int64        `json:"id"`
+    UserID      int64        `json:"user_id"`
+    HashedToken string       `json:"hashed_token"`
+    ExpiresAt   time.Time    `json:"expires_at"`
+    UsedAt      sql.NullTime `json:"used_at"`
+    CreatedAt   time.Time    `json:"created_at"`
+}
+
+func (p PasswordReset) IsExpired(now time.Time) bool {
+    return !now.Before(p.ExpiresAt)
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+type PaymentLinkMode string
+
+const (
+    PaymentLinkModeSINGLEUSE PaymentLinkMode = "SINGLE_USE"
+    PaymentLinkModeMULTIUSE  PaymentLinkMode = "MULTI_USE"
+)
+
+type PaymentLinkStatus string
+
+const (
+    PaymentLinkStatusACTIVE    PaymentLinkStatus = "ACTIVE"
+    PaymentLinkStatusCOMPLETED PaymentLinkStatus = "COMPLETED"
+    PaymentLinkStatusDISABLED  PaymentLinkStatus = "DISABLED"
+)
+
+type CheckoutSessionStatus string
+
+const (
+    CheckoutSessionStatusOPEN      CheckoutSessionStatus = "OPEN"
+    CheckoutSessionStatusCOMPLETED CheckoutSessionStatus = "COMPLETED"
+)
+
+// PaymentLink is a hosted page at /pay/{slug} collecting payments for the merchant. An Amount of 0
+// lets the payer choose the amount. A SINGLE_USE link is COMPLETED by its first payment.
+type PaymentLink struct {
+    ID          int64             `json:"id"`
+    MerchantID  int64             `json:"merchant_id"`
+    Slug        string            `json:"slug"`
+    Description string            `json:"description"`
+    Amount      int64             `json:"amount"`
+    Currency    string            `json:"currency"`
+    Mode        PaymentLinkMode   `json:"mode"`
+    Status      PaymentLinkStatus `json:"status"`
+    SuccessUrl  string            `json:"success_url"`
+    Uses        int64             `json:"uses"`
+    ExpiresAt   sql.NullTime      `json:"expires_at"`
+    CreatedAt   time.Time         `json:"created_at"`
+    UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+func (l PaymentLink) IsOpenAmount() bool {
+    return l.Amount == 0
+}
+
+func (l PaymentLink) IsExpired(now time.Time) bool {
+    return l.ExpiresAt.Valid && now.After(l.ExpiresAt.Time)
+}
+
+// CheckoutSession is a payment of a payer through a payment link, it pays Amount to the merchant's
+// settlement wallet ToWalletID when the payer completes it before ExpiresAt.
+type CheckoutSession struct {
+    ID            int64                 `json:"id"`
+    PaymentLinkID int64                 `json:"payment_link_id"`
+    UserID        int64                 `json:"user_id"`
+    ToWalletID    int64                 `json:"to_wallet_id"`
+    Amount        int64                 `json:"amount"`
+    Status        CheckoutSessionStatus `json:"status"`
+    FromWalletID  sql.NullInt64         `json:"from_wallet_id"`
+    TransferID    sql.NullInt64         `json:"transfer_id"`
+    ExpiresAt     time.Time             `json:"expires_at"`
+    CreatedAt     time.Time             `json:"created_at"`
+    UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+func (e *PaymentLinkMode) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = PaymentLinkMode(s)
+    case string:
+        *e = PaymentLinkMode(s)
+    default:
+        return fmt.Errorf("unsupported scan type for PaymentLinkMode: %T", src)
+    }
+    return nil
+}
+
+func (e *PaymentLinkStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = PaymentLinkStatus(s)
+    case string:
+        *e = PaymentLinkStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for PaymentLinkStatus: %T", src)
+    }
+    return nil
+}
+
+func (e *CheckoutSessionStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = CheckoutSessionStatus(s)
+    case string:
+        *e = CheckoutSessionStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for CheckoutSessionStatus: %T", src)
+    }
+    return nil
+}
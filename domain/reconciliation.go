@@ -0,0 +1,73 @@
+package domain
+
+import (
+    "database/sql"
+    "fmt"
+    "time"
+)
+
+type ReconciliationStatus string
+
+const (
+    ReconciliationStatusOK            ReconciliationStatus = "OK"
+    ReconciliationStatusDISCREPANCIES ReconciliationStatus = "DISCREPANCIES"
+)
+
+type DiscrepancyKind string
+
+const (
+    // DiscrepancyKindWALLETBALANCE is a wallet whose balance differs from its opening balance plus its entries
+    DiscrepancyKindWALLETBALANCE DiscrepancyKind = "WALLET_BALANCE"
+    // DiscrepancyKindTRANSFERENTRIES is a transfer without exactly one debit and one credit entry of its amount
+    DiscrepancyKindTRANSFERENTRIES DiscrepancyKind = "TRANSFER_ENTRIES"
+    // DiscrepancyKindCURRENCYTOTAL is a currency whose money was created or destroyed outside of the ledger
+    DiscrepancyKindCURRENCYTOTAL DiscrepancyKind = "CURRENCY_TOTAL"
+)
+
+type ReconciliationReport struct {
+    ID                int64                `json:"id"`
+    Status            ReconciliationStatus `json:"status"`
+    WalletsChecked    int64                `json:"wallets_checked"`
+    TransfersChecked  int64                `json:"transfers_checked"`
+    CurrenciesChecked int64                `json:"currencies_checked"`
+    DiscrepancyCount  int64                `json:"discrepancy_count"`
+    StartedAt         time.Time            `json:"started_at"`
+    FinishedAt        time.Time            `json:"finished_at"`
+}
+
+type ReconciliationDiscrepancy struct {
+    ID         int64           `json:"id"`
+    ReportID   int64           `json:"report_id"`
+    Kind       DiscrepancyKind `json:"kind"`
+    Currency   string          `json:"currency"`
+    WalletID   sql.NullInt64   `json:"wallet_id"`
+    TransferID sql.NullInt64   `json:"transfer_id"`
+    Expected   int64           `json:"expected"`
+    Actual     int64           `json:"actual"`
+    Detail     string          `json:"detail"`
+    CreatedAt  time.Time       `json:"created_at"`
+}
+
+func (e *ReconciliationStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = ReconciliationStatus(s)
+    case string:
+        *e = ReconciliationStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for ReconciliationStatus: %T", src)
+    }
+    return nil
+}
+
+func (e *DiscrepancyKind) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = DiscrepancyKind(s)
+    case string:
+        *e = DiscrepancyKind(s)
+    default:
+        return fmt.Errorf("unsupported scan type for DiscrepancyKind: %T", src)
+    }
+    return nil
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+    "database/sql"
+    "time"
+)
+
+// TOTPCredential is the TOTP secret of a user, shared with an authenticator app. It is pending until the user
+// confirms it with a first code, then the login and the payments above the step-up threshold of their currency need
+// a code of it.
+// LastUsedStep is the time step of the last code used, a code is not accepted twice. FailedAttempts counts the
+// invalid codes since the last valid one, too many lock the user out of the codes until LockedUntil.
+type TOTPCredential struct {
+    UserID         int64        `json:"user_id"`
+    Secret         string       `json:"secret"`
+    ConfirmedAt    sql.NullTime `json:"confirmed_at"`
+    LastUsedStep   int64        `json:"last_used_step"`
+    FailedAttempts int32        `json:"failed_attempts"`
+    LockedUntil    sql.NullTime `json:"locked_until"`
+    CreatedAt      time.Time    `json:"created_at"`
+}
+
+func (t TOTPCredential) IsConfirmed() bool {
+    return t.ConfirmedAt.Valid
+}
+
+// IsLocked tells whether the user is locked out of the codes at now.
+func (t TOTPCredential) IsLocked(now time.Time) bool {
+    return t.LockedUntil.Valid && now.Before(t.LockedUntil.Time)
+}
+
+// TOTPRecoveryCode stands in for a TOTP code once, when the device of the user is lost. Only its hash is stored.
+type TOTPRecoveryCode struct {
+    ID         int64        `json:"id"`
+    UserID     int64        `json:"user_id"`
+    HashedCode string       `json:"hashed_code"`
+    UsedAt     sql.NullTime `json:"used_at"`
+    CreatedAt  time.Time    `json:"created_at"`
+}
@@ -1,13 +1,71 @@
 package domain
 
 import (
+    "database/sql"
+    "fmt"
     "time"
 )
 
+type TransferKind string
+
+// the ESCROW, CAPTURE and CHECKOUT transfers settle an escrow, a hold or a checkout session, they are refunded
+// through it and never on their own.
+const (
+    TransferKindPAYMENT  TransferKind = "PAYMENT"
+    TransferKindREFUND   TransferKind = "REFUND"
+    TransferKindREVERSAL TransferKind = "REVERSAL"
+    TransferKindESCROW   TransferKind = "ESCROW"
+    TransferKindCAPTURE  TransferKind = "CAPTURE"
+    TransferKindCHECKOUT TransferKind = "CHECKOUT"
+)
+
+type RefundStatus string
+
+const (
+    RefundStatusNONE              RefundStatus = "NONE"
+    RefundStatusPARTIALLYREFUNDED RefundStatus = "PARTIALLY_REFUNDED"
+    RefundStatusREFUNDED          RefundStatus = "REFUNDED"
+)
+
+// Transfer moves Amount from one wallet to another. A refund or a reversal is a transfer going back,
+// linked to the payment through OriginalTransferID, RefundedAmount sums up what went back so far.
 type Transfer struct {
-    ID           int64     `json:"id"`
-    FromWalletID int64     `json:"from_wallet_id"`
-    ToWalletID   int64     `json:"to_wallet_id"`
-    Amount       int64     `json:"amount"`
-    CreatedAt    time.Time `json:"created_at"`
+    ID                 int64         `json:"id"`
+    FromWalletID       int64         `json:"from_wallet_id"`
+    ToWalletID         int64         `json:"to_wallet_id"`
+    Amount             int64         `json:"amount"`
+    Kind               TransferKind  `json:"kind"`
+    OriginalTransferID sql.NullInt64 `json:"original_transfer_id"`
+    RefundedAmount     int64         `json:"refunded_amount"`
+    RefundStatus       RefundStatus  `json:"refund_status"`
+    CreatedAt          time.Time     `json:"created_at"`
+}
+
+// RefundableAmount is what is left to refund on the transfer.
+func (e *Transfer) RefundableAmount() int64 {
+    return e.Amount - e.RefundedAmount
+}
+
+func (e *TransferKind) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = TransferKind(s)
+    case string:
+        *e = TransferKind(s)
+    default:
+        return fmt.Errorf("unsupported scan type for TransferKind: %T", src)
+    }
+    return nil
+}
+
+func (e *RefundStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = RefundStatus(s)
+    case string:
+        *e = RefundStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for RefundStatus: %T", src)
+    }
+    return nil
 }
@@ -12,6 +12,13 @@ const (
     UserStatusBLOCKED UserStatus = "BLOCKED"
 )
 
+type UserRole string
+
+const (
+    UserRoleUSER  UserRole = "USER"
+    UserRoleADMIN UserRole = "ADMIN"
+)
+
 type User struct {
     ID                int64      `json:"id"`
     Username          string     `json:"username"`
@@ -19,11 +26,18 @@ type User struct {
     Status            UserStatus `json:"status"`
     FullName          string     `json:"full_name"`
     Email             string     `json:"email"`
+    Role              UserRole   `json:"role"`
     PasswordChangedAt time.Time  `json:"password_changed_at"`
     CreatedAt         time.Time  `json:"created_at"`
     UpdatedAt         time.Time  `json:"updated_at"`
 }
 
+// TokenRevoked tells whether a token issued at issuedAt predates the last change of the password, which revokes the
+// tokens issued before it.
+func (u User) TokenRevoked(issuedAt time.Time) bool {
+    return issuedAt.Before(u.PasswordChangedAt)
+}
+
 func (e *UserStatus) Scan(src interface{}) error {
     switch s := src.(type) {
     case []byte:
@@ -35,3 +49,15 @@ func (e *UserStatus) Scan(src interface{}) error {
     }
     return nil
 }
+
+func (e *UserRole) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = UserRole(s)
+    case string:
+        *e = UserRole(s)
+    default:
+        return fmt.Errorf("unsupported scan type for UserRole: %T", src)
+    }
+    return nil
+}
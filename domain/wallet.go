@@ -20,13 +20,20 @@ type Wallet struct {
     BankAccountID        int64        `json:"bank_account_id"`
     OrganizationWalletID int64        `json:"organization_wallet_id"`
     Balance              int64        `json:"balance"`
+    HeldBalance          int64        `json:"held_balance"`
     Currency             string       `json:"currency"`
     CreatedAt            time.Time    `json:"created_at"`
     UpdatedAt            time.Time    `json:"updated_at"`
 }
 
+// AvailableBalance is the ledger balance less the money reserved by authorized holds.
+func (e *Wallet) AvailableBalance() int64 {
+    return e.Balance - e.HeldBalance
+}
+
+// IsBalanceSufficient checks the available balance, money on hold can't be spent.
 func (e *Wallet) IsBalanceSufficient(expectedAmount int64) bool {
-    return e.Balance >= expectedAmount
+    return e.AvailableBalance() >= expectedAmount
 }
 
 func (e *WalletStatus) Scan(src interface{}) error {
@@ -0,0 +1,73 @@
+package domain
+
+import (
+    "fmt"
+    "time"
+)
+
+type WebhookEndpointStatus string
+
+const (
+    WebhookEndpointStatusACTIVE   WebhookEndpointStatus = "ACTIVE"
+    WebhookEndpointStatusDISABLED WebhookEndpointStatus = "DISABLED"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+    WebhookDeliveryStatusPENDING   WebhookDeliveryStatus = "PENDING"
+    WebhookDeliveryStatusDELIVERED WebhookDeliveryStatus = "DELIVERED"
+    WebhookDeliveryStatusFAILED    WebhookDeliveryStatus = "FAILED"
+)
+
+// WebhookEndpoint is a partner URL notified about the user's events. An empty
+// EventTypes subscribes the endpoint to every event.
+type WebhookEndpoint struct {
+    ID         int64                 `json:"id"`
+    UserID     int64                 `json:"user_id"`
+    Url        string                `json:"url"`
+    Secret     string                `json:"secret"`
+    EventTypes []string              `json:"event_types"`
+    Status     WebhookEndpointStatus `json:"status"`
+    CreatedAt  time.Time             `json:"created_at"`
+    UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+    ID               int64                 `json:"id"`
+    EndpointID       int64                 `json:"endpoint_id"`
+    EventID          string                `json:"event_id"`
+    EventType        EventType             `json:"event_type"`
+    Payload          string                `json:"payload"`
+    Status           WebhookDeliveryStatus `json:"status"`
+    Attempts         int64                 `json:"attempts"`
+    NextAttemptAt    time.Time             `json:"next_attempt_at"`
+    LastResponseCode int64                 `json:"last_response_code"`
+    LastError        string                `json:"last_error"`
+    CreatedAt        time.Time             `json:"created_at"`
+    UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+func (e *WebhookEndpointStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = WebhookEndpointStatus(s)
+    case string:
+        *e = WebhookEndpointStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for WebhookEndpointStatus: %T", src)
+    }
+    return nil
+}
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+    switch s := src.(type) {
+    case []byte:
+        *e = WebhookDeliveryStatus(s)
+    case string:
+        *e = WebhookDeliveryStatus(s)
+    default:
+        return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+    }
+    return nil
+}
@@ -7,8 +7,7 @@ import (
 
 type CreateBankAccountDto struct {
     AccountNo string `json:"account_no" validate:"required"`
-    Ifsc      string `json:"ifsc" validate:"required"`
-    BankName  string `json:"bank_name" validate:"required"`
+    Ifsc      string `json:"ifsc" validate:"required,ifsc"`
     Currency  string `json:"currency" validate:"required"`
     UserID    int64  `json:"-"`
 }
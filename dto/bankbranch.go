@@ -0,0 +1,25 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type BankBranchDto struct {
+    Ifsc     string `json:"ifsc" validate:"required,ifsc"`
+    BankName string `json:"bank_name" validate:"required"`
+    Branch   string `json:"branch" validate:"required"`
+    Address  string `json:"address"`
+    City     string `json:"city"`
+    State    string `json:"state"`
+}
+
+func NewBankBranchDto(branch domain.BankBranch) BankBranchDto {
+    return BankBranchDto{
+        Ifsc:     branch.Ifsc,
+        BankName: branch.BankName,
+        Branch:   branch.Branch,
+        Address:  branch.Address,
+        City:     branch.City,
+        State:    branch.State,
+    }
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "time"
+)
+
+// CreateEscrowDto funds an escrow for the payee, ReleaseAfterSeconds defaults to constant.EscrowDefaultReleaseAfter.
+type CreateEscrowDto struct {
+    UserID              int64  `json:"-"`
+    FromWalletAddress   string `json:"from_wallet_address" validate:"required"`
+    ToWalletAddress     string `json:"to_wallet_address" validate:"required"`
+    Amount              int64  `json:"amount" validate:"required,gt=0"`
+    ReleaseAfterSeconds int64  `json:"release_after_seconds" validate:"gte=0,lte=7776000"`
+    TOTPCode            string `json:"-"`
+}
+
+type DisputeEscrowDto struct {
+    EscrowID int64  `json:"-"`
+    UserID   int64  `json:"-"`
+    Reason   string `json:"reason" validate:"required,max=500"`
+}
+
+// ResolveEscrowDto is the decision of ops on a disputed escrow, RELEASED pays the payee and REFUNDED the payer.
+type ResolveEscrowDto struct {
+    EscrowID   int64               `json:"-"`
+    AdminID    int64               `json:"-"`
+    Resolution domain.EscrowStatus `json:"resolution" validate:"required,oneof=RELEASED REFUNDED"`
+}
+
+type ListEscrowsDto struct {
+    Status domain.EscrowStatus `json:"status" validate:"required,oneof=FUNDED DISPUTED RELEASED REFUNDED"`
+    Limit  int32               `json:"limit"`
+    Offset int32               `json:"offset"`
+}
+
+type EscrowDto struct {
+    ID                   int64               `json:"id"`
+    PayerWalletID        int64               `json:"payer_wallet_id"`
+    PayeeWalletID        int64               `json:"payee_wallet_id"`
+    Amount               int64               `json:"amount"`
+    Status               domain.EscrowStatus `json:"status"`
+    FundingTransferID    int64               `json:"funding_transfer_id"`
+    SettlementTransferID int64               `json:"settlement_transfer_id,omitempty"`
+    DisputeReason        string              `json:"dispute_reason,omitempty"`
+    ReleaseAfter         time.Time           `json:"release_after"`
+    CreatedAt            time.Time           `json:"created_at"`
+    UpdatedAt            time.Time           `json:"updated_at"`
+    ReleaseAttempts      int64               `json:"release_attempts,omitempty"`
+    ReleaseError         string              `json:"release_error,omitempty"`
+}
+
+type EscrowTransferResultDto struct {
+    Escrow   EscrowDto               `json:"escrow"`
+    Transfer WalletTransferResultDto `json:"transfer"`
+}
+
+func NewEscrowDto(escrow domain.Escrow) EscrowDto {
+    return EscrowDto{
+        ID:                   escrow.ID,
+        PayerWalletID:        escrow.PayerWalletID,
+        PayeeWalletID:        escrow.PayeeWalletID,
+        Amount:               escrow.Amount,
+        Status:               escrow.Status,
+        FundingTransferID:    escrow.FundingTransferID,
+        SettlementTransferID: escrow.SettlementTransferID.Int64,
+        DisputeReason:        escrow.DisputeReason,
+        ReleaseAfter:         escrow.ReleaseAfter,
+        CreatedAt:            escrow.CreatedAt,
+        UpdatedAt:            escrow.UpdatedAt,
+        ReleaseAttempts:      escrow.ReleaseAttempts,
+        ReleaseError:         escrow.ReleaseError,
+    }
+}
+
+func NewEscrowTransferResultDto(etr store.EscrowTransferResult) EscrowTransferResultDto {
+    return EscrowTransferResultDto{
+        Escrow:   NewEscrowDto(etr.Escrow),
+        Transfer: NewWalletTransferDto(etr.Transfer),
+    }
+}
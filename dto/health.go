@@ -0,0 +1,27 @@
+package dto
+
+const (
+    HealthStatusOK       = "ok"
+    HealthStatusFailed   = "failed"
+    HealthStatusReady    = "ready"
+    HealthStatusNotReady = "not_ready"
+)
+
+type HealthCheckDto struct {
+    Name   string `json:"name"`
+    Status string `json:"status"`
+    Error  string `json:"error,omitempty"`
+}
+
+type ReadinessDto struct {
+    Status string           `json:"status"`
+    Checks []HealthCheckDto `json:"checks"`
+}
+
+func (r ReadinessDto) IsReady() bool {
+    return r.Status == HealthStatusReady
+}
+
+type LivenessDto struct {
+    Status string `json:"status"`
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "time"
+)
+
+// AuthorizeHoldDto reserves Amount of the payer's wallet for the merchant, TTLSeconds defaults to
+// constant.HoldDefaultTTL.
+type AuthorizeHoldDto struct {
+    UserID            int64  `json:"-"`
+    FromWalletAddress string `json:"from_wallet_address" validate:"required"`
+    ToWalletAddress   string `json:"to_wallet_address" validate:"required"`
+    Amount            int64  `json:"amount" validate:"required,gt=0"`
+    TTLSeconds        int64  `json:"ttl_seconds" validate:"gte=0,lte=2592000"`
+    TOTPCode          string `json:"-"`
+}
+
+// CaptureHoldDto captures Amount of the hold, a zero Amount captures all of it.
+type CaptureHoldDto struct {
+    HoldID   int64  `json:"-"`
+    UserID   int64  `json:"-"`
+    Amount   int64  `json:"amount" validate:"gte=0"`
+    TOTPCode string `json:"-"`
+}
+
+type ListHoldsDto struct {
+    WalletID int64 `json:"-"`
+    UserID   int64 `json:"-"`
+    Limit    int32 `json:"limit"`
+    Offset   int32 `json:"offset"`
+}
+
+type HoldDto struct {
+    ID             int64             `json:"id"`
+    WalletID       int64             `json:"wallet_id"`
+    ToWalletID     int64             `json:"to_wallet_id"`
+    Amount         int64             `json:"amount"`
+    CapturedAmount int64             `json:"captured_amount"`
+    Status         domain.HoldStatus `json:"status"`
+    TransferID     int64             `json:"transfer_id,omitempty"`
+    ExpiresAt      time.Time         `json:"expires_at"`
+    CreatedAt      time.Time         `json:"created_at"`
+    UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type CaptureHoldResultDto struct {
+    Hold     HoldDto                 `json:"hold"`
+    Transfer WalletTransferResultDto `json:"transfer"`
+}
+
+func NewHoldDto(hold domain.Hold) HoldDto {
+    return HoldDto{
+        ID:             hold.ID,
+        WalletID:       hold.WalletID,
+        ToWalletID:     hold.ToWalletID,
+        Amount:         hold.Amount,
+        CapturedAmount: hold.CapturedAmount,
+        Status:         hold.Status,
+        TransferID:     hold.TransferID.Int64,
+        ExpiresAt:      hold.ExpiresAt,
+        CreatedAt:      hold.CreatedAt,
+        UpdatedAt:      hold.UpdatedAt,
+    }
+}
+
+func NewCaptureHoldResultDto(chr store.CaptureHoldResult) CaptureHoldResultDto {
+    return CaptureHoldResultDto{
+        Hold:     NewHoldDto(chr.Hold),
+        Transfer: NewWalletTransferDto(chr.Transfer),
+    }
+}
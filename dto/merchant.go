@@ -0,0 +1,80 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type CreateMerchantDto struct {
+    UserID                  int64  `json:"-"`
+    DisplayName             string `json:"display_name" validate:"required,max=100"`
+    Category                string `json:"category" validate:"required,oneof=RETAIL FOOD TRAVEL DIGITAL_GOODS SERVICES EDUCATION HEALTH OTHER"`
+    SettlementWalletAddress string `json:"settlement_wallet_address" validate:"required"`
+    CallbackUrl             string `json:"callback_url" validate:"omitempty,url"`
+}
+
+// UpdateMerchantDto replaces the whole profile of the merchant.
+type UpdateMerchantDto struct {
+    UserID                  int64  `json:"-"`
+    DisplayName             string `json:"display_name" validate:"required,max=100"`
+    Category                string `json:"category" validate:"required,oneof=RETAIL FOOD TRAVEL DIGITAL_GOODS SERVICES EDUCATION HEALTH OTHER"`
+    SettlementWalletAddress string `json:"settlement_wallet_address" validate:"required"`
+    CallbackUrl             string `json:"callback_url" validate:"omitempty,url"`
+}
+
+type MerchantDto struct {
+    ID                 int64     `json:"id"`
+    UserID             int64     `json:"user_id"`
+    DisplayName        string    `json:"display_name"`
+    Category           string    `json:"category"`
+    SettlementWalletID int64     `json:"settlement_wallet_id"`
+    CallbackUrl        string    `json:"callback_url"`
+    CreatedAt          time.Time `json:"created_at"`
+    UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// CreateAPIKeyDto scopes are "<resource>:<read|write>", a write scope grants the read scope as well.
+type CreateAPIKeyDto struct {
+    UserID int64    `json:"-"`
+    Name   string   `json:"name" validate:"required,max=100"`
+    Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=wallets:read wallets:write transfers:read transfers:write holds:read holds:write escrows:read escrows:write payment-req:read payment-req:write webhooks:read webhooks:write payment-links:read payment-links:write"`
+}
+
+type APIKeyDto struct {
+    ID         int64               `json:"id"`
+    MerchantID int64               `json:"merchant_id"`
+    Name       string              `json:"name"`
+    Prefix     string              `json:"prefix"`
+    Key        string              `json:"key,omitempty"`
+    Scopes     []string            `json:"scopes"`
+    Status     domain.APIKeyStatus `json:"status"`
+    CreatedAt  time.Time           `json:"created_at"`
+    UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+func NewMerchantDto(merchant domain.Merchant) MerchantDto {
+    return MerchantDto{
+        ID:                 merchant.ID,
+        UserID:             merchant.UserID,
+        DisplayName:        merchant.DisplayName,
+        Category:           merchant.Category,
+        SettlementWalletID: merchant.SettlementWalletID,
+        CallbackUrl:        merchant.CallbackUrl,
+        CreatedAt:          merchant.CreatedAt,
+        UpdatedAt:          merchant.UpdatedAt,
+    }
+}
+
+// NewAPIKeyDto leaves out the key, it is only shown once when the key is created.
+func NewAPIKeyDto(apiKey domain.APIKey) APIKeyDto {
+    return APIKeyDto{
+        ID:         apiKey.ID,
+        MerchantID: apiKey.MerchantID,
+        Name:       apiKey.Name,
+        Prefix:     apiKey.Prefix,
+        Scopes:     apiKey.Scopes,
+        Status:     apiKey.Status,
+        CreatedAt:  apiKey.CreatedAt,
+        UpdatedAt:  apiKey.UpdatedAt,
+    }
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type NotificationDto struct {
+    ID        int64            `json:"id"`
+    EventType domain.EventType `json:"event_type"`
+    Title     string           `json:"title"`
+    Body      string           `json:"body"`
+    Read      bool             `json:"read"`
+    ReadAt    *time.Time       `json:"read_at,omitempty"`
+    CreatedAt time.Time        `json:"created_at"`
+}
+
+type ListNotificationsDto struct {
+    UserID     int64 `json:"-"`
+    UnreadOnly bool  `json:"unread_only"`
+    Limit      int32 `json:"limit"`
+    Offset     int32 `json:"offset"`
+}
+
+type NotificationPreferenceDto struct {
+    Channel domain.NotificationChannel `json:"channel" validate:"required,oneof=IN_APP EMAIL"`
+    Enabled bool                       `json:"enabled"`
+    UserID  int64                      `json:"-"`
+}
+
+func NewNotificationDto(notification domain.Notification) NotificationDto {
+    res := NotificationDto{
+        ID:        notification.ID,
+        EventType: notification.EventType,
+        Title:     notification.Title,
+        Body:      notification.Body,
+        Read:      notification.ReadAt.Valid,
+        CreatedAt: notification.CreatedAt,
+    }
+
+    if notification.ReadAt.Valid {
+        readAt := notification.ReadAt.Time
+        res.ReadAt = &readAt
+    }
+
+    return res
+}
+
+func NewNotificationPreferenceDto(preference domain.NotificationPreference) NotificationPreferenceDto {
+    return NotificationPreferenceDto{
+        Channel: preference.Channel,
+        Enabled: preference.Enabled,
+        UserID:  preference.UserID,
+    }
+}
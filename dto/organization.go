@@ -0,0 +1,21 @@
+package dto
+
+// CreateOrganizationCurrencyDto adds a currency with its organization and escrow wallets, each linked to a bank
+// account of MyWallet. The banks of the accounts come from the bank directory.
+type CreateOrganizationCurrencyDto struct {
+    Code                string                     `json:"code" validate:"required,len=3,alpha"`
+    Fraction            int64                      `json:"fraction" validate:"required,gt=0,lte=3"`
+    OrganizationAccount OrganizationBankAccountDto `json:"organization_account" validate:"required"`
+    EscrowAccount       OrganizationBankAccountDto `json:"escrow_account" validate:"required"`
+}
+
+type OrganizationBankAccountDto struct {
+    AccountNo string `json:"account_no" validate:"required"`
+    Ifsc      string `json:"ifsc" validate:"required,ifsc"`
+}
+
+type OrganizationCurrencyDto struct {
+    Currency           CurrencyDto `json:"currency"`
+    OrganizationWallet WalletDto   `json:"organization_wallet"`
+    EscrowWallet       WalletDto   `json:"escrow_wallet"`
+}
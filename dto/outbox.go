@@ -0,0 +1,39 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type OutboxEventDto struct {
+    ID            int64                `json:"id"`
+    AggregateType domain.AggregateType `json:"aggregate_type"`
+    AggregateID   int64                `json:"aggregate_id"`
+    EventType     domain.EventType     `json:"event_type"`
+    Payload       string               `json:"payload"`
+    Status        domain.OutboxStatus  `json:"status"`
+    Attempts      int64                `json:"attempts"`
+    NextAttemptAt time.Time            `json:"next_attempt_at"`
+    LastError     string               `json:"last_error"`
+    CreatedAt     time.Time            `json:"created_at"`
+}
+
+type ListDeadOutboxEventsDto struct {
+    Limit  int32 `json:"limit"`
+    Offset int32 `json:"offset"`
+}
+
+func NewOutboxEventDto(event domain.OutboxEvent) OutboxEventDto {
+    return OutboxEventDto{
+        ID:            event.ID,
+        AggregateType: event.AggregateType,
+        AggregateID:   event.AggregateID,
+        EventType:     event.EventType,
+        Payload:       event.Payload,
+        Status:        event.Status,
+        Attempts:      event.Attempts,
+        NextAttemptAt: event.NextAttemptAt,
+        LastError:     event.LastError,
+        CreatedAt:     event.CreatedAt,
+    }
+}
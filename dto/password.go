@@ -0,0 +1,18 @@
+package dto
+
+type ChangePasswordDto struct {
+    UserID          int64  `json:"-"`
+    CurrentPassword string `json:"current_password" validate:"required"`
+    NewPassword     string `json:"new_password" validate:"required,min=6,nefield=CurrentPassword"`
+}
+
+// ForgotPasswordDto mails a password reset token to the user of Email.
+type ForgotPasswordDto struct {
+    Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordDto sets the password with the token of a forgotten password.
+type ResetPasswordDto struct {
+    Token       string `json:"token" validate:"required"`
+    NewPassword string `json:"new_password" validate:"required,min=6"`
+}
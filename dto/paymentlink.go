@@ -0,0 +1,169 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "time"
+)
+
+// CreatePaymentLinkDto leaves Amount at 0 to let the payer choose the amount, and ExpiresInSeconds at 0 for a link that doesn't expire.
+type CreatePaymentLinkDto struct {
+    UserID           int64                  `json:"-"`
+    Description      string                 `json:"description" validate:"max=200"`
+    Amount           int64                  `json:"amount" validate:"gte=0"`
+    Currency         string                 `json:"currency" validate:"required"`
+    Mode             domain.PaymentLinkMode `json:"mode" validate:"required,oneof=SINGLE_USE MULTI_USE"`
+    SuccessUrl       string                 `json:"success_url" validate:"omitempty,url"`
+    ExpiresInSeconds int64                  `json:"expires_in_seconds" validate:"gte=0,lte=31536000"`
+}
+
+type ListPaymentLinksDto struct {
+    UserID int64 `json:"-"`
+    Limit  int32 `json:"limit"`
+    Offset int32 `json:"offset"`
+}
+
+type PaymentLinkDto struct {
+    ID          int64                    `json:"id"`
+    MerchantID  int64                    `json:"merchant_id"`
+    Slug        string                   `json:"slug"`
+    Description string                   `json:"description"`
+    Amount      int64                    `json:"amount"`
+    Currency    string                   `json:"currency"`
+    Mode        domain.PaymentLinkMode   `json:"mode"`
+    Status      domain.PaymentLinkStatus `json:"status"`
+    SuccessUrl  string                   `json:"success_url,omitempty"`
+    Uses        int64                    `json:"uses"`
+    ExpiresAt   *time.Time               `json:"expires_at,omitempty"`
+    CreatedAt   time.Time                `json:"created_at"`
+    UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// PaymentLinkPageDto is what the payers see at /pay/{slug}.
+type PaymentLinkPageDto struct {
+    Slug         string                   `json:"slug"`
+    MerchantName string                   `json:"merchant_name"`
+    Description  string                   `json:"description"`
+    Amount       int64                    `json:"amount"`
+    Currency     string                   `json:"currency"`
+    Mode         domain.PaymentLinkMode   `json:"mode"`
+    Status       domain.PaymentLinkStatus `json:"status"`
+    ExpiresAt    *time.Time               `json:"expires_at,omitempty"`
+}
+
+// StartCheckoutDto Amount is required for the links with an open amount, else it must be 0 or the amount of the link.
+type StartCheckoutDto struct {
+    Slug   string `json:"-"`
+    UserID int64  `json:"-"`
+    Amount int64  `json:"amount" validate:"gte=0"`
+}
+
+type CompleteCheckoutDto struct {
+    SessionID         int64  `json:"-"`
+    UserID            int64  `json:"-"`
+    FromWalletAddress string `json:"from_wallet_address" validate:"required"`
+    TOTPCode          string `json:"-"`
+}
+
+type CheckoutSessionDto struct {
+    ID            int64                        `json:"id"`
+    PaymentLinkID int64                        `json:"payment_link_id"`
+    ToWalletID    int64                        `json:"to_wallet_id"`
+    Amount        int64                        `json:"amount"`
+    Status        domain.CheckoutSessionStatus `json:"status"`
+    FromWalletID  int64                        `json:"from_wallet_id,omitempty"`
+    TransferID    int64                        `json:"transfer_id,omitempty"`
+    ExpiresAt     time.Time                    `json:"expires_at"`
+    CreatedAt     time.Time                    `json:"created_at"`
+    UpdatedAt     time.Time                    `json:"updated_at"`
+}
+
+// CheckoutResultDto RedirectUrl is the success url of the link, where the payer is sent back to the merchant.
+type CheckoutResultDto struct {
+    Session     CheckoutSessionDto      `json:"session"`
+    Transfer    WalletTransferResultDto `json:"transfer"`
+    RedirectUrl string                  `json:"redirect_url,omitempty"`
+}
+
+// CheckoutEventDto is posted to the merchant's callback url and sent with the checkout.completed webhooks.
+type CheckoutEventDto struct {
+    SessionID     int64     `json:"session_id"`
+    PaymentLinkID int64     `json:"payment_link_id"`
+    Slug          string    `json:"slug"`
+    Amount        int64     `json:"amount"`
+    Currency      string    `json:"currency"`
+    TransferID    int64     `json:"transfer_id"`
+    FromWalletID  int64     `json:"from_wallet_id"`
+    ToWalletID    int64     `json:"to_wallet_id"`
+    CompletedAt   time.Time `json:"completed_at"`
+}
+
+func NewPaymentLinkDto(link domain.PaymentLink) PaymentLinkDto {
+    res := PaymentLinkDto{
+        ID:          link.ID,
+        MerchantID:  link.MerchantID,
+        Slug:        link.Slug,
+        Description: link.Description,
+        Amount:      link.Amount,
+        Currency:    link.Currency,
+        Mode:        link.Mode,
+        Status:      link.Status,
+        SuccessUrl:  link.SuccessUrl,
+        Uses:        link.Uses,
+        CreatedAt:   link.CreatedAt,
+        UpdatedAt:   link.UpdatedAt,
+    }
+
+    if link.ExpiresAt.Valid {
+        res.ExpiresAt = &link.ExpiresAt.Time
+    }
+
+    return res
+}
+
+func NewPaymentLinkPageDto(link domain.PaymentLink, merchant domain.Merchant) PaymentLinkPageDto {
+    res := PaymentLinkPageDto{
+        Slug:         link.Slug,
+        MerchantName: merchant.DisplayName,
+        Description:  link.Description,
+        Amount:       link.Amount,
+        Currency:     link.Currency,
+        Mode:         link.Mode,
+        Status:       link.Status,
+    }
+
+    if link.ExpiresAt.Valid {
+        res.ExpiresAt = &link.ExpiresAt.Time
+    }
+
+    return res
+}
+
+func NewCheckoutSessionDto(session domain.CheckoutSession) CheckoutSessionDto {
+    return CheckoutSessionDto{
+        ID:            session.ID,
+        PaymentLinkID: session.PaymentLinkID,
+        ToWalletID:    session.ToWalletID,
+        Amount:        session.Amount,
+        Status:        session.Status,
+        FromWalletID:  session.FromWalletID.Int64,
+        TransferID:    session.TransferID.Int64,
+        ExpiresAt:     session.ExpiresAt,
+        CreatedAt:     session.CreatedAt,
+        UpdatedAt:     session.UpdatedAt,
+    }
+}
+
+func NewCheckoutEventDto(res store.CompleteCheckoutSessionResult) CheckoutEventDto {
+    return CheckoutEventDto{
+        SessionID:     res.Session.ID,
+        PaymentLinkID: res.PaymentLink.ID,
+        Slug:          res.PaymentLink.Slug,
+        Amount:        res.Session.Amount,
+        Currency:      res.PaymentLink.Currency,
+        TransferID:    res.Transfer.Transfer.ID,
+        FromWalletID:  res.Session.FromWalletID.Int64,
+        ToWalletID:    res.Session.ToWalletID,
+        CompletedAt:   res.Session.UpdatedAt,
+    }
+}
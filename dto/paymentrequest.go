@@ -16,6 +16,12 @@ type PaymentRequestDto struct {
     CreatedAt         time.Time                   `json:"created_at"`
 }
 
+// ApprovePaymentRequestDto pays the payment request ID.
+type ApprovePaymentRequestDto struct {
+    ID       int64
+    TOTPCode string
+}
+
 type ListPaymentRequestsDto struct {
     FromWalletID int64 `json:"from_wallet_id"`
     Limit        int32 `json:"limit"`
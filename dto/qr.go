@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/pranayhere/simple-wallet/pkg/qr"
+
+// GetQRDto ID is the wallet for a static code, or the payment request for a dynamic one. Scale is in pixels per module.
+type GetQRDto struct {
+    ID     int64  `json:"id"`
+    UserID int64  `json:"-"`
+    Format string `json:"format" validate:"required,oneof=png svg"`
+    Scale  int    `json:"scale" validate:"gte=1,lte=32"`
+}
+
+type ParseQRDto struct {
+    Payload string `json:"payload" validate:"required"`
+}
+
+type QRPayloadDto struct {
+    Address   string `json:"address"`
+    Amount    int64  `json:"amount,omitempty"`
+    Currency  string `json:"currency"`
+    Reference string `json:"reference,omitempty"`
+}
+
+// QRPayDto Amount is only needed for the static codes, for the others it must be 0 or the amount of the code.
+type QRPayDto struct {
+    UserID            int64  `json:"-"`
+    Payload           string `json:"payload" validate:"required"`
+    FromWalletAddress string `json:"from_wallet_address" validate:"required"`
+    Amount            int64  `json:"amount" validate:"gte=0"`
+    TOTPCode          string `json:"-"`
+}
+
+type QRPayResultDto struct {
+    Reference string                  `json:"reference,omitempty"`
+    Transfer  WalletTransferResultDto `json:"transfer"`
+}
+
+func NewQRPayloadDto(payload qr.Payload) QRPayloadDto {
+    return QRPayloadDto{
+        Address:   payload.Address,
+        Amount:    payload.Amount,
+        Currency:  payload.Currency,
+        Reference: payload.Reference,
+    }
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type ReconciliationReportDto struct {
+    ID                int64                          `json:"id"`
+    Status            domain.ReconciliationStatus    `json:"status"`
+    WalletsChecked    int64                          `json:"wallets_checked"`
+    TransfersChecked  int64                          `json:"transfers_checked"`
+    CurrenciesChecked int64                          `json:"currencies_checked"`
+    DiscrepancyCount  int64                          `json:"discrepancy_count"`
+    StartedAt         time.Time                      `json:"started_at"`
+    FinishedAt        time.Time                      `json:"finished_at"`
+    Discrepancies     []ReconciliationDiscrepancyDto `json:"discrepancies,omitempty"`
+}
+
+type ReconciliationDiscrepancyDto struct {
+    ID         int64                  `json:"id"`
+    Kind       domain.DiscrepancyKind `json:"kind"`
+    Currency   string                 `json:"currency"`
+    WalletID   int64                  `json:"wallet_id,omitempty"`
+    TransferID int64                  `json:"transfer_id,omitempty"`
+    Expected   int64                  `json:"expected"`
+    Actual     int64                  `json:"actual"`
+    Detail     string                 `json:"detail"`
+}
+
+type ListReconciliationReportsDto struct {
+    Limit  int32 `json:"limit"`
+    Offset int32 `json:"offset"`
+}
+
+func NewReconciliationReportDto(report domain.ReconciliationReport, discrepancies []domain.ReconciliationDiscrepancy) ReconciliationReportDto {
+    res := ReconciliationReportDto{
+        ID:                report.ID,
+        Status:            report.Status,
+        WalletsChecked:    report.WalletsChecked,
+        TransfersChecked:  report.TransfersChecked,
+        CurrenciesChecked: report.CurrenciesChecked,
+        DiscrepancyCount:  report.DiscrepancyCount,
+        StartedAt:         report.StartedAt,
+        FinishedAt:        report.FinishedAt,
+    }
+
+    for _, d := range discrepancies {
+        res.Discrepancies = append(res.Discrepancies, ReconciliationDiscrepancyDto{
+            ID:         d.ID,
+            Kind:       d.Kind,
+            Currency:   d.Currency,
+            WalletID:   d.WalletID.Int64,
+            TransferID: d.TransferID.Int64,
+            Expected:   d.Expected,
+            Actual:     d.Actual,
+            Detail:     d.Detail,
+        })
+    }
+
+    return res
+}
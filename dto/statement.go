@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type GetStatementDto struct {
+    WalletID int64     `json:"wallet_id"`
+    UserID   int64     `json:"-"`
+    Month    time.Time `json:"month" validate:"required"`
+    Format   string    `json:"format" validate:"required,oneof=csv pdf"`
+}
+
+// StatementDto is the movements of a wallet over a month, the amounts are in the currency's minor unit.
+type StatementDto struct {
+    WalletID       int64              `json:"wallet_id"`
+    WalletAddress  string             `json:"wallet_address"`
+    Currency       string             `json:"currency"`
+    Fraction       int64              `json:"fraction"`
+    From           time.Time          `json:"from"`
+    To             time.Time          `json:"to"`
+    OpeningBalance int64              `json:"opening_balance"`
+    ClosingBalance int64              `json:"closing_balance"`
+    TotalCredits   int64              `json:"total_credits"`
+    TotalDebits    int64              `json:"total_debits"`
+    Lines          []StatementLineDto `json:"lines"`
+}
+
+type StatementLineDto struct {
+    Date         time.Time `json:"date"`
+    TransferID   int64     `json:"transfer_id"`
+    Counterparty string    `json:"counterparty"`
+    Amount       int64     `json:"amount"`
+    Balance      int64     `json:"balance"`
+}
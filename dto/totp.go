@@ -0,0 +1,42 @@
+package dto
+
+// TOTPEnrollmentDto is the pending secret of the user, to add to an authenticator app by scanning QRCode, the PNG of
+// the QR code of ProvisioningURI, or by typing Secret. It is enabled once confirmed with a code.
+type TOTPEnrollmentDto struct {
+    Secret          string `json:"secret"`
+    ProvisioningURI string `json:"provisioning_uri"`
+    QRCode          []byte `json:"qr_code"`
+}
+
+type ConfirmTOTPDto struct {
+    UserID int64  `json:"-"`
+    Code   string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// TOTPRecoveryCodesDto are shown once, each of them stands in for a TOTP code once.
+type TOTPRecoveryCodesDto struct {
+    RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableTOTPDto Code is a TOTP code or a recovery code.
+type DisableTOTPDto struct {
+    UserID   int64  `json:"-"`
+    Password string `json:"password" validate:"required"`
+    Code     string `json:"code" validate:"required"`
+}
+
+// VerifyLoginDto exchanges the two factor token of POST /users/login for an access token, Code is a TOTP code or a
+// recovery code.
+type VerifyLoginDto struct {
+    TwoFactorToken string `json:"two_factor_token" validate:"required"`
+    Code           string `json:"code" validate:"required"`
+}
+
+// StepUpDto is a payment of Amount in Currency by the user, Code is the TOTP code it needs above the step-up
+// threshold of the currency.
+type StepUpDto struct {
+    UserID   int64
+    Amount   int64
+    Currency string
+    Code     string
+}
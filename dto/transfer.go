@@ -0,0 +1,95 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "time"
+)
+
+// TransferDto is a payment between two wallets, or the refund of one: OriginalTransferID is then the payment
+// refunded, null otherwise.
+type TransferDto struct {
+    ID                 int64               `json:"id"`
+    FromWalletID       int64               `json:"from_wallet_id"`
+    ToWalletID         int64               `json:"to_wallet_id"`
+    Amount             int64               `json:"amount"`
+    Kind               domain.TransferKind `json:"kind"`
+    OriginalTransferID *int64              `json:"original_transfer_id"`
+    RefundedAmount     int64               `json:"refunded_amount"`
+    RefundStatus       domain.RefundStatus `json:"refund_status"`
+    CreatedAt          time.Time           `json:"created_at"`
+}
+
+// RefundTransferDto refunds Amount of a payment to its sender, a zero Amount refunds what is left.
+type RefundTransferDto struct {
+    TransferID int64 `json:"-"`
+    UserID     int64 `json:"-"`
+    Amount     int64 `json:"amount" validate:"gte=0"`
+}
+
+// ReverseTransferDto is an admin forced refund, AllowNegativeBalance lets the receiver's wallet go below zero.
+type ReverseTransferDto struct {
+    TransferID           int64 `json:"-"`
+    AdminID              int64 `json:"-"`
+    Amount               int64 `json:"amount" validate:"gte=0"`
+    AllowNegativeBalance bool  `json:"allow_negative_balance"`
+}
+
+type RefundResultDto struct {
+    Refund           WalletTransferResultDto `json:"refund"`
+    OriginalTransfer TransferDto             `json:"original_transfer"`
+}
+
+func NewRefundResultDto(rtr store.RefundTransferResult) RefundResultDto {
+    return RefundResultDto{
+        Refund:           NewWalletTransferDto(rtr.Refund),
+        OriginalTransfer: NewTransferDto(rtr.OriginalTransfer),
+    }
+}
+
+func NewTransferDto(transfer domain.Transfer) TransferDto {
+    var originalTransferID *int64
+    if transfer.OriginalTransferID.Valid {
+        originalTransferID = &transfer.OriginalTransferID.Int64
+    }
+
+    return TransferDto{
+        ID:                 transfer.ID,
+        FromWalletID:       transfer.FromWalletID,
+        ToWalletID:         transfer.ToWalletID,
+        Amount:             transfer.Amount,
+        Kind:               transfer.Kind,
+        OriginalTransferID: originalTransferID,
+        RefundedAmount:     transfer.RefundedAmount,
+        RefundStatus:       transfer.RefundStatus,
+        CreatedAt:          transfer.CreatedAt,
+    }
+}
+
+// UnversionedResponse turns a response of v1, decoded from JSON, into the shape the routes had before the api was
+// versioned, for the deprecated routes at the root: the original_transfer_id of the transfers is the object of
+// sql.NullInt64, {"Int64": 0, "Valid": false} on the transfers that are not refunds.
+func UnversionedResponse(v interface{}) interface{} {
+    switch v := v.(type) {
+    case map[string]interface{}:
+        for key, value := range v {
+            if key == "original_transfer_id" {
+                v[key] = unversionedNullInt64(value)
+                continue
+            }
+            v[key] = UnversionedResponse(value)
+        }
+    case []interface{}:
+        for i := range v {
+            v[i] = UnversionedResponse(v[i])
+        }
+    }
+    return v
+}
+
+func unversionedNullInt64(value interface{}) map[string]interface{} {
+    if value == nil {
+        return map[string]interface{}{"Int64": 0, "Valid": false}
+    }
+    return map[string]interface{}{"Int64": value, "Valid": true}
+}
@@ -27,9 +27,13 @@ type LoginCredentialsDto struct {
     Password string `json:"password" validate:"required,min=6"`
 }
 
+// LoggedInUserDto of a user with two factor authentication has no AccessToken but a TwoFactorToken, exchanged
+// for the access token with a TOTP code at POST /users/login/verify.
 type LoggedInUserDto struct {
-    AccessToken string  `json:"access_token"`
-    User        UserDto `json:"user"`
+    AccessToken       string  `json:"access_token,omitempty"`
+    TwoFactorRequired bool    `json:"two_factor_required"`
+    TwoFactorToken    string  `json:"two_factor_token,omitempty"`
+    User              UserDto `json:"user"`
 }
 
 func NewUserDto(user domain.User) UserDto {
@@ -6,23 +6,36 @@ import (
     "time"
 )
 
+// TransferMoneyDto TOTPCode is the code of the X-Totp-Code header, it steps up the payment. So do the TOTPCode of
+// the other dtos of the payments.
 type TransferMoneyDto struct {
     FromWalletAddress string `json:"from_wallet_address" validate:"required"`
     ToWalletAddress   string `json:"to_wallet_address" validate:"required"`
     Amount            int64  `json:"amount" validate:"required,gt=0"`
+    TOTPCode          string `json:"-"`
 }
 
 type TransferMoneyByWalletIDDto struct {
-    FromWalletID int64 `json:"from_wallet_id" validate:"required"`
-    ToWalletID   int64 `json:"to_wallet_address" validate:"required"`
-    Amount       int64 `json:"amount" validate:"required,gt=0"`
+    FromWalletID int64  `json:"from_wallet_id" validate:"required"`
+    ToWalletID   int64  `json:"to_wallet_address" validate:"required"`
+    Amount       int64  `json:"amount" validate:"required,gt=0"`
+    TOTPCode     string `json:"-"`
 }
 
 type WalletTransferResultDto struct {
-    Wallet    domain.Wallet   `json:"wallet" validate:"required"`
-    FromEntry domain.Entry    `json:"from_entry" validate:"required"`
-    ToEntry   domain.Entry    `json:"to_entry" validate:"required"`
-    Transfer  domain.Transfer `json:"transfer" validate:"required"`
+    Wallet    WalletDto   `json:"wallet" validate:"required"`
+    FromEntry EntryDto    `json:"from_entry" validate:"required"`
+    ToEntry   EntryDto    `json:"to_entry" validate:"required"`
+    Transfer  TransferDto `json:"transfer" validate:"required"`
+}
+
+// EntryDto is the movement of a transfer on one of its wallets, negative on the sender's.
+type EntryDto struct {
+    ID         int64     `json:"id"`
+    WalletID   int64     `json:"wallet_id"`
+    Amount     int64     `json:"amount"`
+    TransferID int64     `json:"transfer_id"`
+    CreatedAt  time.Time `json:"created_at"`
 }
 
 type WalletDto struct {
@@ -33,6 +46,8 @@ type WalletDto struct {
     BankAccountID        int64               `json:"bank_account_id" validate:"required"`
     OrganizationWalletID int64               `json:"organization_wallet_id" validate:"required"`
     Balance              int64               `json:"balance" validate:"required"`
+    HeldBalance          int64               `json:"held_balance"`
+    AvailableBalance     int64               `json:"available_balance"`
     Currency             string              `json:"currency" validate:"required"`
     CreatedAt            time.Time           `json:"created_at" validate:"required"`
     UpdatedAt            time.Time           `json:"updated_at" validate:"required"`
@@ -40,10 +55,20 @@ type WalletDto struct {
 
 func NewWalletTransferDto(wtr store.WalletTransferResult) WalletTransferResultDto {
     return WalletTransferResultDto{
-        Wallet:    wtr.Wallet,
-        FromEntry: wtr.FromEntry,
-        ToEntry:   wtr.ToEntry,
-        Transfer:  wtr.Transfer,
+        Wallet:    NewWalletDto(wtr.Wallet),
+        FromEntry: NewEntryDto(wtr.FromEntry),
+        ToEntry:   NewEntryDto(wtr.ToEntry),
+        Transfer:  NewTransferDto(wtr.Transfer),
+    }
+}
+
+func NewEntryDto(entry domain.Entry) EntryDto {
+    return EntryDto{
+        ID:         entry.ID,
+        WalletID:   entry.WalletID,
+        Amount:     entry.Amount,
+        TransferID: entry.TransferID,
+        CreatedAt:  entry.CreatedAt,
     }
 }
 
@@ -56,6 +81,8 @@ func NewWalletDto(wallet domain.Wallet) WalletDto {
         BankAccountID:        wallet.BankAccountID,
         OrganizationWalletID: wallet.OrganizationWalletID,
         Balance:              wallet.Balance,
+        HeldBalance:          wallet.HeldBalance,
+        AvailableBalance:     wallet.AvailableBalance(),
         Currency:             wallet.Currency,
         CreatedAt:            wallet.CreatedAt,
         UpdatedAt:            wallet.UpdatedAt,
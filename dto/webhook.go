@@ -0,0 +1,102 @@
+package dto
+
+import (
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "time"
+)
+
+type CreateWebhookEndpointDto struct {
+    Url        string   `json:"url" validate:"required,url"`
+    EventTypes []string `json:"event_types" validate:"dive,oneof=transfer.completed payment_request.status_changed bank_account.verified bank_account.verification_failed checkout.completed"`
+    UserID     int64    `json:"-"`
+}
+
+type WebhookEndpointDto struct {
+    ID         int64                        `json:"id"`
+    UserID     int64                        `json:"user_id"`
+    Url        string                       `json:"url"`
+    Secret     string                       `json:"secret,omitempty"`
+    EventTypes []string                     `json:"event_types"`
+    Status     domain.WebhookEndpointStatus `json:"status"`
+    CreatedAt  time.Time                    `json:"created_at"`
+    UpdatedAt  time.Time                    `json:"updated_at"`
+}
+
+type ListWebhookDeliveriesDto struct {
+    EndpointID int64 `json:"-"`
+    UserID     int64 `json:"-"`
+    Limit      int32 `json:"limit"`
+    Offset     int32 `json:"offset"`
+}
+
+type WebhookDeliveryDto struct {
+    ID               int64                        `json:"id"`
+    EndpointID       int64                        `json:"endpoint_id"`
+    EventID          string                       `json:"event_id"`
+    EventType        domain.EventType             `json:"event_type"`
+    Payload          string                       `json:"payload"`
+    Status           domain.WebhookDeliveryStatus `json:"status"`
+    Attempts         int64                        `json:"attempts"`
+    NextAttemptAt    time.Time                    `json:"next_attempt_at"`
+    LastResponseCode int64                        `json:"last_response_code"`
+    LastError        string                       `json:"last_error"`
+    CreatedAt        time.Time                    `json:"created_at"`
+    UpdatedAt        time.Time                    `json:"updated_at"`
+}
+
+// NewWebhookEndpointDto leaves out the signing secret, it is only shown once when the endpoint is registered.
+func NewWebhookEndpointDto(endpoint domain.WebhookEndpoint) WebhookEndpointDto {
+    return WebhookEndpointDto{
+        ID:         endpoint.ID,
+        UserID:     endpoint.UserID,
+        Url:        endpoint.Url,
+        EventTypes: endpoint.EventTypes,
+        Status:     endpoint.Status,
+        CreatedAt:  endpoint.CreatedAt,
+        UpdatedAt:  endpoint.UpdatedAt,
+    }
+}
+
+func NewWebhookDeliveryDto(delivery domain.WebhookDelivery) WebhookDeliveryDto {
+    return WebhookDeliveryDto{
+        ID:               delivery.ID,
+        EndpointID:       delivery.EndpointID,
+        EventID:          delivery.EventID,
+        EventType:        delivery.EventType,
+        Payload:          delivery.Payload,
+        Status:           delivery.Status,
+        Attempts:         delivery.Attempts,
+        NextAttemptAt:    delivery.NextAttemptAt,
+        LastResponseCode: delivery.LastResponseCode,
+        LastError:        delivery.LastError,
+        CreatedAt:        delivery.CreatedAt,
+        UpdatedAt:        delivery.UpdatedAt,
+    }
+}
+
+// TransferEventDto is the data of the transfer.completed event, sent to both the sender and the receiver.
+// Refunds and reversals set OriginalTransferID to the payment they give back.
+type TransferEventDto struct {
+    TransferID         int64               `json:"transfer_id"`
+    Kind               domain.TransferKind `json:"kind"`
+    OriginalTransferID int64               `json:"original_transfer_id,omitempty"`
+    FromWalletAddress  string              `json:"from_wallet_address"`
+    ToWalletAddress    string              `json:"to_wallet_address"`
+    Amount             int64               `json:"amount"`
+    Currency           string              `json:"currency"`
+    CreatedAt          time.Time           `json:"created_at"`
+}
+
+func NewTransferEventDto(wtr store.WalletTransferResult) TransferEventDto {
+    return TransferEventDto{
+        TransferID:         wtr.Transfer.ID,
+        Kind:               wtr.Transfer.Kind,
+        OriginalTransferID: wtr.Transfer.OriginalTransferID.Int64,
+        FromWalletAddress:  wtr.Wallet.Address,
+        ToWalletAddress:    wtr.ToWallet.Address,
+        Amount:             wtr.Transfer.Amount,
+        Currency:           wtr.Wallet.Currency,
+        CreatedAt:          wtr.Transfer.CreatedAt,
+    }
+}
@@ -0,0 +1,54 @@
+package grpcapi
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/token"
+)
+
+type bankAccountServer struct {
+    pb.UnimplementedBankAccountServiceServer
+    bankAcctSvc service.BankAccountSvc
+}
+
+func NewBankAccountServer(bankAcctSvc service.BankAccountSvc) pb.BankAccountServiceServer {
+    return &bankAccountServer{
+        bankAcctSvc: bankAcctSvc,
+    }
+}
+
+func (s *bankAccountServer) CreateBankAccount(ctx context.Context, req *pb.CreateBankAccountRequest) (*pb.BankAccount, error) {
+    authPayload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+
+    createBankAcctDto := dto.CreateBankAccountDto{
+        AccountNo: req.GetAccountNo(),
+        Ifsc:      req.GetIfsc(),
+        Currency:  req.GetCurrency(),
+        UserID:    authPayload.UserID,
+    }
+
+    if err := validation.Struct(createBankAcctDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.bankAcctSvc.CreateBankAccount(ctx, createBankAcctDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newBankAccount(res), nil
+}
+
+func (s *bankAccountServer) GetBankAccount(ctx context.Context, req *pb.GetBankAccountRequest) (*pb.BankAccount, error) {
+    res, err := s.bankAcctSvc.GetBankAccount(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    return newBankAccount(res), nil
+}
@@ -0,0 +1,94 @@
+package grpcapi_test
+
+import (
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/grpcapi"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "testing"
+)
+
+func TestCreateBankAccount(t *testing.T) {
+    req := &pb.CreateBankAccountRequest{
+        AccountNo: "1234567910",
+        Ifsc:      "HDFC0000076",
+        Currency:  "INR",
+    }
+
+    testcases := []struct {
+        name      string
+        req       *pb.CreateBankAccountRequest
+        buildStub func(mockBankAcctSvc *mocksvc.MockBankAccountSvc)
+        checkResp func(t *testing.T, res *pb.BankAccount, err error)
+    }{
+        {
+            name: "Ok",
+            req:  req,
+            buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
+                mockBankAcctSvc.EXPECT().CreateBankAccount(gomock.Any(), dto.CreateBankAccountDto{
+                    AccountNo: req.AccountNo,
+                    Ifsc:      req.Ifsc,
+                    Currency:  req.Currency,
+                    UserID:    5,
+                }).Times(1).Return(dto.BankAccountDto{ID: 1, AccountNo: req.AccountNo, UserID: 5, Status: domain.BankAccountStatusINVERIFICATION}, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.BankAccount, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(5), res.GetUserId())
+                require.Equal(t, string(domain.BankAccountStatusINVERIFICATION), res.GetStatus())
+            },
+        },
+        {
+            name: "InvalidIfsc",
+            req:  &pb.CreateBankAccountRequest{AccountNo: req.AccountNo, Ifsc: "HDFC", Currency: req.Currency},
+            buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
+                mockBankAcctSvc.EXPECT().CreateBankAccount(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.BankAccount, err error) {
+                require.Equal(t, codes.InvalidArgument, status.Code(err))
+            },
+        },
+        {
+            name: "BankAccountAlreadyExist",
+            req:  req,
+            buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
+                mockBankAcctSvc.EXPECT().CreateBankAccount(gomock.Any(), gomock.Any()).Times(1).Return(dto.BankAccountDto{}, errors.ErrBankAccountAlreadyExist)
+            },
+            checkResp: func(t *testing.T, res *pb.BankAccount, err error) {
+                require.Equal(t, codes.AlreadyExists, status.Code(err))
+            },
+        },
+        {
+            name: "CurrencyNotFound",
+            req:  req,
+            buildStub: func(mockBankAcctSvc *mocksvc.MockBankAccountSvc) {
+                mockBankAcctSvc.EXPECT().CreateBankAccount(gomock.Any(), gomock.Any()).Times(1).Return(dto.BankAccountDto{}, errors.ErrCurrencyNotFound)
+            },
+            checkResp: func(t *testing.T, res *pb.BankAccount, err error) {
+                require.Equal(t, codes.NotFound, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockBankAcctSvc := mocksvc.NewMockBankAccountSvc(ctrl)
+            tc.buildStub(mockBankAcctSvc)
+
+            tokenMaker := NewTestTokenMaker(t)
+            conn := NewTestClientConn(t, tokenMaker, grpcapi.Services{BankAccountSvc: mockBankAcctSvc})
+
+            res, err := pb.NewBankAccountServiceClient(conn).CreateBankAccount(AuthorizedContext(t, tokenMaker, 5), tc.req)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
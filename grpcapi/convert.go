@@ -0,0 +1,130 @@
+package grpcapi
+
+import (
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pb"
+    "google.golang.org/protobuf/types/known/timestamppb"
+    "time"
+)
+
+// timestamp leaves the zero time unset rather than sending 0001-01-01.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+    if t.IsZero() {
+        return nil
+    }
+    return timestamppb.New(t)
+}
+
+func newUser(user dto.UserDto) *pb.User {
+    return &pb.User{
+        Id:                user.ID,
+        Username:          user.Username,
+        Status:            user.Status,
+        FullName:          user.FullName,
+        Email:             user.Email,
+        PasswordChangedAt: timestamp(user.PasswordChangedAt),
+        CreatedAt:         timestamp(user.CreatedAt),
+    }
+}
+
+func newLoginUserResponse(loggedInUser dto.LoggedInUserDto) *pb.LoginUserResponse {
+    return &pb.LoginUserResponse{
+        AccessToken:       loggedInUser.AccessToken,
+        User:              newUser(loggedInUser.User),
+        TwoFactorRequired: loggedInUser.TwoFactorRequired,
+        TwoFactorToken:    loggedInUser.TwoFactorToken,
+    }
+}
+
+func newWallet(wallet dto.WalletDto) *pb.Wallet {
+    return &pb.Wallet{
+        Id:                   wallet.ID,
+        Address:              wallet.Address,
+        Status:               string(wallet.Status),
+        UserId:               wallet.UserID,
+        BankAccountId:        wallet.BankAccountID,
+        OrganizationWalletId: wallet.OrganizationWalletID,
+        Balance:              wallet.Balance,
+        HeldBalance:          wallet.HeldBalance,
+        AvailableBalance:     wallet.AvailableBalance,
+        Currency:             wallet.Currency,
+        CreatedAt:            timestamp(wallet.CreatedAt),
+        UpdatedAt:            timestamp(wallet.UpdatedAt),
+    }
+}
+
+func newEntry(entry dto.EntryDto) *pb.Entry {
+    return &pb.Entry{
+        Id:         entry.ID,
+        WalletId:   entry.WalletID,
+        Amount:     entry.Amount,
+        TransferId: entry.TransferID,
+        CreatedAt:  timestamp(entry.CreatedAt),
+    }
+}
+
+func newTransfer(transfer dto.TransferDto) *pb.Transfer {
+    return &pb.Transfer{
+        Id:                 transfer.ID,
+        FromWalletId:       transfer.FromWalletID,
+        ToWalletId:         transfer.ToWalletID,
+        Amount:             transfer.Amount,
+        Kind:               string(transfer.Kind),
+        OriginalTransferId: originalTransferID(transfer),
+        RefundedAmount:     transfer.RefundedAmount,
+        RefundStatus:       string(transfer.RefundStatus),
+        CreatedAt:          timestamp(transfer.CreatedAt),
+    }
+}
+
+// originalTransferID is 0 on the transfers that are not refunds.
+func originalTransferID(transfer dto.TransferDto) int64 {
+    if transfer.OriginalTransferID == nil {
+        return 0
+    }
+    return *transfer.OriginalTransferID
+}
+
+func newWalletTransferResult(res dto.WalletTransferResultDto) *pb.WalletTransferResult {
+    return &pb.WalletTransferResult{
+        Wallet:    newWallet(res.Wallet),
+        FromEntry: newEntry(res.FromEntry),
+        ToEntry:   newEntry(res.ToEntry),
+        Transfer:  newTransfer(res.Transfer),
+    }
+}
+
+func newBankAccount(bankAcct dto.BankAccountDto) *pb.BankAccount {
+    return &pb.BankAccount{
+        Id:        bankAcct.ID,
+        AccountNo: bankAcct.AccountNo,
+        Ifsc:      bankAcct.Ifsc,
+        BankName:  bankAcct.BankName,
+        Status:    string(bankAcct.Status),
+        UserId:    bankAcct.UserID,
+        Currency:  bankAcct.Currency,
+        CreatedAt: timestamp(bankAcct.CreatedAt),
+        UpdatedAt: timestamp(bankAcct.UpdatedAt),
+    }
+}
+
+func newCurrency(currency dto.CurrencyDto) *pb.Currency {
+    return &pb.Currency{
+        Code:      currency.Code,
+        Fraction:  currency.Fraction,
+        CreatedAt: timestamp(currency.CreatedAt),
+    }
+}
+
+func newPaymentRequest(payReq dto.PaymentRequestDto) *pb.PaymentRequest {
+    return &pb.PaymentRequest{
+        Id:                payReq.ID,
+        FromWalletId:      payReq.FromWalletID,
+        ToWalletId:        payReq.ToWalletID,
+        FromWalletAddress: payReq.FromWalletAddress,
+        ToWalletAddress:   payReq.ToWalletAddress,
+        Amount:            payReq.Amount,
+        Status:            string(payReq.Status),
+        CreatedAt:         timestamp(payReq.CreatedAt),
+    }
+}
@@ -0,0 +1,48 @@
+package grpcapi
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+)
+
+type currencyServer struct {
+    pb.UnimplementedCurrencyServiceServer
+    currencySvc service.CurrencySvc
+}
+
+func NewCurrencyServer(currencySvc service.CurrencySvc) pb.CurrencyServiceServer {
+    return &currencyServer{
+        currencySvc: currencySvc,
+    }
+}
+
+func (s *currencyServer) CreateCurrency(ctx context.Context, req *pb.CreateCurrencyRequest) (*pb.Currency, error) {
+    currencyDto := dto.CurrencyDto{
+        Code:     req.GetCode(),
+        Fraction: req.GetFraction(),
+    }
+
+    if err := validation.Struct(currencyDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.currencySvc.CreateCurrency(ctx, currencyDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newCurrency(res), nil
+}
+
+func (s *currencyServer) GetCurrency(ctx context.Context, req *pb.GetCurrencyRequest) (*pb.Currency, error) {
+    res, err := s.currencySvc.GetCurrency(ctx, req.GetCode())
+    if err != nil {
+        return nil, err
+    }
+
+    return newCurrency(res), nil
+}
@@ -0,0 +1,160 @@
+package grpcapi
+
+import (
+    "context"
+    "database/sql"
+    "github.com/google/uuid"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/token"
+    log "github.com/sirupsen/logrus"
+    "go.opentelemetry.io/otel/attribute"
+    otelcodes "go.opentelemetry.io/otel/codes"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/peer"
+    "google.golang.org/grpc/status"
+    "net/http"
+    "strings"
+    "time"
+)
+
+// publicMethods are served without an access token, the way the user routes are.
+var publicMethods = map[string]bool{
+    pb.UserService_CreateUser_FullMethodName:  true,
+    pb.UserService_LoginUser_FullMethodName:   true,
+    pb.UserService_VerifyLogin_FullMethodName: true,
+}
+
+// metadataValue is the first value of key in the metadata of the call, "" when there is none.
+func metadataValue(ctx context.Context, key string) string {
+    md, ok := metadata.FromIncomingContext(ctx)
+    if !ok {
+        return ""
+    }
+    if values := md.Get(key); len(values) > 0 {
+        return values[0]
+    }
+    return ""
+}
+
+// Tracing runs every call in a server span named after the method, the child of the span of the caller when the
+// call carries a traceparent metadata.
+func Tracing(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    h := http.Header{}
+    h.Set(trace.TraceparentHeader, metadataValue(ctx, trace.TraceparentHeader))
+    ctx = trace.Extract(ctx, h)
+
+    ctx, span := trace.Start(ctx, info.FullMethod, oteltrace.WithSpanKind(oteltrace.SpanKindServer), oteltrace.WithAttributes(
+        attribute.String("rpc.system", "grpc"),
+        attribute.String("rpc.method", info.FullMethod),
+    ))
+    defer span.End()
+
+    res, err := handler(ctx, req)
+
+    code := status.Code(err)
+    span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+    if code == codes.Internal || code == codes.Unknown {
+        span.SetStatus(otelcodes.Error, err.Error())
+    }
+
+    return res, err
+}
+
+// Logging puts the call logger in the context, with the request ID of the x-request-id metadata or a new one,
+// and logs a line for every call once it is served.
+func Logging(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    start := time.Now()
+
+    reqID := metadataValue(ctx, "x-request-id")
+    if reqID == "" {
+        reqID = uuid.New().String()
+    }
+    ctx = logging.WithFields(ctx, log.Fields{
+        "request_id": reqID,
+        "method":     info.FullMethod,
+    })
+
+    res, err := handler(ctx, req)
+
+    code := status.Code(err)
+    logger := logging.FromContext(ctx).WithFields(log.Fields{
+        "code":        code.String(),
+        "duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+    })
+    if p, ok := peer.FromContext(ctx); ok {
+        logger = logger.WithField("remote_addr", p.Addr.String())
+    }
+    if code == codes.Internal || code == codes.Unknown {
+        logger.Error("call served")
+        return res, err
+    }
+    logger.Info("call served")
+
+    return res, err
+}
+
+// Errors turns the errors of the services into gRPC status errors, see errors.Code.
+func Errors(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+    res, err := handler(ctx, req)
+    return res, errors.GRPCStatus(err)
+}
+
+// Auth verifies the bearer token of the authorization metadata, like middleware.Auth does for the routes, and puts
+// its payload in the context under constant.AuthorizationPayloadKey. The public methods are let through.
+func Auth(tokenMaker token.Maker, userRepo store.UserRepo) grpc.UnaryServerInterceptor {
+    return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+        if publicMethods[info.FullMethod] {
+            return handler(ctx, req)
+        }
+
+        authorization := metadataValue(ctx, constant.AuthorizationHeaderKey)
+        if len(authorization) == 0 {
+            return nil, errors.ErrMissingAuthHeader
+        }
+
+        fields := strings.Fields(authorization)
+        if len(fields) < 2 {
+            return nil, errors.ErrInvalidAuthHeaderFormat
+        }
+
+        authorizationType := strings.ToLower(fields[0])
+        if authorizationType != constant.AuthorizationTypeBearer {
+            return nil, errors.ErrUnsupportedAuth
+        }
+
+        payload, err := tokenMaker.VerifyToken(fields[1])
+        if err != nil || payload.Purpose != "" {
+            return nil, errors.ErrUnauthorized
+        }
+
+        user, err := userRepo.GetUser(ctx, payload.UserID)
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return nil, errors.ErrUnauthorized
+            }
+            return nil, err
+        }
+
+        if user.TokenRevoked(payload.IssuedAt) {
+            return nil, errors.ErrTokenRevoked
+        }
+
+        if user.Status != domain.UserStatusACTIVE {
+            return nil, errors.ErrUserBlocked
+        }
+
+        logging.AddFields(ctx, log.Fields{"user_id": payload.UserID})
+
+        ctx = context.WithValue(ctx, constant.AuthorizationPayloadKey, payload)
+        return handler(ctx, req)
+    }
+}
@@ -0,0 +1,177 @@
+package grpcapi_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/grpcapi"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "testing"
+    "time"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+    testcases := []struct {
+        name      string
+        setupAuth func(t *testing.T, tokenMaker token.Maker) context.Context
+        buildStub func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo)
+        checkResp func(t *testing.T, res *pb.Wallet, err error)
+    }{
+        {
+            name: "Ok",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                return AuthorizedContext(t, tokenMaker, 1)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{ID: 1, Status: domain.UserStatusACTIVE}, nil)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), int64(7)).Times(1).
+                    DoAndReturn(func(ctx context.Context, id int64) (dto.WalletDto, error) {
+                        payload := ctx.Value(constant.AuthorizationPayloadKey).(*token.Payload)
+                        require.Equal(t, int64(1), payload.UserID)
+                        return dto.WalletDto{ID: id}, nil
+                    })
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(7), res.GetId())
+            },
+        },
+        {
+            name: "NoAuthorization",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                return context.Background()
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "UnsupportedAuthorization",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                accessToken, err := tokenMaker.CreateToken(1, time.Minute)
+                require.NoError(t, err)
+                return metadata.AppendToOutgoingContext(context.Background(), constant.AuthorizationHeaderKey, "unsupported "+accessToken)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "InvalidAuthorizationFormat",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                return metadata.AppendToOutgoingContext(context.Background(), constant.AuthorizationHeaderKey, constant.AuthorizationTypeBearer)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "ExpiredToken",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                accessToken, err := tokenMaker.CreateToken(1, -time.Minute)
+                require.NoError(t, err)
+                return metadata.AppendToOutgoingContext(context.Background(), constant.AuthorizationHeaderKey, "Bearer "+accessToken)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "TwoFactorToken",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                twoFactorToken, err := tokenMaker.CreatePurposeToken(1, token.PurposeTwoFactor, time.Minute)
+                require.NoError(t, err)
+                return metadata.AppendToOutgoingContext(context.Background(), constant.AuthorizationHeaderKey, "Bearer "+twoFactorToken)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "RevokedToken",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                return AuthorizedContext(t, tokenMaker, 1)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{ID: 1, PasswordChangedAt: time.Now().Add(time.Minute)}, nil)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "BlockedUser",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                return AuthorizedContext(t, tokenMaker, 1)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{ID: 1, Status: domain.UserStatusBLOCKED}, nil)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.PermissionDenied, status.Code(err))
+            },
+        },
+        {
+            name: "UserNotFound",
+            setupAuth: func(t *testing.T, tokenMaker token.Maker) context.Context {
+                return AuthorizedContext(t, tokenMaker, 1)
+            },
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{}, sql.ErrNoRows)
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            tc.buildStub(mockWalletSvc, mockUserRepo)
+
+            tokenMaker := NewTestTokenMaker(t)
+            conn := NewTestClientConnWithUserRepo(t, tokenMaker, mockUserRepo, grpcapi.Services{WalletSvc: mockWalletSvc})
+
+            res, err := pb.NewWalletServiceClient(conn).GetWallet(tc.setupAuth(t, tokenMaker), &pb.GetWalletRequest{Id: 7})
+            tc.checkResp(t, res, err)
+        })
+    }
+}
@@ -0,0 +1,89 @@
+package grpcapi
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+)
+
+type paymentRequestServer struct {
+    pb.UnimplementedPaymentRequestServiceServer
+    payReqSvc service.PaymentRequestSvc
+}
+
+func NewPaymentRequestServer(payReqSvc service.PaymentRequestSvc) pb.PaymentRequestServiceServer {
+    return &paymentRequestServer{
+        payReqSvc: payReqSvc,
+    }
+}
+
+func (s *paymentRequestServer) CreatePaymentRequest(ctx context.Context, req *pb.CreatePaymentRequestRequest) (*pb.PaymentRequest, error) {
+    payReqDto := dto.PaymentRequestDto{
+        FromWalletAddress: req.GetFromWalletAddress(),
+        ToWalletAddress:   req.GetToWalletAddress(),
+        Amount:            req.GetAmount(),
+    }
+
+    if err := validation.Struct(payReqDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.payReqSvc.Create(ctx, payReqDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newPaymentRequest(res), nil
+}
+
+func (s *paymentRequestServer) GetPaymentRequest(ctx context.Context, req *pb.GetPaymentRequestRequest) (*pb.PaymentRequest, error) {
+    res, err := s.payReqSvc.Get(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    return newPaymentRequest(res), nil
+}
+
+func (s *paymentRequestServer) ListPaymentRequests(ctx context.Context, req *pb.ListPaymentRequestsRequest) (*pb.ListPaymentRequestsResponse, error) {
+    res, err := s.payReqSvc.List(ctx, dto.ListPaymentRequestsDto{
+        FromWalletID: req.GetFromWalletId(),
+        Limit:        req.GetLimit(),
+        Offset:       req.GetOffset(),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    payReqs := make([]*pb.PaymentRequest, 0, len(res))
+    for _, payReq := range res {
+        payReqs = append(payReqs, newPaymentRequest(payReq))
+    }
+
+    return &pb.ListPaymentRequestsResponse{PaymentRequests: payReqs}, nil
+}
+
+func (s *paymentRequestServer) ApprovePaymentRequest(ctx context.Context, req *pb.GetPaymentRequestRequest) (*pb.PaymentRequest, error) {
+    res, err := s.payReqSvc.Approve(ctx, dto.ApprovePaymentRequestDto{
+        ID:       req.GetId(),
+        TOTPCode: metadataValue(ctx, constant.TOTPCodeHeaderKey),
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return newPaymentRequest(res), nil
+}
+
+func (s *paymentRequestServer) RefusePaymentRequest(ctx context.Context, req *pb.GetPaymentRequestRequest) (*pb.PaymentRequest, error) {
+    res, err := s.payReqSvc.Refuse(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    return newPaymentRequest(res), nil
+}
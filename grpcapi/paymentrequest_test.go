@@ -0,0 +1,91 @@
+package grpcapi_test
+
+import (
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/grpcapi"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "testing"
+)
+
+func TestApprovePaymentRequest(t *testing.T) {
+    approveDto := dto.ApprovePaymentRequestDto{ID: 3, TOTPCode: "123456"}
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockPayReqSvc *mocksvc.MockPaymentRequestSvc)
+        checkResp func(t *testing.T, res *pb.PaymentRequest, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockPayReqSvc.EXPECT().Approve(gomock.Any(), approveDto).Times(1).
+                    Return(dto.PaymentRequestDto{ID: 3, Amount: 100, Status: domain.PaymentRequestStatusAPPROVED}, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.PaymentRequest, err error) {
+                require.NoError(t, err)
+                require.Equal(t, string(domain.PaymentRequestStatusAPPROVED), res.GetStatus())
+            },
+        },
+        {
+            name: "PaymentRequestNotFound",
+            buildStub: func(mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockPayReqSvc.EXPECT().Approve(gomock.Any(), approveDto).Times(1).Return(dto.PaymentRequestDto{}, errors.ErrPaymentRequestNotFound)
+            },
+            checkResp: func(t *testing.T, res *pb.PaymentRequest, err error) {
+                require.Equal(t, codes.NotFound, status.Code(err))
+            },
+        },
+        {
+            name: "PaymentRequestNotPending",
+            buildStub: func(mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockPayReqSvc.EXPECT().Approve(gomock.Any(), approveDto).Times(1).Return(dto.PaymentRequestDto{}, errors.ErrPaymentRequestNotPending)
+            },
+            checkResp: func(t *testing.T, res *pb.PaymentRequest, err error) {
+                require.Equal(t, codes.FailedPrecondition, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockPayReqSvc := mocksvc.NewMockPaymentRequestSvc(ctrl)
+            tc.buildStub(mockPayReqSvc)
+
+            tokenMaker := NewTestTokenMaker(t)
+            conn := NewTestClientConn(t, tokenMaker, grpcapi.Services{PaymentRequestSvc: mockPayReqSvc})
+
+            ctx := metadata.AppendToOutgoingContext(AuthorizedContext(t, tokenMaker, 1), constant.TOTPCodeHeaderKey, "123456")
+            res, err := pb.NewPaymentRequestServiceClient(conn).ApprovePaymentRequest(ctx, &pb.GetPaymentRequestRequest{Id: 3})
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestListPaymentRequests(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockPayReqSvc := mocksvc.NewMockPaymentRequestSvc(ctrl)
+    mockPayReqSvc.EXPECT().List(gomock.Any(), dto.ListPaymentRequestsDto{FromWalletID: 1, Limit: 5, Offset: 0}).Times(1).
+        Return([]dto.PaymentRequestDto{{ID: 1, FromWalletID: 1}, {ID: 2, FromWalletID: 1}}, nil)
+
+    tokenMaker := NewTestTokenMaker(t)
+    conn := NewTestClientConn(t, tokenMaker, grpcapi.Services{PaymentRequestSvc: mockPayReqSvc})
+
+    res, err := pb.NewPaymentRequestServiceClient(conn).ListPaymentRequests(AuthorizedContext(t, tokenMaker, 1), &pb.ListPaymentRequestsRequest{FromWalletId: 1, Limit: 5})
+    require.NoError(t, err)
+    require.Len(t, res.GetPaymentRequests(), 2)
+    require.Equal(t, int64(2), res.GetPaymentRequests()[1].GetId())
+}
@@ -0,0 +1,36 @@
+// Package grpcapi serves the user, wallet, bank account, currency and payment request services over gRPC, next to
+// the REST api. The servers are as thin as the resources of the api package: they validate the request with the
+// dtos and leave the rest to the services, whose errors are mapped to status codes by the Errors interceptor.
+package grpcapi
+
+import (
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/token"
+    "google.golang.org/grpc"
+)
+
+type Services struct {
+    UserSvc           service.UserSvc
+    TwoFactorSvc      service.TwoFactorSvc
+    WalletSvc         service.WalletSvc
+    BankAccountSvc    service.BankAccountSvc
+    CurrencySvc       service.CurrencySvc
+    PaymentRequestSvc service.PaymentRequestSvc
+}
+
+// NewServer returns the gRPC server of svc, the calls are traced, logged and authenticated with tokenMaker, the
+// tokens revoked by a password change are read from userRepo.
+func NewServer(tokenMaker token.Maker, userRepo store.UserRepo, svc Services, opts ...grpc.ServerOption) *grpc.Server {
+    opts = append(opts, grpc.ChainUnaryInterceptor(Tracing, Logging, Errors, Auth(tokenMaker, userRepo)))
+    server := grpc.NewServer(opts...)
+
+    pb.RegisterUserServiceServer(server, NewUserServer(svc.UserSvc, svc.TwoFactorSvc))
+    pb.RegisterWalletServiceServer(server, NewWalletServer(svc.WalletSvc))
+    pb.RegisterBankAccountServiceServer(server, NewBankAccountServer(svc.BankAccountSvc))
+    pb.RegisterCurrencyServiceServer(server, NewCurrencyServer(svc.CurrencySvc))
+    pb.RegisterPaymentRequestServiceServer(server, NewPaymentRequestServer(svc.PaymentRequestSvc))
+
+    return server
+}
@@ -0,0 +1,76 @@
+package grpcapi_test
+
+import (
+    "context"
+    "fmt"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/grpcapi"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/test/bufconn"
+    "net"
+    "testing"
+    "time"
+)
+
+func NewTestTokenMaker(t *testing.T) token.Maker {
+    tokenMaker, err := token.NewJWTMaker(util.RandomString(32))
+    require.NoError(t, err)
+    return tokenMaker
+}
+
+// NewTestUserRepo returns a user repo whose users never changed their password, so that no token is revoked.
+func NewTestUserRepo(t *testing.T) store.UserRepo {
+    ctrl := gomock.NewController(t)
+    mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+    mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+        DoAndReturn(func(ctx context.Context, id int64) (domain.User, error) {
+            return domain.User{ID: id, Status: domain.UserStatusACTIVE}, nil
+        })
+    return mockUserRepo
+}
+
+// NewTestClientConn serves svc on an in-memory listener and returns the client connection to it.
+func NewTestClientConn(t *testing.T, tokenMaker token.Maker, svc grpcapi.Services) *grpc.ClientConn {
+    return NewTestClientConnWithUserRepo(t, tokenMaker, NewTestUserRepo(t), svc)
+}
+
+// NewTestClientConnWithUserRepo is NewTestClientConn with the users of userRepo.
+func NewTestClientConnWithUserRepo(t *testing.T, tokenMaker token.Maker, userRepo store.UserRepo, svc grpcapi.Services) *grpc.ClientConn {
+    lis := bufconn.Listen(1024 * 1024)
+    server := grpcapi.NewServer(tokenMaker, userRepo, svc)
+    go func() {
+        _ = server.Serve(lis)
+    }()
+    t.Cleanup(server.Stop)
+
+    conn, err := grpc.NewClient("passthrough:///bufconn",
+        grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+            return lis.DialContext(ctx)
+        }),
+        grpc.WithTransportCredentials(insecure.NewCredentials()),
+    )
+    require.NoError(t, err)
+    t.Cleanup(func() {
+        _ = conn.Close()
+    })
+
+    return conn
+}
+
+// AuthorizedContext returns the context of a call authorized with the token of userID.
+func AuthorizedContext(t *testing.T, tokenMaker token.Maker, userID int64) context.Context {
+    accessToken, err := tokenMaker.CreateToken(userID, time.Minute)
+    require.NoError(t, err)
+
+    authorization := fmt.Sprintf("%s %s", constant.AuthorizationTypeBearer, accessToken)
+    return metadata.AppendToOutgoingContext(context.Background(), constant.AuthorizationHeaderKey, authorization)
+}
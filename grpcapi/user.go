@@ -0,0 +1,79 @@
+package grpcapi
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+)
+
+type userServer struct {
+    pb.UnimplementedUserServiceServer
+    userSvc      service.UserSvc
+    twoFactorSvc service.TwoFactorSvc
+}
+
+func NewUserServer(userSvc service.UserSvc, twoFactorSvc service.TwoFactorSvc) pb.UserServiceServer {
+    return &userServer{
+        userSvc:      userSvc,
+        twoFactorSvc: twoFactorSvc,
+    }
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+    createUserDto := dto.CreateUserDto{
+        Username: req.GetUsername(),
+        Password: req.GetPassword(),
+        FullName: req.GetFullName(),
+        Email:    req.GetEmail(),
+    }
+
+    if err := validation.Struct(createUserDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.userSvc.CreateUser(ctx, createUserDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newUser(res), nil
+}
+
+func (s *userServer) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+    loginCredsDto := dto.LoginCredentialsDto{
+        Username: req.GetUsername(),
+        Password: req.GetPassword(),
+    }
+
+    if err := validation.Struct(loginCredsDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.userSvc.LoginUser(ctx, loginCredsDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newLoginUserResponse(res), nil
+}
+
+func (s *userServer) VerifyLogin(ctx context.Context, req *pb.VerifyLoginRequest) (*pb.LoginUserResponse, error) {
+    verifyLoginDto := dto.VerifyLoginDto{
+        TwoFactorToken: req.GetTwoFactorToken(),
+        Code:           req.GetCode(),
+    }
+
+    if err := validation.Struct(verifyLoginDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.twoFactorSvc.VerifyLogin(ctx, verifyLoginDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newLoginUserResponse(res), nil
+}
@@ -0,0 +1,227 @@
+package grpcapi_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/grpcapi"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+    "testing"
+)
+
+func TestCreateUser(t *testing.T) {
+    req := &pb.CreateUserRequest{
+        Username: "walter",
+        Password: "secret",
+        FullName: "Walter White",
+        Email:    "walter@email.com",
+    }
+
+    testcases := []struct {
+        name      string
+        req       *pb.CreateUserRequest
+        buildStub func(mockUserSvc *mocksvc.MockUserSvc)
+        checkResp func(t *testing.T, res *pb.User, err error)
+    }{
+        {
+            name: "Ok",
+            req:  req,
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().CreateUser(gomock.Any(), dto.CreateUserDto{
+                    Username: req.Username,
+                    Password: req.Password,
+                    FullName: req.FullName,
+                    Email:    req.Email,
+                }).Times(1).Return(dto.UserDto{ID: 1, Username: req.Username, Status: "ACTIVE"}, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.User, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(1), res.GetId())
+                require.Equal(t, req.Username, res.GetUsername())
+                require.Nil(t, res.GetCreatedAt())
+            },
+        },
+        {
+            name: "InvalidEmail",
+            req:  &pb.CreateUserRequest{Username: "walter", Password: "secret", FullName: "Walter White", Email: "walter"},
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.User, err error) {
+                require.Equal(t, codes.InvalidArgument, status.Code(err))
+            },
+        },
+        {
+            name: "UserAlreadyExist",
+            req:  req,
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(dto.UserDto{}, errors.ErrUserAlreadyExist)
+            },
+            checkResp: func(t *testing.T, res *pb.User, err error) {
+                require.Equal(t, codes.AlreadyExists, status.Code(err))
+                require.Equal(t, errors.ErrUserAlreadyExist.Error(), status.Convert(err).Message())
+            },
+        },
+        {
+            name: "InternalServerError",
+            req:  req,
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(dto.UserDto{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, res *pb.User, err error) {
+                require.Equal(t, codes.Internal, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserSvc := mocksvc.NewMockUserSvc(ctrl)
+            tc.buildStub(mockUserSvc)
+
+            conn := NewTestClientConn(t, NewTestTokenMaker(t), grpcapi.Services{UserSvc: mockUserSvc})
+
+            res, err := pb.NewUserServiceClient(conn).CreateUser(context.Background(), tc.req)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestLoginUser(t *testing.T) {
+    req := &pb.LoginUserRequest{Username: "walter", Password: "secret"}
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockUserSvc *mocksvc.MockUserSvc)
+        checkResp func(t *testing.T, res *pb.LoginUserResponse, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().LoginUser(gomock.Any(), dto.LoginCredentialsDto{Username: req.Username, Password: req.Password}).Times(1).
+                    Return(dto.LoggedInUserDto{AccessToken: "token", User: dto.UserDto{ID: 1, Username: req.Username}}, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.NoError(t, err)
+                require.Equal(t, "token", res.GetAccessToken())
+                require.Equal(t, int64(1), res.GetUser().GetId())
+                require.False(t, res.GetTwoFactorRequired())
+            },
+        },
+        {
+            name: "TwoFactorRequired",
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().LoginUser(gomock.Any(), gomock.Any()).Times(1).
+                    Return(dto.LoggedInUserDto{TwoFactorRequired: true, TwoFactorToken: "two-factor-token", User: dto.UserDto{ID: 1}}, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.NoError(t, err)
+                require.Empty(t, res.GetAccessToken())
+                require.True(t, res.GetTwoFactorRequired())
+                require.Equal(t, "two-factor-token", res.GetTwoFactorToken())
+            },
+        },
+        {
+            name: "IncorrectPassword",
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().LoginUser(gomock.Any(), gomock.Any()).Times(1).Return(dto.LoggedInUserDto{}, errors.ErrIncorrectPassword)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+        {
+            name: "UserBlocked",
+            buildStub: func(mockUserSvc *mocksvc.MockUserSvc) {
+                mockUserSvc.EXPECT().LoginUser(gomock.Any(), gomock.Any()).Times(1).Return(dto.LoggedInUserDto{}, errors.ErrUserBlocked)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.Equal(t, codes.PermissionDenied, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserSvc := mocksvc.NewMockUserSvc(ctrl)
+            tc.buildStub(mockUserSvc)
+
+            conn := NewTestClientConn(t, NewTestTokenMaker(t), grpcapi.Services{UserSvc: mockUserSvc})
+
+            res, err := pb.NewUserServiceClient(conn).LoginUser(context.Background(), req)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestVerifyLogin(t *testing.T) {
+    req := &pb.VerifyLoginRequest{TwoFactorToken: "two-factor-token", Code: "123456"}
+
+    testcases := []struct {
+        name      string
+        req       *pb.VerifyLoginRequest
+        buildStub func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp func(t *testing.T, res *pb.LoginUserResponse, err error)
+    }{
+        {
+            name: "Ok",
+            req:  req,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().VerifyLogin(gomock.Any(), dto.VerifyLoginDto{TwoFactorToken: req.TwoFactorToken, Code: req.Code}).Times(1).
+                    Return(dto.LoggedInUserDto{AccessToken: "token", User: dto.UserDto{ID: 1}}, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.NoError(t, err)
+                require.Equal(t, "token", res.GetAccessToken())
+                require.Equal(t, int64(1), res.GetUser().GetId())
+            },
+        },
+        {
+            name: "MissingCode",
+            req:  &pb.VerifyLoginRequest{TwoFactorToken: req.TwoFactorToken},
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().VerifyLogin(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.Equal(t, codes.InvalidArgument, status.Code(err))
+            },
+        },
+        {
+            name: "InvalidCode",
+            req:  req,
+            buildStub: func(mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockTwoFactorSvc.EXPECT().VerifyLogin(gomock.Any(), gomock.Any()).Times(1).Return(dto.LoggedInUserDto{}, errors.ErrInvalidTOTPCode)
+            },
+            checkResp: func(t *testing.T, res *pb.LoginUserResponse, err error) {
+                require.Equal(t, codes.Unauthenticated, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockTwoFactorSvc)
+
+            conn := NewTestClientConn(t, NewTestTokenMaker(t), grpcapi.Services{TwoFactorSvc: mockTwoFactorSvc})
+
+            res, err := pb.NewUserServiceClient(conn).VerifyLogin(context.Background(), tc.req)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
@@ -0,0 +1,80 @@
+package grpcapi
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/service"
+)
+
+type walletServer struct {
+    pb.UnimplementedWalletServiceServer
+    walletSvc service.WalletSvc
+}
+
+func NewWalletServer(walletSvc service.WalletSvc) pb.WalletServiceServer {
+    return &walletServer{
+        walletSvc: walletSvc,
+    }
+}
+
+func (s *walletServer) GetWallet(ctx context.Context, req *pb.GetWalletRequest) (*pb.Wallet, error) {
+    res, err := s.walletSvc.GetWalletById(ctx, req.GetId())
+    if err != nil {
+        return nil, err
+    }
+
+    return newWallet(res), nil
+}
+
+func (s *walletServer) GetWalletByAddress(ctx context.Context, req *pb.GetWalletByAddressRequest) (*pb.Wallet, error) {
+    res, err := s.walletSvc.GetWalletByAddress(ctx, req.GetAddress())
+    if err != nil {
+        return nil, err
+    }
+
+    return newWallet(res), nil
+}
+
+func (s *walletServer) Pay(ctx context.Context, req *pb.PayRequest) (*pb.WalletTransferResult, error) {
+    transferMoneyDto := dto.TransferMoneyDto{
+        FromWalletAddress: req.GetFromWalletAddress(),
+        ToWalletAddress:   req.GetToWalletAddress(),
+        Amount:            req.GetAmount(),
+        TOTPCode:          metadataValue(ctx, constant.TOTPCodeHeaderKey),
+    }
+
+    if err := validation.Struct(transferMoneyDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.walletSvc.Pay(ctx, transferMoneyDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newWalletTransferResult(res), nil
+}
+
+func (s *walletServer) PayByWalletID(ctx context.Context, req *pb.PayByWalletIDRequest) (*pb.WalletTransferResult, error) {
+    transferMoneyDto := dto.TransferMoneyByWalletIDDto{
+        FromWalletID: req.GetFromWalletId(),
+        ToWalletID:   req.GetToWalletId(),
+        Amount:       req.GetAmount(),
+        TOTPCode:     metadataValue(ctx, constant.TOTPCodeHeaderKey),
+    }
+
+    if err := validation.Struct(transferMoneyDto); err != nil {
+        return nil, errors.InvalidArgument(err)
+    }
+
+    res, err := s.walletSvc.PayByWalletID(ctx, transferMoneyDto)
+    if err != nil {
+        return nil, err
+    }
+
+    return newWalletTransferResult(res), nil
+}
@@ -0,0 +1,164 @@
+package grpcapi_test
+
+import (
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/grpcapi"
+    "github.com/pranayhere/simple-wallet/pb"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/metadata"
+    "google.golang.org/grpc/status"
+    "testing"
+    "time"
+)
+
+func TestGetWallet(t *testing.T) {
+    wallet := dto.WalletDto{
+        ID:               7,
+        Address:          "walter_1234@my.wallet",
+        Status:           domain.WalletStatusACTIVE,
+        UserID:           1,
+        Balance:          1500,
+        HeldBalance:      500,
+        AvailableBalance: 1000,
+        Currency:         "INR",
+        CreatedAt:        time.Now().UTC(),
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockWalletSvc *mocksvc.MockWalletSvc)
+        checkResp func(t *testing.T, res *pb.Wallet, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), wallet.ID).Times(1).Return(wallet, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.NoError(t, err)
+                require.Equal(t, wallet.Address, res.GetAddress())
+                require.Equal(t, string(wallet.Status), res.GetStatus())
+                require.Equal(t, wallet.AvailableBalance, res.GetAvailableBalance())
+                require.True(t, wallet.CreatedAt.Equal(res.GetCreatedAt().AsTime()))
+            },
+        },
+        {
+            name: "WalletNotFound",
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), wallet.ID).Times(1).Return(dto.WalletDto{}, errors.ErrWalletNotFound)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.NotFound, status.Code(err))
+            },
+        },
+        {
+            name: "InternalServerError",
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), wallet.ID).Times(1).Return(dto.WalletDto{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, res *pb.Wallet, err error) {
+                require.Equal(t, codes.Internal, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            tc.buildStub(mockWalletSvc)
+
+            tokenMaker := NewTestTokenMaker(t)
+            conn := NewTestClientConn(t, tokenMaker, grpcapi.Services{WalletSvc: mockWalletSvc})
+
+            res, err := pb.NewWalletServiceClient(conn).GetWallet(AuthorizedContext(t, tokenMaker, 1), &pb.GetWalletRequest{Id: wallet.ID})
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestPay(t *testing.T) {
+    req := &pb.PayRequest{
+        FromWalletAddress: "walter_1234@my.wallet",
+        ToWalletAddress:   "jesse_5678@my.wallet",
+        Amount:            100,
+    }
+
+    testcases := []struct {
+        name      string
+        req       *pb.PayRequest
+        buildStub func(mockWalletSvc *mocksvc.MockWalletSvc)
+        checkResp func(t *testing.T, res *pb.WalletTransferResult, err error)
+    }{
+        {
+            name: "Ok",
+            req:  req,
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), dto.TransferMoneyDto{
+                    FromWalletAddress: req.FromWalletAddress,
+                    ToWalletAddress:   req.ToWalletAddress,
+                    Amount:            req.Amount,
+                    TOTPCode:          "123456",
+                }).Times(1).Return(dto.WalletTransferResultDto{
+                    Wallet:    dto.WalletDto{ID: 1, Address: req.FromWalletAddress, Balance: 900},
+                    FromEntry: dto.EntryDto{ID: 1, WalletID: 1, Amount: -100, TransferID: 3},
+                    ToEntry:   dto.EntryDto{ID: 2, WalletID: 2, Amount: 100, TransferID: 3},
+                    Transfer:  dto.TransferDto{ID: 3, FromWalletID: 1, ToWalletID: 2, Amount: 100, Kind: domain.TransferKindPAYMENT},
+                }, nil)
+            },
+            checkResp: func(t *testing.T, res *pb.WalletTransferResult, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(900), res.GetWallet().GetBalance())
+                require.Equal(t, int64(-100), res.GetFromEntry().GetAmount())
+                require.Equal(t, int64(100), res.GetToEntry().GetAmount())
+                require.Equal(t, string(domain.TransferKindPAYMENT), res.GetTransfer().GetKind())
+            },
+        },
+        {
+            name: "InvalidAmount",
+            req:  &pb.PayRequest{FromWalletAddress: req.FromWalletAddress, ToWalletAddress: req.ToWalletAddress, Amount: -1},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res *pb.WalletTransferResult, err error) {
+                require.Equal(t, codes.InvalidArgument, status.Code(err))
+            },
+        },
+        {
+            name: "InsufficientBalance",
+            req:  req,
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(1).Return(dto.WalletTransferResultDto{}, errors.ErrInsufficientBalance)
+            },
+            checkResp: func(t *testing.T, res *pb.WalletTransferResult, err error) {
+                require.Equal(t, codes.FailedPrecondition, status.Code(err))
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            tc.buildStub(mockWalletSvc)
+
+            tokenMaker := NewTestTokenMaker(t)
+            conn := NewTestClientConn(t, tokenMaker, grpcapi.Services{WalletSvc: mockWalletSvc})
+
+            ctx := metadata.AppendToOutgoingContext(AuthorizedContext(t, tokenMaker, 1), constant.TOTPCodeHeaderKey, "123456")
+            res, err := pb.NewWalletServiceClient(conn).Pay(ctx, tc.req)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
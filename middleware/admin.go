@@ -0,0 +1,43 @@
+package middleware
+
+import (
+    "database/sql"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/token"
+    "net/http"
+)
+
+// Admin lets through only the users with the ADMIN role, it must be mounted after Auth.
+// The role is read from the database so that a demoted admin loses access right away.
+func Admin(userRepo store.UserRepo) func(next http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            payload, ok := r.Context().Value(constant.AuthorizationPayloadKey).(*token.Payload)
+            if !ok {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrUnauthorized))
+                return
+            }
+
+            user, err := userRepo.GetUser(r.Context(), payload.UserID)
+            if err != nil {
+                if err == sql.ErrNoRows {
+                    _ = render.Render(w, r, errors.ErrResponse(errors.ErrUnauthorized))
+                    return
+                }
+                _ = render.Render(w, r, errors.ErrResponse(err))
+                return
+            }
+
+            if user.Role != domain.UserRoleADMIN || user.Status != domain.UserStatusACTIVE {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrForbidden))
+                return
+            }
+
+            next.ServeHTTP(w, r)
+        })
+    }
+}
@@ -0,0 +1,95 @@
+package middleware_test
+
+import (
+    "database/sql"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+    var userID int64 = 1
+
+    testCases := []struct {
+        name          string
+        buildStub     func(mockUserRepo *mockdb.MockUserRepo)
+        checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name: "OK",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), userID).Times(2).Return(domain.User{ID: userID, Role: domain.UserRoleADMIN, Status: domain.UserStatusACTIVE}, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name: "NotAdmin",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), userID).Times(2).Return(domain.User{ID: userID, Role: domain.UserRoleUSER, Status: domain.UserStatusACTIVE}, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name: "BlockedAdmin",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                // refused by Auth already
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), userID).Times(1).Return(domain.User{ID: userID, Role: domain.UserRoleADMIN, Status: domain.UserStatusBLOCKED}, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name: "UserNotFound",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), userID).Times(1).Return(domain.User{}, sql.ErrNoRows)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            tc.buildStub(mockUserRepo)
+
+            tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+            require.NoError(t, err)
+
+            r := chi.NewRouter()
+            adminPath := "/admin"
+            r.With(middleware.Auth(tokenMaker, mockUserRepo), middleware.Admin(mockUserRepo)).Get(
+                adminPath,
+                func(w http.ResponseWriter, r *http.Request) {
+                    render.JSON(w, r, "Ok")
+                })
+
+            recorder := httptest.NewRecorder()
+            request, err := http.NewRequest(http.MethodGet, adminPath, nil)
+            require.NoError(t, err)
+
+            AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, userID, time.Minute)
+            r.ServeHTTP(recorder, request)
+            tc.checkResponse(t, recorder)
+        })
+    }
+}
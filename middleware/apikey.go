@@ -0,0 +1,96 @@
+package middleware
+
+import (
+    "context"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/token"
+    log "github.com/sirupsen/logrus"
+    "net/http"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// APIKeyAuth authenticates the merchant servers sending the X-Api-Key header, it must be mounted
+// before Auth. The request is then handled as one of the merchant's user, Auth lets it through.
+// Requests without the header are left to Auth. The keys of a merchant whose user is blocked are refused, and so
+// are the keys created before the last password change of the user, like its tokens. userRepo is read on each
+// request.
+//
+// The key must have the "<resource>:<read|write>" scope of the request, the resource is the
+// first segment of the path after the version, e.g. transfers for /v1/transfers, and GET
+// requests only need the read scope.
+func APIKeyAuth(merchantSvc service.MerchantSvc, userRepo store.UserRepo) func(next http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            key := r.Header.Get(constant.APIKeyHeaderKey)
+            if len(key) == 0 {
+                next.ServeHTTP(w, r)
+                return
+            }
+
+            merchant, apiKey, err := merchantSvc.Authenticate(r.Context(), key)
+            if err != nil {
+                _ = render.Render(w, r, errors.ErrResponse(err))
+                return
+            }
+
+            user, err := userRepo.GetUser(r.Context(), merchant.UserID)
+            if err != nil {
+                _ = render.Render(w, r, errors.ErrResponse(err))
+                return
+            }
+
+            if user.TokenRevoked(apiKey.CreatedAt) {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrTokenRevoked))
+                return
+            }
+
+            if user.Status != domain.UserStatusACTIVE {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrUserBlocked))
+                return
+            }
+
+            if !apiKey.HasScope(requestScope(r)) {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrAPIKeyScope))
+                return
+            }
+
+            now := time.Now()
+            payload := &token.Payload{
+                UserID:    merchant.UserID,
+                IssuedAt:  now,
+                ExpiredAt: now,
+            }
+
+            logging.AddFields(r.Context(), log.Fields{"user_id": merchant.UserID, "merchant_id": merchant.ID, "api_key_id": apiKey.ID})
+
+            ctx := context.WithValue(r.Context(), constant.AuthorizationPayloadKey, payload)
+            ctx = context.WithValue(ctx, constant.APIKeyPayloadKey, &apiKey)
+            next.ServeHTTP(w, r.WithContext(ctx))
+        })
+    }
+}
+
+var apiVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+func requestScope(r *http.Request) string {
+    segments := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
+    resource := segments[0]
+    if apiVersion.MatchString(resource) && len(segments) > 1 {
+        resource = segments[1]
+    }
+
+    switch r.Method {
+    case http.MethodGet, http.MethodHead, http.MethodOptions:
+        return resource + ":read"
+    default:
+        return resource + ":write"
+    }
+}
@@ -0,0 +1,248 @@
+package middleware_test
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestAPIKeyAuthMiddleware(t *testing.T) {
+    key := "sk_0123abcd_" + "00112233445566778899aabbccddeeff0011223344556677"
+    merchant := domain.Merchant{ID: 1, UserID: 7}
+
+    testCases := []struct {
+        name          string
+        method        string
+        path          string
+        setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+        buildStub     func(mockMerchantSvc *mocksvc.MockMerchantSvc)
+        checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:   "OK",
+            method: http.MethodPost,
+            path:   "/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                apiKey := domain.APIKey{ID: 1, MerchantID: merchant.ID, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE}
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(merchant, apiKey, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Contains(t, recorder.Body.String(), "7")
+            },
+        },
+        {
+            name:   "Versioned",
+            method: http.MethodPost,
+            path:   "/v1/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                apiKey := domain.APIKey{ID: 1, MerchantID: merchant.ID, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE}
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(merchant, apiKey, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "VersionedOtherResource",
+            method: http.MethodGet,
+            path:   "/v1/merchants",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                apiKey := domain.APIKey{ID: 1, MerchantID: merchant.ID, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE}
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(merchant, apiKey, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "WriteScopeGrantsRead",
+            method: http.MethodGet,
+            path:   "/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                apiKey := domain.APIKey{ID: 1, MerchantID: merchant.ID, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE}
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(merchant, apiKey, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "ReadScopeCantWrite",
+            method: http.MethodPost,
+            path:   "/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                apiKey := domain.APIKey{ID: 1, MerchantID: merchant.ID, Scopes: []string{"transfers:read"}, Status: domain.APIKeyStatusACTIVE}
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(merchant, apiKey, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "OtherResource",
+            method: http.MethodGet,
+            path:   "/merchants",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                apiKey := domain.APIKey{ID: 1, MerchantID: merchant.ID, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE}
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(merchant, apiKey, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name:   "InvalidKey",
+            method: http.MethodGet,
+            path:   "/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                request.Header.Set(constant.APIKeyHeaderKey, key)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(domain.Merchant{}, domain.APIKey{}, errors.ErrInvalidAPIKey)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name:   "FallsBackToToken",
+            method: http.MethodGet,
+            path:   "/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 7, time.Minute)
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+            },
+        },
+        {
+            name:   "NoAuthorization",
+            method: http.MethodGet,
+            path:   "/transfers",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+            },
+            buildStub: func(mockMerchantSvc *mocksvc.MockMerchantSvc) {
+                mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockMerchantSvc := mocksvc.NewMockMerchantSvc(ctrl)
+            tc.buildStub(mockMerchantSvc)
+
+            tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+            require.NoError(t, err)
+
+            r := chi.NewRouter()
+            r.With(middleware.APIKeyAuth(mockMerchantSvc, NewTestUserRepo(t)), middleware.Auth(tokenMaker, NewTestUserRepo(t))).MethodFunc(
+                tc.method,
+                tc.path,
+                func(w http.ResponseWriter, r *http.Request) {
+                    payload := r.Context().Value(constant.AuthorizationPayloadKey).(*token.Payload)
+                    render.JSON(w, r, payload.UserID)
+                })
+
+            recorder := httptest.NewRecorder()
+            request, err := http.NewRequest(tc.method, tc.path, nil)
+            require.NoError(t, err)
+
+            tc.setupAuth(t, request, tokenMaker)
+            r.ServeHTTP(recorder, request)
+            tc.checkResponse(t, recorder)
+        })
+    }
+}
+
+func TestAPIKeyAuthBlockedUser(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    key := "sk_0123abcd_" + "00112233445566778899aabbccddeeff0011223344556677"
+    apiKey := domain.APIKey{ID: 1, MerchantID: 1, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE}
+
+    mockMerchantSvc := mocksvc.NewMockMerchantSvc(ctrl)
+    mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(domain.Merchant{ID: 1, UserID: 7}, apiKey, nil)
+    mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+    mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(7)).Times(1).Return(domain.User{ID: 7, Status: domain.UserStatusBLOCKED}, nil)
+
+    r := chi.NewRouter()
+    r.With(middleware.APIKeyAuth(mockMerchantSvc, mockUserRepo)).Post("/transfers", func(w http.ResponseWriter, r *http.Request) {
+        t.Fatal("the key of a blocked user must not reach the handler")
+    })
+
+    recorder := httptest.NewRecorder()
+    request := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+    request.Header.Set(constant.APIKeyHeaderKey, key)
+    r.ServeHTTP(recorder, request)
+
+    require.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestAPIKeyAuthRevokedKey(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    key := "sk_0123abcd_" + "00112233445566778899aabbccddeeff0011223344556677"
+    apiKey := domain.APIKey{ID: 1, MerchantID: 1, Scopes: []string{"transfers:write"}, Status: domain.APIKeyStatusACTIVE, CreatedAt: time.Now().Add(-time.Hour)}
+
+    mockMerchantSvc := mocksvc.NewMockMerchantSvc(ctrl)
+    mockMerchantSvc.EXPECT().Authenticate(gomock.Any(), key).Times(1).Return(domain.Merchant{ID: 1, UserID: 7}, apiKey, nil)
+    mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+    // the password was changed after the key was created
+    mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(7)).Times(1).
+        Return(domain.User{ID: 7, Status: domain.UserStatusACTIVE, PasswordChangedAt: time.Now().Add(-time.Minute)}, nil)
+
+    r := chi.NewRouter()
+    r.With(middleware.APIKeyAuth(mockMerchantSvc, mockUserRepo)).Post("/transfers", func(w http.ResponseWriter, r *http.Request) {
+        t.Fatal("a key created before the password change must not reach the handler")
+    })
+
+    recorder := httptest.NewRecorder()
+    request := httptest.NewRequest(http.MethodPost, "/transfers", nil)
+    request.Header.Set(constant.APIKeyHeaderKey, key)
+    r.ServeHTTP(recorder, request)
+
+    require.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
@@ -2,17 +2,31 @@ package middleware
 
 import (
     "context"
+    "database/sql"
     "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/pkg/constant"
     "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/store"
     "github.com/pranayhere/simple-wallet/token"
+    log "github.com/sirupsen/logrus"
     "net/http"
     "strings"
 )
 
-func Auth(tokenMaker token.Maker) func(next http.Handler) http.Handler {
+// Auth verifies the bearer token of the user. The requests already authenticated by APIKeyAuth are let through.
+// The tokens issued before the last password change of the user are revoked and the users blocked are refused,
+// userRepo is read on each request.
+// The tokens of a purpose, e.g. of a login waiting for its TOTP code, are not access tokens.
+func Auth(tokenMaker token.Maker, userRepo store.UserRepo) func(next http.Handler) http.Handler {
     return func(next http.Handler) http.Handler {
         return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            if _, ok := r.Context().Value(constant.AuthorizationPayloadKey).(*token.Payload); ok {
+                next.ServeHTTP(w, r)
+                return
+            }
+
             authorizationHeader := r.Header.Get(constant.AuthorizationHeaderKey)
             if len(authorizationHeader) == 0 {
                 _ = render.Render(w, r, errors.ErrResponse(errors.ErrMissingAuthHeader))
@@ -33,11 +47,33 @@ func Auth(tokenMaker token.Maker) func(next http.Handler) http.Handler {
 
             accessToken := fields[1]
             payload, err := tokenMaker.VerifyToken(accessToken)
-            if err != nil {
+            if err != nil || payload.Purpose != "" {
                 _ = render.Render(w, r, errors.ErrResponse(errors.ErrUnauthorized))
                 return
             }
 
+            user, err := userRepo.GetUser(r.Context(), payload.UserID)
+            if err != nil {
+                if err == sql.ErrNoRows {
+                    _ = render.Render(w, r, errors.ErrResponse(errors.ErrUnauthorized))
+                    return
+                }
+                _ = render.Render(w, r, errors.ErrResponse(err))
+                return
+            }
+
+            if user.TokenRevoked(payload.IssuedAt) {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrTokenRevoked))
+                return
+            }
+
+            if user.Status != domain.UserStatusACTIVE {
+                _ = render.Render(w, r, errors.ErrResponse(errors.ErrUserBlocked))
+                return
+            }
+
+            logging.AddFields(r.Context(), log.Fields{"user_id": payload.UserID})
+
             ctx := context.WithValue(r.Context(), constant.AuthorizationPayloadKey, payload)
             next.ServeHTTP(w, r.WithContext(ctx))
         })
@@ -1,11 +1,17 @@
 package middleware_test
 
 import (
+    "context"
+    "database/sql"
     "fmt"
     "github.com/go-chi/chi"
     "github.com/go-chi/render"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/middleware"
     "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
     "github.com/pranayhere/simple-wallet/token"
     "github.com/stretchr/testify/require"
     "net/http"
@@ -22,10 +28,22 @@ func AddAuthorization(t *testing.T, request *http.Request, tokenMaker token.Make
     request.Header.Set(constant.AuthorizationHeaderKey, authorizationHeader)
 }
 
+// NewTestUserRepo returns a user repo whose users never changed their password, so that no token is revoked.
+func NewTestUserRepo(t *testing.T) store.UserRepo {
+    ctrl := gomock.NewController(t)
+    mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+    mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().
+        DoAndReturn(func(ctx context.Context, id int64) (domain.User, error) {
+            return domain.User{ID: id, Status: domain.UserStatusACTIVE}, nil
+        })
+    return mockUserRepo
+}
+
 func TestAuthMiddleware(t *testing.T) {
     testCases := []struct {
         name          string
         setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+        buildStub     func(mockUserRepo *mockdb.MockUserRepo)
         checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
     }{
         {
@@ -33,6 +51,9 @@ func TestAuthMiddleware(t *testing.T) {
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
                 AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 1, time.Minute)
             },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{ID: 1, Status: domain.UserStatusACTIVE, PasswordChangedAt: time.Now().Add(-time.Hour)}, nil)
+            },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusOK, recorder.Code)
             },
@@ -41,6 +62,9 @@ func TestAuthMiddleware(t *testing.T) {
             name: "NoAuthorization",
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
             },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+            },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
             },
@@ -50,6 +74,9 @@ func TestAuthMiddleware(t *testing.T) {
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
                 AddAuthorization(t, request, tokenMaker, "unsupported", 1, time.Minute)
             },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+            },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
             },
@@ -59,6 +86,9 @@ func TestAuthMiddleware(t *testing.T) {
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
                 AddAuthorization(t, request, tokenMaker, "", 1, time.Minute)
             },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+            },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
             },
@@ -68,6 +98,59 @@ func TestAuthMiddleware(t *testing.T) {
             setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
                 AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 1, -time.Minute)
             },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "TwoFactorToken",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                twoFactorToken, err := tokenMaker.CreatePurposeToken(1, token.PurposeTwoFactor, time.Minute)
+                require.NoError(t, err)
+                request.Header.Set(constant.AuthorizationHeaderKey, constant.AuthorizationTypeBearer+" "+twoFactorToken)
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "RevokedToken",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 1, time.Minute)
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{ID: 1, PasswordChangedAt: time.Now().Add(time.Minute)}, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            },
+        },
+        {
+            name: "BlockedUser",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 1, time.Minute)
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{ID: 1, Status: domain.UserStatusBLOCKED}, nil)
+            },
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusForbidden, recorder.Code)
+            },
+        },
+        {
+            name: "UserNotFound",
+            setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+                AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 1, time.Minute)
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), int64(1)).Times(1).Return(domain.User{}, sql.ErrNoRows)
+            },
             checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
                 require.Equal(t, http.StatusUnauthorized, recorder.Code)
             },
@@ -76,6 +159,12 @@ func TestAuthMiddleware(t *testing.T) {
 
     for _, tc := range testCases {
         t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            tc.buildStub(mockUserRepo)
+
             r := chi.NewRouter()
 
             tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
@@ -83,7 +172,7 @@ func TestAuthMiddleware(t *testing.T) {
             require.NotEmpty(t, tokenMaker)
 
             authPath := "/auth"
-            r.With(middleware.Auth(tokenMaker)).Get(
+            r.With(middleware.Auth(tokenMaker, mockUserRepo)).Get(
                 authPath,
                 func(w http.ResponseWriter, r *http.Request) {
                     render.JSON(w, r, "Ok")
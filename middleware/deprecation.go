@@ -0,0 +1,45 @@
+package middleware
+
+import (
+    "fmt"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    log "github.com/sirupsen/logrus"
+    "net/http"
+    "time"
+)
+
+// Deprecation tells the clients of deprecated routes since when they are, when they are removed and what replaces them.
+type Deprecation struct {
+    Since time.Time
+    // Sunset is when the routes stop being served, not announced when zero
+    Sunset time.Time
+    // Successor returns the path of the route replacing the one requested, no Link is set when nil
+    Successor func(path string) string
+}
+
+// Successor is the successor of the routes mounted again under prefix, e.g. /v1/wallets for /wallets.
+func Successor(prefix string) func(path string) string {
+    return func(path string) string {
+        return prefix + path
+    }
+}
+
+// Deprecated sets the Deprecation (RFC 9745) and Sunset (RFC 8594) headers of d on the responses, with a Link to
+// the successor-version. The requests are logged deprecated to find the clients still calling them.
+func Deprecated(d Deprecation) func(next http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            w.Header().Set("Deprecation", fmt.Sprintf("@%d", d.Since.Unix()))
+            if !d.Sunset.IsZero() {
+                w.Header().Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+            }
+            if d.Successor != nil {
+                w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, d.Successor(r.URL.Path)))
+            }
+
+            logging.AddFields(r.Context(), log.Fields{"deprecated": true})
+
+            next.ServeHTTP(w, r)
+        })
+    }
+}
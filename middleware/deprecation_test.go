@@ -0,0 +1,60 @@
+package middleware_test
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestDeprecatedMiddleware(t *testing.T) {
+    since := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+    sunset := time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+
+    testCases := []struct {
+        name          string
+        deprecation   middleware.Deprecation
+        checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+    }{
+        {
+            name:        "WithSunsetAndSuccessor",
+            deprecation: middleware.Deprecation{Since: since, Sunset: sunset, Successor: middleware.Successor("/v1")},
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Equal(t, "@1792368000", recorder.Header().Get("Deprecation"))
+                require.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", recorder.Header().Get("Sunset"))
+                require.Equal(t, `</v1/wallets/1>; rel="successor-version"`, recorder.Header().Get("Link"))
+            },
+        },
+        {
+            name:        "Deprecated",
+            deprecation: middleware.Deprecation{Since: since},
+            checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+                require.Equal(t, http.StatusOK, recorder.Code)
+                require.Equal(t, "@1792368000", recorder.Header().Get("Deprecation"))
+                require.Empty(t, recorder.Header().Get("Sunset"))
+                require.Empty(t, recorder.Header().Get("Link"))
+            },
+        },
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            r := chi.NewRouter()
+            r.With(middleware.Deprecated(tc.deprecation)).Get("/wallets/{walletID}", func(w http.ResponseWriter, r *http.Request) {
+                render.JSON(w, r, "ok")
+            })
+
+            recorder := httptest.NewRecorder()
+            request, err := http.NewRequest(http.MethodGet, "/wallets/1", nil)
+            require.NoError(t, err)
+
+            r.ServeHTTP(recorder, request)
+            tc.checkResponse(t, recorder)
+        })
+    }
+}
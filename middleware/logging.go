@@ -0,0 +1,43 @@
+package middleware
+
+import (
+    "github.com/go-chi/chi/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    log "github.com/sirupsen/logrus"
+    "net/http"
+    "time"
+)
+
+// Logging puts the request logger in the context of the request, with the request ID set by
+// middleware.RequestID, and logs a line for every request once it is served. The route and the user
+// are added to it as they become known, see logging.FromContext and logging.AddFields.
+func Logging(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+        ctx := logging.WithFields(r.Context(), log.Fields{
+            "request_id": middleware.GetReqID(r.Context()),
+            "method":     r.Method,
+            "path":       r.URL.Path,
+        })
+
+        ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+        next.ServeHTTP(ww, r.WithContext(ctx))
+
+        status := ww.Status()
+        if status == 0 {
+            status = http.StatusOK
+        }
+
+        logger := logging.FromContext(ctx).WithFields(log.Fields{
+            "status":      status,
+            "bytes":       ww.BytesWritten(),
+            "duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+            "remote_addr": r.RemoteAddr,
+        })
+        if status >= http.StatusInternalServerError {
+            logger.Error("request served")
+            return
+        }
+        logger.Info("request served")
+    })
+}
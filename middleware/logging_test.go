@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+    "bytes"
+    "encoding/json"
+    "github.com/go-chi/chi"
+    chimiddleware "github.com/go-chi/chi/middleware"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    log "github.com/sirupsen/logrus"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestLogging(t *testing.T) {
+    var out bytes.Buffer
+    log.SetOutput(&out)
+    log.SetFormatter(logging.NewJSONFormatter())
+    defer func() {
+        log.SetOutput(os.Stderr)
+        log.SetFormatter(&log.TextFormatter{})
+    }()
+
+    tokenMaker, err := token.NewJWTMaker(util.RandomString(32))
+    require.NoError(t, err)
+
+    router := chi.NewRouter()
+    router.Use(chimiddleware.RequestID)
+    router.Use(middleware.Logging)
+    router.With(middleware.Auth(tokenMaker, NewTestUserRepo(t))).Post("/wallets/{walletID}/pay", func(w http.ResponseWriter, r *http.Request) {
+        logging.FromContext(r.Context()).WithField("amount", 500).Info("money moved")
+        w.WriteHeader(http.StatusCreated)
+    })
+
+    accessToken, err := tokenMaker.CreateToken(42, time.Minute)
+    require.NoError(t, err)
+
+    req := httptest.NewRequest(http.MethodPost, "/wallets/1/pay", nil)
+    req.Header.Set(constant.AuthorizationHeaderKey, constant.AuthorizationTypeBearer+" "+accessToken)
+    router.ServeHTTP(httptest.NewRecorder(), req)
+
+    lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+    require.Len(t, lines, 2)
+
+    var handlerLine, accessLine map[string]interface{}
+    require.NoError(t, json.Unmarshal([]byte(lines[0]), &handlerLine))
+    require.NoError(t, json.Unmarshal([]byte(lines[1]), &accessLine))
+
+    // both lines tell the request, the route and the user
+    for _, line := range []map[string]interface{}{handlerLine, accessLine} {
+        require.NotEmpty(t, line["request_id"])
+        require.Equal(t, "/wallets/{walletID}/pay", line["route"])
+        require.Equal(t, float64(42), line["user_id"])
+        require.Equal(t, "POST", line["method"])
+        require.Equal(t, "info", line["level"])
+    }
+    require.Equal(t, handlerLine["request_id"], accessLine["request_id"])
+
+    require.Equal(t, "money moved", handlerLine["msg"])
+    require.Equal(t, float64(500), handlerLine["amount"])
+
+    require.Equal(t, "request served", accessLine["msg"])
+    require.Equal(t, "/wallets/1/pay", accessLine["path"])
+    require.Equal(t, float64(http.StatusCreated), accessLine["status"])
+    require.NotContains(t, out.String(), accessToken)
+}
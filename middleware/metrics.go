@@ -0,0 +1,45 @@
+package middleware
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/chi/middleware"
+    "github.com/prometheus/client_golang/prometheus"
+    "net/http"
+    "strconv"
+    "time"
+)
+
+// Metrics observes the latency of every request by method, route pattern and status. The pattern, e.g.
+// /wallets/{walletID}, is known once the request is routed, the requests matching no route share "unmatched".
+func Metrics(registerer prometheus.Registerer) func(next http.Handler) http.Handler {
+    duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+        Name:    "http_request_duration_seconds",
+        Help:    "Latency of the HTTP requests by method, route and status.",
+        Buckets: prometheus.DefBuckets,
+    }, []string{"method", "route", "status"})
+    registerer.MustRegister(duration)
+
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            start := time.Now()
+            ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+            next.ServeHTTP(ww, r)
+
+            route := ""
+            if rctx := chi.RouteContext(r.Context()); rctx != nil {
+                route = rctx.RoutePattern()
+            }
+            if route == "" {
+                route = "unmatched"
+            }
+
+            status := ww.Status()
+            if status == 0 {
+                status = http.StatusOK
+            }
+
+            duration.WithLabelValues(r.Method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+        })
+    }
+}
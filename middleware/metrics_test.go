@@ -0,0 +1,55 @@
+package middleware_test
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestMetrics(t *testing.T) {
+    registry := prometheus.NewRegistry()
+
+    router := chi.NewRouter()
+    router.Use(middleware.Metrics(registry))
+    router.Get("/wallets/{walletID}", func(w http.ResponseWriter, r *http.Request) {
+        _, _ = w.Write([]byte("ok"))
+    })
+    router.Post("/wallets/pay", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusForbidden)
+    })
+
+    for _, req := range []*http.Request{
+        httptest.NewRequest(http.MethodGet, "/wallets/1", nil),
+        httptest.NewRequest(http.MethodGet, "/wallets/2", nil),
+        httptest.NewRequest(http.MethodPost, "/wallets/pay", nil),
+        httptest.NewRequest(http.MethodGet, "/missing", nil),
+    } {
+        router.ServeHTTP(httptest.NewRecorder(), req)
+    }
+
+    // the two wallets share the route pattern
+    count, err := testutil.GatherAndCount(registry, "http_request_duration_seconds")
+    require.NoError(t, err)
+    require.Equal(t, 3, count)
+
+    families, err := registry.Gather()
+    require.NoError(t, err)
+    counts := make(map[string]uint64)
+    for _, m := range families[0].GetMetric() {
+        labels := make(map[string]string)
+        for _, label := range m.GetLabel() {
+            labels[label.GetName()] = label.GetValue()
+        }
+        counts[labels["method"]+" "+labels["route"]+" "+labels["status"]] = m.GetHistogram().GetSampleCount()
+    }
+    require.Equal(t, map[string]uint64{
+        "GET /wallets/{walletID} 200": 2,
+        "POST /wallets/pay 403":       1,
+        "GET unmatched 404":           1,
+    }, counts)
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/ratelimit"
+    "github.com/pranayhere/simple-wallet/token"
+    "net"
+    "net/http"
+    "time"
+)
+
+// RateLimit limits the requests to the policy of the route, set with the RateLimit-* headers on the responses. The
+// requests of a merchant server are counted by api key, the ones of a user by user and the others by ip, so it must
+// be mounted after the authentication, if any.
+// The requests are let through when the store fails, rather than failing them all.
+func RateLimit(limiter *ratelimit.Limiter) func(next http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            pattern := chi.RouteContext(r.Context()).RoutePattern()
+            limit(w, r, next, func() (ratelimit.Result, error) {
+                return limiter.Allow(r.Context(), rateLimitSubject(r), r.Method, pattern)
+            })
+        })
+    }
+}
+
+// RateLimitByIP limits the requests of each ip, whatever the route, to the ip policy. It is mounted before the
+// authentication, for the requests with a bad api key or token, refused by it, to be counted too.
+func RateLimitByIP(limiter *ratelimit.Limiter) func(next http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            limit(w, r, next, func() (ratelimit.Result, error) {
+                return limiter.AllowIP(r.Context(), remoteIP(r))
+            })
+        })
+    }
+}
+
+// limit serves r with next when allow lets it through, else answers 429.
+func limit(w http.ResponseWriter, r *http.Request, next http.Handler, allow func() (ratelimit.Result, error)) {
+    res, err := allow()
+    if err != nil {
+        logging.FromContext(r.Context()).WithError(err).Error("rate limit store failed, request let through")
+        next.ServeHTTP(w, r)
+        return
+    }
+
+    ratelimit.SetHeaders(w.Header(), res, time.Now())
+    if !res.Allowed {
+        _ = render.Render(w, r, errors.ErrResponse(errors.ErrTooManyRequests))
+        return
+    }
+
+    next.ServeHTTP(w, r)
+}
+
+// rateLimitSubject is the api key, the user or the ip of the request, as set by middleware.RealIP.
+func rateLimitSubject(r *http.Request) string {
+    if apiKey, ok := r.Context().Value(constant.APIKeyPayloadKey).(*domain.APIKey); ok {
+        return fmt.Sprintf("apikey:%d", apiKey.ID)
+    }
+    if payload, ok := r.Context().Value(constant.AuthorizationPayloadKey).(*token.Payload); ok {
+        return fmt.Sprintf("user:%d", payload.UserID)
+    }
+
+    return "ip:" + remoteIP(r)
+}
+
+// remoteIP is the ip of the request, as set by middleware.RealIP.
+func remoteIP(r *http.Request) string {
+    ip, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        return r.RemoteAddr
+    }
+    return ip
+}
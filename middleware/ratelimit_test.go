@@ -0,0 +1,156 @@
+package middleware_test
+
+import (
+    "context"
+    "fmt"
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/ratelimit"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, policy ratelimit.Policy) (int, time.Time, bool, error) {
+    return 0, time.Time{}, false, fmt.Errorf("store unavailable")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+    config := ratelimit.Config{
+        IP:      ratelimit.Policy{Limit: 100, Window: ratelimit.Duration(time.Minute)},
+        Default: ratelimit.Policy{Limit: 2, Window: ratelimit.Duration(time.Minute)},
+        Routes: map[string]ratelimit.Policy{
+            "GET /wallets/{walletID}": {Limit: 1, Window: ratelimit.Duration(time.Minute)},
+        },
+    }
+
+    type request struct {
+        path       string
+        userID     int64
+        remoteAddr string
+        status     int
+    }
+
+    testCases := []struct {
+        name     string
+        store    ratelimit.Store
+        requests []request
+    }{
+        {
+            name:  "ByUser",
+            store: ratelimit.NewMemoryStore(time.Minute),
+            requests: []request{
+                {path: "/v1/wallets/1", userID: 1, remoteAddr: "10.0.0.1:1234", status: http.StatusOK},
+                {path: "/v1/wallets/2", userID: 1, remoteAddr: "10.0.0.2:1234", status: http.StatusTooManyRequests},
+                {path: "/v1/wallets/1", userID: 2, remoteAddr: "10.0.0.1:1234", status: http.StatusOK},
+            },
+        },
+        {
+            name:  "ByIP",
+            store: ratelimit.NewMemoryStore(time.Minute),
+            requests: []request{
+                {path: "/v1/public", remoteAddr: "10.0.0.1:1234", status: http.StatusOK},
+                {path: "/v1/public", remoteAddr: "10.0.0.1:5678", status: http.StatusOK},
+                {path: "/v1/public", remoteAddr: "10.0.0.1:1234", status: http.StatusTooManyRequests},
+                {path: "/v1/public", remoteAddr: "10.0.0.2:1234", status: http.StatusOK},
+            },
+        },
+        {
+            name:  "StoreFailure",
+            store: failingStore{},
+            requests: []request{
+                {path: "/v1/public", remoteAddr: "10.0.0.1:1234", status: http.StatusOK},
+                {path: "/v1/public", remoteAddr: "10.0.0.1:1234", status: http.StatusOK},
+                {path: "/v1/public", remoteAddr: "10.0.0.1:1234", status: http.StatusOK},
+            },
+        },
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+            require.NoError(t, err)
+
+            rateLimit := middleware.RateLimit(ratelimit.NewLimiter(config, tc.store))
+            ok := func(w http.ResponseWriter, r *http.Request) {
+                render.JSON(w, r, "ok")
+            }
+
+            r := chi.NewRouter()
+            r.Route("/v1", func(r chi.Router) {
+                r.With(rateLimit).Get("/public", ok)
+                r.With(middleware.Auth(tokenMaker, NewTestUserRepo(t)), rateLimit).Get("/wallets/{walletID}", ok)
+            })
+
+            for _, req := range tc.requests {
+                recorder := httptest.NewRecorder()
+                request, err := http.NewRequest(http.MethodGet, req.path, nil)
+                require.NoError(t, err)
+                request.RemoteAddr = req.remoteAddr
+                if req.userID != 0 {
+                    AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, req.userID, time.Minute)
+                }
+
+                r.ServeHTTP(recorder, request)
+                require.Equal(t, req.status, recorder.Code)
+
+                if _, failing := tc.store.(failingStore); failing {
+                    require.Empty(t, recorder.Header().Get(ratelimit.HeaderLimit))
+                    continue
+                }
+                require.NotEmpty(t, recorder.Header().Get(ratelimit.HeaderLimit))
+                require.NotEmpty(t, recorder.Header().Get(ratelimit.HeaderReset))
+                if req.status == http.StatusTooManyRequests {
+                    require.Equal(t, "0", recorder.Header().Get(ratelimit.HeaderRemaining))
+                    require.NotEmpty(t, recorder.Header().Get(ratelimit.HeaderRetryAfter))
+                }
+            }
+        })
+    }
+}
+
+func TestRateLimitByIPMiddleware(t *testing.T) {
+    tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+    require.NoError(t, err)
+
+    limiter := ratelimit.NewLimiter(ratelimit.Config{
+        IP:      ratelimit.Policy{Limit: 2, Window: ratelimit.Duration(time.Minute)},
+        Default: ratelimit.Policy{Limit: 100, Window: ratelimit.Duration(time.Minute)},
+    }, ratelimit.NewMemoryStore(time.Minute))
+
+    r := chi.NewRouter()
+    r.Use(middleware.RateLimitByIP(limiter))
+    r.Use(middleware.Auth(tokenMaker, NewTestUserRepo(t)))
+    r.Get("/wallets/{walletID}", func(w http.ResponseWriter, r *http.Request) {
+        render.JSON(w, r, "ok")
+    })
+
+    // the requests refused by the authentication are counted, then the ip is refused whatever its token
+    for _, status := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+        recorder := httptest.NewRecorder()
+        request, err := http.NewRequest(http.MethodGet, "/wallets/1", nil)
+        require.NoError(t, err)
+        request.RemoteAddr = "10.0.0.1:1234"
+        request.Header.Set(constant.AuthorizationHeaderKey, "Bearer bad")
+
+        r.ServeHTTP(recorder, request)
+        require.Equal(t, status, recorder.Code)
+        require.Equal(t, "2", recorder.Header().Get(ratelimit.HeaderLimit))
+    }
+
+    recorder := httptest.NewRecorder()
+    request, err := http.NewRequest(http.MethodGet, "/wallets/1", nil)
+    require.NoError(t, err)
+    request.RemoteAddr = "10.0.0.2:1234"
+    AddAuthorization(t, request, tokenMaker, constant.AuthorizationTypeBearer, 1, time.Minute)
+
+    r.ServeHTTP(recorder, request)
+    require.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/chi/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "net/http"
+)
+
+// Tracing runs every request in a server span, the child of the span of the caller when the request carries
+// a traceparent header. The span is renamed after the route pattern, e.g. POST /wallets/pay, once the
+// request is routed, and the context of the request carries it to the services and the queries.
+func Tracing(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        ctx := trace.Extract(r.Context(), r.Header)
+        ctx, span := trace.Start(ctx, r.Method, oteltrace.WithSpanKind(oteltrace.SpanKindServer), oteltrace.WithAttributes(
+            attribute.String("http.method", r.Method),
+            attribute.String("http.target", r.URL.Path),
+            attribute.String("net.peer.ip", r.RemoteAddr),
+        ))
+        defer span.End()
+
+        if reqID := middleware.GetReqID(ctx); reqID != "" {
+            span.SetAttributes(attribute.String("http.request_id", reqID))
+        }
+
+        ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+        next.ServeHTTP(ww, r.WithContext(ctx))
+
+        status := ww.Status()
+        if status == 0 {
+            status = http.StatusOK
+        }
+        span.SetAttributes(attribute.Int("http.status_code", status))
+        if status >= http.StatusInternalServerError {
+            span.SetStatus(codes.Error, http.StatusText(status))
+        }
+
+        if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+            span.SetName(r.Method + " " + rctx.RoutePattern())
+            span.SetAttributes(attribute.String("http.route", rctx.RoutePattern()))
+        }
+    })
+}
@@ -0,0 +1,63 @@
+package middleware_test
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/stretchr/testify/require"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/sdk/trace/tracetest"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestTracing(t *testing.T) {
+    rec := tracetest.NewSpanRecorder()
+    previous := otel.GetTracerProvider()
+    otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+    defer otel.SetTracerProvider(previous)
+
+    var handlerSpan oteltrace.SpanContext
+    router := chi.NewRouter()
+    router.Use(middleware.Tracing)
+    router.Get("/wallets/{walletID}", func(w http.ResponseWriter, r *http.Request) {
+        // the handler sees the span of the request, the spans it starts are its children
+        handlerSpan = oteltrace.SpanContextFromContext(r.Context())
+        _, _ = w.Write([]byte("ok"))
+    })
+    router.Post("/wallets/pay", func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusInternalServerError)
+    })
+
+    req := httptest.NewRequest(http.MethodGet, "/wallets/1", nil)
+    req.Header.Set(trace.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+    router.ServeHTTP(httptest.NewRecorder(), req)
+    router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/wallets/pay", nil))
+
+    spans := rec.Ended()
+    require.Len(t, spans, 2)
+
+    get := spans[0]
+    require.Equal(t, "GET /wallets/{walletID}", get.Name())
+    require.Equal(t, oteltrace.SpanKindServer, get.SpanKind())
+    require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", get.SpanContext().TraceID().String())
+    require.Equal(t, "00f067aa0ba902b7", get.Parent().SpanID().String())
+    require.Equal(t, get.SpanContext().SpanID(), handlerSpan.SpanID())
+    require.Contains(t, get.Attributes(), attribute.String("http.route", "/wallets/{walletID}"))
+    require.Contains(t, get.Attributes(), attribute.String("http.target", "/wallets/1"))
+    require.Contains(t, get.Attributes(), attribute.Int("http.status_code", http.StatusOK))
+    require.Equal(t, codes.Unset, get.Status().Code)
+
+    // without a traceparent the request starts a trace
+    pay := spans[1]
+    require.Equal(t, "POST /wallets/pay", pay.Name())
+    require.False(t, pay.Parent().IsValid())
+    require.NotEqual(t, get.SpanContext().TraceID(), pay.SpanContext().TraceID())
+    require.Contains(t, pay.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+    require.Equal(t, codes.Error, pay.Status().Code)
+}
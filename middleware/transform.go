@@ -0,0 +1,74 @@
+package middleware
+
+import (
+    "bytes"
+    "encoding/json"
+    "net/http"
+    "strings"
+)
+
+// TransformJSON rewrites the JSON responses with transform, e.g. to answer in the shape of an older version of the
+// api from the handlers of the current one. The JSON responses are buffered until the handler returns, the others
+// are passed through as they are written.
+func TransformJSON(transform func(v interface{}) interface{}) func(next http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            tw := &transformWriter{ResponseWriter: w}
+            next.ServeHTTP(tw, r)
+            tw.finish(transform)
+        })
+    }
+}
+
+type transformWriter struct {
+    http.ResponseWriter
+    status      int
+    wroteHeader bool
+    buffered    bool
+    body        bytes.Buffer
+}
+
+// WriteHeader decides whether the response is buffered, from the Content-Type set by then.
+func (t *transformWriter) WriteHeader(status int) {
+    if t.wroteHeader {
+        return
+    }
+    t.wroteHeader = true
+    t.status = status
+    t.buffered = strings.HasPrefix(t.Header().Get("Content-Type"), "application/json")
+    if !t.buffered {
+        t.ResponseWriter.WriteHeader(status)
+    }
+}
+
+func (t *transformWriter) Write(b []byte) (int, error) {
+    if !t.wroteHeader {
+        t.WriteHeader(http.StatusOK)
+    }
+    if t.buffered {
+        return t.body.Write(b)
+    }
+    return t.ResponseWriter.Write(b)
+}
+
+// finish writes the buffered response transformed, as it is when it isn't valid JSON.
+func (t *transformWriter) finish(transform func(v interface{}) interface{}) {
+    if !t.buffered {
+        return
+    }
+
+    body := t.body.Bytes()
+
+    var v interface{}
+    decoder := json.NewDecoder(bytes.NewReader(body))
+    decoder.UseNumber()
+    if err := decoder.Decode(&v); err == nil {
+        if transformed, err := json.Marshal(transform(v)); err == nil {
+            body = append(transformed, '\n')
+        }
+    }
+
+    t.Header().Del("Content-Length")
+    t.ResponseWriter.WriteHeader(t.status)
+    _, _ = t.ResponseWriter.Write(body)
+}
@@ -0,0 +1,42 @@
+package middleware_test
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/go-chi/render"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/middleware"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestTransformJSON(t *testing.T) {
+    originalTransferID := int64(41)
+    res := dto.RefundResultDto{
+        Refund:           dto.WalletTransferResultDto{Transfer: dto.TransferDto{ID: 42, OriginalTransferID: &originalTransferID}},
+        OriginalTransfer: dto.TransferDto{ID: 41},
+    }
+
+    router := chi.NewRouter()
+    router.With(middleware.TransformJSON(dto.UnversionedResponse)).Post("/transfers/41/refund", func(w http.ResponseWriter, r *http.Request) {
+        render.Status(r, http.StatusCreated)
+        render.JSON(w, r, res)
+    })
+    router.With(middleware.TransformJSON(dto.UnversionedResponse)).Get("/statement.pdf", func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/pdf")
+        _, _ = w.Write([]byte("%PDF-1.3"))
+    })
+
+    recorder := httptest.NewRecorder()
+    router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transfers/41/refund", nil))
+    require.Equal(t, http.StatusCreated, recorder.Code)
+    require.Contains(t, recorder.Body.String(), `"original_transfer_id":{"Int64":41,"Valid":true}`)
+    require.Contains(t, recorder.Body.String(), `"original_transfer_id":{"Int64":0,"Valid":false}`)
+
+    // the other responses are passed through
+    recorder = httptest.NewRecorder()
+    router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/statement.pdf", nil))
+    require.Equal(t, http.StatusOK, recorder.Code)
+    require.Equal(t, "%PDF-1.3", recorder.Body.String())
+}
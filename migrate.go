@@ -0,0 +1,94 @@
+package main
+
+import (
+    "context"
+    "database/sql"
+    "flag"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/db/migration"
+    log "github.com/sirupsen/logrus"
+    "os"
+)
+
+const migrateUsage = `usage: wallet migrate up [-steps n]     apply the pending migrations, the first n when set
+       wallet migrate down [-steps n|-all]  revert the last migration, the last n, or all of them
+       wallet migrate status                print the schema version and the pending migrations
+`
+
+// migrate runs the migrate subcommand, it prints to stdout and exits non zero on failure.
+func migrate(args []string) {
+    if len(args) == 0 {
+        fmt.Fprint(os.Stderr, migrateUsage)
+        os.Exit(2)
+    }
+
+    fs := flag.NewFlagSet("migrate "+args[0], flag.ExitOnError)
+    fs.Usage = func() { fmt.Fprint(os.Stderr, migrateUsage) }
+    steps := fs.Int("steps", 0, "number of migrations")
+    all := fs.Bool("all", false, "revert all the migrations")
+    fs.Parse(args[1:])
+
+    db := newStore()
+    defer db.Close()
+
+    m, err := migration.NewMigrator(db)
+    if err != nil {
+        log.Fatal("cannot load the migrations: ", err)
+    }
+
+    ctx := context.Background()
+    switch args[0] {
+    case "up":
+        applied, err := m.Up(ctx, *steps)
+        printMigrations("applied", applied)
+        if err != nil {
+            log.Fatal("migrate up failed: ", err)
+        }
+    case "down":
+        n := *steps
+        if n == 0 && !*all {
+            n = 1
+        }
+        reverted, err := m.Down(ctx, n)
+        printMigrations("reverted", reverted)
+        if err != nil {
+            log.Fatal("migrate down failed: ", err)
+        }
+    case "status":
+        status, err := m.Status(ctx)
+        if err != nil {
+            log.Fatal("cannot read the schema version: ", err)
+        }
+        fmt.Printf("version %d", status.Version)
+        if status.Dirty {
+            fmt.Print(" (dirty)")
+        }
+        fmt.Println()
+        printMigrations("pending", status.Pending)
+    default:
+        fmt.Fprint(os.Stderr, migrateUsage)
+        os.Exit(2)
+    }
+}
+
+// migrateSchemaUp applies the pending migrations at start, the instances starting together wait on each other.
+func migrateSchemaUp(db *sql.DB) {
+    m, err := migration.NewMigrator(db)
+    if err != nil {
+        log.Fatal("cannot load the migrations: ", err)
+    }
+
+    applied, err := m.Up(context.Background(), 0)
+    for _, mig := range applied {
+        log.WithField("version", mig.Version).Info("migration applied: ", mig.Name)
+    }
+    if err != nil {
+        log.Fatal("migrate up failed: ", err)
+    }
+}
+
+func printMigrations(verb string, migrations []migration.Migration) {
+    for _, mig := range migrations {
+        fmt.Printf("%s %d_%s\n", verb, mig.Version, mig.Name)
+    }
+}
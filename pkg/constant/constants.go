@@ -5,10 +5,121 @@ import "time"
 const (
     AccessTokenDuration = 4 * time.Hour
     SymmetricKey        = "12345678901234567890123456789012"
+    // PasswordResetTokenTTL is how long the token mailed by a forgotten password resets it
+    PasswordResetTokenTTL = 1 * time.Hour
+    // TwoFactorTokenDuration is how long the token of a login waits for its TOTP code
+    TwoFactorTokenDuration = 5 * time.Minute
+)
+
+const (
+    TOTPIssuer            = "SimpleWallet"
+    TOTPRecoveryCodeCount = 10
+    TOTPQRScale           = 4
+    TOTPCodeHeaderKey     = "X-Totp-Code"
+    // TOTPMaxFailedAttempts invalid codes in a row lock the user out of the codes for TOTPLockoutDuration
+    TOTPMaxFailedAttempts = 5
+    TOTPLockoutDuration   = 15 * time.Minute
 )
 
 const (
     AuthorizationHeaderKey  = "authorization"
     AuthorizationTypeBearer = "bearer"
     AuthorizationPayloadKey = "authorization_payload"
+    APIKeyHeaderKey         = "X-Api-Key"
+    APIKeyPayloadKey        = "api_key_payload"
+)
+
+const (
+    BankVerificationPollInterval   = 30 * time.Second
+    BankVerificationBatchSize      = 50
+    BankVerificationNameMatchScore = 80
+)
+
+const (
+    BankDirectoryFile = "db/data/bank_branches.csv"
+)
+
+const (
+    WebhookDeliveryInterval   = 10 * time.Second
+    WebhookDeliveryBatchSize  = 50
+    WebhookDeliveryTimeout    = 10 * time.Second
+    WebhookMaxAttempts        = 8
+    WebhookRetryBaseDelay     = 30 * time.Second
+    WebhookSignatureHeaderKey = "X-Wallet-Signature"
+    WebhookEventIDHeaderKey   = "X-Wallet-Event-Id"
+    WebhookEventTypeHeaderKey = "X-Wallet-Event-Type"
+    WebhookResponseLimit      = 64 << 10
+)
+
+const (
+    OutboxRelayInterval  = 1 * time.Second
+    OutboxRelayBatchSize = 100
+    OutboxMaxAttempts    = 10
+    OutboxRetryBaseDelay = 5 * time.Second
+)
+
+const (
+    NotificationLogFile = "notifications.log"
+    SMTPDefaultPort     = 587
+    SMTPDefaultFrom     = "MyWallet <no-reply@my.wallet>"
+)
+
+const (
+    ReconciliationInterval = 1 * time.Hour
+)
+
+const (
+    TraceServiceName   = "simple-wallet"
+    TraceExportTimeout = 10 * time.Second
+)
+
+const (
+    HoldDefaultTTL      = 7 * 24 * time.Hour
+    HoldMaxTTL          = 30 * 24 * time.Hour
+    HoldExpiryInterval  = 1 * time.Minute
+    HoldExpiryBatchSize = 100
+)
+
+const (
+    EscrowDefaultReleaseAfter   = 14 * 24 * time.Hour
+    EscrowReleaseInterval       = 1 * time.Minute
+    EscrowReleaseBatchSize      = 100
+    EscrowReleaseRetryBaseDelay = 1 * time.Minute
+    EscrowReleaseMaxRetryDelay  = 24 * time.Hour
+)
+
+const (
+    PaymentLinkSlugLength   = 12
+    CheckoutSessionTTL      = 30 * time.Minute
+    CheckoutCallbackTimeout = 10 * time.Second
+)
+
+const (
+    QRDefaultFormat = "png"
+    QRDefaultScale  = 8
+)
+
+const (
+    DBPingTimeout      = 5 * time.Second
+    HealthCheckTimeout = 2 * time.Second
+    // ShutdownDrainDelay is how long the service keeps serving, not ready, before it stops accepting requests,
+    // for the load balancer to take it out.
+    ShutdownDrainDelay = 5 * time.Second
+)
+
+// The rate limit policies are read from RateLimitConfigFile, the routes without one share the default policy.
+const (
+    RateLimitConfigFile    = "ratelimit.json"
+    RateLimitIPLimit       = 300
+    RateLimitIPWindow      = 1 * time.Minute
+    RateLimitDefaultLimit  = 100
+    RateLimitDefaultWindow = 1 * time.Minute
+    RateLimitSweepInterval = 1 * time.Minute
+)
+
+// The routes served at the root before the api was versioned are deprecated for their /v1 counterpart, and removed
+// after the sunset.
+var (
+    UnversionedRoutesDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+    UnversionedRoutesSunsetAt     = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
 )
@@ -7,23 +7,71 @@ import (
 )
 
 var (
-    ErrUserNotFound               = errors.New("user not found")
-    ErrIncorrectPassword          = errors.New("incorrect password")
-    ErrUserAlreadyExist           = errors.New("user already exist")
-    ErrCurrencyNotFound           = errors.New("currency not found")
-    ErrBankAccountAlreadyExist    = errors.New("bank account already exist")
-    ErrBankAccountNotFound        = errors.New("bank account not found")
-    ErrSomethingWrong             = errors.New("something went wrong")
-    ErrCurrencyMismatch           = errors.New("currency mismatch")
-    ErrWalletNotFound             = errors.New("wallet not found")
-    ErrMissingAuthHeader          = errors.New("missing authorization header")
-    ErrInvalidAuthHeaderFormat    = errors.New("invalid auth header format")
-    ErrUnsupportedAuth            = errors.New("auth type not supported")
-    ErrUnauthorized               = errors.New("unauthorized user")
-    ErrOrganizationWalletNotFound = errors.New("organization wallet with the currency doesn't exist")
-    ErrInsufficientBalance        = errors.New("insufficient balance")
-    ErrWalletInactive             = errors.New("wallet is inactive")
-    ErrPaymentRequestNotFound     = errors.New("payment request not found")
+    ErrUserNotFound                   = errors.New("user not found")
+    ErrIncorrectPassword              = errors.New("incorrect password")
+    ErrUserAlreadyExist               = errors.New("user already exist")
+    ErrCurrencyNotFound               = errors.New("currency not found")
+    ErrBankAccountAlreadyExist        = errors.New("bank account already exist")
+    ErrBankAccountNotFound            = errors.New("bank account not found")
+    ErrSomethingWrong                 = errors.New("something went wrong")
+    ErrCurrencyMismatch               = errors.New("currency mismatch")
+    ErrWalletNotFound                 = errors.New("wallet not found")
+    ErrMissingAuthHeader              = errors.New("missing authorization header")
+    ErrInvalidAuthHeaderFormat        = errors.New("invalid auth header format")
+    ErrUnsupportedAuth                = errors.New("auth type not supported")
+    ErrUnauthorized                   = errors.New("unauthorized user")
+    ErrOrganizationWalletNotFound     = errors.New("organization wallet with the currency doesn't exist")
+    ErrInsufficientBalance            = errors.New("insufficient balance")
+    ErrWalletInactive                 = errors.New("wallet is inactive")
+    ErrPaymentRequestNotFound         = errors.New("payment request not found")
+    ErrIfscNotFound                   = errors.New("ifsc not found in bank directory")
+    ErrWebhookEndpointNotFound        = errors.New("webhook endpoint not found")
+    ErrWebhookDeliveryNotFound        = errors.New("webhook delivery not found")
+    ErrNotificationNotFound           = errors.New("notification not found")
+    ErrPennyDropNotFound              = errors.New("penny drop not found")
+    ErrNonPublicURL                   = errors.New("url must be an http or https url of a public host")
+    ErrForbidden                      = errors.New("forbidden")
+    ErrReconciliationNotFound         = errors.New("reconciliation report not found")
+    ErrTransferNotFound               = errors.New("transfer not found")
+    ErrTransferNotRefundable          = errors.New("only payments can be refunded")
+    ErrRefundExceedsTransfer          = errors.New("refund exceeds the amount left to refund")
+    ErrHoldNotFound                   = errors.New("hold not found")
+    ErrHoldNotAuthorized              = errors.New("hold is already captured, voided or expired")
+    ErrHoldExpired                    = errors.New("hold is expired")
+    ErrCaptureExceedsHold             = errors.New("capture exceeds the amount on hold")
+    ErrEscrowNotFound                 = errors.New("escrow not found")
+    ErrEscrowWalletNotFound           = errors.New("escrow wallet with the currency doesn't exist")
+    ErrEscrowNotFunded                = errors.New("escrow is already released, refunded or disputed")
+    ErrEscrowNotDisputed              = errors.New("escrow is not disputed")
+    ErrMerchantNotFound               = errors.New("merchant not found")
+    ErrMerchantAlreadyExist           = errors.New("merchant already exist")
+    ErrAPIKeyNotFound                 = errors.New("api key not found")
+    ErrInvalidAPIKey                  = errors.New("invalid api key")
+    ErrAPIKeyScope                    = errors.New("api key doesn't have the scope for this request")
+    ErrPaymentLinkNotFound            = errors.New("payment link not found")
+    ErrPaymentLinkInactive            = errors.New("payment link is disabled or already used")
+    ErrPaymentLinkExpired             = errors.New("payment link is expired")
+    ErrCheckoutSessionNotFound        = errors.New("checkout session not found")
+    ErrCheckoutSessionNotOpen         = errors.New("checkout session is already completed")
+    ErrCheckoutSessionExpired         = errors.New("checkout session is expired")
+    ErrCheckoutAmountMismatch         = errors.New("amount doesn't match the amount of the payment link")
+    ErrInvalidQRPayload               = errors.New("invalid or damaged qr payload")
+    ErrQRAmountMismatch               = errors.New("amount is missing or doesn't match the amount of the qr code")
+    ErrPaymentRequestNotPending       = errors.New("payment request is not waiting for approval")
+    ErrOrganizationWalletAlreadyExist = errors.New("organization wallet with the currency already exist")
+    ErrUserBlocked                    = errors.New("user is blocked")
+    ErrTooManyRequests                = errors.New("too many requests, retry later")
+    ErrInvalidPasswordResetToken      = errors.New("invalid, expired or already used password reset token")
+    ErrTokenRevoked                   = errors.New("token revoked by a password change, log in again")
+    ErrTOTPAlreadyEnabled             = errors.New("two factor authentication is already enabled")
+    ErrTOTPNotEnrolled                = errors.New("two factor authentication is not enrolled")
+    ErrInvalidTOTPCode                = errors.New("invalid or already used two factor code")
+    ErrTOTPRequired                   = errors.New("two factor code required, send it in the X-Totp-Code header")
+    ErrTOTPLocked                     = errors.New("too many invalid two factor codes, retry later")
+    ErrBankAccountNotInVerification   = errors.New("bank account is already verified or failed")
+    ErrBankVerificationNotPending     = errors.New("bank verification is already completed")
+    ErrNotificationAlreadySent        = errors.New("notification of the event already sent")
+    ErrDeadOutboxEventNotFound        = errors.New("dead outbox event not found")
 )
 
 // Error renderer type for handling all sorts of errors.
@@ -41,14 +89,27 @@ func (e *Error) Render(w http.ResponseWriter, r *http.Request) error {
 
 func Status(err error) int {
     switch err {
-    case ErrUserNotFound, ErrWalletNotFound, ErrBankAccountNotFound, ErrCurrencyNotFound, ErrPaymentRequestNotFound:
+    case ErrUserNotFound, ErrWalletNotFound, ErrBankAccountNotFound, ErrCurrencyNotFound, ErrPaymentRequestNotFound, ErrIfscNotFound,
+        ErrWebhookEndpointNotFound, ErrWebhookDeliveryNotFound, ErrNotificationNotFound, ErrReconciliationNotFound,
+        ErrTransferNotFound, ErrHoldNotFound, ErrEscrowNotFound, ErrMerchantNotFound, ErrAPIKeyNotFound,
+        ErrPaymentLinkNotFound, ErrCheckoutSessionNotFound, ErrDeadOutboxEventNotFound:
         return http.StatusNotFound
-    case ErrUserAlreadyExist, ErrBankAccountAlreadyExist, ErrOrganizationWalletNotFound, ErrInsufficientBalance, ErrWalletInactive:
+    case ErrUserAlreadyExist, ErrBankAccountAlreadyExist, ErrOrganizationWalletNotFound, ErrInsufficientBalance, ErrWalletInactive,
+        ErrForbidden, ErrTransferNotRefundable, ErrRefundExceedsTransfer,
+        ErrHoldNotAuthorized, ErrHoldExpired, ErrCaptureExceedsHold, ErrEscrowWalletNotFound, ErrEscrowNotFunded, ErrEscrowNotDisputed,
+        ErrMerchantAlreadyExist, ErrAPIKeyScope, ErrPaymentLinkInactive, ErrPaymentLinkExpired, ErrCheckoutSessionNotOpen, ErrCheckoutSessionExpired,
+        ErrPaymentRequestNotPending, ErrOrganizationWalletAlreadyExist, ErrUserBlocked, ErrTOTPAlreadyEnabled, ErrTOTPNotEnrolled,
+        ErrBankAccountNotInVerification, ErrBankVerificationNotPending:
         return http.StatusForbidden
+    case ErrCheckoutAmountMismatch, ErrInvalidQRPayload, ErrQRAmountMismatch, ErrNonPublicURL:
+        return http.StatusBadRequest
     case ErrCurrencyMismatch:
         return http.StatusConflict
-    case ErrMissingAuthHeader, ErrInvalidAuthHeaderFormat, ErrUnsupportedAuth, ErrUnauthorized, ErrIncorrectPassword:
+    case ErrMissingAuthHeader, ErrInvalidAuthHeaderFormat, ErrUnsupportedAuth, ErrUnauthorized, ErrIncorrectPassword, ErrInvalidAPIKey,
+        ErrInvalidPasswordResetToken, ErrTokenRevoked, ErrInvalidTOTPCode, ErrTOTPRequired:
         return http.StatusUnauthorized
+    case ErrTooManyRequests, ErrTOTPLocked:
+        return http.StatusTooManyRequests
     case ErrSomethingWrong:
         return http.StatusInternalServerError
     default:
@@ -0,0 +1,53 @@
+package errors
+
+import (
+    "google.golang.org/grpc/codes"
+    "google.golang.org/grpc/status"
+)
+
+// Code is the gRPC counterpart of Status.
+func Code(err error) codes.Code {
+    switch err {
+    case nil:
+        return codes.OK
+    case ErrUserNotFound, ErrWalletNotFound, ErrBankAccountNotFound, ErrCurrencyNotFound, ErrPaymentRequestNotFound, ErrIfscNotFound,
+        ErrWebhookEndpointNotFound, ErrWebhookDeliveryNotFound, ErrNotificationNotFound, ErrReconciliationNotFound,
+        ErrTransferNotFound, ErrHoldNotFound, ErrEscrowNotFound, ErrMerchantNotFound, ErrAPIKeyNotFound,
+        ErrPaymentLinkNotFound, ErrCheckoutSessionNotFound, ErrDeadOutboxEventNotFound:
+        return codes.NotFound
+    case ErrUserAlreadyExist, ErrBankAccountAlreadyExist, ErrMerchantAlreadyExist, ErrOrganizationWalletAlreadyExist, ErrTOTPAlreadyEnabled:
+        return codes.AlreadyExists
+    case ErrForbidden, ErrAPIKeyScope, ErrUserBlocked:
+        return codes.PermissionDenied
+    case ErrOrganizationWalletNotFound, ErrInsufficientBalance, ErrWalletInactive, ErrTransferNotRefundable, ErrRefundExceedsTransfer,
+        ErrHoldNotAuthorized, ErrHoldExpired, ErrCaptureExceedsHold, ErrEscrowWalletNotFound, ErrEscrowNotFunded, ErrEscrowNotDisputed,
+        ErrPaymentLinkInactive, ErrPaymentLinkExpired, ErrCheckoutSessionNotOpen, ErrCheckoutSessionExpired,
+        ErrPaymentRequestNotPending, ErrCurrencyMismatch, ErrTOTPNotEnrolled, ErrBankAccountNotInVerification, ErrBankVerificationNotPending:
+        return codes.FailedPrecondition
+    case ErrCheckoutAmountMismatch, ErrInvalidQRPayload, ErrQRAmountMismatch, ErrNonPublicURL:
+        return codes.InvalidArgument
+    case ErrMissingAuthHeader, ErrInvalidAuthHeaderFormat, ErrUnsupportedAuth, ErrUnauthorized, ErrIncorrectPassword, ErrInvalidAPIKey,
+        ErrInvalidPasswordResetToken, ErrTokenRevoked, ErrInvalidTOTPCode, ErrTOTPRequired:
+        return codes.Unauthenticated
+    case ErrTooManyRequests, ErrTOTPLocked:
+        return codes.ResourceExhausted
+    default:
+        return codes.Internal
+    }
+}
+
+// GRPCStatus returns the gRPC status error of err, the errors already carrying a status are returned as they are.
+func GRPCStatus(err error) error {
+    if err == nil {
+        return nil
+    }
+    if _, ok := status.FromError(err); ok {
+        return err
+    }
+    return status.Error(Code(err), err.Error())
+}
+
+// InvalidArgument is the gRPC counterpart of ErrBadRequest.
+func InvalidArgument(err error) error {
+    return status.Error(codes.InvalidArgument, err.Error())
+}
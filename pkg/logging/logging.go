@@ -0,0 +1,75 @@
+// Package logging carries a logger in the context, so that every line logged while serving a request tells the
+// request ID, the route and the user it was logged for, whichever layer logs it. Lines are written as JSON by
+// a formatter redacting the secrets.
+package logging
+
+import (
+    "context"
+    "github.com/go-chi/chi"
+    log "github.com/sirupsen/logrus"
+    "go.opentelemetry.io/otel/trace"
+    "sync"
+)
+
+type ctxKey struct{}
+
+// fields are shared by the contexts derived from the one they were set in, AddFields fills them in place.
+type fields struct {
+    mu   sync.RWMutex
+    data log.Fields
+}
+
+// WithFields returns ctx whose logger adds fields to the ones of ctx.
+func WithFields(ctx context.Context, f log.Fields) context.Context {
+    data := make(log.Fields)
+    if parent, ok := ctx.Value(ctxKey{}).(*fields); ok {
+        parent.mu.RLock()
+        for k, v := range parent.data {
+            data[k] = v
+        }
+        parent.mu.RUnlock()
+    }
+    for k, v := range f {
+        data[k] = v
+    }
+
+    return context.WithValue(ctx, ctxKey{}, &fields{data: data})
+}
+
+// AddFields adds f to the logger of ctx in place, so that the contexts ctx was derived from log them too. It is
+// how the user authenticated deep in the middleware chain shows in the access log of the request.
+// It does nothing when ctx carries no logger fields.
+func AddFields(ctx context.Context, f log.Fields) {
+    current, ok := ctx.Value(ctxKey{}).(*fields)
+    if !ok {
+        return
+    }
+
+    current.mu.Lock()
+    defer current.mu.Unlock()
+    for k, v := range f {
+        current.data[k] = v
+    }
+}
+
+// FromContext returns the logger of ctx, with the fields set on ctx, the route pattern once the request is
+// routed and the ids of the current span.
+func FromContext(ctx context.Context) *log.Entry {
+    entry := log.NewEntry(log.StandardLogger()).WithContext(ctx)
+
+    if f, ok := ctx.Value(ctxKey{}).(*fields); ok {
+        f.mu.RLock()
+        entry = entry.WithFields(f.data)
+        f.mu.RUnlock()
+    }
+
+    if rctx := chi.RouteContext(ctx); rctx != nil && rctx.RoutePattern() != "" {
+        entry = entry.WithField("route", rctx.RoutePattern())
+    }
+
+    if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+        entry = entry.WithFields(log.Fields{"trace_id": sc.TraceID().String(), "span_id": sc.SpanID().String()})
+    }
+
+    return entry
+}
@@ -0,0 +1,53 @@
+package logging_test
+
+import (
+    "context"
+    "github.com/go-chi/chi"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    log "github.com/sirupsen/logrus"
+    "github.com/stretchr/testify/require"
+    "go.opentelemetry.io/otel/trace"
+    "testing"
+)
+
+func TestWithFields(t *testing.T) {
+    require.Empty(t, logging.FromContext(context.Background()).Data)
+
+    ctx := logging.WithFields(context.Background(), log.Fields{"request_id": "host/abc-000001", "method": "POST"})
+    child := logging.WithFields(ctx, log.Fields{"worker": "hold-expiry", "method": "GET"})
+
+    // the child adds to the fields of its parent and overrides them, the parent is left as is
+    require.Equal(t, log.Fields{"request_id": "host/abc-000001", "method": "POST"}, logging.FromContext(ctx).Data)
+    require.Equal(t, log.Fields{"request_id": "host/abc-000001", "method": "GET", "worker": "hold-expiry"}, logging.FromContext(child).Data)
+}
+
+func TestAddFields(t *testing.T) {
+    // without fields in the context there is nothing to add to
+    logging.AddFields(context.Background(), log.Fields{"user_id": 1})
+    require.Empty(t, logging.FromContext(context.Background()).Data)
+
+    ctx := logging.WithFields(context.Background(), log.Fields{"request_id": "host/abc-000001"})
+    authenticated := context.WithValue(ctx, "k", "v")
+    logging.AddFields(authenticated, log.Fields{"user_id": int64(7)})
+
+    // the fields are added in place, the context they were set in logs them too
+    require.Equal(t, int64(7), logging.FromContext(ctx).Data["user_id"])
+    require.Equal(t, int64(7), logging.FromContext(authenticated).Data["user_id"])
+}
+
+func TestFromContextRouteAndSpan(t *testing.T) {
+    rctx := chi.NewRouteContext()
+    rctx.RoutePatterns = []string{"/wallets/*", "/{walletID}"}
+    ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+
+    traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+    require.NoError(t, err)
+    spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+    require.NoError(t, err)
+    ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+    data := logging.FromContext(ctx).Data
+    require.Equal(t, "/wallets/{walletID}", data["route"])
+    require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", data["trace_id"])
+    require.Equal(t, "00f067aa0ba902b7", data["span_id"])
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+    "encoding/json"
+    log "github.com/sirupsen/logrus"
+    "reflect"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// Redacted replaces the value of the secret fields.
+const Redacted = "[REDACTED]"
+
+// secretKeys end the names of the fields whose values are never logged, e.g. password, hashed_password and
+// access_token, but not password_changed_at. They are matched case insensitively against the keys of the
+// fields and of the structs and maps logged as field values.
+var secretKeys = []string{"password", "token", "secret", "api_key", "apikey", "authorization", "cookie"}
+
+// accountNumberKeys end the names of the fields whose values are masked down to their last 4 characters.
+var accountNumberKeys = []string{"account_number", "accountnumber"}
+
+// secretPatterns are the secrets found in messages and string values: bearer tokens, JWTs and PASETOs.
+var secretPatterns = regexp.MustCompile(`(?i)bearer\s+\S+|eyJ[\w-]+\.[\w-]+\.[\w-]*|v2\.local\.[\w-]+(\.[\w-]+)?`)
+
+// Formatter redacts the secrets of every entry before formatting it with Formatter.
+type Formatter struct {
+    log.Formatter
+}
+
+// NewJSONFormatter writes every entry as one JSON object with time, level, msg and the fields, redacted.
+func NewJSONFormatter() *Formatter {
+    return &Formatter{Formatter: &log.JSONFormatter{TimestampFormat: time.RFC3339Nano}}
+}
+
+func (f *Formatter) Format(entry *log.Entry) ([]byte, error) {
+    data := make(log.Fields, len(entry.Data))
+    for k, v := range entry.Data {
+        data[k] = redact(k, v)
+    }
+
+    redacted := *entry
+    redacted.Data = data
+    redacted.Message = secretPatterns.ReplaceAllString(entry.Message, Redacted)
+    return f.Formatter.Format(&redacted)
+}
+
+func redact(key string, value interface{}) interface{} {
+    k := strings.ToLower(key)
+    for _, s := range secretKeys {
+        if strings.HasSuffix(k, s) {
+            return Redacted
+        }
+    }
+    for _, s := range accountNumberKeys {
+        if strings.HasSuffix(k, s) {
+            return MaskAccountNumber(toString(value))
+        }
+    }
+
+    switch v := value.(type) {
+    case nil, error, time.Time, time.Duration, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+        return v
+    case string:
+        return secretPatterns.ReplaceAllString(v, Redacted)
+    case map[string]interface{}:
+        res := make(map[string]interface{}, len(v))
+        for k, vv := range v {
+            res[k] = redact(k, vv)
+        }
+        return res
+    case []interface{}:
+        res := make([]interface{}, len(v))
+        for i, vv := range v {
+            res[i] = redact(key, vv)
+        }
+        return res
+    }
+
+    // structs, like a dto logged whole, are redacted by their json field names
+    switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+    case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+        b, err := json.Marshal(value)
+        if err != nil {
+            return value
+        }
+        var decoded interface{}
+        if err := json.Unmarshal(b, &decoded); err != nil {
+            return value
+        }
+        return redact(key, decoded)
+    }
+    return value
+}
+
+func toString(value interface{}) string {
+    if s, ok := value.(string); ok {
+        return s
+    }
+    b, _ := json.Marshal(value)
+    return strings.Trim(string(b), `"`)
+}
+
+// MaskAccountNumber keeps the last 4 characters of an account number, enough to tell the accounts of a user apart.
+func MaskAccountNumber(accountNumber string) string {
+    if len(accountNumber) <= 4 {
+        return strings.Repeat("*", len(accountNumber))
+    }
+    return strings.Repeat("*", len(accountNumber)-4) + accountNumber[len(accountNumber)-4:]
+}
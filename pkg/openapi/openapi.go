@@ -0,0 +1,317 @@
+// Package openapi writes the OpenAPI 3 document of the REST api. The routes are described by hand, their request
+// and response bodies are read from the dto structs: a property per json tag, its constraints from the validate tag.
+package openapi
+
+import (
+    "fmt"
+    "net/http"
+    "reflect"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+    OpenAPI    string              `json:"openapi"`
+    Info       Info                `json:"info"`
+    Paths      map[string]PathItem `json:"paths"`
+    Components Components          `json:"components"`
+}
+
+type Info struct {
+    Title       string `json:"title"`
+    Description string `json:"description,omitempty"`
+    Version     string `json:"version"`
+}
+
+// PathItem are the operations of a path by lower case method.
+type PathItem map[string]*Operation
+
+type Components struct {
+    Schemas         map[string]*Schema        `json:"schemas"`
+    SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+    Type         string `json:"type"`
+    Scheme       string `json:"scheme,omitempty"`
+    BearerFormat string `json:"bearerFormat,omitempty"`
+    In           string `json:"in,omitempty"`
+    Name         string `json:"name,omitempty"`
+}
+
+type Operation struct {
+    OperationID string                `json:"operationId"`
+    Summary     string                `json:"summary,omitempty"`
+    Tags        []string              `json:"tags,omitempty"`
+    Parameters  []Parameter           `json:"parameters,omitempty"`
+    RequestBody *RequestBody          `json:"requestBody,omitempty"`
+    Responses   map[string]Response   `json:"responses"`
+    Security    []map[string][]string `json:"security,omitempty"`
+    Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+    Name        string  `json:"name"`
+    In          string  `json:"in"`
+    Description string  `json:"description,omitempty"`
+    Required    bool    `json:"required,omitempty"`
+    Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+    Required bool                 `json:"required"`
+    Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+    Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+    Description string               `json:"description"`
+    Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Schema struct {
+    Ref                  string             `json:"$ref,omitempty"`
+    Type                 string             `json:"type,omitempty"`
+    Format               string             `json:"format,omitempty"`
+    Properties           map[string]*Schema `json:"properties,omitempty"`
+    Required             []string           `json:"required,omitempty"`
+    Items                *Schema            `json:"items,omitempty"`
+    AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+    Enum                 []string           `json:"enum,omitempty"`
+    Pattern              string             `json:"pattern,omitempty"`
+    Minimum              *float64           `json:"minimum,omitempty"`
+    ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+    Maximum              *float64           `json:"maximum,omitempty"`
+    ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+    MinLength            *int64             `json:"minLength,omitempty"`
+    MaxLength            *int64             `json:"maxLength,omitempty"`
+    MinItems             *int64             `json:"minItems,omitempty"`
+    MaxItems             *int64             `json:"maxItems,omitempty"`
+    Nullable             bool               `json:"nullable,omitempty"`
+}
+
+// Security schemes of the routes, a route accepting both takes either of them.
+const (
+    BearerAuth = "bearerAuth"
+    APIKeyAuth = "apiKeyAuth"
+)
+
+// Route describes an operation of the api. Request and Response are values of the dto of the JSON bodies, nil
+// when there is none; a Response of Content is served as is, e.g. an image.
+type Route struct {
+    Method   string
+    Pattern  string
+    Summary  string
+    Tags     []string
+    Query    []Parameter
+    Request  interface{}
+    Response interface{}
+    // Status is the status of the response, http.StatusOK when 0
+    Status   int
+    Security []string
+    // Deprecated routes are still served but will be removed
+    Deprecated bool
+    // RateLimited routes answer 429 past their quota
+    RateLimited bool
+}
+
+// Mount returns the routes under prefix, e.g. the routes of a version of the api under /v1.
+func Mount(prefix string, routes []Route) []Route {
+    mounted := make([]Route, len(routes))
+    for i, route := range routes {
+        route.Pattern = prefix + route.Pattern
+        mounted[i] = route
+    }
+    return mounted
+}
+
+// RateLimit returns the routes marked rate limited.
+func RateLimit(routes []Route) []Route {
+    limited := make([]Route, len(routes))
+    for i, route := range routes {
+        route.RateLimited = true
+        limited[i] = route
+    }
+    return limited
+}
+
+// Deprecate returns the routes marked deprecated.
+func Deprecate(routes []Route) []Route {
+    deprecated := make([]Route, len(routes))
+    for i, route := range routes {
+        route.Deprecated = true
+        deprecated[i] = route
+    }
+    return deprecated
+}
+
+// Content is a response served in one of the media types rather than as JSON.
+type Content []string
+
+// Spec builds the document of the routes.
+type Spec struct {
+    doc   *Document
+    types map[string]reflect.Type
+}
+
+// ErrorSchema is the name of the schema of the error responses.
+const ErrorSchema = "Error"
+
+// New returns the spec of an api without routes, errorBody is the body of its error responses.
+func New(info Info, errorBody interface{}, securitySchemes map[string]SecurityScheme) *Spec {
+    s := &Spec{
+        doc: &Document{
+            OpenAPI: Version,
+            Info:    info,
+            Paths:   make(map[string]PathItem),
+            Components: Components{
+                Schemas:         make(map[string]*Schema),
+                SecuritySchemes: securitySchemes,
+            },
+        },
+        types: make(map[string]reflect.Type),
+    }
+    s.doc.Components.Schemas[ErrorSchema] = s.structSchema(reflect.TypeOf(errorBody))
+
+    return s
+}
+
+// Document returns the document of the routes added.
+func (s *Spec) Document() *Document {
+    return s.doc
+}
+
+// Has tells whether the operation of method on the chi pattern is described.
+func (s *Spec) Has(method, pattern string) bool {
+    item, ok := s.doc.Paths[pattern]
+    if !ok {
+        return false
+    }
+    _, ok = item[strings.ToLower(method)]
+    return ok
+}
+
+// Operations lists the described operations as "METHOD pattern".
+func (s *Spec) Operations() []string {
+    var ops []string
+    for pattern, item := range s.doc.Paths {
+        for method := range item {
+            ops = append(ops, strings.ToUpper(method)+" "+pattern)
+        }
+    }
+    sort.Strings(ops)
+    return ops
+}
+
+var pathParam = regexp.MustCompile(`{([^}:]+)}`)
+
+// Add describes the routes, their chi patterns are OpenAPI paths as they are.
+func (s *Spec) Add(routes ...Route) {
+    for _, route := range routes {
+        op := &Operation{
+            OperationID: operationID(route.Method, route.Pattern),
+            Summary:     route.Summary,
+            Tags:        route.Tags,
+            Responses:   make(map[string]Response),
+            Deprecated:  route.Deprecated,
+        }
+
+        for _, m := range pathParam.FindAllStringSubmatch(route.Pattern, -1) {
+            op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: pathParamSchema(m[1])})
+        }
+        op.Parameters = append(op.Parameters, route.Query...)
+
+        if route.Request != nil {
+            op.RequestBody = &RequestBody{
+                Required: true,
+                Content:  map[string]MediaType{"application/json": {Schema: s.schema(reflect.TypeOf(route.Request))}},
+            }
+        }
+
+        status := route.Status
+        if status == 0 {
+            status = http.StatusOK
+        }
+        op.Responses[fmt.Sprint(status)] = s.response(status, route.Response)
+
+        if len(route.Security) > 0 {
+            for _, name := range route.Security {
+                op.Security = append(op.Security, map[string][]string{name: {}})
+            }
+            op.Responses["401"] = errorResponse(http.StatusUnauthorized)
+            op.Responses["403"] = errorResponse(http.StatusForbidden)
+        }
+        if len(op.Parameters) > 0 || op.RequestBody != nil {
+            op.Responses["400"] = errorResponse(http.StatusBadRequest)
+        }
+        if strings.Contains(route.Pattern, "{") {
+            op.Responses["404"] = errorResponse(http.StatusNotFound)
+        }
+        if route.RateLimited {
+            op.Responses["429"] = errorResponse(http.StatusTooManyRequests)
+        }
+        op.Responses["500"] = errorResponse(http.StatusInternalServerError)
+
+        item, ok := s.doc.Paths[route.Pattern]
+        if !ok {
+            item = make(PathItem)
+            s.doc.Paths[route.Pattern] = item
+        }
+        item[strings.ToLower(route.Method)] = op
+    }
+}
+
+func (s *Spec) response(status int, body interface{}) Response {
+    res := Response{Description: http.StatusText(status)}
+    switch body := body.(type) {
+    case nil:
+    case Content:
+        res.Content = make(map[string]MediaType)
+        for _, contentType := range body {
+            res.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+        }
+    default:
+        res.Content = map[string]MediaType{"application/json": {Schema: s.schema(reflect.TypeOf(body))}}
+    }
+    return res
+}
+
+func errorResponse(status int) Response {
+    return Response{
+        Description: http.StatusText(status),
+        Content:     map[string]MediaType{"application/json": {Schema: &Schema{Ref: "#/components/schemas/" + ErrorSchema}}},
+    }
+}
+
+// operationID is e.g. postWalletsPay for POST /wallets/pay and getWalletsByWalletID for GET /wallets/{walletID}.
+func operationID(method, pattern string) string {
+    var b strings.Builder
+    b.WriteString(strings.ToLower(method))
+    for _, segment := range strings.Split(pattern, "/") {
+        if segment == "" {
+            continue
+        }
+        if m := pathParam.FindStringSubmatch(segment); m != nil {
+            b.WriteString("By")
+            segment = m[1]
+        }
+        for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '.' || r == '_' }) {
+            b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+        }
+    }
+    return b.String()
+}
+
+// pathParamSchema reads the ids, e.g. walletID, as integers and the rest, e.g. slug, as strings.
+func pathParamSchema(name string) *Schema {
+    if strings.HasSuffix(name, "ID") {
+        return &Schema{Type: "integer", Format: "int64"}
+    }
+    return &Schema{Type: "string"}
+}
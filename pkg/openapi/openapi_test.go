@@ -0,0 +1,140 @@
+package openapi
+
+import (
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "testing"
+    "time"
+)
+
+type testError struct {
+    Err  error  `json:"-"`
+    Text string `json:"error,omitempty"`
+}
+
+type testBase struct {
+    ID        int64     `json:"id"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+type testItem struct {
+    Code string `json:"code" validate:"required,len=3"`
+}
+
+type testRequest struct {
+    testBase
+    Username string     `json:"username" validate:"required,alphanum,min=3,max=20"`
+    Email    string     `json:"email" validate:"omitempty,email"`
+    Ifsc     string     `json:"ifsc" validate:"required,ifsc"`
+    Amount   int64      `json:"amount" validate:"required,gt=0,lte=1000"`
+    Kind     string     `json:"kind" validate:"oneof=CARD BANK"`
+    Tags     []string   `json:"tags" validate:"max=5,dive,alpha"`
+    Items    []testItem `json:"items"`
+    Note     *string    `json:"note"`
+    UserID   int64      `json:"-"`
+    internal int64
+}
+
+func TestSchemaFromValidateTags(t *testing.T) {
+    spec := New(Info{Title: "test", Version: "1"}, testError{}, nil)
+    spec.Add(Route{Method: http.MethodPost, Pattern: "/things", Request: testRequest{}, Response: testItem{}, Status: http.StatusCreated})
+
+    schemas := spec.Document().Components.Schemas
+    require.Contains(t, schemas, ErrorSchema)
+    require.Contains(t, schemas[ErrorSchema].Properties, "error")
+    require.NotContains(t, schemas[ErrorSchema].Properties, "Err")
+
+    schema := schemas["testRequest"]
+    require.NotNil(t, schema)
+    require.ElementsMatch(t, []string{"username", "ifsc", "amount"}, schema.Required)
+    require.NotContains(t, schema.Properties, "UserID")
+    require.NotContains(t, schema.Properties, "internal")
+
+    // the embedded struct is inlined
+    require.Equal(t, "int64", schema.Properties["id"].Format)
+    require.Equal(t, "date-time", schema.Properties["created_at"].Format)
+
+    username := schema.Properties["username"]
+    require.Equal(t, "^[a-zA-Z0-9]+$", username.Pattern)
+    require.Equal(t, int64(3), *username.MinLength)
+    require.Equal(t, int64(20), *username.MaxLength)
+
+    require.Equal(t, "email", schema.Properties["email"].Format)
+    require.Equal(t, patterns["ifsc"], schema.Properties["ifsc"].Pattern)
+
+    amount := schema.Properties["amount"]
+    require.Equal(t, float64(0), *amount.Minimum)
+    require.True(t, amount.ExclusiveMinimum)
+    require.Equal(t, float64(1000), *amount.Maximum)
+    require.False(t, amount.ExclusiveMaximum)
+
+    require.Equal(t, []string{"CARD", "BANK"}, schema.Properties["kind"].Enum)
+
+    tags := schema.Properties["tags"]
+    require.Equal(t, "array", tags.Type)
+    require.Equal(t, int64(5), *tags.MaxItems)
+    require.Equal(t, patterns["alpha"], tags.Items.Pattern)
+
+    require.Equal(t, "#/components/schemas/testItem", schema.Properties["items"].Items.Ref)
+    require.Equal(t, []string{"code"}, schemas["testItem"].Required)
+    require.Equal(t, int64(3), *schemas["testItem"].Properties["code"].MinLength)
+    require.Equal(t, int64(3), *schemas["testItem"].Properties["code"].MaxLength)
+
+    require.True(t, schema.Properties["note"].Nullable)
+}
+
+func TestAddRoute(t *testing.T) {
+    spec := New(Info{Title: "test", Version: "1"}, testError{}, map[string]SecurityScheme{BearerAuth: {Type: "http", Scheme: "bearer"}})
+    spec.Add(
+        Route{Method: http.MethodGet, Pattern: "/pay/{slug}", Response: testItem{}},
+        Route{Method: http.MethodPost, Pattern: "/wallets/{walletID}/items", Request: testItem{}, Response: []testItem{}, Status: http.StatusCreated,
+            Security: []string{BearerAuth}},
+    )
+
+    require.True(t, spec.Has(http.MethodGet, "/pay/{slug}"))
+    require.False(t, spec.Has(http.MethodPost, "/pay/{slug}"))
+    require.Equal(t, []string{"GET /pay/{slug}", "POST /wallets/{walletID}/items"}, spec.Operations())
+
+    public := spec.Document().Paths["/pay/{slug}"]["get"]
+    require.Equal(t, "getPayBySlug", public.OperationID)
+    require.Equal(t, "string", public.Parameters[0].Schema.Type)
+    require.Empty(t, public.Security)
+    require.NotContains(t, public.Responses, "401")
+    require.Contains(t, public.Responses, "404")
+
+    op := spec.Document().Paths["/wallets/{walletID}/items"]["post"]
+    require.Equal(t, "postWalletsByWalletIDItems", op.OperationID)
+    require.Equal(t, "walletID", op.Parameters[0].Name)
+    require.Equal(t, "integer", op.Parameters[0].Schema.Type)
+    require.True(t, op.RequestBody.Required)
+    require.Equal(t, "array", op.Responses["201"].Content["application/json"].Schema.Type)
+    require.Equal(t, []map[string][]string{{BearerAuth: {}}}, op.Security)
+    for _, status := range []string{"400", "401", "403", "404", "500"} {
+        require.Equal(t, "#/components/schemas/"+ErrorSchema, op.Responses[status].Content["application/json"].Schema.Ref)
+    }
+}
+
+func TestMountAndDeprecate(t *testing.T) {
+    routes := []Route{{Method: http.MethodGet, Pattern: "/wallets/{walletID}", Response: testItem{}}}
+
+    spec := New(Info{Title: "test", Version: "1"}, testError{}, nil)
+    spec.Add(Mount("/v1", routes)...)
+    spec.Add(Deprecate(routes)...)
+
+    require.Equal(t, []string{"GET /v1/wallets/{walletID}", "GET /wallets/{walletID}"}, spec.Operations())
+    require.Equal(t, "/wallets/{walletID}", routes[0].Pattern)
+
+    v1 := spec.Document().Paths["/v1/wallets/{walletID}"]["get"]
+    require.Equal(t, "getV1WalletsByWalletID", v1.OperationID)
+    require.False(t, v1.Deprecated)
+    require.NotContains(t, v1.Responses, "429")
+    require.True(t, spec.Document().Paths["/wallets/{walletID}"]["get"].Deprecated)
+}
+
+func TestRateLimit(t *testing.T) {
+    spec := New(Info{Title: "test", Version: "1"}, testError{}, nil)
+    spec.Add(RateLimit([]Route{{Method: http.MethodPost, Pattern: "/wallets/pay"}})...)
+
+    op := spec.Document().Paths["/wallets/pay"]["post"]
+    require.Equal(t, "#/components/schemas/"+ErrorSchema, op.Responses["429"].Content["application/json"].Schema.Ref)
+}
@@ -0,0 +1,234 @@
+package openapi
+
+import (
+    "encoding/json"
+    "reflect"
+    "strconv"
+    "strings"
+    "time"
+)
+
+var (
+    timeType      = reflect.TypeOf(time.Time{})
+    rawJSONType   = reflect.TypeOf(json.RawMessage{})
+    marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// patterns of the validate tags that are not JSON schema keywords
+var patterns = map[string]string{
+    "alpha":    "^[a-zA-Z]+$",
+    "alphanum": "^[a-zA-Z0-9]+$",
+    "numeric":  "^[-+]?[0-9]+(\\.[0-9]+)?$",
+    "ifsc":     "^[A-Z]{4}0[A-Z0-9]{6}$",
+}
+
+var formats = map[string]string{
+    "email": "email",
+    "url":   "uri",
+    "uri":   "uri",
+    "uuid":  "uuid",
+}
+
+// schema is the schema of t, the named structs are added to the components and referenced.
+func (s *Spec) schema(t reflect.Type) *Schema {
+    switch {
+    case t == timeType:
+        return &Schema{Type: "string", Format: "date-time"}
+    case t == rawJSONType:
+        return &Schema{}
+    case t.Kind() != reflect.Ptr && t.Implements(marshalerType):
+        return &Schema{}
+    }
+
+    switch t.Kind() {
+    case reflect.Ptr:
+        schema := s.schema(t.Elem())
+        if schema.Ref != "" {
+            return schema
+        }
+        schema.Nullable = true
+        return schema
+    case reflect.Bool:
+        return &Schema{Type: "boolean"}
+    case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+        return &Schema{Type: "integer"}
+    case reflect.Int32, reflect.Uint32:
+        return &Schema{Type: "integer", Format: "int32"}
+    case reflect.Int64, reflect.Uint64:
+        return &Schema{Type: "integer", Format: "int64"}
+    case reflect.Float32:
+        return &Schema{Type: "number", Format: "float"}
+    case reflect.Float64:
+        return &Schema{Type: "number", Format: "double"}
+    case reflect.String:
+        return &Schema{Type: "string"}
+    case reflect.Slice, reflect.Array:
+        if t.Elem().Kind() == reflect.Uint8 {
+            return &Schema{Type: "string", Format: "byte"}
+        }
+        return &Schema{Type: "array", Items: s.schema(t.Elem())}
+    case reflect.Map:
+        return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+    case reflect.Struct:
+        if t.Name() == "" {
+            return s.structSchema(t)
+        }
+        return &Schema{Ref: "#/components/schemas/" + s.component(t)}
+    default:
+        return &Schema{}
+    }
+}
+
+// component adds the schema of the named struct t to the components, once, and returns its name. The name is the
+// name of the type, prefixed by its package when another package has a type of the same name, e.g. domain.Wallet.
+func (s *Spec) component(t reflect.Type) string {
+    name := t.Name()
+    if other, ok := s.types[name]; ok && other != t {
+        pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+        name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+    }
+    if _, ok := s.types[name]; ok {
+        return name
+    }
+
+    s.types[name] = t
+    // set before the properties, for the structs referencing themselves
+    s.doc.Components.Schemas[name] = &Schema{Type: "object"}
+    s.doc.Components.Schemas[name] = s.structSchema(t)
+    return name
+}
+
+// structSchema is the object of the exported fields of t as encoding/json writes them, embedded structs inlined.
+func (s *Spec) structSchema(t reflect.Type) *Schema {
+    schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+    for i := 0; i < t.NumField(); i++ {
+        field := t.Field(i)
+        if field.PkgPath != "" && !field.Anonymous {
+            continue
+        }
+
+        tag := field.Tag.Get("json")
+        if tag == "-" {
+            continue
+        }
+        name := strings.Split(tag, ",")[0]
+
+        if field.Anonymous && name == "" {
+            ft := field.Type
+            if ft.Kind() == reflect.Ptr {
+                ft = ft.Elem()
+            }
+            if ft.Kind() == reflect.Struct {
+                embedded := s.structSchema(ft)
+                for k, v := range embedded.Properties {
+                    schema.Properties[k] = v
+                }
+                schema.Required = append(schema.Required, embedded.Required...)
+                continue
+            }
+        }
+        if field.PkgPath != "" {
+            continue
+        }
+        if name == "" {
+            name = field.Name
+        }
+
+        property := s.schema(field.Type)
+        if applyValidation(property, field.Tag.Get("validate")) {
+            schema.Required = append(schema.Required, name)
+        }
+        schema.Properties[name] = property
+    }
+
+    return schema
+}
+
+// applyValidation sets the constraints of the validate tag on schema, and tells whether the field is required.
+// The constraints after dive apply to the items of the array.
+func applyValidation(schema *Schema, tag string) bool {
+    if tag == "" || tag == "-" {
+        return false
+    }
+
+    required := false
+    target := schema
+    for _, rule := range strings.Split(tag, ",") {
+        name, param := rule, ""
+        if i := strings.Index(rule, "="); i >= 0 {
+            name, param = rule[:i], rule[i+1:]
+        }
+
+        switch name {
+        case "required":
+            if target == schema {
+                required = true
+            }
+        case "dive":
+            if target.Items == nil {
+                return required
+            }
+            target = target.Items
+        case "oneof":
+            target.Enum = strings.Fields(param)
+        case "len":
+            bound(target, param, true, false)
+            bound(target, param, false, false)
+        case "min", "gte":
+            bound(target, param, true, false)
+        case "gt":
+            bound(target, param, true, true)
+        case "max", "lte":
+            bound(target, param, false, false)
+        case "lt":
+            bound(target, param, false, true)
+        default:
+            if pattern, ok := patterns[name]; ok {
+                target.Pattern = pattern
+            } else if format, ok := formats[name]; ok {
+                target.Format = format
+            }
+        }
+    }
+
+    return required
+}
+
+// bound sets the lower or upper bound of the value, the length of the string or the number of items.
+func bound(schema *Schema, param string, lower, exclusive bool) {
+    n, err := strconv.ParseFloat(param, 64)
+    if err != nil {
+        return
+    }
+
+    count := int64(n)
+    if exclusive && lower {
+        count++
+    } else if exclusive {
+        count--
+    }
+
+    switch schema.Type {
+    case "string":
+        if lower {
+            schema.MinLength = &count
+        } else {
+            schema.MaxLength = &count
+        }
+    case "array":
+        if lower {
+            schema.MinItems = &count
+        } else {
+            schema.MaxItems = &count
+        }
+    case "integer", "number":
+        if lower {
+            schema.Minimum = &n
+            schema.ExclusiveMinimum = exclusive
+        } else {
+            schema.Maximum = &n
+            schema.ExclusiveMaximum = exclusive
+        }
+    }
+}
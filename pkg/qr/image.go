@@ -0,0 +1,59 @@
+package qr
+
+import (
+    "fmt"
+    "image"
+    "image/color"
+    "image/png"
+    "io"
+    "strings"
+)
+
+// QuietZone is the light border around the code, in modules, that scanners need to find it.
+const QuietZone = 4
+
+// Image renders the code with scale pixels per module, quiet zone included.
+func (c *Code) Image(scale int) *image.Paletted {
+    width := (c.Size + 2*QuietZone) * scale
+    img := image.NewPaletted(image.Rect(0, 0, width, width), color.Palette{color.White, color.Black})
+
+    for y := 0; y < c.Size; y++ {
+        for x := 0; x < c.Size; x++ {
+            if !c.modules[y][x] {
+                continue
+            }
+            for dy := 0; dy < scale; dy++ {
+                for dx := 0; dx < scale; dx++ {
+                    img.SetColorIndex((x+QuietZone)*scale+dx, (y+QuietZone)*scale+dy, 1)
+                }
+            }
+        }
+    }
+    return img
+}
+
+func (c *Code) WritePNG(w io.Writer, scale int) error {
+    return png.Encode(w, c.Image(scale))
+}
+
+// WriteSVG writes the code as a single path in a viewBox of one unit per module, scale only sets its default size.
+func (c *Code) WriteSVG(w io.Writer, scale int) error {
+    width := c.Size + 2*QuietZone
+
+    var path strings.Builder
+    for y := 0; y < c.Size; y++ {
+        for x := 0; x < c.Size; x++ {
+            if c.modules[y][x] {
+                fmt.Fprintf(&path, "M%d,%dh1v1h-1z", x+QuietZone, y+QuietZone)
+            }
+        }
+    }
+
+    _, err := fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">
+<rect width="100%%" height="100%%" fill="#ffffff"/>
+<path d="%s" fill="#000000"/>
+</svg>
+`, width*scale, width*scale, width, width, path.String())
+    return err
+}
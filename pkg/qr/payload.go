@@ -0,0 +1,93 @@
+package qr
+
+import (
+    "errors"
+    "fmt"
+    "hash/crc32"
+    "net/url"
+    "strconv"
+    "strings"
+)
+
+// PayloadPrefix starts every payment payload, it lets the apps tell them apart from any other QR code.
+const PayloadPrefix = "simplewallet://pay?"
+
+var (
+    ErrInvalidPayload   = errors.New("invalid payment payload")
+    ErrChecksumMismatch = errors.New("payment payload checksum mismatch")
+)
+
+// Payload is what a payment QR code holds. A static code of a wallet has no amount, the payer enters it,
+// a dynamic code of a payment request carries the amount and a reference to it.
+type Payload struct {
+    Address   string
+    Amount    int64
+    Currency  string
+    Reference string
+}
+
+// String encodes p as simplewallet://pay?address=..&amount=..&currency=..&reference=..&checksum=..
+// where the empty amount and reference are left out, and the checksum is the CRC-32 of everything before it.
+func (p Payload) String() string {
+    var b strings.Builder
+    b.WriteString(PayloadPrefix)
+    b.WriteString("address=" + url.QueryEscape(p.Address))
+    if p.Amount > 0 {
+        b.WriteString("&amount=" + strconv.FormatInt(p.Amount, 10))
+    }
+    b.WriteString("&currency=" + url.QueryEscape(p.Currency))
+    if p.Reference != "" {
+        b.WriteString("&reference=" + url.QueryEscape(p.Reference))
+    }
+
+    s := b.String()
+    return s + "&checksum=" + checksum(s)
+}
+
+// Encode makes the QR code of p, at the medium level to survive a worn print or a cracked screen.
+func (p Payload) Encode() (*Code, error) {
+    return Encode(p.String(), Medium)
+}
+
+// Parse reads a scanned payload, the checksum is checked before anything else.
+func Parse(s string) (Payload, error) {
+    var p Payload
+
+    s = strings.TrimSpace(s)
+    if !strings.HasPrefix(s, PayloadPrefix) {
+        return p, ErrInvalidPayload
+    }
+
+    i := strings.LastIndex(s, "&checksum=")
+    if i < 0 {
+        return p, ErrInvalidPayload
+    }
+    if s[i+len("&checksum="):] != checksum(s[:i]) {
+        return p, ErrChecksumMismatch
+    }
+
+    values, err := url.ParseQuery(s[len(PayloadPrefix):i])
+    if err != nil {
+        return p, ErrInvalidPayload
+    }
+
+    p.Address = values.Get("address")
+    p.Currency = values.Get("currency")
+    p.Reference = values.Get("reference")
+    if p.Address == "" || p.Currency == "" {
+        return p, ErrInvalidPayload
+    }
+
+    if v := values.Get("amount"); v != "" {
+        p.Amount, err = strconv.ParseInt(v, 10, 64)
+        if err != nil || p.Amount <= 0 {
+            return Payload{}, ErrInvalidPayload
+        }
+    }
+
+    return p, nil
+}
+
+func checksum(s string) string {
+    return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(s)))
+}
@@ -0,0 +1,70 @@
+package qr_test
+
+import (
+    "github.com/pranayhere/simple-wallet/pkg/qr"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+)
+
+func TestPayload(t *testing.T) {
+    testcases := []struct {
+        name    string
+        payload qr.Payload
+        prefix  string
+    }{
+        {
+            name:    "Static",
+            payload: qr.Payload{Address: "walter@my.wallet", Currency: "INR"},
+            prefix:  "simplewallet://pay?address=walter%40my.wallet&currency=INR&checksum=",
+        },
+        {
+            name:    "Dynamic",
+            payload: qr.Payload{Address: "walter@my.wallet", Amount: 1250, Currency: "INR", Reference: "payment-req 42"},
+            prefix:  "simplewallet://pay?address=walter%40my.wallet&amount=1250&currency=INR&reference=payment-req+42&checksum=",
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            s := tc.payload.String()
+            require.True(t, strings.HasPrefix(s, tc.prefix), s)
+            require.Len(t, s, len(tc.prefix)+8)
+
+            p, err := qr.Parse(s)
+            require.NoError(t, err)
+            require.Equal(t, tc.payload, p)
+
+            code, err := tc.payload.Encode()
+            require.NoError(t, err)
+            require.Equal(t, qr.Medium, code.Level)
+        })
+    }
+}
+
+func TestParseInvalid(t *testing.T) {
+    valid := qr.Payload{Address: "walter@my.wallet", Amount: 1250, Currency: "INR"}.String()
+
+    testcases := []struct {
+        name    string
+        payload string
+        err     error
+    }{
+        {name: "OtherQR", payload: "https://example.com", err: qr.ErrInvalidPayload},
+        {name: "NoChecksum", payload: "simplewallet://pay?address=walter%40my.wallet&currency=INR", err: qr.ErrInvalidPayload},
+        {name: "Tampered", payload: strings.Replace(valid, "amount=1250", "amount=9250", 1), err: qr.ErrChecksumMismatch},
+        {name: "NoAddress", payload: qr.Payload{Currency: "INR"}.String(), err: qr.ErrInvalidPayload},
+        {name: "NoCurrency", payload: qr.Payload{Address: "walter@my.wallet"}.String(), err: qr.ErrInvalidPayload},
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            _, err := qr.Parse(tc.payload)
+            require.Equal(t, tc.err, err)
+        })
+    }
+
+    // surrounding whitespace from the scanner is ignored
+    _, err := qr.Parse(" " + valid + "\n")
+    require.NoError(t, err)
+}
@@ -0,0 +1,503 @@
+// Package qr encodes text as QR codes (ISO/IEC 18004) in byte mode, up to version 10,
+// and renders them as PNG or SVG images, so no external library or tool is needed.
+// It also builds and parses the payment payloads the wallets share as QR codes.
+package qr
+
+import "errors"
+
+// Level is the error correction level, a higher level survives more damage but holds less data.
+type Level int
+
+const (
+    Low Level = iota
+    Medium
+    Quartile
+    High
+)
+
+// MaxVersion is the largest version Encode produces, a 57x57 code.
+const MaxVersion = 10
+
+var ErrTooLong = errors.New("text too long for a qr code")
+
+// Code is a square of modules, true being dark.
+type Code struct {
+    Version  int
+    Level    Level
+    Mask     int
+    Size     int
+    modules  [][]bool
+    function [][]bool
+}
+
+// Dark reports whether the module at column x, row y is dark. Modules outside the code are light.
+func (c *Code) Dark(x, y int) bool {
+    if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+        return false
+    }
+    return c.modules[y][x]
+}
+
+// block layout of each version and level, from table 9 of the specification
+type blockLayout struct {
+    ecPerBlock int
+    group1     int
+    group1Data int
+    group2     int
+    group2Data int
+}
+
+var blockLayouts = [MaxVersion + 1][4]blockLayout{
+    {},
+    {{7, 1, 19, 0, 0}, {10, 1, 16, 0, 0}, {13, 1, 13, 0, 0}, {17, 1, 9, 0, 0}},
+    {{10, 1, 34, 0, 0}, {16, 1, 28, 0, 0}, {22, 1, 22, 0, 0}, {28, 1, 16, 0, 0}},
+    {{15, 1, 55, 0, 0}, {26, 1, 44, 0, 0}, {18, 2, 17, 0, 0}, {22, 2, 13, 0, 0}},
+    {{20, 1, 80, 0, 0}, {18, 2, 32, 0, 0}, {26, 2, 24, 0, 0}, {16, 4, 9, 0, 0}},
+    {{26, 1, 108, 0, 0}, {24, 2, 43, 0, 0}, {18, 2, 15, 2, 16}, {22, 2, 11, 2, 12}},
+    {{18, 2, 68, 0, 0}, {16, 4, 27, 0, 0}, {24, 4, 19, 0, 0}, {28, 4, 15, 0, 0}},
+    {{20, 2, 78, 0, 0}, {18, 4, 31, 0, 0}, {18, 2, 14, 4, 15}, {26, 4, 13, 1, 14}},
+    {{24, 2, 97, 0, 0}, {22, 2, 38, 2, 39}, {22, 4, 18, 2, 19}, {26, 4, 14, 2, 15}},
+    {{30, 2, 116, 0, 0}, {22, 3, 36, 2, 37}, {20, 4, 16, 4, 17}, {24, 4, 12, 4, 13}},
+    {{18, 2, 68, 2, 69}, {26, 4, 43, 1, 44}, {24, 6, 19, 2, 20}, {28, 6, 15, 2, 16}},
+}
+
+func (b blockLayout) dataCodewords() int {
+    return b.group1*b.group1Data + b.group2*b.group2Data
+}
+
+var alignmentPositions = [MaxVersion + 1][]int{
+    {}, {}, {6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34}, {6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50},
+}
+
+// format bits of each level, L and M are swapped on purpose
+var levelBits = [4]int{1, 0, 3, 2}
+
+// Encode makes the smallest code holding text at level, choosing the mask with the lowest penalty.
+func Encode(text string, level Level) (*Code, error) {
+    data := []byte(text)
+
+    version := 0
+    for v := 1; v <= MaxVersion; v++ {
+        if 4+countBits(v)+8*len(data) <= 8*blockLayouts[v][level].dataCodewords() {
+            version = v
+            break
+        }
+    }
+    if version == 0 {
+        return nil, ErrTooLong
+    }
+
+    codewords := addErrorCorrection(dataCodewords(data, version, level), version, level)
+
+    best, bestPenalty := -1, 0
+    var code *Code
+    for mask := 0; mask < 8; mask++ {
+        c := newCode(version, level)
+        c.drawCodewords(codewords)
+        c.applyMask(mask)
+        c.drawFormatBits(mask)
+
+        if penalty := c.penalty(); best < 0 || penalty < bestPenalty {
+            best, bestPenalty, code = mask, penalty, c
+        }
+    }
+
+    code.Mask = best
+    return code, nil
+}
+
+// countBits is the length of the character count indicator in byte mode
+func countBits(version int) int {
+    if version < 10 {
+        return 8
+    }
+    return 16
+}
+
+func dataCodewords(data []byte, version int, level Level) []byte {
+    capacity := blockLayouts[version][level].dataCodewords()
+
+    var bb bitBuffer
+    bb.append(0x4, 4) // byte mode
+    bb.append(len(data), countBits(version))
+    for _, b := range data {
+        bb.append(int(b), 8)
+    }
+
+    // terminator, then padding to a whole codeword
+    terminator := 8*capacity - len(bb)
+    if terminator > 4 {
+        terminator = 4
+    }
+    bb.append(0, terminator)
+    bb.append(0, (8-len(bb)%8)%8)
+
+    res := make([]byte, 0, capacity)
+    for i := 0; i < len(bb); i += 8 {
+        var b byte
+        for _, bit := range bb[i : i+8] {
+            b = b<<1 | bit
+        }
+        res = append(res, b)
+    }
+
+    for pad := byte(0xEC); len(res) < capacity; pad ^= 0xEC ^ 0x11 {
+        res = append(res, pad)
+    }
+    return res
+}
+
+// addErrorCorrection splits the data in blocks and interleaves them, followed by their error correction codewords.
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+    layout := blockLayouts[version][level]
+    divisor := rsDivisor(layout.ecPerBlock)
+
+    var blocks, ecBlocks [][]byte
+    for i := 0; i < layout.group1+layout.group2; i++ {
+        n := layout.group1Data
+        if i >= layout.group1 {
+            n = layout.group2Data
+        }
+        blocks = append(blocks, data[:n])
+        ecBlocks = append(ecBlocks, rsRemainder(data[:n], divisor))
+        data = data[n:]
+    }
+
+    var res []byte
+    for i := 0; i < layout.group2Data || i < layout.group1Data; i++ {
+        for _, b := range blocks {
+            if i < len(b) {
+                res = append(res, b[i])
+            }
+        }
+    }
+    for i := 0; i < layout.ecPerBlock; i++ {
+        for _, b := range ecBlocks {
+            res = append(res, b[i])
+        }
+    }
+    return res
+}
+
+func newCode(version int, level Level) *Code {
+    size := 4*version + 17
+    c := &Code{Version: version, Level: level, Size: size}
+    c.modules = make([][]bool, size)
+    c.function = make([][]bool, size)
+    for i := range c.modules {
+        c.modules[i] = make([]bool, size)
+        c.function[i] = make([]bool, size)
+    }
+
+    for i := 0; i < size; i++ {
+        c.setFunction(6, i, i%2 == 0)
+        c.setFunction(i, 6, i%2 == 0)
+    }
+
+    c.drawFinder(3, 3)
+    c.drawFinder(size-4, 3)
+    c.drawFinder(3, size-4)
+
+    pos := alignmentPositions[version]
+    for i := range pos {
+        for j := range pos {
+            // the corners taken by the finders
+            if (i == 0 && j == 0) || (i == 0 && j == len(pos)-1) || (i == len(pos)-1 && j == 0) {
+                continue
+            }
+            c.drawAlignment(pos[i], pos[j])
+        }
+    }
+
+    // reserves the format areas, they are written once the mask is known
+    c.drawFormatBits(0)
+    c.drawVersion()
+    return c
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+    c.modules[y][x] = dark
+    c.function[y][x] = true
+}
+
+// drawFinder draws the finder centred on x, y with its light separator
+func (c *Code) drawFinder(x, y int) {
+    for dy := -4; dy <= 4; dy++ {
+        for dx := -4; dx <= 4; dx++ {
+            xx, yy := x+dx, y+dy
+            if xx < 0 || yy < 0 || xx >= c.Size || yy >= c.Size {
+                continue
+            }
+            dist := max(abs(dx), abs(dy))
+            c.setFunction(xx, yy, dist != 2 && dist != 4)
+        }
+    }
+}
+
+func (c *Code) drawAlignment(x, y int) {
+    for dy := -2; dy <= 2; dy++ {
+        for dx := -2; dx <= 2; dx++ {
+            c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+        }
+    }
+}
+
+func (c *Code) drawFormatBits(mask int) {
+    bits := formatBits(c.Level, mask)
+    bit := func(i int) bool { return bits>>uint(i)&1 != 0 }
+
+    // next to the top left finder
+    for i := 0; i <= 5; i++ {
+        c.setFunction(8, i, bit(i))
+    }
+    c.setFunction(8, 7, bit(6))
+    c.setFunction(8, 8, bit(7))
+    c.setFunction(7, 8, bit(8))
+    for i := 9; i < 15; i++ {
+        c.setFunction(14-i, 8, bit(i))
+    }
+
+    // split between the two other finders
+    for i := 0; i < 8; i++ {
+        c.setFunction(c.Size-1-i, 8, bit(i))
+    }
+    for i := 8; i < 15; i++ {
+        c.setFunction(8, c.Size-15+i, bit(i))
+    }
+    c.setFunction(8, c.Size-8, true)
+}
+
+// formatBits is the level and mask protected by a BCH(15,5) code
+func formatBits(level Level, mask int) int {
+    data := levelBits[level]<<3 | mask
+    rem := data
+    for i := 0; i < 10; i++ {
+        rem = rem<<1 ^ (rem>>9)*0x537
+    }
+    return (data<<10 | rem) ^ 0x5412
+}
+
+// drawVersion writes the version blocks of the versions 7 and up, protected by a BCH(18,6) code
+func (c *Code) drawVersion() {
+    if c.Version < 7 {
+        return
+    }
+
+    bits := versionBits(c.Version)
+    for i := 0; i < 18; i++ {
+        dark := bits>>uint(i)&1 != 0
+        a, b := c.Size-11+i%3, i/3
+        c.setFunction(a, b, dark)
+        c.setFunction(b, a, dark)
+    }
+}
+
+func versionBits(version int) int {
+    rem := version
+    for i := 0; i < 12; i++ {
+        rem = rem<<1 ^ (rem>>11)*0x1F25
+    }
+    return version<<12 | rem
+}
+
+// drawCodewords fills the modules left free by the function patterns, in the zigzag order of the specification.
+func (c *Code) drawCodewords(codewords []byte) {
+    i := 0
+    for right := c.Size - 1; right >= 1; right -= 2 {
+        // the vertical timing pattern is skipped
+        if right == 6 {
+            right = 5
+        }
+        for vert := 0; vert < c.Size; vert++ {
+            for j := 0; j < 2; j++ {
+                x := right - j
+                y := vert
+                if (right+1)&2 == 0 {
+                    y = c.Size - 1 - vert
+                }
+                if c.function[y][x] || i >= len(codewords)*8 {
+                    continue
+                }
+                c.modules[y][x] = codewords[i>>3]>>uint(7-i&7)&1 != 0
+                i++
+            }
+        }
+    }
+}
+
+func (c *Code) applyMask(mask int) {
+    for y := 0; y < c.Size; y++ {
+        for x := 0; x < c.Size; x++ {
+            if !c.function[y][x] && maskBit(mask, x, y) {
+                c.modules[y][x] = !c.modules[y][x]
+            }
+        }
+    }
+}
+
+func maskBit(mask, x, y int) bool {
+    switch mask {
+    case 0:
+        return (x+y)%2 == 0
+    case 1:
+        return y%2 == 0
+    case 2:
+        return x%3 == 0
+    case 3:
+        return (x+y)%3 == 0
+    case 4:
+        return (x/3+y/2)%2 == 0
+    case 5:
+        return x*y%2+x*y%3 == 0
+    case 6:
+        return (x*y%2+x*y%3)%2 == 0
+    default:
+        return ((x+y)%2+x*y%3)%2 == 0
+    }
+}
+
+// penalty scores the code with the four rules of the specification, the lowest score is the easiest to scan.
+func (c *Code) penalty() int {
+    res := 0
+
+    // runs of 5 or more modules of the same colour, in rows and columns
+    for i := 0; i < c.Size; i++ {
+        for _, horizontal := range []bool{true, false} {
+            run := 0
+            for j := 0; j < c.Size; j++ {
+                if j > 0 && c.at(i, j, horizontal) == c.at(i, j-1, horizontal) {
+                    run++
+                } else {
+                    run = 1
+                }
+                if run == 5 {
+                    res += 3
+                } else if run > 5 {
+                    res++
+                }
+            }
+        }
+    }
+
+    // 2x2 blocks of the same colour
+    for y := 0; y < c.Size-1; y++ {
+        for x := 0; x < c.Size-1; x++ {
+            d := c.modules[y][x]
+            if d == c.modules[y][x+1] && d == c.modules[y+1][x] && d == c.modules[y+1][x+1] {
+                res += 3
+            }
+        }
+    }
+
+    // patterns looking like a finder, 1:1:3:1:1 with 4 light modules on one side
+    finder := []bool{true, false, true, true, true, false, true}
+    for i := 0; i < c.Size; i++ {
+        for _, horizontal := range []bool{true, false} {
+            for j := 0; j+7 <= c.Size; j++ {
+                matches := true
+                for k, dark := range finder {
+                    if c.at(i, j+k, horizontal) != dark {
+                        matches = false
+                        break
+                    }
+                }
+                if matches && (c.lightRun(i, j-4, horizontal) || c.lightRun(i, j+7, horizontal)) {
+                    res += 40
+                }
+            }
+        }
+    }
+
+    // balance of dark and light modules, 10 points per 5% away from half
+    dark := 0
+    for y := 0; y < c.Size; y++ {
+        for x := 0; x < c.Size; x++ {
+            if c.modules[y][x] {
+                dark++
+            }
+        }
+    }
+    total := c.Size * c.Size
+    res += (abs(dark*20-total*10)+total-1)/total*10 - 10
+    return res
+}
+
+// at is the module j of the row i, or of the column i when not horizontal
+func (c *Code) at(i, j int, horizontal bool) bool {
+    if horizontal {
+        return c.Dark(j, i)
+    }
+    return c.Dark(i, j)
+}
+
+// lightRun reports whether the 4 modules from j are light, the quiet zone counts as light
+func (c *Code) lightRun(i, j int, horizontal bool) bool {
+    for k := j; k < j+4; k++ {
+        if c.at(i, k, horizontal) {
+            return false
+        }
+    }
+    return true
+}
+
+type bitBuffer []byte
+
+func (bb *bitBuffer) append(v, n int) {
+    for i := n - 1; i >= 0; i-- {
+        *bb = append(*bb, byte(v>>uint(i)&1))
+    }
+}
+
+// rsDivisor is the generator polynomial of degree n over GF(256), without its leading 1.
+func rsDivisor(n int) []byte {
+    res := make([]byte, n)
+    res[n-1] = 1
+    root := byte(1)
+    for i := 0; i < n; i++ {
+        for j := range res {
+            res[j] = gfMul(res[j], root)
+            if j+1 < n {
+                res[j] ^= res[j+1]
+            }
+        }
+        root = gfMul(root, 0x02)
+    }
+    return res
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+    res := make([]byte, len(divisor))
+    for _, b := range data {
+        factor := b ^ res[0]
+        copy(res, res[1:])
+        res[len(res)-1] = 0
+        for i, d := range divisor {
+            res[i] ^= gfMul(d, factor)
+        }
+    }
+    return res
+}
+
+// gfMul multiplies in GF(256) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMul(x, y byte) byte {
+    z := 0
+    for i := 7; i >= 0; i-- {
+        z = z<<1 ^ (z>>7)*0x11D
+        z ^= int(y>>uint(i)&1) * int(x)
+    }
+    return byte(z)
+}
+
+func abs(v int) int {
+    if v < 0 {
+        return -v
+    }
+    return v
+}
+
+func max(a, b int) int {
+    if a > b {
+        return a
+    }
+    return b
+}
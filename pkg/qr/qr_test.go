@@ -0,0 +1,174 @@
+package qr
+
+import (
+    "bytes"
+    "github.com/stretchr/testify/require"
+    "image/png"
+    "strings"
+    "testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+    // "HELLO WORLD" at 1-M, the worked example of the specification
+    data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+    ec := rsRemainder(data, rsDivisor(10))
+    require.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ec)
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+    require.Equal(t, 0x77C4, formatBits(Low, 0))    // 111011111000100
+    require.Equal(t, 0x662F, formatBits(Low, 4))    // 110011000101111
+    require.Equal(t, 0x5412, formatBits(Medium, 0)) // 101010000010010
+    require.Equal(t, 0x07C94, versionBits(7))       // 000111110010010100
+}
+
+func TestEncode(t *testing.T) {
+    testcases := []struct {
+        name    string
+        text    string
+        level   Level
+        version int
+    }{
+        {name: "Version1", text: "hello", level: Medium, version: 1},
+        {name: "Version4", text: strings.Repeat("a", 60), level: Medium, version: 4},
+        {name: "Version7", text: strings.Repeat("b", 120), level: Medium, version: 7},
+        {name: "Version10", text: strings.Repeat("c", 200), level: Medium, version: 10},
+        {name: "TwoGroups", text: strings.Repeat("d", 100), level: Quartile, version: 8},
+        {name: "High", text: "simplewallet://pay?address=a%40my.wallet&currency=INR", level: High, version: 6},
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            code, err := Encode(tc.text, tc.level)
+            require.NoError(t, err)
+            require.Equal(t, tc.version, code.Version)
+            require.Equal(t, 4*tc.version+17, code.Size)
+            require.Equal(t, tc.text, decode(t, code))
+        })
+    }
+}
+
+func TestEncodeTooLong(t *testing.T) {
+    _, err := Encode(strings.Repeat("x", 300), Medium)
+    require.Equal(t, ErrTooLong, err)
+}
+
+func TestWritePNG(t *testing.T) {
+    code, err := Encode("hello", Medium)
+    require.NoError(t, err)
+
+    var buf bytes.Buffer
+    require.NoError(t, code.WritePNG(&buf, 4))
+
+    img, err := png.Decode(&buf)
+    require.NoError(t, err)
+    require.Equal(t, (21+2*QuietZone)*4, img.Bounds().Dx())
+
+    // the top left corner of the finder is dark, the quiet zone is light
+    r, _, _, _ := img.At(QuietZone*4, QuietZone*4).RGBA()
+    require.Zero(t, r)
+    r, _, _, _ = img.At(0, 0).RGBA()
+    require.NotZero(t, r)
+}
+
+func TestWriteSVG(t *testing.T) {
+    code, err := Encode("hello", Medium)
+    require.NoError(t, err)
+
+    var buf bytes.Buffer
+    require.NoError(t, code.WriteSVG(&buf, 8))
+    require.Contains(t, buf.String(), `width="232" height="232" viewBox="0 0 29 29"`)
+    require.Contains(t, buf.String(), `<path d="M4,4h1v1h-1z`)
+}
+
+// decode reads the code back the way a scanner does once the modules are sampled:
+// format bits, unmasking, zigzag reading, de-interleaving and the error correction check.
+func decode(t *testing.T, c *Code) string {
+    format := 0
+    for i := 0; i < 8; i++ {
+        if c.Dark(c.Size-1-i, 8) {
+            format |= 1 << uint(i)
+        }
+    }
+    for i := 8; i < 15; i++ {
+        if c.Dark(8, c.Size-15+i) {
+            format |= 1 << uint(i)
+        }
+    }
+    require.Equal(t, formatBits(c.Level, c.Mask), format)
+
+    var bits []bool
+    for right := c.Size - 1; right >= 1; right -= 2 {
+        if right == 6 {
+            right = 5
+        }
+        for vert := 0; vert < c.Size; vert++ {
+            for j := 0; j < 2; j++ {
+                x, y := right-j, vert
+                if (right+1)&2 == 0 {
+                    y = c.Size - 1 - vert
+                }
+                if !c.function[y][x] {
+                    bits = append(bits, c.Dark(x, y) != maskBit(c.Mask, x, y))
+                }
+            }
+        }
+    }
+
+    codewords := make([]byte, len(bits)/8)
+    for i := range codewords {
+        for _, bit := range bits[i*8 : i*8+8] {
+            codewords[i] <<= 1
+            if bit {
+                codewords[i] |= 1
+            }
+        }
+    }
+
+    layout := blockLayouts[c.Version][c.Level]
+    blockCount := layout.group1 + layout.group2
+    blocks := make([][]byte, blockCount)
+    k := 0
+    for i := 0; i < layout.group2Data || i < layout.group1Data; i++ {
+        for b := range blocks {
+            if (b < layout.group1 && i < layout.group1Data) || (b >= layout.group1 && i < layout.group2Data) {
+                blocks[b] = append(blocks[b], codewords[k])
+                k++
+            }
+        }
+    }
+
+    var data []byte
+    divisor := rsDivisor(layout.ecPerBlock)
+    for i := 0; i < layout.ecPerBlock; i++ {
+        for b := range blocks {
+            blocks[b] = append(blocks[b], codewords[k])
+            k++
+        }
+    }
+    for _, b := range blocks {
+        n := len(b) - layout.ecPerBlock
+        require.Equal(t, b[n:], rsRemainder(b[:n], divisor))
+        data = append(data, b[:n]...)
+    }
+
+    require.Equal(t, byte(0x4), data[0]>>4)
+    var bb bitBuffer
+    for _, b := range data {
+        bb.append(int(b), 8)
+    }
+    read := func(from, n int) int {
+        v := 0
+        for _, bit := range bb[from : from+n] {
+            v = v<<1 | int(bit)
+        }
+        return v
+    }
+
+    n := read(4, countBits(c.Version))
+    text := make([]byte, n)
+    for i := range text {
+        text[i] = byte(read(4+countBits(c.Version)+8*i, 8))
+    }
+    return string(text)
+}
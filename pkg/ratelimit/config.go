@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "strings"
+    "time"
+)
+
+// Config is the policy of the requests of an ip on any route, the default policy and the policies of the routes, by
+// "METHOD pattern" without the version prefix, e.g. "POST /wallets/pay":
+//
+//    {
+//        "ip": {"limit": 300, "window": "1m"},
+//        "default": {"limit": 100, "window": "1m"},
+//        "routes": {
+//            "POST /users/login": {"limit": 10, "window": "1m"}
+//        }
+//    }
+type Config struct {
+    IP      Policy            `json:"ip"`
+    Default Policy            `json:"default"`
+    Routes  map[string]Policy `json:"routes"`
+}
+
+// ReadConfig reads the JSON config of r.
+func ReadConfig(r io.Reader) (Config, error) {
+    var config Config
+
+    dec := json.NewDecoder(r)
+    dec.DisallowUnknownFields()
+    if err := dec.Decode(&config); err != nil {
+        return Config{}, err
+    }
+
+    if err := config.IP.validate(); err != nil {
+        return Config{}, fmt.Errorf("ip: %w", err)
+    }
+    if err := config.Default.validate(); err != nil {
+        return Config{}, fmt.Errorf("default: %w", err)
+    }
+    for route, policy := range config.Routes {
+        parts := strings.SplitN(route, " ", 2)
+        if len(parts) != 2 || parts[0] != strings.ToUpper(parts[0]) || !strings.HasPrefix(parts[1], "/") {
+            return Config{}, fmt.Errorf("route %q: expected METHOD /pattern", route)
+        }
+        if err := policy.validate(); err != nil {
+            return Config{}, fmt.Errorf("route %q: %w", route, err)
+        }
+    }
+
+    return config, nil
+}
+
+func (p Policy) validate() error {
+    if p.Limit <= 0 {
+        return fmt.Errorf("limit must be positive")
+    }
+    if p.Window <= 0 {
+        return fmt.Errorf("window must be positive")
+    }
+    return nil
+}
+
+// Duration is a time.Duration written as in Go, e.g. "1m" or "30s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+    return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+    var s string
+    if err := json.Unmarshal(b, &s); err != nil {
+        return err
+    }
+
+    v, err := time.ParseDuration(s)
+    if err != nil {
+        return err
+    }
+    *d = Duration(v)
+    return nil
+}
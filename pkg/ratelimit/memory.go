@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+    "context"
+    "sync"
+    "time"
+)
+
+type bucket struct {
+    tokens float64
+    last   time.Time
+    full   time.Time
+}
+
+// MemoryStore keeps the buckets in the memory of the process, each instance limits its own requests. The buckets
+// full again are swept at most once per sweepInterval, a bucket taken again afterwards starts full as they were.
+type MemoryStore struct {
+    mu            sync.Mutex
+    buckets       map[string]*bucket
+    now           func() time.Time
+    sweepInterval time.Duration
+    lastSweep     time.Time
+}
+
+func NewMemoryStore(sweepInterval time.Duration) *MemoryStore {
+    return &MemoryStore{
+        buckets:       make(map[string]*bucket),
+        now:           time.Now,
+        sweepInterval: sweepInterval,
+    }
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, policy Policy) (int, time.Time, bool, error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+
+    now := s.now()
+    s.sweep(now)
+
+    limit := float64(policy.Limit)
+    interval := policy.interval()
+
+    b, ok := s.buckets[key]
+    if !ok {
+        b = &bucket{tokens: limit}
+        s.buckets[key] = b
+    } else {
+        b.tokens += float64(now.Sub(b.last)) / float64(interval)
+        if b.tokens > limit {
+            b.tokens = limit
+        }
+    }
+    b.last = now
+
+    allowed := b.tokens >= 1
+    if allowed {
+        b.tokens--
+    }
+    b.full = now.Add(time.Duration((limit - b.tokens) * float64(interval)))
+
+    reset := b.full
+    if !allowed {
+        reset = now.Add(time.Duration((1 - b.tokens) * float64(interval)))
+    }
+
+    return int(b.tokens), reset, allowed, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+    if now.Sub(s.lastSweep) < s.sweepInterval {
+        return
+    }
+    s.lastSweep = now
+
+    for key, b := range s.buckets {
+        if !now.Before(b.full) {
+            delete(s.buckets, key)
+        }
+    }
+}
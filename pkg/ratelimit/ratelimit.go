@@ -0,0 +1,119 @@
+// Package ratelimit limits the requests of each subject, a user, an api key or an ip, to the policy of the route
+// requested, and the requests of each ip, whatever the route, before their authentication. The requests take the
+// tokens of a bucket per subject and route, kept by a Store, in memory for a single instance; a store shared by the
+// instances, e.g. on redis, limits them together.
+package ratelimit
+
+import (
+    "context"
+    "fmt"
+    "net/http"
+    "regexp"
+    "time"
+)
+
+// Policy lets Limit requests per Window: a bucket of Limit tokens, refilled by one token each Window/Limit, a
+// request takes a token or is refused. A subject idle for a Window has its full bucket again, the burst of Limit
+// requests, and a busy one is held to the rate of the policy rather than to Limit requests at each start of a window.
+type Policy struct {
+    Limit  int      `json:"limit"`
+    Window Duration `json:"window"`
+}
+
+// interval is the time to refill a token.
+func (p Policy) interval() time.Duration {
+    return time.Duration(p.Window) / time.Duration(p.Limit)
+}
+
+// Store keeps the token bucket of each key.
+type Store interface {
+    // Take takes a token of the bucket of key, refilled to the policy since it was last taken, and returns the
+    // tokens left, whether there was one to take and when the bucket is full again, or when it has a token again
+    // when there was none. The bucket of a key taken for the first time starts full.
+    Take(ctx context.Context, key string, policy Policy) (remaining int, reset time.Time, allowed bool, err error)
+}
+
+// Result is the quota of the subject on the route after a request.
+type Result struct {
+    Policy    Policy
+    Remaining int
+    Reset     time.Time
+    Allowed   bool
+}
+
+// Limiter applies the policies of Config to the requests, counted in its store.
+type Limiter struct {
+    config Config
+    store  Store
+}
+
+func NewLimiter(config Config, store Store) *Limiter {
+    return &Limiter{
+        config: config,
+        store:  store,
+    }
+}
+
+// Allow takes a token of subject on the route, method and chi pattern, and tells whether it is within the policy
+// of the route. The routes without a policy share the default one.
+func (l *Limiter) Allow(ctx context.Context, subject, method, pattern string) (Result, error) {
+    route, policy := l.config.policy(method, pattern)
+    return l.take(ctx, subject+" "+route, policy)
+}
+
+// AllowIP takes a token of ip on any route, and tells whether it is within the ip policy.
+func (l *Limiter) AllowIP(ctx context.Context, ip string) (Result, error) {
+    return l.take(ctx, "ip:"+ip+" any", l.config.IP)
+}
+
+func (l *Limiter) take(ctx context.Context, key string, policy Policy) (Result, error) {
+    remaining, reset, allowed, err := l.store.Take(ctx, key, policy)
+    if err != nil {
+        return Result{}, err
+    }
+
+    return Result{
+        Policy:    policy,
+        Remaining: remaining,
+        Reset:     reset,
+        Allowed:   allowed,
+    }, nil
+}
+
+var apiVersion = regexp.MustCompile(`^/v[0-9]+/`)
+
+// policy returns the policy of the route and its key, the policies apply to every version of the route.
+func (c Config) policy(method, pattern string) (string, Policy) {
+    route := method + " " + apiVersion.ReplaceAllString(pattern, "/")
+    if policy, ok := c.Routes[route]; ok {
+        return route, policy
+    }
+    return "default", c.Default
+}
+
+// headers
+const (
+    HeaderLimit      = "RateLimit-Limit"
+    HeaderRemaining  = "RateLimit-Remaining"
+    HeaderReset      = "RateLimit-Reset"
+    HeaderPolicy     = "RateLimit-Policy"
+    HeaderRetryAfter = "Retry-After"
+)
+
+// SetHeaders writes the RateLimit-* headers of the IETF draft (draft-ietf-httpapi-ratelimit-headers) of res, and
+// Retry-After when the request is refused. Reset is when the bucket is full again, or has a token again when the
+// request is refused.
+func SetHeaders(h http.Header, res Result, now time.Time) {
+    reset := int64(res.Reset.Sub(now).Round(time.Second) / time.Second)
+    if reset < 0 {
+        reset = 0
+    }
+
+    h.Set(HeaderLimit, fmt.Sprint(res.Policy.Limit))
+    h.Set(HeaderRemaining, fmt.Sprint(res.Remaining))
+    h.Set(HeaderReset, fmt.Sprint(reset))
+    h.Set(HeaderPolicy, fmt.Sprintf("%d;w=%d", res.Policy.Limit, int64(time.Duration(res.Policy.Window)/time.Second)))
+    if !res.Allowed {
+        h.Set(HeaderRetryAfter, fmt.Sprint(reset))
+    }
+}
@@ -0,0 +1,155 @@
+package ratelimit
+
+import (
+    "context"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "strings"
+    "testing"
+    "time"
+)
+
+func newTestLimiter(now *time.Time) *Limiter {
+    store := NewMemoryStore(time.Minute)
+    store.now = func() time.Time { return *now }
+
+    return NewLimiter(Config{
+        IP:      Policy{Limit: 2, Window: Duration(time.Minute)},
+        Default: Policy{Limit: 3, Window: Duration(time.Minute)},
+        Routes: map[string]Policy{
+            "POST /wallets/pay": {Limit: 1, Window: Duration(10 * time.Second)},
+        },
+    }, store)
+}
+
+func TestLimiterAllow(t *testing.T) {
+    ctx := context.Background()
+    now := time.Date(2026, time.October, 20, 10, 0, 0, 0, time.UTC)
+    limiter := newTestLimiter(&now)
+
+    // the bucket starts full, each token refilled in 20s
+    for i := 2; i >= 0; i-- {
+        res, err := limiter.Allow(ctx, "user:1", http.MethodGet, "/v1/wallets/{walletID}")
+        require.NoError(t, err)
+        require.True(t, res.Allowed)
+        require.Equal(t, i, res.Remaining)
+        require.Equal(t, now.Add(time.Duration(3-i)*20*time.Second), res.Reset)
+    }
+
+    // the routes without a policy share the default one
+    res, err := limiter.Allow(ctx, "user:1", http.MethodGet, "/v1/transfers")
+    require.NoError(t, err)
+    require.False(t, res.Allowed)
+    require.Equal(t, 0, res.Remaining)
+    require.Equal(t, now.Add(20*time.Second), res.Reset)
+
+    // other subjects have their own bucket
+    res, err = limiter.Allow(ctx, "user:2", http.MethodGet, "/v1/transfers")
+    require.NoError(t, err)
+    require.True(t, res.Allowed)
+
+    // the route policy applies to every version of the route
+    res, err = limiter.Allow(ctx, "user:1", http.MethodPost, "/v1/wallets/pay")
+    require.NoError(t, err)
+    require.True(t, res.Allowed)
+    require.Equal(t, 1, res.Policy.Limit)
+    res, err = limiter.Allow(ctx, "user:1", http.MethodPost, "/wallets/pay")
+    require.NoError(t, err)
+    require.False(t, res.Allowed)
+
+    // a token is refilled each 20s, not the whole quota at once
+    now = now.Add(30 * time.Second)
+    res, err = limiter.Allow(ctx, "user:1", http.MethodGet, "/v1/transfers")
+    require.NoError(t, err)
+    require.True(t, res.Allowed)
+    require.Equal(t, 0, res.Remaining)
+    res, err = limiter.Allow(ctx, "user:1", http.MethodGet, "/v1/transfers")
+    require.NoError(t, err)
+    require.False(t, res.Allowed)
+    require.Equal(t, now.Add(10*time.Second), res.Reset)
+
+    // an idle subject has its full bucket again
+    now = now.Add(time.Minute)
+    res, err = limiter.Allow(ctx, "user:1", http.MethodGet, "/v1/transfers")
+    require.NoError(t, err)
+    require.True(t, res.Allowed)
+    require.Equal(t, 2, res.Remaining)
+}
+
+func TestLimiterAllowIP(t *testing.T) {
+    ctx := context.Background()
+    now := time.Date(2026, time.October, 20, 10, 0, 0, 0, time.UTC)
+    limiter := newTestLimiter(&now)
+
+    // the ip bucket is apart from the buckets of the routes of the ip
+    res, err := limiter.Allow(ctx, "ip:10.0.0.1", http.MethodGet, "/v1/transfers")
+    require.NoError(t, err)
+    require.True(t, res.Allowed)
+
+    for _, allowed := range []bool{true, true, false} {
+        res, err = limiter.AllowIP(ctx, "10.0.0.1")
+        require.NoError(t, err)
+        require.Equal(t, allowed, res.Allowed)
+        require.Equal(t, 2, res.Policy.Limit)
+    }
+
+    res, err = limiter.AllowIP(ctx, "10.0.0.2")
+    require.NoError(t, err)
+    require.True(t, res.Allowed)
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+    now := time.Date(2026, time.October, 20, 10, 0, 0, 0, time.UTC)
+    store := NewMemoryStore(time.Minute)
+    store.now = func() time.Time { return now }
+
+    _, _, _, err := store.Take(context.Background(), "a", Policy{Limit: 1, Window: Duration(time.Second)})
+    require.NoError(t, err)
+
+    now = now.Add(2 * time.Minute)
+    _, _, _, err = store.Take(context.Background(), "b", Policy{Limit: 1, Window: Duration(time.Hour)})
+    require.NoError(t, err)
+    require.NotContains(t, store.buckets, "a")
+    require.Contains(t, store.buckets, "b")
+}
+
+func TestSetHeaders(t *testing.T) {
+    now := time.Date(2026, time.October, 20, 10, 0, 0, 0, time.UTC)
+    res := Result{Policy: Policy{Limit: 10, Window: Duration(time.Minute)}, Remaining: 4, Reset: now.Add(30 * time.Second), Allowed: true}
+
+    h := make(http.Header)
+    SetHeaders(h, res, now)
+    require.Equal(t, "10", h.Get(HeaderLimit))
+    require.Equal(t, "4", h.Get(HeaderRemaining))
+    require.Equal(t, "30", h.Get(HeaderReset))
+    require.Equal(t, "10;w=60", h.Get(HeaderPolicy))
+    require.Empty(t, h.Get(HeaderRetryAfter))
+
+    res.Remaining, res.Allowed = 0, false
+    SetHeaders(h, res, now)
+    require.Equal(t, "30", h.Get(HeaderRetryAfter))
+}
+
+func TestReadConfig(t *testing.T) {
+    config, err := ReadConfig(strings.NewReader(`{
+        "ip": {"limit": 300, "window": "1m"},
+        "default": {"limit": 100, "window": "1m"},
+        "routes": {"POST /users/login": {"limit": 10, "window": "30s"}}
+    }`))
+    require.NoError(t, err)
+    require.Equal(t, Policy{Limit: 300, Window: Duration(time.Minute)}, config.IP)
+    require.Equal(t, Policy{Limit: 100, Window: Duration(time.Minute)}, config.Default)
+    require.Equal(t, Policy{Limit: 10, Window: Duration(30 * time.Second)}, config.Routes["POST /users/login"])
+
+    for name, body := range map[string]string{
+        "NoIP":         `{"default": {"limit": 1, "window": "1s"}}`,
+        "NoDefault":    `{"ip": {"limit": 1, "window": "1s"}, "routes": {}}`,
+        "BadWindow":    `{"ip": {"limit": 1, "window": "1s"}, "default": {"limit": 1, "window": "soon"}}`,
+        "BadRoute":     `{"ip": {"limit": 1, "window": "1s"}, "default": {"limit": 1, "window": "1s"}, "routes": {"/users": {"limit": 1, "window": "1s"}}}`,
+        "ZeroLimit":    `{"ip": {"limit": 1, "window": "1s"}, "default": {"limit": 1, "window": "1s"}, "routes": {"GET /users": {"limit": 0, "window": "1s"}}}`,
+        "UnknownField": `{"ip": {"limit": 1, "window": "1s"}, "default": {"limit": 1, "window": "1s", "burst": 2}}`,
+    } {
+        _, err := ReadConfig(strings.NewReader(body))
+        require.Errorf(t, err, name)
+    }
+}
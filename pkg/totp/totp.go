@@ -0,0 +1,133 @@
+// Package totp generates and checks the time-based one-time passwords of RFC 6238, the 6 digit codes of the
+// authenticator apps, and the recovery codes that stand in for them when the device is lost.
+package totp
+
+import (
+    "crypto/hmac"
+    "crypto/rand"
+    "crypto/sha1"
+    "crypto/subtle"
+    "encoding/base32"
+    "encoding/binary"
+    "fmt"
+    "net/url"
+    "strings"
+    "time"
+)
+
+const (
+    // Digits of a code
+    Digits = 6
+    // Period is the time step, a code is valid for one period
+    Period = 30 * time.Second
+    // Skew is the number of steps before and after the current one accepted, for the clocks drifting apart
+    Skew = 1
+    // SecretSize is the size of the secrets in bytes, the size of the SHA-1 HMAC key RFC 4226 recommends
+    SecretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewSecret returns a random secret, base32 encoded the way the provisioning URI carries it.
+func NewSecret() (string, error) {
+    secret := make([]byte, SecretSize)
+    if _, err := rand.Read(secret); err != nil {
+        return "", err
+    }
+    return encoding.EncodeToString(secret), nil
+}
+
+// Step is the number of periods since the unix epoch at t.
+func Step(t time.Time) int64 {
+    return t.Unix() / int64(Period/time.Second)
+}
+
+// Code is the code of the base32 secret at step.
+func Code(secret string, step int64) (string, error) {
+    key, err := encoding.DecodeString(strings.ToUpper(secret))
+    if err != nil {
+        return "", fmt.Errorf("invalid totp secret: %w", err)
+    }
+
+    var counter [8]byte
+    binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+    mac := hmac.New(sha1.New, key)
+    mac.Write(counter[:])
+    sum := mac.Sum(nil)
+
+    // dynamic truncation, RFC 4226 section 5.3
+    offset := sum[len(sum)-1] & 0x0f
+    value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+    mod := uint32(1)
+    for i := 0; i < Digits; i++ {
+        mod *= 10
+    }
+    return fmt.Sprintf("%0*d", Digits, value%mod), nil
+}
+
+// Validate tells whether code is the code of the secret at t, give or take Skew steps, and returns its step.
+// The steps up to afterStep are not accepted: a code is used once, the step of the last one used is passed.
+func Validate(secret, code string, t time.Time, afterStep int64) (int64, bool) {
+    code = strings.TrimSpace(code)
+    if len(code) != Digits {
+        return 0, false
+    }
+
+    now := Step(t)
+    for step := now - Skew; step <= now+Skew; step++ {
+        if step <= afterStep {
+            continue
+        }
+        expected, err := Code(secret, step)
+        if err != nil {
+            return 0, false
+        }
+        if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+            return step, true
+        }
+    }
+    return 0, false
+}
+
+// ProvisioningURI is the otpauth URI of the secret the authenticator apps scan as a QR code, the account is shown
+// under the issuer.
+func ProvisioningURI(secret, issuer, account string) string {
+    query := url.Values{}
+    query.Set("secret", secret)
+    query.Set("issuer", issuer)
+    query.Set("algorithm", "SHA1")
+    query.Set("digits", fmt.Sprint(Digits))
+    query.Set("period", fmt.Sprint(int64(Period/time.Second)))
+
+    label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+    return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// recoveryCodeSize is the size of the recovery codes in bytes, 50 bits written as 10 base32 characters
+const recoveryCodeSize = 10
+
+// NewRecoveryCode returns a random recovery code, e.g. ABCDE-FGHIJ.
+func NewRecoveryCode() (string, error) {
+    b := make([]byte, recoveryCodeSize)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    code := encoding.EncodeToString(b)[:recoveryCodeSize]
+    return code[:5] + "-" + code[5:], nil
+}
+
+// NormalizeRecoveryCode is the recovery code as it is hashed, whatever its case and separators as typed.
+func NormalizeRecoveryCode(code string) string {
+    return strings.Map(func(r rune) rune {
+        switch {
+        case r == '-' || r == ' ':
+            return -1
+        case r >= 'a' && r <= 'z':
+            return r - 'a' + 'A'
+        default:
+            return r
+        }
+    }, code)
+}
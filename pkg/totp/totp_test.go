@@ -0,0 +1,93 @@
+package totp
+
+import (
+    "encoding/base32"
+    "github.com/stretchr/testify/require"
+    "net/url"
+    "testing"
+    "time"
+)
+
+// the SHA-1 test vectors of RFC 6238 appendix B, to 6 digits
+func TestCode(t *testing.T) {
+    secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+    testCases := []struct {
+        unix int64
+        code string
+    }{
+        {59, "287082"},
+        {1111111109, "081804"},
+        {1111111111, "050471"},
+        {1234567890, "005924"},
+        {2000000000, "279037"},
+        {20000000000, "353130"},
+    }
+
+    for _, tc := range testCases {
+        code, err := Code(secret, Step(time.Unix(tc.unix, 0)))
+        require.NoError(t, err)
+        require.Equal(t, tc.code, code, "at %d", tc.unix)
+    }
+}
+
+func TestValidate(t *testing.T) {
+    secret, err := NewSecret()
+    require.NoError(t, err)
+
+    now := time.Now()
+    step := Step(now)
+
+    code, err := Code(secret, step)
+    require.NoError(t, err)
+
+    matched, ok := Validate(secret, code, now, 0)
+    require.True(t, ok)
+    require.Equal(t, step, matched)
+
+    // used once
+    _, ok = Validate(secret, code, now, step)
+    require.False(t, ok)
+
+    // the previous code is still accepted, not the one before it
+    previous, err := Code(secret, step-1)
+    require.NoError(t, err)
+    _, ok = Validate(secret, previous, now, 0)
+    require.True(t, ok)
+
+    old, err := Code(secret, step-2)
+    require.NoError(t, err)
+    if old != code && old != previous {
+        _, ok = Validate(secret, old, now, 0)
+        require.False(t, ok)
+    }
+
+    _, ok = Validate(secret, "12345", now, 0)
+    require.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+    uri, err := url.Parse(ProvisioningURI("JBSWY3DPEHPK3PXP", "Simple Wallet", "walter"))
+    require.NoError(t, err)
+
+    require.Equal(t, "otpauth", uri.Scheme)
+    require.Equal(t, "totp", uri.Host)
+    require.Equal(t, "/Simple Wallet:walter", uri.Path)
+    require.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+    require.Equal(t, "Simple Wallet", uri.Query().Get("issuer"))
+    require.Equal(t, "6", uri.Query().Get("digits"))
+}
+
+func TestRecoveryCode(t *testing.T) {
+    code, err := NewRecoveryCode()
+    require.NoError(t, err)
+    require.Len(t, code, 11)
+    require.Equal(t, byte('-'), code[5])
+
+    other, err := NewRecoveryCode()
+    require.NoError(t, err)
+    require.NotEqual(t, code, other)
+
+    require.Equal(t, NormalizeRecoveryCode(code), NormalizeRecoveryCode(" "+code[:5]+code[6:]))
+    require.Equal(t, "ABCDEFGHIJ", NormalizeRecoveryCode("abcde-fghij"))
+}
@@ -0,0 +1,44 @@
+// Package trace starts the spans of this module with the OpenTelemetry tracer provider set by otel.SetTracerProvider,
+// a no-op one until main sets it, and carries the context of a trace across processes in the W3C traceparent header.
+//
+// Start is called with the context of the caller, the span it returns is a child of the span in that context,
+// the context it returns carries the new span to the callees.
+package trace
+
+import (
+    "context"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/propagation"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "net/http"
+)
+
+// ScopeName is the instrumentation scope of the spans of this module.
+const ScopeName = "github.com/pranayhere/simple-wallet"
+
+// TraceparentHeader carries the span context between processes, https://www.w3.org/TR/trace-context/
+const TraceparentHeader = "traceparent"
+
+// propagator reads and writes the traceparent header, whatever propagator the process sets globally.
+var propagator = propagation.TraceContext{}
+
+// Start starts a span named name with the global tracer provider.
+func Start(ctx context.Context, name string, opts ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+    return otel.Tracer(ScopeName).Start(ctx, name, opts...)
+}
+
+// RecordError adds an exception event for err and sets the status of span to error, a nil err is ignored.
+func RecordError(span oteltrace.Span, err error) {
+    if err == nil {
+        return
+    }
+    span.RecordError(err)
+    span.SetStatus(codes.Error, err.Error())
+}
+
+// Extract returns ctx carrying the span context of the traceparent header of h, ctx as is when the header
+// is missing or malformed.
+func Extract(ctx context.Context, h http.Header) context.Context {
+    return propagator.Extract(ctx, propagation.HeaderCarrier(h))
+}
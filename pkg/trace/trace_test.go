@@ -0,0 +1,75 @@
+package trace_test
+
+import (
+    "context"
+    "errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/stretchr/testify/require"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/codes"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/sdk/trace/tracetest"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "net/http"
+    "testing"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+    rec := tracetest.NewSpanRecorder()
+    previous := otel.GetTracerProvider()
+    otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+    t.Cleanup(func() { otel.SetTracerProvider(previous) })
+    return rec
+}
+
+func TestStartChildSpans(t *testing.T) {
+    rec := newRecorder(t)
+
+    ctx, root := trace.Start(context.Background(), "POST /wallets/pay", oteltrace.WithSpanKind(oteltrace.SpanKindServer))
+    childCtx, child := trace.Start(ctx, "WalletSvc.Pay")
+    _, query := trace.Start(childCtx, "GetWalletByAddressForUpdate")
+
+    trace.RecordError(query, errors.New("canceling statement due to lock timeout"))
+    query.End()
+    trace.RecordError(child, nil)
+    child.End()
+    root.End()
+
+    spans := rec.Ended()
+    require.Len(t, spans, 3)
+    querySpan, childSpan, rootSpan := spans[0], spans[1], spans[2]
+
+    // one trace, each span the child of the one above
+    require.False(t, rootSpan.Parent().IsValid())
+    require.Equal(t, rootSpan.SpanContext().TraceID(), querySpan.SpanContext().TraceID())
+    require.Equal(t, rootSpan.SpanContext().SpanID(), childSpan.Parent().SpanID())
+    require.Equal(t, childSpan.SpanContext().SpanID(), querySpan.Parent().SpanID())
+    require.Equal(t, oteltrace.SpanKindServer, rootSpan.SpanKind())
+    require.Equal(t, "GetWalletByAddressForUpdate", querySpan.Name())
+
+    require.Equal(t, codes.Error, querySpan.Status().Code)
+    require.Len(t, querySpan.Events(), 1)
+    require.Equal(t, "exception", querySpan.Events()[0].Name)
+    require.Equal(t, codes.Unset, childSpan.Status().Code)
+}
+
+func TestExtract(t *testing.T) {
+    rec := newRecorder(t)
+
+    h := http.Header{}
+    h.Set(trace.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+    _, span := trace.Start(trace.Extract(context.Background(), h), "GET /wallets/{walletID}")
+    span.End()
+
+    // a malformed header starts a new trace
+    h.Set(trace.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-zz")
+    _, span = trace.Start(trace.Extract(context.Background(), h), "GET /wallets")
+    span.End()
+
+    spans := rec.Ended()
+    require.Len(t, spans, 2)
+    require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+    require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+    require.True(t, spans[0].Parent().IsRemote())
+    require.False(t, spans[1].Parent().IsValid())
+}
@@ -0,0 +1,23 @@
+package validation
+
+import (
+    "github.com/go-playground/validator/v10"
+    "github.com/pranayhere/simple-wallet/util"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+    v := validator.New()
+
+    _ = v.RegisterValidation("ifsc", func(fl validator.FieldLevel) bool {
+        return util.IsValidIfsc(fl.Field().String())
+    })
+
+    return v
+}
+
+// Struct validates the `validate` tags of s, including the custom tags registered here.
+func Struct(s interface{}) error {
+    return validate.Struct(s)
+}
@@ -0,0 +1,17 @@
+package validation_test
+
+import (
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestIfscTag(t *testing.T) {
+    type req struct {
+        Ifsc string `validate:"required,ifsc"`
+    }
+
+    require.NoError(t, validation.Struct(req{Ifsc: "HDFC0000076"}))
+    require.Error(t, validation.Struct(req{Ifsc: "HDFC000076"}))
+    require.Error(t, validation.Struct(req{}))
+}
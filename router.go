@@ -1,34 +1,48 @@
 package main
 
 import (
+    "context"
     "database/sql"
     "github.com/go-chi/chi"
     "github.com/go-chi/chi/middleware"
-    "github.com/go-chi/httprate"
     "github.com/go-chi/render"
     "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/grpcapi"
     middleware2 "github.com/pranayhere/simple-wallet/middleware"
     "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/ratelimit"
     "github.com/pranayhere/simple-wallet/service"
     "github.com/pranayhere/simple-wallet/store"
     "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/prometheus/client_golang/prometheus"
+    log "github.com/sirupsen/logrus"
+    "google.golang.org/grpc"
     "net/http"
-    "time"
+    "os"
+    "strconv"
+    "strings"
 )
 
-func createRouter() *chi.Mux {
+func createRouter(registerer prometheus.Registerer) *chi.Mux {
     r := chi.NewRouter()
 
     r.Use(middleware.RequestID)
     r.Use(middleware.RealIP)
+    r.Use(middleware2.Tracing)
+    r.Use(middleware2.Metrics(registerer))
+    r.Use(middleware2.Logging)
     r.Use(middleware.Recoverer)
-    r.Use(middleware.Logger)
-    r.Use(httprate.LimitByIP(100, 1*time.Minute))
 
     return r
 }
 
-func initRoutes(db *sql.DB, r *chi.Mux) *chi.Mux {
+// initRoutes registers the routes on r and returns them with the gRPC server of the same services.
+func initRoutes(ctx context.Context, db *sql.DB, r *chi.Mux, registerer prometheus.Registerer, healthSvc service.HealthSvc) (*chi.Mux, *grpc.Server) {
+    storeMetrics := store.NewMetrics(registerer)
+
     currencyRepo := store.NewCurrencyRepo(db)
     currencySvc := service.NewCurrencyService(currencyRepo)
     currencyApi := api.NewCurrencyResource(currencySvc)
@@ -39,39 +53,380 @@ func initRoutes(db *sql.DB, r *chi.Mux) *chi.Mux {
     }
 
     userRepo := store.NewUserRepo(db)
-    userSvc := service.NewUserService(userRepo, tokenMaker)
+    totpRepo := store.NewTOTPRepo(db)
+    userSvc := service.NewUserService(userRepo, totpRepo, tokenMaker)
     userApi := api.NewUserResource(userSvc)
+    passwordResetRepo := store.NewPasswordResetRepo(db, userRepo)
+    passwordSvc := service.NewPasswordService(userRepo, passwordResetRepo, tokenMaker, service.NewNotifierMailer(newEmailNotifier()))
+    passwordApi := api.NewPasswordResource(passwordSvc)
+    twoFactorSvc := service.NewTwoFactorService(userRepo, totpRepo, tokenMaker, newStepUpThresholds())
+    twoFactorApi := api.NewTwoFactorResource(twoFactorSvc)
 
     transferRepo := store.NewTransferRepo(db)
     entryRepo := store.NewEntryRepo(db)
-    walletRepo := store.NewWalletRepo(db, transferRepo, entryRepo)
-    bankAccountRepo := store.NewBankAccountRepo(db, walletRepo, userRepo)
-    bankAcctSvc := service.NewBankAccountService(bankAccountRepo, currencySvc)
+    outboxRepo := store.NewOutboxRepo(db)
+    walletRepo := store.NewWalletRepoWithMetrics(store.NewWalletRepo(db, transferRepo, entryRepo, outboxRepo), storeMetrics)
+    bankVerificationRepo := store.NewBankVerificationRepo(db)
+    bankAccountRepo := store.NewBankAccountRepo(db, walletRepo, userRepo, bankVerificationRepo, outboxRepo)
+    bankBranchRepo := store.NewBankBranchRepo(db)
+    bankDirectorySvc := service.NewBankDirectoryService(bankBranchRepo)
+    bankDirectoryApi := api.NewBankDirectoryResource(bankDirectorySvc)
+
+    webhookEndpointRepo := store.NewWebhookEndpointRepo(db)
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(db)
+    webhookSvc := service.NewWebhookService(webhookEndpointRepo, webhookDeliveryRepo, util.NewOutboundHTTPClient(constant.WebhookDeliveryTimeout))
+    webhookApi := api.NewWebhookResource(webhookSvc)
+    bankAcctSvc := service.NewBankAccountService(bankAccountRepo, currencySvc, bankDirectorySvc)
     bankAcctApi := api.NewBankAccountResource(bankAcctSvc)
 
-    walletSvc := service.NewWalletService(walletRepo)
+    var bankVerificationSvc service.BankVerificationSvc
+    if verifier := newBankVerifier(); verifier != nil {
+        bankVerificationSvc = service.NewBankVerificationService(bankVerificationRepo, bankAccountRepo, userRepo, verifier)
+    }
+
+    walletSvc := service.NewWalletService(walletRepo, twoFactorSvc)
     walletApi := api.NewWalletResource(walletSvc)
 
-    paymentRequestRepo := store.NewPaymentRequestRepo(db)
-    paymentRequestSvc := service.NewPaymentRequestService(paymentRequestRepo, walletSvc)
+    transferSvc := service.NewTransferService(transferRepo, walletRepo)
+    transferApi := api.NewTransferResource(transferSvc)
+
+    holdRepo := store.NewHoldRepo(db, walletRepo)
+    holdSvc := service.NewHoldService(holdRepo, walletRepo, twoFactorSvc)
+    holdApi := api.NewHoldResource(holdSvc)
+
+    escrowRepo := store.NewEscrowRepo(db, walletRepo)
+    escrowSvc := service.NewEscrowService(escrowRepo, walletRepo, twoFactorSvc)
+    escrowApi := api.NewEscrowResource(escrowSvc)
+
+    merchantRepo := store.NewMerchantRepo(db)
+    apiKeyRepo := store.NewAPIKeyRepo(db)
+    merchantSvc := service.NewMerchantService(merchantRepo, apiKeyRepo, walletRepo)
+    merchantApi := api.NewMerchantResource(merchantSvc)
+
+    paymentLinkRepo := store.NewPaymentLinkRepo(db)
+    checkoutSessionRepo := store.NewCheckoutSessionRepo(db, paymentLinkRepo, walletRepo, outboxRepo)
+    paymentLinkSvc := service.NewPaymentLinkService(paymentLinkRepo, checkoutSessionRepo, merchantRepo, walletRepo, twoFactorSvc,
+        util.NewOutboundHTTPClient(constant.CheckoutCallbackTimeout))
+    paymentLinkApi := api.NewPaymentLinkResource(paymentLinkSvc)
+
+    statementRepo := store.NewStatementRepo(db)
+    statementSvc := service.NewStatementService(statementRepo, walletRepo, currencyRepo)
+    statementApi := api.NewStatementResource(statementSvc)
+
+    notificationRepo := store.NewNotificationRepo(db)
+    notificationPreferenceRepo := store.NewNotificationPreferenceRepo(db)
+    notifiers := map[domain.NotificationChannel]service.Notifier{
+        domain.NotificationChannelINAPP: service.NewInAppNotifier(notificationRepo),
+        domain.NotificationChannelEMAIL: newEmailNotifier(),
+    }
+    notificationSvc := service.NewNotificationService(userRepo, currencyRepo, notificationRepo, notificationPreferenceRepo, notifiers)
+    notificationApi := api.NewNotificationResource(notificationSvc)
+
+    paymentRequestRepo := store.NewPaymentRequestRepoWithMetrics(store.NewPaymentRequestRepo(db, walletRepo, outboxRepo), storeMetrics)
+    paymentRequestSvc := service.NewPaymentRequestService(paymentRequestRepo, walletSvc, twoFactorSvc)
     paymentRequestApi := api.NewPaymentRequestResource(paymentRequestSvc)
 
-    // Routes
-    // public
-    userApi.RegisterRoutes(r.With(httprate.LimitByIP(10, 1*time.Minute)))
+    qrSvc := service.NewQRService(walletSvc, paymentRequestSvc)
+    qrApi := api.NewQRResource(qrSvc)
+
+    eventBus := service.NewInProcessPublisher()
+    for _, eventType := range []domain.EventType{domain.EventTypeTransferCreated, domain.EventTypeWalletCreated, domain.EventTypeWalletActivated,
+        domain.EventTypeBankAccountVerificationFailed, domain.EventTypeCheckoutCompleted, domain.EventTypePaymentRequestStatusChanged} {
+        eventBus.Subscribe(eventType, logOutboxEvent)
+        eventBus.Subscribe(eventType, notificationSvc.HandleEvent)
+        eventBus.Subscribe(eventType, webhookSvc.HandleEvent)
+    }
+    outboxRelaySvc := service.NewOutboxRelayService(outboxRepo, eventBus)
+    outboxApi := api.NewOutboxResource(outboxRelaySvc)
+    store.RegisterOutboxMetrics(registerer, outboxRepo)
+
+    healthApi := api.NewHealthResource(healthSvc)
+
+    reconciliationRepo := store.NewReconciliationRepo(db)
+    reconciliationSvc := service.NewReconciliationService(reconciliationRepo)
+    reconciliationApi := api.NewReconciliationResource(reconciliationSvc)
+
+    importBankDirectory(ctx, bankDirectorySvc)
+
+    // Workers
+    if bankVerificationSvc != nil {
+        runEvery(ctx, "bank-verification", constant.BankVerificationPollInterval, bankVerificationSvc.ProcessPendingVerifications)
+    }
+    runEvery(ctx, "webhook-delivery", constant.WebhookDeliveryInterval, webhookSvc.DeliverPending)
+    runEvery(ctx, "outbox-relay", constant.OutboxRelayInterval, outboxRelaySvc.RelayPending)
+    runEvery(ctx, "hold-expiry", constant.HoldExpiryInterval, holdSvc.ExpireHolds)
+    runEvery(ctx, "escrow-release", constant.EscrowReleaseInterval, escrowSvc.ReleaseDue)
+    runEvery(ctx, "reconciliation", constant.ReconciliationInterval, func(ctx context.Context) error {
+        _, err := reconciliationSvc.Run(ctx)
+        return err
+    })
+
+    registerRoutes(r, resources{
+        user:           userApi,
+        password:       passwordApi,
+        twoFactor:      twoFactorApi,
+        bankAcct:       bankAcctApi,
+        bankDirectory:  bankDirectoryApi,
+        currency:       currencyApi,
+        wallet:         walletApi,
+        transfer:       transferApi,
+        hold:           holdApi,
+        escrow:         escrowApi,
+        statement:      statementApi,
+        paymentRequest: paymentRequestApi,
+        qr:             qrApi,
+        webhook:        webhookApi,
+        notification:   notificationApi,
+        merchant:       merchantApi,
+        paymentLink:    paymentLinkApi,
+        reconciliation: reconciliationApi,
+        outbox:         outboxApi,
+        health:         healthApi,
+        openAPI:        api.NewOpenAPIResource(api.Spec()),
+    }, tokenMaker, merchantSvc, userRepo, newRateLimiter())
+
+    grpcServer := grpcapi.NewServer(tokenMaker, userRepo, grpcapi.Services{
+        UserSvc:           userSvc,
+        TwoFactorSvc:      twoFactorSvc,
+        WalletSvc:         walletSvc,
+        BankAccountSvc:    bankAcctSvc,
+        CurrencySvc:       currencySvc,
+        PaymentRequestSvc: paymentRequestSvc,
+    })
+
+    return r, grpcServer
+}
+
+// resources are the REST resources of the api, registered by registerRoutes.
+type resources struct {
+    user           api.UserResource
+    password       api.PasswordResource
+    twoFactor      api.TwoFactorResource
+    bankAcct       api.BankAccountResource
+    bankDirectory  api.BankDirectoryResource
+    currency       api.CurrencyResource
+    wallet         api.WalletResource
+    transfer       api.TransferResource
+    hold           api.HoldResource
+    escrow         api.EscrowResource
+    statement      api.StatementResource
+    paymentRequest api.PaymentRequestResource
+    qr             api.QRResource
+    webhook        api.WebhookResource
+    notification   api.NotificationResource
+    merchant       api.MerchantResource
+    paymentLink    api.PaymentLinkResource
+    reconciliation api.ReconciliationResource
+    outbox         api.OutboxResource
+    health         api.HealthResource
+    openAPI        api.OpenAPIResource
+}
+
+// registerRoutes registers each version of the api under its prefix, side by side: a breaking change of the
+// routes goes to the next version, e.g. r.Route("/v2", ...) with handlers of its own, rather than changing one.
+// v1 is also served at the root, where it was before the api was versioned, deprecated, its responses in the shape
+// they had there (dto.UnversionedResponse). The operational routes
+// are not versioned. Each of them is described by api.Spec.
+func registerRoutes(r chi.Router, res resources, tokenMaker token.Maker, merchantSvc service.MerchantSvc, userRepo store.UserRepo,
+    limiter *ratelimit.Limiter) {
+    v1 := func(r chi.Router) {
+        registerV1Routes(r, res, tokenMaker, merchantSvc, userRepo, limiter)
+    }
+    r.Route("/v1", v1)
 
-    // authorized
     r.Group(func(r chi.Router) {
-        r.Use(middleware2.Auth(tokenMaker))
-        bankAcctApi.RegisterRoutes(r)
-        currencyApi.RegisterRoutes(r)
-        walletApi.RegisterRoutes(r)
-        paymentRequestApi.RegisterRoutes(r)
+        r.Use(middleware2.Deprecated(middleware2.Deprecation{
+            Since:     constant.UnversionedRoutesDeprecatedAt,
+            Sunset:    constant.UnversionedRoutesSunsetAt,
+            Successor: middleware2.Successor("/v1"),
+        }))
+        r.Use(middleware2.TransformJSON(dto.UnversionedResponse))
+        v1(r)
     })
 
     r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
         render.JSON(w, r, "ok")
     })
+    res.health.RegisterRoutes(r)
+    res.openAPI.RegisterRoutes(r)
+}
 
-    return r
+// registerV1Routes registers the routes of v1 behind their authentication, rate limited by ip before it, and by ip,
+// user or api key after it.
+func registerV1Routes(r chi.Router, res resources, tokenMaker token.Maker, merchantSvc service.MerchantSvc, userRepo store.UserRepo,
+    limiter *ratelimit.Limiter) {
+    r.Use(middleware2.RateLimitByIP(limiter))
+
+    // public
+    r.Group(func(r chi.Router) {
+        r.Use(middleware2.RateLimit(limiter))
+        res.user.RegisterRoutes(r)
+        res.password.RegisterPublicRoutes(r)
+        res.twoFactor.RegisterPublicRoutes(r)
+        res.paymentLink.RegisterPublicRoutes(r)
+    })
+
+    // authorized, with the token of the user or the api key of a merchant
+    r.Group(func(r chi.Router) {
+        r.Use(middleware2.APIKeyAuth(merchantSvc, userRepo))
+        r.Use(middleware2.Auth(tokenMaker, userRepo))
+        r.Use(middleware2.RateLimit(limiter))
+        res.password.RegisterRoutes(r)
+        res.twoFactor.RegisterRoutes(r)
+        res.bankAcct.RegisterRoutes(r)
+        res.bankDirectory.RegisterRoutes(r)
+        res.currency.RegisterRoutes(r)
+        res.wallet.RegisterRoutes(r)
+        res.transfer.RegisterRoutes(r)
+        res.hold.RegisterRoutes(r)
+        res.escrow.RegisterRoutes(r)
+        res.statement.RegisterRoutes(r)
+        res.paymentRequest.RegisterRoutes(r)
+        res.qr.RegisterRoutes(r)
+        res.webhook.RegisterRoutes(r)
+        res.notification.RegisterRoutes(r)
+        res.merchant.RegisterRoutes(r)
+        res.paymentLink.RegisterRoutes(r)
+    })
+
+    // admin
+    r.Group(func(r chi.Router) {
+        r.Use(middleware2.Auth(tokenMaker, userRepo))
+        r.Use(middleware2.Admin(userRepo))
+        r.Use(middleware2.RateLimit(limiter))
+        res.reconciliation.RegisterRoutes(r)
+        res.outbox.RegisterRoutes(r)
+        res.bankAcct.RegisterAdminRoutes(r)
+        res.transfer.RegisterAdminRoutes(r)
+        res.escrow.RegisterAdminRoutes(r)
+    })
+}
+
+// newRateLimiter limits the requests to the policies of the rate limit config file, or to the default policy
+// without one. The requests are counted in memory, per instance.
+func newRateLimiter() *ratelimit.Limiter {
+    store := ratelimit.NewMemoryStore(constant.RateLimitSweepInterval)
+    config := ratelimit.Config{
+        IP:      ratelimit.Policy{Limit: constant.RateLimitIPLimit, Window: ratelimit.Duration(constant.RateLimitIPWindow)},
+        Default: ratelimit.Policy{Limit: constant.RateLimitDefaultLimit, Window: ratelimit.Duration(constant.RateLimitDefaultWindow)},
+    }
+
+    f, err := os.Open(constant.RateLimitConfigFile)
+    if err != nil {
+        log.Println("using the default rate limit:", err)
+        return ratelimit.NewLimiter(config, store)
+    }
+    defer f.Close()
+
+    config, err = ratelimit.ReadConfig(f)
+    if err != nil {
+        log.Fatal("invalid rate limit config: ", err)
+    }
+
+    log.Printf("rate limiting %d routes", len(config.Routes))
+    return ratelimit.NewLimiter(config, store)
+}
+
+// importBankDirectory refreshes the bank directory from the bundled CSV file, if present.
+func importBankDirectory(ctx context.Context, bankDirectorySvc service.BankDirectorySvc) {
+    f, err := os.Open(constant.BankDirectoryFile)
+    if err != nil {
+        log.Println("skipping bank directory import:", err)
+        return
+    }
+    defer f.Close()
+
+    count, err := bankDirectorySvc.ImportBankBranches(ctx, f)
+    if err != nil {
+        log.Error("failed to import bank directory: ", err)
+        return
+    }
+
+    log.Printf("imported %d bank branches", count)
+}
+
+func logOutboxEvent(ctx context.Context, event domain.OutboxEvent) error {
+    log.WithFields(log.Fields{
+        "event_id":       event.ID,
+        "event_type":     event.EventType,
+        "aggregate_type": event.AggregateType,
+        "aggregate_id":   event.AggregateID,
+    }).Info("domain event published")
+    return nil
+}
+
+// newEmailNotifier sends emails through the SMTP server set in SMTP_HOST, without one the
+// emails are written to the notification log file for local development.
+func newEmailNotifier() service.Notifier {
+    host := os.Getenv("SMTP_HOST")
+    if host == "" {
+        f, err := os.OpenFile(constant.NotificationLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+        if err != nil {
+            log.Println("writing email notifications to stdout:", err)
+            return service.NewLogNotifier(os.Stdout)
+        }
+
+        return service.NewLogNotifier(f)
+    }
+
+    port := constant.SMTPDefaultPort
+    if v, err := strconv.Atoi(os.Getenv("SMTP_PORT")); err == nil {
+        port = v
+    }
+
+    from := os.Getenv("SMTP_FROM")
+    if from == "" {
+        from = constant.SMTPDefaultFrom
+    }
+
+    return service.NewSMTPNotifier(service.SMTPConfig{
+        Host:     host,
+        Port:     port,
+        Username: os.Getenv("SMTP_USERNAME"),
+        Password: os.Getenv("SMTP_PASSWORD"),
+        From:     from,
+    })
+}
+
+// newBankVerifier is the penny drop provider set in BANK_VERIFIER. Without one no bank account is verified
+// automatically, they stay IN_VERIFICATION until an admin verifies them with walletctl. The fake provider verifies
+// every account and is for local development only.
+func newBankVerifier() service.BankVerifier {
+    switch v := os.Getenv("BANK_VERIFIER"); v {
+    case "", "none":
+        log.Warn("no bank verifier set in BANK_VERIFIER, the bank accounts are left to the admins")
+        return nil
+    case "fake":
+        log.Warn("using the fake bank verifier, for development only")
+        return service.NewFakeBankVerifier()
+    default:
+        log.Fatalf("unknown bank verifier %q, expected none or fake", v)
+        return nil
+    }
+}
+
+// newStepUpThresholds are the amounts by currency set in TOTP_STEP_UP_THRESHOLDS, e.g. "USD:50000,INR:2000000", above
+// which a payment needs a two factor code of the users who enabled it. The payments in the other currencies don't.
+func newStepUpThresholds() map[string]int64 {
+    thresholds := make(map[string]int64)
+
+    config := os.Getenv("TOTP_STEP_UP_THRESHOLDS")
+    if config == "" {
+        return thresholds
+    }
+
+    for _, threshold := range strings.Split(config, ",") {
+        parts := strings.SplitN(strings.TrimSpace(threshold), ":", 2)
+        if len(parts) != 2 {
+            log.Fatalf("invalid step-up threshold %q, expected CURRENCY:amount", threshold)
+        }
+
+        amount, err := strconv.ParseInt(parts[1], 10, 64)
+        if err != nil || amount < 0 {
+            log.Fatalf("invalid step-up threshold %q, expected CURRENCY:amount", threshold)
+        }
+        thresholds[strings.ToUpper(parts[0])] = amount
+    }
+
+    return thresholds
 }
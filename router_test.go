@@ -0,0 +1,118 @@
+package main
+
+import (
+    "github.com/go-chi/chi"
+    "github.com/pranayhere/simple-wallet/api"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/openapi"
+    "github.com/pranayhere/simple-wallet/pkg/ratelimit"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "strings"
+    "testing"
+    "time"
+)
+
+// TestRoutesHaveSpec walks the routes registered by registerRoutes, every one of them must be described by api.Spec
+// and every operation of api.Spec must be routed.
+func TestRoutesHaveSpec(t *testing.T) {
+    spec := api.Spec()
+
+    r := newTestRouter(t, spec)
+
+    routed := make(map[string]bool)
+    err := chi.Walk(r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+        routed[method+" "+route] = true
+        require.Truef(t, spec.Has(method, route), "%s %s has no spec, describe it in api.Spec", method, route)
+        return nil
+    })
+    require.NoError(t, err)
+
+    for _, op := range spec.Operations() {
+        require.Truef(t, routed[op], "%s is described in api.Spec but not routed", op)
+    }
+}
+
+// newTestRouter registers the routes of resources without services, for the requests not reaching them.
+func newTestRouter(t *testing.T, spec *openapi.Spec) chi.Router {
+    tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+    require.NoError(t, err)
+
+    r := chi.NewRouter()
+    registerRoutes(r, resources{
+        user:           api.NewUserResource(nil),
+        password:       api.NewPasswordResource(nil),
+        twoFactor:      api.NewTwoFactorResource(nil),
+        bankAcct:       api.NewBankAccountResource(nil),
+        bankDirectory:  api.NewBankDirectoryResource(nil),
+        currency:       api.NewCurrencyResource(nil),
+        wallet:         api.NewWalletResource(nil),
+        transfer:       api.NewTransferResource(nil),
+        hold:           api.NewHoldResource(nil),
+        escrow:         api.NewEscrowResource(nil),
+        statement:      api.NewStatementResource(nil),
+        paymentRequest: api.NewPaymentRequestResource(nil),
+        qr:             api.NewQRResource(nil),
+        webhook:        api.NewWebhookResource(nil),
+        notification:   api.NewNotificationResource(nil),
+        merchant:       api.NewMerchantResource(nil),
+        paymentLink:    api.NewPaymentLinkResource(nil),
+        reconciliation: api.NewReconciliationResource(nil),
+        outbox:         api.NewOutboxResource(nil),
+        health:         api.NewHealthResource(nil),
+        openAPI:        api.NewOpenAPIResource(spec),
+    }, tokenMaker, nil, nil, ratelimit.NewLimiter(ratelimit.Config{
+        IP:      ratelimit.Policy{Limit: 100, Window: ratelimit.Duration(time.Minute)},
+        Default: ratelimit.Policy{Limit: 100, Window: ratelimit.Duration(time.Minute)},
+    }, ratelimit.NewMemoryStore(time.Minute)))
+
+    return r
+}
+
+func TestUnversionedRoutesDeprecated(t *testing.T) {
+    r := newTestRouter(t, api.Spec())
+
+    testCases := []struct {
+        name       string
+        path       string
+        deprecated bool
+    }{
+        {name: "V1", path: "/v1/wallets/pay"},
+        {name: "Unversioned", path: "/wallets/pay", deprecated: true},
+    }
+
+    for _, tc := range testCases {
+        t.Run(tc.name, func(t *testing.T) {
+            recorder := httptest.NewRecorder()
+            r.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, tc.path, nil))
+
+            require.Equal(t, http.StatusUnauthorized, recorder.Code)
+            if !tc.deprecated {
+                require.Empty(t, recorder.Header().Get("Deprecation"))
+                return
+            }
+            require.NotEmpty(t, recorder.Header().Get("Deprecation"))
+            require.NotEmpty(t, recorder.Header().Get("Sunset"))
+            require.Equal(t, `</v1/wallets/pay>; rel="successor-version"`, recorder.Header().Get("Link"))
+        })
+    }
+}
+
+// TestRateLimitConfig reads the rate limit config file, each of its routes must be a route of v1.
+func TestRateLimitConfig(t *testing.T) {
+    f, err := os.Open(constant.RateLimitConfigFile)
+    require.NoError(t, err)
+    defer f.Close()
+
+    config, err := ratelimit.ReadConfig(f)
+    require.NoError(t, err)
+
+    spec := api.Spec()
+    for route := range config.Routes {
+        parts := strings.SplitN(route, " ", 2)
+        require.Truef(t, spec.Has(parts[0], "/v1"+parts[1]), "%s of %s is not a route of v1", route, constant.RateLimitConfigFile)
+    }
+}
@@ -5,6 +5,7 @@ import (
     "database/sql"
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
     "github.com/pranayhere/simple-wallet/store"
 )
 
@@ -16,18 +17,23 @@ type BankAccountSvc interface {
 }
 
 type bankAccountService struct {
-    bankAcctRepo store.BankAccountRepo
-    currencySvc  CurrencySvc
+    bankAcctRepo     store.BankAccountRepo
+    currencySvc      CurrencySvc
+    bankDirectorySvc BankDirectorySvc
 }
 
-func NewBankAccountService(bankAcctRepo store.BankAccountRepo, currencySvc CurrencySvc) BankAccountSvc {
+func NewBankAccountService(bankAcctRepo store.BankAccountRepo, currencySvc CurrencySvc, bankDirectorySvc BankDirectorySvc) BankAccountSvc {
     return &bankAccountService{
-        bankAcctRepo: bankAcctRepo,
-        currencySvc:  currencySvc,
+        bankAcctRepo:     bankAcctRepo,
+        currencySvc:      currencySvc,
+        bankDirectorySvc: bankDirectorySvc,
     }
 }
 
 func (b *bankAccountService) CreateBankAccount(ctx context.Context, newBankAcctDto dto.CreateBankAccountDto) (dto.BankAccountDto, error) {
+    ctx, span := trace.Start(ctx, "BankAccountSvc.CreateBankAccount")
+    defer span.End()
+
     var bankAcctDto dto.BankAccountDto
 
     currency, err := b.currencySvc.GetCurrency(ctx, newBankAcctDto.Currency)
@@ -35,10 +41,16 @@ func (b *bankAccountService) CreateBankAccount(ctx context.Context, newBankAcctD
         return bankAcctDto, err
     }
 
+    // bank name comes from the directory rather than the client
+    branch, err := b.bankDirectorySvc.GetBankBranch(ctx, newBankAcctDto.Ifsc)
+    if err != nil {
+        return bankAcctDto, err
+    }
+
     arg := store.CreateBankAccountWithWalletParams{
         UserID:    newBankAcctDto.UserID,
-        BankName:  newBankAcctDto.BankName,
-        Ifsc:      newBankAcctDto.Ifsc,
+        BankName:  branch.BankName,
+        Ifsc:      branch.Ifsc,
         AccountNo: newBankAcctDto.AccountNo,
         Currency:  currency.Code,
     }
@@ -53,6 +65,9 @@ func (b *bankAccountService) CreateBankAccount(ctx context.Context, newBankAcctD
 }
 
 func (b *bankAccountService) GetBankAccount(ctx context.Context, bankAccountId int64) (dto.BankAccountDto, error) {
+    ctx, span := trace.Start(ctx, "BankAccountSvc.GetBankAccount")
+    defer span.End()
+
     var bankAcctDto dto.BankAccountDto
 
     bankAcct, err := b.bankAcctRepo.GetBankAccount(ctx, bankAccountId)
@@ -67,7 +82,12 @@ func (b *bankAccountService) GetBankAccount(ctx context.Context, bankAccountId i
     return bankAcctDto, nil
 }
 
+// VerificationSuccess is the manual override of an admin, the bank accounts are otherwise verified by
+// BankVerificationSvc. Their verifications still open are closed and the owner is notified the same way.
 func (b *bankAccountService) VerificationSuccess(ctx context.Context, verificationDto dto.BankAccountVerificationDto) (dto.BankAccountDto, error) {
+    ctx, span := trace.Start(ctx, "BankAccountSvc.VerificationSuccess")
+    defer span.End()
+
     var bankAcctDto dto.BankAccountDto
 
     bankAcctDto, err := b.GetBankAccount(ctx, verificationDto.BankAccountID)
@@ -88,7 +108,11 @@ func (b *bankAccountService) VerificationSuccess(ctx context.Context, verificati
     return bankAcctDto, nil
 }
 
+// VerificationFailed is the manual override of an admin, like VerificationSuccess.
 func (b *bankAccountService) VerificationFailed(ctx context.Context, verificationDto dto.BankAccountVerificationDto) (dto.BankAccountDto, error) {
+    ctx, span := trace.Start(ctx, "BankAccountSvc.VerificationFailed")
+    defer span.End()
+
     var bankAcctDto dto.BankAccountDto
 
     bankAcctDto, err := b.GetBankAccount(ctx, verificationDto.BankAccountID)
@@ -21,7 +21,7 @@ func TestCreateBankAccount(t *testing.T) {
         name        string
         currency    func(t *testing.T) (dto.CurrencyDto, domain.Currency)
         bankAccount func(t *testing.T, currency string) (dto.CreateBankAccountDto, domain.BankAccount)
-        buildStub   func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount)
+        buildStub   func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, mockBankBranchRepo *mockdb.MockBankBranchRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount)
         checkResp   func(t *testing.T, dto dto.CreateBankAccountDto, res dto.BankAccountDto, err error)
     }{
         {
@@ -36,14 +36,18 @@ func TestCreateBankAccount(t *testing.T) {
                 bankAccount := util.RandomBankAccount(bankAccountDto)
                 return bankAccountDto, bankAccount
             },
-            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
+            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, mockBankBranchRepo *mockdb.MockBankBranchRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
                 mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), strings.ToUpper(currency.Code)).Times(1).Return(currency, nil)
 
+                branch := util.RandomBankBranch(bankAccountDto.Ifsc)
+                branch.BankName = bankAccount.BankName
+                mockBankBranchRepo.EXPECT().GetBankBranch(gomock.Any(), bankAccountDto.Ifsc).Times(1).Return(branch, nil)
+
                 arg := store.CreateBankAccountWithWalletParams{
                     AccountNo: bankAccountDto.AccountNo,
                     Currency:  bankAccountDto.Currency,
                     UserID:    bankAccountDto.UserID,
-                    BankName:  bankAccountDto.BankName,
+                    BankName:  bankAccount.BankName,
                     Ifsc:      bankAccountDto.Ifsc,
                 }
 
@@ -58,7 +62,7 @@ func TestCreateBankAccount(t *testing.T) {
                 require.NotEmpty(t, res)
 
                 require.Equal(t, dto.AccountNo, res.AccountNo)
-                require.Equal(t, dto.BankName, res.BankName)
+                require.NotEmpty(t, res.BankName)
                 require.Equal(t, dto.Ifsc, res.Ifsc)
                 require.Equal(t, dto.Currency, res.Currency)
                 require.Equal(t, dto.UserID, res.UserID)
@@ -76,14 +80,18 @@ func TestCreateBankAccount(t *testing.T) {
                 bankAccount := util.RandomBankAccount(bankAccountDto)
                 return bankAccountDto, bankAccount
             },
-            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
+            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, mockBankBranchRepo *mockdb.MockBankBranchRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
                 mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), strings.ToUpper(currency.Code)).Times(1).Return(currency, nil)
 
+                branch := util.RandomBankBranch(bankAccountDto.Ifsc)
+                branch.BankName = bankAccount.BankName
+                mockBankBranchRepo.EXPECT().GetBankBranch(gomock.Any(), bankAccountDto.Ifsc).Times(1).Return(branch, nil)
+
                 arg := store.CreateBankAccountWithWalletParams{
                     AccountNo: bankAccountDto.AccountNo,
                     Currency:  bankAccountDto.Currency,
                     UserID:    bankAccountDto.UserID,
-                    BankName:  bankAccountDto.BankName,
+                    BankName:  bankAccount.BankName,
                     Ifsc:      bankAccountDto.Ifsc,
                 }
 
@@ -98,6 +106,28 @@ func TestCreateBankAccount(t *testing.T) {
                 require.EqualError(t, err, errors.ErrBankAccountAlreadyExist.Error())
             },
         },
+        {
+            name: "IfscNotFound",
+            currency: func(t *testing.T) (dto.CurrencyDto, domain.Currency) {
+                currencyDto := util.RandomCurrencyDto()
+                currency := util.RandomCurrency(currencyDto)
+                return currencyDto, currency
+            },
+            bankAccount: func(t *testing.T, currency string) (dto.CreateBankAccountDto, domain.BankAccount) {
+                bankAccountDto := util.RandomCreateBankAccountDto(currency)
+                bankAccount := util.RandomBankAccount(bankAccountDto)
+                return bankAccountDto, bankAccount
+            },
+            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, mockBankBranchRepo *mockdb.MockBankBranchRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
+                mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), strings.ToUpper(currency.Code)).Times(1).Return(currency, nil)
+                mockBankBranchRepo.EXPECT().GetBankBranch(gomock.Any(), bankAccountDto.Ifsc).Times(1).Return(domain.BankBranch{}, sql.ErrNoRows)
+                mockBankAcctRepo.EXPECT().CreateBankAccountWithWallet(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, dto dto.CreateBankAccountDto, res dto.BankAccountDto, err error) {
+                require.Error(t, err)
+                require.EqualError(t, err, errors.ErrIfscNotFound.Error())
+            },
+        },
         {
             name: "CurrencyNotFound",
             currency: func(t *testing.T) (dto.CurrencyDto, domain.Currency) {
@@ -110,7 +140,7 @@ func TestCreateBankAccount(t *testing.T) {
                 bankAccount := util.RandomBankAccount(bankAccountDto)
                 return bankAccountDto, bankAccount
             },
-            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
+            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, mockBankBranchRepo *mockdb.MockBankBranchRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
                 mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), strings.ToUpper(currency.Code)).Times(1).Return(currency, sql.ErrNoRows)
             },
             checkResp: func(t *testing.T, dto dto.CreateBankAccountDto, res dto.BankAccountDto, err error) {
@@ -130,7 +160,7 @@ func TestCreateBankAccount(t *testing.T) {
                 bankAccount := util.RandomBankAccount(bankAccountDto)
                 return bankAccountDto, bankAccount
             },
-            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
+            buildStub: func(mockCurrencyRepo *mockdb.MockCurrencyRepo, mockBankAcctRepo *mockdb.MockBankAccountRepo, mockBankBranchRepo *mockdb.MockBankBranchRepo, currency domain.Currency, bankAccountDto dto.CreateBankAccountDto, bankAccount domain.BankAccount) {
                 mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), strings.ToUpper(currency.Code)).Times(1).Return(currency, sql.ErrConnDone)
             },
             checkResp: func(t *testing.T, dto dto.CreateBankAccountDto, res dto.BankAccountDto, err error) {
@@ -150,12 +180,14 @@ func TestCreateBankAccount(t *testing.T) {
 
             mockCurrencyRepo := mockdb.NewMockCurrencyRepo(ctrl)
             mockBankAcctRepo := mockdb.NewMockBankAccountRepo(ctrl)
+            mockBankBranchRepo := mockdb.NewMockBankBranchRepo(ctrl)
 
-            tc.buildStub(mockCurrencyRepo, mockBankAcctRepo, currency, bankAccountDto, bankAccount)
+            tc.buildStub(mockCurrencyRepo, mockBankAcctRepo, mockBankBranchRepo, currency, bankAccountDto, bankAccount)
 
             ctx := context.TODO()
             currencySvc := service.NewCurrencyService(mockCurrencyRepo)
-            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc)
+            bankDirectorySvc := service.NewBankDirectoryService(mockBankBranchRepo)
+            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc, bankDirectorySvc)
 
             res, err := bankAcctSvc.CreateBankAccount(ctx, bankAccountDto)
 
@@ -211,7 +243,8 @@ func TestGetBankAccount(t *testing.T) {
 
             ctx := context.TODO()
             currencySvc := service.NewCurrencyService(mockCurrencyRepo)
-            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc)
+            bankDirectorySvc := service.NewBankDirectoryService(mockdb.NewMockBankBranchRepo(ctrl))
+            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc, bankDirectorySvc)
 
             _, err := bankAcctSvc.GetBankAccount(ctx, 1)
             tc.checkResp(t, err)
@@ -258,6 +291,17 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
                 require.EqualError(t, err, sql.ErrConnDone.Error())
             },
         },
+        {
+            name: "BankAcctNotInVerification",
+            buildStub: func(mockBankAcctRepo *mockdb.MockBankAccountRepo, bankAcct domain.BankAccount) {
+                mockBankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), gomock.Any()).Times(1).Return(bankAcct, nil)
+                mockBankAcctRepo.EXPECT().BankAccountVerificationSuccess(gomock.Any(), gomock.Any()).Times(1).Return(store.BankAccountVerificationResult{}, errors.ErrBankAccountNotInVerification)
+            },
+            checkResp: func(t *testing.T, res dto.BankAccountDto, err error) {
+                require.Error(t, err)
+                require.EqualError(t, err, errors.ErrBankAccountNotInVerification.Error())
+            },
+        },
     }
 
     for _, tc := range testcases {
@@ -273,7 +317,8 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
 
             ctx := context.TODO()
             currencySvc := service.NewCurrencyService(mockCurrencyRepo)
-            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc)
+            bankDirectorySvc := service.NewBankDirectoryService(mockdb.NewMockBankBranchRepo(ctrl))
+            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc, bankDirectorySvc)
 
             verificationDto := dto.BankAccountVerificationDto{
                 BankAccountID: bankAcct.ID,
@@ -339,7 +384,8 @@ func TestBankAccountVerificationFailed(t *testing.T) {
 
             ctx := context.TODO()
             currencySvc := service.NewCurrencyService(mockCurrencyRepo)
-            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc)
+            bankDirectorySvc := service.NewBankDirectoryService(mockdb.NewMockBankBranchRepo(ctrl))
+            bankAcctSvc := service.NewBankAccountService(mockBankAcctRepo, currencySvc, bankDirectorySvc)
 
             verificationDto := dto.BankAccountVerificationDto{
                 BankAccountID: bankAcct.ID,
@@ -0,0 +1,104 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "encoding/csv"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/pkg/validation"
+    "github.com/pranayhere/simple-wallet/store"
+    "io"
+    "strings"
+)
+
+type BankDirectorySvc interface {
+    GetBankBranch(ctx context.Context, ifsc string) (dto.BankBranchDto, error)
+    ImportBankBranches(ctx context.Context, r io.Reader) (int, error)
+}
+
+type bankDirectoryService struct {
+    bankBranchRepo store.BankBranchRepo
+}
+
+func NewBankDirectoryService(bankBranchRepo store.BankBranchRepo) BankDirectorySvc {
+    return &bankDirectoryService{
+        bankBranchRepo: bankBranchRepo,
+    }
+}
+
+func (b *bankDirectoryService) GetBankBranch(ctx context.Context, ifsc string) (dto.BankBranchDto, error) {
+    ctx, span := trace.Start(ctx, "BankDirectorySvc.GetBankBranch")
+    defer span.End()
+
+    var res dto.BankBranchDto
+
+    branch, err := b.bankBranchRepo.GetBankBranch(ctx, strings.ToUpper(ifsc))
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrIfscNotFound
+        }
+        return res, err
+    }
+
+    res = dto.NewBankBranchDto(branch)
+    return res, nil
+}
+
+// ImportBankBranches upserts the branches of a CSV file with the header
+// ifsc,bank_name,branch,address,city,state and returns the number of rows imported.
+func (b *bankDirectoryService) ImportBankBranches(ctx context.Context, r io.Reader) (int, error) {
+    ctx, span := trace.Start(ctx, "BankDirectorySvc.ImportBankBranches")
+    defer span.End()
+
+    reader := csv.NewReader(r)
+    reader.FieldsPerRecord = 6
+    reader.TrimLeadingSpace = true
+
+    // skip header
+    if _, err := reader.Read(); err != nil {
+        return 0, err
+    }
+
+    count := 0
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return count, err
+        }
+
+        branch := dto.BankBranchDto{
+            Ifsc:     strings.ToUpper(strings.TrimSpace(record[0])),
+            BankName: strings.TrimSpace(record[1]),
+            Branch:   strings.TrimSpace(record[2]),
+            Address:  strings.TrimSpace(record[3]),
+            City:     strings.TrimSpace(record[4]),
+            State:    strings.TrimSpace(record[5]),
+        }
+
+        if err := validation.Struct(branch); err != nil {
+            return count, fmt.Errorf("record %d: %w", count+1, err)
+        }
+
+        _, err = b.bankBranchRepo.UpsertBankBranch(ctx, store.UpsertBankBranchParams{
+            Ifsc:     branch.Ifsc,
+            BankName: branch.BankName,
+            Branch:   branch.Branch,
+            Address:  branch.Address,
+            City:     branch.City,
+            State:    branch.State,
+        })
+        if err != nil {
+            return count, err
+        }
+
+        count++
+    }
+
+    return count, nil
+}
@@ -0,0 +1,157 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+)
+
+func TestGetBankBranch(t *testing.T) {
+    branch := util.RandomBankBranch(util.RandomIfsc())
+
+    testcases := []struct {
+        name      string
+        ifsc      string
+        buildStub func(mockBankBranchRepo *mockdb.MockBankBranchRepo)
+        checkResp func(t *testing.T, err error)
+    }{
+        {
+            name: "Ok",
+            ifsc: strings.ToLower(branch.Ifsc),
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                mockBankBranchRepo.EXPECT().GetBankBranch(gomock.Any(), branch.Ifsc).Times(1).Return(branch, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "IfscNotFound",
+            ifsc: branch.Ifsc,
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                mockBankBranchRepo.EXPECT().GetBankBranch(gomock.Any(), branch.Ifsc).Times(1).Return(domain.BankBranch{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.Error(t, err)
+                require.EqualError(t, err, errors.ErrIfscNotFound.Error())
+            },
+        },
+        {
+            name: "ConnectionError",
+            ifsc: branch.Ifsc,
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                mockBankBranchRepo.EXPECT().GetBankBranch(gomock.Any(), branch.Ifsc).Times(1).Return(domain.BankBranch{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.Error(t, err)
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockBankBranchRepo := mockdb.NewMockBankBranchRepo(ctrl)
+            tc.buildStub(mockBankBranchRepo)
+
+            bankDirectorySvc := service.NewBankDirectoryService(mockBankBranchRepo)
+            _, err := bankDirectorySvc.GetBankBranch(context.TODO(), tc.ifsc)
+            tc.checkResp(t, err)
+        })
+    }
+}
+
+func TestImportBankBranches(t *testing.T) {
+    header := "ifsc,bank_name,branch,address,city,state\n"
+
+    testcases := []struct {
+        name      string
+        csv       string
+        buildStub func(mockBankBranchRepo *mockdb.MockBankBranchRepo)
+        checkResp func(t *testing.T, count int, err error)
+    }{
+        {
+            name: "Ok",
+            csv: header +
+                "hdfc0000076,HDFC BANK,MUMBAI - FORT,\"MANEKJI WADIA BUILDING, FORT\",MUMBAI,MAHARASHTRA\n" +
+                "SBIN0000691,STATE BANK OF INDIA,NEW DELHI MAIN BRANCH,11 SANSAD MARG,NEW DELHI,DELHI\n",
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                arg := store.UpsertBankBranchParams{
+                    Ifsc:     "HDFC0000076",
+                    BankName: "HDFC BANK",
+                    Branch:   "MUMBAI - FORT",
+                    Address:  "MANEKJI WADIA BUILDING, FORT",
+                    City:     "MUMBAI",
+                    State:    "MAHARASHTRA",
+                }
+                mockBankBranchRepo.EXPECT().UpsertBankBranch(gomock.Any(), arg).Times(1)
+                mockBankBranchRepo.EXPECT().UpsertBankBranch(gomock.Any(), gomock.Any()).Times(1)
+            },
+            checkResp: func(t *testing.T, count int, err error) {
+                require.NoError(t, err)
+                require.Equal(t, 2, count)
+            },
+        },
+        {
+            name: "InvalidIfsc",
+            csv: header +
+                "HDFC0000076,HDFC BANK,MUMBAI - FORT,,MUMBAI,MAHARASHTRA\n" +
+                "HDFC000076,HDFC BANK,MUMBAI - FORT,,MUMBAI,MAHARASHTRA\n",
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                mockBankBranchRepo.EXPECT().UpsertBankBranch(gomock.Any(), gomock.Any()).Times(1)
+            },
+            checkResp: func(t *testing.T, count int, err error) {
+                require.Error(t, err)
+                require.Equal(t, 1, count)
+            },
+        },
+        {
+            name: "WrongColumnCount",
+            csv:  header + "HDFC0000076,HDFC BANK\n",
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                mockBankBranchRepo.EXPECT().UpsertBankBranch(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, count int, err error) {
+                require.Error(t, err)
+                require.Zero(t, count)
+            },
+        },
+        {
+            name: "UpsertErr",
+            csv:  header + "HDFC0000076,HDFC BANK,MUMBAI - FORT,,MUMBAI,MAHARASHTRA\n",
+            buildStub: func(mockBankBranchRepo *mockdb.MockBankBranchRepo) {
+                mockBankBranchRepo.EXPECT().UpsertBankBranch(gomock.Any(), gomock.Any()).Times(1).Return(domain.BankBranch{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, count int, err error) {
+                require.Error(t, err)
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockBankBranchRepo := mockdb.NewMockBankBranchRepo(ctrl)
+            tc.buildStub(mockBankBranchRepo)
+
+            bankDirectorySvc := service.NewBankDirectoryService(mockBankBranchRepo)
+            count, err := bankDirectorySvc.ImportBankBranches(context.TODO(), strings.NewReader(tc.csv))
+            tc.checkResp(t, count, err)
+        })
+    }
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+)
+
+type BankVerificationSvc interface {
+    ProcessPendingVerifications(ctx context.Context) error
+}
+
+type bankVerificationService struct {
+    bankVerificationRepo store.BankVerificationRepo
+    bankAcctRepo         store.BankAccountRepo
+    userRepo             store.UserRepo
+    verifier             BankVerifier
+}
+
+func NewBankVerificationService(bankVerificationRepo store.BankVerificationRepo, bankAcctRepo store.BankAccountRepo, userRepo store.UserRepo, verifier BankVerifier) BankVerificationSvc {
+    return &bankVerificationService{
+        bankVerificationRepo: bankVerificationRepo,
+        bankAcctRepo:         bankAcctRepo,
+        userRepo:             userRepo,
+        verifier:             verifier,
+    }
+}
+
+// ProcessPendingVerifications initiates penny drops for newly created bank accounts and
+// polls the provider for the ones already initiated. A failure on one verification is
+// logged and does not stop the rest of the batch.
+func (b *bankVerificationService) ProcessPendingVerifications(ctx context.Context) error {
+    ctx, span := trace.Start(ctx, "BankVerificationSvc.ProcessPendingVerifications")
+    defer span.End()
+
+    pending, err := b.bankVerificationRepo.ListBankVerificationsByStatus(ctx, store.ListBankVerificationsByStatusParams{
+        Status: domain.BankVerificationStatusPENDING,
+        Limit:  constant.BankVerificationBatchSize,
+    })
+    if err != nil {
+        return err
+    }
+
+    for _, v := range pending {
+        if err := b.initiate(ctx, v); err != nil {
+            logging.FromContext(ctx).WithField("bank_verification_id", v.ID).Error("failed to initiate penny drop: ", err)
+        }
+    }
+
+    initiated, err := b.bankVerificationRepo.ListBankVerificationsByStatus(ctx, store.ListBankVerificationsByStatusParams{
+        Status: domain.BankVerificationStatusINITIATED,
+        Limit:  constant.BankVerificationBatchSize,
+    })
+    if err != nil {
+        return err
+    }
+
+    for _, v := range initiated {
+        if err := b.poll(ctx, v); err != nil {
+            logging.FromContext(ctx).WithField("bank_verification_id", v.ID).Error("failed to poll penny drop: ", err)
+        }
+    }
+
+    return nil
+}
+
+func (b *bankVerificationService) initiate(ctx context.Context, verification domain.BankVerification) error {
+    bankAcct, err := b.bankAcctRepo.GetBankAccount(ctx, verification.BankAccountID)
+    if err != nil {
+        return err
+    }
+
+    user, err := b.userRepo.GetUser(ctx, bankAcct.UserID)
+    if err != nil {
+        return err
+    }
+
+    res, err := b.verifier.InitiatePennyDrop(ctx, PennyDropRequest{
+        AccountNo:         bankAcct.AccountNo,
+        Ifsc:              bankAcct.Ifsc,
+        AccountHolderName: user.FullName,
+    })
+    if err != nil {
+        return err
+    }
+
+    verification, err = b.bankVerificationRepo.UpdateBankVerification(ctx, store.UpdateBankVerificationParams{
+        ID:          verification.ID,
+        ReferenceID: res.ReferenceID,
+        Status:      domain.BankVerificationStatusINITIATED,
+    })
+    if err == sql.ErrNoRows {
+        logging.FromContext(ctx).WithField("bank_verification_id", verification.ID).Info("bank verification completed meanwhile, skipping it")
+        return nil
+    }
+    if err != nil {
+        return err
+    }
+
+    if res.Status == PennyDropStatusPENDING {
+        return nil
+    }
+
+    return b.complete(ctx, verification, user, res)
+}
+
+// poll fails the verifications whose penny drop the provider doesn't know, e.g. lost on its restart, rather
+// than polling them forever.
+func (b *bankVerificationService) poll(ctx context.Context, verification domain.BankVerification) error {
+    res, err := b.verifier.GetPennyDropStatus(ctx, verification.ReferenceID)
+    if err == errors.ErrPennyDropNotFound {
+        logging.FromContext(ctx).WithField("bank_verification_id", verification.ID).Warn("penny drop not found, failing the verification")
+        res = PennyDropResult{ReferenceID: verification.ReferenceID, Status: PennyDropStatusFAILED}
+    } else if err != nil {
+        return err
+    }
+
+    if res.Status == PennyDropStatusPENDING {
+        return nil
+    }
+
+    bankAcct, err := b.bankAcctRepo.GetBankAccount(ctx, verification.BankAccountID)
+    if err != nil {
+        return err
+    }
+
+    user, err := b.userRepo.GetUser(ctx, bankAcct.UserID)
+    if err != nil {
+        return err
+    }
+
+    return b.complete(ctx, verification, user, res)
+}
+
+// complete scores the beneficiary name reported by the bank against the user's full name
+// and marks the bank account verified only when the penny drop succeeded and the names match.
+// The verifications completed meanwhile, e.g. by an admin, are skipped.
+func (b *bankVerificationService) complete(ctx context.Context, verification domain.BankVerification, user domain.User, res PennyDropResult) error {
+    var score int64
+    status := domain.BankVerificationStatusFAILED
+
+    if res.Status == PennyDropStatusSUCCESS {
+        score = util.NameMatchScore(user.FullName, res.BeneficiaryName)
+        if score >= constant.BankVerificationNameMatchScore {
+            status = domain.BankVerificationStatusSUCCESS
+        }
+    }
+
+    verificationArg := store.UpdateBankVerificationParams{
+        ID:              verification.ID,
+        ReferenceID:     verification.ReferenceID,
+        Status:          status,
+        BeneficiaryName: res.BeneficiaryName,
+        NameMatchScore:  score,
+    }
+    arg := store.BankAccountVerificationParams{
+        BankAccountID: verification.BankAccountID,
+        Verification:  &verificationArg,
+    }
+
+    var err error
+    if status == domain.BankVerificationStatusSUCCESS {
+        _, err = b.bankAcctRepo.BankAccountVerificationSuccess(ctx, arg)
+    } else {
+        _, err = b.bankAcctRepo.BankAccountVerificationFailed(ctx, arg)
+    }
+
+    switch err {
+    case errors.ErrBankVerificationNotPending:
+        logging.FromContext(ctx).WithField("bank_verification_id", verification.ID).Info("bank verification completed meanwhile, skipping it")
+        return nil
+    case errors.ErrBankAccountNotInVerification:
+        // the bank account was settled without this verification, its result is only recorded
+        logging.FromContext(ctx).WithField("bank_verification_id", verification.ID).Info("bank account no longer in verification, recording the penny drop only")
+        _, err = b.bankVerificationRepo.UpdateBankVerification(ctx, verificationArg)
+        if err == sql.ErrNoRows {
+            return nil
+        }
+        return err
+    }
+
+    return err
+}
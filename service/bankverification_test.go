@@ -0,0 +1,273 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+type bankVerificationStubs struct {
+    bankVerificationRepo *mockdb.MockBankVerificationRepo
+    bankAcctRepo         *mockdb.MockBankAccountRepo
+    userRepo             *mockdb.MockUserRepo
+    verifier             *mocksvc.MockBankVerifier
+}
+
+func TestProcessPendingVerifications(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    user.FullName = "Walter Hartwell White"
+
+    bankAcct := util.RandomBankAccount(util.RandomCreateBankAccountDto(util.INR))
+    bankAcct.ID = util.RandomInt(1, 1000)
+    bankAcct.UserID = user.ID
+
+    pending := domain.BankVerification{
+        ID:            util.RandomInt(1, 1000),
+        BankAccountID: bankAcct.ID,
+        Status:        domain.BankVerificationStatusPENDING,
+    }
+
+    initiated := pending
+    initiated.ReferenceID = "pd_ref_1"
+    initiated.Status = domain.BankVerificationStatusINITIATED
+
+    pendingArg := store.ListBankVerificationsByStatusParams{Status: domain.BankVerificationStatusPENDING, Limit: 50}
+    initiatedArg := store.ListBankVerificationsByStatusParams{Status: domain.BankVerificationStatusINITIATED, Limit: 50}
+
+    testcases := []struct {
+        name      string
+        buildStub func(s bankVerificationStubs)
+        checkResp func(t *testing.T, err error)
+    }{
+        {
+            name: "Initiate",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{pending}, nil)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+
+                pennyDropReq := service.PennyDropRequest{AccountNo: bankAcct.AccountNo, Ifsc: bankAcct.Ifsc, AccountHolderName: user.FullName}
+                s.verifier.EXPECT().InitiatePennyDrop(gomock.Any(), pennyDropReq).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusPENDING}, nil)
+
+                updateArg := store.UpdateBankVerificationParams{ID: pending.ID, ReferenceID: initiated.ReferenceID, Status: domain.BankVerificationStatusINITIATED}
+                s.bankVerificationRepo.EXPECT().UpdateBankVerification(gomock.Any(), updateArg).Times(1).Return(initiated, nil)
+
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{}, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "NameMatched",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{}, nil)
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{initiated}, nil)
+                s.verifier.EXPECT().GetPennyDropStatus(gomock.Any(), initiated.ReferenceID).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusSUCCESS, BeneficiaryName: "WALTER H WHITE"}, nil)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+
+                updateArg := store.UpdateBankVerificationParams{
+                    ID:              initiated.ID,
+                    ReferenceID:     initiated.ReferenceID,
+                    Status:          domain.BankVerificationStatusSUCCESS,
+                    BeneficiaryName: "WALTER H WHITE",
+                    NameMatchScore:  100,
+                }
+                s.bankAcctRepo.EXPECT().BankAccountVerificationSuccess(gomock.Any(), store.BankAccountVerificationParams{BankAccountID: bankAcct.ID, Verification: &updateArg}).Times(1)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "NameMismatch",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{}, nil)
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{initiated}, nil)
+                s.verifier.EXPECT().GetPennyDropStatus(gomock.Any(), initiated.ReferenceID).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusSUCCESS, BeneficiaryName: "JESSE PINKMAN"}, nil)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+
+                updateArg := store.UpdateBankVerificationParams{
+                    ID:              initiated.ID,
+                    ReferenceID:     initiated.ReferenceID,
+                    Status:          domain.BankVerificationStatusFAILED,
+                    BeneficiaryName: "JESSE PINKMAN",
+                    NameMatchScore:  0,
+                }
+                s.bankAcctRepo.EXPECT().BankAccountVerificationFailed(gomock.Any(), store.BankAccountVerificationParams{BankAccountID: bankAcct.ID, Verification: &updateArg}).Times(1)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "PennyDropStillPending",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{}, nil)
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{initiated}, nil)
+                s.verifier.EXPECT().GetPennyDropStatus(gomock.Any(), initiated.ReferenceID).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusPENDING}, nil)
+                s.bankVerificationRepo.EXPECT().UpdateBankVerification(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "PennyDropNotFound",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{}, nil)
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{initiated}, nil)
+                s.verifier.EXPECT().GetPennyDropStatus(gomock.Any(), initiated.ReferenceID).Times(1).Return(service.PennyDropResult{}, errors.ErrPennyDropNotFound)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+
+                updateArg := store.UpdateBankVerificationParams{
+                    ID:          initiated.ID,
+                    ReferenceID: initiated.ReferenceID,
+                    Status:      domain.BankVerificationStatusFAILED,
+                }
+                s.bankAcctRepo.EXPECT().BankAccountVerificationFailed(gomock.Any(), store.BankAccountVerificationParams{BankAccountID: bankAcct.ID, Verification: &updateArg}).Times(1)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "InitiateCompletedMeanwhile",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{pending}, nil)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                s.verifier.EXPECT().InitiatePennyDrop(gomock.Any(), gomock.Any()).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusSUCCESS}, nil)
+                s.bankVerificationRepo.EXPECT().UpdateBankVerification(gomock.Any(), gomock.Any()).Times(1).Return(domain.BankVerification{}, sql.ErrNoRows)
+                s.bankAcctRepo.EXPECT().BankAccountVerificationSuccess(gomock.Any(), gomock.Any()).Times(0)
+                s.bankAcctRepo.EXPECT().BankAccountVerificationFailed(gomock.Any(), gomock.Any()).Times(0)
+
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{}, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "VerificationCompletedMeanwhile",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{}, nil)
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{initiated}, nil)
+                s.verifier.EXPECT().GetPennyDropStatus(gomock.Any(), initiated.ReferenceID).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusSUCCESS, BeneficiaryName: "WALTER H WHITE"}, nil)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                s.bankAcctRepo.EXPECT().BankAccountVerificationSuccess(gomock.Any(), gomock.Any()).Times(1).Return(store.BankAccountVerificationResult{}, errors.ErrBankVerificationNotPending)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "BankAccountSettledMeanwhile",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return([]domain.BankVerification{}, nil)
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), initiatedArg).Times(1).Return([]domain.BankVerification{initiated}, nil)
+                s.verifier.EXPECT().GetPennyDropStatus(gomock.Any(), initiated.ReferenceID).Times(1).Return(service.PennyDropResult{ReferenceID: initiated.ReferenceID, Status: service.PennyDropStatusFAILED}, nil)
+                s.bankAcctRepo.EXPECT().GetBankAccount(gomock.Any(), bankAcct.ID).Times(1).Return(bankAcct, nil)
+                s.userRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+
+                updateArg := store.UpdateBankVerificationParams{
+                    ID:          initiated.ID,
+                    ReferenceID: initiated.ReferenceID,
+                    Status:      domain.BankVerificationStatusFAILED,
+                }
+                s.bankAcctRepo.EXPECT().BankAccountVerificationFailed(gomock.Any(), gomock.Any()).Times(1).Return(store.BankAccountVerificationResult{}, errors.ErrBankAccountNotInVerification)
+                s.bankVerificationRepo.EXPECT().UpdateBankVerification(gomock.Any(), updateArg).Times(1).Return(domain.BankVerification{}, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "ListErr",
+            buildStub: func(s bankVerificationStubs) {
+                s.bankVerificationRepo.EXPECT().ListBankVerificationsByStatus(gomock.Any(), pendingArg).Times(1).Return(nil, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.Error(t, err)
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            stubs := bankVerificationStubs{
+                bankVerificationRepo: mockdb.NewMockBankVerificationRepo(ctrl),
+                bankAcctRepo:         mockdb.NewMockBankAccountRepo(ctrl),
+                userRepo:             mockdb.NewMockUserRepo(ctrl),
+                verifier:             mocksvc.NewMockBankVerifier(ctrl),
+            }
+            tc.buildStub(stubs)
+
+            bankVerificationSvc := service.NewBankVerificationService(stubs.bankVerificationRepo, stubs.bankAcctRepo, stubs.userRepo, stubs.verifier)
+            err := bankVerificationSvc.ProcessPendingVerifications(context.TODO())
+            tc.checkResp(t, err)
+        })
+    }
+}
+
+func TestFakeBankVerifier(t *testing.T) {
+    testcases := []struct {
+        name            string
+        accountNo       string
+        status          service.PennyDropStatus
+        beneficiaryName string
+    }{
+        {
+            name:            "Success",
+            accountNo:       "1234567890",
+            status:          service.PennyDropStatusSUCCESS,
+            beneficiaryName: "WALTER WHITE",
+        },
+        {
+            name:      "Failed",
+            accountNo: "123456" + service.FakeBankVerifierFailingSuffix,
+            status:    service.PennyDropStatusFAILED,
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctx := context.TODO()
+            verifier := service.NewFakeBankVerifier()
+
+            res, err := verifier.InitiatePennyDrop(ctx, service.PennyDropRequest{
+                AccountNo:         tc.accountNo,
+                Ifsc:              "HDFC0000076",
+                AccountHolderName: "Walter White",
+            })
+            require.NoError(t, err)
+            require.NotEmpty(t, res.ReferenceID)
+            require.Equal(t, service.PennyDropStatusPENDING, res.Status)
+
+            res, err = verifier.GetPennyDropStatus(ctx, res.ReferenceID)
+            require.NoError(t, err)
+            require.Equal(t, tc.status, res.Status)
+            require.Equal(t, tc.beneficiaryName, res.BeneficiaryName)
+        })
+    }
+
+    _, err := service.NewFakeBankVerifier().GetPennyDropStatus(context.TODO(), "fake_pd_1")
+    require.Equal(t, errors.ErrPennyDropNotFound, err)
+}
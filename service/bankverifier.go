@@ -0,0 +1,95 @@
+package service
+
+import (
+    "context"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "strings"
+    "sync"
+)
+
+type PennyDropStatus string
+
+const (
+    PennyDropStatusPENDING PennyDropStatus = "PENDING"
+    PennyDropStatusSUCCESS PennyDropStatus = "SUCCESS"
+    PennyDropStatusFAILED  PennyDropStatus = "FAILED"
+)
+
+// PennyDropRequest describes the bank account a token amount is deposited into.
+type PennyDropRequest struct {
+    AccountNo         string
+    Ifsc              string
+    AccountHolderName string
+}
+
+// PennyDropResult is the provider's view of a penny drop. BeneficiaryName is the
+// account holder name reported by the bank and is only set once the drop succeeds.
+type PennyDropResult struct {
+    ReferenceID     string
+    Status          PennyDropStatus
+    BeneficiaryName string
+}
+
+// BankVerifier is implemented by bank account verification providers. GetPennyDropStatus fails with
+// errors.ErrPennyDropNotFound for a reference the provider doesn't know.
+type BankVerifier interface {
+    InitiatePennyDrop(ctx context.Context, req PennyDropRequest) (PennyDropResult, error)
+    GetPennyDropStatus(ctx context.Context, referenceID string) (PennyDropResult, error)
+}
+
+// FakeBankVerifierFailingSuffix marks account numbers the fake provider rejects.
+const FakeBankVerifierFailingSuffix = "0000"
+
+type fakeBankVerifier struct {
+    mu       sync.Mutex
+    seq      int64
+    requests map[string]PennyDropRequest
+}
+
+// NewFakeBankVerifier returns an in-memory provider for local development and tests.
+// Every penny drop completes on the first status check and reports the expected account
+// holder name, except for account numbers ending with FakeBankVerifierFailingSuffix. The
+// penny drops are lost on restart, their status checks then fail with errors.ErrPennyDropNotFound.
+func NewFakeBankVerifier() BankVerifier {
+    return &fakeBankVerifier{
+        requests: make(map[string]PennyDropRequest),
+    }
+}
+
+func (f *fakeBankVerifier) InitiatePennyDrop(ctx context.Context, req PennyDropRequest) (PennyDropResult, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+
+    f.seq++
+    referenceID := fmt.Sprintf("fake_pd_%d", f.seq)
+    f.requests[referenceID] = req
+
+    return PennyDropResult{
+        ReferenceID: referenceID,
+        Status:      PennyDropStatusPENDING,
+    }, nil
+}
+
+func (f *fakeBankVerifier) GetPennyDropStatus(ctx context.Context, referenceID string) (PennyDropResult, error) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+
+    req, ok := f.requests[referenceID]
+    if !ok {
+        return PennyDropResult{}, errors.ErrPennyDropNotFound
+    }
+
+    if strings.HasSuffix(req.AccountNo, FakeBankVerifierFailingSuffix) {
+        return PennyDropResult{
+            ReferenceID: referenceID,
+            Status:      PennyDropStatusFAILED,
+        }, nil
+    }
+
+    return PennyDropResult{
+        ReferenceID:     referenceID,
+        Status:          PennyDropStatusSUCCESS,
+        BeneficiaryName: strings.ToUpper(req.AccountHolderName),
+    }, nil
+}
@@ -5,6 +5,7 @@ import (
     "database/sql"
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
     "github.com/pranayhere/simple-wallet/store"
     "strings"
 )
@@ -25,6 +26,9 @@ func NewCurrencyService(currencyRepo store.CurrencyRepo) CurrencySvc {
 }
 
 func (c *currencyService) CreateCurrency(ctx context.Context, currencyDto dto.CurrencyDto) (dto.CurrencyDto, error) {
+    ctx, span := trace.Start(ctx, "CurrencySvc.CreateCurrency")
+    defer span.End()
+
     var res dto.CurrencyDto
 
     arg := store.CreateCurrencyParams{
@@ -47,6 +51,9 @@ func (c *currencyService) CreateCurrency(ctx context.Context, currencyDto dto.Cu
 }
 
 func (c *currencyService) GetCurrency(ctx context.Context, currencyCode string) (dto.CurrencyDto, error) {
+    ctx, span := trace.Start(ctx, "CurrencySvc.GetCurrency")
+    defer span.End()
+
     var res dto.CurrencyDto
 
     currency, err := c.currencyRepo.GetCurrency(ctx, strings.ToUpper(currencyCode))
@@ -0,0 +1,312 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    log "github.com/sirupsen/logrus"
+    "time"
+)
+
+type EscrowSvc interface {
+    Create(ctx context.Context, createEscrowDto dto.CreateEscrowDto) (dto.EscrowTransferResultDto, error)
+    Get(ctx context.Context, userID int64, id int64) (dto.EscrowDto, error)
+    Release(ctx context.Context, userID int64, id int64) (dto.EscrowTransferResultDto, error)
+    Dispute(ctx context.Context, disputeEscrowDto dto.DisputeEscrowDto) (dto.EscrowDto, error)
+    Resolve(ctx context.Context, resolveEscrowDto dto.ResolveEscrowDto) (dto.EscrowTransferResultDto, error)
+    List(ctx context.Context, listEscrowsDto dto.ListEscrowsDto) ([]dto.EscrowDto, error)
+    ReleaseDue(ctx context.Context) error
+}
+
+type escrowService struct {
+    escrowRepo   store.EscrowRepo
+    walletRepo   store.WalletRepo
+    twoFactorSvc TwoFactorSvc
+}
+
+func NewEscrowService(escrowRepo store.EscrowRepo, walletRepo store.WalletRepo, twoFactorSvc TwoFactorSvc) EscrowSvc {
+    return &escrowService{
+        escrowRepo:   escrowRepo,
+        walletRepo:   walletRepo,
+        twoFactorSvc: twoFactorSvc,
+    }
+}
+
+// Create is done by the owner of the paying wallet, stepped up for the funding.
+func (e *escrowService) Create(ctx context.Context, createEscrowDto dto.CreateEscrowDto) (dto.EscrowTransferResultDto, error) {
+    ctx, span := trace.Start(ctx, "EscrowSvc.Create")
+    defer span.End()
+
+    var res dto.EscrowTransferResultDto
+
+    payer, err := e.getWalletByAddress(ctx, createEscrowDto.FromWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    if payer.UserID != createEscrowDto.UserID {
+        return res, errors.ErrWalletNotFound
+    }
+
+    payee, err := e.getWalletByAddress(ctx, createEscrowDto.ToWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    ctx, err = stepUp(ctx, e.twoFactorSvc, dto.StepUpDto{
+        UserID:   payer.UserID,
+        Amount:   createEscrowDto.Amount,
+        Currency: payer.Currency,
+        Code:     createEscrowDto.TOTPCode,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    releaseAfter := constant.EscrowDefaultReleaseAfter
+    if createEscrowDto.ReleaseAfterSeconds > 0 {
+        releaseAfter = time.Duration(createEscrowDto.ReleaseAfterSeconds) * time.Second
+    }
+
+    funded, err := e.escrowRepo.FundEscrow(ctx, store.FundEscrowParams{
+        PayerWalletID: payer.ID,
+        PayeeWalletID: payee.ID,
+        Amount:        createEscrowDto.Amount,
+        ReleaseAfter:  time.Now().UTC().Add(releaseAfter),
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewEscrowTransferResultDto(funded)
+    return res, nil
+}
+
+// Get returns the escrow to its payer and its payee.
+func (e *escrowService) Get(ctx context.Context, userID int64, id int64) (dto.EscrowDto, error) {
+    ctx, span := trace.Start(ctx, "EscrowSvc.Get")
+    defer span.End()
+
+    var res dto.EscrowDto
+
+    escrow, err := e.getEscrow(ctx, userID, id, false)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewEscrowDto(escrow)
+    return res, nil
+}
+
+// Release is the payer confirming the delivery, the money goes to the payee.
+func (e *escrowService) Release(ctx context.Context, userID int64, id int64) (dto.EscrowTransferResultDto, error) {
+    ctx, span := trace.Start(ctx, "EscrowSvc.Release")
+    defer span.End()
+
+    var res dto.EscrowTransferResultDto
+
+    escrow, err := e.getEscrow(ctx, userID, id, true)
+    if err != nil {
+        return res, err
+    }
+
+    return e.settle(ctx, store.SettleEscrowParams{
+        ID:     escrow.ID,
+        From:   domain.EscrowStatusFUNDED,
+        Status: domain.EscrowStatusRELEASED,
+    })
+}
+
+// Dispute can be opened by the payer or the payee, it stops the release after the deadline.
+func (e *escrowService) Dispute(ctx context.Context, disputeEscrowDto dto.DisputeEscrowDto) (dto.EscrowDto, error) {
+    ctx, span := trace.Start(ctx, "EscrowSvc.Dispute")
+    defer span.End()
+
+    var res dto.EscrowDto
+
+    escrow, err := e.getEscrow(ctx, disputeEscrowDto.UserID, disputeEscrowDto.EscrowID, false)
+    if err != nil {
+        return res, err
+    }
+
+    escrow, err = e.escrowRepo.DisputeEscrow(ctx, store.DisputeEscrowParams{
+        ID:     escrow.ID,
+        Reason: disputeEscrowDto.Reason,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewEscrowDto(escrow)
+    return res, nil
+}
+
+// Resolve settles a disputed escrow on the decision of ops.
+func (e *escrowService) Resolve(ctx context.Context, resolveEscrowDto dto.ResolveEscrowDto) (dto.EscrowTransferResultDto, error) {
+    ctx, span := trace.Start(ctx, "EscrowSvc.Resolve")
+    defer span.End()
+
+    res, err := e.settle(ctx, store.SettleEscrowParams{
+        ID:     resolveEscrowDto.EscrowID,
+        From:   domain.EscrowStatusDISPUTED,
+        Status: resolveEscrowDto.Resolution,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    logging.FromContext(ctx).WithFields(log.Fields{
+        "admin_id":   resolveEscrowDto.AdminID,
+        "escrow_id":  resolveEscrowDto.EscrowID,
+        "resolution": resolveEscrowDto.Resolution,
+    }).Info("escrow dispute resolved")
+
+    return res, nil
+}
+
+func (e *escrowService) List(ctx context.Context, listEscrowsDto dto.ListEscrowsDto) ([]dto.EscrowDto, error) {
+    ctx, span := trace.Start(ctx, "EscrowSvc.List")
+    defer span.End()
+
+    res := []dto.EscrowDto{}
+
+    escrows, err := e.escrowRepo.ListEscrows(ctx, store.ListEscrowsParams{
+        Status: listEscrowsDto.Status,
+        Limit:  listEscrowsDto.Limit,
+        Offset: listEscrowsDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, escrow := range escrows {
+        res = append(res, dto.NewEscrowDto(escrow))
+    }
+
+    return res, nil
+}
+
+// ReleaseDue releases the funded escrows past their deadline to the payee, it runs as a background job.
+// An escrow released or disputed since it was listed is skipped. An escrow that fails to release, e.g. to
+// a frozen wallet, doesn't stop the others, the failed ones are logged, recorded on the escrow to be tried again
+// after EscrowReleaseRetryDelay, and reported in the returned error.
+func (e *escrowService) ReleaseDue(ctx context.Context) error {
+    ctx, span := trace.Start(ctx, "EscrowSvc.ReleaseDue")
+    defer span.End()
+
+    escrows, err := e.escrowRepo.ListDueEscrows(ctx, constant.EscrowReleaseBatchSize)
+    if err != nil {
+        return err
+    }
+
+    var failed []int64
+    for _, escrow := range escrows {
+        _, err := e.settle(ctx, store.SettleEscrowParams{
+            ID:     escrow.ID,
+            From:   domain.EscrowStatusFUNDED,
+            Status: domain.EscrowStatusRELEASED,
+        })
+        if err == errors.ErrEscrowNotFunded {
+            continue
+        }
+        if err != nil {
+            logging.FromContext(ctx).WithField("escrow_id", escrow.ID).Warn("failed to release due escrow: ", err)
+            failed = append(failed, escrow.ID)
+
+            arg := store.FailEscrowReleaseParams{
+                ID:            escrow.ID,
+                ReleaseError:  err.Error(),
+                NextReleaseAt: time.Now().UTC().Add(EscrowReleaseRetryDelay(escrow.ReleaseAttempts + 1)),
+            }
+            if _, err := e.escrowRepo.FailEscrowRelease(ctx, arg); err != nil && err != sql.ErrNoRows {
+                logging.FromContext(ctx).WithField("escrow_id", escrow.ID).Error("failed to record the failed release: ", err)
+            }
+        }
+    }
+
+    if len(failed) > 0 {
+        return fmt.Errorf("%d due escrows not released: %v", len(failed), failed)
+    }
+
+    return nil
+}
+
+func (e *escrowService) settle(ctx context.Context, arg store.SettleEscrowParams) (dto.EscrowTransferResultDto, error) {
+    var res dto.EscrowTransferResultDto
+
+    settled, err := e.escrowRepo.SettleEscrow(ctx, arg)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewEscrowTransferResultDto(settled)
+    return res, nil
+}
+
+// getEscrow returns the escrow if the user owns the paying wallet or, unless payerOnly, the payee's wallet.
+func (e *escrowService) getEscrow(ctx context.Context, userID int64, id int64, payerOnly bool) (domain.Escrow, error) {
+    escrow, err := e.escrowRepo.GetEscrow(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return escrow, errors.ErrEscrowNotFound
+        }
+
+        return escrow, err
+    }
+
+    walletIDs := []int64{escrow.PayerWalletID}
+    if !payerOnly {
+        walletIDs = append(walletIDs, escrow.PayeeWalletID)
+    }
+
+    for _, walletID := range walletIDs {
+        wallet, err := e.walletRepo.GetWallet(ctx, walletID)
+        if err != nil {
+            return escrow, err
+        }
+
+        if wallet.UserID == userID {
+            return escrow, nil
+        }
+    }
+
+    return escrow, errors.ErrEscrowNotFound
+}
+
+func (e *escrowService) getWalletByAddress(ctx context.Context, address string) (domain.Wallet, error) {
+    wallet, err := e.walletRepo.GetWalletByAddress(ctx, address)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return wallet, errors.ErrWalletNotFound
+        }
+
+        return wallet, err
+    }
+
+    return wallet, nil
+}
+
+// EscrowReleaseRetryDelay is the wait before releasing an escrow again after the given number of failed releases,
+// doubling from constant.EscrowReleaseRetryBaseDelay up to constant.EscrowReleaseMaxRetryDelay.
+func EscrowReleaseRetryDelay(attempts int64) time.Duration {
+    if attempts < 1 {
+        attempts = 1
+    }
+
+    delay := constant.EscrowReleaseMaxRetryDelay
+    if attempts <= 20 {
+        delay = constant.EscrowReleaseRetryBaseDelay * time.Duration(1<<uint(attempts-1))
+    }
+    if delay > constant.EscrowReleaseMaxRetryDelay {
+        delay = constant.EscrowReleaseMaxRetryDelay
+    }
+
+    return delay
+}
@@ -0,0 +1,281 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func TestCreateEscrow(t *testing.T) {
+    payer := domain.Wallet{ID: 1, UserID: 10, Address: "payer@my.wallet", Currency: "INR"}
+    payee := domain.Wallet{ID: 2, UserID: 20, Address: "payee@my.wallet", Currency: "INR"}
+    createDto := dto.CreateEscrowDto{
+        UserID:              payer.UserID,
+        FromWalletAddress:   payer.Address,
+        ToWalletAddress:     payee.Address,
+        Amount:              500,
+        ReleaseAfterSeconds: 3600,
+        TOTPCode:            "123456",
+    }
+
+    testcases := []struct {
+        name      string
+        createDto dto.CreateEscrowDto
+        buildStub func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp func(t *testing.T, res dto.EscrowTransferResultDto, err error)
+    }{
+        {
+            name:      "Ok",
+            createDto: createDto,
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: payer.UserID, Amount: 500, Currency: "INR", Code: "123456"}).Times(1)
+                mockEscrowRepo.EXPECT().FundEscrow(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.FundEscrowParams) (store.EscrowTransferResult, error) {
+                        require.Equal(t, payer.ID, arg.PayerWalletID)
+                        require.Equal(t, payee.ID, arg.PayeeWalletID)
+                        require.Equal(t, int64(500), arg.Amount)
+                        require.WithinDuration(t, time.Now().UTC().Add(time.Hour), arg.ReleaseAfter, time.Second)
+
+                        return store.EscrowTransferResult{
+                            Escrow: domain.Escrow{ID: 1, Amount: arg.Amount, Status: domain.EscrowStatusFUNDED, FundingTransferID: 5},
+                            Transfer: store.WalletTransferResult{
+                                Wallet:   payer,
+                                ToWallet: domain.Wallet{ID: 3, UserID: 4},
+                                Transfer: domain.Transfer{ID: 5, Amount: arg.Amount},
+                            },
+                        }, nil
+                    })
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.EscrowStatusFUNDED, res.Escrow.Status)
+                require.Equal(t, int64(5), res.Escrow.FundingTransferID)
+            },
+        },
+        {
+            name:      "NotTheOwner",
+            createDto: dto.CreateEscrowDto{UserID: payee.UserID, FromWalletAddress: payer.Address, ToWalletAddress: payee.Address, Amount: 500},
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockEscrowRepo.EXPECT().FundEscrow(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name:      "EscrowWalletNotFound",
+            createDto: createDto,
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1)
+                mockEscrowRepo.EXPECT().FundEscrow(gomock.Any(), gomock.Any()).Times(1).Return(store.EscrowTransferResult{}, errors.ErrEscrowWalletNotFound)
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.EqualError(t, err, errors.ErrEscrowWalletNotFound.Error())
+            },
+        },
+        {
+            name:      "InvalidTOTPCode",
+            createDto: createDto,
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrInvalidTOTPCode)
+                mockEscrowRepo.EXPECT().FundEscrow(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockEscrowRepo := mockdb.NewMockEscrowRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockEscrowRepo, mockWalletRepo, mockTwoFactorSvc)
+
+            escrowSvc := service.NewEscrowService(mockEscrowRepo, mockWalletRepo, mockTwoFactorSvc)
+            res, err := escrowSvc.Create(context.TODO(), tc.createDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestReleaseEscrow(t *testing.T) {
+    escrow := domain.Escrow{ID: 1, PayerWalletID: 1, PayeeWalletID: 2, Amount: 500, Status: domain.EscrowStatusFUNDED}
+    payer := domain.Wallet{ID: 1, UserID: 10}
+
+    testcases := []struct {
+        name      string
+        userID    int64
+        buildStub func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo)
+        checkResp func(t *testing.T, res dto.EscrowTransferResultDto, err error)
+    }{
+        {
+            name:   "Ok",
+            userID: payer.UserID,
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                arg := store.SettleEscrowParams{ID: escrow.ID, From: domain.EscrowStatusFUNDED, Status: domain.EscrowStatusRELEASED}
+                settled := store.EscrowTransferResult{
+                    Escrow: domain.Escrow{ID: escrow.ID, Status: domain.EscrowStatusRELEASED, SettlementTransferID: sql.NullInt64{Int64: 9, Valid: true}},
+                }
+
+                mockEscrowRepo.EXPECT().GetEscrow(gomock.Any(), escrow.ID).Times(1).Return(escrow, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), escrow.PayerWalletID).Times(1).Return(payer, nil)
+                mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), arg).Times(1).Return(settled, nil)
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.EscrowStatusRELEASED, res.Escrow.Status)
+                require.Equal(t, int64(9), res.Escrow.SettlementTransferID)
+            },
+        },
+        {
+            name:   "PayeeCantRelease",
+            userID: 20,
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockEscrowRepo.EXPECT().GetEscrow(gomock.Any(), escrow.ID).Times(1).Return(escrow, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), escrow.PayerWalletID).Times(1).Return(payer, nil)
+                mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.EqualError(t, err, errors.ErrEscrowNotFound.Error())
+            },
+        },
+        {
+            name:   "Disputed",
+            userID: payer.UserID,
+            buildStub: func(mockEscrowRepo *mockdb.MockEscrowRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockEscrowRepo.EXPECT().GetEscrow(gomock.Any(), escrow.ID).Times(1).Return(escrow, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), escrow.PayerWalletID).Times(1).Return(payer, nil)
+                mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), gomock.Any()).Times(1).Return(store.EscrowTransferResult{}, errors.ErrEscrowNotFunded)
+            },
+            checkResp: func(t *testing.T, res dto.EscrowTransferResultDto, err error) {
+                require.EqualError(t, err, errors.ErrEscrowNotFunded.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockEscrowRepo := mockdb.NewMockEscrowRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            tc.buildStub(mockEscrowRepo, mockWalletRepo)
+
+            escrowSvc := service.NewEscrowService(mockEscrowRepo, mockWalletRepo, mocksvc.NewMockTwoFactorSvc(ctrl))
+            res, err := escrowSvc.Release(context.TODO(), tc.userID, escrow.ID)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestDisputeEscrow(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    escrow := domain.Escrow{ID: 1, PayerWalletID: 1, PayeeWalletID: 2, Amount: 500, Status: domain.EscrowStatusFUNDED}
+
+    mockEscrowRepo := mockdb.NewMockEscrowRepo(ctrl)
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    mockEscrowRepo.EXPECT().GetEscrow(gomock.Any(), escrow.ID).Times(1).Return(escrow, nil)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), escrow.PayerWalletID).Times(1).Return(domain.Wallet{ID: 1, UserID: 10}, nil)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), escrow.PayeeWalletID).Times(1).Return(domain.Wallet{ID: 2, UserID: 20}, nil)
+    mockEscrowRepo.EXPECT().DisputeEscrow(gomock.Any(), store.DisputeEscrowParams{ID: escrow.ID, Reason: "not delivered"}).Times(1).
+        Return(domain.Escrow{ID: escrow.ID, Status: domain.EscrowStatusDISPUTED, DisputeReason: "not delivered"}, nil)
+
+    // the payee can open a dispute too
+    escrowSvc := service.NewEscrowService(mockEscrowRepo, mockWalletRepo, mocksvc.NewMockTwoFactorSvc(ctrl))
+    res, err := escrowSvc.Dispute(context.TODO(), dto.DisputeEscrowDto{EscrowID: escrow.ID, UserID: 20, Reason: "not delivered"})
+    require.NoError(t, err)
+    require.Equal(t, domain.EscrowStatusDISPUTED, res.Status)
+    require.Equal(t, "not delivered", res.DisputeReason)
+}
+
+func TestResolveEscrow(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockEscrowRepo := mockdb.NewMockEscrowRepo(ctrl)
+
+    arg := store.SettleEscrowParams{ID: 1, From: domain.EscrowStatusDISPUTED, Status: domain.EscrowStatusREFUNDED}
+    mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), arg).Times(1).
+        Return(store.EscrowTransferResult{Escrow: domain.Escrow{ID: 1, Status: domain.EscrowStatusREFUNDED}}, nil)
+
+    escrowSvc := service.NewEscrowService(mockEscrowRepo, mockdb.NewMockWalletRepo(ctrl), mocksvc.NewMockTwoFactorSvc(ctrl))
+    res, err := escrowSvc.Resolve(context.TODO(), dto.ResolveEscrowDto{EscrowID: 1, AdminID: 99, Resolution: domain.EscrowStatusREFUNDED})
+    require.NoError(t, err)
+    require.Equal(t, domain.EscrowStatusREFUNDED, res.Escrow.Status)
+}
+
+func TestReleaseDueEscrows(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockEscrowRepo := mockdb.NewMockEscrowRepo(ctrl)
+
+    mockEscrowRepo.EXPECT().ListDueEscrows(gomock.Any(), gomock.Any()).Times(1).Return([]domain.Escrow{{ID: 1}, {ID: 2}}, nil)
+    mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), store.SettleEscrowParams{ID: 1, From: domain.EscrowStatusFUNDED, Status: domain.EscrowStatusRELEASED}).Times(1).
+        Return(store.EscrowTransferResult{}, nil)
+    // disputed since it was listed
+    mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), store.SettleEscrowParams{ID: 2, From: domain.EscrowStatusFUNDED, Status: domain.EscrowStatusRELEASED}).Times(1).
+        Return(store.EscrowTransferResult{}, errors.ErrEscrowNotFunded)
+
+    escrowSvc := service.NewEscrowService(mockEscrowRepo, mockdb.NewMockWalletRepo(ctrl), mocksvc.NewMockTwoFactorSvc(ctrl))
+    require.NoError(t, escrowSvc.ReleaseDue(context.TODO()))
+}
+
+func TestReleaseDueEscrowsSkipsInactiveWallet(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockEscrowRepo := mockdb.NewMockEscrowRepo(ctrl)
+
+    mockEscrowRepo.EXPECT().ListDueEscrows(gomock.Any(), gomock.Any()).Times(1).Return([]domain.Escrow{{ID: 1, ReleaseAttempts: 2}, {ID: 2}}, nil)
+    // the payee wallet is frozen
+    mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), store.SettleEscrowParams{ID: 1, From: domain.EscrowStatusFUNDED, Status: domain.EscrowStatusRELEASED}).Times(1).
+        Return(store.EscrowTransferResult{}, errors.ErrWalletInactive)
+    // and the release is tried again after the backoff of the third failure
+    mockEscrowRepo.EXPECT().FailEscrowRelease(gomock.Any(), gomock.Any()).Times(1).
+        DoAndReturn(func(ctx context.Context, arg store.FailEscrowReleaseParams) (domain.Escrow, error) {
+            require.Equal(t, int64(1), arg.ID)
+            require.Equal(t, errors.ErrWalletInactive.Error(), arg.ReleaseError)
+            require.WithinDuration(t, time.Now().UTC().Add(4*constant.EscrowReleaseRetryBaseDelay), arg.NextReleaseAt, time.Minute)
+            return domain.Escrow{}, nil
+        })
+    mockEscrowRepo.EXPECT().SettleEscrow(gomock.Any(), store.SettleEscrowParams{ID: 2, From: domain.EscrowStatusFUNDED, Status: domain.EscrowStatusRELEASED}).Times(1).
+        Return(store.EscrowTransferResult{}, nil)
+
+    escrowSvc := service.NewEscrowService(mockEscrowRepo, mockdb.NewMockWalletRepo(ctrl), mocksvc.NewMockTwoFactorSvc(ctrl))
+    require.EqualError(t, escrowSvc.ReleaseDue(context.TODO()), "1 due escrows not released: [1]")
+}
+
+func TestEscrowReleaseRetryDelay(t *testing.T) {
+    require.Equal(t, constant.EscrowReleaseRetryBaseDelay, service.EscrowReleaseRetryDelay(0))
+    require.Equal(t, constant.EscrowReleaseRetryBaseDelay, service.EscrowReleaseRetryDelay(1))
+    require.Equal(t, 8*constant.EscrowReleaseRetryBaseDelay, service.EscrowReleaseRetryDelay(4))
+    require.Equal(t, constant.EscrowReleaseMaxRetryDelay, service.EscrowReleaseRetryDelay(30))
+    require.Equal(t, constant.EscrowReleaseMaxRetryDelay, service.EscrowReleaseRetryDelay(100))
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "strings"
+    "sync/atomic"
+)
+
+type HealthSvc interface {
+    Live(ctx context.Context) dto.LivenessDto
+    Ready(ctx context.Context) dto.ReadinessDto
+    Drain()
+}
+
+type healthService struct {
+    healthRepo    store.HealthRepo
+    schemaVersion int64
+    draining      int32
+}
+
+// NewHealthService checks the readiness against the schema version schemaVersion, migration.LatestVersion() outside the tests.
+func NewHealthService(healthRepo store.HealthRepo, schemaVersion int64) HealthSvc {
+    return &healthService{
+        healthRepo:    healthRepo,
+        schemaVersion: schemaVersion,
+    }
+}
+
+// Live tells the process is up and serving, it checks no dependency: a database down must not get it restarted.
+func (h *healthService) Live(ctx context.Context) dto.LivenessDto {
+    ctx, span := trace.Start(ctx, "HealthSvc.Live")
+    defer span.End()
+
+    return dto.LivenessDto{Status: dto.HealthStatusOK}
+}
+
+// Ready tells whether the service can serve: it is not shutting down, the database answers, its schema is
+// migrated to the version of the service and every currency has its organization wallet.
+func (h *healthService) Ready(ctx context.Context) dto.ReadinessDto {
+    ctx, span := trace.Start(ctx, "HealthSvc.Ready")
+    defer span.End()
+
+    ctx, cancel := context.WithTimeout(ctx, constant.HealthCheckTimeout)
+    defer cancel()
+
+    checks := []dto.HealthCheckDto{
+        healthCheck("shutdown", h.checkNotDraining()),
+        healthCheck("database", h.healthRepo.Ping(ctx)),
+        healthCheck("schema", h.checkSchemaVersion(ctx)),
+        healthCheck("organization_wallets", h.checkOrganizationWallets(ctx)),
+    }
+
+    res := dto.ReadinessDto{Status: dto.HealthStatusReady, Checks: checks}
+    for _, c := range checks {
+        if c.Status != dto.HealthStatusOK {
+            res.Status = dto.HealthStatusNotReady
+        }
+    }
+    return res
+}
+
+// Drain makes the service not ready for good, it is called when the graceful shutdown starts.
+func (h *healthService) Drain() {
+    atomic.StoreInt32(&h.draining, 1)
+}
+
+func (h *healthService) checkNotDraining() error {
+    if atomic.LoadInt32(&h.draining) == 1 {
+        return errors.New("shutting down")
+    }
+    return nil
+}
+
+func (h *healthService) checkSchemaVersion(ctx context.Context) error {
+    version, err := h.healthRepo.GetSchemaVersion(ctx)
+    if err != nil {
+        return err
+    }
+    if version.Dirty {
+        return fmt.Errorf("migration %d is dirty", version.Version)
+    }
+    if version.Version != h.schemaVersion {
+        return fmt.Errorf("schema version is %d, expected %d", version.Version, h.schemaVersion)
+    }
+    return nil
+}
+
+func (h *healthService) checkOrganizationWallets(ctx context.Context) error {
+    currencies, err := h.healthRepo.ListCurrenciesWithoutOrganizationWallet(ctx)
+    if err != nil {
+        return err
+    }
+    if len(currencies) > 0 {
+        return fmt.Errorf("no organization wallet for %s", strings.Join(currencies, ", "))
+    }
+    return nil
+}
+
+func healthCheck(name string, err error) dto.HealthCheckDto {
+    if err != nil {
+        return dto.HealthCheckDto{Name: name, Status: dto.HealthStatusFailed, Error: err.Error()}
+    }
+    return dto.HealthCheckDto{Name: name, Status: dto.HealthStatusOK}
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestHealthReady(t *testing.T) {
+    const schemaVersion = 13
+
+    testCases := []struct {
+        name      string
+        drain     bool
+        buildStub func(mockHealthRepo *mockdb.MockHealthRepo)
+        failed    map[string]string
+    }{
+        {
+            name: "Ready",
+            buildStub: func(mockHealthRepo *mockdb.MockHealthRepo) {
+                mockHealthRepo.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+                mockHealthRepo.EXPECT().GetSchemaVersion(gomock.Any()).Times(1).Return(store.SchemaVersion{Version: schemaVersion}, nil)
+                mockHealthRepo.EXPECT().ListCurrenciesWithoutOrganizationWallet(gomock.Any()).Times(1).Return([]string{}, nil)
+            },
+            failed: map[string]string{},
+        },
+        {
+            name: "DatabaseDown",
+            buildStub: func(mockHealthRepo *mockdb.MockHealthRepo) {
+                mockHealthRepo.EXPECT().Ping(gomock.Any()).Times(1).Return(sql.ErrConnDone)
+                mockHealthRepo.EXPECT().GetSchemaVersion(gomock.Any()).Times(1).Return(store.SchemaVersion{}, sql.ErrConnDone)
+                mockHealthRepo.EXPECT().ListCurrenciesWithoutOrganizationWallet(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+            },
+            failed: map[string]string{
+                "database":             sql.ErrConnDone.Error(),
+                "schema":               sql.ErrConnDone.Error(),
+                "organization_wallets": sql.ErrConnDone.Error(),
+            },
+        },
+        {
+            name: "SchemaBehind",
+            buildStub: func(mockHealthRepo *mockdb.MockHealthRepo) {
+                mockHealthRepo.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+                mockHealthRepo.EXPECT().GetSchemaVersion(gomock.Any()).Times(1).Return(store.SchemaVersion{Version: 12}, nil)
+                mockHealthRepo.EXPECT().ListCurrenciesWithoutOrganizationWallet(gomock.Any()).Times(1).Return([]string{}, nil)
+            },
+            failed: map[string]string{"schema": "schema version is 12, expected 13"},
+        },
+        {
+            name: "SchemaDirty",
+            buildStub: func(mockHealthRepo *mockdb.MockHealthRepo) {
+                mockHealthRepo.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+                mockHealthRepo.EXPECT().GetSchemaVersion(gomock.Any()).Times(1).Return(store.SchemaVersion{Version: schemaVersion, Dirty: true}, nil)
+                mockHealthRepo.EXPECT().ListCurrenciesWithoutOrganizationWallet(gomock.Any()).Times(1).Return([]string{}, nil)
+            },
+            failed: map[string]string{"schema": "migration 13 is dirty"},
+        },
+        {
+            name: "OrganizationWalletMissing",
+            buildStub: func(mockHealthRepo *mockdb.MockHealthRepo) {
+                mockHealthRepo.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+                mockHealthRepo.EXPECT().GetSchemaVersion(gomock.Any()).Times(1).Return(store.SchemaVersion{Version: schemaVersion}, nil)
+                mockHealthRepo.EXPECT().ListCurrenciesWithoutOrganizationWallet(gomock.Any()).Times(1).Return([]string{"GBP", "JPY"}, nil)
+            },
+            failed: map[string]string{"organization_wallets": "no organization wallet for GBP, JPY"},
+        },
+        {
+            name:  "Draining",
+            drain: true,
+            buildStub: func(mockHealthRepo *mockdb.MockHealthRepo) {
+                mockHealthRepo.EXPECT().Ping(gomock.Any()).Times(1).Return(nil)
+                mockHealthRepo.EXPECT().GetSchemaVersion(gomock.Any()).Times(1).Return(store.SchemaVersion{Version: schemaVersion}, nil)
+                mockHealthRepo.EXPECT().ListCurrenciesWithoutOrganizationWallet(gomock.Any()).Times(1).Return([]string{}, nil)
+            },
+            failed: map[string]string{"shutdown": "shutting down"},
+        },
+    }
+
+    for i := range testCases {
+        tc := testCases[i]
+
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockHealthRepo := mockdb.NewMockHealthRepo(ctrl)
+            tc.buildStub(mockHealthRepo)
+
+            healthSvc := service.NewHealthService(mockHealthRepo, schemaVersion)
+            if tc.drain {
+                healthSvc.Drain()
+            }
+
+            res := healthSvc.Ready(context.Background())
+            require.Equal(t, len(tc.failed) == 0, res.IsReady())
+            require.Len(t, res.Checks, 4)
+
+            failed := map[string]string{}
+            for _, c := range res.Checks {
+                if c.Status == dto.HealthStatusFailed {
+                    failed[c.Name] = c.Error
+                } else {
+                    require.Equal(t, dto.HealthStatusOK, c.Status)
+                }
+            }
+            require.Equal(t, tc.failed, failed)
+        })
+    }
+}
+
+func TestHealthLive(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    // liveness checks no dependency, even while draining
+    healthSvc := service.NewHealthService(mockdb.NewMockHealthRepo(ctrl), 13)
+    healthSvc.Drain()
+    require.Equal(t, dto.LivenessDto{Status: dto.HealthStatusOK}, healthSvc.Live(context.Background()))
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "time"
+)
+
+type HoldSvc interface {
+    Authorize(ctx context.Context, authorizeHoldDto dto.AuthorizeHoldDto) (dto.HoldDto, error)
+    Capture(ctx context.Context, captureHoldDto dto.CaptureHoldDto) (dto.CaptureHoldResultDto, error)
+    Void(ctx context.Context, userID int64, id int64) (dto.HoldDto, error)
+    Get(ctx context.Context, userID int64, id int64) (dto.HoldDto, error)
+    List(ctx context.Context, listHoldsDto dto.ListHoldsDto) ([]dto.HoldDto, error)
+    ExpireHolds(ctx context.Context) error
+}
+
+type holdService struct {
+    holdRepo     store.HoldRepo
+    walletRepo   store.WalletRepo
+    twoFactorSvc TwoFactorSvc
+}
+
+func NewHoldService(holdRepo store.HoldRepo, walletRepo store.WalletRepo, twoFactorSvc TwoFactorSvc) HoldSvc {
+    return &holdService{
+        holdRepo:     holdRepo,
+        walletRepo:   walletRepo,
+        twoFactorSvc: twoFactorSvc,
+    }
+}
+
+// Authorize is done by the owner of the paying wallet, stepped up for the amount reserved.
+func (h *holdService) Authorize(ctx context.Context, authorizeHoldDto dto.AuthorizeHoldDto) (dto.HoldDto, error) {
+    ctx, span := trace.Start(ctx, "HoldSvc.Authorize")
+    defer span.End()
+
+    var res dto.HoldDto
+
+    wallet, err := h.getWalletByAddress(ctx, authorizeHoldDto.FromWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    if wallet.UserID != authorizeHoldDto.UserID {
+        return res, errors.ErrWalletNotFound
+    }
+
+    toWallet, err := h.getWalletByAddress(ctx, authorizeHoldDto.ToWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    ctx, err = stepUp(ctx, h.twoFactorSvc, dto.StepUpDto{
+        UserID:   wallet.UserID,
+        Amount:   authorizeHoldDto.Amount,
+        Currency: wallet.Currency,
+        Code:     authorizeHoldDto.TOTPCode,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    ttl := constant.HoldDefaultTTL
+    if authorizeHoldDto.TTLSeconds > 0 {
+        ttl = time.Duration(authorizeHoldDto.TTLSeconds) * time.Second
+    }
+
+    hold, err := h.holdRepo.AuthorizeHold(ctx, store.CreateHoldParams{
+        WalletID:   wallet.ID,
+        ToWalletID: toWallet.ID,
+        Amount:     authorizeHoldDto.Amount,
+        ExpiresAt:  time.Now().UTC().Add(ttl),
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewHoldDto(hold)
+    return res, nil
+}
+
+// Capture is done by the owner of the merchant's wallet, stepped up for the amount captured.
+func (h *holdService) Capture(ctx context.Context, captureHoldDto dto.CaptureHoldDto) (dto.CaptureHoldResultDto, error) {
+    ctx, span := trace.Start(ctx, "HoldSvc.Capture")
+    defer span.End()
+
+    var res dto.CaptureHoldResultDto
+
+    hold, err := h.getHold(ctx, captureHoldDto.UserID, captureHoldDto.HoldID, true)
+    if err != nil {
+        return res, err
+    }
+
+    wallet, err := h.walletRepo.GetWallet(ctx, hold.WalletID)
+    if err != nil {
+        return res, err
+    }
+
+    amount := captureHoldDto.Amount
+    if amount == 0 {
+        amount = hold.Amount
+    }
+
+    ctx, err = stepUp(ctx, h.twoFactorSvc, dto.StepUpDto{
+        UserID:   captureHoldDto.UserID,
+        Amount:   amount,
+        Currency: wallet.Currency,
+        Code:     captureHoldDto.TOTPCode,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    captured, err := h.holdRepo.CaptureHold(ctx, store.CaptureHoldParams{
+        ID:     hold.ID,
+        Amount: captureHoldDto.Amount,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewCaptureHoldResultDto(captured)
+    return res, nil
+}
+
+// Void is done by the owner of the merchant's wallet, the payer can't take back an authorized hold.
+func (h *holdService) Void(ctx context.Context, userID int64, id int64) (dto.HoldDto, error) {
+    ctx, span := trace.Start(ctx, "HoldSvc.Void")
+    defer span.End()
+
+    var res dto.HoldDto
+
+    hold, err := h.getHold(ctx, userID, id, true)
+    if err != nil {
+        return res, err
+    }
+
+    hold, err = h.holdRepo.ReleaseHold(ctx, store.ReleaseHoldParams{
+        ID:     hold.ID,
+        Status: domain.HoldStatusVOIDED,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewHoldDto(hold)
+    return res, nil
+}
+
+func (h *holdService) Get(ctx context.Context, userID int64, id int64) (dto.HoldDto, error) {
+    ctx, span := trace.Start(ctx, "HoldSvc.Get")
+    defer span.End()
+
+    var res dto.HoldDto
+
+    hold, err := h.getHold(ctx, userID, id, false)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewHoldDto(hold)
+    return res, nil
+}
+
+func (h *holdService) List(ctx context.Context, listHoldsDto dto.ListHoldsDto) ([]dto.HoldDto, error) {
+    ctx, span := trace.Start(ctx, "HoldSvc.List")
+    defer span.End()
+
+    res := []dto.HoldDto{}
+
+    wallet, err := h.walletRepo.GetWallet(ctx, listHoldsDto.WalletID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrWalletNotFound
+        }
+
+        return res, err
+    }
+
+    if wallet.UserID != listHoldsDto.UserID {
+        return res, errors.ErrWalletNotFound
+    }
+
+    holds, err := h.holdRepo.ListHolds(ctx, store.ListHoldsParams{
+        WalletID: wallet.ID,
+        Limit:    listHoldsDto.Limit,
+        Offset:   listHoldsDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, hold := range holds {
+        res = append(res, dto.NewHoldDto(hold))
+    }
+
+    return res, nil
+}
+
+// ExpireHolds releases the authorized holds past their TTL, it runs as a background job. A hold
+// captured or voided since it was listed is skipped.
+func (h *holdService) ExpireHolds(ctx context.Context) error {
+    ctx, span := trace.Start(ctx, "HoldSvc.ExpireHolds")
+    defer span.End()
+
+    holds, err := h.holdRepo.ListExpiredHolds(ctx, constant.HoldExpiryBatchSize)
+    if err != nil {
+        return err
+    }
+
+    var expired int
+    for _, hold := range holds {
+        _, err := h.holdRepo.ReleaseHold(ctx, store.ReleaseHoldParams{
+            ID:     hold.ID,
+            Status: domain.HoldStatusEXPIRED,
+        })
+        if err == errors.ErrHoldNotAuthorized {
+            continue
+        }
+        if err != nil {
+            return err
+        }
+        expired++
+    }
+
+    if expired > 0 {
+        logging.FromContext(ctx).WithField("count", expired).Info("expired holds")
+    }
+
+    return nil
+}
+
+// getHold returns the hold if the user owns the merchant's wallet or, unless merchantOnly, the paying wallet.
+func (h *holdService) getHold(ctx context.Context, userID int64, id int64, merchantOnly bool) (domain.Hold, error) {
+    hold, err := h.holdRepo.GetHold(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return hold, errors.ErrHoldNotFound
+        }
+
+        return hold, err
+    }
+
+    walletIDs := []int64{hold.ToWalletID}
+    if !merchantOnly {
+        walletIDs = append(walletIDs, hold.WalletID)
+    }
+
+    for _, walletID := range walletIDs {
+        wallet, err := h.walletRepo.GetWallet(ctx, walletID)
+        if err != nil {
+            return hold, err
+        }
+
+        if wallet.UserID == userID {
+            return hold, nil
+        }
+    }
+
+    return hold, errors.ErrHoldNotFound
+}
+
+func (h *holdService) getWalletByAddress(ctx context.Context, address string) (domain.Wallet, error) {
+    wallet, err := h.walletRepo.GetWalletByAddress(ctx, address)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return wallet, errors.ErrWalletNotFound
+        }
+
+        return wallet, err
+    }
+
+    return wallet, nil
+}
@@ -0,0 +1,290 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func TestAuthorizeHold(t *testing.T) {
+    payer := domain.Wallet{ID: 1, UserID: 10, Address: "payer@my.wallet", Currency: "INR"}
+    merchant := domain.Wallet{ID: 2, UserID: 20, Address: "merchant@my.wallet"}
+    authorizeDto := dto.AuthorizeHoldDto{
+        UserID:            payer.UserID,
+        FromWalletAddress: payer.Address,
+        ToWalletAddress:   merchant.Address,
+        Amount:            50,
+        TTLSeconds:        60,
+        TOTPCode:          "123456",
+    }
+
+    testcases := []struct {
+        name         string
+        authorizeDto dto.AuthorizeHoldDto
+        buildStub    func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp    func(t *testing.T, res dto.HoldDto, err error)
+    }{
+        {
+            name:         "Ok",
+            authorizeDto: authorizeDto,
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), merchant.Address).Times(1).Return(merchant, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: payer.UserID, Amount: 50, Currency: "INR", Code: "123456"}).Times(1)
+                mockHoldRepo.EXPECT().AuthorizeHold(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.CreateHoldParams) (domain.Hold, error) {
+                        require.Equal(t, payer.ID, arg.WalletID)
+                        require.Equal(t, merchant.ID, arg.ToWalletID)
+                        require.Equal(t, int64(50), arg.Amount)
+                        require.WithinDuration(t, time.Now().UTC().Add(time.Minute), arg.ExpiresAt, time.Second)
+
+                        return domain.Hold{ID: 1, WalletID: arg.WalletID, ToWalletID: arg.ToWalletID, Amount: arg.Amount, Status: domain.HoldStatusAUTHORIZED, ExpiresAt: arg.ExpiresAt}, nil
+                    })
+            },
+            checkResp: func(t *testing.T, res dto.HoldDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.HoldStatusAUTHORIZED, res.Status)
+            },
+        },
+        {
+            name:         "NotTheOwner",
+            authorizeDto: dto.AuthorizeHoldDto{UserID: merchant.UserID, FromWalletAddress: payer.Address, ToWalletAddress: merchant.Address, Amount: 50},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockHoldRepo.EXPECT().AuthorizeHold(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.HoldDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name:         "MerchantWalletNotFound",
+            authorizeDto: authorizeDto,
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), merchant.Address).Times(1).Return(domain.Wallet{}, sql.ErrNoRows)
+                mockHoldRepo.EXPECT().AuthorizeHold(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.HoldDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name:         "InsufficientBalance",
+            authorizeDto: authorizeDto,
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), merchant.Address).Times(1).Return(merchant, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1)
+                mockHoldRepo.EXPECT().AuthorizeHold(gomock.Any(), gomock.Any()).Times(1).Return(domain.Hold{}, errors.ErrInsufficientBalance)
+            },
+            checkResp: func(t *testing.T, res dto.HoldDto, err error) {
+                require.EqualError(t, err, errors.ErrInsufficientBalance.Error())
+            },
+        },
+        {
+            name:         "TOTPRequired",
+            authorizeDto: dto.AuthorizeHoldDto{UserID: payer.UserID, FromWalletAddress: payer.Address, ToWalletAddress: merchant.Address, Amount: 50},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), merchant.Address).Times(1).Return(merchant, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrTOTPRequired)
+                mockHoldRepo.EXPECT().AuthorizeHold(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.HoldDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPRequired.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockHoldRepo := mockdb.NewMockHoldRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockHoldRepo, mockWalletRepo, mockTwoFactorSvc)
+
+            holdSvc := service.NewHoldService(mockHoldRepo, mockWalletRepo, mockTwoFactorSvc)
+            res, err := holdSvc.Authorize(context.TODO(), tc.authorizeDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestCaptureHold(t *testing.T) {
+    hold := domain.Hold{ID: 1, WalletID: 1, ToWalletID: 2, Amount: 50, Status: domain.HoldStatusAUTHORIZED}
+    payer := domain.Wallet{ID: 1, UserID: 10, Currency: "INR"}
+    merchant := domain.Wallet{ID: 2, UserID: 20, Currency: "INR"}
+
+    testcases := []struct {
+        name       string
+        captureDto dto.CaptureHoldDto
+        buildStub  func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp  func(t *testing.T, res dto.CaptureHoldResultDto, err error)
+    }{
+        {
+            name:       "PartialCapture",
+            captureDto: dto.CaptureHoldDto{HoldID: hold.ID, UserID: merchant.UserID, Amount: 30, TOTPCode: "123456"},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                captured := store.CaptureHoldResult{
+                    Hold: domain.Hold{ID: hold.ID, Amount: 50, CapturedAmount: 30, Status: domain.HoldStatusCAPTURED, TransferID: sql.NullInt64{Int64: 7, Valid: true}},
+                    Transfer: store.WalletTransferResult{
+                        Wallet:   domain.Wallet{ID: 1, UserID: 10},
+                        ToWallet: merchant,
+                        Transfer: domain.Transfer{ID: 7, Amount: 30},
+                    },
+                }
+
+                mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(1).Return(hold, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.ToWalletID).Times(1).Return(merchant, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.WalletID).Times(1).Return(payer, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: merchant.UserID, Amount: 30, Currency: "INR", Code: "123456"}).Times(1)
+                mockHoldRepo.EXPECT().CaptureHold(gomock.Any(), store.CaptureHoldParams{ID: hold.ID, Amount: 30}).Times(1).Return(captured, nil)
+            },
+            checkResp: func(t *testing.T, res dto.CaptureHoldResultDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(30), res.Hold.CapturedAmount)
+                require.Equal(t, int64(7), res.Hold.TransferID)
+                require.Equal(t, int64(30), res.Transfer.Transfer.Amount)
+            },
+        },
+        {
+            name:       "PayerCantCapture",
+            captureDto: dto.CaptureHoldDto{HoldID: hold.ID, UserID: 10},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(1).Return(hold, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.ToWalletID).Times(1).Return(merchant, nil)
+                mockHoldRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.CaptureHoldResultDto, err error) {
+                require.EqualError(t, err, errors.ErrHoldNotFound.Error())
+            },
+        },
+        {
+            name:       "HoldExpired",
+            captureDto: dto.CaptureHoldDto{HoldID: hold.ID, UserID: merchant.UserID},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(1).Return(hold, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.ToWalletID).Times(1).Return(merchant, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.WalletID).Times(1).Return(payer, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: merchant.UserID, Amount: hold.Amount, Currency: "INR"}).Times(1)
+                mockHoldRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Any()).Times(1).Return(store.CaptureHoldResult{}, errors.ErrHoldExpired)
+            },
+            checkResp: func(t *testing.T, res dto.CaptureHoldResultDto, err error) {
+                require.EqualError(t, err, errors.ErrHoldExpired.Error())
+            },
+        },
+        {
+            name:       "HoldNotFound",
+            captureDto: dto.CaptureHoldDto{HoldID: hold.ID, UserID: merchant.UserID},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(1).Return(domain.Hold{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, res dto.CaptureHoldResultDto, err error) {
+                require.EqualError(t, err, errors.ErrHoldNotFound.Error())
+            },
+        },
+        {
+            name:       "InvalidTOTPCode",
+            captureDto: dto.CaptureHoldDto{HoldID: hold.ID, UserID: merchant.UserID, TOTPCode: "000000"},
+            buildStub: func(mockHoldRepo *mockdb.MockHoldRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(1).Return(hold, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.ToWalletID).Times(1).Return(merchant, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.WalletID).Times(1).Return(payer, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrInvalidTOTPCode)
+                mockHoldRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.CaptureHoldResultDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockHoldRepo := mockdb.NewMockHoldRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockHoldRepo, mockWalletRepo, mockTwoFactorSvc)
+
+            holdSvc := service.NewHoldService(mockHoldRepo, mockWalletRepo, mockTwoFactorSvc)
+            res, err := holdSvc.Capture(context.TODO(), tc.captureDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestVoidHold(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    hold := domain.Hold{ID: 1, WalletID: 1, ToWalletID: 2, Amount: 50, Status: domain.HoldStatusAUTHORIZED}
+
+    mockHoldRepo := mockdb.NewMockHoldRepo(ctrl)
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(1).Return(hold, nil)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.ToWalletID).Times(1).Return(domain.Wallet{ID: 2, UserID: 20}, nil)
+    mockHoldRepo.EXPECT().ReleaseHold(gomock.Any(), store.ReleaseHoldParams{ID: hold.ID, Status: domain.HoldStatusVOIDED}).Times(1).
+        Return(domain.Hold{ID: hold.ID, Status: domain.HoldStatusVOIDED}, nil)
+
+    holdSvc := service.NewHoldService(mockHoldRepo, mockWalletRepo, mocksvc.NewMockTwoFactorSvc(ctrl))
+    res, err := holdSvc.Void(context.TODO(), 20, hold.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.HoldStatusVOIDED, res.Status)
+}
+
+func TestGetHold(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    hold := domain.Hold{ID: 1, WalletID: 1, ToWalletID: 2, Amount: 50, Status: domain.HoldStatusAUTHORIZED}
+
+    mockHoldRepo := mockdb.NewMockHoldRepo(ctrl)
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    mockHoldRepo.EXPECT().GetHold(gomock.Any(), hold.ID).Times(2).Return(hold, nil)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.ToWalletID).Times(2).Return(domain.Wallet{ID: 2, UserID: 20}, nil)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), hold.WalletID).Times(2).Return(domain.Wallet{ID: 1, UserID: 10}, nil)
+
+    holdSvc := service.NewHoldService(mockHoldRepo, mockWalletRepo, mocksvc.NewMockTwoFactorSvc(ctrl))
+
+    // the payer sees the hold too
+    res, err := holdSvc.Get(context.TODO(), 10, hold.ID)
+    require.NoError(t, err)
+    require.Equal(t, hold.ID, res.ID)
+
+    _, err = holdSvc.Get(context.TODO(), 30, hold.ID)
+    require.EqualError(t, err, errors.ErrHoldNotFound.Error())
+}
+
+func TestExpireHolds(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    holds := []domain.Hold{{ID: 1}, {ID: 2}, {ID: 3}}
+
+    mockHoldRepo := mockdb.NewMockHoldRepo(ctrl)
+    mockHoldRepo.EXPECT().ListExpiredHolds(gomock.Any(), gomock.Any()).Times(1).Return(holds, nil)
+    mockHoldRepo.EXPECT().ReleaseHold(gomock.Any(), store.ReleaseHoldParams{ID: 1, Status: domain.HoldStatusEXPIRED}).Times(1).Return(domain.Hold{}, nil)
+    // captured since it was listed
+    mockHoldRepo.EXPECT().ReleaseHold(gomock.Any(), store.ReleaseHoldParams{ID: 2, Status: domain.HoldStatusEXPIRED}).Times(1).Return(domain.Hold{}, errors.ErrHoldNotAuthorized)
+    mockHoldRepo.EXPECT().ReleaseHold(gomock.Any(), store.ReleaseHoldParams{ID: 3, Status: domain.HoldStatusEXPIRED}).Times(1).Return(domain.Hold{}, nil)
+
+    holdSvc := service.NewHoldService(mockHoldRepo, mockdb.NewMockWalletRepo(ctrl), mocksvc.NewMockTwoFactorSvc(ctrl))
+    require.NoError(t, holdSvc.ExpireHolds(context.TODO()))
+}
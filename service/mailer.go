@@ -0,0 +1,44 @@
+package service
+
+import (
+    "context"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+)
+
+// Mailer sends the emails about the account of a user, whatever their notification preferences.
+type Mailer interface {
+    SendPasswordReset(ctx context.Context, user domain.User, token string) error
+}
+
+type notifierMailer struct {
+    notifier Notifier
+}
+
+// NewNotifierMailer sends the emails through the email notifier.
+func NewNotifierMailer(notifier Notifier) Mailer {
+    return &notifierMailer{
+        notifier: notifier,
+    }
+}
+
+func (n *notifierMailer) SendPasswordReset(ctx context.Context, user domain.User, token string) error {
+    body := fmt.Sprintf(`Hi %s,
+
+A reset of the password of your wallet account %s was asked. Reset it within %s with the token
+
+%s
+
+sent to POST /v1/users/password/reset with your new password. The token works once.
+
+If you didn't ask for it, ignore this email: your password is unchanged.`,
+        user.FullName, user.Username, constant.PasswordResetTokenTTL, token)
+
+    return n.notifier.Send(ctx, Message{
+        UserID:  user.ID,
+        Email:   user.Email,
+        Subject: "Reset your password",
+        Body:    body,
+    })
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "github.com/lib/pq"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+)
+
+type MerchantSvc interface {
+    Create(ctx context.Context, createMerchantDto dto.CreateMerchantDto) (dto.MerchantDto, error)
+    Get(ctx context.Context, userID int64) (dto.MerchantDto, error)
+    Update(ctx context.Context, updateMerchantDto dto.UpdateMerchantDto) (dto.MerchantDto, error)
+    CreateAPIKey(ctx context.Context, createAPIKeyDto dto.CreateAPIKeyDto) (dto.APIKeyDto, error)
+    ListAPIKeys(ctx context.Context, userID int64) ([]dto.APIKeyDto, error)
+    RevokeAPIKey(ctx context.Context, userID int64, keyID int64) (dto.APIKeyDto, error)
+    Authenticate(ctx context.Context, key string) (domain.Merchant, domain.APIKey, error)
+}
+
+type merchantService struct {
+    merchantRepo store.MerchantRepo
+    apiKeyRepo   store.APIKeyRepo
+    walletRepo   store.WalletRepo
+}
+
+func NewMerchantService(merchantRepo store.MerchantRepo, apiKeyRepo store.APIKeyRepo, walletRepo store.WalletRepo) MerchantSvc {
+    return &merchantService{
+        merchantRepo: merchantRepo,
+        apiKeyRepo:   apiKeyRepo,
+        walletRepo:   walletRepo,
+    }
+}
+
+// Create makes the user a merchant, a user has at most one merchant profile.
+func (m *merchantService) Create(ctx context.Context, createMerchantDto dto.CreateMerchantDto) (dto.MerchantDto, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.Create")
+    defer span.End()
+
+    var res dto.MerchantDto
+
+    if err := validateCallbackURL(createMerchantDto.CallbackUrl); err != nil {
+        return res, err
+    }
+
+    wallet, err := m.getUserWallet(ctx, createMerchantDto.UserID, createMerchantDto.SettlementWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    merchant, err := m.merchantRepo.CreateMerchant(ctx, store.CreateMerchantParams{
+        UserID:             createMerchantDto.UserID,
+        DisplayName:        createMerchantDto.DisplayName,
+        Category:           createMerchantDto.Category,
+        SettlementWalletID: wallet.ID,
+        CallbackUrl:        createMerchantDto.CallbackUrl,
+    })
+    if err != nil {
+        if pqErr, ok := err.(*pq.Error); ok {
+            switch pqErr.Code.Name() {
+            case "unique_violation":
+                return res, errors.ErrMerchantAlreadyExist
+            }
+        }
+        return res, err
+    }
+
+    res = dto.NewMerchantDto(merchant)
+    return res, nil
+}
+
+func (m *merchantService) Get(ctx context.Context, userID int64) (dto.MerchantDto, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.Get")
+    defer span.End()
+
+    var res dto.MerchantDto
+
+    merchant, err := m.getMerchant(ctx, userID)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewMerchantDto(merchant)
+    return res, nil
+}
+
+func (m *merchantService) Update(ctx context.Context, updateMerchantDto dto.UpdateMerchantDto) (dto.MerchantDto, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.Update")
+    defer span.End()
+
+    var res dto.MerchantDto
+
+    merchant, err := m.getMerchant(ctx, updateMerchantDto.UserID)
+    if err != nil {
+        return res, err
+    }
+
+    if err := validateCallbackURL(updateMerchantDto.CallbackUrl); err != nil {
+        return res, err
+    }
+
+    wallet, err := m.getUserWallet(ctx, updateMerchantDto.UserID, updateMerchantDto.SettlementWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    merchant, err = m.merchantRepo.UpdateMerchant(ctx, store.UpdateMerchantParams{
+        ID:                 merchant.ID,
+        DisplayName:        updateMerchantDto.DisplayName,
+        Category:           updateMerchantDto.Category,
+        SettlementWalletID: wallet.ID,
+        CallbackUrl:        updateMerchantDto.CallbackUrl,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewMerchantDto(merchant)
+    return res, nil
+}
+
+// CreateAPIKey returns the key in clear only here, afterwards only its prefix is known.
+func (m *merchantService) CreateAPIKey(ctx context.Context, createAPIKeyDto dto.CreateAPIKeyDto) (dto.APIKeyDto, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.CreateAPIKey")
+    defer span.End()
+
+    var res dto.APIKeyDto
+
+    merchant, err := m.getMerchant(ctx, createAPIKeyDto.UserID)
+    if err != nil {
+        return res, err
+    }
+
+    key, prefix, err := util.GenerateAPIKey()
+    if err != nil {
+        return res, err
+    }
+
+    apiKey, err := m.apiKeyRepo.CreateAPIKey(ctx, store.CreateAPIKeyParams{
+        MerchantID: merchant.ID,
+        Name:       createAPIKeyDto.Name,
+        Prefix:     prefix,
+        HashedKey:  util.HashAPIKey(key),
+        Scopes:     createAPIKeyDto.Scopes,
+        Status:     domain.APIKeyStatusACTIVE,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewAPIKeyDto(apiKey)
+    res.Key = key
+    return res, nil
+}
+
+func (m *merchantService) ListAPIKeys(ctx context.Context, userID int64) ([]dto.APIKeyDto, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.ListAPIKeys")
+    defer span.End()
+
+    res := []dto.APIKeyDto{}
+
+    merchant, err := m.getMerchant(ctx, userID)
+    if err != nil {
+        return res, err
+    }
+
+    apiKeys, err := m.apiKeyRepo.ListAPIKeys(ctx, merchant.ID)
+    if err != nil {
+        return res, err
+    }
+
+    for _, k := range apiKeys {
+        res = append(res, dto.NewAPIKeyDto(k))
+    }
+
+    return res, nil
+}
+
+// RevokeAPIKey stops the key from authenticating right away, revoked keys can't be restored.
+func (m *merchantService) RevokeAPIKey(ctx context.Context, userID int64, keyID int64) (dto.APIKeyDto, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.RevokeAPIKey")
+    defer span.End()
+
+    var res dto.APIKeyDto
+
+    merchant, err := m.getMerchant(ctx, userID)
+    if err != nil {
+        return res, err
+    }
+
+    apiKey, err := m.apiKeyRepo.GetAPIKey(ctx, keyID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrAPIKeyNotFound
+        }
+        return res, err
+    }
+
+    if apiKey.MerchantID != merchant.ID {
+        return res, errors.ErrAPIKeyNotFound
+    }
+
+    apiKey, err = m.apiKeyRepo.UpdateAPIKeyStatus(ctx, store.UpdateAPIKeyStatusParams{
+        ID:     apiKey.ID,
+        Status: domain.APIKeyStatusREVOKED,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewAPIKeyDto(apiKey)
+    return res, nil
+}
+
+// Authenticate finds the active key matching the one sent by the merchant. Every failure is
+// reported as ErrInvalidAPIKey so that the caller can't tell revoked keys from unknown ones.
+func (m *merchantService) Authenticate(ctx context.Context, key string) (domain.Merchant, domain.APIKey, error) {
+    ctx, span := trace.Start(ctx, "MerchantSvc.Authenticate")
+    defer span.End()
+
+    var merchant domain.Merchant
+
+    prefix, ok := util.APIKeyPrefix(key)
+    if !ok {
+        return merchant, domain.APIKey{}, errors.ErrInvalidAPIKey
+    }
+
+    apiKey, err := m.apiKeyRepo.GetAPIKeyByPrefix(ctx, prefix)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return merchant, apiKey, errors.ErrInvalidAPIKey
+        }
+        return merchant, apiKey, err
+    }
+
+    if !util.CheckAPIKey(key, apiKey.HashedKey) || apiKey.Status != domain.APIKeyStatusACTIVE {
+        return merchant, apiKey, errors.ErrInvalidAPIKey
+    }
+
+    merchant, err = m.merchantRepo.GetMerchant(ctx, apiKey.MerchantID)
+    if err != nil {
+        return merchant, apiKey, err
+    }
+
+    return merchant, apiKey, nil
+}
+
+func (m *merchantService) getMerchant(ctx context.Context, userID int64) (domain.Merchant, error) {
+    merchant, err := m.merchantRepo.GetMerchantByUserID(ctx, userID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return merchant, errors.ErrMerchantNotFound
+        }
+        return merchant, err
+    }
+
+    return merchant, nil
+}
+
+// getUserWallet returns ErrWalletNotFound for the wallets of other users as well.
+func (m *merchantService) getUserWallet(ctx context.Context, userID int64, address string) (domain.Wallet, error) {
+    wallet, err := m.walletRepo.GetWalletByAddress(ctx, address)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return wallet, errors.ErrWalletNotFound
+        }
+        return wallet, err
+    }
+
+    if wallet.UserID != userID {
+        return wallet, errors.ErrWalletNotFound
+    }
+
+    return wallet, nil
+}
+
+// validateCallbackURL checks the optional callback url of a merchant, the checkout callbacks must not reach the
+// internal network.
+func validateCallbackURL(callbackURL string) error {
+    if callbackURL == "" {
+        return nil
+    }
+
+    if err := util.ValidateOutboundURL(callbackURL); err != nil {
+        return errors.ErrNonPublicURL
+    }
+
+    return nil
+}
@@ -0,0 +1,271 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/lib/pq"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestCreateMerchant(t *testing.T) {
+    wallet := domain.Wallet{ID: 1, UserID: 10, Address: "shop@my.wallet"}
+    createDto := dto.CreateMerchantDto{
+        UserID:                  wallet.UserID,
+        DisplayName:             "Walter's Shop",
+        Category:                "RETAIL",
+        SettlementWalletAddress: wallet.Address,
+        CallbackUrl:             "https://shop.example.com/callback",
+    }
+
+    testcases := []struct {
+        name      string
+        createDto dto.CreateMerchantDto
+        buildStub func(mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo)
+        checkResp func(t *testing.T, res dto.MerchantDto, err error)
+    }{
+        {
+            name:      "Ok",
+            createDto: createDto,
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                arg := store.CreateMerchantParams{
+                    UserID:             wallet.UserID,
+                    DisplayName:        createDto.DisplayName,
+                    Category:           createDto.Category,
+                    SettlementWalletID: wallet.ID,
+                    CallbackUrl:        createDto.CallbackUrl,
+                }
+
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), wallet.Address).Times(1).Return(wallet, nil)
+                mockMerchantRepo.EXPECT().CreateMerchant(gomock.Any(), arg).Times(1).
+                    Return(domain.Merchant{ID: 1, UserID: arg.UserID, DisplayName: arg.DisplayName, SettlementWalletID: arg.SettlementWalletID}, nil)
+            },
+            checkResp: func(t *testing.T, res dto.MerchantDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(1), res.ID)
+                require.Equal(t, wallet.ID, res.SettlementWalletID)
+            },
+        },
+        {
+            name:      "WalletOfOtherUser",
+            createDto: dto.CreateMerchantDto{UserID: 11, DisplayName: "Shop", Category: "RETAIL", SettlementWalletAddress: wallet.Address},
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), wallet.Address).Times(1).Return(wallet, nil)
+                mockMerchantRepo.EXPECT().CreateMerchant(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.MerchantDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name:      "AlreadyExist",
+            createDto: createDto,
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), wallet.Address).Times(1).Return(wallet, nil)
+                mockMerchantRepo.EXPECT().CreateMerchant(gomock.Any(), gomock.Any()).Times(1).Return(domain.Merchant{}, &pq.Error{Code: "23505"})
+            },
+            checkResp: func(t *testing.T, res dto.MerchantDto, err error) {
+                require.EqualError(t, err, errors.ErrMerchantAlreadyExist.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            tc.buildStub(mockMerchantRepo, mockWalletRepo)
+
+            merchantSvc := service.NewMerchantService(mockMerchantRepo, mockdb.NewMockAPIKeyRepo(ctrl), mockWalletRepo)
+            res, err := merchantSvc.Create(context.TODO(), tc.createDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestCreateAPIKey(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    merchant := domain.Merchant{ID: 1, UserID: 10}
+
+    mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+    mockAPIKeyRepo := mockdb.NewMockAPIKeyRepo(ctrl)
+
+    mockMerchantRepo.EXPECT().GetMerchantByUserID(gomock.Any(), merchant.UserID).Times(1).Return(merchant, nil)
+    mockAPIKeyRepo.EXPECT().CreateAPIKey(gomock.Any(), gomock.Any()).Times(1).
+        DoAndReturn(func(ctx context.Context, arg store.CreateAPIKeyParams) (domain.APIKey, error) {
+            require.Equal(t, merchant.ID, arg.MerchantID)
+            require.Equal(t, domain.APIKeyStatusACTIVE, arg.Status)
+            require.Equal(t, []string{"transfers:write"}, arg.Scopes)
+            require.Regexp(t, `^sk_[0-9a-f]{8}$`, arg.Prefix)
+            require.Len(t, arg.HashedKey, 64)
+
+            return domain.APIKey{ID: 1, MerchantID: arg.MerchantID, Prefix: arg.Prefix, HashedKey: arg.HashedKey, Scopes: arg.Scopes, Status: arg.Status}, nil
+        })
+
+    merchantSvc := service.NewMerchantService(mockMerchantRepo, mockAPIKeyRepo, mockdb.NewMockWalletRepo(ctrl))
+    res, err := merchantSvc.CreateAPIKey(context.TODO(), dto.CreateAPIKeyDto{UserID: merchant.UserID, Name: "backend", Scopes: []string{"transfers:write"}})
+    require.NoError(t, err)
+    require.NotEmpty(t, res.Key)
+
+    prefix, ok := util.APIKeyPrefix(res.Key)
+    require.True(t, ok)
+    require.Equal(t, res.Prefix, prefix)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+    merchant := domain.Merchant{ID: 1, UserID: 10}
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockAPIKeyRepo *mockdb.MockAPIKeyRepo)
+        checkResp func(t *testing.T, res dto.APIKeyDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                arg := store.UpdateAPIKeyStatusParams{ID: 5, Status: domain.APIKeyStatusREVOKED}
+                mockAPIKeyRepo.EXPECT().GetAPIKey(gomock.Any(), int64(5)).Times(1).Return(domain.APIKey{ID: 5, MerchantID: merchant.ID}, nil)
+                mockAPIKeyRepo.EXPECT().UpdateAPIKeyStatus(gomock.Any(), arg).Times(1).Return(domain.APIKey{ID: 5, MerchantID: merchant.ID, Status: domain.APIKeyStatusREVOKED}, nil)
+            },
+            checkResp: func(t *testing.T, res dto.APIKeyDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.APIKeyStatusREVOKED, res.Status)
+            },
+        },
+        {
+            name: "KeyOfOtherMerchant",
+            buildStub: func(mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKey(gomock.Any(), int64(5)).Times(1).Return(domain.APIKey{ID: 5, MerchantID: 2}, nil)
+                mockAPIKeyRepo.EXPECT().UpdateAPIKeyStatus(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.APIKeyDto, err error) {
+                require.EqualError(t, err, errors.ErrAPIKeyNotFound.Error())
+            },
+        },
+        {
+            name: "NotFound",
+            buildStub: func(mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKey(gomock.Any(), int64(5)).Times(1).Return(domain.APIKey{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, res dto.APIKeyDto, err error) {
+                require.EqualError(t, err, errors.ErrAPIKeyNotFound.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+            mockAPIKeyRepo := mockdb.NewMockAPIKeyRepo(ctrl)
+            mockMerchantRepo.EXPECT().GetMerchantByUserID(gomock.Any(), merchant.UserID).Times(1).Return(merchant, nil)
+            tc.buildStub(mockAPIKeyRepo)
+
+            merchantSvc := service.NewMerchantService(mockMerchantRepo, mockAPIKeyRepo, mockdb.NewMockWalletRepo(ctrl))
+            res, err := merchantSvc.RevokeAPIKey(context.TODO(), merchant.UserID, 5)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+    key, prefix, err := util.GenerateAPIKey()
+    require.NoError(t, err)
+
+    merchant := domain.Merchant{ID: 1, UserID: 10}
+    apiKey := domain.APIKey{ID: 5, MerchantID: merchant.ID, Prefix: prefix, HashedKey: util.HashAPIKey(key), Status: domain.APIKeyStatusACTIVE}
+    revokedKey := apiKey
+    revokedKey.Status = domain.APIKeyStatusREVOKED
+
+    testcases := []struct {
+        name      string
+        key       string
+        buildStub func(mockMerchantRepo *mockdb.MockMerchantRepo, mockAPIKeyRepo *mockdb.MockAPIKeyRepo)
+        checkResp func(t *testing.T, res domain.Merchant, err error)
+    }{
+        {
+            name: "Ok",
+            key:  key,
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), prefix).Times(1).Return(apiKey, nil)
+                mockMerchantRepo.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Times(1).Return(merchant, nil)
+            },
+            checkResp: func(t *testing.T, res domain.Merchant, err error) {
+                require.NoError(t, err)
+                require.Equal(t, merchant, res)
+            },
+        },
+        {
+            name: "Malformed",
+            key:  "sk_nope",
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res domain.Merchant, err error) {
+                require.EqualError(t, err, errors.ErrInvalidAPIKey.Error())
+            },
+        },
+        {
+            name: "WrongSecret",
+            key:  prefix + "_" + util.RandomString(48),
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), prefix).Times(1).Return(apiKey, nil)
+                mockMerchantRepo.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res domain.Merchant, err error) {
+                require.EqualError(t, err, errors.ErrInvalidAPIKey.Error())
+            },
+        },
+        {
+            name: "Revoked",
+            key:  key,
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), prefix).Times(1).Return(revokedKey, nil)
+                mockMerchantRepo.EXPECT().GetMerchant(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res domain.Merchant, err error) {
+                require.EqualError(t, err, errors.ErrInvalidAPIKey.Error())
+            },
+        },
+        {
+            name: "Unknown",
+            key:  key,
+            buildStub: func(mockMerchantRepo *mockdb.MockMerchantRepo, mockAPIKeyRepo *mockdb.MockAPIKeyRepo) {
+                mockAPIKeyRepo.EXPECT().GetAPIKeyByPrefix(gomock.Any(), prefix).Times(1).Return(domain.APIKey{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, res domain.Merchant, err error) {
+                require.EqualError(t, err, errors.ErrInvalidAPIKey.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+            mockAPIKeyRepo := mockdb.NewMockAPIKeyRepo(ctrl)
+            tc.buildStub(mockMerchantRepo, mockAPIKeyRepo)
+
+            merchantSvc := service.NewMerchantService(mockMerchantRepo, mockAPIKeyRepo, mockdb.NewMockWalletRepo(ctrl))
+            res, _, err := merchantSvc.Authenticate(context.TODO(), tc.key)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
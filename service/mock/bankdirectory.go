@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/bankdirectory.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockBankDirectorySvc is a mock of BankDirectorySvc interface.
+type MockBankDirectorySvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankDirectorySvcMockRecorder
+}
+
+// MockBankDirectorySvcMockRecorder is the mock recorder for MockBankDirectorySvc.
+type MockBankDirectorySvcMockRecorder struct {
+	mock *MockBankDirectorySvc
+}
+
+// NewMockBankDirectorySvc creates a new mock instance.
+func NewMockBankDirectorySvc(ctrl *gomock.Controller) *MockBankDirectorySvc {
+	mock := &MockBankDirectorySvc{ctrl: ctrl}
+	mock.recorder = &MockBankDirectorySvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankDirectorySvc) EXPECT() *MockBankDirectorySvcMockRecorder {
+	return m.recorder
+}
+
+// GetBankBranch mocks base method.
+func (m *MockBankDirectorySvc) GetBankBranch(ctx context.Context, ifsc string) (dto.BankBranchDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankBranch", ctx, ifsc)
+	ret0, _ := ret[0].(dto.BankBranchDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankBranch indicates an expected call of GetBankBranch.
+func (mr *MockBankDirectorySvcMockRecorder) GetBankBranch(ctx, ifsc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankBranch", reflect.TypeOf((*MockBankDirectorySvc)(nil).GetBankBranch), ctx, ifsc)
+}
+
+// ImportBankBranches mocks base method.
+func (m *MockBankDirectorySvc) ImportBankBranches(ctx context.Context, r io.Reader) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBankBranches", ctx, r)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBankBranches indicates an expected call of ImportBankBranches.
+func (mr *MockBankDirectorySvcMockRecorder) ImportBankBranches(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBankBranches", reflect.TypeOf((*MockBankDirectorySvc)(nil).ImportBankBranches), ctx, r)
+}
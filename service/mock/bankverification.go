@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/bankverification.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBankVerificationSvc is a mock of BankVerificationSvc interface.
+type MockBankVerificationSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankVerificationSvcMockRecorder
+}
+
+// MockBankVerificationSvcMockRecorder is the mock recorder for MockBankVerificationSvc.
+type MockBankVerificationSvcMockRecorder struct {
+	mock *MockBankVerificationSvc
+}
+
+// NewMockBankVerificationSvc creates a new mock instance.
+func NewMockBankVerificationSvc(ctrl *gomock.Controller) *MockBankVerificationSvc {
+	mock := &MockBankVerificationSvc{ctrl: ctrl}
+	mock.recorder = &MockBankVerificationSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankVerificationSvc) EXPECT() *MockBankVerificationSvcMockRecorder {
+	return m.recorder
+}
+
+// ProcessPendingVerifications mocks base method.
+func (m *MockBankVerificationSvc) ProcessPendingVerifications(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingVerifications", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessPendingVerifications indicates an expected call of ProcessPendingVerifications.
+func (mr *MockBankVerificationSvcMockRecorder) ProcessPendingVerifications(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingVerifications", reflect.TypeOf((*MockBankVerificationSvc)(nil).ProcessPendingVerifications), ctx)
+}
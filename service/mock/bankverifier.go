@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/bankverifier.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	service "github.com/pranayhere/simple-wallet/service"
+)
+
+// MockBankVerifier is a mock of BankVerifier interface.
+type MockBankVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankVerifierMockRecorder
+}
+
+// MockBankVerifierMockRecorder is the mock recorder for MockBankVerifier.
+type MockBankVerifierMockRecorder struct {
+	mock *MockBankVerifier
+}
+
+// NewMockBankVerifier creates a new mock instance.
+func NewMockBankVerifier(ctrl *gomock.Controller) *MockBankVerifier {
+	mock := &MockBankVerifier{ctrl: ctrl}
+	mock.recorder = &MockBankVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankVerifier) EXPECT() *MockBankVerifierMockRecorder {
+	return m.recorder
+}
+
+// GetPennyDropStatus mocks base method.
+func (m *MockBankVerifier) GetPennyDropStatus(ctx context.Context, referenceID string) (service.PennyDropResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPennyDropStatus", ctx, referenceID)
+	ret0, _ := ret[0].(service.PennyDropResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPennyDropStatus indicates an expected call of GetPennyDropStatus.
+func (mr *MockBankVerifierMockRecorder) GetPennyDropStatus(ctx, referenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPennyDropStatus", reflect.TypeOf((*MockBankVerifier)(nil).GetPennyDropStatus), ctx, referenceID)
+}
+
+// InitiatePennyDrop mocks base method.
+func (m *MockBankVerifier) InitiatePennyDrop(ctx context.Context, req service.PennyDropRequest) (service.PennyDropResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitiatePennyDrop", ctx, req)
+	ret0, _ := ret[0].(service.PennyDropResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InitiatePennyDrop indicates an expected call of InitiatePennyDrop.
+func (mr *MockBankVerifierMockRecorder) InitiatePennyDrop(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitiatePennyDrop", reflect.TypeOf((*MockBankVerifier)(nil).InitiatePennyDrop), ctx, req)
+}
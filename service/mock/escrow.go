@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/escrow.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockEscrowSvc is a mock of EscrowSvc interface.
+type MockEscrowSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscrowSvcMockRecorder
+}
+
+// MockEscrowSvcMockRecorder is the mock recorder for MockEscrowSvc.
+type MockEscrowSvcMockRecorder struct {
+	mock *MockEscrowSvc
+}
+
+// NewMockEscrowSvc creates a new mock instance.
+func NewMockEscrowSvc(ctrl *gomock.Controller) *MockEscrowSvc {
+	mock := &MockEscrowSvc{ctrl: ctrl}
+	mock.recorder = &MockEscrowSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscrowSvc) EXPECT() *MockEscrowSvcMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockEscrowSvc) Create(ctx context.Context, createEscrowDto dto.CreateEscrowDto) (dto.EscrowTransferResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, createEscrowDto)
+	ret0, _ := ret[0].(dto.EscrowTransferResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEscrowSvcMockRecorder) Create(ctx, createEscrowDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEscrowSvc)(nil).Create), ctx, createEscrowDto)
+}
+
+// Dispute mocks base method.
+func (m *MockEscrowSvc) Dispute(ctx context.Context, disputeEscrowDto dto.DisputeEscrowDto) (dto.EscrowDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispute", ctx, disputeEscrowDto)
+	ret0, _ := ret[0].(dto.EscrowDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Dispute indicates an expected call of Dispute.
+func (mr *MockEscrowSvcMockRecorder) Dispute(ctx, disputeEscrowDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispute", reflect.TypeOf((*MockEscrowSvc)(nil).Dispute), ctx, disputeEscrowDto)
+}
+
+// Get mocks base method.
+func (m *MockEscrowSvc) Get(ctx context.Context, userID, id int64) (dto.EscrowDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID, id)
+	ret0, _ := ret[0].(dto.EscrowDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockEscrowSvcMockRecorder) Get(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockEscrowSvc)(nil).Get), ctx, userID, id)
+}
+
+// List mocks base method.
+func (m *MockEscrowSvc) List(ctx context.Context, listEscrowsDto dto.ListEscrowsDto) ([]dto.EscrowDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, listEscrowsDto)
+	ret0, _ := ret[0].([]dto.EscrowDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEscrowSvcMockRecorder) List(ctx, listEscrowsDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEscrowSvc)(nil).List), ctx, listEscrowsDto)
+}
+
+// Release mocks base method.
+func (m *MockEscrowSvc) Release(ctx context.Context, userID, id int64) (dto.EscrowTransferResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, userID, id)
+	ret0, _ := ret[0].(dto.EscrowTransferResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockEscrowSvcMockRecorder) Release(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockEscrowSvc)(nil).Release), ctx, userID, id)
+}
+
+// ReleaseDue mocks base method.
+func (m *MockEscrowSvc) ReleaseDue(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDue", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDue indicates an expected call of ReleaseDue.
+func (mr *MockEscrowSvcMockRecorder) ReleaseDue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDue", reflect.TypeOf((*MockEscrowSvc)(nil).ReleaseDue), ctx)
+}
+
+// Resolve mocks base method.
+func (m *MockEscrowSvc) Resolve(ctx context.Context, resolveEscrowDto dto.ResolveEscrowDto) (dto.EscrowTransferResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, resolveEscrowDto)
+	ret0, _ := ret[0].(dto.EscrowTransferResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockEscrowSvcMockRecorder) Resolve(ctx, resolveEscrowDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockEscrowSvc)(nil).Resolve), ctx, resolveEscrowDto)
+}
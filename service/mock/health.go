@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/health.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockHealthSvc is a mock of HealthSvc interface.
+type MockHealthSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthSvcMockRecorder
+}
+
+// MockHealthSvcMockRecorder is the mock recorder for MockHealthSvc.
+type MockHealthSvcMockRecorder struct {
+	mock *MockHealthSvc
+}
+
+// NewMockHealthSvc creates a new mock instance.
+func NewMockHealthSvc(ctrl *gomock.Controller) *MockHealthSvc {
+	mock := &MockHealthSvc{ctrl: ctrl}
+	mock.recorder = &MockHealthSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthSvc) EXPECT() *MockHealthSvcMockRecorder {
+	return m.recorder
+}
+
+// Drain mocks base method.
+func (m *MockHealthSvc) Drain() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Drain")
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockHealthSvcMockRecorder) Drain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockHealthSvc)(nil).Drain))
+}
+
+// Live mocks base method.
+func (m *MockHealthSvc) Live(ctx context.Context) dto.LivenessDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Live", ctx)
+	ret0, _ := ret[0].(dto.LivenessDto)
+	return ret0
+}
+
+// Live indicates an expected call of Live.
+func (mr *MockHealthSvcMockRecorder) Live(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Live", reflect.TypeOf((*MockHealthSvc)(nil).Live), ctx)
+}
+
+// Ready mocks base method.
+func (m *MockHealthSvc) Ready(ctx context.Context) dto.ReadinessDto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(dto.ReadinessDto)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockHealthSvcMockRecorder) Ready(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockHealthSvc)(nil).Ready), ctx)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/hold.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockHoldSvc is a mock of HoldSvc interface.
+type MockHoldSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldSvcMockRecorder
+}
+
+// MockHoldSvcMockRecorder is the mock recorder for MockHoldSvc.
+type MockHoldSvcMockRecorder struct {
+	mock *MockHoldSvc
+}
+
+// NewMockHoldSvc creates a new mock instance.
+func NewMockHoldSvc(ctrl *gomock.Controller) *MockHoldSvc {
+	mock := &MockHoldSvc{ctrl: ctrl}
+	mock.recorder = &MockHoldSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldSvc) EXPECT() *MockHoldSvcMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockHoldSvc) Authorize(ctx context.Context, authorizeHoldDto dto.AuthorizeHoldDto) (dto.HoldDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, authorizeHoldDto)
+	ret0, _ := ret[0].(dto.HoldDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockHoldSvcMockRecorder) Authorize(ctx, authorizeHoldDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockHoldSvc)(nil).Authorize), ctx, authorizeHoldDto)
+}
+
+// Capture mocks base method.
+func (m *MockHoldSvc) Capture(ctx context.Context, captureHoldDto dto.CaptureHoldDto) (dto.CaptureHoldResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, captureHoldDto)
+	ret0, _ := ret[0].(dto.CaptureHoldResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockHoldSvcMockRecorder) Capture(ctx, captureHoldDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockHoldSvc)(nil).Capture), ctx, captureHoldDto)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockHoldSvc) ExpireHolds(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockHoldSvcMockRecorder) ExpireHolds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockHoldSvc)(nil).ExpireHolds), ctx)
+}
+
+// Get mocks base method.
+func (m *MockHoldSvc) Get(ctx context.Context, userID, id int64) (dto.HoldDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID, id)
+	ret0, _ := ret[0].(dto.HoldDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHoldSvcMockRecorder) Get(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHoldSvc)(nil).Get), ctx, userID, id)
+}
+
+// List mocks base method.
+func (m *MockHoldSvc) List(ctx context.Context, listHoldsDto dto.ListHoldsDto) ([]dto.HoldDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, listHoldsDto)
+	ret0, _ := ret[0].([]dto.HoldDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHoldSvcMockRecorder) List(ctx, listHoldsDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHoldSvc)(nil).List), ctx, listHoldsDto)
+}
+
+// Void mocks base method.
+func (m *MockHoldSvc) Void(ctx context.Context, userID, id int64) (dto.HoldDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", ctx, userID, id)
+	ret0, _ := ret[0].(dto.HoldDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockHoldSvcMockRecorder) Void(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockHoldSvc)(nil).Void), ctx, userID, id)
+}
@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/merchant.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockMerchantSvc is a mock of MerchantSvc interface.
+type MockMerchantSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantSvcMockRecorder
+}
+
+// MockMerchantSvcMockRecorder is the mock recorder for MockMerchantSvc.
+type MockMerchantSvcMockRecorder struct {
+	mock *MockMerchantSvc
+}
+
+// NewMockMerchantSvc creates a new mock instance.
+func NewMockMerchantSvc(ctrl *gomock.Controller) *MockMerchantSvc {
+	mock := &MockMerchantSvc{ctrl: ctrl}
+	mock.recorder = &MockMerchantSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantSvc) EXPECT() *MockMerchantSvcMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockMerchantSvc) Authenticate(ctx context.Context, key string) (domain.Merchant, domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, key)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(domain.APIKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockMerchantSvcMockRecorder) Authenticate(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockMerchantSvc)(nil).Authenticate), ctx, key)
+}
+
+// Create mocks base method.
+func (m *MockMerchantSvc) Create(ctx context.Context, createMerchantDto dto.CreateMerchantDto) (dto.MerchantDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, createMerchantDto)
+	ret0, _ := ret[0].(dto.MerchantDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMerchantSvcMockRecorder) Create(ctx, createMerchantDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMerchantSvc)(nil).Create), ctx, createMerchantDto)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockMerchantSvc) CreateAPIKey(ctx context.Context, createAPIKeyDto dto.CreateAPIKeyDto) (dto.APIKeyDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, createAPIKeyDto)
+	ret0, _ := ret[0].(dto.APIKeyDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockMerchantSvcMockRecorder) CreateAPIKey(ctx, createAPIKeyDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockMerchantSvc)(nil).CreateAPIKey), ctx, createAPIKeyDto)
+}
+
+// Get mocks base method.
+func (m *MockMerchantSvc) Get(ctx context.Context, userID int64) (dto.MerchantDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, userID)
+	ret0, _ := ret[0].(dto.MerchantDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMerchantSvcMockRecorder) Get(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMerchantSvc)(nil).Get), ctx, userID)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockMerchantSvc) ListAPIKeys(ctx context.Context, userID int64) ([]dto.APIKeyDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, userID)
+	ret0, _ := ret[0].([]dto.APIKeyDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockMerchantSvcMockRecorder) ListAPIKeys(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockMerchantSvc)(nil).ListAPIKeys), ctx, userID)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockMerchantSvc) RevokeAPIKey(ctx context.Context, userID, keyID int64) (dto.APIKeyDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, userID, keyID)
+	ret0, _ := ret[0].(dto.APIKeyDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockMerchantSvcMockRecorder) RevokeAPIKey(ctx, userID, keyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockMerchantSvc)(nil).RevokeAPIKey), ctx, userID, keyID)
+}
+
+// Update mocks base method.
+func (m *MockMerchantSvc) Update(ctx context.Context, updateMerchantDto dto.UpdateMerchantDto) (dto.MerchantDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, updateMerchantDto)
+	ret0, _ := ret[0].(dto.MerchantDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMerchantSvcMockRecorder) Update(ctx, updateMerchantDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMerchantSvc)(nil).Update), ctx, updateMerchantDto)
+}
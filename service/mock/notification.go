@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/notification.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	dto "github.com/pranayhere/simple-wallet/dto"
+	service "github.com/pranayhere/simple-wallet/service"
+)
+
+// MockNotificationSvc is a mock of NotificationSvc interface.
+type MockNotificationSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationSvcMockRecorder
+}
+
+// MockNotificationSvcMockRecorder is the mock recorder for MockNotificationSvc.
+type MockNotificationSvcMockRecorder struct {
+	mock *MockNotificationSvc
+}
+
+// NewMockNotificationSvc creates a new mock instance.
+func NewMockNotificationSvc(ctrl *gomock.Controller) *MockNotificationSvc {
+	mock := &MockNotificationSvc{ctrl: ctrl}
+	mock.recorder = &MockNotificationSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationSvc) EXPECT() *MockNotificationSvcMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationSvc) GetPreferences(ctx context.Context, userID int64) ([]dto.NotificationPreferenceDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].([]dto.NotificationPreferenceDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationSvcMockRecorder) GetPreferences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationSvc)(nil).GetPreferences), ctx, userID)
+}
+
+// HandleEvent mocks base method.
+func (m *MockNotificationSvc) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockNotificationSvcMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockNotificationSvc)(nil).HandleEvent), ctx, event)
+}
+
+// List mocks base method.
+func (m *MockNotificationSvc) List(ctx context.Context, listNotificationsDto dto.ListNotificationsDto) ([]dto.NotificationDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, listNotificationsDto)
+	ret0, _ := ret[0].([]dto.NotificationDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationSvcMockRecorder) List(ctx, listNotificationsDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationSvc)(nil).List), ctx, listNotificationsDto)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationSvc) MarkRead(ctx context.Context, userID, id int64, read bool) (dto.NotificationDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, userID, id, read)
+	ret0, _ := ret[0].(dto.NotificationDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationSvcMockRecorder) MarkRead(ctx, userID, id, read interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationSvc)(nil).MarkRead), ctx, userID, id, read)
+}
+
+// Notify mocks base method.
+func (m *MockNotificationSvc) Notify(ctx context.Context, userID int64, eventType domain.EventType, data service.NotificationData) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, userID, eventType, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationSvcMockRecorder) Notify(ctx, userID, eventType, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationSvc)(nil).Notify), ctx, userID, eventType, data)
+}
+
+// UpdatePreference mocks base method.
+func (m *MockNotificationSvc) UpdatePreference(ctx context.Context, preferenceDto dto.NotificationPreferenceDto) (dto.NotificationPreferenceDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreference", ctx, preferenceDto)
+	ret0, _ := ret[0].(dto.NotificationPreferenceDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreference indicates an expected call of UpdatePreference.
+func (mr *MockNotificationSvcMockRecorder) UpdatePreference(ctx, preferenceDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreference", reflect.TypeOf((*MockNotificationSvc)(nil).UpdatePreference), ctx, preferenceDto)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/organization.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockOrganizationSvc is a mock of OrganizationSvc interface.
+type MockOrganizationSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationSvcMockRecorder
+}
+
+// MockOrganizationSvcMockRecorder is the mock recorder for MockOrganizationSvc.
+type MockOrganizationSvcMockRecorder struct {
+	mock *MockOrganizationSvc
+}
+
+// NewMockOrganizationSvc creates a new mock instance.
+func NewMockOrganizationSvc(ctrl *gomock.Controller) *MockOrganizationSvc {
+	mock := &MockOrganizationSvc{ctrl: ctrl}
+	mock.recorder = &MockOrganizationSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationSvc) EXPECT() *MockOrganizationSvcMockRecorder {
+	return m.recorder
+}
+
+// CreateCurrency mocks base method.
+func (m *MockOrganizationSvc) CreateCurrency(ctx context.Context, createCurrencyDto dto.CreateOrganizationCurrencyDto) (dto.OrganizationCurrencyDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCurrency", ctx, createCurrencyDto)
+	ret0, _ := ret[0].(dto.OrganizationCurrencyDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCurrency indicates an expected call of CreateCurrency.
+func (mr *MockOrganizationSvcMockRecorder) CreateCurrency(ctx, createCurrencyDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCurrency", reflect.TypeOf((*MockOrganizationSvc)(nil).CreateCurrency), ctx, createCurrencyDto)
+}
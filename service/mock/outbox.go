@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/outbox.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockOutboxRelaySvc is a mock of OutboxRelaySvc interface.
+type MockOutboxRelaySvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRelaySvcMockRecorder
+}
+
+// MockOutboxRelaySvcMockRecorder is the mock recorder for MockOutboxRelaySvc.
+type MockOutboxRelaySvcMockRecorder struct {
+	mock *MockOutboxRelaySvc
+}
+
+// NewMockOutboxRelaySvc creates a new mock instance.
+func NewMockOutboxRelaySvc(ctrl *gomock.Controller) *MockOutboxRelaySvc {
+	mock := &MockOutboxRelaySvc{ctrl: ctrl}
+	mock.recorder = &MockOutboxRelaySvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRelaySvc) EXPECT() *MockOutboxRelaySvcMockRecorder {
+	return m.recorder
+}
+
+// ListDead mocks base method.
+func (m *MockOutboxRelaySvc) ListDead(ctx context.Context, listDeadDto dto.ListDeadOutboxEventsDto) ([]dto.OutboxEventDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDead", ctx, listDeadDto)
+	ret0, _ := ret[0].([]dto.OutboxEventDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDead indicates an expected call of ListDead.
+func (mr *MockOutboxRelaySvcMockRecorder) ListDead(ctx, listDeadDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDead", reflect.TypeOf((*MockOutboxRelaySvc)(nil).ListDead), ctx, listDeadDto)
+}
+
+// RelayPending mocks base method.
+func (m *MockOutboxRelaySvc) RelayPending(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayPending", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RelayPending indicates an expected call of RelayPending.
+func (mr *MockOutboxRelaySvcMockRecorder) RelayPending(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayPending", reflect.TypeOf((*MockOutboxRelaySvc)(nil).RelayPending), ctx)
+}
+
+// RetryDead mocks base method.
+func (m *MockOutboxRelaySvc) RetryDead(ctx context.Context, id int64) (dto.OutboxEventDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDead", ctx, id)
+	ret0, _ := ret[0].(dto.OutboxEventDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryDead indicates an expected call of RetryDead.
+func (mr *MockOutboxRelaySvcMockRecorder) RetryDead(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDead", reflect.TypeOf((*MockOutboxRelaySvc)(nil).RetryDead), ctx, id)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/password.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockPasswordSvc is a mock of PasswordSvc interface.
+type MockPasswordSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordSvcMockRecorder
+}
+
+// MockPasswordSvcMockRecorder is the mock recorder for MockPasswordSvc.
+type MockPasswordSvcMockRecorder struct {
+	mock *MockPasswordSvc
+}
+
+// NewMockPasswordSvc creates a new mock instance.
+func NewMockPasswordSvc(ctrl *gomock.Controller) *MockPasswordSvc {
+	mock := &MockPasswordSvc{ctrl: ctrl}
+	mock.recorder = &MockPasswordSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordSvc) EXPECT() *MockPasswordSvcMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPasswordSvc) ChangePassword(ctx context.Context, changePasswordDto dto.ChangePasswordDto) (dto.LoggedInUserDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, changePasswordDto)
+	ret0, _ := ret[0].(dto.LoggedInUserDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPasswordSvcMockRecorder) ChangePassword(ctx, changePasswordDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPasswordSvc)(nil).ChangePassword), ctx, changePasswordDto)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockPasswordSvc) ForgotPassword(ctx context.Context, forgotPasswordDto dto.ForgotPasswordDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, forgotPasswordDto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockPasswordSvcMockRecorder) ForgotPassword(ctx, forgotPasswordDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockPasswordSvc)(nil).ForgotPassword), ctx, forgotPasswordDto)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordSvc) ResetPassword(ctx context.Context, resetPasswordDto dto.ResetPasswordDto) (dto.UserDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, resetPasswordDto)
+	ret0, _ := ret[0].(dto.UserDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordSvcMockRecorder) ResetPassword(ctx, resetPasswordDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordSvc)(nil).ResetPassword), ctx, resetPasswordDto)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/paymentlink.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockPaymentLinkSvc is a mock of PaymentLinkSvc interface.
+type MockPaymentLinkSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentLinkSvcMockRecorder
+}
+
+// MockPaymentLinkSvcMockRecorder is the mock recorder for MockPaymentLinkSvc.
+type MockPaymentLinkSvcMockRecorder struct {
+	mock *MockPaymentLinkSvc
+}
+
+// NewMockPaymentLinkSvc creates a new mock instance.
+func NewMockPaymentLinkSvc(ctrl *gomock.Controller) *MockPaymentLinkSvc {
+	mock := &MockPaymentLinkSvc{ctrl: ctrl}
+	mock.recorder = &MockPaymentLinkSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentLinkSvc) EXPECT() *MockPaymentLinkSvcMockRecorder {
+	return m.recorder
+}
+
+// CompleteCheckout mocks base method.
+func (m *MockPaymentLinkSvc) CompleteCheckout(ctx context.Context, completeCheckoutDto dto.CompleteCheckoutDto) (dto.CheckoutResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteCheckout", ctx, completeCheckoutDto)
+	ret0, _ := ret[0].(dto.CheckoutResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteCheckout indicates an expected call of CompleteCheckout.
+func (mr *MockPaymentLinkSvcMockRecorder) CompleteCheckout(ctx, completeCheckoutDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCheckout", reflect.TypeOf((*MockPaymentLinkSvc)(nil).CompleteCheckout), ctx, completeCheckoutDto)
+}
+
+// Create mocks base method.
+func (m *MockPaymentLinkSvc) Create(ctx context.Context, createPaymentLinkDto dto.CreatePaymentLinkDto) (dto.PaymentLinkDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, createPaymentLinkDto)
+	ret0, _ := ret[0].(dto.PaymentLinkDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentLinkSvcMockRecorder) Create(ctx, createPaymentLinkDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentLinkSvc)(nil).Create), ctx, createPaymentLinkDto)
+}
+
+// Disable mocks base method.
+func (m *MockPaymentLinkSvc) Disable(ctx context.Context, userID, id int64) (dto.PaymentLinkDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, userID, id)
+	ret0, _ := ret[0].(dto.PaymentLinkDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockPaymentLinkSvcMockRecorder) Disable(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockPaymentLinkSvc)(nil).Disable), ctx, userID, id)
+}
+
+// GetPage mocks base method.
+func (m *MockPaymentLinkSvc) GetPage(ctx context.Context, slug string) (dto.PaymentLinkPageDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPage", ctx, slug)
+	ret0, _ := ret[0].(dto.PaymentLinkPageDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPage indicates an expected call of GetPage.
+func (mr *MockPaymentLinkSvcMockRecorder) GetPage(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPage", reflect.TypeOf((*MockPaymentLinkSvc)(nil).GetPage), ctx, slug)
+}
+
+// List mocks base method.
+func (m *MockPaymentLinkSvc) List(ctx context.Context, listPaymentLinksDto dto.ListPaymentLinksDto) ([]dto.PaymentLinkDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, listPaymentLinksDto)
+	ret0, _ := ret[0].([]dto.PaymentLinkDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPaymentLinkSvcMockRecorder) List(ctx, listPaymentLinksDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPaymentLinkSvc)(nil).List), ctx, listPaymentLinksDto)
+}
+
+// StartCheckout mocks base method.
+func (m *MockPaymentLinkSvc) StartCheckout(ctx context.Context, startCheckoutDto dto.StartCheckoutDto) (dto.CheckoutSessionDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCheckout", ctx, startCheckoutDto)
+	ret0, _ := ret[0].(dto.CheckoutSessionDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCheckout indicates an expected call of StartCheckout.
+func (mr *MockPaymentLinkSvcMockRecorder) StartCheckout(ctx, startCheckoutDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCheckout", reflect.TypeOf((*MockPaymentLinkSvc)(nil).StartCheckout), ctx, startCheckoutDto)
+}
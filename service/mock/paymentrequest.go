@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/paymentrequest.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockPaymentRequestSvc is a mock of PaymentRequestSvc interface.
+type MockPaymentRequestSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRequestSvcMockRecorder
+}
+
+// MockPaymentRequestSvcMockRecorder is the mock recorder for MockPaymentRequestSvc.
+type MockPaymentRequestSvcMockRecorder struct {
+	mock *MockPaymentRequestSvc
+}
+
+// NewMockPaymentRequestSvc creates a new mock instance.
+func NewMockPaymentRequestSvc(ctrl *gomock.Controller) *MockPaymentRequestSvc {
+	mock := &MockPaymentRequestSvc{ctrl: ctrl}
+	mock.recorder = &MockPaymentRequestSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRequestSvc) EXPECT() *MockPaymentRequestSvcMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockPaymentRequestSvc) Approve(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.PaymentRequestDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, approveDto)
+	ret0, _ := ret[0].(dto.PaymentRequestDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockPaymentRequestSvcMockRecorder) Approve(ctx, approveDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockPaymentRequestSvc)(nil).Approve), ctx, approveDto)
+}
+
+// Create mocks base method.
+func (m *MockPaymentRequestSvc) Create(ctx context.Context, patReqDto dto.PaymentRequestDto) (dto.PaymentRequestDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, patReqDto)
+	ret0, _ := ret[0].(dto.PaymentRequestDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRequestSvcMockRecorder) Create(ctx, patReqDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRequestSvc)(nil).Create), ctx, patReqDto)
+}
+
+// Get mocks base method.
+func (m *MockPaymentRequestSvc) Get(ctx context.Context, id int64) (dto.PaymentRequestDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(dto.PaymentRequestDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPaymentRequestSvcMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPaymentRequestSvc)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockPaymentRequestSvc) List(ctx context.Context, patReqDto dto.ListPaymentRequestsDto) ([]dto.PaymentRequestDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, patReqDto)
+	ret0, _ := ret[0].([]dto.PaymentRequestDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPaymentRequestSvcMockRecorder) List(ctx, patReqDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPaymentRequestSvc)(nil).List), ctx, patReqDto)
+}
+
+// Pay mocks base method.
+func (m *MockPaymentRequestSvc) Pay(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.WalletTransferResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, approveDto)
+	ret0, _ := ret[0].(dto.WalletTransferResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockPaymentRequestSvcMockRecorder) Pay(ctx, approveDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockPaymentRequestSvc)(nil).Pay), ctx, approveDto)
+}
+
+// Refuse mocks base method.
+func (m *MockPaymentRequestSvc) Refuse(ctx context.Context, id int64) (dto.PaymentRequestDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refuse", ctx, id)
+	ret0, _ := ret[0].(dto.PaymentRequestDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refuse indicates an expected call of Refuse.
+func (mr *MockPaymentRequestSvcMockRecorder) Refuse(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refuse", reflect.TypeOf((*MockPaymentRequestSvc)(nil).Refuse), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPaymentRequestSvc) UpdateStatus(ctx context.Context, id int64, status domain.PaymentRequestStatus) (dto.PaymentRequestDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status)
+	ret0, _ := ret[0].(dto.PaymentRequestDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPaymentRequestSvcMockRecorder) UpdateStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentRequestSvc)(nil).UpdateStatus), ctx, id, status)
+}
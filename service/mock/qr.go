@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/qr.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockQRSvc is a mock of QRSvc interface.
+type MockQRSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockQRSvcMockRecorder
+}
+
+// MockQRSvcMockRecorder is the mock recorder for MockQRSvc.
+type MockQRSvcMockRecorder struct {
+	mock *MockQRSvc
+}
+
+// NewMockQRSvc creates a new mock instance.
+func NewMockQRSvc(ctrl *gomock.Controller) *MockQRSvc {
+	mock := &MockQRSvc{ctrl: ctrl}
+	mock.recorder = &MockQRSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQRSvc) EXPECT() *MockQRSvcMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockQRSvc) Parse(ctx context.Context, parseQRDto dto.ParseQRDto) (dto.QRPayloadDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", ctx, parseQRDto)
+	ret0, _ := ret[0].(dto.QRPayloadDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockQRSvcMockRecorder) Parse(ctx, parseQRDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockQRSvc)(nil).Parse), ctx, parseQRDto)
+}
+
+// Pay mocks base method.
+func (m *MockQRSvc) Pay(ctx context.Context, qrPayDto dto.QRPayDto) (dto.QRPayResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", ctx, qrPayDto)
+	ret0, _ := ret[0].(dto.QRPayResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockQRSvcMockRecorder) Pay(ctx, qrPayDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*MockQRSvc)(nil).Pay), ctx, qrPayDto)
+}
+
+// PaymentRequestQR mocks base method.
+func (m *MockQRSvc) PaymentRequestQR(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaymentRequestQR", ctx, getQRDto, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PaymentRequestQR indicates an expected call of PaymentRequestQR.
+func (mr *MockQRSvcMockRecorder) PaymentRequestQR(ctx, getQRDto, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaymentRequestQR", reflect.TypeOf((*MockQRSvc)(nil).PaymentRequestQR), ctx, getQRDto, w)
+}
+
+// WalletQR mocks base method.
+func (m *MockQRSvc) WalletQR(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletQR", ctx, getQRDto, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalletQR indicates an expected call of WalletQR.
+func (mr *MockQRSvcMockRecorder) WalletQR(ctx, getQRDto, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletQR", reflect.TypeOf((*MockQRSvc)(nil).WalletQR), ctx, getQRDto, w)
+}
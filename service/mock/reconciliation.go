@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/reconciliation.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockReconciliationSvc is a mock of ReconciliationSvc interface.
+type MockReconciliationSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationSvcMockRecorder
+}
+
+// MockReconciliationSvcMockRecorder is the mock recorder for MockReconciliationSvc.
+type MockReconciliationSvcMockRecorder struct {
+	mock *MockReconciliationSvc
+}
+
+// NewMockReconciliationSvc creates a new mock instance.
+func NewMockReconciliationSvc(ctrl *gomock.Controller) *MockReconciliationSvc {
+	mock := &MockReconciliationSvc{ctrl: ctrl}
+	mock.recorder = &MockReconciliationSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationSvc) EXPECT() *MockReconciliationSvcMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockReconciliationSvc) Get(ctx context.Context, id int64) (dto.ReconciliationReportDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(dto.ReconciliationReportDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockReconciliationSvcMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockReconciliationSvc)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockReconciliationSvc) List(ctx context.Context, listReportsDto dto.ListReconciliationReportsDto) ([]dto.ReconciliationReportDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, listReportsDto)
+	ret0, _ := ret[0].([]dto.ReconciliationReportDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockReconciliationSvcMockRecorder) List(ctx, listReportsDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReconciliationSvc)(nil).List), ctx, listReportsDto)
+}
+
+// Run mocks base method.
+func (m *MockReconciliationSvc) Run(ctx context.Context) (dto.ReconciliationReportDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(dto.ReconciliationReportDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockReconciliationSvcMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockReconciliationSvc)(nil).Run), ctx)
+}
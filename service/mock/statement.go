@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/statement.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockStatementSvc is a mock of StatementSvc interface.
+type MockStatementSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementSvcMockRecorder
+}
+
+// MockStatementSvcMockRecorder is the mock recorder for MockStatementSvc.
+type MockStatementSvcMockRecorder struct {
+	mock *MockStatementSvc
+}
+
+// NewMockStatementSvc creates a new mock instance.
+func NewMockStatementSvc(ctrl *gomock.Controller) *MockStatementSvc {
+	mock := &MockStatementSvc{ctrl: ctrl}
+	mock.recorder = &MockStatementSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementSvc) EXPECT() *MockStatementSvcMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockStatementSvc) Export(ctx context.Context, getStatementDto dto.GetStatementDto, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, getStatementDto, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockStatementSvcMockRecorder) Export(ctx, getStatementDto, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockStatementSvc)(nil).Export), ctx, getStatementDto, w)
+}
+
+// GetStatement mocks base method.
+func (m *MockStatementSvc) GetStatement(ctx context.Context, getStatementDto dto.GetStatementDto) (dto.StatementDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatement", ctx, getStatementDto)
+	ret0, _ := ret[0].(dto.StatementDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatement indicates an expected call of GetStatement.
+func (mr *MockStatementSvcMockRecorder) GetStatement(ctx, getStatementDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatement", reflect.TypeOf((*MockStatementSvc)(nil).GetStatement), ctx, getStatementDto)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/transfer.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockTransferSvc is a mock of TransferSvc interface.
+type MockTransferSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransferSvcMockRecorder
+}
+
+// MockTransferSvcMockRecorder is the mock recorder for MockTransferSvc.
+type MockTransferSvcMockRecorder struct {
+	mock *MockTransferSvc
+}
+
+// NewMockTransferSvc creates a new mock instance.
+func NewMockTransferSvc(ctrl *gomock.Controller) *MockTransferSvc {
+	mock := &MockTransferSvc{ctrl: ctrl}
+	mock.recorder = &MockTransferSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransferSvc) EXPECT() *MockTransferSvcMockRecorder {
+	return m.recorder
+}
+
+// Refund mocks base method.
+func (m *MockTransferSvc) Refund(ctx context.Context, refundDto dto.RefundTransferDto) (dto.RefundResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, refundDto)
+	ret0, _ := ret[0].(dto.RefundResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockTransferSvcMockRecorder) Refund(ctx, refundDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockTransferSvc)(nil).Refund), ctx, refundDto)
+}
+
+// Reverse mocks base method.
+func (m *MockTransferSvc) Reverse(ctx context.Context, reverseDto dto.ReverseTransferDto) (dto.RefundResultDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, reverseDto)
+	ret0, _ := ret[0].(dto.RefundResultDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockTransferSvcMockRecorder) Reverse(ctx, reverseDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockTransferSvc)(nil).Reverse), ctx, reverseDto)
+}
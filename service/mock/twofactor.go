@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/twofactor.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockTwoFactorSvc is a mock of TwoFactorSvc interface.
+type MockTwoFactorSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorSvcMockRecorder
+}
+
+// MockTwoFactorSvcMockRecorder is the mock recorder for MockTwoFactorSvc.
+type MockTwoFactorSvcMockRecorder struct {
+	mock *MockTwoFactorSvc
+}
+
+// NewMockTwoFactorSvc creates a new mock instance.
+func NewMockTwoFactorSvc(ctrl *gomock.Controller) *MockTwoFactorSvc {
+	mock := &MockTwoFactorSvc{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorSvc) EXPECT() *MockTwoFactorSvcMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockTwoFactorSvc) Confirm(ctx context.Context, confirmTOTPDto dto.ConfirmTOTPDto) (dto.TOTPRecoveryCodesDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, confirmTOTPDto)
+	ret0, _ := ret[0].(dto.TOTPRecoveryCodesDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockTwoFactorSvcMockRecorder) Confirm(ctx, confirmTOTPDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockTwoFactorSvc)(nil).Confirm), ctx, confirmTOTPDto)
+}
+
+// Disable mocks base method.
+func (m *MockTwoFactorSvc) Disable(ctx context.Context, disableTOTPDto dto.DisableTOTPDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, disableTOTPDto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTwoFactorSvcMockRecorder) Disable(ctx, disableTOTPDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTwoFactorSvc)(nil).Disable), ctx, disableTOTPDto)
+}
+
+// Enroll mocks base method.
+func (m *MockTwoFactorSvc) Enroll(ctx context.Context, userID int64) (dto.TOTPEnrollmentDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, userID)
+	ret0, _ := ret[0].(dto.TOTPEnrollmentDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockTwoFactorSvcMockRecorder) Enroll(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockTwoFactorSvc)(nil).Enroll), ctx, userID)
+}
+
+// StepUp mocks base method.
+func (m *MockTwoFactorSvc) StepUp(ctx context.Context, stepUpDto dto.StepUpDto) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StepUp", ctx, stepUpDto)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StepUp indicates an expected call of StepUp.
+func (mr *MockTwoFactorSvcMockRecorder) StepUp(ctx, stepUpDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StepUp", reflect.TypeOf((*MockTwoFactorSvc)(nil).StepUp), ctx, stepUpDto)
+}
+
+// VerifyLogin mocks base method.
+func (m *MockTwoFactorSvc) VerifyLogin(ctx context.Context, verifyLoginDto dto.VerifyLoginDto) (dto.LoggedInUserDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLogin", ctx, verifyLoginDto)
+	ret0, _ := ret[0].(dto.LoggedInUserDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLogin indicates an expected call of VerifyLogin.
+func (mr *MockTwoFactorSvcMockRecorder) VerifyLogin(ctx, verifyLoginDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockTwoFactorSvc)(nil).VerifyLogin), ctx, verifyLoginDto)
+}
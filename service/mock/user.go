@@ -35,6 +35,21 @@ func (m *MockUserSvc) EXPECT() *MockUserSvcMockRecorder {
 	return m.recorder
 }
 
+// BlockUser mocks base method.
+func (m *MockUserSvc) BlockUser(ctx context.Context, username string) (dto.UserDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", ctx, username)
+	ret0, _ := ret[0].(dto.UserDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockUserSvcMockRecorder) BlockUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockUserSvc)(nil).BlockUser), ctx, username)
+}
+
 // CreateUser mocks base method.
 func (m *MockUserSvc) CreateUser(ctx context.Context, createUserDto dto.CreateUserDto) (dto.UserDto, error) {
 	m.ctrl.T.Helper()
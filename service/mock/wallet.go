@@ -35,6 +35,21 @@ func (m *MockWalletSvc) EXPECT() *MockWalletSvcMockRecorder {
 	return m.recorder
 }
 
+// FreezeWallet mocks base method.
+func (m *MockWalletSvc) FreezeWallet(ctx context.Context, id int64) (dto.WalletDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FreezeWallet", ctx, id)
+	ret0, _ := ret[0].(dto.WalletDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FreezeWallet indicates an expected call of FreezeWallet.
+func (mr *MockWalletSvcMockRecorder) FreezeWallet(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreezeWallet", reflect.TypeOf((*MockWalletSvc)(nil).FreezeWallet), ctx, id)
+}
+
 // GetWalletByAddress mocks base method.
 func (m *MockWalletSvc) GetWalletByAddress(ctx context.Context, address string) (dto.WalletDto, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service/webhook.go
+
+// Package mocksvc is a generated GoMock package.
+package mocksvc
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	dto "github.com/pranayhere/simple-wallet/dto"
+)
+
+// MockWebhookSvc is a mock of WebhookSvc interface.
+type MockWebhookSvc struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSvcMockRecorder
+}
+
+// MockWebhookSvcMockRecorder is the mock recorder for MockWebhookSvc.
+type MockWebhookSvcMockRecorder struct {
+	mock *MockWebhookSvc
+}
+
+// NewMockWebhookSvc creates a new mock instance.
+func NewMockWebhookSvc(ctrl *gomock.Controller) *MockWebhookSvc {
+	mock := &MockWebhookSvc{ctrl: ctrl}
+	mock.recorder = &MockWebhookSvcMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSvc) EXPECT() *MockWebhookSvcMockRecorder {
+	return m.recorder
+}
+
+// DeliverPending mocks base method.
+func (m *MockWebhookSvc) DeliverPending(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverPending", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverPending indicates an expected call of DeliverPending.
+func (mr *MockWebhookSvcMockRecorder) DeliverPending(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverPending", reflect.TypeOf((*MockWebhookSvc)(nil).DeliverPending), ctx)
+}
+
+// DisableEndpoint mocks base method.
+func (m *MockWebhookSvc) DisableEndpoint(ctx context.Context, userID, endpointID int64) (dto.WebhookEndpointDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableEndpoint", ctx, userID, endpointID)
+	ret0, _ := ret[0].(dto.WebhookEndpointDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableEndpoint indicates an expected call of DisableEndpoint.
+func (mr *MockWebhookSvcMockRecorder) DisableEndpoint(ctx, userID, endpointID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableEndpoint", reflect.TypeOf((*MockWebhookSvc)(nil).DisableEndpoint), ctx, userID, endpointID)
+}
+
+// HandleEvent mocks base method.
+func (m *MockWebhookSvc) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockWebhookSvcMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockWebhookSvc)(nil).HandleEvent), ctx, event)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookSvc) ListDeliveries(ctx context.Context, listDeliveriesDto dto.ListWebhookDeliveriesDto) ([]dto.WebhookDeliveryDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, listDeliveriesDto)
+	ret0, _ := ret[0].([]dto.WebhookDeliveryDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookSvcMockRecorder) ListDeliveries(ctx, listDeliveriesDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookSvc)(nil).ListDeliveries), ctx, listDeliveriesDto)
+}
+
+// ListEndpoints mocks base method.
+func (m *MockWebhookSvc) ListEndpoints(ctx context.Context, userID int64) ([]dto.WebhookEndpointDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEndpoints", ctx, userID)
+	ret0, _ := ret[0].([]dto.WebhookEndpointDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEndpoints indicates an expected call of ListEndpoints.
+func (mr *MockWebhookSvcMockRecorder) ListEndpoints(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpoints", reflect.TypeOf((*MockWebhookSvc)(nil).ListEndpoints), ctx, userID)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookSvc) Redeliver(ctx context.Context, userID, deliveryID int64) (dto.WebhookDeliveryDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, userID, deliveryID)
+	ret0, _ := ret[0].(dto.WebhookDeliveryDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookSvcMockRecorder) Redeliver(ctx, userID, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookSvc)(nil).Redeliver), ctx, userID, deliveryID)
+}
+
+// RegisterEndpoint mocks base method.
+func (m *MockWebhookSvc) RegisterEndpoint(ctx context.Context, createWebhookDto dto.CreateWebhookEndpointDto) (dto.WebhookEndpointDto, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterEndpoint", ctx, createWebhookDto)
+	ret0, _ := ret[0].(dto.WebhookEndpointDto)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterEndpoint indicates an expected call of RegisterEndpoint.
+func (mr *MockWebhookSvcMockRecorder) RegisterEndpoint(ctx, createWebhookDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterEndpoint", reflect.TypeOf((*MockWebhookSvc)(nil).RegisterEndpoint), ctx, createWebhookDto)
+}
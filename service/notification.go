@@ -0,0 +1,410 @@
+package service
+
+import (
+    "bytes"
+    "context"
+    "database/sql"
+    "encoding/json"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    log "github.com/sirupsen/logrus"
+    "text/template"
+)
+
+type NotificationSvc interface {
+    Notify(ctx context.Context, userID int64, eventType domain.EventType, data NotificationData) error
+    HandleEvent(ctx context.Context, event domain.OutboxEvent) error
+    List(ctx context.Context, listNotificationsDto dto.ListNotificationsDto) ([]dto.NotificationDto, error)
+    MarkRead(ctx context.Context, userID int64, id int64, read bool) (dto.NotificationDto, error)
+    GetPreferences(ctx context.Context, userID int64) ([]dto.NotificationPreferenceDto, error)
+    UpdatePreference(ctx context.Context, preferenceDto dto.NotificationPreferenceDto) (dto.NotificationPreferenceDto, error)
+}
+
+type notificationService struct {
+    userRepo         store.UserRepo
+    currencyRepo     store.CurrencyRepo
+    notificationRepo store.NotificationRepo
+    preferenceRepo   store.NotificationPreferenceRepo
+    notifiers        map[domain.NotificationChannel]Notifier
+}
+
+func NewNotificationService(userRepo store.UserRepo, currencyRepo store.CurrencyRepo, notificationRepo store.NotificationRepo, preferenceRepo store.NotificationPreferenceRepo, notifiers map[domain.NotificationChannel]Notifier) NotificationSvc {
+    return &notificationService{
+        userRepo:         userRepo,
+        currencyRepo:     currencyRepo,
+        notificationRepo: notificationRepo,
+        preferenceRepo:   preferenceRepo,
+        notifiers:        notifiers,
+    }
+}
+
+// notificationChannels is the order in which the channels are notified
+var notificationChannels = []domain.NotificationChannel{
+    domain.NotificationChannelINAPP,
+    domain.NotificationChannelEMAIL,
+}
+
+// NotificationData fills the template of the event, Amount is in the currency's minor unit.
+type NotificationData struct {
+    Amount        int64
+    Currency      string
+    Counterparty  string
+    WalletAddress string
+    Status        domain.PaymentRequestStatus
+    BankName      string
+    AccountNo     string
+}
+
+// notificationView is what the templates are rendered with
+type notificationView struct {
+    FullName      string
+    Amount        string
+    Currency      string
+    Counterparty  string
+    WalletAddress string
+    Status        string
+    BankName      string
+    AccountLast4  string
+}
+
+type notificationTemplate struct {
+    subject *template.Template
+    body    *template.Template
+}
+
+func newNotificationTemplate(eventType domain.EventType, subject string, body string) notificationTemplate {
+    return notificationTemplate{
+        subject: template.Must(template.New(string(eventType) + ".subject").Parse(subject)),
+        body:    template.Must(template.New(string(eventType) + ".body").Parse(body)),
+    }
+}
+
+var notificationTemplates = map[domain.EventType]notificationTemplate{
+    domain.EventTypeTransferReceived: newNotificationTemplate(domain.EventTypeTransferReceived,
+        "You received {{.Amount}} {{.Currency}}",
+        "Hi {{.FullName}},\n\n{{.Counterparty}} sent you {{.Amount}} {{.Currency}}. The money is now available in your wallet {{.WalletAddress}}.",
+    ),
+    domain.EventTypePaymentRequestCreated: newNotificationTemplate(domain.EventTypePaymentRequestCreated,
+        "Payment request for {{.Amount}} {{.Currency}}",
+        "Hi {{.FullName}},\n\n{{.Counterparty}} requested {{.Amount}} {{.Currency}} from your wallet {{.WalletAddress}}. You can approve or refuse the request in the app.",
+    ),
+    domain.EventTypePaymentRequestStatusChanged: newNotificationTemplate(domain.EventTypePaymentRequestStatusChanged,
+        "Your payment request was {{.Status}}",
+        "Hi {{.FullName}},\n\nYour request for {{.Amount}} {{.Currency}} from {{.Counterparty}} was {{.Status}}.",
+    ),
+    domain.EventTypeBankAccountVerified: newNotificationTemplate(domain.EventTypeBankAccountVerified,
+        "Your bank account is verified",
+        "Hi {{.FullName}},\n\nYour {{.BankName}} account ending in {{.AccountLast4}} is verified and your wallet {{.WalletAddress}} is now active.",
+    ),
+    domain.EventTypeBankAccountVerificationFailed: newNotificationTemplate(domain.EventTypeBankAccountVerificationFailed,
+        "We could not verify your bank account",
+        "Hi {{.FullName}},\n\nWe could not verify your {{.BankName}} account ending in {{.AccountLast4}}. Please check that the account is in your name and add it again.",
+    ),
+}
+
+var paymentRequestStatusText = map[domain.PaymentRequestStatus]string{
+    domain.PaymentRequestStatusWAITINGAPPROVAL: "sent",
+    domain.PaymentRequestStatusAPPROVED:        "approved",
+    domain.PaymentRequestStatusREFUSED:         "refused",
+    domain.PaymentRequestStatusPAYMENTSUCCESS:  "paid",
+    domain.PaymentRequestStatusPAYMENTFAILED:   "approved, but the payment failed",
+}
+
+// Notify renders the template of the event for the user and sends it over every channel the user
+// has not turned off. The in-app copy is stored first and its failure returned, the other channels
+// are best effort: their failures are logged only.
+func (n *notificationService) Notify(ctx context.Context, userID int64, eventType domain.EventType, data NotificationData) error {
+    return n.notify(ctx, 0, userID, eventType, data)
+}
+
+// notify is Notify for the outbox event outboxEventID, the event is notified once to the user: when
+// its in-app copy is already stored the event was notified before and nothing is sent again.
+func (n *notificationService) notify(ctx context.Context, outboxEventID int64, userID int64, eventType domain.EventType, data NotificationData) error {
+    ctx, span := trace.Start(ctx, "NotificationSvc.Notify")
+    defer span.End()
+
+    tmpl, ok := notificationTemplates[eventType]
+    if !ok {
+        return fmt.Errorf("no notification template for event %s", eventType)
+    }
+
+    user, err := n.userRepo.GetUser(ctx, userID)
+    if err != nil {
+        return err
+    }
+
+    view, err := n.newView(ctx, user, data)
+    if err != nil {
+        return err
+    }
+
+    var subject, body bytes.Buffer
+    if err := tmpl.subject.Execute(&subject, view); err != nil {
+        return err
+    }
+    if err := tmpl.body.Execute(&body, view); err != nil {
+        return err
+    }
+
+    enabled, err := n.enabledChannels(ctx, userID)
+    if err != nil {
+        return err
+    }
+
+    msg := Message{
+        UserID:        user.ID,
+        Email:         user.Email,
+        EventType:     eventType,
+        Subject:       subject.String(),
+        Body:          body.String(),
+        OutboxEventID: outboxEventID,
+    }
+
+    for _, channel := range notificationChannels {
+        notifier, ok := n.notifiers[channel]
+        if !ok || !enabled[channel] {
+            continue
+        }
+
+        err := notifier.Send(ctx, msg)
+        if err == nil {
+            continue
+        }
+
+        if channel == domain.NotificationChannelINAPP {
+            if err == errors.ErrNotificationAlreadySent {
+                logging.FromContext(ctx).WithFields(log.Fields{"user_id": userID, "event_id": outboxEventID}).Info("event already notified, skipping it")
+                return nil
+            }
+            return err
+        }
+
+        logging.FromContext(ctx).WithFields(log.Fields{"user_id": userID, "channel": channel}).Error("failed to send notification: ", err)
+    }
+
+    return nil
+}
+
+// HandleEvent notifies the users concerned by an outbox event, it is subscribed to the event bus.
+// A failure to store the in-app copy is returned, the relay publishes the event again later and holds
+// back the later events of its aggregate only. The users are not notified twice of the same event.
+func (n *notificationService) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+    ctx, span := trace.Start(ctx, "NotificationSvc.HandleEvent")
+    defer span.End()
+
+    if err := n.handleEvent(ctx, event); err != nil {
+        logging.FromContext(ctx).WithField("event_id", event.ID).Error("failed to notify event: ", err)
+        return err
+    }
+
+    return nil
+}
+
+func (n *notificationService) handleEvent(ctx context.Context, event domain.OutboxEvent) error {
+    switch event.EventType {
+    case domain.EventTypeTransferCreated:
+        var res store.WalletTransferResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        // only the receiver is notified, with the event of the receiving wallet
+        if event.AggregateID != res.ToWallet.ID {
+            return nil
+        }
+
+        return n.notify(ctx, event.ID, res.ToWallet.UserID, domain.EventTypeTransferReceived, NotificationData{
+            Amount:        res.Transfer.Amount,
+            Currency:      res.ToWallet.Currency,
+            Counterparty:  res.Wallet.Address,
+            WalletAddress: res.ToWallet.Address,
+        })
+    case domain.EventTypeWalletActivated, domain.EventTypeBankAccountVerificationFailed:
+        var res store.BankAccountVerificationResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        eventType := domain.EventTypeBankAccountVerified
+        if event.EventType == domain.EventTypeBankAccountVerificationFailed {
+            eventType = domain.EventTypeBankAccountVerificationFailed
+        }
+
+        return n.notify(ctx, event.ID, res.BankAccount.UserID, eventType, NotificationData{
+            WalletAddress: res.Wallet.Address,
+            BankName:      res.BankAccount.BankName,
+            AccountNo:     res.BankAccount.AccountNo,
+        })
+    case domain.EventTypePaymentRequestStatusChanged:
+        var res store.PaymentRequestResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        // the payer is asked to act on a new request, the requester is told how the request ended
+        switch res.PaymentRequest.Status {
+        case domain.PaymentRequestStatusWAITINGAPPROVAL:
+            return n.notify(ctx, event.ID, res.FromWallet.UserID, domain.EventTypePaymentRequestCreated, NotificationData{
+                Amount:        res.PaymentRequest.Amount,
+                Currency:      res.FromWallet.Currency,
+                Counterparty:  res.ToWallet.Address,
+                WalletAddress: res.FromWallet.Address,
+            })
+        case domain.PaymentRequestStatusREFUSED, domain.PaymentRequestStatusPAYMENTSUCCESS, domain.PaymentRequestStatusPAYMENTFAILED:
+            return n.notify(ctx, event.ID, res.ToWallet.UserID, domain.EventTypePaymentRequestStatusChanged, NotificationData{
+                Amount:        res.PaymentRequest.Amount,
+                Currency:      res.ToWallet.Currency,
+                Counterparty:  res.FromWallet.Address,
+                WalletAddress: res.ToWallet.Address,
+                Status:        res.PaymentRequest.Status,
+            })
+        }
+    }
+
+    return nil
+}
+
+func (n *notificationService) List(ctx context.Context, listNotificationsDto dto.ListNotificationsDto) ([]dto.NotificationDto, error) {
+    ctx, span := trace.Start(ctx, "NotificationSvc.List")
+    defer span.End()
+
+    res := []dto.NotificationDto{}
+
+    notifications, err := n.notificationRepo.ListNotifications(ctx, store.ListNotificationsParams{
+        UserID:     listNotificationsDto.UserID,
+        UnreadOnly: listNotificationsDto.UnreadOnly,
+        Limit:      listNotificationsDto.Limit,
+        Offset:     listNotificationsDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, notification := range notifications {
+        res = append(res, dto.NewNotificationDto(notification))
+    }
+
+    return res, nil
+}
+
+func (n *notificationService) MarkRead(ctx context.Context, userID int64, id int64, read bool) (dto.NotificationDto, error) {
+    ctx, span := trace.Start(ctx, "NotificationSvc.MarkRead")
+    defer span.End()
+
+    var res dto.NotificationDto
+
+    notification, err := n.notificationRepo.GetNotification(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrNotificationNotFound
+        }
+
+        return res, err
+    }
+
+    if notification.UserID != userID {
+        return res, errors.ErrNotificationNotFound
+    }
+
+    notification, err = n.notificationRepo.UpdateNotificationRead(ctx, store.UpdateNotificationReadParams{
+        ID:   id,
+        Read: read,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewNotificationDto(notification)
+    return res, nil
+}
+
+func (n *notificationService) GetPreferences(ctx context.Context, userID int64) ([]dto.NotificationPreferenceDto, error) {
+    ctx, span := trace.Start(ctx, "NotificationSvc.GetPreferences")
+    defer span.End()
+
+    res := []dto.NotificationPreferenceDto{}
+
+    enabled, err := n.enabledChannels(ctx, userID)
+    if err != nil {
+        return res, err
+    }
+
+    for _, channel := range notificationChannels {
+        res = append(res, dto.NotificationPreferenceDto{
+            Channel: channel,
+            Enabled: enabled[channel],
+            UserID:  userID,
+        })
+    }
+
+    return res, nil
+}
+
+func (n *notificationService) UpdatePreference(ctx context.Context, preferenceDto dto.NotificationPreferenceDto) (dto.NotificationPreferenceDto, error) {
+    ctx, span := trace.Start(ctx, "NotificationSvc.UpdatePreference")
+    defer span.End()
+
+    var res dto.NotificationPreferenceDto
+
+    preference, err := n.preferenceRepo.UpsertNotificationPreference(ctx, store.UpsertNotificationPreferenceParams{
+        UserID:  preferenceDto.UserID,
+        Channel: preferenceDto.Channel,
+        Enabled: preferenceDto.Enabled,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewNotificationPreferenceDto(preference)
+    return res, nil
+}
+
+// enabledChannels applies the user's preferences over the default of every channel enabled.
+func (n *notificationService) enabledChannels(ctx context.Context, userID int64) (map[domain.NotificationChannel]bool, error) {
+    enabled := map[domain.NotificationChannel]bool{}
+    for _, channel := range notificationChannels {
+        enabled[channel] = true
+    }
+
+    preferences, err := n.preferenceRepo.ListNotificationPreferences(ctx, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    for _, p := range preferences {
+        enabled[p.Channel] = p.Enabled
+    }
+
+    return enabled, nil
+}
+
+func (n *notificationService) newView(ctx context.Context, user domain.User, data NotificationData) (notificationView, error) {
+    view := notificationView{
+        FullName:      user.FullName,
+        Currency:      data.Currency,
+        Counterparty:  data.Counterparty,
+        WalletAddress: data.WalletAddress,
+        Status:        paymentRequestStatusText[data.Status],
+        BankName:      data.BankName,
+    }
+
+    if len(data.AccountNo) >= 4 {
+        view.AccountLast4 = data.AccountNo[len(data.AccountNo)-4:]
+    }
+
+    if data.Currency != "" {
+        currency, err := n.currencyRepo.GetCurrency(ctx, data.Currency)
+        if err != nil {
+            return view, err
+        }
+        view.Amount = util.FormatAmount(data.Amount, currency.Fraction)
+    }
+
+    return view, nil
+}
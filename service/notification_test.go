@@ -0,0 +1,340 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "encoding/json"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestNotify(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    currency := domain.Currency{Code: "INR", Fraction: 2}
+    data := service.NotificationData{
+        Amount:        12345,
+        Currency:      currency.Code,
+        Counterparty:  "alice@my.wallet",
+        WalletAddress: "bob@my.wallet",
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo, mockPreferenceRepo *mockdb.MockNotificationPreferenceRepo, mockInApp *mocksvc.MockNotifier, mockEmail *mocksvc.MockNotifier)
+        checkResp func(t *testing.T, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo, mockPreferenceRepo *mockdb.MockNotificationPreferenceRepo, mockInApp *mocksvc.MockNotifier, mockEmail *mocksvc.MockNotifier) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), currency.Code).Times(1).Return(currency, nil)
+                mockPreferenceRepo.EXPECT().ListNotificationPreferences(gomock.Any(), user.ID).Times(1).Return([]domain.NotificationPreference{}, nil)
+
+                send := func(ctx context.Context, msg service.Message) error {
+                    require.Equal(t, user.ID, msg.UserID)
+                    require.Equal(t, user.Email, msg.Email)
+                    require.Equal(t, domain.EventTypeTransferReceived, msg.EventType)
+                    require.Equal(t, "You received 123.45 INR", msg.Subject)
+                    require.Contains(t, msg.Body, "alice@my.wallet sent you 123.45 INR")
+                    return nil
+                }
+                gomock.InOrder(
+                    mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(send),
+                    mockEmail.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(send),
+                )
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "EmailDisabled",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo, mockPreferenceRepo *mockdb.MockNotificationPreferenceRepo, mockInApp *mocksvc.MockNotifier, mockEmail *mocksvc.MockNotifier) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), currency.Code).Times(1).Return(currency, nil)
+                mockPreferenceRepo.EXPECT().ListNotificationPreferences(gomock.Any(), user.ID).Times(1).Return([]domain.NotificationPreference{
+                    {UserID: user.ID, Channel: domain.NotificationChannelEMAIL, Enabled: false},
+                }, nil)
+                mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+                mockEmail.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "SendErr",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo, mockPreferenceRepo *mockdb.MockNotificationPreferenceRepo, mockInApp *mocksvc.MockNotifier, mockEmail *mocksvc.MockNotifier) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), currency.Code).Times(1).Return(currency, nil)
+                mockPreferenceRepo.EXPECT().ListNotificationPreferences(gomock.Any(), user.ID).Times(1).Return([]domain.NotificationPreference{}, nil)
+                mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+                mockEmail.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+        {
+            name: "EmailErr",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo, mockPreferenceRepo *mockdb.MockNotificationPreferenceRepo, mockInApp *mocksvc.MockNotifier, mockEmail *mocksvc.MockNotifier) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), currency.Code).Times(1).Return(currency, nil)
+                mockPreferenceRepo.EXPECT().ListNotificationPreferences(gomock.Any(), user.ID).Times(1).Return([]domain.NotificationPreference{}, nil)
+                mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+                mockEmail.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "UserNotFound",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo, mockPreferenceRepo *mockdb.MockNotificationPreferenceRepo, mockInApp *mocksvc.MockNotifier, mockEmail *mocksvc.MockNotifier) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(domain.User{}, sql.ErrNoRows)
+                mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+                mockEmail.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, sql.ErrNoRows.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockCurrencyRepo := mockdb.NewMockCurrencyRepo(ctrl)
+            mockNotificationRepo := mockdb.NewMockNotificationRepo(ctrl)
+            mockPreferenceRepo := mockdb.NewMockNotificationPreferenceRepo(ctrl)
+            mockInApp := mocksvc.NewMockNotifier(ctrl)
+            mockEmail := mocksvc.NewMockNotifier(ctrl)
+            tc.buildStub(mockUserRepo, mockCurrencyRepo, mockPreferenceRepo, mockInApp, mockEmail)
+
+            notificationSvc := service.NewNotificationService(mockUserRepo, mockCurrencyRepo, mockNotificationRepo, mockPreferenceRepo, map[domain.NotificationChannel]service.Notifier{
+                domain.NotificationChannelINAPP: mockInApp,
+                domain.NotificationChannelEMAIL: mockEmail,
+            })
+            err := notificationSvc.Notify(context.Background(), user.ID, domain.EventTypeTransferReceived, data)
+            tc.checkResp(t, err)
+        })
+    }
+}
+
+func TestNotificationHandleEvent(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    res := store.WalletTransferResult{
+        Wallet:   domain.Wallet{ID: 1, Address: "alice@my.wallet", Currency: "INR"},
+        ToWallet: domain.Wallet{ID: 2, Address: "bob@my.wallet", Currency: "INR", UserID: user.ID},
+        Transfer: domain.Transfer{Amount: 500},
+    }
+    payload, err := json.Marshal(res)
+    require.NoError(t, err)
+
+    mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+    mockCurrencyRepo := mockdb.NewMockCurrencyRepo(ctrl)
+    mockNotificationRepo := mockdb.NewMockNotificationRepo(ctrl)
+    mockPreferenceRepo := mockdb.NewMockNotificationPreferenceRepo(ctrl)
+
+    mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(3).Return(user, nil)
+    mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), "INR").Times(3).Return(domain.Currency{Code: "INR", Fraction: 2}, nil)
+    mockPreferenceRepo.EXPECT().ListNotificationPreferences(gomock.Any(), user.ID).Times(3).Return([]domain.NotificationPreference{}, nil)
+    mockInApp := mocksvc.NewMockNotifier(ctrl)
+    mockEmail := mocksvc.NewMockNotifier(ctrl)
+
+    notificationSvc := service.NewNotificationService(mockUserRepo, mockCurrencyRepo, mockNotificationRepo, mockPreferenceRepo, map[domain.NotificationChannel]service.Notifier{
+        domain.NotificationChannelINAPP: mockInApp,
+        domain.NotificationChannelEMAIL: mockEmail,
+    })
+    event := domain.OutboxEvent{
+        ID:            2,
+        AggregateType: domain.AggregateTypeWALLET,
+        AggregateID:   res.ToWallet.ID,
+        EventType:     domain.EventTypeTransferCreated,
+        Payload:       string(payload),
+    }
+
+    // a failed in-app copy is returned, the relay publishes the event again
+    mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, msg service.Message) error {
+        require.Equal(t, domain.EventTypeTransferReceived, msg.EventType)
+        require.Equal(t, "You received 5.00 INR", msg.Subject)
+        require.Equal(t, event.ID, msg.OutboxEventID)
+        return sql.ErrConnDone
+    })
+    err = notificationSvc.HandleEvent(context.Background(), event)
+    require.EqualError(t, err, sql.ErrConnDone.Error())
+
+    // a failed email is not, it would store the in-app copy again
+    mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+    mockEmail.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+    require.NoError(t, notificationSvc.HandleEvent(context.Background(), event))
+
+    // an event already notified is not sent again
+    mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrNotificationAlreadySent)
+    require.NoError(t, notificationSvc.HandleEvent(context.Background(), event))
+
+    // the event of the sending wallet notifies nobody
+    senderEvent := event
+    senderEvent.ID = 1
+    senderEvent.AggregateID = res.Wallet.ID
+    require.NoError(t, notificationSvc.HandleEvent(context.Background(), senderEvent))
+}
+
+func TestNotificationHandlePaymentRequestEvent(t *testing.T) {
+    payer, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    requester, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    requester.ID = payer.ID + 1
+
+    testcases := []struct {
+        name    string
+        status  domain.PaymentRequestStatus
+        userID  int64
+        subject string
+    }{
+        {
+            name:    "WaitingApproval",
+            status:  domain.PaymentRequestStatusWAITINGAPPROVAL,
+            userID:  payer.ID,
+            subject: "Payment request for 12.50 INR",
+        },
+        {
+            name:    "Refused",
+            status:  domain.PaymentRequestStatusREFUSED,
+            userID:  requester.ID,
+            subject: "Your payment request was refused",
+        },
+        {
+            name:   "Approved",
+            status: domain.PaymentRequestStatusAPPROVED,
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            res := store.PaymentRequestResult{
+                PaymentRequest: domain.PaymentRequest{ID: 42, Amount: 1250, Status: tc.status},
+                FromWallet:     domain.Wallet{UserID: payer.ID, Address: "payer@my.wallet", Currency: "INR"},
+                ToWallet:       domain.Wallet{UserID: requester.ID, Address: "requester@my.wallet", Currency: "INR"},
+            }
+            payload, err := json.Marshal(res)
+            require.NoError(t, err)
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockCurrencyRepo := mockdb.NewMockCurrencyRepo(ctrl)
+            mockPreferenceRepo := mockdb.NewMockNotificationPreferenceRepo(ctrl)
+            mockInApp := mocksvc.NewMockNotifier(ctrl)
+
+            if tc.userID == 0 {
+                mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+            } else {
+                user := payer
+                if tc.userID == requester.ID {
+                    user = requester
+                }
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), tc.userID).Times(1).Return(user, nil)
+                mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), "INR").Times(1).Return(domain.Currency{Code: "INR", Fraction: 2}, nil)
+                mockPreferenceRepo.EXPECT().ListNotificationPreferences(gomock.Any(), tc.userID).Times(1).Return([]domain.NotificationPreference{}, nil)
+                mockInApp.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, msg service.Message) error {
+                    require.Equal(t, tc.userID, msg.UserID)
+                    require.Equal(t, tc.subject, msg.Subject)
+                    return nil
+                })
+            }
+
+            notificationSvc := service.NewNotificationService(mockUserRepo, mockCurrencyRepo, mockdb.NewMockNotificationRepo(ctrl), mockPreferenceRepo, map[domain.NotificationChannel]service.Notifier{
+                domain.NotificationChannelINAPP: mockInApp,
+            })
+            err = notificationSvc.HandleEvent(context.Background(), domain.OutboxEvent{
+                ID:        1,
+                EventType: domain.EventTypePaymentRequestStatusChanged,
+                Payload:   string(payload),
+            })
+            require.NoError(t, err)
+        })
+    }
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    notification := domain.Notification{
+        ID:        util.RandomInt(1, 1000),
+        UserID:    userID,
+        EventType: domain.EventTypeTransferReceived,
+        Title:     util.RandomString(12),
+    }
+
+    testcases := []struct {
+        name      string
+        userID    int64
+        buildStub func(mockNotificationRepo *mockdb.MockNotificationRepo)
+        checkResp func(t *testing.T, read bool, err error)
+    }{
+        {
+            name:   "Ok",
+            userID: userID,
+            buildStub: func(mockNotificationRepo *mockdb.MockNotificationRepo) {
+                readNotification := notification
+                readNotification.ReadAt = sql.NullTime{Valid: true}
+                mockNotificationRepo.EXPECT().GetNotification(gomock.Any(), notification.ID).Times(1).Return(notification, nil)
+                mockNotificationRepo.EXPECT().UpdateNotificationRead(gomock.Any(), store.UpdateNotificationReadParams{ID: notification.ID, Read: true}).Times(1).Return(readNotification, nil)
+            },
+            checkResp: func(t *testing.T, read bool, err error) {
+                require.NoError(t, err)
+                require.True(t, read)
+            },
+        },
+        {
+            name:   "NotOwner",
+            userID: userID + 1,
+            buildStub: func(mockNotificationRepo *mockdb.MockNotificationRepo) {
+                mockNotificationRepo.EXPECT().GetNotification(gomock.Any(), notification.ID).Times(1).Return(notification, nil)
+                mockNotificationRepo.EXPECT().UpdateNotificationRead(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, read bool, err error) {
+                require.EqualError(t, err, errors.ErrNotificationNotFound.Error())
+            },
+        },
+        {
+            name:   "NotFound",
+            userID: userID,
+            buildStub: func(mockNotificationRepo *mockdb.MockNotificationRepo) {
+                mockNotificationRepo.EXPECT().GetNotification(gomock.Any(), notification.ID).Times(1).Return(domain.Notification{}, sql.ErrNoRows)
+                mockNotificationRepo.EXPECT().UpdateNotificationRead(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, read bool, err error) {
+                require.EqualError(t, err, errors.ErrNotificationNotFound.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockNotificationRepo := mockdb.NewMockNotificationRepo(ctrl)
+            tc.buildStub(mockNotificationRepo)
+
+            notificationSvc := service.NewNotificationService(mockdb.NewMockUserRepo(ctrl), mockdb.NewMockCurrencyRepo(ctrl), mockNotificationRepo, mockdb.NewMockNotificationPreferenceRepo(ctrl), nil)
+            res, err := notificationSvc.MarkRead(context.Background(), tc.userID, notification.ID, true)
+            tc.checkResp(t, res.Read, err)
+        })
+    }
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "io"
+    "net"
+    "net/smtp"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+)
+
+// Message is a rendered notification addressed to a user.
+type Message struct {
+    UserID    int64
+    Email     string
+    EventType domain.EventType
+    Subject   string
+    Body      string
+    // OutboxEventID is the outbox event notified, 0 for the notifications sent by the services directly
+    OutboxEventID int64
+}
+
+// Notifier delivers a message over one channel.
+type Notifier interface {
+    Send(ctx context.Context, msg Message) error
+}
+
+type SMTPConfig struct {
+    Host     string
+    Port     int
+    Username string
+    Password string
+    From     string
+}
+
+type smtpNotifier struct {
+    config SMTPConfig
+}
+
+// NewSMTPNotifier sends the messages as plain text emails, authenticating only when a username is set.
+func NewSMTPNotifier(config SMTPConfig) Notifier {
+    return &smtpNotifier{
+        config: config,
+    }
+}
+
+func (s *smtpNotifier) Send(ctx context.Context, msg Message) error {
+    var auth smtp.Auth
+    if s.config.Username != "" {
+        auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+    }
+
+    addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+    return smtp.SendMail(addr, auth, s.config.From, []string{msg.Email}, buildEmail(s.config.From, msg))
+}
+
+func buildEmail(from string, msg Message) []byte {
+    var b strings.Builder
+    b.WriteString("From: " + from + "\r\n")
+    b.WriteString("To: " + msg.Email + "\r\n")
+    b.WriteString("Subject: " + msg.Subject + "\r\n")
+    b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+    b.WriteString("MIME-Version: 1.0\r\n")
+    b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+    b.WriteString("\r\n")
+    b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+    b.WriteString("\r\n")
+    return []byte(b.String())
+}
+
+type logNotifier struct {
+    mu sync.Mutex
+    w  io.Writer
+}
+
+// NewLogNotifier writes the messages to w instead of sending them, for local development.
+func NewLogNotifier(w io.Writer) Notifier {
+    return &logNotifier{
+        w: w,
+    }
+}
+
+func (l *logNotifier) Send(ctx context.Context, msg Message) error {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+
+    _, err := fmt.Fprintf(l.w, "--- %s to: %s (user %d) event: %s\nSubject: %s\n\n%s\n\n",
+        time.Now().Format(time.RFC3339), msg.Email, msg.UserID, msg.EventType, msg.Subject, msg.Body)
+    return err
+}
+
+type inAppNotifier struct {
+    notificationRepo store.NotificationRepo
+}
+
+// NewInAppNotifier stores the messages in the user's inbox, once per outbox event: the message of an event
+// already stored fails with errors.ErrNotificationAlreadySent.
+func NewInAppNotifier(notificationRepo store.NotificationRepo) Notifier {
+    return &inAppNotifier{
+        notificationRepo: notificationRepo,
+    }
+}
+
+func (i *inAppNotifier) Send(ctx context.Context, msg Message) error {
+    _, err := i.notificationRepo.CreateNotification(ctx, store.CreateNotificationParams{
+        UserID:        msg.UserID,
+        EventType:     msg.EventType,
+        Title:         msg.Subject,
+        Body:          msg.Body,
+        OutboxEventID: sql.NullInt64{Int64: msg.OutboxEventID, Valid: msg.OutboxEventID != 0},
+    })
+    if err == sql.ErrNoRows {
+        return errors.ErrNotificationAlreadySent
+    }
+    return err
+}
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+    "bufio"
+    "bytes"
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "net"
+    "strconv"
+    "strings"
+    "testing"
+)
+
+var testMessage = service.Message{
+    UserID:    7,
+    Email:     "bob@example.com",
+    EventType: domain.EventTypeTransferReceived,
+    Subject:   "You received 5.00 INR",
+    Body:      "Hi Bob,\n\nalice@my.wallet sent you 5.00 INR.",
+}
+
+func TestLogNotifier(t *testing.T) {
+    var buf bytes.Buffer
+    notifier := service.NewLogNotifier(&buf)
+
+    err := notifier.Send(context.Background(), testMessage)
+    require.NoError(t, err)
+    require.Contains(t, buf.String(), "to: bob@example.com (user 7) event: transfer.received")
+    require.Contains(t, buf.String(), "Subject: You received 5.00 INR")
+    require.Contains(t, buf.String(), testMessage.Body)
+}
+
+func TestInAppNotifier(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockNotificationRepo := mockdb.NewMockNotificationRepo(ctrl)
+    mockNotificationRepo.EXPECT().CreateNotification(gomock.Any(), store.CreateNotificationParams{
+        UserID:    testMessage.UserID,
+        EventType: testMessage.EventType,
+        Title:     testMessage.Subject,
+        Body:      testMessage.Body,
+    }).Times(1).Return(domain.Notification{ID: 1}, nil)
+
+    notifier := service.NewInAppNotifier(mockNotificationRepo)
+    require.NoError(t, notifier.Send(context.Background(), testMessage))
+
+    eventMessage := testMessage
+    eventMessage.OutboxEventID = 7
+    mockNotificationRepo.EXPECT().CreateNotification(gomock.Any(), store.CreateNotificationParams{
+        UserID:        eventMessage.UserID,
+        EventType:     eventMessage.EventType,
+        Title:         eventMessage.Subject,
+        Body:          eventMessage.Body,
+        OutboxEventID: sql.NullInt64{Int64: 7, Valid: true},
+    }).Times(1).Return(domain.Notification{}, sql.ErrNoRows)
+
+    require.Equal(t, errors.ErrNotificationAlreadySent, notifier.Send(context.Background(), eventMessage))
+}
+
+func TestSMTPNotifier(t *testing.T) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    require.NoError(t, err)
+    defer listener.Close()
+
+    // a minimal SMTP server that accepts a single mail
+    received := make(chan string, 1)
+    go func() {
+        c, err := listener.Accept()
+        if err != nil {
+            return
+        }
+        defer c.Close()
+
+        r := bufio.NewReader(c)
+        write := func(s string) { _, _ = c.Write([]byte(s + "\r\n")) }
+        write("220 localhost ESMTP")
+
+        var data strings.Builder
+        inData := false
+        for {
+            line, err := r.ReadString('\n')
+            if err != nil {
+                return
+            }
+
+            if inData {
+                if line == ".\r\n" {
+                    inData = false
+                    received <- data.String()
+                    write("250 OK")
+                    continue
+                }
+                data.WriteString(line)
+                continue
+            }
+
+            switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+            case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+                write("250 localhost")
+            case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+                write("250 OK")
+            case cmd == "DATA":
+                inData = true
+                write("354 End data with <CR><LF>.<CR><LF>")
+            case cmd == "QUIT":
+                write("221 Bye")
+                return
+            default:
+                write("250 OK")
+            }
+        }
+    }()
+
+    host, port, err := net.SplitHostPort(listener.Addr().String())
+    require.NoError(t, err)
+    portNo, err := strconv.Atoi(port)
+    require.NoError(t, err)
+
+    notifier := service.NewSMTPNotifier(service.SMTPConfig{
+        Host: host,
+        Port: portNo,
+        From: "no-reply@my.wallet",
+    })
+    require.NoError(t, notifier.Send(context.Background(), testMessage))
+
+    mail := <-received
+    require.Contains(t, mail, "To: bob@example.com\r\n")
+    require.Contains(t, mail, "Subject: You received 5.00 INR\r\n")
+    require.Contains(t, mail, "alice@my.wallet sent you 5.00 INR.")
+}
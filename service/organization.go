@@ -0,0 +1,88 @@
+package service
+
+import (
+    "context"
+    "crypto/rand"
+    "encoding/hex"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "strings"
+)
+
+type OrganizationSvc interface {
+    CreateCurrency(ctx context.Context, createCurrencyDto dto.CreateOrganizationCurrencyDto) (dto.OrganizationCurrencyDto, error)
+}
+
+type organizationService struct {
+    organizationRepo store.OrganizationRepo
+    bankDirectorySvc BankDirectorySvc
+}
+
+func NewOrganizationService(organizationRepo store.OrganizationRepo, bankDirectorySvc BankDirectorySvc) OrganizationSvc {
+    return &organizationService{
+        organizationRepo: organizationRepo,
+        bankDirectorySvc: bankDirectorySvc,
+    }
+}
+
+// CreateCurrency adds the currency with its organization wallet and its escrow wallet, the users of the wallets get a
+// random password nobody knows. The banks of their accounts are looked up in the bank directory by ifsc.
+func (o *organizationService) CreateCurrency(ctx context.Context, createCurrencyDto dto.CreateOrganizationCurrencyDto) (dto.OrganizationCurrencyDto, error) {
+    ctx, span := trace.Start(ctx, "OrganizationSvc.CreateCurrency")
+    defer span.End()
+
+    var res dto.OrganizationCurrencyDto
+
+    orgBranch, err := o.bankDirectorySvc.GetBankBranch(ctx, createCurrencyDto.OrganizationAccount.Ifsc)
+    if err != nil {
+        return res, err
+    }
+
+    escrowBranch, err := o.bankDirectorySvc.GetBankBranch(ctx, createCurrencyDto.EscrowAccount.Ifsc)
+    if err != nil {
+        return res, err
+    }
+
+    password := make([]byte, 24)
+    if _, err := rand.Read(password); err != nil {
+        return res, err
+    }
+    hashedPassword, err := util.HashPassword(hex.EncodeToString(password))
+    if err != nil {
+        return res, err
+    }
+
+    arg := store.CreateCurrencyWithWalletsParams{
+        Code:           strings.ToUpper(createCurrencyDto.Code),
+        Fraction:       createCurrencyDto.Fraction,
+        HashedPassword: hashedPassword,
+        OrganizationAccount: store.OrganizationBankAccountParams{
+            AccountNo: createCurrencyDto.OrganizationAccount.AccountNo,
+            Ifsc:      orgBranch.Ifsc,
+            BankName:  orgBranch.BankName,
+        },
+        EscrowAccount: store.OrganizationBankAccountParams{
+            AccountNo: createCurrencyDto.EscrowAccount.AccountNo,
+            Ifsc:      escrowBranch.Ifsc,
+            BankName:  escrowBranch.BankName,
+        },
+    }
+
+    result, err := o.organizationRepo.CreateCurrencyWithWallets(ctx, arg)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.OrganizationCurrencyDto{
+        Currency: dto.CurrencyDto{
+            Code:      result.Currency.Code,
+            Fraction:  result.Currency.Fraction,
+            CreatedAt: result.Currency.CreatedAt,
+        },
+        OrganizationWallet: dto.NewWalletDto(result.OrganizationWallet),
+        EscrowWallet:       dto.NewWalletDto(result.EscrowWallet),
+    }
+    return res, nil
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestOrganizationCreateCurrency(t *testing.T) {
+    req := dto.CreateOrganizationCurrencyDto{
+        Code:     "gbp",
+        Fraction: 2,
+        OrganizationAccount: dto.OrganizationBankAccountDto{
+            AccountNo: "1234567910",
+            Ifsc:      "hdfc0000076",
+        },
+        EscrowAccount: dto.OrganizationBankAccountDto{
+            AccountNo: "1234567911",
+            Ifsc:      "HDFC0000076",
+        },
+    }
+
+    branch := dto.BankBranchDto{Ifsc: "HDFC0000076", BankName: "HDFC BANK"}
+
+    result := store.CurrencyWithWalletsResult{
+        Currency: domain.Currency{Code: "GBP", Fraction: 2},
+        OrganizationWallet: domain.Wallet{
+            ID:                   10,
+            Address:              "grabgbp@my.wallet",
+            Status:               domain.WalletStatusACTIVE,
+            OrganizationWalletID: 10,
+            Currency:             "GBP",
+        },
+        EscrowWallet: domain.Wallet{
+            ID:                   11,
+            Address:              "escrowgbp@my.wallet",
+            Status:               domain.WalletStatusACTIVE,
+            OrganizationWalletID: 10,
+            Currency:             "GBP",
+        },
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockOrganizationRepo *mockdb.MockOrganizationRepo, mockBankDirectorySvc *mocksvc.MockBankDirectorySvc)
+        checkResp func(t *testing.T, res dto.OrganizationCurrencyDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockOrganizationRepo *mockdb.MockOrganizationRepo, mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), gomock.Any()).Times(2).Return(branch, nil)
+                mockOrganizationRepo.EXPECT().CreateCurrencyWithWallets(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.CreateCurrencyWithWalletsParams) (store.CurrencyWithWalletsResult, error) {
+                        require.Equal(t, "GBP", arg.Code)
+                        require.Equal(t, req.Fraction, arg.Fraction)
+                        require.NotEmpty(t, arg.HashedPassword)
+                        require.Equal(t, req.OrganizationAccount.AccountNo, arg.OrganizationAccount.AccountNo)
+                        require.Equal(t, req.EscrowAccount.AccountNo, arg.EscrowAccount.AccountNo)
+                        // the banks come from the directory
+                        require.Equal(t, store.OrganizationBankAccountParams{AccountNo: "1234567910", Ifsc: branch.Ifsc, BankName: branch.BankName}, arg.OrganizationAccount)
+                        require.Equal(t, store.OrganizationBankAccountParams{AccountNo: "1234567911", Ifsc: branch.Ifsc, BankName: branch.BankName}, arg.EscrowAccount)
+                        return result, nil
+                    })
+            },
+            checkResp: func(t *testing.T, res dto.OrganizationCurrencyDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, "GBP", res.Currency.Code)
+                require.Equal(t, dto.NewWalletDto(result.OrganizationWallet), res.OrganizationWallet)
+                require.Equal(t, dto.NewWalletDto(result.EscrowWallet), res.EscrowWallet)
+            },
+        },
+        {
+            name: "IfscNotFound",
+            buildStub: func(mockOrganizationRepo *mockdb.MockOrganizationRepo, mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), req.OrganizationAccount.Ifsc).Times(1).Return(branch, nil)
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), req.EscrowAccount.Ifsc).Times(1).Return(dto.BankBranchDto{}, errors.ErrIfscNotFound)
+                mockOrganizationRepo.EXPECT().CreateCurrencyWithWallets(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.OrganizationCurrencyDto, err error) {
+                require.EqualError(t, err, errors.ErrIfscNotFound.Error())
+            },
+        },
+        {
+            name: "AlreadyExist",
+            buildStub: func(mockOrganizationRepo *mockdb.MockOrganizationRepo, mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), gomock.Any()).Times(2).Return(branch, nil)
+                mockOrganizationRepo.EXPECT().CreateCurrencyWithWallets(gomock.Any(), gomock.Any()).Times(1).
+                    Return(store.CurrencyWithWalletsResult{}, errors.ErrOrganizationWalletAlreadyExist)
+            },
+            checkResp: func(t *testing.T, res dto.OrganizationCurrencyDto, err error) {
+                require.EqualError(t, err, errors.ErrOrganizationWalletAlreadyExist.Error())
+            },
+        },
+        {
+            name: "DbConnectionClosed",
+            buildStub: func(mockOrganizationRepo *mockdb.MockOrganizationRepo, mockBankDirectorySvc *mocksvc.MockBankDirectorySvc) {
+                mockBankDirectorySvc.EXPECT().GetBankBranch(gomock.Any(), gomock.Any()).Times(2).Return(branch, nil)
+                mockOrganizationRepo.EXPECT().CreateCurrencyWithWallets(gomock.Any(), gomock.Any()).Times(1).
+                    Return(store.CurrencyWithWalletsResult{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, res dto.OrganizationCurrencyDto, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockOrganizationRepo := mockdb.NewMockOrganizationRepo(ctrl)
+            mockBankDirectorySvc := mocksvc.NewMockBankDirectorySvc(ctrl)
+            tc.buildStub(mockOrganizationRepo, mockBankDirectorySvc)
+
+            organizationSvc := service.NewOrganizationService(mockOrganizationRepo, mockBankDirectorySvc)
+            res, err := organizationSvc.CreateCurrency(context.Background(), req)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
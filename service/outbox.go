@@ -0,0 +1,91 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+)
+
+type OutboxRelaySvc interface {
+    RelayPending(ctx context.Context) error
+    ListDead(ctx context.Context, listDeadDto dto.ListDeadOutboxEventsDto) ([]dto.OutboxEventDto, error)
+    RetryDead(ctx context.Context, id int64) (dto.OutboxEventDto, error)
+}
+
+type outboxRelayService struct {
+    outboxRepo store.OutboxRepo
+    publisher  Publisher
+}
+
+func NewOutboxRelayService(outboxRepo store.OutboxRepo, publisher Publisher) OutboxRelaySvc {
+    return &outboxRelayService{
+        outboxRepo: outboxRepo,
+        publisher:  publisher,
+    }
+}
+
+// RelayPending publishes the outbox events written since the last run, in order. The events failing
+// constant.OutboxMaxAttempts times are set aside as dead.
+func (o *outboxRelayService) RelayPending(ctx context.Context) error {
+    ctx, span := trace.Start(ctx, "OutboxRelaySvc.RelayPending")
+    defer span.End()
+
+    arg := store.RelayOutboxEventsParams{
+        Limit:          constant.OutboxRelayBatchSize,
+        MaxAttempts:    constant.OutboxMaxAttempts,
+        RetryBaseDelay: constant.OutboxRetryBaseDelay,
+    }
+
+    count, err := o.outboxRepo.RelayOutboxEvents(ctx, arg, o.publisher.Publish)
+    if count > 0 {
+        logging.FromContext(ctx).WithField("count", count).Debug("published outbox events")
+    }
+
+    return err
+}
+
+// ListDead lists the events set aside by the relay, oldest first.
+func (o *outboxRelayService) ListDead(ctx context.Context, listDeadDto dto.ListDeadOutboxEventsDto) ([]dto.OutboxEventDto, error) {
+    ctx, span := trace.Start(ctx, "OutboxRelaySvc.ListDead")
+    defer span.End()
+
+    res := []dto.OutboxEventDto{}
+
+    events, err := o.outboxRepo.ListDeadOutboxEvents(ctx, store.ListDeadOutboxEventsParams{
+        Limit:  listDeadDto.Limit,
+        Offset: listDeadDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, event := range events {
+        res = append(res, dto.NewOutboxEventDto(event))
+    }
+
+    return res, nil
+}
+
+// RetryDead hands a dead event back to the relay once its handler is fixed, with its attempts reset.
+func (o *outboxRelayService) RetryDead(ctx context.Context, id int64) (dto.OutboxEventDto, error) {
+    ctx, span := trace.Start(ctx, "OutboxRelaySvc.RetryDead")
+    defer span.End()
+
+    var res dto.OutboxEventDto
+
+    event, err := o.outboxRepo.RetryOutboxEvent(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrDeadOutboxEventNotFound
+        }
+        return res, err
+    }
+
+    res = dto.NewOutboxEventDto(event)
+    return res, nil
+}
@@ -0,0 +1,163 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func TestRelayPending(t *testing.T) {
+    events := []domain.OutboxEvent{
+        {ID: 1, AggregateType: domain.AggregateTypeWALLET, AggregateID: 10, EventType: domain.EventTypeWalletCreated},
+        {ID: 2, AggregateType: domain.AggregateTypeWALLET, AggregateID: 10, EventType: domain.EventTypeTransferCreated},
+    }
+    relayArg := store.RelayOutboxEventsParams{Limit: 100, MaxAttempts: 10, RetryBaseDelay: 5 * time.Second}
+
+    // relay mimics the repository, publishing the events in order until the first failure
+    relay := func(ctx context.Context, arg store.RelayOutboxEventsParams, publish store.PublishOutboxEventFn) (int, error) {
+        for i, e := range events {
+            if err := publish(ctx, e); err != nil {
+                return i, err
+            }
+        }
+        return len(events), nil
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockOutboxRepo *mockdb.MockOutboxRepo, mockPublisher *mocksvc.MockPublisher)
+        checkResp func(t *testing.T, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockOutboxRepo *mockdb.MockOutboxRepo, mockPublisher *mocksvc.MockPublisher) {
+                mockOutboxRepo.EXPECT().RelayOutboxEvents(gomock.Any(), relayArg, gomock.Any()).Times(1).DoAndReturn(relay)
+                gomock.InOrder(
+                    mockPublisher.EXPECT().Publish(gomock.Any(), events[0]).Times(1),
+                    mockPublisher.EXPECT().Publish(gomock.Any(), events[1]).Times(1),
+                )
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name: "PublishErr",
+            buildStub: func(mockOutboxRepo *mockdb.MockOutboxRepo, mockPublisher *mocksvc.MockPublisher) {
+                mockOutboxRepo.EXPECT().RelayOutboxEvents(gomock.Any(), relayArg, gomock.Any()).Times(1).DoAndReturn(relay)
+                mockPublisher.EXPECT().Publish(gomock.Any(), events[0]).Times(1).Return(sql.ErrConnDone)
+                mockPublisher.EXPECT().Publish(gomock.Any(), events[1]).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+        {
+            name: "RelayErr",
+            buildStub: func(mockOutboxRepo *mockdb.MockOutboxRepo, mockPublisher *mocksvc.MockPublisher) {
+                mockOutboxRepo.EXPECT().RelayOutboxEvents(gomock.Any(), relayArg, gomock.Any()).Times(1).Return(0, sql.ErrTxDone)
+                mockPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, sql.ErrTxDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockOutboxRepo := mockdb.NewMockOutboxRepo(ctrl)
+            mockPublisher := mocksvc.NewMockPublisher(ctrl)
+            tc.buildStub(mockOutboxRepo, mockPublisher)
+
+            outboxRelaySvc := service.NewOutboxRelayService(mockOutboxRepo, mockPublisher)
+            err := outboxRelaySvc.RelayPending(context.TODO())
+            tc.checkResp(t, err)
+        })
+    }
+}
+
+func TestListDeadOutboxEvents(t *testing.T) {
+    events := []domain.OutboxEvent{
+        {ID: 1, AggregateType: domain.AggregateTypeWALLET, AggregateID: 10, EventType: domain.EventTypeWalletCreated,
+            Status: domain.OutboxStatusDEAD, Attempts: 10, LastError: sql.ErrConnDone.Error()},
+    }
+
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockOutboxRepo := mockdb.NewMockOutboxRepo(ctrl)
+    mockOutboxRepo.EXPECT().ListDeadOutboxEvents(gomock.Any(), store.ListDeadOutboxEventsParams{Limit: 20, Offset: 40}).Times(1).Return(events, nil)
+
+    outboxRelaySvc := service.NewOutboxRelayService(mockOutboxRepo, mocksvc.NewMockPublisher(ctrl))
+    res, err := outboxRelaySvc.ListDead(context.TODO(), dto.ListDeadOutboxEventsDto{Limit: 20, Offset: 40})
+    require.NoError(t, err)
+    require.Equal(t, []dto.OutboxEventDto{dto.NewOutboxEventDto(events[0])}, res)
+}
+
+func TestRetryDeadOutboxEvent(t *testing.T) {
+    event := domain.OutboxEvent{ID: 1, AggregateType: domain.AggregateTypeWALLET, AggregateID: 10, EventType: domain.EventTypeWalletCreated,
+        Status: domain.OutboxStatusPENDING}
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockOutboxRepo *mockdb.MockOutboxRepo)
+        checkResp func(t *testing.T, res dto.OutboxEventDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockOutboxRepo *mockdb.MockOutboxRepo) {
+                mockOutboxRepo.EXPECT().RetryOutboxEvent(gomock.Any(), event.ID).Times(1).Return(event, nil)
+            },
+            checkResp: func(t *testing.T, res dto.OutboxEventDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.OutboxStatusPENDING, res.Status)
+            },
+        },
+        {
+            name: "NotDead",
+            buildStub: func(mockOutboxRepo *mockdb.MockOutboxRepo) {
+                mockOutboxRepo.EXPECT().RetryOutboxEvent(gomock.Any(), event.ID).Times(1).Return(domain.OutboxEvent{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, res dto.OutboxEventDto, err error) {
+                require.EqualError(t, err, errors.ErrDeadOutboxEventNotFound.Error())
+            },
+        },
+        {
+            name: "RetryErr",
+            buildStub: func(mockOutboxRepo *mockdb.MockOutboxRepo) {
+                mockOutboxRepo.EXPECT().RetryOutboxEvent(gomock.Any(), event.ID).Times(1).Return(domain.OutboxEvent{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, res dto.OutboxEventDto, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockOutboxRepo := mockdb.NewMockOutboxRepo(ctrl)
+            tc.buildStub(mockOutboxRepo)
+
+            outboxRelaySvc := service.NewOutboxRelayService(mockOutboxRepo, mocksvc.NewMockPublisher(ctrl))
+            res, err := outboxRelaySvc.RetryDead(context.TODO(), event.ID)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
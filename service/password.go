@@ -0,0 +1,165 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "time"
+)
+
+// PasswordSvc changes the passwords. A change sets password_changed_at, which revokes the tokens issued before it.
+type PasswordSvc interface {
+    ChangePassword(ctx context.Context, changePasswordDto dto.ChangePasswordDto) (dto.LoggedInUserDto, error)
+    ForgotPassword(ctx context.Context, forgotPasswordDto dto.ForgotPasswordDto) error
+    ResetPassword(ctx context.Context, resetPasswordDto dto.ResetPasswordDto) (dto.UserDto, error)
+}
+
+type passwordService struct {
+    userRepo          store.UserRepo
+    passwordResetRepo store.PasswordResetRepo
+    tokenMaker        token.Maker
+    mailer            Mailer
+}
+
+func NewPasswordService(userRepo store.UserRepo, passwordResetRepo store.PasswordResetRepo, tokenMaker token.Maker, mailer Mailer) PasswordSvc {
+    return &passwordService{
+        userRepo:          userRepo,
+        passwordResetRepo: passwordResetRepo,
+        tokenMaker:        tokenMaker,
+        mailer:            mailer,
+    }
+}
+
+// ChangePassword sets the new password of the user, who must know the current one, and returns a new token: the
+// token of the request is revoked with the others. The password resets pending are used.
+func (p *passwordService) ChangePassword(ctx context.Context, changePasswordDto dto.ChangePasswordDto) (dto.LoggedInUserDto, error) {
+    ctx, span := trace.Start(ctx, "PasswordSvc.ChangePassword")
+    defer span.End()
+
+    var loggedInDto dto.LoggedInUserDto
+
+    user, err := p.userRepo.GetUser(ctx, changePasswordDto.UserID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return loggedInDto, errors.ErrUserNotFound
+        }
+        return loggedInDto, err
+    }
+
+    err = util.CheckPassword(changePasswordDto.CurrentPassword, user.HashedPassword)
+    if err != nil {
+        return loggedInDto, errors.ErrIncorrectPassword
+    }
+
+    hashedPassword, err := util.HashPassword(changePasswordDto.NewPassword)
+    if err != nil {
+        return loggedInDto, err
+    }
+
+    user, err = p.passwordResetRepo.ChangePassword(ctx, store.ChangePasswordParams{
+        UserID:         user.ID,
+        HashedPassword: hashedPassword,
+        ChangedAt:      passwordChangedAt(),
+    })
+    if err != nil {
+        return loggedInDto, err
+    }
+
+    accessToken, err := p.tokenMaker.CreateToken(user.ID, constant.AccessTokenDuration)
+    if err != nil {
+        return loggedInDto, err
+    }
+
+    loggedInDto = dto.LoggedInUserDto{
+        AccessToken: accessToken,
+        User:        dto.NewUserDto(user),
+    }
+
+    return loggedInDto, nil
+}
+
+// ForgotPassword mails a password reset token to the user of the email, valid for PasswordResetTokenTTL. Nothing
+// tells whether the email is the one of a user: an unknown email, or a blocked user, is not an error.
+func (p *passwordService) ForgotPassword(ctx context.Context, forgotPasswordDto dto.ForgotPasswordDto) error {
+    ctx, span := trace.Start(ctx, "PasswordSvc.ForgotPassword")
+    defer span.End()
+
+    user, err := p.userRepo.GetUserByEmail(ctx, forgotPasswordDto.Email)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return nil
+        }
+        return err
+    }
+
+    if user.Status != domain.UserStatusACTIVE {
+        return nil
+    }
+
+    resetToken, err := util.GenerateToken()
+    if err != nil {
+        return err
+    }
+
+    _, err = p.passwordResetRepo.CreatePasswordReset(ctx, store.CreatePasswordResetParams{
+        UserID:      user.ID,
+        HashedToken: util.HashToken(resetToken),
+        ExpiresAt:   time.Now().UTC().Add(constant.PasswordResetTokenTTL),
+    })
+    if err != nil {
+        return err
+    }
+
+    return p.mailer.SendPasswordReset(ctx, user, resetToken)
+}
+
+// ResetPassword sets the password of the user of the reset token, once.
+func (p *passwordService) ResetPassword(ctx context.Context, resetPasswordDto dto.ResetPasswordDto) (dto.UserDto, error) {
+    ctx, span := trace.Start(ctx, "PasswordSvc.ResetPassword")
+    defer span.End()
+
+    var userDto dto.UserDto
+
+    reset, err := p.passwordResetRepo.GetPasswordResetByHashedToken(ctx, util.HashToken(resetPasswordDto.Token))
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return userDto, errors.ErrInvalidPasswordResetToken
+        }
+        return userDto, err
+    }
+
+    changedAt := passwordChangedAt()
+    if reset.UsedAt.Valid || reset.IsExpired(changedAt) {
+        return userDto, errors.ErrInvalidPasswordResetToken
+    }
+
+    hashedPassword, err := util.HashPassword(resetPasswordDto.NewPassword)
+    if err != nil {
+        return userDto, err
+    }
+
+    user, err := p.passwordResetRepo.ResetPassword(ctx, store.ResetPasswordParams{
+        PasswordResetID: reset.ID,
+        HashedPassword:  hashedPassword,
+        ResetAt:         changedAt,
+    })
+    if err != nil {
+        return userDto, err
+    }
+
+    userDto = dto.NewUserDto(user)
+    return userDto, nil
+}
+
+// passwordChangedAt is now to the microsecond stored by postgres, for the tokens issued right after the change not
+// to be taken for tokens issued before it.
+func passwordChangedAt() time.Time {
+    return time.Now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,288 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestChangePassword(t *testing.T) {
+    user, password := util.RandomNewUser(util.RandomCreateUserDto())
+    newPassword := util.RandomString(8)
+
+    testcases := []struct {
+        name      string
+        reqDto    dto.ChangePasswordDto
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo)
+        checkResp func(t *testing.T, tokenMaker token.Maker, loggedInUserDto dto.LoggedInUserDto, err error)
+    }{
+        {
+            name:   "OK",
+            reqDto: dto.ChangePasswordDto{UserID: user.ID, CurrentPassword: password, NewPassword: newPassword},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockPasswordResetRepo.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.ChangePasswordParams) (domain.User, error) {
+                        require.Equal(t, user.ID, arg.UserID)
+                        require.NoError(t, util.CheckPassword(newPassword, arg.HashedPassword))
+                        require.WithinDuration(t, time.Now(), arg.ChangedAt, time.Second)
+
+                        updated := user
+                        updated.HashedPassword = arg.HashedPassword
+                        updated.PasswordChangedAt = arg.ChangedAt
+                        return updated, nil
+                    })
+            },
+            checkResp: func(t *testing.T, tokenMaker token.Maker, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, user.ID, loggedInUserDto.User.ID)
+
+                payload, err := tokenMaker.VerifyToken(loggedInUserDto.AccessToken)
+                require.NoError(t, err)
+                require.Equal(t, user.ID, payload.UserID)
+            },
+        },
+        {
+            name:   "IncorrectPassword",
+            reqDto: dto.ChangePasswordDto{UserID: user.ID, CurrentPassword: "incorrect", NewPassword: newPassword},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockPasswordResetRepo.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, tokenMaker token.Maker, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrIncorrectPassword.Error())
+            },
+        },
+        {
+            name:   "UserNotFound",
+            reqDto: dto.ChangePasswordDto{UserID: user.ID, CurrentPassword: password, NewPassword: newPassword},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(domain.User{}, sql.ErrNoRows)
+                mockPasswordResetRepo.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, tokenMaker token.Maker, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrUserNotFound.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockPasswordResetRepo := mockdb.NewMockPasswordResetRepo(ctrl)
+            mockMailer := mocksvc.NewMockMailer(ctrl)
+            tc.buildStub(mockUserRepo, mockPasswordResetRepo)
+
+            tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+            require.NoError(t, err)
+
+            passwordSvc := service.NewPasswordService(mockUserRepo, mockPasswordResetRepo, tokenMaker, mockMailer)
+            loggedInUserDto, err := passwordSvc.ChangePassword(context.Background(), tc.reqDto)
+            tc.checkResp(t, tokenMaker, loggedInUserDto, err)
+        })
+    }
+}
+
+func TestForgotPassword(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    user.Status = domain.UserStatusACTIVE
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo, mockMailer *mocksvc.MockMailer)
+    }{
+        {
+            name: "OK",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo, mockMailer *mocksvc.MockMailer) {
+                var hashedToken string
+                mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Times(1).Return(user, nil)
+                mockPasswordResetRepo.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.CreatePasswordResetParams) (domain.PasswordReset, error) {
+                        require.Equal(t, user.ID, arg.UserID)
+                        require.WithinDuration(t, time.Now().Add(constant.PasswordResetTokenTTL), arg.ExpiresAt, time.Second)
+                        hashedToken = arg.HashedToken
+                        return domain.PasswordReset{ID: 1, UserID: arg.UserID, HashedToken: arg.HashedToken, ExpiresAt: arg.ExpiresAt}, nil
+                    })
+                mockMailer.EXPECT().SendPasswordReset(gomock.Any(), user, gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, user domain.User, resetToken string) error {
+                        // only the hash of the token mailed is stored
+                        require.NotEqual(t, resetToken, hashedToken)
+                        require.Equal(t, hashedToken, util.HashToken(resetToken))
+                        return nil
+                    })
+            },
+        },
+        {
+            name: "UnknownEmail",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo, mockMailer *mocksvc.MockMailer) {
+                mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Times(1).Return(domain.User{}, sql.ErrNoRows)
+                mockPasswordResetRepo.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+                mockMailer.EXPECT().SendPasswordReset(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+        },
+        {
+            name: "BlockedUser",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockPasswordResetRepo *mockdb.MockPasswordResetRepo, mockMailer *mocksvc.MockMailer) {
+                blocked := user
+                blocked.Status = domain.UserStatusBLOCKED
+                mockUserRepo.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Times(1).Return(blocked, nil)
+                mockPasswordResetRepo.EXPECT().CreatePasswordReset(gomock.Any(), gomock.Any()).Times(0)
+                mockMailer.EXPECT().SendPasswordReset(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockPasswordResetRepo := mockdb.NewMockPasswordResetRepo(ctrl)
+            mockMailer := mocksvc.NewMockMailer(ctrl)
+            tc.buildStub(mockUserRepo, mockPasswordResetRepo, mockMailer)
+
+            tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+            require.NoError(t, err)
+
+            passwordSvc := service.NewPasswordService(mockUserRepo, mockPasswordResetRepo, tokenMaker, mockMailer)
+            err = passwordSvc.ForgotPassword(context.Background(), dto.ForgotPasswordDto{Email: user.Email})
+            require.NoError(t, err)
+        })
+    }
+}
+
+func TestResetPassword(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    resetToken, err := util.GenerateToken()
+    require.NoError(t, err)
+    newPassword := util.RandomString(8)
+
+    reset := domain.PasswordReset{
+        ID:          1,
+        UserID:      user.ID,
+        HashedToken: util.HashToken(resetToken),
+        ExpiresAt:   time.Now().UTC().Add(time.Minute),
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockPasswordResetRepo *mockdb.MockPasswordResetRepo)
+        checkResp func(t *testing.T, userDto dto.UserDto, err error)
+    }{
+        {
+            name: "OK",
+            buildStub: func(mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                mockPasswordResetRepo.EXPECT().GetPasswordResetByHashedToken(gomock.Any(), reset.HashedToken).Times(1).Return(reset, nil)
+                mockPasswordResetRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.ResetPasswordParams) (domain.User, error) {
+                        require.Equal(t, reset.ID, arg.PasswordResetID)
+                        require.NoError(t, util.CheckPassword(newPassword, arg.HashedPassword))
+                        return user, nil
+                    })
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, user.ID, userDto.ID)
+            },
+        },
+        {
+            name: "UnknownToken",
+            buildStub: func(mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                mockPasswordResetRepo.EXPECT().GetPasswordResetByHashedToken(gomock.Any(), reset.HashedToken).Times(1).Return(domain.PasswordReset{}, sql.ErrNoRows)
+                mockPasswordResetRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidPasswordResetToken.Error())
+            },
+        },
+        {
+            name: "UsedToken",
+            buildStub: func(mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                used := reset
+                used.UsedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+                mockPasswordResetRepo.EXPECT().GetPasswordResetByHashedToken(gomock.Any(), reset.HashedToken).Times(1).Return(used, nil)
+                mockPasswordResetRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidPasswordResetToken.Error())
+            },
+        },
+        {
+            name: "ExpiredToken",
+            buildStub: func(mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                expired := reset
+                expired.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+                mockPasswordResetRepo.EXPECT().GetPasswordResetByHashedToken(gomock.Any(), reset.HashedToken).Times(1).Return(expired, nil)
+                mockPasswordResetRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidPasswordResetToken.Error())
+            },
+        },
+        {
+            name: "UsedConcurrently",
+            buildStub: func(mockPasswordResetRepo *mockdb.MockPasswordResetRepo) {
+                mockPasswordResetRepo.EXPECT().GetPasswordResetByHashedToken(gomock.Any(), reset.HashedToken).Times(1).Return(reset, nil)
+                mockPasswordResetRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Times(1).Return(domain.User{}, errors.ErrInvalidPasswordResetToken)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidPasswordResetToken.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockPasswordResetRepo := mockdb.NewMockPasswordResetRepo(ctrl)
+            mockMailer := mocksvc.NewMockMailer(ctrl)
+            tc.buildStub(mockPasswordResetRepo)
+
+            tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+            require.NoError(t, err)
+
+            passwordSvc := service.NewPasswordService(mockUserRepo, mockPasswordResetRepo, tokenMaker, mockMailer)
+            userDto, err := passwordSvc.ResetPassword(context.Background(), dto.ResetPasswordDto{Token: resetToken, NewPassword: newPassword})
+            tc.checkResp(t, userDto, err)
+        })
+    }
+}
+
+func TestNotifierMailer(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    mockNotifier := mocksvc.NewMockNotifier(ctrl)
+    mockNotifier.EXPECT().Send(gomock.Any(), gomock.Any()).Times(1).
+        DoAndReturn(func(ctx context.Context, msg service.Message) error {
+            require.Equal(t, user.ID, msg.UserID)
+            require.Equal(t, user.Email, msg.Email)
+            require.True(t, strings.Contains(msg.Body, "reset-token"))
+            return nil
+        })
+
+    err := service.NewNotifierMailer(mockNotifier).SendPasswordReset(context.Background(), user, "reset-token")
+    require.NoError(t, err)
+}
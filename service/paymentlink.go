@@ -0,0 +1,392 @@
+package service
+
+import (
+    "bytes"
+    "context"
+    "crypto/rand"
+    "database/sql"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    log "github.com/sirupsen/logrus"
+    "net/http"
+    "net/url"
+    "strconv"
+    "time"
+)
+
+type PaymentLinkSvc interface {
+    Create(ctx context.Context, createPaymentLinkDto dto.CreatePaymentLinkDto) (dto.PaymentLinkDto, error)
+    List(ctx context.Context, listPaymentLinksDto dto.ListPaymentLinksDto) ([]dto.PaymentLinkDto, error)
+    Disable(ctx context.Context, userID int64, id int64) (dto.PaymentLinkDto, error)
+    GetPage(ctx context.Context, slug string) (dto.PaymentLinkPageDto, error)
+    StartCheckout(ctx context.Context, startCheckoutDto dto.StartCheckoutDto) (dto.CheckoutSessionDto, error)
+    CompleteCheckout(ctx context.Context, completeCheckoutDto dto.CompleteCheckoutDto) (dto.CheckoutResultDto, error)
+}
+
+type paymentLinkService struct {
+    paymentLinkRepo     store.PaymentLinkRepo
+    checkoutSessionRepo store.CheckoutSessionRepo
+    merchantRepo        store.MerchantRepo
+    walletRepo          store.WalletRepo
+    twoFactorSvc        TwoFactorSvc
+    client              *http.Client
+}
+
+func NewPaymentLinkService(paymentLinkRepo store.PaymentLinkRepo, checkoutSessionRepo store.CheckoutSessionRepo, merchantRepo store.MerchantRepo,
+    walletRepo store.WalletRepo, twoFactorSvc TwoFactorSvc, client *http.Client) PaymentLinkSvc {
+    return &paymentLinkService{
+        paymentLinkRepo:     paymentLinkRepo,
+        checkoutSessionRepo: checkoutSessionRepo,
+        merchantRepo:        merchantRepo,
+        walletRepo:          walletRepo,
+        twoFactorSvc:        twoFactorSvc,
+        client:              client,
+    }
+}
+
+// Create is done by a merchant, the link collects in the currency of the merchant's settlement wallet.
+func (p *paymentLinkService) Create(ctx context.Context, createPaymentLinkDto dto.CreatePaymentLinkDto) (dto.PaymentLinkDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentLinkSvc.Create")
+    defer span.End()
+
+    var res dto.PaymentLinkDto
+
+    merchant, err := p.getMerchantByUserID(ctx, createPaymentLinkDto.UserID)
+    if err != nil {
+        return res, err
+    }
+
+    wallet, err := p.walletRepo.GetWallet(ctx, merchant.SettlementWalletID)
+    if err != nil {
+        return res, err
+    }
+
+    if wallet.Currency != createPaymentLinkDto.Currency {
+        return res, errors.ErrCurrencyMismatch
+    }
+
+    slug, err := newPaymentLinkSlug()
+    if err != nil {
+        return res, err
+    }
+
+    var expiresAt sql.NullTime
+    if createPaymentLinkDto.ExpiresInSeconds > 0 {
+        expiresAt = sql.NullTime{Time: time.Now().UTC().Add(time.Duration(createPaymentLinkDto.ExpiresInSeconds) * time.Second), Valid: true}
+    }
+
+    link, err := p.paymentLinkRepo.CreatePaymentLink(ctx, store.CreatePaymentLinkParams{
+        MerchantID:  merchant.ID,
+        Slug:        slug,
+        Description: createPaymentLinkDto.Description,
+        Amount:      createPaymentLinkDto.Amount,
+        Currency:    createPaymentLinkDto.Currency,
+        Mode:        createPaymentLinkDto.Mode,
+        Status:      domain.PaymentLinkStatusACTIVE,
+        SuccessUrl:  createPaymentLinkDto.SuccessUrl,
+        ExpiresAt:   expiresAt,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewPaymentLinkDto(link)
+    return res, nil
+}
+
+func (p *paymentLinkService) List(ctx context.Context, listPaymentLinksDto dto.ListPaymentLinksDto) ([]dto.PaymentLinkDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentLinkSvc.List")
+    defer span.End()
+
+    res := []dto.PaymentLinkDto{}
+
+    merchant, err := p.getMerchantByUserID(ctx, listPaymentLinksDto.UserID)
+    if err != nil {
+        return res, err
+    }
+
+    links, err := p.paymentLinkRepo.ListPaymentLinks(ctx, store.ListPaymentLinksParams{
+        MerchantID: merchant.ID,
+        Limit:      listPaymentLinksDto.Limit,
+        Offset:     listPaymentLinksDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, l := range links {
+        res = append(res, dto.NewPaymentLinkDto(l))
+    }
+
+    return res, nil
+}
+
+// Disable stops the link from starting or completing checkouts.
+func (p *paymentLinkService) Disable(ctx context.Context, userID int64, id int64) (dto.PaymentLinkDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentLinkSvc.Disable")
+    defer span.End()
+
+    var res dto.PaymentLinkDto
+
+    merchant, err := p.getMerchantByUserID(ctx, userID)
+    if err != nil {
+        return res, err
+    }
+
+    link, err := p.paymentLinkRepo.GetPaymentLink(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrPaymentLinkNotFound
+        }
+        return res, err
+    }
+
+    if link.MerchantID != merchant.ID {
+        return res, errors.ErrPaymentLinkNotFound
+    }
+
+    link, err = p.paymentLinkRepo.UpdatePaymentLinkStatus(ctx, store.UpdatePaymentLinkStatusParams{
+        ID:     link.ID,
+        Status: domain.PaymentLinkStatusDISABLED,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewPaymentLinkDto(link)
+    return res, nil
+}
+
+// GetPage is public, it shows the link to anyone having its slug.
+func (p *paymentLinkService) GetPage(ctx context.Context, slug string) (dto.PaymentLinkPageDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentLinkSvc.GetPage")
+    defer span.End()
+
+    var res dto.PaymentLinkPageDto
+
+    link, err := p.getPaymentLinkBySlug(ctx, slug)
+    if err != nil {
+        return res, err
+    }
+
+    merchant, err := p.merchantRepo.GetMerchant(ctx, link.MerchantID)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewPaymentLinkPageDto(link, merchant)
+    return res, nil
+}
+
+// StartCheckout opens a session for the payer, to be completed within constant.CheckoutSessionTTL.
+// The money goes to the settlement wallet the merchant has when the session starts.
+func (p *paymentLinkService) StartCheckout(ctx context.Context, startCheckoutDto dto.StartCheckoutDto) (dto.CheckoutSessionDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentLinkSvc.StartCheckout")
+    defer span.End()
+
+    var res dto.CheckoutSessionDto
+
+    link, err := p.getPaymentLinkBySlug(ctx, startCheckoutDto.Slug)
+    if err != nil {
+        return res, err
+    }
+
+    if link.Status != domain.PaymentLinkStatusACTIVE {
+        return res, errors.ErrPaymentLinkInactive
+    }
+
+    if link.IsExpired(time.Now().UTC()) {
+        return res, errors.ErrPaymentLinkExpired
+    }
+
+    amount := link.Amount
+    if link.IsOpenAmount() {
+        amount = startCheckoutDto.Amount
+    }
+
+    if amount == 0 || (startCheckoutDto.Amount != 0 && startCheckoutDto.Amount != amount) {
+        return res, errors.ErrCheckoutAmountMismatch
+    }
+
+    merchant, err := p.merchantRepo.GetMerchant(ctx, link.MerchantID)
+    if err != nil {
+        return res, err
+    }
+
+    session, err := p.checkoutSessionRepo.CreateCheckoutSession(ctx, store.CreateCheckoutSessionParams{
+        PaymentLinkID: link.ID,
+        UserID:        startCheckoutDto.UserID,
+        ToWalletID:    merchant.SettlementWalletID,
+        Amount:        amount,
+        Status:        domain.CheckoutSessionStatusOPEN,
+        ExpiresAt:     time.Now().UTC().Add(constant.CheckoutSessionTTL),
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewCheckoutSessionDto(session)
+    return res, nil
+}
+
+// CompleteCheckout pays the session from the payer's wallet, stepped up for the payer. The merchant hears about it through its
+// callback url and the checkout.completed webhook, the payer is redirected to the success url of the link.
+func (p *paymentLinkService) CompleteCheckout(ctx context.Context, completeCheckoutDto dto.CompleteCheckoutDto) (dto.CheckoutResultDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentLinkSvc.CompleteCheckout")
+    defer span.End()
+
+    var res dto.CheckoutResultDto
+
+    session, err := p.checkoutSessionRepo.GetCheckoutSession(ctx, completeCheckoutDto.SessionID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrCheckoutSessionNotFound
+        }
+        return res, err
+    }
+
+    if session.UserID != completeCheckoutDto.UserID {
+        return res, errors.ErrCheckoutSessionNotFound
+    }
+
+    wallet, err := p.walletRepo.GetWalletByAddress(ctx, completeCheckoutDto.FromWalletAddress)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrWalletNotFound
+        }
+        return res, err
+    }
+
+    if wallet.UserID != completeCheckoutDto.UserID {
+        return res, errors.ErrWalletNotFound
+    }
+
+    ctx, err = stepUp(ctx, p.twoFactorSvc, dto.StepUpDto{
+        UserID:   wallet.UserID,
+        Amount:   session.Amount,
+        Currency: wallet.Currency,
+        Code:     completeCheckoutDto.TOTPCode,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    completed, err := p.checkoutSessionRepo.CompleteCheckoutSession(ctx, store.CompleteCheckoutSessionParams{
+        ID:           session.ID,
+        FromWalletID: wallet.ID,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    checkoutEvent := dto.NewCheckoutEventDto(completed)
+    merchant, err := p.merchantRepo.GetMerchant(ctx, completed.PaymentLink.MerchantID)
+    if err != nil {
+        logging.FromContext(ctx).WithField("checkout_session_id", completed.Session.ID).Error("failed to get merchant for the callback: ", err)
+    } else if merchant.CallbackUrl != "" {
+        go p.sendCallback(logging.FromContext(ctx), merchant.CallbackUrl, checkoutEvent)
+    }
+
+    res = dto.CheckoutResultDto{
+        Session:     dto.NewCheckoutSessionDto(completed.Session),
+        Transfer:    dto.NewWalletTransferDto(completed.Transfer),
+        RedirectUrl: successRedirectUrl(completed.PaymentLink.SuccessUrl, completed.Session.ID),
+    }
+    return res, nil
+}
+
+// sendCallback posts the event to the merchant's callback url once, it is not signed nor retried. The
+// merchants needing that subscribe a webhook endpoint to checkout.completed.
+func (p *paymentLinkService) sendCallback(logger *log.Entry, callbackUrl string, event dto.CheckoutEventDto) {
+    ctx, cancel := context.WithTimeout(context.Background(), constant.CheckoutCallbackTimeout)
+    defer cancel()
+
+    logger = logger.WithFields(log.Fields{
+        "checkout_session_id": event.SessionID,
+        "callback_url":        callbackUrl,
+    })
+
+    payload, err := json.Marshal(event)
+    if err != nil {
+        logger.Error("failed to encode checkout callback: ", err)
+        return
+    }
+
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackUrl, bytes.NewReader(payload))
+    if err != nil {
+        logger.Error("failed to create checkout callback: ", err)
+        return
+    }
+
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set(constant.WebhookEventTypeHeaderKey, string(domain.EventTypeCheckoutCompleted))
+
+    resp, err := p.client.Do(req)
+    if err != nil {
+        logger.Error("failed to send checkout callback: ", err)
+        return
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        logger.Error("checkout callback failed: ", fmt.Errorf("unexpected status code %d", resp.StatusCode))
+    }
+}
+
+// successRedirectUrl adds the session id to the success url, so the merchant can match the payer's return.
+func successRedirectUrl(successUrl string, sessionID int64) string {
+    if successUrl == "" {
+        return ""
+    }
+
+    u, err := url.Parse(successUrl)
+    if err != nil {
+        return successUrl
+    }
+
+    query := u.Query()
+    query.Set("checkout_session_id", strconv.FormatInt(sessionID, 10))
+    u.RawQuery = query.Encode()
+    return u.String()
+}
+
+func (p *paymentLinkService) getMerchantByUserID(ctx context.Context, userID int64) (domain.Merchant, error) {
+    merchant, err := p.merchantRepo.GetMerchantByUserID(ctx, userID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return merchant, errors.ErrMerchantNotFound
+        }
+        return merchant, err
+    }
+
+    return merchant, nil
+}
+
+func (p *paymentLinkService) getPaymentLinkBySlug(ctx context.Context, slug string) (domain.PaymentLink, error) {
+    link, err := p.paymentLinkRepo.GetPaymentLinkBySlug(ctx, slug)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return link, errors.ErrPaymentLinkNotFound
+        }
+        return link, err
+    }
+
+    return link, nil
+}
+
+func newPaymentLinkSlug() (string, error) {
+    b := make([]byte, constant.PaymentLinkSlugLength/2)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+
+    return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,275 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "encoding/json"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestCreatePaymentLink(t *testing.T) {
+    merchant := domain.Merchant{ID: 1, UserID: 10, SettlementWalletID: 2}
+    wallet := domain.Wallet{ID: 2, UserID: 10, Currency: "INR"}
+
+    testcases := []struct {
+        name      string
+        createDto dto.CreatePaymentLinkDto
+        buildStub func(mockPaymentLinkRepo *mockdb.MockPaymentLinkRepo)
+        checkResp func(t *testing.T, res dto.PaymentLinkDto, err error)
+    }{
+        {
+            name:      "Ok",
+            createDto: dto.CreatePaymentLinkDto{UserID: merchant.UserID, Amount: 500, Currency: "INR", Mode: domain.PaymentLinkModeSINGLEUSE, ExpiresInSeconds: 3600},
+            buildStub: func(mockPaymentLinkRepo *mockdb.MockPaymentLinkRepo) {
+                mockPaymentLinkRepo.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.CreatePaymentLinkParams) (domain.PaymentLink, error) {
+                        require.Equal(t, merchant.ID, arg.MerchantID)
+                        require.Regexp(t, `^[0-9a-f]{12}$`, arg.Slug)
+                        require.Equal(t, domain.PaymentLinkStatusACTIVE, arg.Status)
+                        require.True(t, arg.ExpiresAt.Valid)
+                        require.WithinDuration(t, time.Now().UTC().Add(time.Hour), arg.ExpiresAt.Time, time.Second)
+
+                        return domain.PaymentLink{ID: 1, MerchantID: arg.MerchantID, Slug: arg.Slug, Amount: arg.Amount, ExpiresAt: arg.ExpiresAt}, nil
+                    })
+            },
+            checkResp: func(t *testing.T, res dto.PaymentLinkDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(500), res.Amount)
+                require.NotNil(t, res.ExpiresAt)
+            },
+        },
+        {
+            name:      "CurrencyMismatch",
+            createDto: dto.CreatePaymentLinkDto{UserID: merchant.UserID, Currency: "USD", Mode: domain.PaymentLinkModeMULTIUSE},
+            buildStub: func(mockPaymentLinkRepo *mockdb.MockPaymentLinkRepo) {
+                mockPaymentLinkRepo.EXPECT().CreatePaymentLink(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentLinkDto, err error) {
+                require.EqualError(t, err, errors.ErrCurrencyMismatch.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockPaymentLinkRepo := mockdb.NewMockPaymentLinkRepo(ctrl)
+            mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            mockMerchantRepo.EXPECT().GetMerchantByUserID(gomock.Any(), merchant.UserID).Times(1).Return(merchant, nil)
+            mockWalletRepo.EXPECT().GetWallet(gomock.Any(), merchant.SettlementWalletID).Times(1).Return(wallet, nil)
+            tc.buildStub(mockPaymentLinkRepo)
+
+            paymentLinkSvc := service.NewPaymentLinkService(mockPaymentLinkRepo, mockdb.NewMockCheckoutSessionRepo(ctrl), mockMerchantRepo, mockWalletRepo,
+                mocksvc.NewMockTwoFactorSvc(ctrl), http.DefaultClient)
+            res, err := paymentLinkSvc.Create(context.TODO(), tc.createDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestStartCheckout(t *testing.T) {
+    merchant := domain.Merchant{ID: 1, UserID: 10, SettlementWalletID: 2}
+    fixedLink := domain.PaymentLink{ID: 1, MerchantID: merchant.ID, Slug: "fixed", Amount: 500, Status: domain.PaymentLinkStatusACTIVE}
+    openLink := domain.PaymentLink{ID: 2, MerchantID: merchant.ID, Slug: "open", Status: domain.PaymentLinkStatusACTIVE}
+    expiredLink := domain.PaymentLink{ID: 3, MerchantID: merchant.ID, Slug: "expired", Status: domain.PaymentLinkStatusACTIVE,
+        ExpiresAt: sql.NullTime{Time: time.Now().UTC().Add(-time.Minute), Valid: true}}
+    usedLink := domain.PaymentLink{ID: 4, MerchantID: merchant.ID, Slug: "used", Amount: 500, Status: domain.PaymentLinkStatusCOMPLETED}
+
+    testcases := []struct {
+        name       string
+        link       domain.PaymentLink
+        amount     int64
+        wantAmount int64
+        wantErr    error
+    }{
+        {name: "FixedAmount", link: fixedLink, wantAmount: 500},
+        {name: "FixedAmountRepeated", link: fixedLink, amount: 500, wantAmount: 500},
+        {name: "FixedAmountMismatch", link: fixedLink, amount: 400, wantErr: errors.ErrCheckoutAmountMismatch},
+        {name: "OpenAmount", link: openLink, amount: 250, wantAmount: 250},
+        {name: "OpenAmountMissing", link: openLink, wantErr: errors.ErrCheckoutAmountMismatch},
+        {name: "Expired", link: expiredLink, amount: 250, wantErr: errors.ErrPaymentLinkExpired},
+        {name: "AlreadyUsed", link: usedLink, wantErr: errors.ErrPaymentLinkInactive},
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockPaymentLinkRepo := mockdb.NewMockPaymentLinkRepo(ctrl)
+            mockCheckoutSessionRepo := mockdb.NewMockCheckoutSessionRepo(ctrl)
+            mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+
+            mockPaymentLinkRepo.EXPECT().GetPaymentLinkBySlug(gomock.Any(), tc.link.Slug).Times(1).Return(tc.link, nil)
+            if tc.wantErr == nil {
+                mockMerchantRepo.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Times(1).Return(merchant, nil)
+                mockCheckoutSessionRepo.EXPECT().CreateCheckoutSession(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.CreateCheckoutSessionParams) (domain.CheckoutSession, error) {
+                        require.Equal(t, tc.link.ID, arg.PaymentLinkID)
+                        require.Equal(t, int64(20), arg.UserID)
+                        require.Equal(t, merchant.SettlementWalletID, arg.ToWalletID)
+                        require.Equal(t, domain.CheckoutSessionStatusOPEN, arg.Status)
+
+                        return domain.CheckoutSession{ID: 1, PaymentLinkID: arg.PaymentLinkID, Amount: arg.Amount, Status: arg.Status}, nil
+                    })
+            } else {
+                mockCheckoutSessionRepo.EXPECT().CreateCheckoutSession(gomock.Any(), gomock.Any()).Times(0)
+            }
+
+            paymentLinkSvc := service.NewPaymentLinkService(mockPaymentLinkRepo, mockCheckoutSessionRepo, mockMerchantRepo, mockdb.NewMockWalletRepo(ctrl),
+                mocksvc.NewMockTwoFactorSvc(ctrl), http.DefaultClient)
+            res, err := paymentLinkSvc.StartCheckout(context.TODO(), dto.StartCheckoutDto{Slug: tc.link.Slug, UserID: 20, Amount: tc.amount})
+            if tc.wantErr != nil {
+                require.EqualError(t, err, tc.wantErr.Error())
+                return
+            }
+
+            require.NoError(t, err)
+            require.Equal(t, tc.wantAmount, res.Amount)
+        })
+    }
+}
+
+func TestCompleteCheckout(t *testing.T) {
+    callbacks := make(chan dto.CheckoutEventDto, 1)
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        var event dto.CheckoutEventDto
+        _ = json.NewDecoder(r.Body).Decode(&event)
+        callbacks <- event
+    }))
+    defer server.Close()
+
+    merchant := domain.Merchant{ID: 1, UserID: 10, SettlementWalletID: 2, CallbackUrl: server.URL}
+    payer := domain.Wallet{ID: 3, UserID: 20, Address: "payer@my.wallet", Currency: "INR"}
+    session := domain.CheckoutSession{ID: 7, PaymentLinkID: 1, UserID: payer.UserID, ToWalletID: 2, Amount: 500, Status: domain.CheckoutSessionStatusOPEN}
+
+    testcases := []struct {
+        name        string
+        completeDto dto.CompleteCheckoutDto
+        buildStub   func(mockCheckoutSessionRepo *mockdb.MockCheckoutSessionRepo, mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp   func(t *testing.T, res dto.CheckoutResultDto, err error)
+    }{
+        {
+            name:        "Ok",
+            completeDto: dto.CompleteCheckoutDto{SessionID: session.ID, UserID: payer.UserID, FromWalletAddress: payer.Address, TOTPCode: "123456"},
+            buildStub: func(mockCheckoutSessionRepo *mockdb.MockCheckoutSessionRepo, mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                completed := session
+                completed.Status = domain.CheckoutSessionStatusCOMPLETED
+                completed.FromWalletID = sql.NullInt64{Int64: payer.ID, Valid: true}
+                completed.TransferID = sql.NullInt64{Int64: 9, Valid: true}
+
+                mockCheckoutSessionRepo.EXPECT().GetCheckoutSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: payer.UserID, Amount: session.Amount, Currency: "INR", Code: "123456"}).Times(1)
+                mockCheckoutSessionRepo.EXPECT().CompleteCheckoutSession(gomock.Any(), store.CompleteCheckoutSessionParams{ID: session.ID, FromWalletID: payer.ID}).Times(1).
+                    Return(store.CompleteCheckoutSessionResult{
+                        Session:     completed,
+                        PaymentLink: domain.PaymentLink{ID: 1, MerchantID: merchant.ID, Slug: "abc", Currency: "INR", SuccessUrl: "https://shop.example.com/thanks?order=42"},
+                        Transfer: store.WalletTransferResult{
+                            Wallet:   payer,
+                            ToWallet: domain.Wallet{ID: 2, UserID: merchant.UserID},
+                            Transfer: domain.Transfer{ID: 9, Amount: 500},
+                        },
+                    }, nil)
+                mockMerchantRepo.EXPECT().GetMerchant(gomock.Any(), merchant.ID).Times(1).Return(merchant, nil)
+            },
+            checkResp: func(t *testing.T, res dto.CheckoutResultDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.CheckoutSessionStatusCOMPLETED, res.Session.Status)
+                require.Equal(t, int64(9), res.Session.TransferID)
+                require.Equal(t, "https://shop.example.com/thanks?checkout_session_id=7&order=42", res.RedirectUrl)
+
+                select {
+                case event := <-callbacks:
+                    require.Equal(t, session.ID, event.SessionID)
+                    require.Equal(t, int64(9), event.TransferID)
+                    require.Equal(t, "INR", event.Currency)
+                case <-time.After(5 * time.Second):
+                    t.Fatal("callback not received")
+                }
+            },
+        },
+        {
+            name:        "SessionOfOtherPayer",
+            completeDto: dto.CompleteCheckoutDto{SessionID: session.ID, UserID: 21, FromWalletAddress: payer.Address},
+            buildStub: func(mockCheckoutSessionRepo *mockdb.MockCheckoutSessionRepo, mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockCheckoutSessionRepo.EXPECT().GetCheckoutSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+                mockCheckoutSessionRepo.EXPECT().CompleteCheckoutSession(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.CheckoutResultDto, err error) {
+                require.EqualError(t, err, errors.ErrCheckoutSessionNotFound.Error())
+            },
+        },
+        {
+            name:        "WalletOfOtherUser",
+            completeDto: dto.CompleteCheckoutDto{SessionID: session.ID, UserID: payer.UserID, FromWalletAddress: "other@my.wallet"},
+            buildStub: func(mockCheckoutSessionRepo *mockdb.MockCheckoutSessionRepo, mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockCheckoutSessionRepo.EXPECT().GetCheckoutSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), "other@my.wallet").Times(1).Return(domain.Wallet{ID: 4, UserID: 21}, nil)
+                mockCheckoutSessionRepo.EXPECT().CompleteCheckoutSession(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.CheckoutResultDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name:        "AlreadyUsed",
+            completeDto: dto.CompleteCheckoutDto{SessionID: session.ID, UserID: payer.UserID, FromWalletAddress: payer.Address},
+            buildStub: func(mockCheckoutSessionRepo *mockdb.MockCheckoutSessionRepo, mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockCheckoutSessionRepo.EXPECT().GetCheckoutSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1)
+                mockCheckoutSessionRepo.EXPECT().CompleteCheckoutSession(gomock.Any(), gomock.Any()).Times(1).Return(store.CompleteCheckoutSessionResult{}, errors.ErrPaymentLinkInactive)
+            },
+            checkResp: func(t *testing.T, res dto.CheckoutResultDto, err error) {
+                require.EqualError(t, err, errors.ErrPaymentLinkInactive.Error())
+            },
+        },
+        {
+            name:        "TOTPRequired",
+            completeDto: dto.CompleteCheckoutDto{SessionID: session.ID, UserID: payer.UserID, FromWalletAddress: payer.Address},
+            buildStub: func(mockCheckoutSessionRepo *mockdb.MockCheckoutSessionRepo, mockMerchantRepo *mockdb.MockMerchantRepo, mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockCheckoutSessionRepo.EXPECT().GetCheckoutSession(gomock.Any(), session.ID).Times(1).Return(session, nil)
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrTOTPRequired)
+                mockCheckoutSessionRepo.EXPECT().CompleteCheckoutSession(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.CheckoutResultDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPRequired.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockCheckoutSessionRepo := mockdb.NewMockCheckoutSessionRepo(ctrl)
+            mockMerchantRepo := mockdb.NewMockMerchantRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockCheckoutSessionRepo, mockMerchantRepo, mockWalletRepo, mockTwoFactorSvc)
+
+            paymentLinkSvc := service.NewPaymentLinkService(mockdb.NewMockPaymentLinkRepo(ctrl), mockCheckoutSessionRepo, mockMerchantRepo, mockWalletRepo,
+                mockTwoFactorSvc, server.Client())
+            res, err := paymentLinkSvc.CompleteCheckout(context.TODO(), tc.completeDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
@@ -6,13 +6,15 @@ import (
     "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
     "github.com/pranayhere/simple-wallet/store"
 )
 
 type PaymentRequestSvc interface {
     Create(ctx context.Context, patReqDto dto.PaymentRequestDto) (dto.PaymentRequestDto, error)
     List(ctx context.Context, patReqDto dto.ListPaymentRequestsDto) ([]dto.PaymentRequestDto, error)
-    Approve(ctx context.Context, id int64) (dto.PaymentRequestDto, error)
+    Approve(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.PaymentRequestDto, error)
+    Pay(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.WalletTransferResultDto, error)
     Refuse(ctx context.Context, id int64) (dto.PaymentRequestDto, error)
     UpdateStatus(ctx context.Context, id int64, status domain.PaymentRequestStatus) (dto.PaymentRequestDto, error)
     Get(ctx context.Context, id int64) (dto.PaymentRequestDto, error)
@@ -21,16 +23,21 @@ type PaymentRequestSvc interface {
 type paymentRequestService struct {
     paymentRequestRepo store.PaymentRequestRepo
     walletSvc          WalletSvc
+    twoFactorSvc       TwoFactorSvc
 }
 
-func NewPaymentRequestService(paymentRequestRepo store.PaymentRequestRepo, walletSvc WalletSvc) PaymentRequestSvc {
+func NewPaymentRequestService(paymentRequestRepo store.PaymentRequestRepo, walletSvc WalletSvc, twoFactorSvc TwoFactorSvc) PaymentRequestSvc {
     return &paymentRequestService{
         paymentRequestRepo: paymentRequestRepo,
         walletSvc:          walletSvc,
+        twoFactorSvc:       twoFactorSvc,
     }
 }
 
 func (p *paymentRequestService) Create(ctx context.Context, payReqDto dto.PaymentRequestDto) (dto.PaymentRequestDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.Create")
+    defer span.End()
+
     var res dto.PaymentRequestDto
 
     fromWallet, err := p.walletSvc.GetWalletByAddress(ctx, payReqDto.FromWalletAddress)
@@ -59,22 +66,72 @@ func (p *paymentRequestService) Create(ctx context.Context, payReqDto dto.Paymen
     return res, nil
 }
 
-func (p *paymentRequestService) Approve(ctx context.Context, id int64) (dto.PaymentRequestDto, error) {
+// Approve pays a payment request waiting for approval, the request ends PAYMENT_SUCCESS or PAYMENT_FAILED. A failed
+// payment is reported in the status of the returned request. It is stepped up for the payer before the approval, a
+// missing or invalid code leaves the request waiting.
+func (p *paymentRequestService) Approve(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.PaymentRequestDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.Approve")
+    defer span.End()
+
+    res, _, err := p.approve(ctx, approveDto)
+    if err != nil && res.Status == domain.PaymentRequestStatusPAYMENTFAILED {
+        return res, nil
+    }
+
+    return res, err
+}
+
+// Pay is Approve for the payer scanning the qr code of the request, it returns the transfer and the error of a
+// failed payment.
+func (p *paymentRequestService) Pay(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.WalletTransferResultDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.Pay")
+    defer span.End()
+
+    _, transfer, err := p.approve(ctx, approveDto)
+    return transfer, err
+}
+
+func (p *paymentRequestService) approve(ctx context.Context, approveDto dto.ApprovePaymentRequestDto) (dto.PaymentRequestDto, dto.WalletTransferResultDto, error) {
     var res dto.PaymentRequestDto
+    var transfer dto.WalletTransferResultDto
 
-    _, err := p.Get(ctx, id)
+    id := approveDto.ID
+    payReqDto, err := p.Get(ctx, id)
     if err != nil {
-        return res, err
+        return res, transfer, err
     }
 
-    arg := store.UpdatePaymentRequestParams{
-        ID:     id,
-        Status: domain.PaymentRequestStatusAPPROVED,
+    // the code is not asked for a request paid already
+    if payReqDto.Status != domain.PaymentRequestStatusWAITINGAPPROVAL {
+        return res, transfer, errors.ErrPaymentRequestNotPending
     }
 
-    payReq, err := p.paymentRequestRepo.UpdatePaymentRequest(ctx, arg)
+    fromWallet, err := p.walletSvc.GetWalletById(ctx, payReqDto.FromWalletID)
     if err != nil {
-        return res, err
+        return res, transfer, err
+    }
+
+    ctx, err = stepUp(ctx, p.twoFactorSvc, dto.StepUpDto{
+        UserID:   fromWallet.UserID,
+        Amount:   payReqDto.Amount,
+        Currency: fromWallet.Currency,
+        Code:     approveDto.TOTPCode,
+    })
+    if err != nil {
+        return res, transfer, err
+    }
+
+    // only one approval moves the request out of WAITING_APPROVAL, a request is never paid twice
+    payReq, err := p.paymentRequestRepo.ApprovePaymentRequest(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            if _, err := p.Get(ctx, id); err != nil {
+                return res, transfer, err
+            }
+            return res, transfer, errors.ErrPaymentRequestNotPending
+        }
+
+        return res, transfer, err
     }
 
     transferArg := dto.TransferMoneyByWalletIDDto{
@@ -83,31 +140,29 @@ func (p *paymentRequestService) Approve(ctx context.Context, id int64) (dto.Paym
         Amount:       payReq.Amount,
     }
 
-    _, err = p.walletSvc.PayByWalletID(ctx, transferArg)
-    if err != nil {
+    transfer, payErr := p.walletSvc.PayByWalletID(ctx, transferArg)
+    if payErr != nil {
         res, err = p.UpdateStatus(ctx, id, domain.PaymentRequestStatusPAYMENTFAILED)
         if err != nil {
-            return res, err
+            return res, transfer, err
         }
 
-        return res, err
+        return res, transfer, payErr
     }
 
     res, err = p.UpdateStatus(ctx, id, domain.PaymentRequestStatusPAYMENTSUCCESS)
-    if err != nil {
-        return res, err
-    }
-
-    return res, err
-
+    return res, transfer, err
 }
 
 func (p *paymentRequestService) Refuse(ctx context.Context, id int64) (dto.PaymentRequestDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.Refuse")
+    defer span.End()
+
     var res dto.PaymentRequestDto
 
     arg := store.UpdatePaymentRequestParams{
         ID:     id,
-        Status: domain.PaymentRequestStatusAPPROVED,
+        Status: domain.PaymentRequestStatusREFUSED,
     }
 
     payReq, err := p.paymentRequestRepo.UpdatePaymentRequest(ctx, arg)
@@ -120,6 +175,9 @@ func (p *paymentRequestService) Refuse(ctx context.Context, id int64) (dto.Payme
 }
 
 func (p *paymentRequestService) UpdateStatus(ctx context.Context, id int64, status domain.PaymentRequestStatus) (dto.PaymentRequestDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.UpdateStatus")
+    defer span.End()
+
     var res dto.PaymentRequestDto
 
     arg := store.UpdatePaymentRequestParams{
@@ -137,6 +195,9 @@ func (p *paymentRequestService) UpdateStatus(ctx context.Context, id int64, stat
 }
 
 func (p *paymentRequestService) Get(ctx context.Context, id int64) (dto.PaymentRequestDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.Get")
+    defer span.End()
+
     var res dto.PaymentRequestDto
 
     payReq, err := p.paymentRequestRepo.GetPaymentRequest(ctx, id)
@@ -153,6 +214,9 @@ func (p *paymentRequestService) Get(ctx context.Context, id int64) (dto.PaymentR
 }
 
 func (p *paymentRequestService) List(ctx context.Context, listPayReqDto dto.ListPaymentRequestsDto) ([]dto.PaymentRequestDto, error) {
+    ctx, span := trace.Start(ctx, "PaymentRequestSvc.List")
+    defer span.End()
+
     res := []dto.PaymentRequestDto{}
 
     arg := store.ListPaymentRequestsParams{
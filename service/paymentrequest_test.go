@@ -0,0 +1,175 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestApprovePaymentRequest(t *testing.T) {
+    payReq := domain.PaymentRequest{ID: 42, FromWalletID: 1, ToWalletID: 2, Amount: 1250, Status: domain.PaymentRequestStatusAPPROVED}
+    transferArg := dto.TransferMoneyByWalletIDDto{FromWalletID: 1, ToWalletID: 2, Amount: 1250}
+    payer := dto.WalletDto{ID: 1, UserID: 10, Currency: "INR"}
+    stepUpArg := dto.StepUpDto{UserID: payer.UserID, Amount: payReq.Amount, Currency: payer.Currency, Code: "123456"}
+
+    withStatus := func(status domain.PaymentRequestStatus) domain.PaymentRequest {
+        res := payReq
+        res.Status = status
+        return res
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
+        checkResp func(t *testing.T, res dto.PaymentRequestDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(withStatus(domain.PaymentRequestStatusWAITINGAPPROVAL), nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), stepUpArg).Times(1)
+                mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(payReq, nil)
+                mockWalletSvc.EXPECT().PayByWalletID(gomock.Any(), transferArg).Times(1).Return(dto.WalletTransferResultDto{}, nil)
+                arg := store.UpdatePaymentRequestParams{ID: payReq.ID, Status: domain.PaymentRequestStatusPAYMENTSUCCESS}
+                mockPayReqRepo.EXPECT().UpdatePaymentRequest(gomock.Any(), arg).Times(1).Return(withStatus(arg.Status), nil)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentRequestDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.PaymentRequestStatusPAYMENTSUCCESS, res.Status)
+            },
+        },
+        {
+            name: "PaymentFailed",
+            buildStub: func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(withStatus(domain.PaymentRequestStatusWAITINGAPPROVAL), nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), stepUpArg).Times(1)
+                mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(payReq, nil)
+                mockWalletSvc.EXPECT().PayByWalletID(gomock.Any(), transferArg).Times(1).Return(dto.WalletTransferResultDto{}, errors.ErrInsufficientBalance)
+                arg := store.UpdatePaymentRequestParams{ID: payReq.ID, Status: domain.PaymentRequestStatusPAYMENTFAILED}
+                mockPayReqRepo.EXPECT().UpdatePaymentRequest(gomock.Any(), arg).Times(1).Return(withStatus(arg.Status), nil)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentRequestDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.PaymentRequestStatusPAYMENTFAILED, res.Status)
+            },
+        },
+        {
+            name: "AlreadyPaid",
+            buildStub: func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(withStatus(domain.PaymentRequestStatusPAYMENTSUCCESS), nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(0)
+                mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().PayByWalletID(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentRequestDto, err error) {
+                require.EqualError(t, err, errors.ErrPaymentRequestNotPending.Error())
+            },
+        },
+        {
+            name: "ApprovedConcurrently",
+            buildStub: func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(withStatus(domain.PaymentRequestStatusWAITINGAPPROVAL), nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), stepUpArg).Times(1)
+                mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(domain.PaymentRequest{}, sql.ErrNoRows)
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(withStatus(domain.PaymentRequestStatusPAYMENTSUCCESS), nil)
+                mockWalletSvc.EXPECT().PayByWalletID(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentRequestDto, err error) {
+                require.EqualError(t, err, errors.ErrPaymentRequestNotPending.Error())
+            },
+        },
+        {
+            name: "NotFound",
+            buildStub: func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(domain.PaymentRequest{}, sql.ErrNoRows)
+                mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().PayByWalletID(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentRequestDto, err error) {
+                require.EqualError(t, err, errors.ErrPaymentRequestNotFound.Error())
+            },
+        },
+        {
+            // the request is still waiting for an approval with a valid code
+            name: "InvalidTOTPCode",
+            buildStub: func(mockPayReqRepo *mockdb.MockPaymentRequestRepo, mockWalletSvc *mocksvc.MockWalletSvc, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(withStatus(domain.PaymentRequestStatusWAITINGAPPROVAL), nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), stepUpArg).Times(1).Return(errors.ErrInvalidTOTPCode)
+                mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+                mockPayReqRepo.EXPECT().UpdatePaymentRequest(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletSvc.EXPECT().PayByWalletID(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.PaymentRequestDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockPayReqRepo := mockdb.NewMockPaymentRequestRepo(ctrl)
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockPayReqRepo, mockWalletSvc, mockTwoFactorSvc)
+
+            // the payer's wallet is read for the step up
+            mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), gomock.Any()).AnyTimes().Return(payer, nil)
+
+            payReqSvc := service.NewPaymentRequestService(mockPayReqRepo, mockWalletSvc, mockTwoFactorSvc)
+            res, err := payReqSvc.Approve(context.TODO(), dto.ApprovePaymentRequestDto{ID: payReq.ID, TOTPCode: "123456"})
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+// TestApprovePaymentRequestStepsUpOnce checks that the payment made by the approval is not stepped up again, the code
+// of the approval is used once.
+func TestApprovePaymentRequestStepsUpOnce(t *testing.T) {
+    payer := domain.Wallet{ID: 1, UserID: 10, Address: "payer@my.wallet", Currency: "INR"}
+    payee := domain.Wallet{ID: 2, UserID: 20, Address: "payee@my.wallet", Currency: "INR"}
+    payReq := domain.PaymentRequest{ID: 42, FromWalletID: payer.ID, ToWalletID: payee.ID, Amount: 1250, Status: domain.PaymentRequestStatusWAITINGAPPROVAL}
+
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockPayReqRepo := mockdb.NewMockPaymentRequestRepo(ctrl)
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+
+    approved := payReq
+    approved.Status = domain.PaymentRequestStatusAPPROVED
+    paid := payReq
+    paid.Status = domain.PaymentRequestStatusPAYMENTSUCCESS
+
+    mockPayReqRepo.EXPECT().GetPaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(payReq, nil)
+    mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: payer.UserID, Amount: payReq.Amount, Currency: "INR", Code: "123456"}).Times(1)
+    mockPayReqRepo.EXPECT().ApprovePaymentRequest(gomock.Any(), payReq.ID).Times(1).Return(approved, nil)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), gomock.Any()).AnyTimes().
+        DoAndReturn(func(ctx context.Context, id int64) (domain.Wallet, error) {
+            if id == payer.ID {
+                return payer, nil
+            }
+            return payee, nil
+        })
+    mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+    mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1).Return(store.WalletTransferResult{Wallet: payer, ToWallet: payee}, nil)
+    mockPayReqRepo.EXPECT().UpdatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).Return(paid, nil)
+
+    walletSvc := service.NewWalletService(mockWalletRepo, mockTwoFactorSvc)
+    payReqSvc := service.NewPaymentRequestService(mockPayReqRepo, walletSvc, mockTwoFactorSvc)
+    res, err := payReqSvc.Approve(context.TODO(), dto.ApprovePaymentRequestDto{ID: payReq.ID, TOTPCode: "123456"})
+    require.NoError(t, err)
+    require.Equal(t, domain.PaymentRequestStatusPAYMENTSUCCESS, res.Status)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "sync"
+)
+
+// Publisher hands outbox events to their consumers. It may be called more than once
+// for the same event, consumers must be idempotent on the event ID.
+type Publisher interface {
+    Publish(ctx context.Context, event domain.OutboxEvent) error
+}
+
+type EventHandler func(ctx context.Context, event domain.OutboxEvent) error
+
+// EventBus is a Publisher that dispatches events to handlers subscribed in the same process.
+type EventBus interface {
+    Publisher
+    Subscribe(eventType domain.EventType, handler EventHandler)
+}
+
+type inProcessPublisher struct {
+    mu       sync.RWMutex
+    handlers map[domain.EventType][]EventHandler
+}
+
+func NewInProcessPublisher() EventBus {
+    return &inProcessPublisher{
+        handlers: map[domain.EventType][]EventHandler{},
+    }
+}
+
+func (p *inProcessPublisher) Subscribe(eventType domain.EventType, handler EventHandler) {
+    p.mu.Lock()
+    defer p.mu.Unlock()
+
+    p.handlers[eventType] = append(p.handlers[eventType], handler)
+}
+
+// Publish runs the handlers of the event type in the order they subscribed and stops at the
+// first error, the relay then publishes the event again later.
+func (p *inProcessPublisher) Publish(ctx context.Context, event domain.OutboxEvent) error {
+    p.mu.RLock()
+    handlers := p.handlers[event.EventType]
+    p.mu.RUnlock()
+
+    for _, h := range handlers {
+        if err := h(ctx, event); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,37 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestInProcessPublisher(t *testing.T) {
+    eventBus := service.NewInProcessPublisher()
+
+    var calls []string
+    eventBus.Subscribe(domain.EventTypeTransferCreated, func(ctx context.Context, event domain.OutboxEvent) error {
+        calls = append(calls, "first")
+        return nil
+    })
+    eventBus.Subscribe(domain.EventTypeTransferCreated, func(ctx context.Context, event domain.OutboxEvent) error {
+        calls = append(calls, "second")
+        return nil
+    })
+    eventBus.Subscribe(domain.EventTypeWalletActivated, func(ctx context.Context, event domain.OutboxEvent) error {
+        return sql.ErrConnDone
+    })
+
+    err := eventBus.Publish(context.TODO(), domain.OutboxEvent{ID: 1, EventType: domain.EventTypeTransferCreated})
+    require.NoError(t, err)
+    require.Equal(t, []string{"first", "second"}, calls)
+
+    err = eventBus.Publish(context.TODO(), domain.OutboxEvent{ID: 2, EventType: domain.EventTypeWalletCreated})
+    require.NoError(t, err)
+
+    err = eventBus.Publish(context.TODO(), domain.OutboxEvent{ID: 3, EventType: domain.EventTypeWalletActivated})
+    require.EqualError(t, err, sql.ErrConnDone.Error())
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+    "context"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/qr"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "io"
+)
+
+type QRSvc interface {
+    WalletQR(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error
+    PaymentRequestQR(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error
+    Parse(ctx context.Context, parseQRDto dto.ParseQRDto) (dto.QRPayloadDto, error)
+    Pay(ctx context.Context, qrPayDto dto.QRPayDto) (dto.QRPayResultDto, error)
+}
+
+type qrService struct {
+    walletSvc         WalletSvc
+    paymentRequestSvc PaymentRequestSvc
+}
+
+func NewQRService(walletSvc WalletSvc, paymentRequestSvc PaymentRequestSvc) QRSvc {
+    return &qrService{
+        walletSvc:         walletSvc,
+        paymentRequestSvc: paymentRequestSvc,
+    }
+}
+
+// WalletQR writes the static code of a wallet, the payer enters the amount. It can be printed once and reused.
+func (q *qrService) WalletQR(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error {
+    ctx, span := trace.Start(ctx, "QRSvc.WalletQR")
+    defer span.End()
+
+    wallet, err := q.walletSvc.GetWalletById(ctx, getQRDto.ID)
+    if err != nil {
+        return err
+    }
+
+    if wallet.UserID != getQRDto.UserID {
+        return errors.ErrWalletNotFound
+    }
+
+    payload := qr.Payload{
+        Address:  wallet.Address,
+        Currency: wallet.Currency,
+    }
+
+    return writeQR(payload, getQRDto, w)
+}
+
+// PaymentRequestQR writes the dynamic code of a payment request for the requester to show, it carries the amount
+// and a reference to the request.
+func (q *qrService) PaymentRequestQR(ctx context.Context, getQRDto dto.GetQRDto, w io.Writer) error {
+    ctx, span := trace.Start(ctx, "QRSvc.PaymentRequestQR")
+    defer span.End()
+
+    payReq, err := q.paymentRequestSvc.Get(ctx, getQRDto.ID)
+    if err != nil {
+        return err
+    }
+
+    toWallet, err := q.walletSvc.GetWalletById(ctx, payReq.ToWalletID)
+    if err != nil {
+        return err
+    }
+
+    if toWallet.UserID != getQRDto.UserID {
+        return errors.ErrPaymentRequestNotFound
+    }
+
+    if payReq.Status != domain.PaymentRequestStatusWAITINGAPPROVAL {
+        return errors.ErrPaymentRequestNotPending
+    }
+
+    payload := qr.Payload{
+        Address:   toWallet.Address,
+        Amount:    payReq.Amount,
+        Currency:  toWallet.Currency,
+        Reference: paymentRequestReference(payReq.ID),
+    }
+
+    return writeQR(payload, getQRDto, w)
+}
+
+func writeQR(payload qr.Payload, getQRDto dto.GetQRDto, w io.Writer) error {
+    code, err := payload.Encode()
+    if err != nil {
+        return err
+    }
+
+    if getQRDto.Format == "svg" {
+        return code.WriteSVG(w, getQRDto.Scale)
+    }
+    return code.WritePNG(w, getQRDto.Scale)
+}
+
+func (q *qrService) Parse(ctx context.Context, parseQRDto dto.ParseQRDto) (dto.QRPayloadDto, error) {
+    ctx, span := trace.Start(ctx, "QRSvc.Parse")
+    defer span.End()
+
+    payload, err := qr.Parse(parseQRDto.Payload)
+    if err != nil {
+        return dto.QRPayloadDto{}, errors.ErrInvalidQRPayload
+    }
+
+    return dto.NewQRPayloadDto(payload), nil
+}
+
+// paymentRequestReference is the reference of the dynamic code of a payment request.
+func paymentRequestReference(id int64) string {
+    return fmt.Sprintf("payment-req-%d", id)
+}
+
+// parsePaymentRequestReference returns the id of the payment request of a code reference, if it is one.
+func parsePaymentRequestReference(reference string) (int64, bool) {
+    var id int64
+    if _, err := fmt.Sscanf(reference, "payment-req-%d", &id); err != nil || paymentRequestReference(id) != reference {
+        return 0, false
+    }
+    return id, true
+}
+
+// Pay sends the money asked by a scanned code from a wallet of the payer, through WalletSvc.Pay. The code of a
+// payment request is paid through PaymentRequestSvc.Pay instead, which marks the request paid so it is paid once.
+// Both step the payment up.
+func (q *qrService) Pay(ctx context.Context, qrPayDto dto.QRPayDto) (dto.QRPayResultDto, error) {
+    ctx, span := trace.Start(ctx, "QRSvc.Pay")
+    defer span.End()
+
+    var res dto.QRPayResultDto
+
+    payload, err := qr.Parse(qrPayDto.Payload)
+    if err != nil {
+        return res, errors.ErrInvalidQRPayload
+    }
+
+    amount := payload.Amount
+    if amount == 0 {
+        amount = qrPayDto.Amount
+    }
+    if amount == 0 || (qrPayDto.Amount != 0 && qrPayDto.Amount != amount) {
+        return res, errors.ErrQRAmountMismatch
+    }
+
+    fromWallet, err := q.walletSvc.GetWalletByAddress(ctx, qrPayDto.FromWalletAddress)
+    if err != nil {
+        return res, err
+    }
+
+    if fromWallet.UserID != qrPayDto.UserID {
+        return res, errors.ErrWalletNotFound
+    }
+
+    toWallet, err := q.walletSvc.GetWalletByAddress(ctx, payload.Address)
+    if err != nil {
+        return res, err
+    }
+
+    // the amount of the code is in its currency, it is never converted
+    if toWallet.Currency != payload.Currency || fromWallet.Currency != payload.Currency {
+        return res, errors.ErrCurrencyMismatch
+    }
+
+    var transfer dto.WalletTransferResultDto
+    if payReqID, ok := parsePaymentRequestReference(payload.Reference); ok {
+        payReq, err := q.paymentRequestSvc.Get(ctx, payReqID)
+        if err != nil {
+            return res, err
+        }
+
+        // the code pays the request only from the wallet it asks, to the wallet and for the amount it is for
+        if payReq.FromWalletID != fromWallet.ID || payReq.ToWalletID != toWallet.ID {
+            return res, errors.ErrPaymentRequestNotFound
+        }
+        if payReq.Amount != amount {
+            return res, errors.ErrQRAmountMismatch
+        }
+
+        transfer, err = q.paymentRequestSvc.Pay(ctx, dto.ApprovePaymentRequestDto{ID: payReq.ID, TOTPCode: qrPayDto.TOTPCode})
+        if err != nil {
+            return res, err
+        }
+    } else {
+        arg := dto.TransferMoneyDto{
+            FromWalletAddress: fromWallet.Address,
+            ToWalletAddress:   toWallet.Address,
+            Amount:            amount,
+            TOTPCode:          qrPayDto.TOTPCode,
+        }
+
+        transfer, err = q.walletSvc.Pay(ctx, arg)
+        if err != nil {
+            return res, err
+        }
+    }
+
+    res = dto.QRPayResultDto{
+        Reference: payload.Reference,
+        Transfer:  transfer,
+    }
+    return res, nil
+}
@@ -0,0 +1,262 @@
+package service_test
+
+import (
+    "bytes"
+    "context"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/qr"
+    "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
+    "github.com/stretchr/testify/require"
+    "image/png"
+    "strings"
+    "testing"
+)
+
+func TestWalletQR(t *testing.T) {
+    wallet := dto.WalletDto{ID: 1, UserID: 10, Address: "walter@my.wallet", Currency: "INR"}
+
+    testcases := []struct {
+        name      string
+        getQRDto  dto.GetQRDto
+        checkResp func(t *testing.T, out *bytes.Buffer, err error)
+    }{
+        {
+            name:     "Png",
+            getQRDto: dto.GetQRDto{ID: wallet.ID, UserID: wallet.UserID, Format: "png", Scale: 2},
+            checkResp: func(t *testing.T, out *bytes.Buffer, err error) {
+                require.NoError(t, err)
+                _, err = png.Decode(out)
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:     "Svg",
+            getQRDto: dto.GetQRDto{ID: wallet.ID, UserID: wallet.UserID, Format: "svg", Scale: 2},
+            checkResp: func(t *testing.T, out *bytes.Buffer, err error) {
+                require.NoError(t, err)
+                require.True(t, strings.HasPrefix(out.String(), "<?xml"))
+            },
+        },
+        {
+            name:     "WalletOfOtherUser",
+            getQRDto: dto.GetQRDto{ID: wallet.ID, UserID: 11, Format: "png", Scale: 2},
+            checkResp: func(t *testing.T, out *bytes.Buffer, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+                require.Zero(t, out.Len())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), wallet.ID).Times(1).Return(wallet, nil)
+
+            qrSvc := service.NewQRService(mockWalletSvc, mocksvc.NewMockPaymentRequestSvc(ctrl))
+            var out bytes.Buffer
+            err := qrSvc.WalletQR(context.TODO(), tc.getQRDto, &out)
+            tc.checkResp(t, &out, err)
+        })
+    }
+}
+
+func TestPaymentRequestQR(t *testing.T) {
+    toWallet := dto.WalletDto{ID: 2, UserID: 10, Address: "walter@my.wallet", Currency: "INR"}
+
+    testcases := []struct {
+        name    string
+        userID  int64
+        status  domain.PaymentRequestStatus
+        wantErr error
+    }{
+        {name: "Ok", userID: toWallet.UserID, status: domain.PaymentRequestStatusWAITINGAPPROVAL},
+        {name: "RequestOfOtherUser", userID: 11, status: domain.PaymentRequestStatusWAITINGAPPROVAL, wantErr: errors.ErrPaymentRequestNotFound},
+        {name: "AlreadyPaid", userID: toWallet.UserID, status: domain.PaymentRequestStatusPAYMENTSUCCESS, wantErr: errors.ErrPaymentRequestNotPending},
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            payReq := dto.PaymentRequestDto{ID: 42, FromWalletID: 1, ToWalletID: toWallet.ID, Amount: 1250, Status: tc.status}
+            mockPaymentRequestSvc := mocksvc.NewMockPaymentRequestSvc(ctrl)
+            mockPaymentRequestSvc.EXPECT().Get(gomock.Any(), payReq.ID).Times(1).Return(payReq, nil)
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            mockWalletSvc.EXPECT().GetWalletById(gomock.Any(), toWallet.ID).Times(1).Return(toWallet, nil)
+
+            qrSvc := service.NewQRService(mockWalletSvc, mockPaymentRequestSvc)
+            var out bytes.Buffer
+            err := qrSvc.PaymentRequestQR(context.TODO(), dto.GetQRDto{ID: payReq.ID, UserID: tc.userID, Format: "svg", Scale: 1}, &out)
+            if tc.wantErr != nil {
+                require.EqualError(t, err, tc.wantErr.Error())
+                return
+            }
+
+            require.NoError(t, err)
+            require.Contains(t, out.String(), "<svg")
+        })
+    }
+}
+
+func TestParseQR(t *testing.T) {
+    qrSvc := service.NewQRService(nil, nil)
+
+    payload := qr.Payload{Address: "walter@my.wallet", Amount: 1250, Currency: "INR", Reference: "payment-req-42"}
+    res, err := qrSvc.Parse(context.TODO(), dto.ParseQRDto{Payload: payload.String()})
+    require.NoError(t, err)
+    require.Equal(t, dto.QRPayloadDto{Address: "walter@my.wallet", Amount: 1250, Currency: "INR", Reference: "payment-req-42"}, res)
+
+    _, err = qrSvc.Parse(context.TODO(), dto.ParseQRDto{Payload: "https://example.com"})
+    require.EqualError(t, err, errors.ErrInvalidQRPayload.Error())
+}
+
+func TestQRPay(t *testing.T) {
+    payer := dto.WalletDto{ID: 1, UserID: 20, Address: "payer@my.wallet", Currency: "INR"}
+    payee := dto.WalletDto{ID: 2, UserID: 10, Address: "walter@my.wallet", Currency: "INR"}
+    static := qr.Payload{Address: payee.Address, Currency: "INR"}.String()
+    dynamic := qr.Payload{Address: payee.Address, Amount: 1250, Currency: "INR", Reference: "payment-req-42"}.String()
+
+    testcases := []struct {
+        name      string
+        qrPayDto  dto.QRPayDto
+        buildStub func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc)
+        checkResp func(t *testing.T, res dto.QRPayResultDto, err error)
+    }{
+        {
+            name:     "Static",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: static, FromWalletAddress: payer.Address, Amount: 300, TOTPCode: "123456"},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                arg := dto.TransferMoneyDto{FromWalletAddress: payer.Address, ToWalletAddress: payee.Address, Amount: 300, TOTPCode: "123456"}
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), arg).Times(1).Return(dto.WalletTransferResultDto{Transfer: dto.TransferDto{ID: 9, Amount: 300}}, nil)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.NoError(t, err)
+                require.Empty(t, res.Reference)
+                require.Equal(t, int64(9), res.Transfer.Transfer.ID)
+            },
+        },
+        {
+            name:     "Dynamic",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: dynamic, FromWalletAddress: payer.Address, TOTPCode: "123456"},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                payReq := dto.PaymentRequestDto{ID: 42, FromWalletID: payer.ID, ToWalletID: payee.ID, Amount: 1250, Status: domain.PaymentRequestStatusWAITINGAPPROVAL}
+                mockPayReqSvc.EXPECT().Get(gomock.Any(), int64(42)).Times(1).Return(payReq, nil)
+                mockPayReqSvc.EXPECT().Pay(gomock.Any(), dto.ApprovePaymentRequestDto{ID: 42, TOTPCode: "123456"}).Times(1).Return(dto.WalletTransferResultDto{Transfer: dto.TransferDto{ID: 9, Amount: 1250}}, nil)
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, "payment-req-42", res.Reference)
+                require.Equal(t, int64(9), res.Transfer.Transfer.ID)
+            },
+        },
+        {
+            name:     "DynamicPaid",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: dynamic, FromWalletAddress: payer.Address},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                payReq := dto.PaymentRequestDto{ID: 42, FromWalletID: payer.ID, ToWalletID: payee.ID, Amount: 1250, Status: domain.PaymentRequestStatusPAYMENTSUCCESS}
+                mockPayReqSvc.EXPECT().Get(gomock.Any(), int64(42)).Times(1).Return(payReq, nil)
+                mockPayReqSvc.EXPECT().Pay(gomock.Any(), dto.ApprovePaymentRequestDto{ID: 42}).Times(1).Return(dto.WalletTransferResultDto{}, errors.ErrPaymentRequestNotPending)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrPaymentRequestNotPending.Error())
+            },
+        },
+        {
+            name:     "DynamicOtherPayer",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: dynamic, FromWalletAddress: payer.Address},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                payReq := dto.PaymentRequestDto{ID: 42, FromWalletID: 3, ToWalletID: payee.ID, Amount: 1250, Status: domain.PaymentRequestStatusWAITINGAPPROVAL}
+                mockPayReqSvc.EXPECT().Get(gomock.Any(), int64(42)).Times(1).Return(payReq, nil)
+                mockPayReqSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrPaymentRequestNotFound.Error())
+            },
+        },
+        {
+            name:     "StaticWithoutAmount",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: static, FromWalletAddress: payer.Address},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrQRAmountMismatch.Error())
+            },
+        },
+        {
+            name:     "DynamicOtherAmount",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: dynamic, FromWalletAddress: payer.Address, Amount: 1},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrQRAmountMismatch.Error())
+            },
+        },
+        {
+            name:     "Tampered",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: strings.Replace(dynamic, "amount=1250", "amount=125", 1), FromWalletAddress: payer.Address},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidQRPayload.Error())
+            },
+        },
+        {
+            name:     "WalletOfOtherUser",
+            qrPayDto: dto.QRPayDto{UserID: 21, Payload: dynamic, FromWalletAddress: payer.Address},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name:     "CurrencyMismatch",
+            qrPayDto: dto.QRPayDto{UserID: payer.UserID, Payload: qr.Payload{Address: payee.Address, Amount: 5, Currency: "USD"}.String(), FromWalletAddress: payer.Address},
+            buildStub: func(mockWalletSvc *mocksvc.MockWalletSvc, mockPayReqSvc *mocksvc.MockPaymentRequestSvc) {
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payer.Address).Times(1).Return(payer, nil)
+                mockWalletSvc.EXPECT().GetWalletByAddress(gomock.Any(), payee.Address).Times(1).Return(payee, nil)
+                mockWalletSvc.EXPECT().Pay(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.QRPayResultDto, err error) {
+                require.EqualError(t, err, errors.ErrCurrencyMismatch.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletSvc := mocksvc.NewMockWalletSvc(ctrl)
+            mockPayReqSvc := mocksvc.NewMockPaymentRequestSvc(ctrl)
+            tc.buildStub(mockWalletSvc, mockPayReqSvc)
+
+            qrSvc := service.NewQRService(mockWalletSvc, mockPayReqSvc)
+            res, err := qrSvc.Pay(context.TODO(), tc.qrPayDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
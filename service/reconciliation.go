@@ -0,0 +1,187 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    log "github.com/sirupsen/logrus"
+    "time"
+)
+
+type ReconciliationSvc interface {
+    Run(ctx context.Context) (dto.ReconciliationReportDto, error)
+    Get(ctx context.Context, id int64) (dto.ReconciliationReportDto, error)
+    List(ctx context.Context, listReportsDto dto.ListReconciliationReportsDto) ([]dto.ReconciliationReportDto, error)
+}
+
+type reconciliationService struct {
+    reconciliationRepo store.ReconciliationRepo
+}
+
+func NewReconciliationService(reconciliationRepo store.ReconciliationRepo) ReconciliationSvc {
+    return &reconciliationService{
+        reconciliationRepo: reconciliationRepo,
+    }
+}
+
+// Run checks the ledger and writes a report of the discrepancies it found:
+//   - every wallet balance is its opening balance plus the sum of its entries
+//   - every transfer has one debit and one credit entry of its amount
+//   - per currency, the entries net to zero and the user and organization wallets together
+//     hold the money the wallets were opened with
+func (r *reconciliationService) Run(ctx context.Context) (dto.ReconciliationReportDto, error) {
+    ctx, span := trace.Start(ctx, "ReconciliationSvc.Run")
+    defer span.End()
+
+    var res dto.ReconciliationReportDto
+    startedAt := time.Now().UTC()
+
+    walletMismatches, err := r.reconciliationRepo.ListWalletBalanceMismatches(ctx)
+    if err != nil {
+        return res, err
+    }
+
+    unbalancedTransfers, err := r.reconciliationRepo.ListUnbalancedTransfers(ctx)
+    if err != nil {
+        return res, err
+    }
+
+    currencyTotals, err := r.reconciliationRepo.ListCurrencyTotals(ctx)
+    if err != nil {
+        return res, err
+    }
+
+    transfersChecked, err := r.reconciliationRepo.CountTransfers(ctx)
+    if err != nil {
+        return res, err
+    }
+
+    discrepancies := []store.CreateReconciliationDiscrepancyParams{}
+
+    for _, m := range walletMismatches {
+        discrepancies = append(discrepancies, store.CreateReconciliationDiscrepancyParams{
+            Kind:     domain.DiscrepancyKindWALLETBALANCE,
+            Currency: m.Currency,
+            WalletID: sql.NullInt64{Int64: m.WalletID, Valid: true},
+            Expected: m.LedgerBalance,
+            Actual:   m.Balance,
+            Detail:   fmt.Sprintf("wallet %s has balance %d, its entries add up to %d", m.Address, m.Balance, m.LedgerBalance),
+        })
+    }
+
+    for _, t := range unbalancedTransfers {
+        discrepancies = append(discrepancies, store.CreateReconciliationDiscrepancyParams{
+            Kind:       domain.DiscrepancyKindTRANSFERENTRIES,
+            Currency:   t.Currency,
+            TransferID: sql.NullInt64{Int64: t.TransferID, Valid: true},
+            Expected:   0,
+            Actual:     t.EntriesTotal,
+            Detail:     fmt.Sprintf("transfer of %d from wallet %d to wallet %d has %d entries netting to %d", t.Amount, t.FromWalletID, t.ToWalletID, t.EntryCount, t.EntriesTotal),
+        })
+    }
+
+    var walletsChecked int64
+    for _, c := range currencyTotals {
+        walletsChecked += c.WalletCount
+
+        if held := c.UserBalance + c.OrganizationBalance; held != c.OpeningBalance {
+            discrepancies = append(discrepancies, store.CreateReconciliationDiscrepancyParams{
+                Kind:     domain.DiscrepancyKindCURRENCYTOTAL,
+                Currency: c.Currency,
+                Expected: c.OpeningBalance,
+                Actual:   held,
+                Detail:   fmt.Sprintf("user wallets hold %d and organization wallets hold %d, the wallets were opened with %d", c.UserBalance, c.OrganizationBalance, c.OpeningBalance),
+            })
+        }
+
+        if c.EntriesTotal != 0 {
+            discrepancies = append(discrepancies, store.CreateReconciliationDiscrepancyParams{
+                Kind:     domain.DiscrepancyKindCURRENCYTOTAL,
+                Currency: c.Currency,
+                Expected: 0,
+                Actual:   c.EntriesTotal,
+                Detail:   fmt.Sprintf("entries net to %d", c.EntriesTotal),
+            })
+        }
+    }
+
+    status := domain.ReconciliationStatusOK
+    if len(discrepancies) > 0 {
+        status = domain.ReconciliationStatusDISCREPANCIES
+    }
+
+    saved, err := r.reconciliationRepo.SaveReconciliationReport(ctx, store.SaveReconciliationReportParams{
+        Report: store.CreateReconciliationReportParams{
+            Status:            status,
+            WalletsChecked:    walletsChecked,
+            TransfersChecked:  transfersChecked,
+            CurrenciesChecked: int64(len(currencyTotals)),
+            DiscrepancyCount:  int64(len(discrepancies)),
+            StartedAt:         startedAt,
+        },
+        Discrepancies: discrepancies,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    if status != domain.ReconciliationStatusOK {
+        logging.FromContext(ctx).WithFields(log.Fields{
+            "report_id":     saved.Report.ID,
+            "discrepancies": saved.Report.DiscrepancyCount,
+        }).Warn("ledger reconciliation found discrepancies")
+    }
+
+    res = dto.NewReconciliationReportDto(saved.Report, saved.Discrepancies)
+    return res, nil
+}
+
+func (r *reconciliationService) Get(ctx context.Context, id int64) (dto.ReconciliationReportDto, error) {
+    ctx, span := trace.Start(ctx, "ReconciliationSvc.Get")
+    defer span.End()
+
+    var res dto.ReconciliationReportDto
+
+    report, err := r.reconciliationRepo.GetReconciliationReport(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrReconciliationNotFound
+        }
+        return res, err
+    }
+
+    discrepancies, err := r.reconciliationRepo.ListReconciliationDiscrepancies(ctx, id)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewReconciliationReportDto(report, discrepancies)
+    return res, nil
+}
+
+func (r *reconciliationService) List(ctx context.Context, listReportsDto dto.ListReconciliationReportsDto) ([]dto.ReconciliationReportDto, error) {
+    ctx, span := trace.Start(ctx, "ReconciliationSvc.List")
+    defer span.End()
+
+    res := []dto.ReconciliationReportDto{}
+
+    reports, err := r.reconciliationRepo.ListReconciliationReports(ctx, store.ListReconciliationReportsParams{
+        Limit:  listReportsDto.Limit,
+        Offset: listReportsDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, report := range reports {
+        res = append(res, dto.NewReconciliationReportDto(report, nil))
+    }
+
+    return res, nil
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+// saveReport mimics the repository, numbering the report and its discrepancies
+func saveReport(ctx context.Context, arg store.SaveReconciliationReportParams) (store.ReconciliationReportResult, error) {
+    res := store.ReconciliationReportResult{
+        Report: domain.ReconciliationReport{
+            ID:                1,
+            Status:            arg.Report.Status,
+            WalletsChecked:    arg.Report.WalletsChecked,
+            TransfersChecked:  arg.Report.TransfersChecked,
+            CurrenciesChecked: arg.Report.CurrenciesChecked,
+            DiscrepancyCount:  arg.Report.DiscrepancyCount,
+            StartedAt:         arg.Report.StartedAt,
+        },
+    }
+
+    for i, d := range arg.Discrepancies {
+        res.Discrepancies = append(res.Discrepancies, domain.ReconciliationDiscrepancy{
+            ID:         int64(i + 1),
+            ReportID:   1,
+            Kind:       d.Kind,
+            Currency:   d.Currency,
+            WalletID:   d.WalletID,
+            TransferID: d.TransferID,
+            Expected:   d.Expected,
+            Actual:     d.Actual,
+            Detail:     d.Detail,
+        })
+    }
+
+    return res, nil
+}
+
+func TestRunReconciliation(t *testing.T) {
+    balancedTotals := []store.CurrencyTotal{
+        {Currency: "INR", WalletCount: 3, UserBalance: 400, OrganizationBalance: 99600, OpeningBalance: 100000},
+        {Currency: "USD", WalletCount: 1, OrganizationBalance: 100000, OpeningBalance: 100000},
+    }
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockReconciliationRepo *mockdb.MockReconciliationRepo)
+        checkResp func(t *testing.T, res dto.ReconciliationReportDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockReconciliationRepo *mockdb.MockReconciliationRepo) {
+                mockReconciliationRepo.EXPECT().ListWalletBalanceMismatches(gomock.Any()).Times(1).Return([]store.WalletBalanceMismatch{}, nil)
+                mockReconciliationRepo.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return([]store.UnbalancedTransfer{}, nil)
+                mockReconciliationRepo.EXPECT().ListCurrencyTotals(gomock.Any()).Times(1).Return(balancedTotals, nil)
+                mockReconciliationRepo.EXPECT().CountTransfers(gomock.Any()).Times(1).Return(int64(12), nil)
+                mockReconciliationRepo.EXPECT().SaveReconciliationReport(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(saveReport)
+            },
+            checkResp: func(t *testing.T, res dto.ReconciliationReportDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.ReconciliationStatusOK, res.Status)
+                require.Equal(t, int64(4), res.WalletsChecked)
+                require.Equal(t, int64(12), res.TransfersChecked)
+                require.Equal(t, int64(2), res.CurrenciesChecked)
+                require.Zero(t, res.DiscrepancyCount)
+                require.Empty(t, res.Discrepancies)
+            },
+        },
+        {
+            name: "Discrepancies",
+            buildStub: func(mockReconciliationRepo *mockdb.MockReconciliationRepo) {
+                mockReconciliationRepo.EXPECT().ListWalletBalanceMismatches(gomock.Any()).Times(1).Return([]store.WalletBalanceMismatch{
+                    {WalletID: 7, Address: "bob@my.wallet", Currency: "INR", Balance: 150, LedgerBalance: 100},
+                }, nil)
+                mockReconciliationRepo.EXPECT().ListUnbalancedTransfers(gomock.Any()).Times(1).Return([]store.UnbalancedTransfer{
+                    {TransferID: 9, FromWalletID: 7, ToWalletID: 8, Amount: 50, Currency: "INR", EntryCount: 1, EntriesTotal: 50},
+                }, nil)
+                mockReconciliationRepo.EXPECT().ListCurrencyTotals(gomock.Any()).Times(1).Return([]store.CurrencyTotal{
+                    {Currency: "INR", WalletCount: 3, UserBalance: 450, OrganizationBalance: 99600, OpeningBalance: 100000, EntriesTotal: 50},
+                }, nil)
+                mockReconciliationRepo.EXPECT().CountTransfers(gomock.Any()).Times(1).Return(int64(12), nil)
+                mockReconciliationRepo.EXPECT().SaveReconciliationReport(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(saveReport)
+            },
+            checkResp: func(t *testing.T, res dto.ReconciliationReportDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, domain.ReconciliationStatusDISCREPANCIES, res.Status)
+                require.Equal(t, int64(4), res.DiscrepancyCount)
+                require.Len(t, res.Discrepancies, 4)
+
+                require.Equal(t, domain.DiscrepancyKindWALLETBALANCE, res.Discrepancies[0].Kind)
+                require.Equal(t, int64(7), res.Discrepancies[0].WalletID)
+                require.Equal(t, int64(100), res.Discrepancies[0].Expected)
+                require.Equal(t, int64(150), res.Discrepancies[0].Actual)
+
+                require.Equal(t, domain.DiscrepancyKindTRANSFERENTRIES, res.Discrepancies[1].Kind)
+                require.Equal(t, int64(9), res.Discrepancies[1].TransferID)
+
+                require.Equal(t, domain.DiscrepancyKindCURRENCYTOTAL, res.Discrepancies[2].Kind)
+                require.Equal(t, int64(100000), res.Discrepancies[2].Expected)
+                require.Equal(t, int64(100050), res.Discrepancies[2].Actual)
+
+                require.Equal(t, domain.DiscrepancyKindCURRENCYTOTAL, res.Discrepancies[3].Kind)
+                require.Equal(t, int64(50), res.Discrepancies[3].Actual)
+            },
+        },
+        {
+            name: "ListErr",
+            buildStub: func(mockReconciliationRepo *mockdb.MockReconciliationRepo) {
+                mockReconciliationRepo.EXPECT().ListWalletBalanceMismatches(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+                mockReconciliationRepo.EXPECT().SaveReconciliationReport(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.ReconciliationReportDto, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockReconciliationRepo := mockdb.NewMockReconciliationRepo(ctrl)
+            tc.buildStub(mockReconciliationRepo)
+
+            reconciliationSvc := service.NewReconciliationService(mockReconciliationRepo)
+            res, err := reconciliationSvc.Run(context.Background())
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestGetReconciliationReport(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockReconciliationRepo := mockdb.NewMockReconciliationRepo(ctrl)
+    mockReconciliationRepo.EXPECT().GetReconciliationReport(gomock.Any(), int64(1)).Times(1).Return(domain.ReconciliationReport{}, sql.ErrNoRows)
+    mockReconciliationRepo.EXPECT().ListReconciliationDiscrepancies(gomock.Any(), gomock.Any()).Times(0)
+
+    reconciliationSvc := service.NewReconciliationService(mockReconciliationRepo)
+    _, err := reconciliationSvc.Get(context.Background(), 1)
+    require.EqualError(t, err, errors.ErrReconciliationNotFound.Error())
+}
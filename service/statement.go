@@ -0,0 +1,296 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "encoding/csv"
+    "fmt"
+    "github.com/go-pdf/fpdf"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "io"
+    "strconv"
+    "time"
+)
+
+type StatementSvc interface {
+    GetStatement(ctx context.Context, getStatementDto dto.GetStatementDto) (dto.StatementDto, error)
+    Export(ctx context.Context, getStatementDto dto.GetStatementDto, w io.Writer) error
+}
+
+type statementService struct {
+    statementRepo store.StatementRepo
+    walletRepo    store.WalletRepo
+    currencyRepo  store.CurrencyRepo
+}
+
+func NewStatementService(statementRepo store.StatementRepo, walletRepo store.WalletRepo, currencyRepo store.CurrencyRepo) StatementSvc {
+    return &statementService{
+        statementRepo: statementRepo,
+        walletRepo:    walletRepo,
+        currencyRepo:  currencyRepo,
+    }
+}
+
+// GetStatement builds the statement of the calendar month (UTC) of getStatementDto.Month for the
+// owner of the wallet, the balances come from the ledger.
+func (s *statementService) GetStatement(ctx context.Context, getStatementDto dto.GetStatementDto) (dto.StatementDto, error) {
+    ctx, span := trace.Start(ctx, "StatementSvc.GetStatement")
+    defer span.End()
+
+    var res dto.StatementDto
+
+    wallet, err := s.walletRepo.GetWallet(ctx, getStatementDto.WalletID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrWalletNotFound
+        }
+        return res, err
+    }
+
+    if wallet.UserID != getStatementDto.UserID {
+        return res, errors.ErrWalletNotFound
+    }
+
+    currency, err := s.currencyRepo.GetCurrency(ctx, wallet.Currency)
+    if err != nil {
+        return res, err
+    }
+
+    month := getStatementDto.Month.UTC()
+    from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+    to := from.AddDate(0, 1, 0)
+
+    opening, err := s.statementRepo.GetWalletBalanceAt(ctx, store.GetWalletBalanceAtParams{
+        WalletID: wallet.ID,
+        At:       from,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    entries, err := s.statementRepo.ListStatementEntries(ctx, store.ListStatementEntriesParams{
+        WalletID: wallet.ID,
+        From:     from,
+        To:       to,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.StatementDto{
+        WalletID:       wallet.ID,
+        WalletAddress:  wallet.Address,
+        Currency:       currency.Code,
+        Fraction:       currency.Fraction,
+        From:           from,
+        To:             to,
+        OpeningBalance: opening,
+        Lines:          []dto.StatementLineDto{},
+    }
+
+    balance := opening
+    for _, e := range entries {
+        balance += e.Amount
+        if e.Amount > 0 {
+            res.TotalCredits += e.Amount
+        } else {
+            res.TotalDebits -= e.Amount
+        }
+
+        res.Lines = append(res.Lines, dto.StatementLineDto{
+            Date:         e.CreatedAt,
+            TransferID:   e.TransferID,
+            Counterparty: e.Counterparty,
+            Amount:       e.Amount,
+            Balance:      balance,
+        })
+    }
+    res.ClosingBalance = balance
+
+    return res, nil
+}
+
+// Export writes the statement as a csv or pdf file, depending on getStatementDto.Format.
+func (s *statementService) Export(ctx context.Context, getStatementDto dto.GetStatementDto, w io.Writer) error {
+    ctx, span := trace.Start(ctx, "StatementSvc.Export")
+    defer span.End()
+
+    statement, err := s.GetStatement(ctx, getStatementDto)
+    if err != nil {
+        return err
+    }
+
+    switch getStatementDto.Format {
+    case "csv":
+        return writeStatementCSV(w, statement)
+    case "pdf":
+        return writeStatementPDF(w, statement)
+    default:
+        return fmt.Errorf("unsupported statement format %q", getStatementDto.Format)
+    }
+}
+
+const statementDateLayout = "2006-01-02 15:04:05"
+
+// lastDay is the last day covered by the statement, To is the start of the next month
+func lastDay(statement dto.StatementDto) time.Time {
+    return statement.To.AddDate(0, 0, -1)
+}
+
+func writeStatementCSV(w io.Writer, statement dto.StatementDto) error {
+    amount := func(v int64) string {
+        return util.FormatAmount(v, statement.Fraction)
+    }
+
+    cw := csv.NewWriter(w)
+    records := [][]string{
+        {"date", "transfer_id", "description", "counterparty", "amount", "balance"},
+        {statement.From.Format(statementDateLayout), "", "Opening balance", "", "", amount(statement.OpeningBalance)},
+    }
+
+    for _, line := range statement.Lines {
+        description := "Received from"
+        if line.Amount < 0 {
+            description = "Sent to"
+        }
+
+        records = append(records, []string{
+            line.Date.Format(statementDateLayout),
+            strconv.FormatInt(line.TransferID, 10),
+            description,
+            line.Counterparty,
+            amount(line.Amount),
+            amount(line.Balance),
+        })
+    }
+
+    records = append(records, []string{statement.To.Add(-time.Second).Format(statementDateLayout), "", "Closing balance", "", "", amount(statement.ClosingBalance)})
+
+    if err := cw.WriteAll(records); err != nil {
+        return err
+    }
+    return cw.Error()
+}
+
+// statement pdf layout, in points from the top left corner of an A4 page
+const (
+    statementPageHeight   = 841.89
+    statementMarginLeft   = 40.0
+    statementMarginRight  = 595.28 - 40
+    statementTop          = 50.0
+    statementBottom       = statementPageHeight - 60
+    statementRowHeight    = 14.0
+    statementFontSize     = 8.0
+    statementCounterparty = 36
+)
+
+// statement pdf columns, Amount and Balance are right aligned on their x
+var statementColumns = struct {
+    Date, Transfer, Counterparty, Amount, Balance float64
+}{
+    Date:         statementMarginLeft,
+    Transfer:     statementMarginLeft + 100,
+    Counterparty: statementMarginLeft + 160,
+    Amount:       statementMarginRight - 100,
+    Balance:      statementMarginRight,
+}
+
+func writeStatementPDF(w io.Writer, statement dto.StatementDto) error {
+    amount := func(v int64) string {
+        return util.FormatAmount(v, statement.Fraction)
+    }
+
+    doc := fpdf.New("P", "pt", "A4", "")
+    // a statement is a few pages of text, it is left uncompressed
+    doc.SetCompression(false)
+    doc.SetAutoPageBreak(false, 0)
+    doc.AliasNbPages("")
+
+    // the core fonts are cp1252, the addresses and names are translated from utf-8
+    tr := doc.UnicodeTranslatorFromDescriptor("")
+    text := func(x, y float64, family, style string, size float64, s string) {
+        doc.SetFont(family, style, size)
+        doc.Text(x, y, tr(s))
+    }
+    rightAligned := func(x, y float64, family, style string, size float64, s string) {
+        doc.SetFont(family, style, size)
+        doc.Text(x-doc.GetStringWidth(tr(s)), y, tr(s))
+    }
+
+    doc.SetFooterFunc(func() {
+        text(statementMarginLeft, statementPageHeight-30, "Helvetica", "", 8, fmt.Sprintf("%s - page %d of {nb}", statement.WalletAddress, doc.PageNo()))
+    })
+
+    doc.AddPage()
+    y := statementTop
+
+    text(statementMarginLeft, y, "Helvetica", "B", 16, "MyWallet account statement")
+    y += 24
+    for _, line := range []string{
+        "Wallet: " + statement.WalletAddress,
+        "Currency: " + statement.Currency,
+        fmt.Sprintf("Period: %s to %s", statement.From.Format("2006-01-02"), lastDay(statement).Format("2006-01-02")),
+        fmt.Sprintf("Opening balance: %s %s", amount(statement.OpeningBalance), statement.Currency),
+    } {
+        text(statementMarginLeft, y, "Helvetica", "", 10, line)
+        y += statementRowHeight
+    }
+    y += statementRowHeight
+
+    tableHeader := func() {
+        text(statementColumns.Date, y, "Helvetica", "B", 9, "Date")
+        text(statementColumns.Transfer, y, "Helvetica", "B", 9, "Transfer")
+        text(statementColumns.Counterparty, y, "Helvetica", "B", 9, "Counterparty")
+        rightAligned(statementColumns.Amount, y, "Helvetica", "B", 9, "Amount")
+        rightAligned(statementColumns.Balance, y, "Helvetica", "B", 9, "Balance")
+        doc.Line(statementMarginLeft, y+4, statementMarginRight, y+4)
+        y += statementRowHeight + 2
+    }
+    tableHeader()
+
+    for _, line := range statement.Lines {
+        if y > statementBottom {
+            doc.AddPage()
+            y = statementTop
+            tableHeader()
+        }
+
+        counterparty := line.Counterparty
+        if r := []rune(counterparty); len(r) > statementCounterparty {
+            counterparty = string(r[:statementCounterparty-3]) + "..."
+        }
+
+        text(statementColumns.Date, y, "Courier", "", statementFontSize, line.Date.Format(statementDateLayout))
+        text(statementColumns.Transfer, y, "Courier", "", statementFontSize, strconv.FormatInt(line.TransferID, 10))
+        text(statementColumns.Counterparty, y, "Courier", "", statementFontSize, counterparty)
+        rightAligned(statementColumns.Amount, y, "Courier", "", statementFontSize, amount(line.Amount))
+        rightAligned(statementColumns.Balance, y, "Courier", "", statementFontSize, amount(line.Balance))
+        y += statementRowHeight
+    }
+
+    if len(statement.Lines) == 0 {
+        text(statementMarginLeft, y, "Helvetica", "", 9, "No movements in this period.")
+        y += statementRowHeight
+    }
+
+    if y+3*statementRowHeight > statementBottom {
+        doc.AddPage()
+        y = statementTop
+    }
+    doc.Line(statementMarginLeft, y-statementRowHeight+4, statementMarginRight, y-statementRowHeight+4)
+    y += 4
+    for _, line := range []string{
+        fmt.Sprintf("Total received: %s %s", amount(statement.TotalCredits), statement.Currency),
+        fmt.Sprintf("Total sent: %s %s", amount(statement.TotalDebits), statement.Currency),
+        fmt.Sprintf("Closing balance: %s %s", amount(statement.ClosingBalance), statement.Currency),
+    } {
+        text(statementMarginLeft, y, "Helvetica", "", 10, line)
+        y += statementRowHeight
+    }
+
+    return doc.Output(w)
+}
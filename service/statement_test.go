@@ -0,0 +1,151 @@
+package service_test
+
+import (
+    "bytes"
+    "context"
+    "database/sql"
+    "encoding/csv"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestExportStatement(t *testing.T) {
+    wallet := domain.Wallet{ID: 7, UserID: 3, Address: "bob_1234@my.wallet", Currency: "INR"}
+    from := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+    to := time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)
+    entries := []store.StatementEntry{
+        {EntryID: 1, TransferID: 10, Amount: 2550, CreatedAt: from.Add(24 * time.Hour), Counterparty: "alice@my.wallet"},
+        {EntryID: 2, TransferID: 11, Amount: -1000, CreatedAt: from.Add(48 * time.Hour), Counterparty: "grabinr@my.wallet"},
+    }
+
+    buildStub := func(mockStatementRepo *mockdb.MockStatementRepo, mockWalletRepo *mockdb.MockWalletRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo) {
+        mockWalletRepo.EXPECT().GetWallet(gomock.Any(), wallet.ID).Times(1).Return(wallet, nil)
+        mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), "INR").Times(1).Return(domain.Currency{Code: "INR", Fraction: 2}, nil)
+        mockStatementRepo.EXPECT().GetWalletBalanceAt(gomock.Any(), store.GetWalletBalanceAtParams{WalletID: wallet.ID, At: from}).Times(1).Return(int64(10000), nil)
+        mockStatementRepo.EXPECT().ListStatementEntries(gomock.Any(), store.ListStatementEntriesParams{WalletID: wallet.ID, From: from, To: to}).Times(1).Return(entries, nil)
+    }
+
+    testcases := []struct {
+        name      string
+        userID    int64
+        format    string
+        buildStub func(mockStatementRepo *mockdb.MockStatementRepo, mockWalletRepo *mockdb.MockWalletRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo)
+        checkResp func(t *testing.T, out string, err error)
+    }{
+        {
+            name:      "Csv",
+            userID:    wallet.UserID,
+            format:    "csv",
+            buildStub: buildStub,
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+
+                records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+                require.NoError(t, err)
+                require.Equal(t, [][]string{
+                    {"date", "transfer_id", "description", "counterparty", "amount", "balance"},
+                    {"2021-03-01 00:00:00", "", "Opening balance", "", "", "100.00"},
+                    {"2021-03-02 00:00:00", "10", "Received from", "alice@my.wallet", "25.50", "125.50"},
+                    {"2021-03-03 00:00:00", "11", "Sent to", "grabinr@my.wallet", "-10.00", "115.50"},
+                    {"2021-03-31 23:59:59", "", "Closing balance", "", "", "115.50"},
+                }, records)
+            },
+        },
+        {
+            name:      "Pdf",
+            userID:    wallet.UserID,
+            format:    "pdf",
+            buildStub: buildStub,
+            checkResp: func(t *testing.T, out string, err error) {
+                require.NoError(t, err)
+                require.True(t, strings.HasPrefix(out, "%PDF-"))
+                require.Contains(t, out, "(Opening balance: 100.00 INR)")
+                require.Contains(t, out, "(alice@my.wallet)")
+                require.Contains(t, out, "(Closing balance: 115.50 INR)")
+            },
+        },
+        {
+            name:   "NotOwner",
+            userID: wallet.UserID + 1,
+            format: "csv",
+            buildStub: func(mockStatementRepo *mockdb.MockStatementRepo, mockWalletRepo *mockdb.MockWalletRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo) {
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), wallet.ID).Times(1).Return(wallet, nil)
+                mockStatementRepo.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+                require.Empty(t, out)
+            },
+        },
+        {
+            name:   "WalletNotFound",
+            userID: wallet.UserID,
+            format: "csv",
+            buildStub: func(mockStatementRepo *mockdb.MockStatementRepo, mockWalletRepo *mockdb.MockWalletRepo, mockCurrencyRepo *mockdb.MockCurrencyRepo) {
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), wallet.ID).Times(1).Return(domain.Wallet{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, out string, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockStatementRepo := mockdb.NewMockStatementRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            mockCurrencyRepo := mockdb.NewMockCurrencyRepo(ctrl)
+            tc.buildStub(mockStatementRepo, mockWalletRepo, mockCurrencyRepo)
+
+            statementSvc := service.NewStatementService(mockStatementRepo, mockWalletRepo, mockCurrencyRepo)
+
+            var buf bytes.Buffer
+            err := statementSvc.Export(context.Background(), dto.GetStatementDto{
+                WalletID: wallet.ID,
+                UserID:   tc.userID,
+                Month:    from.Add(10 * 24 * time.Hour),
+                Format:   tc.format,
+            }, &buf)
+            tc.checkResp(t, buf.String(), err)
+        })
+    }
+}
+
+func TestStatementPdfPages(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    wallet := domain.Wallet{ID: 7, UserID: 3, Address: "bob_1234@my.wallet", Currency: "USD"}
+    var entries []store.StatementEntry
+    for i := 0; i < 120; i++ {
+        entries = append(entries, store.StatementEntry{EntryID: int64(i), TransferID: int64(i), Amount: 100, Counterparty: "alice@my.wallet"})
+    }
+
+    mockStatementRepo := mockdb.NewMockStatementRepo(ctrl)
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    mockCurrencyRepo := mockdb.NewMockCurrencyRepo(ctrl)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), wallet.ID).Times(1).Return(wallet, nil)
+    mockCurrencyRepo.EXPECT().GetCurrency(gomock.Any(), "USD").Times(1).Return(domain.Currency{Code: "USD", Fraction: 2}, nil)
+    mockStatementRepo.EXPECT().GetWalletBalanceAt(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+    mockStatementRepo.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).Times(1).Return(entries, nil)
+
+    statementSvc := service.NewStatementService(mockStatementRepo, mockWalletRepo, mockCurrencyRepo)
+
+    var buf bytes.Buffer
+    err := statementSvc.Export(context.Background(), dto.GetStatementDto{WalletID: wallet.ID, UserID: wallet.UserID, Month: time.Now(), Format: "pdf"}, &buf)
+    require.NoError(t, err)
+    require.Contains(t, buf.String(), "page 3 of 3")
+    require.Contains(t, buf.String(), "(Closing balance: 120.00 USD)")
+}
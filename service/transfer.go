@@ -0,0 +1,101 @@
+package service
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    log "github.com/sirupsen/logrus"
+)
+
+type TransferSvc interface {
+    Refund(ctx context.Context, refundDto dto.RefundTransferDto) (dto.RefundResultDto, error)
+    Reverse(ctx context.Context, reverseDto dto.ReverseTransferDto) (dto.RefundResultDto, error)
+}
+
+type transferService struct {
+    transferRepo store.TransferRepo
+    walletRepo   store.WalletRepo
+}
+
+func NewTransferService(transferRepo store.TransferRepo, walletRepo store.WalletRepo) TransferSvc {
+    return &transferService{
+        transferRepo: transferRepo,
+        walletRepo:   walletRepo,
+    }
+}
+
+// Refund is initiated by the receiver of the payment, anyone else gets ErrTransferNotFound.
+func (t *transferService) Refund(ctx context.Context, refundDto dto.RefundTransferDto) (dto.RefundResultDto, error) {
+    ctx, span := trace.Start(ctx, "TransferSvc.Refund")
+    defer span.End()
+
+    var res dto.RefundResultDto
+
+    transfer, err := t.transferRepo.GetTransfer(ctx, refundDto.TransferID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrTransferNotFound
+        }
+
+        return res, err
+    }
+
+    receiver, err := t.walletRepo.GetWallet(ctx, transfer.ToWalletID)
+    if err != nil {
+        return res, err
+    }
+
+    if receiver.UserID != refundDto.UserID {
+        return res, errors.ErrTransferNotFound
+    }
+
+    return t.refund(ctx, store.RefundTransferParams{
+        TransferID: refundDto.TransferID,
+        Amount:     refundDto.Amount,
+        Kind:       domain.TransferKindREFUND,
+    })
+}
+
+// Reverse is the admin forced refund, it skips the wallet status check and only lets the receiver's wallet
+// go negative when asked to.
+func (t *transferService) Reverse(ctx context.Context, reverseDto dto.ReverseTransferDto) (dto.RefundResultDto, error) {
+    ctx, span := trace.Start(ctx, "TransferSvc.Reverse")
+    defer span.End()
+
+    res, err := t.refund(ctx, store.RefundTransferParams{
+        TransferID:           reverseDto.TransferID,
+        Amount:               reverseDto.Amount,
+        Kind:                 domain.TransferKindREVERSAL,
+        AllowNegativeBalance: reverseDto.AllowNegativeBalance,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    logging.FromContext(ctx).WithFields(log.Fields{
+        "admin_id":               reverseDto.AdminID,
+        "transfer_id":            reverseDto.TransferID,
+        "reversal_id":            res.Refund.Transfer.ID,
+        "amount":                 res.Refund.Transfer.Amount,
+        "allow_negative_balance": reverseDto.AllowNegativeBalance,
+    }).Warn("transfer reversed by admin")
+
+    return res, nil
+}
+
+func (t *transferService) refund(ctx context.Context, arg store.RefundTransferParams) (dto.RefundResultDto, error) {
+    var res dto.RefundResultDto
+
+    refund, err := t.walletRepo.RefundTransfer(ctx, arg)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewRefundResultDto(refund)
+    return res, nil
+}
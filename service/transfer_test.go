@@ -0,0 +1,131 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestRefundTransfer(t *testing.T) {
+    transfer := domain.Transfer{ID: 1, FromWalletID: 10, ToWalletID: 20, Amount: 100, Kind: domain.TransferKindPAYMENT}
+    receiver := domain.Wallet{ID: 20, UserID: 2}
+    refundDto := dto.RefundTransferDto{TransferID: transfer.ID, UserID: receiver.UserID, Amount: 40}
+
+    refund := store.RefundTransferResult{
+        Refund: store.WalletTransferResult{
+            Wallet:   receiver,
+            ToWallet: domain.Wallet{ID: 10, UserID: 1},
+            Transfer: domain.Transfer{ID: 2, Amount: 40, Kind: domain.TransferKindREFUND},
+        },
+        OriginalTransfer: domain.Transfer{ID: 1, Amount: 100, RefundedAmount: 40, RefundStatus: domain.RefundStatusPARTIALLYREFUNDED},
+    }
+
+    testcases := []struct {
+        name      string
+        refundDto dto.RefundTransferDto
+        buildStub func(mockTransferRepo *mockdb.MockTransferRepo, mockWalletRepo *mockdb.MockWalletRepo)
+        checkResp func(t *testing.T, res dto.RefundResultDto, err error)
+    }{
+        {
+            name:      "Ok",
+            refundDto: refundDto,
+            buildStub: func(mockTransferRepo *mockdb.MockTransferRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                arg := store.RefundTransferParams{TransferID: transfer.ID, Amount: 40, Kind: domain.TransferKindREFUND}
+                mockTransferRepo.EXPECT().GetTransfer(gomock.Any(), transfer.ID).Times(1).Return(transfer, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), transfer.ToWalletID).Times(1).Return(receiver, nil)
+                mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), arg).Times(1).Return(refund, nil)
+            },
+            checkResp: func(t *testing.T, res dto.RefundResultDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, int64(40), res.Refund.Transfer.Amount)
+                require.Equal(t, domain.RefundStatusPARTIALLYREFUNDED, res.OriginalTransfer.RefundStatus)
+            },
+        },
+        {
+            name:      "TransferNotFound",
+            refundDto: refundDto,
+            buildStub: func(mockTransferRepo *mockdb.MockTransferRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockTransferRepo.EXPECT().GetTransfer(gomock.Any(), transfer.ID).Times(1).Return(domain.Transfer{}, sql.ErrNoRows)
+                mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.RefundResultDto, err error) {
+                require.EqualError(t, err, errors.ErrTransferNotFound.Error())
+            },
+        },
+        {
+            name:      "NotTheReceiver",
+            refundDto: dto.RefundTransferDto{TransferID: transfer.ID, UserID: 1},
+            buildStub: func(mockTransferRepo *mockdb.MockTransferRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockTransferRepo.EXPECT().GetTransfer(gomock.Any(), transfer.ID).Times(1).Return(transfer, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), transfer.ToWalletID).Times(1).Return(receiver, nil)
+                mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, res dto.RefundResultDto, err error) {
+                require.EqualError(t, err, errors.ErrTransferNotFound.Error())
+            },
+        },
+        {
+            name:      "RefundExceedsTransfer",
+            refundDto: refundDto,
+            buildStub: func(mockTransferRepo *mockdb.MockTransferRepo, mockWalletRepo *mockdb.MockWalletRepo) {
+                mockTransferRepo.EXPECT().GetTransfer(gomock.Any(), transfer.ID).Times(1).Return(transfer, nil)
+                mockWalletRepo.EXPECT().GetWallet(gomock.Any(), transfer.ToWalletID).Times(1).Return(receiver, nil)
+                mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), gomock.Any()).Times(1).Return(store.RefundTransferResult{}, errors.ErrRefundExceedsTransfer)
+            },
+            checkResp: func(t *testing.T, res dto.RefundResultDto, err error) {
+                require.EqualError(t, err, errors.ErrRefundExceedsTransfer.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockTransferRepo := mockdb.NewMockTransferRepo(ctrl)
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            tc.buildStub(mockTransferRepo, mockWalletRepo)
+
+            transferSvc := service.NewTransferService(mockTransferRepo, mockWalletRepo)
+            res, err := transferSvc.Refund(context.TODO(), tc.refundDto)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
+func TestReverseTransfer(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockTransferRepo := mockdb.NewMockTransferRepo(ctrl)
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+
+    arg := store.RefundTransferParams{TransferID: 1, Kind: domain.TransferKindREVERSAL, AllowNegativeBalance: true}
+    refund := store.RefundTransferResult{
+        Refund: store.WalletTransferResult{
+            Wallet:   domain.Wallet{UserID: 2, Balance: -60},
+            ToWallet: domain.Wallet{UserID: 1},
+            Transfer: domain.Transfer{ID: 2, Amount: 100, Kind: domain.TransferKindREVERSAL},
+        },
+        OriginalTransfer: domain.Transfer{ID: 1, Amount: 100, RefundedAmount: 100, RefundStatus: domain.RefundStatusREFUNDED},
+    }
+
+    // the admin's reversal is not checked against the owner of the transfer
+    mockTransferRepo.EXPECT().GetTransfer(gomock.Any(), gomock.Any()).Times(0)
+    mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), arg).Times(1).Return(refund, nil)
+
+    transferSvc := service.NewTransferService(mockTransferRepo, mockWalletRepo)
+    res, err := transferSvc.Reverse(context.TODO(), dto.ReverseTransferDto{TransferID: 1, AdminID: 99, AllowNegativeBalance: true})
+    require.NoError(t, err)
+    require.Equal(t, domain.RefundStatusREFUNDED, res.OriginalTransfer.RefundStatus)
+    require.Equal(t, int64(-60), res.Refund.Wallet.Balance)
+}
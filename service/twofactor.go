@@ -0,0 +1,332 @@
+package service
+
+import (
+    "bytes"
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/qr"
+    "github.com/pranayhere/simple-wallet/pkg/totp"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "time"
+)
+
+// TwoFactorSvc manages the TOTP two factor authentication of the users: the enrollment of an authenticator app, the
+// second step of their login, and the step-up of their payments above the threshold of their currency.
+type TwoFactorSvc interface {
+    Enroll(ctx context.Context, userID int64) (dto.TOTPEnrollmentDto, error)
+    Confirm(ctx context.Context, confirmTOTPDto dto.ConfirmTOTPDto) (dto.TOTPRecoveryCodesDto, error)
+    Disable(ctx context.Context, disableTOTPDto dto.DisableTOTPDto) error
+    VerifyLogin(ctx context.Context, verifyLoginDto dto.VerifyLoginDto) (dto.LoggedInUserDto, error)
+    StepUp(ctx context.Context, stepUpDto dto.StepUpDto) error
+}
+
+type twoFactorService struct {
+    userRepo         store.UserRepo
+    totpRepo         store.TOTPRepo
+    tokenMaker       token.Maker
+    stepUpThresholds map[string]int64
+}
+
+// NewTwoFactorService returns the service, the payments above the threshold of their currency in stepUpThresholds,
+// by currency code, need a TOTP code of the users who enabled two factor authentication. The payments in a currency
+// without a threshold don't.
+func NewTwoFactorService(userRepo store.UserRepo, totpRepo store.TOTPRepo, tokenMaker token.Maker, stepUpThresholds map[string]int64) TwoFactorSvc {
+    return &twoFactorService{
+        userRepo:         userRepo,
+        totpRepo:         totpRepo,
+        tokenMaker:       tokenMaker,
+        stepUpThresholds: stepUpThresholds,
+    }
+}
+
+// Enroll sets a new pending secret for the user, replacing a pending one, and returns it with its QR code.
+func (t *twoFactorService) Enroll(ctx context.Context, userID int64) (dto.TOTPEnrollmentDto, error) {
+    ctx, span := trace.Start(ctx, "TwoFactorSvc.Enroll")
+    defer span.End()
+
+    var enrollmentDto dto.TOTPEnrollmentDto
+
+    user, err := t.userRepo.GetUser(ctx, userID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return enrollmentDto, errors.ErrUserNotFound
+        }
+        return enrollmentDto, err
+    }
+
+    secret, err := totp.NewSecret()
+    if err != nil {
+        return enrollmentDto, err
+    }
+
+    _, err = t.totpRepo.EnrollTOTP(ctx, store.EnrollTOTPParams{UserID: user.ID, Secret: secret})
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return enrollmentDto, errors.ErrTOTPAlreadyEnabled
+        }
+        return enrollmentDto, err
+    }
+
+    uri := totp.ProvisioningURI(secret, constant.TOTPIssuer, user.Username)
+    code, err := qr.Encode(uri, qr.Medium)
+    if err != nil {
+        return enrollmentDto, err
+    }
+
+    var png bytes.Buffer
+    if err := code.WritePNG(&png, constant.TOTPQRScale); err != nil {
+        return enrollmentDto, err
+    }
+
+    enrollmentDto = dto.TOTPEnrollmentDto{
+        Secret:          secret,
+        ProvisioningURI: uri,
+        QRCode:          png.Bytes(),
+    }
+    return enrollmentDto, nil
+}
+
+// Confirm enables the pending secret of the user with a first code of it, and returns the recovery codes of the
+// user, replacing the previous ones.
+func (t *twoFactorService) Confirm(ctx context.Context, confirmTOTPDto dto.ConfirmTOTPDto) (dto.TOTPRecoveryCodesDto, error) {
+    ctx, span := trace.Start(ctx, "TwoFactorSvc.Confirm")
+    defer span.End()
+
+    var recoveryCodesDto dto.TOTPRecoveryCodesDto
+
+    credential, err := t.totpRepo.GetTOTPCredential(ctx, confirmTOTPDto.UserID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return recoveryCodesDto, errors.ErrTOTPNotEnrolled
+        }
+        return recoveryCodesDto, err
+    }
+
+    if credential.IsConfirmed() {
+        return recoveryCodesDto, errors.ErrTOTPAlreadyEnabled
+    }
+
+    now := time.Now().UTC()
+    step, ok := totp.Validate(credential.Secret, confirmTOTPDto.Code, now, credential.LastUsedStep)
+    if !ok {
+        return recoveryCodesDto, errors.ErrInvalidTOTPCode
+    }
+
+    codes := make([]string, constant.TOTPRecoveryCodeCount)
+    hashedCodes := make([]string, constant.TOTPRecoveryCodeCount)
+    for i := range codes {
+        codes[i], err = totp.NewRecoveryCode()
+        if err != nil {
+            return recoveryCodesDto, err
+        }
+        hashedCodes[i] = util.HashToken(totp.NormalizeRecoveryCode(codes[i]))
+    }
+
+    _, err = t.totpRepo.ConfirmTOTP(ctx, store.ConfirmTOTPParams{
+        UserID:              credential.UserID,
+        Step:                step,
+        ConfirmedAt:         now,
+        HashedRecoveryCodes: hashedCodes,
+    })
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return recoveryCodesDto, errors.ErrTOTPAlreadyEnabled
+        }
+        return recoveryCodesDto, err
+    }
+
+    recoveryCodesDto = dto.TOTPRecoveryCodesDto{RecoveryCodes: codes}
+    return recoveryCodesDto, nil
+}
+
+// Disable removes the secret and the recovery codes of the user, who must send the password and a code.
+func (t *twoFactorService) Disable(ctx context.Context, disableTOTPDto dto.DisableTOTPDto) error {
+    ctx, span := trace.Start(ctx, "TwoFactorSvc.Disable")
+    defer span.End()
+
+    user, err := t.userRepo.GetUser(ctx, disableTOTPDto.UserID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return errors.ErrUserNotFound
+        }
+        return err
+    }
+
+    err = util.CheckPassword(disableTOTPDto.Password, user.HashedPassword)
+    if err != nil {
+        return errors.ErrIncorrectPassword
+    }
+
+    err = t.verifyCode(ctx, user.ID, disableTOTPDto.Code, true)
+    if err != nil {
+        return err
+    }
+
+    return t.totpRepo.DisableTOTP(ctx, user.ID)
+}
+
+// VerifyLogin is the second step of the login of a user with two factor authentication: the two factor token of
+// UserSvc.LoginUser and a code of the user are exchanged for an access token.
+func (t *twoFactorService) VerifyLogin(ctx context.Context, verifyLoginDto dto.VerifyLoginDto) (dto.LoggedInUserDto, error) {
+    ctx, span := trace.Start(ctx, "TwoFactorSvc.VerifyLogin")
+    defer span.End()
+
+    var loggedInDto dto.LoggedInUserDto
+
+    payload, err := t.tokenMaker.VerifyToken(verifyLoginDto.TwoFactorToken)
+    if err != nil || payload.Purpose != token.PurposeTwoFactor {
+        return loggedInDto, errors.ErrUnauthorized
+    }
+
+    user, err := t.userRepo.GetUser(ctx, payload.UserID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return loggedInDto, errors.ErrUnauthorized
+        }
+        return loggedInDto, err
+    }
+
+    if user.Status != domain.UserStatusACTIVE {
+        return loggedInDto, errors.ErrUserBlocked
+    }
+
+    if user.TokenRevoked(payload.IssuedAt) {
+        return loggedInDto, errors.ErrTokenRevoked
+    }
+
+    err = t.verifyCode(ctx, user.ID, verifyLoginDto.Code, true)
+    if err != nil {
+        return loggedInDto, err
+    }
+
+    accessToken, err := t.tokenMaker.CreateToken(user.ID, constant.AccessTokenDuration)
+    if err != nil {
+        return loggedInDto, err
+    }
+
+    loggedInDto = dto.LoggedInUserDto{
+        AccessToken: accessToken,
+        User:        dto.NewUserDto(user),
+    }
+    return loggedInDto, nil
+}
+
+// StepUp checks the TOTP code of a payment above the step-up threshold of its currency, the recovery codes are not
+// accepted. The users without two factor authentication are not stepped up.
+func (t *twoFactorService) StepUp(ctx context.Context, stepUpDto dto.StepUpDto) error {
+    ctx, span := trace.Start(ctx, "TwoFactorSvc.StepUp")
+    defer span.End()
+
+    threshold, ok := t.stepUpThresholds[stepUpDto.Currency]
+    if !ok || stepUpDto.Amount <= threshold {
+        return nil
+    }
+
+    credential, err := t.totpRepo.GetTOTPCredential(ctx, stepUpDto.UserID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return nil
+        }
+        return err
+    }
+
+    if !credential.IsConfirmed() {
+        return nil
+    }
+
+    if stepUpDto.Code == "" {
+        return errors.ErrTOTPRequired
+    }
+
+    return t.verifyCode(ctx, stepUpDto.UserID, stepUpDto.Code, false)
+}
+
+// verifyCode uses the TOTP code of the user, or one of the recovery codes when allowed. A code is used once. The
+// invalid codes are counted, TOTPMaxFailedAttempts in a row lock the user out of the codes for TOTPLockoutDuration,
+// whatever the token or the payment they come with.
+func (t *twoFactorService) verifyCode(ctx context.Context, userID int64, code string, allowRecovery bool) error {
+    credential, err := t.totpRepo.GetTOTPCredential(ctx, userID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return errors.ErrTOTPNotEnrolled
+        }
+        return err
+    }
+
+    if !credential.IsConfirmed() {
+        return errors.ErrTOTPNotEnrolled
+    }
+
+    now := time.Now().UTC()
+    if credential.IsLocked(now) {
+        return errors.ErrTOTPLocked
+    }
+
+    if step, ok := totp.Validate(credential.Secret, code, now, credential.LastUsedStep); ok {
+        // a concurrent request may use the code of this step first
+        _, err = t.totpRepo.UseTOTPStep(ctx, store.UseTOTPStepParams{UserID: userID, Step: step})
+        if err == sql.ErrNoRows {
+            return t.failed(ctx, userID, now)
+        }
+        return err
+    }
+
+    if !allowRecovery {
+        return t.failed(ctx, userID, now)
+    }
+
+    _, err = t.totpRepo.UseTOTPRecoveryCode(ctx, store.UseTOTPRecoveryCodeParams{
+        UserID:     userID,
+        HashedCode: util.HashToken(totp.NormalizeRecoveryCode(code)),
+        UsedAt:     now,
+    })
+    if err == sql.ErrNoRows {
+        return t.failed(ctx, userID, now)
+    }
+    return err
+}
+
+// failed counts an invalid code of the user, it returns ErrInvalidTOTPCode, or ErrTOTPLocked when the code locks the
+// user out.
+func (t *twoFactorService) failed(ctx context.Context, userID int64, now time.Time) error {
+    credential, err := t.totpRepo.RecordTOTPFailure(ctx, store.RecordTOTPFailureParams{
+        UserID:      userID,
+        MaxAttempts: constant.TOTPMaxFailedAttempts,
+        LockedUntil: now.Add(constant.TOTPLockoutDuration),
+    })
+    if err != nil {
+        return err
+    }
+
+    if credential.IsLocked(now) {
+        return errors.ErrTOTPLocked
+    }
+    return errors.ErrInvalidTOTPCode
+}
+
+// steppedUpKey is the key of the user whose payment is stepped up in the context of the request.
+type steppedUpKey struct{}
+
+// stepUp checks the TOTP code of a payment by a user, before any money moves, and returns ctx marked stepped up for
+// the user: the services the payment goes through with it don't ask for the code again, which is used once. The
+// payments of a merchant server, authenticated by its api key, are not stepped up, the merchant has no authenticator.
+func stepUp(ctx context.Context, twoFactorSvc TwoFactorSvc, stepUpDto dto.StepUpDto) (context.Context, error) {
+    if _, ok := ctx.Value(constant.APIKeyPayloadKey).(*domain.APIKey); ok {
+        return ctx, nil
+    }
+    if userID, ok := ctx.Value(steppedUpKey{}).(int64); ok && userID == stepUpDto.UserID {
+        return ctx, nil
+    }
+
+    if err := twoFactorSvc.StepUp(ctx, stepUpDto); err != nil {
+        return ctx, err
+    }
+    return context.WithValue(ctx, steppedUpKey{}, stepUpDto.UserID), nil
+}
@@ -0,0 +1,518 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/totp"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/token"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+    "time"
+)
+
+func randomTOTPCredential(t *testing.T, userID int64, confirmed bool) domain.TOTPCredential {
+    secret, err := totp.NewSecret()
+    require.NoError(t, err)
+
+    credential := domain.TOTPCredential{UserID: userID, Secret: secret, CreatedAt: time.Now().UTC()}
+    if confirmed {
+        credential.ConfirmedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+    }
+    return credential
+}
+
+func currentTOTPCode(t *testing.T, secret string) string {
+    code, err := totp.Code(secret, totp.Step(time.Now()))
+    require.NoError(t, err)
+    return code
+}
+
+func TestEnrollTOTP(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo)
+        checkResp func(t *testing.T, enrollmentDto dto.TOTPEnrollmentDto, err error)
+    }{
+        {
+            name: "OK",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.EnrollTOTPParams) (domain.TOTPCredential, error) {
+                        require.Equal(t, user.ID, arg.UserID)
+                        return domain.TOTPCredential{UserID: arg.UserID, Secret: arg.Secret}, nil
+                    })
+            },
+            checkResp: func(t *testing.T, enrollmentDto dto.TOTPEnrollmentDto, err error) {
+                require.NoError(t, err)
+                require.NotEmpty(t, enrollmentDto.Secret)
+                require.True(t, strings.HasPrefix(enrollmentDto.ProvisioningURI, "otpauth://totp/"+constant.TOTPIssuer+":"))
+                require.Contains(t, enrollmentDto.ProvisioningURI, "secret="+enrollmentDto.Secret)
+                require.Equal(t, "\x89PNG", string(enrollmentDto.QRCode[:4]))
+            },
+        },
+        {
+            name: "AlreadyEnabled",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).Times(1).Return(domain.TOTPCredential{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, enrollmentDto dto.TOTPEnrollmentDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPAlreadyEnabled.Error())
+            },
+        },
+        {
+            name: "UserNotFound",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(domain.User{}, sql.ErrNoRows)
+                mockTOTPRepo.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, enrollmentDto dto.TOTPEnrollmentDto, err error) {
+                require.EqualError(t, err, errors.ErrUserNotFound.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockTOTPRepo := mockdb.NewMockTOTPRepo(ctrl)
+            tc.buildStub(mockUserRepo, mockTOTPRepo)
+
+            twoFactorSvc := service.NewTwoFactorService(mockUserRepo, mockTOTPRepo, nil, nil)
+            enrollmentDto, err := twoFactorSvc.Enroll(context.Background(), user.ID)
+            tc.checkResp(t, enrollmentDto, err)
+        })
+    }
+}
+
+func TestConfirmTOTP(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    pending := randomTOTPCredential(t, userID, false)
+
+    testcases := []struct {
+        name      string
+        code      string
+        buildStub func(mockTOTPRepo *mockdb.MockTOTPRepo)
+        checkResp func(t *testing.T, recoveryCodesDto dto.TOTPRecoveryCodesDto, err error)
+    }{
+        {
+            name: "OK",
+            code: currentTOTPCode(t, pending.Secret),
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(1).Return(pending, nil)
+                mockTOTPRepo.EXPECT().ConfirmTOTP(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.ConfirmTOTPParams) (domain.TOTPCredential, error) {
+                        require.Equal(t, userID, arg.UserID)
+                        require.InDelta(t, totp.Step(arg.ConfirmedAt), arg.Step, totp.Skew)
+                        require.Len(t, arg.HashedRecoveryCodes, constant.TOTPRecoveryCodeCount)
+                        return pending, nil
+                    })
+            },
+            checkResp: func(t *testing.T, recoveryCodesDto dto.TOTPRecoveryCodesDto, err error) {
+                require.NoError(t, err)
+                require.Len(t, recoveryCodesDto.RecoveryCodes, constant.TOTPRecoveryCodeCount)
+            },
+        },
+        {
+            name: "InvalidCode",
+            code: "000000",
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(1).Return(pending, nil)
+                mockTOTPRepo.EXPECT().ConfirmTOTP(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, recoveryCodesDto dto.TOTPRecoveryCodesDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+        {
+            name: "AlreadyEnabled",
+            code: "000000",
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(1).Return(randomTOTPCredential(t, userID, true), nil)
+                mockTOTPRepo.EXPECT().ConfirmTOTP(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, recoveryCodesDto dto.TOTPRecoveryCodesDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPAlreadyEnabled.Error())
+            },
+        },
+        {
+            name: "NotEnrolled",
+            code: "000000",
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(1).Return(domain.TOTPCredential{}, sql.ErrNoRows)
+                mockTOTPRepo.EXPECT().ConfirmTOTP(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, recoveryCodesDto dto.TOTPRecoveryCodesDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPNotEnrolled.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockTOTPRepo := mockdb.NewMockTOTPRepo(ctrl)
+            tc.buildStub(mockTOTPRepo)
+
+            twoFactorSvc := service.NewTwoFactorService(nil, mockTOTPRepo, nil, nil)
+            recoveryCodesDto, err := twoFactorSvc.Confirm(context.Background(), dto.ConfirmTOTPDto{UserID: userID, Code: tc.code})
+            tc.checkResp(t, recoveryCodesDto, err)
+        })
+    }
+}
+
+func TestDisableTOTP(t *testing.T) {
+    user, password := util.RandomNewUser(util.RandomCreateUserDto())
+    credential := randomTOTPCredential(t, user.ID, true)
+
+    testcases := []struct {
+        name      string
+        reqDto    dto.DisableTOTPDto
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo)
+        checkResp func(t *testing.T, err error)
+    }{
+        {
+            name:   "OK",
+            reqDto: dto.DisableTOTPDto{UserID: user.ID, Password: password, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().DisableTOTP(gomock.Any(), user.ID).Times(1).Return(nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:   "IncorrectPassword",
+            reqDto: dto.DisableTOTPDto{UserID: user.ID, Password: "incorrect", Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+                mockTOTPRepo.EXPECT().DisableTOTP(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, errors.ErrIncorrectPassword.Error())
+            },
+        },
+        {
+            name:   "NotEnrolled",
+            reqDto: dto.DisableTOTPDto{UserID: user.ID, Password: password, Code: "000000"},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(domain.TOTPCredential{}, sql.ErrNoRows)
+                mockTOTPRepo.EXPECT().DisableTOTP(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, errors.ErrTOTPNotEnrolled.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockTOTPRepo := mockdb.NewMockTOTPRepo(ctrl)
+            tc.buildStub(mockUserRepo, mockTOTPRepo)
+
+            twoFactorSvc := service.NewTwoFactorService(mockUserRepo, mockTOTPRepo, nil, nil)
+            err := twoFactorSvc.Disable(context.Background(), tc.reqDto)
+            tc.checkResp(t, err)
+        })
+    }
+}
+
+func TestVerifyLogin(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    user.Status = domain.UserStatusACTIVE
+    credential := randomTOTPCredential(t, user.ID, true)
+    recoveryCode := "ABCDE-FGHIJ"
+
+    locked := credential
+    locked.LockedUntil = sql.NullTime{Time: time.Now().UTC().Add(constant.TOTPLockoutDuration), Valid: true}
+
+    tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
+    require.NoError(t, err)
+
+    twoFactorToken, err := tokenMaker.CreatePurposeToken(user.ID, token.PurposeTwoFactor, time.Minute)
+    require.NoError(t, err)
+
+    accessToken, err := tokenMaker.CreateToken(user.ID, time.Minute)
+    require.NoError(t, err)
+
+    testcases := []struct {
+        name      string
+        reqDto    dto.VerifyLoginDto
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo)
+        checkResp func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error)
+    }{
+        {
+            name:   "OK",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.NoError(t, err)
+                require.False(t, loggedInUserDto.TwoFactorRequired)
+
+                payload, err := tokenMaker.VerifyToken(loggedInUserDto.AccessToken)
+                require.NoError(t, err)
+                require.Equal(t, user.ID, payload.UserID)
+                require.Empty(t, payload.Purpose)
+            },
+        },
+        {
+            name:   "RecoveryCode",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: "abcde fghij"},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPRecoveryCode(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.UseTOTPRecoveryCodeParams) (domain.TOTPRecoveryCode, error) {
+                        require.Equal(t, user.ID, arg.UserID)
+                        require.Equal(t, util.HashToken(totp.NormalizeRecoveryCode(recoveryCode)), arg.HashedCode)
+                        return domain.TOTPRecoveryCode{UserID: user.ID, HashedCode: arg.HashedCode}, nil
+                    })
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.NoError(t, err)
+                require.NotEmpty(t, loggedInUserDto.AccessToken)
+            },
+        },
+        {
+            name:   "InvalidCode",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: "000000"},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPRecoveryCode(gomock.Any(), gomock.Any()).Times(1).Return(domain.TOTPRecoveryCode{}, sql.ErrNoRows)
+                mockTOTPRepo.EXPECT().RecordTOTPFailure(gomock.Any(), gomock.Any()).Times(1).
+                    DoAndReturn(func(ctx context.Context, arg store.RecordTOTPFailureParams) (domain.TOTPCredential, error) {
+                        require.Equal(t, user.ID, arg.UserID)
+                        require.Equal(t, int32(constant.TOTPMaxFailedAttempts), arg.MaxAttempts)
+                        require.WithinDuration(t, time.Now().UTC().Add(constant.TOTPLockoutDuration), arg.LockedUntil, time.Second)
+
+                        failed := credential
+                        failed.FailedAttempts = 1
+                        return failed, nil
+                    })
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+        {
+            name:   "LockedByInvalidCode",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: "000000"},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPRecoveryCode(gomock.Any(), gomock.Any()).Times(1).Return(domain.TOTPRecoveryCode{}, sql.ErrNoRows)
+                mockTOTPRepo.EXPECT().RecordTOTPFailure(gomock.Any(), gomock.Any()).Times(1).Return(locked, nil)
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPLocked.Error())
+            },
+        },
+        {
+            name:   "LockedOut",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(locked, nil)
+                mockTOTPRepo.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(0)
+                mockTOTPRepo.EXPECT().UseTOTPRecoveryCode(gomock.Any(), gomock.Any()).Times(0)
+                mockTOTPRepo.EXPECT().RecordTOTPFailure(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrTOTPLocked.Error())
+            },
+        },
+        {
+            name:   "CodeAlreadyUsed",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(domain.TOTPCredential{}, sql.ErrNoRows)
+                mockTOTPRepo.EXPECT().RecordTOTPFailure(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+        {
+            name:   "AccessToken",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: accessToken, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrUnauthorized.Error())
+            },
+        },
+        {
+            name:   "RevokedToken",
+            reqDto: dto.VerifyLoginDto{TwoFactorToken: twoFactorToken, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo) {
+                changed := user
+                changed.PasswordChangedAt = time.Now().Add(time.Minute)
+                mockUserRepo.EXPECT().GetUser(gomock.Any(), user.ID).Times(1).Return(changed, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrTokenRevoked.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            mockTOTPRepo := mockdb.NewMockTOTPRepo(ctrl)
+            tc.buildStub(mockUserRepo, mockTOTPRepo)
+
+            twoFactorSvc := service.NewTwoFactorService(mockUserRepo, mockTOTPRepo, tokenMaker, nil)
+            loggedInUserDto, err := twoFactorSvc.VerifyLogin(context.Background(), tc.reqDto)
+            tc.checkResp(t, loggedInUserDto, err)
+        })
+    }
+}
+
+func TestStepUp(t *testing.T) {
+    userID := util.RandomInt(1, 1000)
+    credential := randomTOTPCredential(t, userID, true)
+    var threshold int64 = 1000
+    thresholds := map[string]int64{util.USD: threshold}
+
+    testcases := []struct {
+        name       string
+        thresholds map[string]int64
+        reqDto     dto.StepUpDto
+        buildStub  func(mockTOTPRepo *mockdb.MockTOTPRepo)
+        checkResp  func(t *testing.T, err error)
+    }{
+        {
+            name:       "OK",
+            thresholds: thresholds,
+            reqDto:     dto.StepUpDto{UserID: userID, Amount: threshold + 1, Currency: util.USD, Code: currentTOTPCode(t, credential.Secret)},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(2).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPStep(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:       "BelowThreshold",
+            thresholds: thresholds,
+            reqDto:     dto.StepUpDto{UserID: userID, Amount: threshold, Currency: util.USD},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:   "NoThreshold",
+            reqDto: dto.StepUpDto{UserID: userID, Amount: threshold * 100, Currency: util.USD},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:       "OtherCurrency",
+            thresholds: thresholds,
+            reqDto:     dto.StepUpDto{UserID: userID, Amount: threshold + 1, Currency: util.INR},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:       "TwoFactorNotEnabled",
+            thresholds: thresholds,
+            reqDto:     dto.StepUpDto{UserID: userID, Amount: threshold + 1, Currency: util.USD},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(1).Return(domain.TOTPCredential{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:       "MissingCode",
+            thresholds: thresholds,
+            reqDto:     dto.StepUpDto{UserID: userID, Amount: threshold + 1, Currency: util.USD},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(1).Return(credential, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, errors.ErrTOTPRequired.Error())
+            },
+        },
+        {
+            name:       "RecoveryCodeNotAccepted",
+            thresholds: thresholds,
+            reqDto:     dto.StepUpDto{UserID: userID, Amount: threshold + 1, Currency: util.USD, Code: "ABCDE-FGHIJ"},
+            buildStub: func(mockTOTPRepo *mockdb.MockTOTPRepo) {
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), userID).Times(2).Return(credential, nil)
+                mockTOTPRepo.EXPECT().UseTOTPRecoveryCode(gomock.Any(), gomock.Any()).Times(0)
+                mockTOTPRepo.EXPECT().RecordTOTPFailure(gomock.Any(), gomock.Any()).Times(1).Return(credential, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, errors.ErrInvalidTOTPCode.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockTOTPRepo := mockdb.NewMockTOTPRepo(ctrl)
+            tc.buildStub(mockTOTPRepo)
+
+            twoFactorSvc := service.NewTwoFactorService(nil, mockTOTPRepo, nil, tc.thresholds)
+            err := twoFactorSvc.StepUp(context.Background(), tc.reqDto)
+            tc.checkResp(t, err)
+        })
+    }
+}
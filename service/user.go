@@ -8,6 +8,7 @@ import (
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/pkg/constant"
     "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
     "github.com/pranayhere/simple-wallet/store"
     "github.com/pranayhere/simple-wallet/token"
     "github.com/pranayhere/simple-wallet/util"
@@ -16,21 +17,27 @@ import (
 type UserSvc interface {
     CreateUser(ctx context.Context, createUserDto dto.CreateUserDto) (dto.UserDto, error)
     LoginUser(ctx context.Context, loginCredsDto dto.LoginCredentialsDto) (dto.LoggedInUserDto, error)
+    BlockUser(ctx context.Context, username string) (dto.UserDto, error)
 }
 
 type userService struct {
     userRepo   store.UserRepo
+    totpRepo   store.TOTPRepo
     tokenMaker token.Maker
 }
 
-func NewUserService(userRepo store.UserRepo, tokenMaker token.Maker) UserSvc {
+func NewUserService(userRepo store.UserRepo, totpRepo store.TOTPRepo, tokenMaker token.Maker) UserSvc {
     return &userService{
         userRepo:   userRepo,
+        totpRepo:   totpRepo,
         tokenMaker: tokenMaker,
     }
 }
 
 func (u *userService) CreateUser(ctx context.Context, createUserDto dto.CreateUserDto) (dto.UserDto, error) {
+    ctx, span := trace.Start(ctx, "UserSvc.CreateUser")
+    defer span.End()
+
     var userDto dto.UserDto
 
     hashedPassword, err := util.HashPassword(createUserDto.Password)
@@ -61,7 +68,12 @@ func (u *userService) CreateUser(ctx context.Context, createUserDto dto.CreateUs
     return userDto, nil
 }
 
+// LoginUser checks the password of the user and returns an access token, or a two factor token to exchange for it
+// with TwoFactorSvc.VerifyLogin when the user enabled two factor authentication.
 func (u *userService) LoginUser(ctx context.Context, loginCredentialsDto dto.LoginCredentialsDto) (dto.LoggedInUserDto, error) {
+    ctx, span := trace.Start(ctx, "UserSvc.LoginUser")
+    defer span.End()
+
     var loggedInDto dto.LoggedInUserDto
 
     user, err := u.userRepo.GetUserByUsername(ctx, loginCredentialsDto.Username)
@@ -77,6 +89,30 @@ func (u *userService) LoginUser(ctx context.Context, loginCredentialsDto dto.Log
         return loggedInDto, errors.ErrIncorrectPassword
     }
 
+    if user.Status != domain.UserStatusACTIVE {
+        return loggedInDto, errors.ErrUserBlocked
+    }
+
+    credential, err := u.totpRepo.GetTOTPCredential(ctx, user.ID)
+    if err != nil && err != sql.ErrNoRows {
+        return loggedInDto, err
+    }
+
+    // the password is only the first step of the users with two factor authentication
+    if err == nil && credential.IsConfirmed() {
+        twoFactorToken, err := u.tokenMaker.CreatePurposeToken(user.ID, token.PurposeTwoFactor, constant.TwoFactorTokenDuration)
+        if err != nil {
+            return loggedInDto, err
+        }
+
+        loggedInDto = dto.LoggedInUserDto{
+            TwoFactorRequired: true,
+            TwoFactorToken:    twoFactorToken,
+            User:              dto.NewUserDto(user),
+        }
+        return loggedInDto, nil
+    }
+
     accessToken, err := u.tokenMaker.CreateToken(user.ID, constant.AccessTokenDuration)
     if err != nil {
         return loggedInDto, err
@@ -89,3 +125,30 @@ func (u *userService) LoginUser(ctx context.Context, loginCredentialsDto dto.Log
 
     return loggedInDto, nil
 }
+
+// BlockUser blocks the user, a blocked user can't log in anymore and the authentication refuses its tokens and api keys.
+func (u *userService) BlockUser(ctx context.Context, username string) (dto.UserDto, error) {
+    ctx, span := trace.Start(ctx, "UserSvc.BlockUser")
+    defer span.End()
+
+    var userDto dto.UserDto
+
+    user, err := u.userRepo.GetUserByUsername(ctx, username)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return userDto, errors.ErrUserNotFound
+        }
+        return userDto, err
+    }
+
+    user, err = u.userRepo.UpdateUserStatus(ctx, store.UpdateUserStatusParams{
+        ID:     user.ID,
+        Status: domain.UserStatusBLOCKED,
+    })
+    if err != nil {
+        return userDto, err
+    }
+
+    userDto = dto.NewUserDto(user)
+    return userDto, nil
+}
@@ -18,6 +18,7 @@ import (
     "github.com/stretchr/testify/require"
     "reflect"
     "testing"
+    "time"
 )
 
 type eqCreateUserParamsMatcher struct {
@@ -125,7 +126,7 @@ func TestCreateUser(t *testing.T) {
             require.NoError(t, err)
 
             ctx := context.TODO()
-            userSvc := service.NewUserService(mockUserRepo, tokenMaker)
+            userSvc := service.NewUserService(mockUserRepo, nil, tokenMaker)
             userDto, err := userSvc.CreateUser(ctx, createUserDto)
 
             tc.checkResp(t, createUserDto, userDto, err)
@@ -140,7 +141,7 @@ func TestLoginUser(t *testing.T) {
     testcases := []struct {
         name      string
         reqDto    func() dto.LoginCredentialsDto
-        buildStub func(mockUserRepo *mockdb.MockUserRepo, username string)
+        buildStub func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string)
         checkResp func(t *testing.T, createUserDto dto.CreateUserDto, userDto dto.LoggedInUserDto, err error)
     }{
         {
@@ -151,17 +152,58 @@ func TestLoginUser(t *testing.T) {
                     Password: password,
                 }
             },
-            buildStub: func(mockUserRepo *mockdb.MockUserRepo, username string) {
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
                 mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), username).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(domain.TOTPCredential{}, sql.ErrNoRows)
             },
             checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
                 require.NoError(t, err)
                 require.NotEmpty(t, loggedInUserDto)
+                require.NotEmpty(t, loggedInUserDto.AccessToken)
+                require.False(t, loggedInUserDto.TwoFactorRequired)
                 require.Equal(t, createUserDto.Username, loggedInUserDto.User.Username)
                 require.Equal(t, createUserDto.Email, loggedInUserDto.User.Email)
                 require.Equal(t, createUserDto.FullName, loggedInUserDto.User.FullName)
             },
         },
+        {
+            name: "TwoFactorRequired",
+            reqDto: func() dto.LoginCredentialsDto {
+                return dto.LoginCredentialsDto{
+                    Username: user.Username,
+                    Password: password,
+                }
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
+                mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), username).Times(1).Return(user, nil)
+                credential := domain.TOTPCredential{UserID: user.ID, ConfirmedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true}}
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(credential, nil)
+            },
+            checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.NoError(t, err)
+                require.True(t, loggedInUserDto.TwoFactorRequired)
+                require.Empty(t, loggedInUserDto.AccessToken)
+                require.NotEmpty(t, loggedInUserDto.TwoFactorToken)
+            },
+        },
+        {
+            name: "PendingTwoFactor",
+            reqDto: func() dto.LoginCredentialsDto {
+                return dto.LoginCredentialsDto{
+                    Username: user.Username,
+                    Password: password,
+                }
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
+                mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), username).Times(1).Return(user, nil)
+                mockTOTPRepo.EXPECT().GetTOTPCredential(gomock.Any(), user.ID).Times(1).Return(domain.TOTPCredential{UserID: user.ID}, nil)
+            },
+            checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.NoError(t, err)
+                require.False(t, loggedInUserDto.TwoFactorRequired)
+                require.NotEmpty(t, loggedInUserDto.AccessToken)
+            },
+        },
         {
             name: "UserNotFound",
             reqDto: func() dto.LoginCredentialsDto {
@@ -170,7 +212,7 @@ func TestLoginUser(t *testing.T) {
                     Password: password,
                 }
             },
-            buildStub: func(mockUserRepo *mockdb.MockUserRepo, username string) {
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
                 mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).Times(1).Return(user, sql.ErrNoRows)
             },
             checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
@@ -186,7 +228,7 @@ func TestLoginUser(t *testing.T) {
                     Password: "invalid",
                 }
             },
-            buildStub: func(mockUserRepo *mockdb.MockUserRepo, username string) {
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
                 mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), username).Times(1).Return(user, nil)
             },
             checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
@@ -194,6 +236,24 @@ func TestLoginUser(t *testing.T) {
                 require.EqualError(t, err, errors.ErrIncorrectPassword.Error())
             },
         },
+        {
+            name: "UserBlocked",
+            reqDto: func() dto.LoginCredentialsDto {
+                return dto.LoginCredentialsDto{
+                    Username: user.Username,
+                    Password: password,
+                }
+            },
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
+                blockedUser := user
+                blockedUser.Status = domain.UserStatusBLOCKED
+                mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), username).Times(1).Return(blockedUser, nil)
+            },
+            checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
+                require.EqualError(t, err, errors.ErrUserBlocked.Error())
+                require.Empty(t, loggedInUserDto.AccessToken)
+            },
+        },
         {
             name: "DatabaseConnectionClosed",
             reqDto: func() dto.LoginCredentialsDto {
@@ -202,7 +262,7 @@ func TestLoginUser(t *testing.T) {
                     Password: password,
                 }
             },
-            buildStub: func(mockUserRepo *mockdb.MockUserRepo, username string) {
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo, mockTOTPRepo *mockdb.MockTOTPRepo, username string) {
                 mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), username).Times(1).Return(user, sql.ErrConnDone)
             },
             checkResp: func(t *testing.T, createUserDto dto.CreateUserDto, loggedInUserDto dto.LoggedInUserDto, err error) {
@@ -218,13 +278,14 @@ func TestLoginUser(t *testing.T) {
             defer ctrl.Finish()
 
             mockUserRepo := mockdb.NewMockUserRepo(ctrl)
-            tc.buildStub(mockUserRepo, user.Username)
+            mockTOTPRepo := mockdb.NewMockTOTPRepo(ctrl)
+            tc.buildStub(mockUserRepo, mockTOTPRepo, user.Username)
 
             tokenMaker, err := token.NewJWTMaker(constant.SymmetricKey)
             require.NoError(t, err)
 
             ctx := context.TODO()
-            userSvc := service.NewUserService(mockUserRepo, tokenMaker)
+            userSvc := service.NewUserService(mockUserRepo, mockTOTPRepo, tokenMaker)
 
             arg := tc.reqDto()
             loggedInUserDto, err := userSvc.LoginUser(ctx, arg)
@@ -233,3 +294,64 @@ func TestLoginUser(t *testing.T) {
         })
     }
 }
+
+func TestBlockUser(t *testing.T) {
+    user, _ := util.RandomNewUser(util.RandomCreateUserDto())
+    blockedUser := user
+    blockedUser.Status = domain.UserStatusBLOCKED
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockUserRepo *mockdb.MockUserRepo)
+        checkResp func(t *testing.T, userDto dto.UserDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Times(1).Return(user, nil)
+                mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), store.UpdateUserStatusParams{
+                    ID:     user.ID,
+                    Status: domain.UserStatusBLOCKED,
+                }).Times(1).Return(blockedUser, nil)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, dto.NewUserDto(blockedUser), userDto)
+            },
+        },
+        {
+            name: "UserNotFound",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Times(1).Return(domain.User{}, sql.ErrNoRows)
+                mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.EqualError(t, err, errors.ErrUserNotFound.Error())
+            },
+        },
+        {
+            name: "DatabaseConnectionClosed",
+            buildStub: func(mockUserRepo *mockdb.MockUserRepo) {
+                mockUserRepo.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Times(1).Return(user, nil)
+                mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any()).Times(1).Return(domain.User{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, userDto dto.UserDto, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockUserRepo := mockdb.NewMockUserRepo(ctrl)
+            tc.buildStub(mockUserRepo)
+
+            userSvc := service.NewUserService(mockUserRepo, nil, nil)
+            userDto, err := userSvc.BlockUser(context.Background(), user.Username)
+            tc.checkResp(t, userDto, err)
+        })
+    }
+}
@@ -3,8 +3,10 @@ package service
 import (
     "context"
     "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/dto"
     "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
     "github.com/pranayhere/simple-wallet/store"
 )
 
@@ -13,21 +15,43 @@ type WalletSvc interface {
     PayByWalletID(ctx context.Context, transferMoneyDto dto.TransferMoneyByWalletIDDto) (dto.WalletTransferResultDto, error)
     GetWalletById(ctx context.Context, id int64) (dto.WalletDto, error)
     GetWalletByAddress(ctx context.Context, address string) (dto.WalletDto, error)
+    FreezeWallet(ctx context.Context, id int64) (dto.WalletDto, error)
 }
 
 type walletService struct {
-    walletRepo store.WalletRepo
+    walletRepo   store.WalletRepo
+    twoFactorSvc TwoFactorSvc
 }
 
-func NewWalletService(walletRepo store.WalletRepo) WalletSvc {
+func NewWalletService(walletRepo store.WalletRepo, twoFactorSvc TwoFactorSvc) WalletSvc {
     return &walletService{
-        walletRepo: walletRepo,
+        walletRepo:   walletRepo,
+        twoFactorSvc: twoFactorSvc,
     }
 }
 
+// Pay is stepped up for the owner of the paying wallet.
 func (w *walletService) Pay(ctx context.Context, transferMoneyDto dto.TransferMoneyDto) (dto.WalletTransferResultDto, error) {
+    ctx, span := trace.Start(ctx, "WalletSvc.Pay")
+    defer span.End()
+
     var txnResDto dto.WalletTransferResultDto
 
+    fromWallet, err := w.GetWalletByAddress(ctx, transferMoneyDto.FromWalletAddress)
+    if err != nil {
+        return txnResDto, err
+    }
+
+    ctx, err = stepUp(ctx, w.twoFactorSvc, dto.StepUpDto{
+        UserID:   fromWallet.UserID,
+        Amount:   transferMoneyDto.Amount,
+        Currency: fromWallet.Currency,
+        Code:     transferMoneyDto.TOTPCode,
+    })
+    if err != nil {
+        return txnResDto, err
+    }
+
     arg := store.SendMoneyParams{
         FromWalletAddress: transferMoneyDto.FromWalletAddress,
         ToWalletAddress:   transferMoneyDto.ToWalletAddress,
@@ -44,6 +68,9 @@ func (w *walletService) Pay(ctx context.Context, transferMoneyDto dto.TransferMo
 }
 
 func (w *walletService) PayByWalletID(ctx context.Context, transferMoneyDto dto.TransferMoneyByWalletIDDto) (dto.WalletTransferResultDto, error) {
+    ctx, span := trace.Start(ctx, "WalletSvc.PayByWalletID")
+    defer span.End()
+
     var res dto.WalletTransferResultDto
 
     fromWallet, err := w.GetWalletById(ctx, transferMoneyDto.FromWalletID)
@@ -60,6 +87,7 @@ func (w *walletService) PayByWalletID(ctx context.Context, transferMoneyDto dto.
         FromWalletAddress: fromWallet.Address,
         ToWalletAddress:   toWallet.Address,
         Amount:            transferMoneyDto.Amount,
+        TOTPCode:          transferMoneyDto.TOTPCode,
     }
 
     res, err = w.Pay(ctx, arg)
@@ -71,6 +99,9 @@ func (w *walletService) PayByWalletID(ctx context.Context, transferMoneyDto dto.
 }
 
 func (w *walletService) GetWalletById(ctx context.Context, id int64) (dto.WalletDto, error) {
+    ctx, span := trace.Start(ctx, "WalletSvc.GetWalletById")
+    defer span.End()
+
     var walletDto dto.WalletDto
 
     wallet, err := w.walletRepo.GetWallet(ctx, id)
@@ -87,6 +118,9 @@ func (w *walletService) GetWalletById(ctx context.Context, id int64) (dto.Wallet
 }
 
 func (w *walletService) GetWalletByAddress(ctx context.Context, address string) (dto.WalletDto, error) {
+    ctx, span := trace.Start(ctx, "WalletSvc.GetWalletByAddress")
+    defer span.End()
+
     var walletDto dto.WalletDto
 
     wallet, err := w.walletRepo.GetWalletByAddress(ctx, address)
@@ -101,3 +135,25 @@ func (w *walletService) GetWalletByAddress(ctx context.Context, address string)
     walletDto = dto.NewWalletDto(wallet)
     return walletDto, nil
 }
+
+// FreezeWallet turns the wallet inactive, it can neither send nor receive money until it is active again.
+func (w *walletService) FreezeWallet(ctx context.Context, id int64) (dto.WalletDto, error) {
+    ctx, span := trace.Start(ctx, "WalletSvc.FreezeWallet")
+    defer span.End()
+
+    var walletDto dto.WalletDto
+
+    wallet, err := w.walletRepo.UpdateWalletStatus(ctx, store.UpdateWalletStatusParams{
+        ID:     id,
+        Status: domain.WalletStatusINACTIVE,
+    })
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return walletDto, errors.ErrWalletNotFound
+        }
+        return walletDto, err
+    }
+
+    walletDto = dto.NewWalletDto(wallet)
+    return walletDto, nil
+}
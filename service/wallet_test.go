@@ -6,8 +6,10 @@ import (
     "github.com/golang/mock/gomock"
     "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
     "github.com/pranayhere/simple-wallet/pkg/errors"
     "github.com/pranayhere/simple-wallet/service"
+    mocksvc "github.com/pranayhere/simple-wallet/service/mock"
     "github.com/pranayhere/simple-wallet/store"
     mockdb "github.com/pranayhere/simple-wallet/store/mock"
     "github.com/pranayhere/simple-wallet/util"
@@ -17,16 +19,23 @@ import (
 
 func TestSendMoney(t *testing.T) {
     amount := int64(10)
+    fromWallet := domain.Wallet{ID: 1, UserID: 1, Address: util.RandomWalletAddress(util.RandomEmail()), Currency: util.USD}
 
     testcases := []struct {
         name      string
-        buildStub func(mockWalletRepo *mockdb.MockWalletRepo)
+        buildStub func(mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc)
         checkResp func(t *testing.T, err error)
     }{
         {
             name: "Ok",
-            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo) {
-                mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1)
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                res := store.WalletTransferResult{
+                    Wallet:   domain.Wallet{UserID: 1},
+                    ToWallet: domain.Wallet{UserID: 2},
+                }
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), fromWallet.Address).Times(1).Return(fromWallet, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), dto.StepUpDto{UserID: 1, Amount: amount, Currency: util.USD, Code: "123456"}).Times(1)
+                mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1).Return(res, nil)
             },
             checkResp: func(t *testing.T, err error) {
                 require.NoError(t, err)
@@ -34,7 +43,9 @@ func TestSendMoney(t *testing.T) {
         },
         {
             name: "SendMoneyTxErr",
-            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo) {
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), gomock.Any()).Times(1).Return(fromWallet, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1)
                 mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1).Return(store.WalletTransferResult{}, sql.ErrTxDone)
             },
             checkResp: func(t *testing.T, err error) {
@@ -42,6 +53,28 @@ func TestSendMoney(t *testing.T) {
                 require.EqualError(t, err, sql.ErrTxDone.Error())
             },
         },
+        {
+            name: "TOTPRequired",
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), gomock.Any()).Times(1).Return(fromWallet, nil)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(1).Return(errors.ErrTOTPRequired)
+                mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, errors.ErrTOTPRequired.Error())
+            },
+        },
+        {
+            name: "FromWalletNotFound",
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo, mockTwoFactorSvc *mocksvc.MockTwoFactorSvc) {
+                mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), gomock.Any()).Times(1).Return(domain.Wallet{}, sql.ErrNoRows)
+                mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(0)
+                mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
     }
 
     for _, tc := range testcases {
@@ -50,15 +83,17 @@ func TestSendMoney(t *testing.T) {
             defer ctrl.Finish()
 
             mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
-            tc.buildStub(mockWalletRepo)
+            mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+            tc.buildStub(mockWalletRepo, mockTwoFactorSvc)
 
             ctx := context.TODO()
-            walletSvc := service.NewWalletService(mockWalletRepo)
+            walletSvc := service.NewWalletService(mockWalletRepo, mockTwoFactorSvc)
 
             sendMoneyDto := dto.TransferMoneyDto{
-                FromWalletAddress: util.RandomWalletAddress(util.RandomEmail()),
+                FromWalletAddress: fromWallet.Address,
                 ToWalletAddress:   util.RandomWalletAddress(util.RandomEmail()),
                 Amount:            amount,
+                TOTPCode:          "123456",
             }
             _, err := walletSvc.Pay(ctx, sendMoneyDto)
             tc.checkResp(t, err)
@@ -66,6 +101,26 @@ func TestSendMoney(t *testing.T) {
     }
 }
 
+// TestSendMoneyStepUp checks that a payment is stepped up once, and not for a merchant server.
+func TestSendMoneyStepUp(t *testing.T) {
+    fromWallet := domain.Wallet{ID: 1, UserID: 1, Address: util.RandomWalletAddress(util.RandomEmail()), Currency: util.USD}
+
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    mockTwoFactorSvc := mocksvc.NewMockTwoFactorSvc(ctrl)
+    walletSvc := service.NewWalletService(mockWalletRepo, mockTwoFactorSvc)
+
+    mockWalletRepo.EXPECT().GetWalletByAddress(gomock.Any(), fromWallet.Address).Times(1).Return(fromWallet, nil)
+    mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1)
+    mockTwoFactorSvc.EXPECT().StepUp(gomock.Any(), gomock.Any()).Times(0)
+
+    ctx := context.WithValue(context.TODO(), constant.APIKeyPayloadKey, &domain.APIKey{ID: 1, MerchantID: 1})
+    _, err := walletSvc.Pay(ctx, dto.TransferMoneyDto{FromWalletAddress: fromWallet.Address, ToWalletAddress: util.RandomWalletAddress(util.RandomEmail()), Amount: 10})
+    require.NoError(t, err)
+}
+
 func TestGetWalletById(t *testing.T) {
     walletDto := randomWalletDto(util.RandomInt(1, 1000), util.RandomEmail())
     wallet := randomWallet(t, walletDto)
@@ -122,13 +177,72 @@ func TestGetWalletById(t *testing.T) {
             tc.buildStub(mockWalletRepo)
 
             ctx := context.TODO()
-            walletSvc := service.NewWalletService(mockWalletRepo)
+            walletSvc := service.NewWalletService(mockWalletRepo, mocksvc.NewMockTwoFactorSvc(ctrl))
             res, err := walletSvc.GetWalletById(ctx, walletDto.ID)
             tc.checkResp(t, res, err)
         })
     }
 }
 
+func TestFreezeWallet(t *testing.T) {
+    walletDto := randomWalletDto(util.RandomInt(1, 1000), util.RandomEmail())
+    wallet := randomWallet(t, walletDto)
+    wallet.Status = domain.WalletStatusINACTIVE
+
+    testcases := []struct {
+        name      string
+        buildStub func(mockWalletRepo *mockdb.MockWalletRepo)
+        checkResp func(t *testing.T, res dto.WalletDto, err error)
+    }{
+        {
+            name: "Ok",
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo) {
+                mockWalletRepo.EXPECT().UpdateWalletStatus(gomock.Any(), store.UpdateWalletStatusParams{
+                    ID:     walletDto.ID,
+                    Status: domain.WalletStatusINACTIVE,
+                }).Times(1).Return(wallet, nil)
+            },
+            checkResp: func(t *testing.T, res dto.WalletDto, err error) {
+                require.NoError(t, err)
+                require.Equal(t, walletDto.ID, res.ID)
+                require.Equal(t, domain.WalletStatusINACTIVE, res.Status)
+            },
+        },
+        {
+            name: "WalletNotFound",
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo) {
+                mockWalletRepo.EXPECT().UpdateWalletStatus(gomock.Any(), gomock.Any()).Times(1).Return(domain.Wallet{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, res dto.WalletDto, err error) {
+                require.EqualError(t, err, errors.ErrWalletNotFound.Error())
+            },
+        },
+        {
+            name: "DbConnectionClosed",
+            buildStub: func(mockWalletRepo *mockdb.MockWalletRepo) {
+                mockWalletRepo.EXPECT().UpdateWalletStatus(gomock.Any(), gomock.Any()).Times(1).Return(domain.Wallet{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, res dto.WalletDto, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+            tc.buildStub(mockWalletRepo)
+
+            walletSvc := service.NewWalletService(mockWalletRepo, mocksvc.NewMockTwoFactorSvc(ctrl))
+            res, err := walletSvc.FreezeWallet(context.Background(), walletDto.ID)
+            tc.checkResp(t, res, err)
+        })
+    }
+}
+
 func randomWalletDto(userId int64, email string) dto.WalletDto {
     return dto.WalletDto{
         ID:            util.RandomInt(1, 1000),
@@ -0,0 +1,437 @@
+package service
+
+import (
+    "bytes"
+    "context"
+    "crypto/rand"
+    "database/sql"
+    "encoding/hex"
+    "encoding/json"
+    "fmt"
+    "github.com/google/uuid"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "io"
+    "io/ioutil"
+    "net/http"
+    "time"
+)
+
+type WebhookSvc interface {
+    RegisterEndpoint(ctx context.Context, createWebhookDto dto.CreateWebhookEndpointDto) (dto.WebhookEndpointDto, error)
+    ListEndpoints(ctx context.Context, userID int64) ([]dto.WebhookEndpointDto, error)
+    DisableEndpoint(ctx context.Context, userID int64, endpointID int64) (dto.WebhookEndpointDto, error)
+    ListDeliveries(ctx context.Context, listDeliveriesDto dto.ListWebhookDeliveriesDto) ([]dto.WebhookDeliveryDto, error)
+    Redeliver(ctx context.Context, userID int64, deliveryID int64) (dto.WebhookDeliveryDto, error)
+    HandleEvent(ctx context.Context, event domain.OutboxEvent) error
+    DeliverPending(ctx context.Context) error
+}
+
+type webhookService struct {
+    endpointRepo store.WebhookEndpointRepo
+    deliveryRepo store.WebhookDeliveryRepo
+    client       *http.Client
+}
+
+func NewWebhookService(endpointRepo store.WebhookEndpointRepo, deliveryRepo store.WebhookDeliveryRepo, client *http.Client) WebhookSvc {
+    return &webhookService{
+        endpointRepo: endpointRepo,
+        deliveryRepo: deliveryRepo,
+        client:       client,
+    }
+}
+
+// WebhookEvent is the body posted to the webhook endpoints.
+type WebhookEvent struct {
+    ID        string           `json:"id"`
+    Type      domain.EventType `json:"type"`
+    CreatedAt time.Time        `json:"created_at"`
+    Data      interface{}      `json:"data"`
+}
+
+// RegisterEndpoint creates an endpoint with a fresh signing secret. The secret is returned
+// only here, the partner needs it to verify the signature header of the deliveries.
+func (wh *webhookService) RegisterEndpoint(ctx context.Context, createWebhookDto dto.CreateWebhookEndpointDto) (dto.WebhookEndpointDto, error) {
+    ctx, span := trace.Start(ctx, "WebhookSvc.RegisterEndpoint")
+    defer span.End()
+
+    var res dto.WebhookEndpointDto
+
+    if err := util.ValidateOutboundURL(createWebhookDto.Url); err != nil {
+        return res, errors.ErrNonPublicURL
+    }
+
+    secret, err := newWebhookSecret()
+    if err != nil {
+        return res, err
+    }
+
+    eventTypes := createWebhookDto.EventTypes
+    if eventTypes == nil {
+        eventTypes = []string{}
+    }
+
+    endpoint, err := wh.endpointRepo.CreateWebhookEndpoint(ctx, store.CreateWebhookEndpointParams{
+        UserID:     createWebhookDto.UserID,
+        Url:        createWebhookDto.Url,
+        Secret:     secret,
+        EventTypes: eventTypes,
+        Status:     domain.WebhookEndpointStatusACTIVE,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewWebhookEndpointDto(endpoint)
+    res.Secret = endpoint.Secret
+    return res, nil
+}
+
+func (wh *webhookService) ListEndpoints(ctx context.Context, userID int64) ([]dto.WebhookEndpointDto, error) {
+    ctx, span := trace.Start(ctx, "WebhookSvc.ListEndpoints")
+    defer span.End()
+
+    res := []dto.WebhookEndpointDto{}
+
+    endpoints, err := wh.endpointRepo.ListWebhookEndpoints(ctx, userID)
+    if err != nil {
+        return res, err
+    }
+
+    for _, e := range endpoints {
+        res = append(res, dto.NewWebhookEndpointDto(e))
+    }
+
+    return res, nil
+}
+
+func (wh *webhookService) DisableEndpoint(ctx context.Context, userID int64, endpointID int64) (dto.WebhookEndpointDto, error) {
+    ctx, span := trace.Start(ctx, "WebhookSvc.DisableEndpoint")
+    defer span.End()
+
+    var res dto.WebhookEndpointDto
+
+    _, err := wh.getEndpoint(ctx, userID, endpointID)
+    if err != nil {
+        return res, err
+    }
+
+    endpoint, err := wh.endpointRepo.UpdateWebhookEndpointStatus(ctx, store.UpdateWebhookEndpointStatusParams{
+        ID:     endpointID,
+        Status: domain.WebhookEndpointStatusDISABLED,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewWebhookEndpointDto(endpoint)
+    return res, nil
+}
+
+func (wh *webhookService) ListDeliveries(ctx context.Context, listDeliveriesDto dto.ListWebhookDeliveriesDto) ([]dto.WebhookDeliveryDto, error) {
+    ctx, span := trace.Start(ctx, "WebhookSvc.ListDeliveries")
+    defer span.End()
+
+    res := []dto.WebhookDeliveryDto{}
+
+    _, err := wh.getEndpoint(ctx, listDeliveriesDto.UserID, listDeliveriesDto.EndpointID)
+    if err != nil {
+        return res, err
+    }
+
+    deliveries, err := wh.deliveryRepo.ListWebhookDeliveries(ctx, store.ListWebhookDeliveriesParams{
+        EndpointID: listDeliveriesDto.EndpointID,
+        Limit:      listDeliveriesDto.Limit,
+        Offset:     listDeliveriesDto.Offset,
+    })
+    if err != nil {
+        return res, err
+    }
+
+    for _, d := range deliveries {
+        res = append(res, dto.NewWebhookDeliveryDto(d))
+    }
+
+    return res, nil
+}
+
+// Redeliver sends the delivery again right away and restarts its retry schedule.
+func (wh *webhookService) Redeliver(ctx context.Context, userID int64, deliveryID int64) (dto.WebhookDeliveryDto, error) {
+    ctx, span := trace.Start(ctx, "WebhookSvc.Redeliver")
+    defer span.End()
+
+    var res dto.WebhookDeliveryDto
+
+    delivery, err := wh.deliveryRepo.GetWebhookDelivery(ctx, deliveryID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return res, errors.ErrWebhookDeliveryNotFound
+        }
+
+        return res, err
+    }
+
+    endpoint, err := wh.getEndpoint(ctx, userID, delivery.EndpointID)
+    if err != nil {
+        if err == errors.ErrWebhookEndpointNotFound {
+            return res, errors.ErrWebhookDeliveryNotFound
+        }
+
+        return res, err
+    }
+
+    delivery.Attempts = 0
+    delivery, err = wh.attempt(ctx, endpoint, delivery)
+    if err != nil {
+        return res, err
+    }
+
+    res = dto.NewWebhookDeliveryDto(delivery)
+    return res, nil
+}
+
+// HandleEvent records the webhook deliveries of an outbox event, it is subscribed to the event bus.
+// The id of the webhook event is derived from the outbox event, an event the relay publishes again
+// is not delivered twice to an endpoint.
+func (wh *webhookService) HandleEvent(ctx context.Context, event domain.OutboxEvent) error {
+    ctx, span := trace.Start(ctx, "WebhookSvc.HandleEvent")
+    defer span.End()
+
+    if err := wh.handleEvent(ctx, event); err != nil {
+        logging.FromContext(ctx).WithField("event_id", event.ID).Error("failed to emit webhook event: ", err)
+        return err
+    }
+
+    return nil
+}
+
+func (wh *webhookService) handleEvent(ctx context.Context, event domain.OutboxEvent) error {
+    switch event.EventType {
+    case domain.EventTypeTransferCreated:
+        var res store.WalletTransferResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        // the transfer has an event for each wallet, each owner is told with the event of its wallet
+        userID := res.Wallet.UserID
+        if event.AggregateID == res.ToWallet.ID {
+            userID = res.ToWallet.UserID
+        }
+
+        return wh.emit(ctx, event, domain.EventTypeTransferCompleted, dto.NewTransferEventDto(res), userID)
+    case domain.EventTypeCheckoutCompleted:
+        var res store.CompleteCheckoutSessionResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        return wh.emit(ctx, event, domain.EventTypeCheckoutCompleted, dto.NewCheckoutEventDto(res), res.Transfer.ToWallet.UserID)
+    case domain.EventTypePaymentRequestStatusChanged:
+        var res store.PaymentRequestResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        return wh.emit(ctx, event, domain.EventTypePaymentRequestStatusChanged, dto.NewPaymentRequestDto(res.PaymentRequest), res.FromWallet.UserID, res.ToWallet.UserID)
+    case domain.EventTypeWalletActivated, domain.EventTypeBankAccountVerificationFailed:
+        var res store.BankAccountVerificationResult
+        if err := json.Unmarshal([]byte(event.Payload), &res); err != nil {
+            return err
+        }
+
+        eventType := domain.EventTypeBankAccountVerified
+        if event.EventType == domain.EventTypeBankAccountVerificationFailed {
+            eventType = domain.EventTypeBankAccountVerificationFailed
+        }
+
+        return wh.emit(ctx, event, eventType, dto.NewBankAccountDto(res.BankAccount), res.BankAccount.UserID)
+    }
+
+    return nil
+}
+
+// emit records a delivery of the event for every active endpoint of the users subscribed to
+// the event type. The deliveries are sent by DeliverPending.
+func (wh *webhookService) emit(ctx context.Context, outboxEvent domain.OutboxEvent, eventType domain.EventType, data interface{}, userIDs ...int64) error {
+    event := WebhookEvent{
+        ID:        uuid.NewSHA1(uuid.NameSpaceOID, []byte(fmt.Sprintf("outbox/%d", outboxEvent.ID))).String(),
+        Type:      eventType,
+        CreatedAt: outboxEvent.CreatedAt.UTC(),
+        Data:      data,
+    }
+
+    payload, err := json.Marshal(event)
+    if err != nil {
+        return err
+    }
+
+    for _, userID := range userIDs {
+        endpoints, err := wh.endpointRepo.ListActiveWebhookEndpointsForEvent(ctx, store.ListActiveWebhookEndpointsForEventParams{
+            UserID:    userID,
+            EventType: eventType,
+        })
+        if err != nil {
+            return err
+        }
+
+        for _, e := range endpoints {
+            _, err = wh.deliveryRepo.CreateWebhookDelivery(ctx, store.CreateWebhookDeliveryParams{
+                EndpointID: e.ID,
+                EventID:    event.ID,
+                EventType:  eventType,
+                Payload:    string(payload),
+                Status:     domain.WebhookDeliveryStatusPENDING,
+            })
+            // sql.ErrNoRows: the endpoint has the delivery already, the event is published again
+            // or both users are the same
+            if err != nil && err != sql.ErrNoRows {
+                return err
+            }
+        }
+    }
+
+    return nil
+}
+
+// DeliverPending sends the deliveries due for an attempt. A failure on one delivery is
+// logged and does not stop the rest of the batch.
+func (wh *webhookService) DeliverPending(ctx context.Context) error {
+    ctx, span := trace.Start(ctx, "WebhookSvc.DeliverPending")
+    defer span.End()
+
+    deliveries, err := wh.deliveryRepo.ClaimDueWebhookDeliveries(ctx, store.ClaimDueWebhookDeliveriesParams{
+        Limit:        constant.WebhookDeliveryBatchSize,
+        LeaseSeconds: int64(2 * constant.WebhookDeliveryTimeout / time.Second),
+    })
+    if err != nil {
+        return err
+    }
+
+    endpoints := map[int64]domain.WebhookEndpoint{}
+    for _, d := range deliveries {
+        endpoint, ok := endpoints[d.EndpointID]
+        if !ok {
+            endpoint, err = wh.endpointRepo.GetWebhookEndpoint(ctx, d.EndpointID)
+            if err != nil {
+                logging.FromContext(ctx).WithField("webhook_delivery_id", d.ID).Error("failed to get webhook endpoint: ", err)
+                continue
+            }
+            endpoints[d.EndpointID] = endpoint
+        }
+
+        if _, err := wh.attempt(ctx, endpoint, d); err != nil {
+            logging.FromContext(ctx).WithField("webhook_delivery_id", d.ID).Error("failed to record webhook delivery: ", err)
+        }
+    }
+
+    return nil
+}
+
+// attempt posts the delivery to the endpoint and records the outcome. Failed attempts are
+// retried with exponential backoff until constant.WebhookMaxAttempts is reached.
+func (wh *webhookService) attempt(ctx context.Context, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+    arg := store.UpdateWebhookDeliveryParams{
+        ID:       delivery.ID,
+        Attempts: delivery.Attempts + 1,
+        Status:   domain.WebhookDeliveryStatusPENDING,
+    }
+
+    if endpoint.Status != domain.WebhookEndpointStatusACTIVE {
+        arg.Status = domain.WebhookDeliveryStatusFAILED
+        arg.Attempts = delivery.Attempts
+        arg.LastError = "webhook endpoint is disabled"
+        arg.NextAttemptAt = delivery.NextAttemptAt
+        return wh.deliveryRepo.UpdateWebhookDelivery(ctx, arg)
+    }
+
+    code, err := wh.send(ctx, endpoint, delivery)
+    arg.LastResponseCode = int64(code)
+    if err != nil {
+        arg.LastError = err.Error()
+    }
+
+    switch {
+    case err == nil:
+        arg.Status = domain.WebhookDeliveryStatusDELIVERED
+        arg.NextAttemptAt = time.Now().UTC()
+    case arg.Attempts >= constant.WebhookMaxAttempts:
+        arg.Status = domain.WebhookDeliveryStatusFAILED
+        arg.NextAttemptAt = time.Now().UTC()
+    default:
+        arg.NextAttemptAt = time.Now().UTC().Add(WebhookRetryDelay(arg.Attempts))
+    }
+
+    return wh.deliveryRepo.UpdateWebhookDelivery(ctx, arg)
+}
+
+func (wh *webhookService) send(ctx context.Context, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery) (int, error) {
+    ctx, cancel := context.WithTimeout(ctx, constant.WebhookDeliveryTimeout)
+    defer cancel()
+
+    payload := []byte(delivery.Payload)
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(payload))
+    if err != nil {
+        return 0, err
+    }
+
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set(constant.WebhookEventIDHeaderKey, delivery.EventID)
+    req.Header.Set(constant.WebhookEventTypeHeaderKey, string(delivery.EventType))
+    req.Header.Set(constant.WebhookSignatureHeaderKey, util.SignWebhookPayload(endpoint.Secret, time.Now().Unix(), payload))
+
+    resp, err := wh.client.Do(req)
+    if err != nil {
+        return 0, err
+    }
+    defer resp.Body.Close()
+    _, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, constant.WebhookResponseLimit))
+
+    if resp.StatusCode < 200 || resp.StatusCode > 299 {
+        return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+    }
+
+    return resp.StatusCode, nil
+}
+
+func (wh *webhookService) getEndpoint(ctx context.Context, userID int64, endpointID int64) (domain.WebhookEndpoint, error) {
+    endpoint, err := wh.endpointRepo.GetWebhookEndpoint(ctx, endpointID)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return endpoint, errors.ErrWebhookEndpointNotFound
+        }
+
+        return endpoint, err
+    }
+
+    if endpoint.UserID != userID {
+        return domain.WebhookEndpoint{}, errors.ErrWebhookEndpointNotFound
+    }
+
+    return endpoint, nil
+}
+
+// WebhookRetryDelay is the wait before the next attempt after the given number of failed attempts,
+// doubling from constant.WebhookRetryBaseDelay.
+func WebhookRetryDelay(attempts int64) time.Duration {
+    if attempts < 1 {
+        attempts = 1
+    }
+
+    return constant.WebhookRetryBaseDelay * time.Duration(1<<uint(attempts-1))
+}
+
+func newWebhookSecret() (string, error) {
+    b := make([]byte, 24)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+
+    return "whsec_" + hex.EncodeToString(b), nil
+}
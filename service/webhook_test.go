@@ -0,0 +1,404 @@
+package service_test
+
+import (
+    "context"
+    "database/sql"
+    "encoding/json"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/dto"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/service"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "io/ioutil"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+// webhookReceiver records the requests posted by the webhook service and answers with status.
+type webhookReceiver struct {
+    server   *httptest.Server
+    status   int
+    requests []*http.Request
+    bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T, status int) *webhookReceiver {
+    rcv := &webhookReceiver{status: status}
+    rcv.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        body, err := ioutil.ReadAll(r.Body)
+        require.NoError(t, err)
+
+        rcv.requests = append(rcv.requests, r)
+        rcv.bodies = append(rcv.bodies, body)
+        w.WriteHeader(rcv.status)
+    }))
+    t.Cleanup(rcv.server.Close)
+    return rcv
+}
+
+func randomWebhookEndpoint(url string) domain.WebhookEndpoint {
+    return domain.WebhookEndpoint{
+        ID:         util.RandomInt(1, 1000),
+        UserID:     util.RandomInt(1, 1000),
+        Url:        url,
+        Secret:     "whsec_" + util.RandomString(32),
+        EventTypes: []string{},
+        Status:     domain.WebhookEndpointStatusACTIVE,
+    }
+}
+
+func randomWebhookDelivery(endpoint domain.WebhookEndpoint, attempts int64) domain.WebhookDelivery {
+    return domain.WebhookDelivery{
+        ID:         util.RandomInt(1, 1000),
+        EndpointID: endpoint.ID,
+        EventID:    util.RandomString(16),
+        EventType:  domain.EventTypeTransferCompleted,
+        Payload:    `{"id":"evt","type":"transfer.completed","data":{"amount":10}}`,
+        Status:     domain.WebhookDeliveryStatusPENDING,
+        Attempts:   attempts,
+    }
+}
+
+func TestDeliverPending(t *testing.T) {
+    claimArg := store.ClaimDueWebhookDeliveriesParams{Limit: 50, LeaseSeconds: 20}
+
+    testcases := []struct {
+        name      string
+        status    int
+        attempts  int64
+        disabled  bool
+        checkResp func(t *testing.T, rcv *webhookReceiver, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery, arg store.UpdateWebhookDeliveryParams)
+    }{
+        {
+            name:   "Delivered",
+            status: http.StatusOK,
+            checkResp: func(t *testing.T, rcv *webhookReceiver, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery, arg store.UpdateWebhookDeliveryParams) {
+                require.Len(t, rcv.requests, 1)
+                req := rcv.requests[0]
+                require.Equal(t, http.MethodPost, req.Method)
+                require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+                require.Equal(t, delivery.EventID, req.Header.Get(constant.WebhookEventIDHeaderKey))
+                require.Equal(t, string(delivery.EventType), req.Header.Get(constant.WebhookEventTypeHeaderKey))
+                require.Equal(t, delivery.Payload, string(rcv.bodies[0]))
+                require.True(t, util.VerifyWebhookSignature(endpoint.Secret, req.Header.Get(constant.WebhookSignatureHeaderKey), rcv.bodies[0]))
+
+                require.Equal(t, domain.WebhookDeliveryStatusDELIVERED, arg.Status)
+                require.Equal(t, int64(1), arg.Attempts)
+                require.Equal(t, int64(http.StatusOK), arg.LastResponseCode)
+                require.Empty(t, arg.LastError)
+            },
+        },
+        {
+            name:     "RetriedWithBackoff",
+            status:   http.StatusInternalServerError,
+            attempts: 2,
+            checkResp: func(t *testing.T, rcv *webhookReceiver, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery, arg store.UpdateWebhookDeliveryParams) {
+                require.Len(t, rcv.requests, 1)
+                require.Equal(t, domain.WebhookDeliveryStatusPENDING, arg.Status)
+                require.Equal(t, int64(3), arg.Attempts)
+                require.Equal(t, int64(http.StatusInternalServerError), arg.LastResponseCode)
+                require.NotEmpty(t, arg.LastError)
+                require.WithinDuration(t, time.Now().UTC().Add(4*constant.WebhookRetryBaseDelay), arg.NextAttemptAt, time.Second)
+            },
+        },
+        {
+            name:     "FailedAfterMaxAttempts",
+            status:   http.StatusBadGateway,
+            attempts: constant.WebhookMaxAttempts - 1,
+            checkResp: func(t *testing.T, rcv *webhookReceiver, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery, arg store.UpdateWebhookDeliveryParams) {
+                require.Len(t, rcv.requests, 1)
+                require.Equal(t, domain.WebhookDeliveryStatusFAILED, arg.Status)
+                require.Equal(t, int64(constant.WebhookMaxAttempts), arg.Attempts)
+                require.Equal(t, int64(http.StatusBadGateway), arg.LastResponseCode)
+            },
+        },
+        {
+            name:     "EndpointDisabled",
+            status:   http.StatusOK,
+            disabled: true,
+            checkResp: func(t *testing.T, rcv *webhookReceiver, endpoint domain.WebhookEndpoint, delivery domain.WebhookDelivery, arg store.UpdateWebhookDeliveryParams) {
+                require.Empty(t, rcv.requests)
+                require.Equal(t, domain.WebhookDeliveryStatusFAILED, arg.Status)
+                require.Equal(t, int64(0), arg.Attempts)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            rcv := newWebhookReceiver(t, tc.status)
+            endpoint := randomWebhookEndpoint(rcv.server.URL)
+            if tc.disabled {
+                endpoint.Status = domain.WebhookEndpointStatusDISABLED
+            }
+            delivery := randomWebhookDelivery(endpoint, tc.attempts)
+
+            var updateArg store.UpdateWebhookDeliveryParams
+            mockEndpointRepo := mockdb.NewMockWebhookEndpointRepo(ctrl)
+            mockDeliveryRepo := mockdb.NewMockWebhookDeliveryRepo(ctrl)
+            mockDeliveryRepo.EXPECT().ClaimDueWebhookDeliveries(gomock.Any(), claimArg).Times(1).Return([]domain.WebhookDelivery{delivery}, nil)
+            mockEndpointRepo.EXPECT().GetWebhookEndpoint(gomock.Any(), endpoint.ID).Times(1).Return(endpoint, nil)
+            mockDeliveryRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), gomock.Any()).Times(1).
+                DoAndReturn(func(ctx context.Context, arg store.UpdateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+                    updateArg = arg
+                    return domain.WebhookDelivery{}, nil
+                })
+
+            webhookSvc := service.NewWebhookService(mockEndpointRepo, mockDeliveryRepo, rcv.server.Client())
+            err := webhookSvc.DeliverPending(context.TODO())
+            require.NoError(t, err)
+
+            require.Equal(t, delivery.ID, updateArg.ID)
+            tc.checkResp(t, rcv, endpoint, delivery, updateArg)
+        })
+    }
+}
+
+func TestWebhookHandleEvent(t *testing.T) {
+    res := store.WalletTransferResult{
+        Transfer: domain.Transfer{ID: 7, Amount: 10},
+        Wallet:   domain.Wallet{ID: 1, UserID: util.RandomInt(1, 1000)},
+        ToWallet: domain.Wallet{ID: 2, UserID: util.RandomInt(1001, 2000)},
+    }
+    payload, err := json.Marshal(res)
+    require.NoError(t, err)
+
+    // the transfer has an event for each wallet
+    senderEvent := domain.OutboxEvent{
+        ID:            util.RandomInt(1, 1000),
+        AggregateType: domain.AggregateTypeWALLET,
+        AggregateID:   res.Wallet.ID,
+        EventType:     domain.EventTypeTransferCreated,
+        Payload:       string(payload),
+    }
+    receiverEvent := senderEvent
+    receiverEvent.ID++
+    receiverEvent.AggregateID = res.ToWallet.ID
+
+    endpoints := []domain.WebhookEndpoint{randomWebhookEndpoint("https://a.example.com"), randomWebhookEndpoint("https://b.example.com")}
+    senderArg := store.ListActiveWebhookEndpointsForEventParams{UserID: res.Wallet.UserID, EventType: domain.EventTypeTransferCompleted}
+    receiverArg := store.ListActiveWebhookEndpointsForEventParams{UserID: res.ToWallet.UserID, EventType: domain.EventTypeTransferCompleted}
+
+    testcases := []struct {
+        name      string
+        event     domain.OutboxEvent
+        buildStub func(mockEndpointRepo *mockdb.MockWebhookEndpointRepo, mockDeliveryRepo *mockdb.MockWebhookDeliveryRepo)
+        checkResp func(t *testing.T, err error)
+    }{
+        {
+            name:  "SenderEvent",
+            event: senderEvent,
+            buildStub: func(mockEndpointRepo *mockdb.MockWebhookEndpointRepo, mockDeliveryRepo *mockdb.MockWebhookDeliveryRepo) {
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), senderArg).Times(1).Return(endpoints, nil)
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), receiverArg).Times(0)
+
+                var eventIDs []string
+                mockDeliveryRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(2).
+                    DoAndReturn(func(ctx context.Context, arg store.CreateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+                        require.Equal(t, domain.WebhookDeliveryStatusPENDING, arg.Status)
+                        require.Equal(t, domain.EventTypeTransferCompleted, arg.EventType)
+
+                        var webhookEvent service.WebhookEvent
+                        require.NoError(t, json.Unmarshal([]byte(arg.Payload), &webhookEvent))
+                        require.Equal(t, arg.EventID, webhookEvent.ID)
+                        require.Equal(t, domain.EventTypeTransferCompleted, webhookEvent.Type)
+
+                        eventIDs = append(eventIDs, arg.EventID)
+                        if len(eventIDs) == 2 {
+                            require.Equal(t, eventIDs[0], eventIDs[1])
+                        }
+                        return domain.WebhookDelivery{}, nil
+                    })
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:  "ReceiverEvent",
+            event: receiverEvent,
+            buildStub: func(mockEndpointRepo *mockdb.MockWebhookEndpointRepo, mockDeliveryRepo *mockdb.MockWebhookDeliveryRepo) {
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), senderArg).Times(0)
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), receiverArg).Times(1).Return(endpoints[1:], nil)
+                mockDeliveryRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(1).Return(domain.WebhookDelivery{}, nil)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:  "AlreadyDelivered",
+            event: senderEvent,
+            buildStub: func(mockEndpointRepo *mockdb.MockWebhookEndpointRepo, mockDeliveryRepo *mockdb.MockWebhookDeliveryRepo) {
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), senderArg).Times(1).Return(endpoints, nil)
+                mockDeliveryRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(2).Return(domain.WebhookDelivery{}, sql.ErrNoRows)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+        {
+            name:  "CreateDeliveryErr",
+            event: senderEvent,
+            buildStub: func(mockEndpointRepo *mockdb.MockWebhookEndpointRepo, mockDeliveryRepo *mockdb.MockWebhookDeliveryRepo) {
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), senderArg).Times(1).Return(endpoints, nil)
+                mockDeliveryRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(1).Return(domain.WebhookDelivery{}, sql.ErrConnDone)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.EqualError(t, err, sql.ErrConnDone.Error())
+            },
+        },
+        {
+            name:  "NotAWebhookEvent",
+            event: domain.OutboxEvent{ID: senderEvent.ID, EventType: domain.EventTypeWalletCreated, Payload: "{}"},
+            buildStub: func(mockEndpointRepo *mockdb.MockWebhookEndpointRepo, mockDeliveryRepo *mockdb.MockWebhookDeliveryRepo) {
+                mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), gomock.Any()).Times(0)
+                mockDeliveryRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(0)
+            },
+            checkResp: func(t *testing.T, err error) {
+                require.NoError(t, err)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            mockEndpointRepo := mockdb.NewMockWebhookEndpointRepo(ctrl)
+            mockDeliveryRepo := mockdb.NewMockWebhookDeliveryRepo(ctrl)
+            tc.buildStub(mockEndpointRepo, mockDeliveryRepo)
+
+            webhookSvc := service.NewWebhookService(mockEndpointRepo, mockDeliveryRepo, http.DefaultClient)
+            err := webhookSvc.HandleEvent(context.TODO(), tc.event)
+            tc.checkResp(t, err)
+        })
+    }
+}
+
+func TestWebhookEventIDOfOutboxEvent(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    res := store.CompleteCheckoutSessionResult{
+        Transfer: store.WalletTransferResult{ToWallet: domain.Wallet{UserID: util.RandomInt(1, 1000)}},
+    }
+    payload, err := json.Marshal(res)
+    require.NoError(t, err)
+
+    mockEndpointRepo := mockdb.NewMockWebhookEndpointRepo(ctrl)
+    mockDeliveryRepo := mockdb.NewMockWebhookDeliveryRepo(ctrl)
+    mockEndpointRepo.EXPECT().ListActiveWebhookEndpointsForEvent(gomock.Any(), gomock.Any()).Times(3).
+        Return([]domain.WebhookEndpoint{randomWebhookEndpoint("https://a.example.com")}, nil)
+
+    var eventIDs []string
+    mockDeliveryRepo.EXPECT().CreateWebhookDelivery(gomock.Any(), gomock.Any()).Times(3).
+        DoAndReturn(func(ctx context.Context, arg store.CreateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+            require.Equal(t, domain.EventTypeCheckoutCompleted, arg.EventType)
+            eventIDs = append(eventIDs, arg.EventID)
+            return domain.WebhookDelivery{}, nil
+        })
+
+    webhookSvc := service.NewWebhookService(mockEndpointRepo, mockDeliveryRepo, http.DefaultClient)
+    event := domain.OutboxEvent{ID: 1, EventType: domain.EventTypeCheckoutCompleted, Payload: string(payload)}
+    require.NoError(t, webhookSvc.HandleEvent(context.TODO(), event))
+    require.NoError(t, webhookSvc.HandleEvent(context.TODO(), event))
+
+    event.ID = 2
+    require.NoError(t, webhookSvc.HandleEvent(context.TODO(), event))
+
+    // an event published again keeps its id, the partners and the deliveries table dedupe on it
+    require.Equal(t, eventIDs[0], eventIDs[1])
+    require.NotEqual(t, eventIDs[0], eventIDs[2])
+}
+
+func TestRegisterWebhookEndpointNonPublicURL(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockEndpointRepo := mockdb.NewMockWebhookEndpointRepo(ctrl)
+    mockEndpointRepo.EXPECT().CreateWebhookEndpoint(gomock.Any(), gomock.Any()).Times(0)
+
+    webhookSvc := service.NewWebhookService(mockEndpointRepo, mockdb.NewMockWebhookDeliveryRepo(ctrl), http.DefaultClient)
+    for _, url := range []string{"ftp://merchant.example.com/hook", "http://169.254.169.254/latest/meta-data", "http://127.0.0.1:6379"} {
+        _, err := webhookSvc.RegisterEndpoint(context.TODO(), dto.CreateWebhookEndpointDto{UserID: 1, Url: url})
+        require.EqualError(t, err, errors.ErrNonPublicURL.Error())
+    }
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+    testcases := []struct {
+        name      string
+        owner     bool
+        checkResp func(t *testing.T, rcv *webhookReceiver, res dto.WebhookDeliveryDto, err error)
+    }{
+        {
+            name:  "Ok",
+            owner: true,
+            checkResp: func(t *testing.T, rcv *webhookReceiver, res dto.WebhookDeliveryDto, err error) {
+                require.NoError(t, err)
+                require.Len(t, rcv.requests, 1)
+                require.Equal(t, domain.WebhookDeliveryStatusDELIVERED, res.Status)
+                require.Equal(t, int64(1), res.Attempts)
+            },
+        },
+        {
+            name:  "NotOwner",
+            owner: false,
+            checkResp: func(t *testing.T, rcv *webhookReceiver, res dto.WebhookDeliveryDto, err error) {
+                require.EqualError(t, err, errors.ErrWebhookDeliveryNotFound.Error())
+                require.Empty(t, rcv.requests)
+            },
+        },
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            ctrl := gomock.NewController(t)
+            defer ctrl.Finish()
+
+            rcv := newWebhookReceiver(t, http.StatusNoContent)
+            endpoint := randomWebhookEndpoint(rcv.server.URL)
+            delivery := randomWebhookDelivery(endpoint, constant.WebhookMaxAttempts)
+            delivery.Status = domain.WebhookDeliveryStatusFAILED
+
+            userID := endpoint.UserID
+            if !tc.owner {
+                userID = endpoint.UserID + 1
+            }
+
+            mockEndpointRepo := mockdb.NewMockWebhookEndpointRepo(ctrl)
+            mockDeliveryRepo := mockdb.NewMockWebhookDeliveryRepo(ctrl)
+            mockDeliveryRepo.EXPECT().GetWebhookDelivery(gomock.Any(), delivery.ID).Times(1).Return(delivery, nil)
+            mockEndpointRepo.EXPECT().GetWebhookEndpoint(gomock.Any(), endpoint.ID).Times(1).Return(endpoint, nil)
+            mockDeliveryRepo.EXPECT().UpdateWebhookDelivery(gomock.Any(), gomock.Any()).AnyTimes().
+                DoAndReturn(func(ctx context.Context, arg store.UpdateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+                    updated := delivery
+                    updated.Status = arg.Status
+                    updated.Attempts = arg.Attempts
+                    return updated, nil
+                })
+
+            webhookSvc := service.NewWebhookService(mockEndpointRepo, mockDeliveryRepo, rcv.server.Client())
+            res, err := webhookSvc.Redeliver(context.TODO(), userID, delivery.ID)
+            tc.checkResp(t, rcv, res, err)
+        })
+    }
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+    require.Equal(t, constant.WebhookRetryBaseDelay, service.WebhookRetryDelay(1))
+    require.Equal(t, 2*constant.WebhookRetryBaseDelay, service.WebhookRetryDelay(2))
+    require.Equal(t, 64*constant.WebhookRetryBaseDelay, service.WebhookRetryDelay(7))
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/lib/pq"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type APIKeyRepo interface {
+    CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (domain.APIKey, error)
+    GetAPIKey(ctx context.Context, id int64) (domain.APIKey, error)
+    GetAPIKeyByPrefix(ctx context.Context, prefix string) (domain.APIKey, error)
+    ListAPIKeys(ctx context.Context, merchantID int64) ([]domain.APIKey, error)
+    UpdateAPIKeyStatus(ctx context.Context, arg UpdateAPIKeyStatusParams) (domain.APIKey, error)
+}
+
+type apiKeyRepository struct {
+    db *sql.DB
+}
+
+func NewAPIKeyRepo(client *sql.DB) APIKeyRepo {
+    return &apiKeyRepository{
+        db: client,
+    }
+}
+
+const createAPIKey = `-- name: CreateAPIKey :one
+INSERT INTO api_keys (merchant_id,
+                      name,
+                      prefix,
+                      hashed_key,
+                      scopes,
+                      status)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+`
+
+type CreateAPIKeyParams struct {
+    MerchantID int64               `json:"merchant_id"`
+    Name       string              `json:"name"`
+    Prefix     string              `json:"prefix"`
+    HashedKey  string              `json:"hashed_key"`
+    Scopes     []string            `json:"scopes"`
+    Status     domain.APIKeyStatus `json:"status"`
+}
+
+func (q *apiKeyRepository) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (domain.APIKey, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createAPIKey,
+        arg.MerchantID,
+        arg.Name,
+        arg.Prefix,
+        arg.HashedKey,
+        pq.Array(arg.Scopes),
+        arg.Status,
+    )
+    return scanAPIKey(row)
+}
+
+const getAPIKey = `-- name: GetAPIKey :one
+SELECT id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+FROM api_keys
+WHERE id = $1 LIMIT 1
+`
+
+func (q *apiKeyRepository) GetAPIKey(ctx context.Context, id int64) (domain.APIKey, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getAPIKey, id)
+    return scanAPIKey(row)
+}
+
+const getAPIKeyByPrefix = `-- name: GetAPIKeyByPrefix :one
+SELECT id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+FROM api_keys
+WHERE prefix = $1 LIMIT 1
+`
+
+func (q *apiKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (domain.APIKey, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getAPIKeyByPrefix, prefix)
+    return scanAPIKey(row)
+}
+
+const listAPIKeys = `-- name: ListAPIKeys :many
+SELECT id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+FROM api_keys
+WHERE merchant_id = $1
+ORDER BY id
+`
+
+func (q *apiKeyRepository) ListAPIKeys(ctx context.Context, merchantID int64) ([]domain.APIKey, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listAPIKeys, merchantID)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.APIKey{}
+    for rows.Next() {
+        var i domain.APIKey
+        if err := rows.Scan(
+            &i.ID,
+            &i.MerchantID,
+            &i.Name,
+            &i.Prefix,
+            &i.HashedKey,
+            pq.Array(&i.Scopes),
+            &i.Status,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const updateAPIKeyStatus = `-- name: UpdateAPIKeyStatus :one
+UPDATE api_keys
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1 RETURNING id, merchant_id, name, prefix, hashed_key, scopes, status, created_at, updated_at
+`
+
+type UpdateAPIKeyStatusParams struct {
+    ID     int64               `json:"id"`
+    Status domain.APIKeyStatus `json:"status"`
+}
+
+func (q *apiKeyRepository) UpdateAPIKeyStatus(ctx context.Context, arg UpdateAPIKeyStatusParams) (domain.APIKey, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateAPIKeyStatus, arg.ID, arg.Status)
+    return scanAPIKey(row)
+}
+
+func scanAPIKey(row *sql.Row) (domain.APIKey, error) {
+    var i domain.APIKey
+    err := row.Scan(
+        &i.ID,
+        &i.MerchantID,
+        &i.Name,
+        &i.Prefix,
+        &i.HashedKey,
+        pq.Array(&i.Scopes),
+        &i.Status,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
@@ -0,0 +1,79 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func createRandomAPIKey(t *testing.T, merchant domain.Merchant) domain.APIKey {
+    key, prefix, err := util.GenerateAPIKey()
+    require.NoError(t, err)
+
+    arg := store.CreateAPIKeyParams{
+        MerchantID: merchant.ID,
+        Name:       util.RandomString(6),
+        Prefix:     prefix,
+        HashedKey:  util.HashAPIKey(key),
+        Scopes:     []string{"transfers:write", "wallets:read"},
+        Status:     domain.APIKeyStatusACTIVE,
+    }
+
+    apiKey, err := store.NewAPIKeyRepo(testDb).CreateAPIKey(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, apiKey.ID)
+    require.Equal(t, arg.MerchantID, apiKey.MerchantID)
+    require.Equal(t, arg.Name, apiKey.Name)
+    require.Equal(t, arg.Prefix, apiKey.Prefix)
+    require.Equal(t, arg.HashedKey, apiKey.HashedKey)
+    require.Equal(t, arg.Scopes, apiKey.Scopes)
+    require.Equal(t, arg.Status, apiKey.Status)
+
+    return apiKey
+}
+
+func TestGetAPIKeyByPrefix(t *testing.T) {
+    apiKeyRepo := store.NewAPIKeyRepo(testDb)
+    apiKey := createRandomAPIKey(t, createRandomMerchant(t))
+
+    apiKey1, err := apiKeyRepo.GetAPIKeyByPrefix(context.Background(), apiKey.Prefix)
+    require.NoError(t, err)
+    require.Equal(t, apiKey.ID, apiKey1.ID)
+
+    _, err = apiKeyRepo.GetAPIKeyByPrefix(context.Background(), "sk_00000000")
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestListAPIKeys(t *testing.T) {
+    apiKeyRepo := store.NewAPIKeyRepo(testDb)
+    merchant := createRandomMerchant(t)
+
+    for i := 0; i < 3; i++ {
+        createRandomAPIKey(t, merchant)
+    }
+    createRandomAPIKey(t, createRandomMerchant(t))
+
+    apiKeys, err := apiKeyRepo.ListAPIKeys(context.Background(), merchant.ID)
+    require.NoError(t, err)
+    require.Len(t, apiKeys, 3)
+
+    for _, apiKey := range apiKeys {
+        require.Equal(t, merchant.ID, apiKey.MerchantID)
+    }
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+    apiKeyRepo := store.NewAPIKeyRepo(testDb)
+    apiKey := createRandomAPIKey(t, createRandomMerchant(t))
+
+    revokedKey, err := apiKeyRepo.UpdateAPIKeyStatus(context.Background(), store.UpdateAPIKeyStatusParams{
+        ID:     apiKey.ID,
+        Status: domain.APIKeyStatusREVOKED,
+    })
+    require.NoError(t, err)
+    require.Equal(t, domain.APIKeyStatusREVOKED, revokedKey.Status)
+}
@@ -21,16 +21,20 @@ type BankAccountRepo interface {
 }
 
 type bankAccountRepository struct {
-    db         *sql.DB
-    walletRepo WalletRepo
-    userRepo   UserRepo
+    db                   *sql.DB
+    walletRepo           WalletRepo
+    userRepo             UserRepo
+    bankVerificationRepo BankVerificationRepo
+    outboxRepo           OutboxRepo
 }
 
-func NewBankAccountRepo(db *sql.DB, walletRepo WalletRepo, userRepo UserRepo) BankAccountRepo {
+func NewBankAccountRepo(db *sql.DB, walletRepo WalletRepo, userRepo UserRepo, bankVerificationRepo BankVerificationRepo, outboxRepo OutboxRepo) BankAccountRepo {
     return &bankAccountRepository{
-        db:         db,
-        walletRepo: walletRepo,
-        userRepo:   userRepo,
+        db:                   db,
+        walletRepo:           walletRepo,
+        userRepo:             userRepo,
+        bankVerificationRepo: bankVerificationRepo,
+        outboxRepo:           outboxRepo,
     }
 }
 
@@ -54,7 +58,7 @@ type CreateBankAccountParams struct {
 }
 
 func (q *bankAccountRepository) CreateBankAccount(ctx context.Context, arg CreateBankAccountParams) (domain.BankAccount, error) {
-    row := q.db.QueryRowContext(ctx, createBankAccount,
+    row := conn(ctx, q.db).QueryRowContext(ctx, createBankAccount,
         arg.AccountNo,
         arg.Ifsc,
         arg.BankName,
@@ -84,7 +88,7 @@ where id = $1 LIMIT 1
 `
 
 func (q *bankAccountRepository) GetBankAccount(ctx context.Context, id int64) (domain.BankAccount, error) {
-    row := q.db.QueryRowContext(ctx, getBankAccount, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getBankAccount, id)
     var i domain.BankAccount
     err := row.Scan(
         &i.ID,
@@ -115,7 +119,7 @@ type ListBankAccountsParams struct {
 }
 
 func (q *bankAccountRepository) ListBankAccounts(ctx context.Context, arg ListBankAccountsParams) ([]domain.BankAccount, error) {
-    rows, err := q.db.QueryContext(ctx, listBankAccounts, arg.UserID, arg.Limit, arg.Offset)
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listBankAccounts, arg.UserID, arg.Limit, arg.Offset)
     if err != nil {
         return nil, err
     }
@@ -160,7 +164,7 @@ type UpdateBankAccountStatusParams struct {
 }
 
 func (q *bankAccountRepository) UpdateBankAccountStatus(ctx context.Context, arg UpdateBankAccountStatusParams) (domain.BankAccount, error) {
-    row := q.db.QueryRowContext(ctx, updateBankAccountStatus, arg.Status, arg.ID)
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateBankAccountStatus, arg.Status, arg.ID)
     var i domain.BankAccount
     err := row.Scan(
         &i.ID,
@@ -185,15 +189,17 @@ type CreateBankAccountWithWalletParams struct {
 }
 
 type BankAccountWithWalletResult struct {
-    BankAccount domain.BankAccount `json:"bank_account"`
-    Wallet      domain.Wallet      `json:"wallet"`
+    BankAccount  domain.BankAccount      `json:"bank_account"`
+    Wallet       domain.Wallet           `json:"wallet"`
+    Verification domain.BankVerification `json:"verification"`
 }
 
+// CreateBankAccountWithWallet creates the bank account with its inactive wallet and its PENDING verification, the
+// penny drop itself is initiated asynchronously by the bank verification worker.
 func (q *bankAccountRepository) CreateBankAccountWithWallet(ctx context.Context, arg CreateBankAccountWithWalletParams) (BankAccountWithWalletResult, error) {
     var result BankAccountWithWalletResult
 
-    orgWalletAddress := fmt.Sprintf("grab%s@my.wallet", strings.ToLower(arg.Currency))
-    orgWallet, err := q.walletRepo.GetWalletByAddress(ctx, orgWalletAddress)
+    orgWallet, err := q.walletRepo.GetWalletByAddress(ctx, OrganizationWalletAddress(arg.Currency))
     if err != nil {
         if err == sql.ErrNoRows {
             return result, errors.ErrOrganizationWalletNotFound
@@ -201,7 +207,7 @@ func (q *bankAccountRepository) CreateBankAccountWithWallet(ctx context.Context,
         return result, err
     }
 
-    err = ExecTx(q.db, func(tx Tx) error {
+    err = ExecTx(ctx, q.db, func(ctx context.Context) error {
         var err error
 
         user, err := q.userRepo.GetUser(ctx, arg.UserID)
@@ -250,14 +256,25 @@ func (q *bankAccountRepository) CreateBankAccountWithWallet(ctx context.Context,
             return err
         }
 
-        return nil
+        result.Verification, err = q.bankVerificationRepo.CreateBankVerification(ctx, CreateBankVerificationParams{
+            BankAccountID: result.BankAccount.ID,
+            Status:        domain.BankVerificationStatusPENDING,
+        })
+        if err != nil {
+            return err
+        }
+
+        return q.createOutboxEvent(ctx, domain.AggregateTypeWALLET, result.Wallet.ID, domain.EventTypeWalletCreated, result)
     })
 
     return result, err
 }
 
+// BankAccountVerificationParams completes the verification of a bank account. Verification is the result of its
+// penny drop, without it the verification is an override of an admin, which closes the verifications still open.
 type BankAccountVerificationParams struct {
-    BankAccountID int64 `json:"bank_account_id"`
+    BankAccountID int64                         `json:"bank_account_id"`
+    Verification  *UpdateBankVerificationParams `json:"verification"`
 }
 
 type BankAccountVerificationResult struct {
@@ -265,51 +282,140 @@ type BankAccountVerificationResult struct {
     Wallet      domain.Wallet      `json:"wallet"`
 }
 
+// BankAccountVerificationSuccess verifies a bank account IN_VERIFICATION and activates its wallet, it fails with
+// errors.ErrBankAccountNotInVerification for the other bank accounts and errors.ErrBankVerificationNotPending once
+// the verification is completed.
 func (q *bankAccountRepository) BankAccountVerificationSuccess(ctx context.Context, arg BankAccountVerificationParams) (BankAccountVerificationResult, error) {
     var result BankAccountVerificationResult
 
-    err := ExecTx(q.db, func(tx Tx) error {
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
         var err error
 
-        result.BankAccount, err = q.UpdateBankAccountStatus(ctx, UpdateBankAccountStatusParams{
-            ID:     arg.BankAccountID,
-            Status: domain.BankAccountStatusVERIFIED,
-        })
+        err = q.completeVerification(ctx, arg, domain.BankVerificationStatusSUCCESS)
         if err != nil {
             return err
         }
 
-        wallet, err := q.walletRepo.GetWalletByBankAccountIDForUpdate(ctx, result.BankAccount.ID)
+        result.BankAccount, err = q.completeBankAccountVerification(ctx, arg.BankAccountID, domain.BankAccountStatusVERIFIED)
         if err != nil {
             return err
         }
 
-        result.Wallet, err = q.walletRepo.UpdateWalletStatus(ctx, UpdateWalletStatusParams{
-            ID:     wallet.ID,
-            Status: domain.WalletStatusACTIVE,
-        })
+        result.Wallet, err = q.activateBankAccountWallet(ctx, result.BankAccount.ID)
         if err != nil {
             return err
         }
 
-        return nil
+        return q.createOutboxEvent(ctx, domain.AggregateTypeWALLET, result.Wallet.ID, domain.EventTypeWalletActivated, result)
     })
 
     return result, err
 }
 
+// BankAccountVerificationFailed fails a bank account IN_VERIFICATION, with the errors of BankAccountVerificationSuccess.
 func (q *bankAccountRepository) BankAccountVerificationFailed(ctx context.Context, arg BankAccountVerificationParams) (BankAccountVerificationResult, error) {
     var result BankAccountVerificationResult
 
-    var err error
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        var err error
+
+        err = q.completeVerification(ctx, arg, domain.BankVerificationStatusFAILED)
+        if err != nil {
+            return err
+        }
 
-    result.BankAccount, err = q.UpdateBankAccountStatus(ctx, UpdateBankAccountStatusParams{
-        ID:     arg.BankAccountID,
-        Status: domain.BankAccountStatusVERIFICATIONFAILED,
+        result.BankAccount, err = q.completeBankAccountVerification(ctx, arg.BankAccountID, domain.BankAccountStatusVERIFICATIONFAILED)
+        if err != nil {
+            return err
+        }
+
+        return q.createOutboxEvent(ctx, domain.AggregateTypeBANKACCOUNT, result.BankAccount.ID, domain.EventTypeBankAccountVerificationFailed, result)
     })
+
+    return result, err
+}
+
+func (q *bankAccountRepository) completeVerification(ctx context.Context, arg BankAccountVerificationParams, status domain.BankVerificationStatus) error {
+    if arg.Verification == nil {
+        return q.bankVerificationRepo.CloseBankVerifications(ctx, CloseBankVerificationsParams{
+            BankAccountID: arg.BankAccountID,
+            Status:        status,
+        })
+    }
+
+    _, err := q.bankVerificationRepo.UpdateBankVerification(ctx, *arg.Verification)
+    if err == sql.ErrNoRows {
+        return errors.ErrBankVerificationNotPending
+    }
+    return err
+}
+
+const completeBankAccountVerification = `-- name: CompleteBankAccountVerification :one
+UPDATE bank_accounts
+set Status = $1
+where id = $2
+  AND status = 'IN_VERIFICATION'
+RETURNING id, account_no, ifsc, bank_name, status, user_id, currency, created_at, updated_at
+`
+
+func (q *bankAccountRepository) completeBankAccountVerification(ctx context.Context, id int64, status domain.BankAccountStatus) (domain.BankAccount, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, completeBankAccountVerification, status, id)
+    var i domain.BankAccount
+    err := row.Scan(
+        &i.ID,
+        &i.AccountNo,
+        &i.Ifsc,
+        &i.BankName,
+        &i.Status,
+        &i.UserID,
+        &i.Currency,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    if err == sql.ErrNoRows {
+        return i, errors.ErrBankAccountNotInVerification
+    }
+    return i, err
+}
+
+const activateBankAccountWallet = `-- name: ActivateBankAccountWallet :one
+UPDATE wallets
+set Status = 'ACTIVE'
+where bank_account_id = $1
+  AND status = 'INACTIVE'
+RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
+`
+
+// activateBankAccountWallet activates the wallet of a bank account on its verification, a wallet already active
+// means the bank account was verified before.
+func (q *bankAccountRepository) activateBankAccountWallet(ctx context.Context, bankAccountID int64) (domain.Wallet, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, activateBankAccountWallet, bankAccountID)
+    var i domain.Wallet
+    err := row.Scan(
+        &i.ID,
+        &i.Address,
+        &i.Status,
+        &i.UserID,
+        &i.BankAccountID,
+        &i.OrganizationWalletID,
+        &i.Balance,
+        &i.HeldBalance,
+        &i.Currency,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    if err == sql.ErrNoRows {
+        return i, errors.ErrBankAccountNotInVerification
+    }
+    return i, err
+}
+
+func (q *bankAccountRepository) createOutboxEvent(ctx context.Context, aggregateType domain.AggregateType, aggregateID int64, eventType domain.EventType, payload interface{}) error {
+    event, err := NewCreateOutboxEventParams(aggregateType, aggregateID, eventType, payload)
     if err != nil {
-        return result, err
+        return err
     }
 
-    return result, err
+    _, err = q.outboxRepo.CreateOutboxEvent(ctx, event)
+    return err
 }
@@ -3,6 +3,7 @@ package store_test
 import (
     "context"
     "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
     "github.com/pranayhere/simple-wallet/store"
     "github.com/pranayhere/simple-wallet/util"
     "github.com/stretchr/testify/require"
@@ -12,9 +13,9 @@ import (
 func InitBankAccountRepo(t *testing.T) store.BankAccountRepo {
     transferRepo := store.NewTransferRepo(testDb)
     entryRepo := store.NewEntryRepo(testDb)
-    walletRepo := store.NewWalletRepo(testDb, transferRepo, entryRepo)
+    walletRepo := store.NewWalletRepo(testDb, transferRepo, entryRepo, store.NewOutboxRepo(testDb))
     userRepo := store.NewUserRepo(testDb)
-    bankAcctRepo := store.NewBankAccountRepo(testDb, walletRepo, userRepo)
+    bankAcctRepo := store.NewBankAccountRepo(testDb, walletRepo, userRepo, store.NewBankVerificationRepo(testDb), store.NewOutboxRepo(testDb))
 
     require.NotEmpty(t, transferRepo)
     require.NotEmpty(t, entryRepo)
@@ -165,6 +166,10 @@ func TestCreateBankAccountWithWallet(t *testing.T) {
     require.Equal(t, bankAcct.ID, wallet.BankAccountID)
     require.Equal(t, wallet.Balance, int64(0))
     require.Equal(t, domain.WalletStatusINACTIVE, wallet.Status)
+
+    require.NotZero(t, res.Verification.ID)
+    require.Equal(t, bankAcct.ID, res.Verification.BankAccountID)
+    require.Equal(t, domain.BankVerificationStatusPENDING, res.Verification.Status)
 }
 
 func TestBankAccountVerificationSuccess(t *testing.T) {
@@ -204,6 +209,56 @@ func TestBankAccountVerificationSuccess(t *testing.T) {
     require.NotEmpty(t, verifiedWallet)
     require.Equal(t, domain.BankAccountStatusVERIFIED, verifiedBankAccount.Status)
     require.Equal(t, domain.WalletStatusACTIVE, verifiedWallet.Status)
+
+    verification, err := store.NewBankVerificationRepo(testDb).GetBankVerification(context.Background(), res.Verification.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.BankVerificationStatusSUCCESS, verification.Status)
+
+    _, err = bankAcctRepo.BankAccountVerificationFailed(context.Background(), store.BankAccountVerificationParams{
+        BankAccountID: res.BankAccount.ID,
+    })
+    require.EqualError(t, err, errors.ErrBankAccountNotInVerification.Error())
+}
+
+func TestBankAccountVerificationSuccessOfVerification(t *testing.T) {
+    bankAcctRepo := InitBankAccountRepo(t)
+
+    user := createRandomUser(t)
+    currency := createRandomCurrency(t, "INR")
+
+    res, err := bankAcctRepo.CreateBankAccountWithWallet(context.Background(), store.CreateBankAccountWithWalletParams{
+        AccountNo: util.RandomString(10),
+        Ifsc:      util.RandomString(7),
+        BankName:  util.RandomString(5),
+        UserID:    user.ID,
+        Currency:  currency.Code,
+    })
+    require.NoError(t, err)
+
+    verificationArg := store.UpdateBankVerificationParams{
+        ID:              res.Verification.ID,
+        ReferenceID:     util.RandomString(12),
+        Status:          domain.BankVerificationStatusSUCCESS,
+        BeneficiaryName: user.FullName,
+        NameMatchScore:  100,
+    }
+    arg := store.BankAccountVerificationParams{
+        BankAccountID: res.BankAccount.ID,
+        Verification:  &verificationArg,
+    }
+
+    verificationRes, err := bankAcctRepo.BankAccountVerificationSuccess(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, domain.BankAccountStatusVERIFIED, verificationRes.BankAccount.Status)
+    require.Equal(t, domain.WalletStatusACTIVE, verificationRes.Wallet.Status)
+
+    verification, err := store.NewBankVerificationRepo(testDb).GetBankVerification(context.Background(), res.Verification.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.BankVerificationStatusSUCCESS, verification.Status)
+    require.Equal(t, verificationArg.ReferenceID, verification.ReferenceID)
+
+    _, err = bankAcctRepo.BankAccountVerificationSuccess(context.Background(), arg)
+    require.EqualError(t, err, errors.ErrBankVerificationNotPending.Error())
 }
 
 func TestAccountVerificationFailed(t *testing.T) {
@@ -241,4 +296,8 @@ func TestAccountVerificationFailed(t *testing.T) {
 
     require.NotEmpty(t, verifiedBankAccount)
     require.Equal(t, domain.BankAccountStatusVERIFICATIONFAILED, verifiedBankAccount.Status)
+
+    verification, err := store.NewBankVerificationRepo(testDb).GetBankVerification(context.Background(), res.Verification.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.BankVerificationStatusFAILED, verification.Status)
 }
@@ -0,0 +1,93 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type BankBranchRepo interface {
+    UpsertBankBranch(ctx context.Context, arg UpsertBankBranchParams) (domain.BankBranch, error)
+    GetBankBranch(ctx context.Context, ifsc string) (domain.BankBranch, error)
+}
+
+type bankBranchRepository struct {
+    db *sql.DB
+}
+
+func NewBankBranchRepo(client *sql.DB) BankBranchRepo {
+    return &bankBranchRepository{
+        db: client,
+    }
+}
+
+const upsertBankBranch = `-- name: UpsertBankBranch :one
+INSERT INTO bank_branches (ifsc,
+                           bank_name,
+                           branch,
+                           address,
+                           city,
+                           state)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (ifsc) DO UPDATE SET bank_name  = $2,
+                                 branch     = $3,
+                                 address    = $4,
+                                 city       = $5,
+                                 state      = $6,
+                                 updated_at = now()
+RETURNING ifsc, bank_name, branch, address, city, state, created_at, updated_at
+`
+
+type UpsertBankBranchParams struct {
+    Ifsc     string `json:"ifsc"`
+    BankName string `json:"bank_name"`
+    Branch   string `json:"branch"`
+    Address  string `json:"address"`
+    City     string `json:"city"`
+    State    string `json:"state"`
+}
+
+func (q *bankBranchRepository) UpsertBankBranch(ctx context.Context, arg UpsertBankBranchParams) (domain.BankBranch, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, upsertBankBranch,
+        arg.Ifsc,
+        arg.BankName,
+        arg.Branch,
+        arg.Address,
+        arg.City,
+        arg.State,
+    )
+    var i domain.BankBranch
+    err := row.Scan(
+        &i.Ifsc,
+        &i.BankName,
+        &i.Branch,
+        &i.Address,
+        &i.City,
+        &i.State,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getBankBranch = `-- name: GetBankBranch :one
+SELECT ifsc, bank_name, branch, address, city, state, created_at, updated_at
+FROM bank_branches
+WHERE ifsc = $1 LIMIT 1
+`
+
+func (q *bankBranchRepository) GetBankBranch(ctx context.Context, ifsc string) (domain.BankBranch, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getBankBranch, ifsc)
+    var i domain.BankBranch
+    err := row.Scan(
+        &i.Ifsc,
+        &i.BankName,
+        &i.Branch,
+        &i.Address,
+        &i.City,
+        &i.State,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
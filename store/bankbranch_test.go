@@ -0,0 +1,70 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func createRandomBankBranch(t *testing.T) domain.BankBranch {
+    bankBranchRepo := store.NewBankBranchRepo(testDb)
+
+    arg := store.UpsertBankBranchParams{
+        Ifsc:     util.RandomIfsc(),
+        BankName: util.RandomString(5),
+        Branch:   util.RandomString(8),
+        Address:  util.RandomString(20),
+        City:     util.RandomString(6),
+        State:    util.RandomString(6),
+    }
+
+    branch, err := bankBranchRepo.UpsertBankBranch(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, branch)
+
+    require.Equal(t, arg.Ifsc, branch.Ifsc)
+    require.Equal(t, arg.BankName, branch.BankName)
+    require.Equal(t, arg.Branch, branch.Branch)
+    require.Equal(t, arg.Address, branch.Address)
+    require.Equal(t, arg.City, branch.City)
+    require.Equal(t, arg.State, branch.State)
+
+    require.NotZero(t, branch.CreatedAt)
+    require.NotZero(t, branch.UpdatedAt)
+
+    return branch
+}
+
+func TestUpsertBankBranch(t *testing.T) {
+    bankBranchRepo := store.NewBankBranchRepo(testDb)
+    branch1 := createRandomBankBranch(t)
+
+    arg := store.UpsertBankBranchParams{
+        Ifsc:     branch1.Ifsc,
+        BankName: util.RandomString(5),
+        Branch:   util.RandomString(8),
+    }
+
+    branch2, err := bankBranchRepo.UpsertBankBranch(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, branch1.Ifsc, branch2.Ifsc)
+    require.Equal(t, arg.BankName, branch2.BankName)
+    require.Equal(t, arg.Branch, branch2.Branch)
+    require.Equal(t, branch1.CreatedAt, branch2.CreatedAt)
+}
+
+func TestGetBankBranch(t *testing.T) {
+    bankBranchRepo := store.NewBankBranchRepo(testDb)
+
+    branch1 := createRandomBankBranch(t)
+    branch2, err := bankBranchRepo.GetBankBranch(context.Background(), branch1.Ifsc)
+    require.NoError(t, err)
+    require.NotEmpty(t, branch2)
+
+    require.Equal(t, branch1.Ifsc, branch2.Ifsc)
+    require.Equal(t, branch1.BankName, branch2.BankName)
+    require.Equal(t, branch1.Branch, branch2.Branch)
+}
@@ -0,0 +1,182 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type BankVerificationRepo interface {
+    CreateBankVerification(ctx context.Context, arg CreateBankVerificationParams) (domain.BankVerification, error)
+    GetBankVerification(ctx context.Context, id int64) (domain.BankVerification, error)
+    ListBankVerificationsByStatus(ctx context.Context, arg ListBankVerificationsByStatusParams) ([]domain.BankVerification, error)
+    UpdateBankVerification(ctx context.Context, arg UpdateBankVerificationParams) (domain.BankVerification, error)
+    CloseBankVerifications(ctx context.Context, arg CloseBankVerificationsParams) error
+}
+
+type bankVerificationRepository struct {
+    db *sql.DB
+}
+
+func NewBankVerificationRepo(client *sql.DB) BankVerificationRepo {
+    return &bankVerificationRepository{
+        db: client,
+    }
+}
+
+const createBankVerification = `-- name: CreateBankVerification :one
+INSERT INTO bank_verifications (bank_account_id,
+                                status)
+VALUES ($1, $2) RETURNING id, bank_account_id, reference_id, status, beneficiary_name, name_match_score, created_at, updated_at
+`
+
+type CreateBankVerificationParams struct {
+    BankAccountID int64                         `json:"bank_account_id"`
+    Status        domain.BankVerificationStatus `json:"status"`
+}
+
+func (q *bankVerificationRepository) CreateBankVerification(ctx context.Context, arg CreateBankVerificationParams) (domain.BankVerification, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createBankVerification, arg.BankAccountID, arg.Status)
+    var i domain.BankVerification
+    err := row.Scan(
+        &i.ID,
+        &i.BankAccountID,
+        &i.ReferenceID,
+        &i.Status,
+        &i.BeneficiaryName,
+        &i.NameMatchScore,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getBankVerification = `-- name: GetBankVerification :one
+SELECT id, bank_account_id, reference_id, status, beneficiary_name, name_match_score, created_at, updated_at
+FROM bank_verifications
+WHERE id = $1 LIMIT 1
+`
+
+func (q *bankVerificationRepository) GetBankVerification(ctx context.Context, id int64) (domain.BankVerification, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getBankVerification, id)
+    var i domain.BankVerification
+    err := row.Scan(
+        &i.ID,
+        &i.BankAccountID,
+        &i.ReferenceID,
+        &i.Status,
+        &i.BeneficiaryName,
+        &i.NameMatchScore,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const listBankVerificationsByStatus = `-- name: ListBankVerificationsByStatus :many
+SELECT id, bank_account_id, reference_id, status, beneficiary_name, name_match_score, created_at, updated_at
+FROM bank_verifications
+WHERE status = $1
+ORDER BY id LIMIT $2
+`
+
+type ListBankVerificationsByStatusParams struct {
+    Status domain.BankVerificationStatus `json:"status"`
+    Limit  int32                         `json:"limit"`
+}
+
+func (q *bankVerificationRepository) ListBankVerificationsByStatus(ctx context.Context, arg ListBankVerificationsByStatusParams) ([]domain.BankVerification, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listBankVerificationsByStatus, arg.Status, arg.Limit)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.BankVerification{}
+    for rows.Next() {
+        var i domain.BankVerification
+        if err := rows.Scan(
+            &i.ID,
+            &i.BankAccountID,
+            &i.ReferenceID,
+            &i.Status,
+            &i.BeneficiaryName,
+            &i.NameMatchScore,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const updateBankVerification = `-- name: UpdateBankVerification :one
+UPDATE bank_verifications
+set reference_id     = $1,
+    status           = $2,
+    beneficiary_name = $3,
+    name_match_score = $4,
+    updated_at       = now()
+where id = $5
+  AND status IN ('PENDING', 'INITIATED')
+RETURNING id, bank_account_id, reference_id, status, beneficiary_name, name_match_score, created_at, updated_at
+`
+
+type UpdateBankVerificationParams struct {
+    ReferenceID     string                        `json:"reference_id"`
+    Status          domain.BankVerificationStatus `json:"status"`
+    BeneficiaryName string                        `json:"beneficiary_name"`
+    NameMatchScore  int64                         `json:"name_match_score"`
+    ID              int64                         `json:"id"`
+}
+
+// UpdateBankVerification only updates a verification still PENDING or INITIATED, it fails with sql.ErrNoRows once
+// the verification is completed.
+func (q *bankVerificationRepository) UpdateBankVerification(ctx context.Context, arg UpdateBankVerificationParams) (domain.BankVerification, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateBankVerification,
+        arg.ReferenceID,
+        arg.Status,
+        arg.BeneficiaryName,
+        arg.NameMatchScore,
+        arg.ID,
+    )
+    var i domain.BankVerification
+    err := row.Scan(
+        &i.ID,
+        &i.BankAccountID,
+        &i.ReferenceID,
+        &i.Status,
+        &i.BeneficiaryName,
+        &i.NameMatchScore,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const closeBankVerifications = `-- name: CloseBankVerifications :exec
+UPDATE bank_verifications
+set status     = $2,
+    updated_at = now()
+where bank_account_id = $1
+  AND status IN ('PENDING', 'INITIATED')
+`
+
+type CloseBankVerificationsParams struct {
+    BankAccountID int64                         `json:"bank_account_id"`
+    Status        domain.BankVerificationStatus `json:"status"`
+}
+
+// CloseBankVerifications completes the verifications of the bank account still PENDING or INITIATED, when the
+// bank account is verified or failed by other means.
+func (q *bankVerificationRepository) CloseBankVerifications(ctx context.Context, arg CloseBankVerificationsParams) error {
+    _, err := conn(ctx, q.db).ExecContext(ctx, closeBankVerifications, arg.BankAccountID, arg.Status)
+    return err
+}
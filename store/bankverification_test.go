@@ -0,0 +1,99 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func createRandomBankVerification(t *testing.T) domain.BankVerification {
+    bankVerificationRepo := store.NewBankVerificationRepo(testDb)
+    bankAcct := createRandomBankAccount(t)
+
+    arg := store.CreateBankVerificationParams{
+        BankAccountID: bankAcct.ID,
+        Status:        domain.BankVerificationStatusPENDING,
+    }
+
+    verification, err := bankVerificationRepo.CreateBankVerification(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, verification)
+
+    require.Equal(t, arg.BankAccountID, verification.BankAccountID)
+    require.Equal(t, arg.Status, verification.Status)
+    require.Empty(t, verification.ReferenceID)
+    require.Zero(t, verification.NameMatchScore)
+
+    require.NotZero(t, verification.ID)
+    require.NotZero(t, verification.CreatedAt)
+    require.NotZero(t, verification.UpdatedAt)
+
+    return verification
+}
+
+func TestCreateBankVerification(t *testing.T) {
+    createRandomBankVerification(t)
+}
+
+func TestGetBankVerification(t *testing.T) {
+    bankVerificationRepo := store.NewBankVerificationRepo(testDb)
+
+    verification1 := createRandomBankVerification(t)
+    verification2, err := bankVerificationRepo.GetBankVerification(context.Background(), verification1.ID)
+    require.NoError(t, err)
+    require.NotEmpty(t, verification2)
+
+    require.Equal(t, verification1.ID, verification2.ID)
+    require.Equal(t, verification1.BankAccountID, verification2.BankAccountID)
+    require.Equal(t, verification1.Status, verification2.Status)
+}
+
+func TestListBankVerificationsByStatus(t *testing.T) {
+    bankVerificationRepo := store.NewBankVerificationRepo(testDb)
+
+    for i := 0; i < 3; i++ {
+        createRandomBankVerification(t)
+    }
+
+    arg := store.ListBankVerificationsByStatusParams{
+        Status: domain.BankVerificationStatusPENDING,
+        Limit:  3,
+    }
+
+    verifications, err := bankVerificationRepo.ListBankVerificationsByStatus(context.Background(), arg)
+    require.NoError(t, err)
+    require.Len(t, verifications, 3)
+
+    for _, verification := range verifications {
+        require.Equal(t, domain.BankVerificationStatusPENDING, verification.Status)
+    }
+}
+
+func TestUpdateBankVerification(t *testing.T) {
+    bankVerificationRepo := store.NewBankVerificationRepo(testDb)
+    verification1 := createRandomBankVerification(t)
+
+    arg := store.UpdateBankVerificationParams{
+        ID:              verification1.ID,
+        ReferenceID:     util.RandomString(12),
+        Status:          domain.BankVerificationStatusSUCCESS,
+        BeneficiaryName: util.RandomUser(),
+        NameMatchScore:  util.RandomInt(0, 100),
+    }
+
+    verification2, err := bankVerificationRepo.UpdateBankVerification(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, verification2)
+
+    require.Equal(t, arg.ReferenceID, verification2.ReferenceID)
+    require.Equal(t, arg.Status, verification2.Status)
+    require.Equal(t, arg.BeneficiaryName, verification2.BeneficiaryName)
+    require.Equal(t, arg.NameMatchScore, verification2.NameMatchScore)
+
+    _, err = bankVerificationRepo.UpdateBankVerification(context.Background(), arg)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
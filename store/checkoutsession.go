@@ -0,0 +1,251 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "time"
+)
+
+type CheckoutSessionRepo interface {
+    CreateCheckoutSession(ctx context.Context, arg CreateCheckoutSessionParams) (domain.CheckoutSession, error)
+    GetCheckoutSession(ctx context.Context, id int64) (domain.CheckoutSession, error)
+    GetCheckoutSessionForUpdate(ctx context.Context, id int64) (domain.CheckoutSession, error)
+    UpdateCheckoutSession(ctx context.Context, arg UpdateCheckoutSessionParams) (domain.CheckoutSession, error)
+    CompleteCheckoutSession(ctx context.Context, arg CompleteCheckoutSessionParams) (CompleteCheckoutSessionResult, error)
+}
+
+type checkoutSessionRepository struct {
+    db              *sql.DB
+    paymentLinkRepo PaymentLinkRepo
+    walletRepo      WalletRepo
+    outboxRepo      OutboxRepo
+}
+
+func NewCheckoutSessionRepo(client *sql.DB, paymentLinkRepo PaymentLinkRepo, walletRepo WalletRepo, outboxRepo OutboxRepo) CheckoutSessionRepo {
+    return &checkoutSessionRepository{
+        db:              client,
+        paymentLinkRepo: paymentLinkRepo,
+        walletRepo:      walletRepo,
+        outboxRepo:      outboxRepo,
+    }
+}
+
+const createCheckoutSession = `-- name: CreateCheckoutSession :one
+INSERT INTO checkout_sessions (payment_link_id,
+                               user_id,
+                               to_wallet_id,
+                               amount,
+                               status,
+                               expires_at)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at
+`
+
+type CreateCheckoutSessionParams struct {
+    PaymentLinkID int64                        `json:"payment_link_id"`
+    UserID        int64                        `json:"user_id"`
+    ToWalletID    int64                        `json:"to_wallet_id"`
+    Amount        int64                        `json:"amount"`
+    Status        domain.CheckoutSessionStatus `json:"status"`
+    ExpiresAt     time.Time                    `json:"expires_at"`
+}
+
+func (q *checkoutSessionRepository) CreateCheckoutSession(ctx context.Context, arg CreateCheckoutSessionParams) (domain.CheckoutSession, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createCheckoutSession,
+        arg.PaymentLinkID,
+        arg.UserID,
+        arg.ToWalletID,
+        arg.Amount,
+        arg.Status,
+        arg.ExpiresAt,
+    )
+    return scanCheckoutSession(row)
+}
+
+const getCheckoutSession = `-- name: GetCheckoutSession :one
+SELECT id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at
+FROM checkout_sessions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *checkoutSessionRepository) GetCheckoutSession(ctx context.Context, id int64) (domain.CheckoutSession, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getCheckoutSession, id)
+    return scanCheckoutSession(row)
+}
+
+const getCheckoutSessionForUpdate = `-- name: GetCheckoutSessionForUpdate :one
+SELECT id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at
+FROM checkout_sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *checkoutSessionRepository) GetCheckoutSessionForUpdate(ctx context.Context, id int64) (domain.CheckoutSession, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getCheckoutSessionForUpdate, id)
+    return scanCheckoutSession(row)
+}
+
+const updateCheckoutSession = `-- name: UpdateCheckoutSession :one
+UPDATE checkout_sessions
+SET status         = $2,
+    from_wallet_id = $3,
+    transfer_id    = $4,
+    updated_at     = now()
+WHERE id = $1 RETURNING id, payment_link_id, user_id, to_wallet_id, amount, status, from_wallet_id, transfer_id, expires_at, created_at, updated_at
+`
+
+type UpdateCheckoutSessionParams struct {
+    ID           int64                        `json:"id"`
+    Status       domain.CheckoutSessionStatus `json:"status"`
+    FromWalletID sql.NullInt64                `json:"from_wallet_id"`
+    TransferID   sql.NullInt64                `json:"transfer_id"`
+}
+
+func (q *checkoutSessionRepository) UpdateCheckoutSession(ctx context.Context, arg UpdateCheckoutSessionParams) (domain.CheckoutSession, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateCheckoutSession,
+        arg.ID,
+        arg.Status,
+        arg.FromWalletID,
+        arg.TransferID,
+    )
+    return scanCheckoutSession(row)
+}
+
+type CompleteCheckoutSessionParams struct {
+    ID           int64 `json:"id"`
+    FromWalletID int64 `json:"from_wallet_id"`
+}
+
+type CompleteCheckoutSessionResult struct {
+    Session     domain.CheckoutSession `json:"session"`
+    PaymentLink domain.PaymentLink     `json:"payment_link"`
+    Transfer    WalletTransferResult   `json:"transfer"`
+}
+
+// CompleteCheckoutSession pays the session from the payer's wallet to the merchant's wallet. The session
+// and its link are locked so that a single use link is paid only once.
+func (q *checkoutSessionRepository) CompleteCheckoutSession(ctx context.Context, arg CompleteCheckoutSessionParams) (CompleteCheckoutSessionResult, error) {
+    var res CompleteCheckoutSessionResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        now := time.Now().UTC()
+
+        session, err := q.GetCheckoutSessionForUpdate(ctx, arg.ID)
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return errors.ErrCheckoutSessionNotFound
+            }
+            return err
+        }
+
+        if session.Status != domain.CheckoutSessionStatusOPEN {
+            return errors.ErrCheckoutSessionNotOpen
+        }
+
+        if now.After(session.ExpiresAt) {
+            return errors.ErrCheckoutSessionExpired
+        }
+
+        link, err := q.paymentLinkRepo.GetPaymentLinkForUpdate(ctx, session.PaymentLinkID)
+        if err != nil {
+            return err
+        }
+
+        if link.Status != domain.PaymentLinkStatusACTIVE {
+            return errors.ErrPaymentLinkInactive
+        }
+
+        if link.IsExpired(now) {
+            return errors.ErrPaymentLinkExpired
+        }
+
+        payer, err := q.getWallet(ctx, arg.FromWalletID)
+        if err != nil {
+            return err
+        }
+
+        toWallet, err := q.getWallet(ctx, session.ToWalletID)
+        if err != nil {
+            return err
+        }
+
+        if payer.Currency != link.Currency || toWallet.Currency != link.Currency {
+            return errors.ErrCurrencyMismatch
+        }
+
+        payer, toWallet, err = lockWallets(ctx, q.walletRepo, payer.Address, toWallet.Address)
+        if err != nil {
+            return err
+        }
+
+        if payer.Status != domain.WalletStatusACTIVE || toWallet.Status != domain.WalletStatusACTIVE {
+            return errors.ErrWalletInactive
+        }
+
+        if !payer.IsBalanceSufficient(session.Amount) {
+            return errors.ErrInsufficientBalance
+        }
+
+        res.Transfer, err = q.walletRepo.SendMoney(ctx, SendMoneyParams{
+            FromWalletAddress: payer.Address,
+            ToWalletAddress:   toWallet.Address,
+            Amount:            session.Amount,
+            Kind:              domain.TransferKindCHECKOUT,
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Session, err = q.UpdateCheckoutSession(ctx, UpdateCheckoutSessionParams{
+            ID:           session.ID,
+            Status:       domain.CheckoutSessionStatusCOMPLETED,
+            FromWalletID: sql.NullInt64{Int64: payer.ID, Valid: true},
+            TransferID:   sql.NullInt64{Int64: res.Transfer.Transfer.ID, Valid: true},
+        })
+        if err != nil {
+            return err
+        }
+
+        res.PaymentLink, err = q.paymentLinkRepo.AddPaymentLinkUse(ctx, link.ID)
+        if err != nil {
+            return err
+        }
+
+        event, err := NewCreateOutboxEventParams(domain.AggregateTypeCHECKOUTSESSION, res.Session.ID, domain.EventTypeCheckoutCompleted, res)
+        if err != nil {
+            return err
+        }
+
+        _, err = q.outboxRepo.CreateOutboxEvent(ctx, event)
+        return err
+    })
+
+    return res, err
+}
+
+func (q *checkoutSessionRepository) getWallet(ctx context.Context, id int64) (domain.Wallet, error) {
+    wallet, err := q.walletRepo.GetWallet(ctx, id)
+    if err == sql.ErrNoRows {
+        return wallet, errors.ErrWalletNotFound
+    }
+    return wallet, err
+}
+
+func scanCheckoutSession(row *sql.Row) (domain.CheckoutSession, error) {
+    var i domain.CheckoutSession
+    err := row.Scan(
+        &i.ID,
+        &i.PaymentLinkID,
+        &i.UserID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.Status,
+        &i.FromWalletID,
+        &i.TransferID,
+        &i.ExpiresAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
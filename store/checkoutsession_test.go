@@ -0,0 +1,148 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func InitCheckoutSessionRepo(t *testing.T) store.CheckoutSessionRepo {
+    checkoutSessionRepo := store.NewCheckoutSessionRepo(testDb, store.NewPaymentLinkRepo(testDb), InitWalletRepo(t), store.NewOutboxRepo(testDb))
+    require.NotEmpty(t, checkoutSessionRepo)
+
+    return checkoutSessionRepo
+}
+
+// createActiveMerchant returns a merchant whose settlement wallet is active and can receive money.
+func createActiveMerchant(t *testing.T) (domain.Merchant, domain.Wallet) {
+    merchant := createRandomMerchant(t)
+
+    wallet, err := InitWalletRepo(t).GetWallet(context.Background(), merchant.SettlementWalletID)
+    require.NoError(t, err)
+    verifyBankAccount(t, wallet.BankAccountID)
+
+    return merchant, wallet
+}
+
+func createRandomCheckoutSession(t *testing.T, link domain.PaymentLink, payer, toWallet domain.Wallet, amount int64, ttl time.Duration) domain.CheckoutSession {
+    arg := store.CreateCheckoutSessionParams{
+        PaymentLinkID: link.ID,
+        UserID:        payer.UserID,
+        ToWalletID:    toWallet.ID,
+        Amount:        amount,
+        Status:        domain.CheckoutSessionStatusOPEN,
+        ExpiresAt:     time.Now().UTC().Add(ttl),
+    }
+
+    session, err := InitCheckoutSessionRepo(t).CreateCheckoutSession(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, session.ID)
+    require.Equal(t, arg.PaymentLinkID, session.PaymentLinkID)
+    require.Equal(t, arg.UserID, session.UserID)
+    require.Equal(t, arg.ToWalletID, session.ToWalletID)
+    require.Equal(t, arg.Amount, session.Amount)
+    require.Equal(t, arg.Status, session.Status)
+    require.False(t, session.FromWalletID.Valid)
+    require.False(t, session.TransferID.Valid)
+    require.WithinDuration(t, arg.ExpiresAt, session.ExpiresAt, time.Second)
+
+    return session
+}
+
+func TestCompleteCheckoutSession(t *testing.T) {
+    checkoutSessionRepo := InitCheckoutSessionRepo(t)
+    merchant, settlementWallet := createActiveMerchant(t)
+    payer, _ := createActiveWallets(t, 1000)
+
+    link := createRandomPaymentLink(t, merchant, 300, domain.PaymentLinkModeMULTIUSE, sql.NullTime{})
+    session := createRandomCheckoutSession(t, link, payer, settlementWallet, link.Amount, time.Minute)
+
+    res, err := checkoutSessionRepo.CompleteCheckoutSession(context.Background(), store.CompleteCheckoutSessionParams{
+        ID:           session.ID,
+        FromWalletID: payer.ID,
+    })
+    require.NoError(t, err)
+
+    require.Equal(t, domain.CheckoutSessionStatusCOMPLETED, res.Session.Status)
+    require.Equal(t, payer.ID, res.Session.FromWalletID.Int64)
+    require.Equal(t, res.Transfer.Transfer.ID, res.Session.TransferID.Int64)
+    require.Equal(t, domain.TransferKindCHECKOUT, res.Transfer.Transfer.Kind)
+    require.Equal(t, int64(1), res.PaymentLink.Uses)
+    require.Equal(t, domain.PaymentLinkStatusACTIVE, res.PaymentLink.Status)
+
+    require.Equal(t, payer.Balance-link.Amount, res.Transfer.Wallet.Balance)
+    require.Equal(t, settlementWallet.Balance+link.Amount, res.Transfer.ToWallet.Balance)
+
+    // a session is paid only once
+    _, err = checkoutSessionRepo.CompleteCheckoutSession(context.Background(), store.CompleteCheckoutSessionParams{
+        ID:           session.ID,
+        FromWalletID: payer.ID,
+    })
+    require.EqualError(t, err, errors.ErrCheckoutSessionNotOpen.Error())
+}
+
+func TestCompleteCheckoutSessionSingleUse(t *testing.T) {
+    checkoutSessionRepo := InitCheckoutSessionRepo(t)
+    merchant, settlementWallet := createActiveMerchant(t)
+    payer, _ := createActiveWallets(t, 1000)
+
+    link := createRandomPaymentLink(t, merchant, 300, domain.PaymentLinkModeSINGLEUSE, sql.NullTime{})
+    session1 := createRandomCheckoutSession(t, link, payer, settlementWallet, link.Amount, time.Minute)
+    session2 := createRandomCheckoutSession(t, link, payer, settlementWallet, link.Amount, time.Minute)
+
+    res, err := checkoutSessionRepo.CompleteCheckoutSession(context.Background(), store.CompleteCheckoutSessionParams{
+        ID:           session1.ID,
+        FromWalletID: payer.ID,
+    })
+    require.NoError(t, err)
+    require.Equal(t, domain.PaymentLinkStatusCOMPLETED, res.PaymentLink.Status)
+
+    _, err = checkoutSessionRepo.CompleteCheckoutSession(context.Background(), store.CompleteCheckoutSessionParams{
+        ID:           session2.ID,
+        FromWalletID: payer.ID,
+    })
+    require.EqualError(t, err, errors.ErrPaymentLinkInactive.Error())
+
+    payer, err = InitWalletRepo(t).GetWallet(context.Background(), payer.ID)
+    require.NoError(t, err)
+    require.Equal(t, int64(700), payer.Balance)
+}
+
+func TestCompleteCheckoutSessionExpired(t *testing.T) {
+    checkoutSessionRepo := InitCheckoutSessionRepo(t)
+    merchant, settlementWallet := createActiveMerchant(t)
+    payer, _ := createActiveWallets(t, 1000)
+
+    link := createRandomPaymentLink(t, merchant, 300, domain.PaymentLinkModeMULTIUSE, sql.NullTime{})
+    session := createRandomCheckoutSession(t, link, payer, settlementWallet, link.Amount, -time.Minute)
+
+    _, err := checkoutSessionRepo.CompleteCheckoutSession(context.Background(), store.CompleteCheckoutSessionParams{
+        ID:           session.ID,
+        FromWalletID: payer.ID,
+    })
+    require.EqualError(t, err, errors.ErrCheckoutSessionExpired.Error())
+}
+
+func TestCompleteCheckoutSessionInsufficientBalance(t *testing.T) {
+    checkoutSessionRepo := InitCheckoutSessionRepo(t)
+    merchant, settlementWallet := createActiveMerchant(t)
+    payer, _ := createActiveWallets(t, 100)
+
+    link := createRandomPaymentLink(t, merchant, 300, domain.PaymentLinkModeMULTIUSE, sql.NullTime{})
+    session := createRandomCheckoutSession(t, link, payer, settlementWallet, link.Amount, time.Minute)
+
+    _, err := checkoutSessionRepo.CompleteCheckoutSession(context.Background(), store.CompleteCheckoutSessionParams{
+        ID:           session.ID,
+        FromWalletID: payer.ID,
+    })
+    require.EqualError(t, err, errors.ErrInsufficientBalance.Error())
+
+    session, err = checkoutSessionRepo.GetCheckoutSession(context.Background(), session.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.CheckoutSessionStatusOPEN, session.Status)
+}
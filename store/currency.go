@@ -35,7 +35,7 @@ type CreateCurrencyParams struct {
 }
 
 func (q *currencyRepository) CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (domain.Currency, error) {
-    row := q.db.QueryRowContext(ctx, createCurrency, arg.Code, arg.Fraction)
+    row := conn(ctx, q.db).QueryRowContext(ctx, createCurrency, arg.Code, arg.Fraction)
     var i domain.Currency
     err := row.Scan(&i.Code, &i.Fraction, &i.CreatedAt)
     return i, err
@@ -47,7 +47,7 @@ where code = $1 LIMIT 1
 `
 
 func (q *currencyRepository) GetCurrency(ctx context.Context, code string) (domain.Currency, error) {
-    row := q.db.QueryRowContext(ctx, getCurrency, code)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getCurrency, code)
     var i domain.Currency
     err := row.Scan(&i.Code, &i.Fraction, &i.CreatedAt)
     return i, err
@@ -36,7 +36,7 @@ type CreateEntryParams struct {
 }
 
 func (q *entryRepository) CreateEntry(ctx context.Context, arg CreateEntryParams) (domain.Entry, error) {
-    row := q.db.QueryRowContext(ctx, createEntry,
+    row := conn(ctx, q.db).QueryRowContext(ctx, createEntry,
         arg.WalletID,
         arg.Amount,
         arg.TransferID,
@@ -60,7 +60,7 @@ WHERE transfer_id = $1
 `
 
 func (q *entryRepository) GetEntriesByTransferID(ctx context.Context, transferID int64) ([]domain.Entry, error) {
-    rows, err := q.db.QueryContext(ctx, getEntriesByTransferID, transferID)
+    rows, err := conn(ctx, q.db).QueryContext(ctx, getEntriesByTransferID, transferID)
     if err != nil {
         return nil, err
     }
@@ -95,7 +95,7 @@ WHERE id = $1 LIMIT 1
 `
 
 func (q *entryRepository) GetEntry(ctx context.Context, id int64) (domain.Entry, error) {
-    row := q.db.QueryRowContext(ctx, getEntry, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getEntry, id)
     var i domain.Entry
     err := row.Scan(
         &i.ID,
@@ -122,7 +122,7 @@ type ListEntriesParams struct {
 }
 
 func (q *entryRepository) ListEntries(ctx context.Context, arg ListEntriesParams) ([]domain.Entry, error) {
-    rows, err := q.db.QueryContext(ctx, listEntries, arg.WalletID, arg.Limit, arg.Offset)
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listEntries, arg.WalletID, arg.Limit, arg.Offset)
     if err != nil {
         return nil, err
     }
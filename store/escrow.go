@@ -0,0 +1,488 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "strings"
+    "time"
+)
+
+type EscrowRepo interface {
+    CreateEscrow(ctx context.Context, arg CreateEscrowParams) (domain.Escrow, error)
+    GetEscrow(ctx context.Context, id int64) (domain.Escrow, error)
+    GetEscrowForUpdate(ctx context.Context, id int64) (domain.Escrow, error)
+    UpdateEscrowStatus(ctx context.Context, arg UpdateEscrowStatusParams) (domain.Escrow, error)
+    ListEscrows(ctx context.Context, arg ListEscrowsParams) ([]domain.Escrow, error)
+    ListDueEscrows(ctx context.Context, limit int32) ([]domain.Escrow, error)
+    FailEscrowRelease(ctx context.Context, arg FailEscrowReleaseParams) (domain.Escrow, error)
+    FundEscrow(ctx context.Context, arg FundEscrowParams) (EscrowTransferResult, error)
+    DisputeEscrow(ctx context.Context, arg DisputeEscrowParams) (domain.Escrow, error)
+    SettleEscrow(ctx context.Context, arg SettleEscrowParams) (EscrowTransferResult, error)
+}
+
+type escrowRepository struct {
+    db         *sql.DB
+    walletRepo WalletRepo
+}
+
+func NewEscrowRepo(client *sql.DB, walletRepo WalletRepo) EscrowRepo {
+    return &escrowRepository{
+        db:         client,
+        walletRepo: walletRepo,
+    }
+}
+
+// EscrowWalletAddress is the address of the escrow system wallet of the currency
+func EscrowWalletAddress(currency string) string {
+    return fmt.Sprintf("escrow%s@my.wallet", strings.ToLower(currency))
+}
+
+const createEscrow = `-- name: CreateEscrow :one
+INSERT INTO escrows (payer_wallet_id,
+                     payee_wallet_id,
+                     escrow_wallet_id,
+                     amount,
+                     funding_transfer_id,
+                     release_after)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+`
+
+type CreateEscrowParams struct {
+    PayerWalletID     int64     `json:"payer_wallet_id"`
+    PayeeWalletID     int64     `json:"payee_wallet_id"`
+    EscrowWalletID    int64     `json:"escrow_wallet_id"`
+    Amount            int64     `json:"amount"`
+    FundingTransferID int64     `json:"funding_transfer_id"`
+    ReleaseAfter      time.Time `json:"release_after"`
+}
+
+func (q *escrowRepository) CreateEscrow(ctx context.Context, arg CreateEscrowParams) (domain.Escrow, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createEscrow,
+        arg.PayerWalletID,
+        arg.PayeeWalletID,
+        arg.EscrowWalletID,
+        arg.Amount,
+        arg.FundingTransferID,
+        arg.ReleaseAfter,
+    )
+    var i domain.Escrow
+    err := row.Scan(
+        &i.ID,
+        &i.PayerWalletID,
+        &i.PayeeWalletID,
+        &i.EscrowWalletID,
+        &i.Amount,
+        &i.Status,
+        &i.FundingTransferID,
+        &i.SettlementTransferID,
+        &i.DisputeReason,
+        &i.ReleaseAfter,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+        &i.ReleaseAttempts,
+        &i.NextReleaseAt,
+        &i.ReleaseError,
+    )
+    return i, err
+}
+
+const getEscrow = `-- name: GetEscrow :one
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE id = $1 LIMIT 1
+`
+
+func (q *escrowRepository) GetEscrow(ctx context.Context, id int64) (domain.Escrow, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getEscrow, id)
+    var i domain.Escrow
+    err := row.Scan(
+        &i.ID,
+        &i.PayerWalletID,
+        &i.PayeeWalletID,
+        &i.EscrowWalletID,
+        &i.Amount,
+        &i.Status,
+        &i.FundingTransferID,
+        &i.SettlementTransferID,
+        &i.DisputeReason,
+        &i.ReleaseAfter,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+        &i.ReleaseAttempts,
+        &i.NextReleaseAt,
+        &i.ReleaseError,
+    )
+    return i, err
+}
+
+const getEscrowForUpdate = `-- name: GetEscrowForUpdate :one
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *escrowRepository) GetEscrowForUpdate(ctx context.Context, id int64) (domain.Escrow, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getEscrowForUpdate, id)
+    var i domain.Escrow
+    err := row.Scan(
+        &i.ID,
+        &i.PayerWalletID,
+        &i.PayeeWalletID,
+        &i.EscrowWalletID,
+        &i.Amount,
+        &i.Status,
+        &i.FundingTransferID,
+        &i.SettlementTransferID,
+        &i.DisputeReason,
+        &i.ReleaseAfter,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+        &i.ReleaseAttempts,
+        &i.NextReleaseAt,
+        &i.ReleaseError,
+    )
+    return i, err
+}
+
+const updateEscrowStatus = `-- name: UpdateEscrowStatus :one
+UPDATE escrows
+SET status                 = $2,
+    settlement_transfer_id = $3,
+    dispute_reason         = $4,
+    updated_at             = now()
+WHERE id = $1 RETURNING id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+`
+
+type UpdateEscrowStatusParams struct {
+    ID                   int64               `json:"id"`
+    Status               domain.EscrowStatus `json:"status"`
+    SettlementTransferID sql.NullInt64       `json:"settlement_transfer_id"`
+    DisputeReason        string              `json:"dispute_reason"`
+}
+
+func (q *escrowRepository) UpdateEscrowStatus(ctx context.Context, arg UpdateEscrowStatusParams) (domain.Escrow, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateEscrowStatus,
+        arg.ID,
+        arg.Status,
+        arg.SettlementTransferID,
+        arg.DisputeReason,
+    )
+    var i domain.Escrow
+    err := row.Scan(
+        &i.ID,
+        &i.PayerWalletID,
+        &i.PayeeWalletID,
+        &i.EscrowWalletID,
+        &i.Amount,
+        &i.Status,
+        &i.FundingTransferID,
+        &i.SettlementTransferID,
+        &i.DisputeReason,
+        &i.ReleaseAfter,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+        &i.ReleaseAttempts,
+        &i.NextReleaseAt,
+        &i.ReleaseError,
+    )
+    return i, err
+}
+
+const listEscrows = `-- name: ListEscrows :many
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE status = $1
+ORDER BY id LIMIT $2
+OFFSET $3
+`
+
+type ListEscrowsParams struct {
+    Status domain.EscrowStatus `json:"status"`
+    Limit  int32               `json:"limit"`
+    Offset int32               `json:"offset"`
+}
+
+func (q *escrowRepository) ListEscrows(ctx context.Context, arg ListEscrowsParams) ([]domain.Escrow, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listEscrows, arg.Status, arg.Limit, arg.Offset)
+    return scanEscrows(rows, err)
+}
+
+const listDueEscrows = `-- name: ListDueEscrows :many
+SELECT id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+FROM escrows
+WHERE status = 'FUNDED'
+  AND release_after <= now()
+  AND next_release_at <= now()
+ORDER BY release_after LIMIT $1
+`
+
+func (q *escrowRepository) ListDueEscrows(ctx context.Context, limit int32) ([]domain.Escrow, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listDueEscrows, limit)
+    return scanEscrows(rows, err)
+}
+
+const failEscrowRelease = `-- name: FailEscrowRelease :one
+UPDATE escrows
+SET release_attempts = release_attempts + 1,
+    release_error    = $2,
+    next_release_at  = $3,
+    updated_at       = now()
+WHERE id = $1
+  AND status = 'FUNDED' RETURNING id, payer_wallet_id, payee_wallet_id, escrow_wallet_id, amount, status, funding_transfer_id, settlement_transfer_id, dispute_reason, release_after, created_at, updated_at, release_attempts, next_release_at, release_error
+`
+
+type FailEscrowReleaseParams struct {
+    ID            int64     `json:"id"`
+    ReleaseError  string    `json:"release_error"`
+    NextReleaseAt time.Time `json:"next_release_at"`
+}
+
+// FailEscrowRelease records a failed release of a funded escrow, ListDueEscrows leaves it out until NextReleaseAt
+func (q *escrowRepository) FailEscrowRelease(ctx context.Context, arg FailEscrowReleaseParams) (domain.Escrow, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, failEscrowRelease, arg.ID, arg.ReleaseError, arg.NextReleaseAt)
+    var i domain.Escrow
+    err := row.Scan(
+        &i.ID,
+        &i.PayerWalletID,
+        &i.PayeeWalletID,
+        &i.EscrowWalletID,
+        &i.Amount,
+        &i.Status,
+        &i.FundingTransferID,
+        &i.SettlementTransferID,
+        &i.DisputeReason,
+        &i.ReleaseAfter,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+        &i.ReleaseAttempts,
+        &i.NextReleaseAt,
+        &i.ReleaseError,
+    )
+    return i, err
+}
+
+func scanEscrows(rows *sql.Rows, err error) ([]domain.Escrow, error) {
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.Escrow{}
+    for rows.Next() {
+        var i domain.Escrow
+        if err := rows.Scan(
+            &i.ID,
+            &i.PayerWalletID,
+            &i.PayeeWalletID,
+            &i.EscrowWalletID,
+            &i.Amount,
+            &i.Status,
+            &i.FundingTransferID,
+            &i.SettlementTransferID,
+            &i.DisputeReason,
+            &i.ReleaseAfter,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+            &i.ReleaseAttempts,
+            &i.NextReleaseAt,
+            &i.ReleaseError,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+type FundEscrowParams struct {
+    PayerWalletID int64     `json:"payer_wallet_id"`
+    PayeeWalletID int64     `json:"payee_wallet_id"`
+    Amount        int64     `json:"amount"`
+    ReleaseAfter  time.Time `json:"release_after"`
+}
+
+type EscrowTransferResult struct {
+    Escrow   domain.Escrow        `json:"escrow"`
+    Transfer WalletTransferResult `json:"transfer"`
+}
+
+// FundEscrow transfers the amount from the payer to the escrow wallet of the currency and records the escrow.
+func (q *escrowRepository) FundEscrow(ctx context.Context, arg FundEscrowParams) (EscrowTransferResult, error) {
+    var res EscrowTransferResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        payer, err := q.getWallet(ctx, arg.PayerWalletID)
+        if err != nil {
+            return err
+        }
+
+        payee, err := q.getWallet(ctx, arg.PayeeWalletID)
+        if err != nil {
+            return err
+        }
+
+        if payer.Currency != payee.Currency {
+            return errors.ErrCurrencyMismatch
+        }
+
+        escrowWallet, err := q.walletRepo.GetWalletByAddress(ctx, EscrowWalletAddress(payer.Currency))
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return errors.ErrEscrowWalletNotFound
+            }
+            return err
+        }
+
+        payer, _, err = lockWallets(ctx, q.walletRepo, payer.Address, escrowWallet.Address)
+        if err != nil {
+            return err
+        }
+
+        if payer.Status != domain.WalletStatusACTIVE || payee.Status != domain.WalletStatusACTIVE {
+            return errors.ErrWalletInactive
+        }
+
+        if !payer.IsBalanceSufficient(arg.Amount) {
+            return errors.ErrInsufficientBalance
+        }
+
+        res.Transfer, err = q.walletRepo.SendMoney(ctx, SendMoneyParams{
+            FromWalletAddress: payer.Address,
+            ToWalletAddress:   escrowWallet.Address,
+            Amount:            arg.Amount,
+            Kind:              domain.TransferKindESCROW,
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Escrow, err = q.CreateEscrow(ctx, CreateEscrowParams{
+            PayerWalletID:     payer.ID,
+            PayeeWalletID:     payee.ID,
+            EscrowWalletID:    escrowWallet.ID,
+            Amount:            arg.Amount,
+            FundingTransferID: res.Transfer.Transfer.ID,
+            ReleaseAfter:      arg.ReleaseAfter,
+        })
+        return err
+    })
+
+    return res, err
+}
+
+type DisputeEscrowParams struct {
+    ID     int64  `json:"id"`
+    Reason string `json:"reason"`
+}
+
+// DisputeEscrow stops the release of a funded escrow until ops resolve the dispute.
+func (q *escrowRepository) DisputeEscrow(ctx context.Context, arg DisputeEscrowParams) (domain.Escrow, error) {
+    var escrow domain.Escrow
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        var err error
+        escrow, err = q.lockEscrow(ctx, arg.ID, domain.EscrowStatusFUNDED)
+        if err != nil {
+            return err
+        }
+
+        escrow, err = q.UpdateEscrowStatus(ctx, UpdateEscrowStatusParams{
+            ID:            escrow.ID,
+            Status:        domain.EscrowStatusDISPUTED,
+            DisputeReason: arg.Reason,
+        })
+        return err
+    })
+
+    return escrow, err
+}
+
+// SettleEscrowParams moves an escrow in status From to RELEASED, paying the payee, or to REFUNDED, paying
+// the payer back.
+type SettleEscrowParams struct {
+    ID     int64               `json:"id"`
+    From   domain.EscrowStatus `json:"from"`
+    Status domain.EscrowStatus `json:"status"`
+}
+
+func (q *escrowRepository) SettleEscrow(ctx context.Context, arg SettleEscrowParams) (EscrowTransferResult, error) {
+    var res EscrowTransferResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        escrow, err := q.lockEscrow(ctx, arg.ID, arg.From)
+        if err != nil {
+            return err
+        }
+
+        toWalletID := escrow.PayeeWalletID
+        if arg.Status == domain.EscrowStatusREFUNDED {
+            toWalletID = escrow.PayerWalletID
+        }
+
+        escrowWallet, err := q.walletRepo.GetWallet(ctx, escrow.EscrowWalletID)
+        if err != nil {
+            return err
+        }
+
+        toWallet, err := q.walletRepo.GetWallet(ctx, toWalletID)
+        if err != nil {
+            return err
+        }
+
+        res.Transfer, err = q.walletRepo.SendMoney(ctx, SendMoneyParams{
+            FromWalletAddress: escrowWallet.Address,
+            ToWalletAddress:   toWallet.Address,
+            Amount:            escrow.Amount,
+            Kind:              domain.TransferKindESCROW,
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Escrow, err = q.UpdateEscrowStatus(ctx, UpdateEscrowStatusParams{
+            ID:                   escrow.ID,
+            Status:               arg.Status,
+            SettlementTransferID: sql.NullInt64{Int64: res.Transfer.Transfer.ID, Valid: true},
+            DisputeReason:        escrow.DisputeReason,
+        })
+        return err
+    })
+
+    return res, err
+}
+
+func (q *escrowRepository) lockEscrow(ctx context.Context, id int64, status domain.EscrowStatus) (domain.Escrow, error) {
+    escrow, err := q.GetEscrowForUpdate(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return escrow, errors.ErrEscrowNotFound
+        }
+        return escrow, err
+    }
+
+    if escrow.Status != status {
+        if status == domain.EscrowStatusDISPUTED {
+            return escrow, errors.ErrEscrowNotDisputed
+        }
+        return escrow, errors.ErrEscrowNotFunded
+    }
+
+    return escrow, nil
+}
+
+func (q *escrowRepository) getWallet(ctx context.Context, id int64) (domain.Wallet, error) {
+    wallet, err := q.walletRepo.GetWallet(ctx, id)
+    if err == sql.ErrNoRows {
+        return wallet, errors.ErrWalletNotFound
+    }
+    return wallet, err
+}
@@ -0,0 +1,214 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func fundRandomEscrow(t *testing.T, escrowRepo store.EscrowRepo, payer, payee domain.Wallet, amount int64, releaseAfter time.Time) store.EscrowTransferResult {
+    arg := store.FundEscrowParams{
+        PayerWalletID: payer.ID,
+        PayeeWalletID: payee.ID,
+        Amount:        amount,
+        ReleaseAfter:  releaseAfter,
+    }
+
+    res, err := escrowRepo.FundEscrow(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, res.Escrow.ID)
+    require.Equal(t, payer.ID, res.Escrow.PayerWalletID)
+    require.Equal(t, payee.ID, res.Escrow.PayeeWalletID)
+    require.Equal(t, amount, res.Escrow.Amount)
+    require.Equal(t, domain.EscrowStatusFUNDED, res.Escrow.Status)
+    require.Equal(t, res.Transfer.Transfer.ID, res.Escrow.FundingTransferID)
+    require.False(t, res.Escrow.SettlementTransferID.Valid)
+    require.Equal(t, res.Escrow.EscrowWalletID, res.Transfer.ToWallet.ID)
+    require.WithinDuration(t, releaseAfter, res.Escrow.ReleaseAfter, time.Second)
+
+    return res
+}
+
+func getEscrowWallet(t *testing.T, walletRepo store.WalletRepo) domain.Wallet {
+    wallet, err := walletRepo.GetWalletByAddress(context.Background(), store.EscrowWalletAddress("INR"))
+    require.NoError(t, err)
+    return wallet
+}
+
+func TestFundAndReleaseEscrow(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    escrowRepo := store.NewEscrowRepo(testDb, walletRepo)
+    payer, payee := createActiveWallets(t, 100)
+
+    escrowWallet := getEscrowWallet(t, walletRepo)
+    res := fundRandomEscrow(t, escrowRepo, payer, payee, 60, time.Now().UTC().Add(time.Hour))
+
+    updatedPayer, err := walletRepo.GetWallet(context.Background(), payer.ID)
+    require.NoError(t, err)
+    require.Equal(t, int64(40), updatedPayer.Balance)
+    require.Equal(t, escrowWallet.Balance+60, getEscrowWallet(t, walletRepo).Balance)
+
+    settled, err := escrowRepo.SettleEscrow(context.Background(), store.SettleEscrowParams{
+        ID:     res.Escrow.ID,
+        From:   domain.EscrowStatusFUNDED,
+        Status: domain.EscrowStatusRELEASED,
+    })
+    require.NoError(t, err)
+    require.Equal(t, domain.EscrowStatusRELEASED, settled.Escrow.Status)
+    require.True(t, settled.Escrow.SettlementTransferID.Valid)
+    require.Equal(t, settled.Transfer.Transfer.ID, settled.Escrow.SettlementTransferID.Int64)
+    require.Equal(t, payee.ID, settled.Transfer.ToWallet.ID)
+    require.Equal(t, domain.TransferKindESCROW, res.Transfer.Transfer.Kind)
+    require.Equal(t, domain.TransferKindESCROW, settled.Transfer.Transfer.Kind)
+
+    // the payee can't send the release back to the escrow wallet as a refund
+    _, err = walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{TransferID: settled.Transfer.Transfer.ID, Kind: domain.TransferKindREFUND})
+    require.EqualError(t, err, errors.ErrTransferNotRefundable.Error())
+
+    updatedPayee, err := walletRepo.GetWallet(context.Background(), payee.ID)
+    require.NoError(t, err)
+    require.Equal(t, payee.Balance+60, updatedPayee.Balance)
+    require.Equal(t, escrowWallet.Balance, getEscrowWallet(t, walletRepo).Balance)
+
+    // settled escrows can't be released or disputed again
+    _, err = escrowRepo.SettleEscrow(context.Background(), store.SettleEscrowParams{
+        ID:     res.Escrow.ID,
+        From:   domain.EscrowStatusFUNDED,
+        Status: domain.EscrowStatusRELEASED,
+    })
+    require.EqualError(t, err, errors.ErrEscrowNotFunded.Error())
+
+    _, err = escrowRepo.DisputeEscrow(context.Background(), store.DisputeEscrowParams{ID: res.Escrow.ID, Reason: "late"})
+    require.EqualError(t, err, errors.ErrEscrowNotFunded.Error())
+}
+
+func TestFundEscrowInsufficientBalance(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    escrowRepo := store.NewEscrowRepo(testDb, walletRepo)
+    payer, payee := createActiveWallets(t, 100)
+
+    _, err := escrowRepo.FundEscrow(context.Background(), store.FundEscrowParams{
+        PayerWalletID: payer.ID,
+        PayeeWalletID: payee.ID,
+        Amount:        101,
+        ReleaseAfter:  time.Now().UTC().Add(time.Hour),
+    })
+    require.EqualError(t, err, errors.ErrInsufficientBalance.Error())
+}
+
+func TestDisputeAndRefundEscrow(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    escrowRepo := store.NewEscrowRepo(testDb, walletRepo)
+    payer, payee := createActiveWallets(t, 100)
+
+    res := fundRandomEscrow(t, escrowRepo, payer, payee, 100, time.Now().UTC().Add(time.Hour))
+
+    disputed, err := escrowRepo.DisputeEscrow(context.Background(), store.DisputeEscrowParams{ID: res.Escrow.ID, Reason: "item not delivered"})
+    require.NoError(t, err)
+    require.Equal(t, domain.EscrowStatusDISPUTED, disputed.Status)
+    require.Equal(t, "item not delivered", disputed.DisputeReason)
+
+    // the payer can't release a disputed escrow, only ops can resolve it
+    _, err = escrowRepo.SettleEscrow(context.Background(), store.SettleEscrowParams{
+        ID:     res.Escrow.ID,
+        From:   domain.EscrowStatusFUNDED,
+        Status: domain.EscrowStatusRELEASED,
+    })
+    require.EqualError(t, err, errors.ErrEscrowNotFunded.Error())
+
+    settled, err := escrowRepo.SettleEscrow(context.Background(), store.SettleEscrowParams{
+        ID:     res.Escrow.ID,
+        From:   domain.EscrowStatusDISPUTED,
+        Status: domain.EscrowStatusREFUNDED,
+    })
+    require.NoError(t, err)
+    require.Equal(t, domain.EscrowStatusREFUNDED, settled.Escrow.Status)
+    require.Equal(t, payer.ID, settled.Transfer.ToWallet.ID)
+
+    updatedPayer, err := walletRepo.GetWallet(context.Background(), payer.ID)
+    require.NoError(t, err)
+    require.Equal(t, int64(100), updatedPayer.Balance)
+
+    _, err = escrowRepo.SettleEscrow(context.Background(), store.SettleEscrowParams{
+        ID:     res.Escrow.ID,
+        From:   domain.EscrowStatusDISPUTED,
+        Status: domain.EscrowStatusRELEASED,
+    })
+    require.EqualError(t, err, errors.ErrEscrowNotDisputed.Error())
+}
+
+func TestListDueEscrows(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    escrowRepo := store.NewEscrowRepo(testDb, walletRepo)
+    payer, payee := createActiveWallets(t, 100)
+
+    due := fundRandomEscrow(t, escrowRepo, payer, payee, 10, time.Now().UTC().Add(-time.Minute))
+    notDue := fundRandomEscrow(t, escrowRepo, payer, payee, 10, time.Now().UTC().Add(time.Hour))
+
+    escrows, err := escrowRepo.ListDueEscrows(context.Background(), 1000)
+    require.NoError(t, err)
+
+    ids := make(map[int64]bool)
+    for _, escrow := range escrows {
+        require.Equal(t, domain.EscrowStatusFUNDED, escrow.Status)
+        ids[escrow.ID] = true
+    }
+
+    require.True(t, ids[due.Escrow.ID])
+    require.False(t, ids[notDue.Escrow.ID])
+}
+
+func TestListDueEscrowsBacksOffFailedRelease(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    escrowRepo := store.NewEscrowRepo(testDb, walletRepo)
+    payer, payee := createActiveWallets(t, 100)
+
+    failing := fundRandomEscrow(t, escrowRepo, payer, payee, 10, time.Now().UTC().Add(-time.Minute))
+
+    failed, err := escrowRepo.FailEscrowRelease(context.Background(), store.FailEscrowReleaseParams{
+        ID:            failing.Escrow.ID,
+        ReleaseError:  errors.ErrWalletInactive.Error(),
+        NextReleaseAt: time.Now().UTC().Add(time.Hour),
+    })
+    require.NoError(t, err)
+    require.Equal(t, int64(1), failed.ReleaseAttempts)
+    require.Equal(t, errors.ErrWalletInactive.Error(), failed.ReleaseError)
+    require.Equal(t, domain.EscrowStatusFUNDED, failed.Status)
+
+    escrows, err := escrowRepo.ListDueEscrows(context.Background(), 1000)
+    require.NoError(t, err)
+    for _, escrow := range escrows {
+        require.NotEqual(t, failing.Escrow.ID, escrow.ID)
+    }
+
+    // once its backoff is over it is due again
+    _, err = escrowRepo.FailEscrowRelease(context.Background(), store.FailEscrowReleaseParams{
+        ID:            failing.Escrow.ID,
+        ReleaseError:  errors.ErrWalletInactive.Error(),
+        NextReleaseAt: time.Now().UTC().Add(-time.Minute),
+    })
+    require.NoError(t, err)
+
+    escrows, err = escrowRepo.ListDueEscrows(context.Background(), 1000)
+    require.NoError(t, err)
+
+    var found bool
+    for _, escrow := range escrows {
+        if escrow.ID == failing.Escrow.ID {
+            found = true
+            require.Equal(t, int64(2), escrow.ReleaseAttempts)
+        }
+    }
+    require.True(t, found)
+}
+
+func TestGetEscrowNotFound(t *testing.T) {
+    escrowRepo := store.NewEscrowRepo(testDb, InitWalletRepo(t))
+
+    _, err := escrowRepo.GetEscrow(context.Background(), -1)
+    require.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+)
+
+type HealthRepo interface {
+    Ping(ctx context.Context) error
+    GetSchemaVersion(ctx context.Context) (SchemaVersion, error)
+    ListCurrenciesWithoutOrganizationWallet(ctx context.Context) ([]string, error)
+}
+
+type healthRepository struct {
+    db *sql.DB
+}
+
+func NewHealthRepo(client *sql.DB) HealthRepo {
+    return &healthRepository{
+        db: client,
+    }
+}
+
+// Ping checks that a connection to the database can be made, it is not traced nor run in a transaction.
+func (q *healthRepository) Ping(ctx context.Context) error {
+    return q.db.PingContext(ctx)
+}
+
+const getSchemaVersion = `-- name: GetSchemaVersion :one
+SELECT version, dirty FROM schema_migrations
+LIMIT 1
+`
+
+// SchemaVersion is the row golang-migrate keeps in schema_migrations, dirty when a migration failed half way.
+type SchemaVersion struct {
+    Version int64 `json:"version"`
+    Dirty   bool  `json:"dirty"`
+}
+
+func (q *healthRepository) GetSchemaVersion(ctx context.Context) (SchemaVersion, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getSchemaVersion)
+    var i SchemaVersion
+    err := row.Scan(&i.Version, &i.Dirty)
+    return i, err
+}
+
+const listCurrenciesWithoutOrganizationWallet = `-- name: ListCurrenciesWithoutOrganizationWallet :many
+SELECT c.code
+FROM currencies c
+WHERE NOT EXISTS(SELECT 1
+                 FROM wallets w
+                 WHERE w.address = 'grab' || lower(c.code) || '@my.wallet'
+                   AND w.currency = c.code)
+ORDER BY c.code
+`
+
+// ListCurrenciesWithoutOrganizationWallet returns the currencies whose organization wallet, grab<currency>@my.wallet,
+// is missing. No bank account can be linked in those.
+func (q *healthRepository) ListCurrenciesWithoutOrganizationWallet(ctx context.Context) ([]string, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listCurrenciesWithoutOrganizationWallet)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []string{}
+    for rows.Next() {
+        var code string
+        if err := rows.Scan(&code); err != nil {
+            return nil, err
+        }
+        items = append(items, code)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
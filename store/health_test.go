@@ -0,0 +1,40 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/db/migration"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+)
+
+func TestPing(t *testing.T) {
+    healthRepo := store.NewHealthRepo(testDb)
+    require.NoError(t, healthRepo.Ping(context.Background()))
+}
+
+func TestGetSchemaVersion(t *testing.T) {
+    healthRepo := store.NewHealthRepo(testDb)
+
+    version, err := healthRepo.GetSchemaVersion(context.Background())
+    require.NoError(t, err)
+    require.Equal(t, migration.LatestVersion(), version.Version)
+    require.False(t, version.Dirty)
+}
+
+func TestListCurrenciesWithoutOrganizationWallet(t *testing.T) {
+    healthRepo := store.NewHealthRepo(testDb)
+
+    currency := createRandomCurrency(t, strings.ToUpper(util.RandomString(3)))
+
+    currencies, err := healthRepo.ListCurrenciesWithoutOrganizationWallet(context.Background())
+    require.NoError(t, err)
+    require.Contains(t, currencies, currency.Code)
+
+    // the currencies seeded with the schema have their organization wallet
+    require.NotContains(t, currencies, "INR")
+    require.NotContains(t, currencies, "USD")
+    require.NotContains(t, currencies, "EUR")
+}
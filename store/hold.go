@@ -0,0 +1,403 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "time"
+)
+
+type HoldRepo interface {
+    CreateHold(ctx context.Context, arg CreateHoldParams) (domain.Hold, error)
+    GetHold(ctx context.Context, id int64) (domain.Hold, error)
+    GetHoldForUpdate(ctx context.Context, id int64) (domain.Hold, error)
+    UpdateHold(ctx context.Context, arg UpdateHoldParams) (domain.Hold, error)
+    ListHolds(ctx context.Context, arg ListHoldsParams) ([]domain.Hold, error)
+    ListExpiredHolds(ctx context.Context, limit int32) ([]domain.Hold, error)
+    AuthorizeHold(ctx context.Context, arg CreateHoldParams) (domain.Hold, error)
+    CaptureHold(ctx context.Context, arg CaptureHoldParams) (CaptureHoldResult, error)
+    ReleaseHold(ctx context.Context, arg ReleaseHoldParams) (domain.Hold, error)
+}
+
+type holdRepository struct {
+    db         *sql.DB
+    walletRepo WalletRepo
+}
+
+func NewHoldRepo(client *sql.DB, walletRepo WalletRepo) HoldRepo {
+    return &holdRepository{
+        db:         client,
+        walletRepo: walletRepo,
+    }
+}
+
+const createHold = `-- name: CreateHold :one
+INSERT INTO holds (wallet_id,
+                   to_wallet_id,
+                   amount,
+                   expires_at)
+VALUES ($1, $2, $3, $4) RETURNING id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+`
+
+type CreateHoldParams struct {
+    WalletID   int64     `json:"wallet_id"`
+    ToWalletID int64     `json:"to_wallet_id"`
+    Amount     int64     `json:"amount"`
+    ExpiresAt  time.Time `json:"expires_at"`
+}
+
+func (q *holdRepository) CreateHold(ctx context.Context, arg CreateHoldParams) (domain.Hold, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createHold,
+        arg.WalletID,
+        arg.ToWalletID,
+        arg.Amount,
+        arg.ExpiresAt,
+    )
+    var i domain.Hold
+    err := row.Scan(
+        &i.ID,
+        &i.WalletID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.CapturedAmount,
+        &i.Status,
+        &i.TransferID,
+        &i.ExpiresAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getHold = `-- name: GetHold :one
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE id = $1 LIMIT 1
+`
+
+func (q *holdRepository) GetHold(ctx context.Context, id int64) (domain.Hold, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getHold, id)
+    var i domain.Hold
+    err := row.Scan(
+        &i.ID,
+        &i.WalletID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.CapturedAmount,
+        &i.Status,
+        &i.TransferID,
+        &i.ExpiresAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getHoldForUpdate = `-- name: GetHoldForUpdate :one
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *holdRepository) GetHoldForUpdate(ctx context.Context, id int64) (domain.Hold, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getHoldForUpdate, id)
+    var i domain.Hold
+    err := row.Scan(
+        &i.ID,
+        &i.WalletID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.CapturedAmount,
+        &i.Status,
+        &i.TransferID,
+        &i.ExpiresAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const updateHold = `-- name: UpdateHold :one
+UPDATE holds
+SET status          = $2,
+    captured_amount = $3,
+    transfer_id     = $4,
+    updated_at      = now()
+WHERE id = $1 RETURNING id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+`
+
+type UpdateHoldParams struct {
+    ID             int64             `json:"id"`
+    Status         domain.HoldStatus `json:"status"`
+    CapturedAmount int64             `json:"captured_amount"`
+    TransferID     sql.NullInt64     `json:"transfer_id"`
+}
+
+func (q *holdRepository) UpdateHold(ctx context.Context, arg UpdateHoldParams) (domain.Hold, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateHold,
+        arg.ID,
+        arg.Status,
+        arg.CapturedAmount,
+        arg.TransferID,
+    )
+    var i domain.Hold
+    err := row.Scan(
+        &i.ID,
+        &i.WalletID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.CapturedAmount,
+        &i.Status,
+        &i.TransferID,
+        &i.ExpiresAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const listHolds = `-- name: ListHolds :many
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE wallet_id = $1
+   OR to_wallet_id = $1
+ORDER BY id DESC LIMIT $2
+OFFSET $3
+`
+
+type ListHoldsParams struct {
+    WalletID int64 `json:"wallet_id"`
+    Limit    int32 `json:"limit"`
+    Offset   int32 `json:"offset"`
+}
+
+func (q *holdRepository) ListHolds(ctx context.Context, arg ListHoldsParams) ([]domain.Hold, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listHolds, arg.WalletID, arg.Limit, arg.Offset)
+    return scanHolds(rows, err)
+}
+
+const listExpiredHolds = `-- name: ListExpiredHolds :many
+SELECT id, wallet_id, to_wallet_id, amount, captured_amount, status, transfer_id, expires_at, created_at, updated_at
+FROM holds
+WHERE status = 'AUTHORIZED'
+  AND expires_at <= now()
+ORDER BY expires_at LIMIT $1
+`
+
+func (q *holdRepository) ListExpiredHolds(ctx context.Context, limit int32) ([]domain.Hold, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listExpiredHolds, limit)
+    return scanHolds(rows, err)
+}
+
+func scanHolds(rows *sql.Rows, err error) ([]domain.Hold, error) {
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.Hold{}
+    for rows.Next() {
+        var i domain.Hold
+        if err := rows.Scan(
+            &i.ID,
+            &i.WalletID,
+            &i.ToWalletID,
+            &i.Amount,
+            &i.CapturedAmount,
+            &i.Status,
+            &i.TransferID,
+            &i.ExpiresAt,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+// AuthorizeHold reserves the amount in the wallet's held balance, the wallet needs it available.
+func (q *holdRepository) AuthorizeHold(ctx context.Context, arg CreateHoldParams) (domain.Hold, error) {
+    var hold domain.Hold
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        wallet, err := q.walletRepo.GetWalletForUpdate(ctx, arg.WalletID)
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return errors.ErrWalletNotFound
+            }
+            return err
+        }
+
+        toWallet, err := q.walletRepo.GetWallet(ctx, arg.ToWalletID)
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return errors.ErrWalletNotFound
+            }
+            return err
+        }
+
+        if wallet.Status != domain.WalletStatusACTIVE || toWallet.Status != domain.WalletStatusACTIVE {
+            return errors.ErrWalletInactive
+        }
+
+        if wallet.Currency != toWallet.Currency {
+            return errors.ErrCurrencyMismatch
+        }
+
+        if !wallet.IsBalanceSufficient(arg.Amount) {
+            return errors.ErrInsufficientBalance
+        }
+
+        _, err = q.walletRepo.AddWalletHeldBalance(ctx, AddWalletHeldBalanceParams{
+            Amount: arg.Amount,
+            ID:     wallet.ID,
+        })
+        if err != nil {
+            return err
+        }
+
+        hold, err = q.CreateHold(ctx, arg)
+        return err
+    })
+
+    return hold, err
+}
+
+type CaptureHoldParams struct {
+    ID     int64 `json:"id"`
+    Amount int64 `json:"amount"`
+}
+
+type CaptureHoldResult struct {
+    Hold     domain.Hold          `json:"hold"`
+    Transfer WalletTransferResult `json:"transfer"`
+}
+
+// CaptureHold transfers Amount of the hold to the merchant, a zero Amount captures all of it. A hold is
+// captured once, what is not captured goes back to the available balance.
+func (q *holdRepository) CaptureHold(ctx context.Context, arg CaptureHoldParams) (CaptureHoldResult, error) {
+    var res CaptureHoldResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        hold, err := q.lockAuthorizedHold(ctx, arg.ID)
+        if err != nil {
+            return err
+        }
+
+        if time.Now().UTC().After(hold.ExpiresAt) {
+            return errors.ErrHoldExpired
+        }
+
+        amount := arg.Amount
+        if amount == 0 {
+            amount = hold.Amount
+        }
+
+        if amount > hold.Amount {
+            return errors.ErrCaptureExceedsHold
+        }
+
+        wallet, err := q.walletRepo.GetWallet(ctx, hold.WalletID)
+        if err != nil {
+            return err
+        }
+
+        toWallet, err := q.walletRepo.GetWallet(ctx, hold.ToWalletID)
+        if err != nil {
+            return err
+        }
+
+        // locked before the held balance is released
+        _, _, err = lockWallets(ctx, q.walletRepo, wallet.Address, toWallet.Address)
+        if err != nil {
+            return err
+        }
+
+        _, err = q.walletRepo.AddWalletHeldBalance(ctx, AddWalletHeldBalanceParams{
+            Amount: -hold.Amount,
+            ID:     wallet.ID,
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Transfer, err = q.walletRepo.SendMoney(ctx, SendMoneyParams{
+            FromWalletAddress: wallet.Address,
+            ToWalletAddress:   toWallet.Address,
+            Amount:            amount,
+            Kind:              domain.TransferKindCAPTURE,
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+            ID:             hold.ID,
+            Status:         domain.HoldStatusCAPTURED,
+            CapturedAmount: amount,
+            TransferID:     sql.NullInt64{Int64: res.Transfer.Transfer.ID, Valid: true},
+        })
+        return err
+    })
+
+    return res, err
+}
+
+type ReleaseHoldParams struct {
+    ID     int64             `json:"id"`
+    Status domain.HoldStatus `json:"status"`
+}
+
+// ReleaseHold voids or expires an authorized hold, its amount goes back to the available balance.
+func (q *holdRepository) ReleaseHold(ctx context.Context, arg ReleaseHoldParams) (domain.Hold, error) {
+    var hold domain.Hold
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        var err error
+        hold, err = q.lockAuthorizedHold(ctx, arg.ID)
+        if err != nil {
+            return err
+        }
+
+        _, err = q.walletRepo.AddWalletHeldBalance(ctx, AddWalletHeldBalanceParams{
+            Amount: -hold.Amount,
+            ID:     hold.WalletID,
+        })
+        if err != nil {
+            return err
+        }
+
+        hold, err = q.UpdateHold(ctx, UpdateHoldParams{
+            ID:     hold.ID,
+            Status: arg.Status,
+        })
+        return err
+    })
+
+    return hold, err
+}
+
+func (q *holdRepository) lockAuthorizedHold(ctx context.Context, id int64) (domain.Hold, error) {
+    hold, err := q.GetHoldForUpdate(ctx, id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return hold, errors.ErrHoldNotFound
+        }
+        return hold, err
+    }
+
+    if hold.Status != domain.HoldStatusAUTHORIZED {
+        return hold, errors.ErrHoldNotAuthorized
+    }
+
+    return hold, nil
+}
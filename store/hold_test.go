@@ -0,0 +1,155 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func createActiveWallets(t *testing.T, amount int64) (domain.Wallet, domain.Wallet) {
+    wallet := createRandomWalletWithAmount(t, amount)
+    verifyBankAccount(t, wallet.BankAccountID)
+
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    return wallet, toWallet
+}
+
+func authorizeRandomHold(t *testing.T, holdRepo store.HoldRepo, wallet, toWallet domain.Wallet, amount int64, ttl time.Duration) domain.Hold {
+    arg := store.CreateHoldParams{
+        WalletID:   wallet.ID,
+        ToWalletID: toWallet.ID,
+        Amount:     amount,
+        ExpiresAt:  time.Now().UTC().Add(ttl),
+    }
+
+    hold, err := holdRepo.AuthorizeHold(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, hold.ID)
+    require.Equal(t, arg.WalletID, hold.WalletID)
+    require.Equal(t, arg.ToWalletID, hold.ToWalletID)
+    require.Equal(t, arg.Amount, hold.Amount)
+    require.Equal(t, domain.HoldStatusAUTHORIZED, hold.Status)
+    require.Zero(t, hold.CapturedAmount)
+    require.False(t, hold.TransferID.Valid)
+    require.WithinDuration(t, arg.ExpiresAt, hold.ExpiresAt, time.Second)
+
+    return hold
+}
+
+func TestAuthorizeHold(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    holdRepo := store.NewHoldRepo(testDb, walletRepo)
+    wallet, toWallet := createActiveWallets(t, 100)
+
+    authorizeRandomHold(t, holdRepo, wallet, toWallet, 70, time.Hour)
+
+    updatedWallet, err := walletRepo.GetWallet(context.Background(), wallet.ID)
+    require.NoError(t, err)
+    require.Equal(t, int64(100), updatedWallet.Balance)
+    require.Equal(t, int64(70), updatedWallet.HeldBalance)
+    require.Equal(t, int64(30), updatedWallet.AvailableBalance())
+
+    // only the available balance can be held or spent
+    _, err = holdRepo.AuthorizeHold(context.Background(), store.CreateHoldParams{
+        WalletID:   wallet.ID,
+        ToWalletID: toWallet.ID,
+        Amount:     40,
+        ExpiresAt:  time.Now().UTC().Add(time.Hour),
+    })
+    require.EqualError(t, err, errors.ErrInsufficientBalance.Error())
+
+    _, err = walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: wallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            40,
+    })
+    require.Error(t, err)
+
+    _, err = walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: wallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            30,
+    })
+    require.NoError(t, err)
+}
+
+func TestCaptureHold(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    holdRepo := store.NewHoldRepo(testDb, walletRepo)
+    wallet, toWallet := createActiveWallets(t, 100)
+
+    hold := authorizeRandomHold(t, holdRepo, wallet, toWallet, 70, time.Hour)
+
+    _, err := holdRepo.CaptureHold(context.Background(), store.CaptureHoldParams{ID: hold.ID, Amount: 80})
+    require.EqualError(t, err, errors.ErrCaptureExceedsHold.Error())
+
+    res, err := holdRepo.CaptureHold(context.Background(), store.CaptureHoldParams{ID: hold.ID, Amount: 50})
+    require.NoError(t, err)
+    require.Equal(t, domain.HoldStatusCAPTURED, res.Hold.Status)
+    require.Equal(t, int64(50), res.Hold.CapturedAmount)
+    require.Equal(t, res.Transfer.Transfer.ID, res.Hold.TransferID.Int64)
+    require.Equal(t, int64(50), res.Transfer.Transfer.Amount)
+    require.Equal(t, domain.TransferKindCAPTURE, res.Transfer.Transfer.Kind)
+
+    // the 20 not captured is available again
+    require.Equal(t, int64(50), res.Transfer.Wallet.Balance)
+    require.Zero(t, res.Transfer.Wallet.HeldBalance)
+    require.Equal(t, int64(50), res.Transfer.ToWallet.Balance)
+
+    _, err = holdRepo.CaptureHold(context.Background(), store.CaptureHoldParams{ID: hold.ID})
+    require.EqualError(t, err, errors.ErrHoldNotAuthorized.Error())
+
+    _, err = holdRepo.CaptureHold(context.Background(), store.CaptureHoldParams{ID: -1})
+    require.EqualError(t, err, errors.ErrHoldNotFound.Error())
+}
+
+func TestReleaseHold(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    holdRepo := store.NewHoldRepo(testDb, walletRepo)
+    wallet, toWallet := createActiveWallets(t, 100)
+
+    hold := authorizeRandomHold(t, holdRepo, wallet, toWallet, 70, time.Hour)
+
+    voided, err := holdRepo.ReleaseHold(context.Background(), store.ReleaseHoldParams{ID: hold.ID, Status: domain.HoldStatusVOIDED})
+    require.NoError(t, err)
+    require.Equal(t, domain.HoldStatusVOIDED, voided.Status)
+
+    updatedWallet, err := walletRepo.GetWallet(context.Background(), wallet.ID)
+    require.NoError(t, err)
+    require.Zero(t, updatedWallet.HeldBalance)
+
+    _, err = holdRepo.ReleaseHold(context.Background(), store.ReleaseHoldParams{ID: hold.ID, Status: domain.HoldStatusVOIDED})
+    require.EqualError(t, err, errors.ErrHoldNotAuthorized.Error())
+}
+
+func TestListExpiredHolds(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    holdRepo := store.NewHoldRepo(testDb, walletRepo)
+    wallet, toWallet := createActiveWallets(t, 100)
+
+    expired := authorizeRandomHold(t, holdRepo, wallet, toWallet, 10, -time.Minute)
+    active := authorizeRandomHold(t, holdRepo, wallet, toWallet, 10, time.Hour)
+
+    _, err := holdRepo.CaptureHold(context.Background(), store.CaptureHoldParams{ID: expired.ID})
+    require.EqualError(t, err, errors.ErrHoldExpired.Error())
+
+    holds, err := holdRepo.ListExpiredHolds(context.Background(), 1000)
+    require.NoError(t, err)
+
+    ids := map[int64]bool{}
+    for _, hold := range holds {
+        ids[hold.ID] = true
+    }
+    require.True(t, ids[expired.ID])
+    require.False(t, ids[active.ID])
+
+    list, err := holdRepo.ListHolds(context.Background(), store.ListHoldsParams{WalletID: toWallet.ID, Limit: 5})
+    require.NoError(t, err)
+    require.Len(t, list, 2)
+}
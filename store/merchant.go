@@ -0,0 +1,118 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type MerchantRepo interface {
+    CreateMerchant(ctx context.Context, arg CreateMerchantParams) (domain.Merchant, error)
+    GetMerchant(ctx context.Context, id int64) (domain.Merchant, error)
+    GetMerchantByUserID(ctx context.Context, userID int64) (domain.Merchant, error)
+    UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (domain.Merchant, error)
+}
+
+type merchantRepository struct {
+    db *sql.DB
+}
+
+func NewMerchantRepo(client *sql.DB) MerchantRepo {
+    return &merchantRepository{
+        db: client,
+    }
+}
+
+const createMerchant = `-- name: CreateMerchant :one
+INSERT INTO merchants (user_id,
+                       display_name,
+                       category,
+                       settlement_wallet_id,
+                       callback_url)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at
+`
+
+type CreateMerchantParams struct {
+    UserID             int64  `json:"user_id"`
+    DisplayName        string `json:"display_name"`
+    Category           string `json:"category"`
+    SettlementWalletID int64  `json:"settlement_wallet_id"`
+    CallbackUrl        string `json:"callback_url"`
+}
+
+func (q *merchantRepository) CreateMerchant(ctx context.Context, arg CreateMerchantParams) (domain.Merchant, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createMerchant,
+        arg.UserID,
+        arg.DisplayName,
+        arg.Category,
+        arg.SettlementWalletID,
+        arg.CallbackUrl,
+    )
+    return scanMerchant(row)
+}
+
+const getMerchant = `-- name: GetMerchant :one
+SELECT id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at
+FROM merchants
+WHERE id = $1 LIMIT 1
+`
+
+func (q *merchantRepository) GetMerchant(ctx context.Context, id int64) (domain.Merchant, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getMerchant, id)
+    return scanMerchant(row)
+}
+
+const getMerchantByUserID = `-- name: GetMerchantByUserID :one
+SELECT id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at
+FROM merchants
+WHERE user_id = $1 LIMIT 1
+`
+
+func (q *merchantRepository) GetMerchantByUserID(ctx context.Context, userID int64) (domain.Merchant, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getMerchantByUserID, userID)
+    return scanMerchant(row)
+}
+
+const updateMerchant = `-- name: UpdateMerchant :one
+UPDATE merchants
+SET display_name         = $2,
+    category             = $3,
+    settlement_wallet_id = $4,
+    callback_url         = $5,
+    updated_at           = now()
+WHERE id = $1 RETURNING id, user_id, display_name, category, settlement_wallet_id, callback_url, created_at, updated_at
+`
+
+type UpdateMerchantParams struct {
+    ID                 int64  `json:"id"`
+    DisplayName        string `json:"display_name"`
+    Category           string `json:"category"`
+    SettlementWalletID int64  `json:"settlement_wallet_id"`
+    CallbackUrl        string `json:"callback_url"`
+}
+
+func (q *merchantRepository) UpdateMerchant(ctx context.Context, arg UpdateMerchantParams) (domain.Merchant, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateMerchant,
+        arg.ID,
+        arg.DisplayName,
+        arg.Category,
+        arg.SettlementWalletID,
+        arg.CallbackUrl,
+    )
+    return scanMerchant(row)
+}
+
+func scanMerchant(row *sql.Row) (domain.Merchant, error) {
+    var i domain.Merchant
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.DisplayName,
+        &i.Category,
+        &i.SettlementWalletID,
+        &i.CallbackUrl,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
@@ -0,0 +1,83 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func createRandomMerchant(t *testing.T) domain.Merchant {
+    wallet := createRandomWallet(t)
+
+    arg := store.CreateMerchantParams{
+        UserID:             wallet.UserID,
+        DisplayName:        util.RandomUser(),
+        Category:           "RETAIL",
+        SettlementWalletID: wallet.ID,
+        CallbackUrl:        "https://" + util.RandomString(8) + ".example.com/callback",
+    }
+
+    merchantRepo := store.NewMerchantRepo(testDb)
+    merchant, err := merchantRepo.CreateMerchant(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, merchant.ID)
+    require.Equal(t, arg.UserID, merchant.UserID)
+    require.Equal(t, arg.DisplayName, merchant.DisplayName)
+    require.Equal(t, arg.Category, merchant.Category)
+    require.Equal(t, arg.SettlementWalletID, merchant.SettlementWalletID)
+    require.Equal(t, arg.CallbackUrl, merchant.CallbackUrl)
+    require.NotZero(t, merchant.CreatedAt)
+
+    return merchant
+}
+
+func TestCreateMerchant(t *testing.T) {
+    merchant := createRandomMerchant(t)
+
+    // one merchant per user
+    _, err := store.NewMerchantRepo(testDb).CreateMerchant(context.Background(), store.CreateMerchantParams{
+        UserID:             merchant.UserID,
+        DisplayName:        util.RandomUser(),
+        Category:           "OTHER",
+        SettlementWalletID: merchant.SettlementWalletID,
+    })
+    require.Error(t, err)
+}
+
+func TestGetMerchant(t *testing.T) {
+    merchantRepo := store.NewMerchantRepo(testDb)
+    merchant := createRandomMerchant(t)
+
+    merchant1, err := merchantRepo.GetMerchant(context.Background(), merchant.ID)
+    require.NoError(t, err)
+    require.Equal(t, merchant.ID, merchant1.ID)
+
+    merchant2, err := merchantRepo.GetMerchantByUserID(context.Background(), merchant.UserID)
+    require.NoError(t, err)
+    require.Equal(t, merchant.ID, merchant2.ID)
+
+    _, err = merchantRepo.GetMerchantByUserID(context.Background(), -1)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestUpdateMerchant(t *testing.T) {
+    merchantRepo := store.NewMerchantRepo(testDb)
+    merchant := createRandomMerchant(t)
+
+    arg := store.UpdateMerchantParams{
+        ID:                 merchant.ID,
+        DisplayName:        util.RandomUser(),
+        Category:           "FOOD",
+        SettlementWalletID: merchant.SettlementWalletID,
+    }
+
+    updatedMerchant, err := merchantRepo.UpdateMerchant(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, arg.DisplayName, updatedMerchant.DisplayName)
+    require.Equal(t, arg.Category, updatedMerchant.Category)
+    require.Empty(t, updatedMerchant.CallbackUrl)
+}
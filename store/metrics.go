@@ -0,0 +1,168 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/constant"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/prometheus/client_golang/prometheus"
+    "math"
+)
+
+// Metrics are the business counters, they are collected by decorating the repos with them so that
+// every path moving money is counted, whichever service calls it.
+type Metrics struct {
+    Transfers                 *prometheus.CounterVec
+    AmountMoved               *prometheus.CounterVec
+    FailedTransfers           *prometheus.CounterVec
+    PaymentRequestTransitions *prometheus.CounterVec
+}
+
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+    m := &Metrics{
+        Transfers: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "wallet_transfers_total",
+            Help: "Transfers between wallets by kind and currency.",
+        }, []string{"kind", "currency"}),
+        AmountMoved: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "wallet_amount_moved_total",
+            Help: "Amount moved between wallets by kind and currency, in the minor unit of the currency.",
+        }, []string{"kind", "currency"}),
+        FailedTransfers: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "wallet_transfers_failed_total",
+            Help: "Transfers refused or failed by kind and reason.",
+        }, []string{"kind", "reason"}),
+        PaymentRequestTransitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+            Name: "payment_request_transitions_total",
+            Help: "Payment requests entering each status.",
+        }, []string{"status"}),
+    }
+    registerer.MustRegister(m.Transfers, m.AmountMoved, m.FailedTransfers, m.PaymentRequestTransitions)
+    return m
+}
+
+// RegisterOutboxMetrics exports the count of the dead outbox events, the events the relay gave up on, to alert
+// on. The count is read at each scrape, it is NaN while the database can't be reached.
+func RegisterOutboxMetrics(registerer prometheus.Registerer, outboxRepo OutboxRepo) {
+    registerer.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+        Name: "outbox_dead_events",
+        Help: "Outbox events set aside after failing to publish too many times.",
+    }, func() float64 {
+        ctx, cancel := context.WithTimeout(context.Background(), constant.DBPingTimeout)
+        defer cancel()
+
+        count, err := outboxRepo.CountDeadOutboxEvents(ctx)
+        if err != nil {
+            return math.NaN()
+        }
+        return float64(count)
+    }))
+}
+
+// transferred counts a transfer once it is done, a transfer inside an outer transaction is counted even if the
+// transaction is rolled back later, which the checks made before the transfer keep rare.
+func (m *Metrics) transferred(res WalletTransferResult) {
+    kind := string(res.Transfer.Kind)
+    m.Transfers.WithLabelValues(kind, res.Wallet.Currency).Inc()
+    m.AmountMoved.WithLabelValues(kind, res.Wallet.Currency).Add(float64(res.Transfer.Amount))
+}
+
+func (m *Metrics) transferFailed(kind domain.TransferKind, err error) {
+    m.FailedTransfers.WithLabelValues(string(kind), failureReason(err)).Inc()
+}
+
+func failureReason(err error) string {
+    switch err {
+    case errors.ErrInsufficientBalance:
+        return "insufficient_balance"
+    case errors.ErrWalletInactive:
+        return "wallet_inactive"
+    case sql.ErrNoRows, errors.ErrWalletNotFound:
+        return "wallet_not_found"
+    case errors.ErrCurrencyMismatch:
+        return "currency_mismatch"
+    case errors.ErrTransferNotFound:
+        return "transfer_not_found"
+    case errors.ErrTransferNotRefundable:
+        return "transfer_not_refundable"
+    case errors.ErrRefundExceedsTransfer:
+        return "refund_exceeds_transfer"
+    case context.Canceled, context.DeadlineExceeded:
+        return "canceled"
+    default:
+        return "other"
+    }
+}
+
+type walletRepoWithMetrics struct {
+    WalletRepo
+    metrics *Metrics
+}
+
+// NewWalletRepoWithMetrics counts the transfers of walletRepo, the other methods are passed through.
+func NewWalletRepoWithMetrics(walletRepo WalletRepo, metrics *Metrics) WalletRepo {
+    return &walletRepoWithMetrics{
+        WalletRepo: walletRepo,
+        metrics:    metrics,
+    }
+}
+
+func (w *walletRepoWithMetrics) SendMoney(ctx context.Context, arg SendMoneyParams) (WalletTransferResult, error) {
+    res, err := w.WalletRepo.SendMoney(ctx, arg)
+    if err != nil {
+        kind := arg.Kind
+        if kind == "" {
+            kind = domain.TransferKindPAYMENT
+        }
+        w.metrics.transferFailed(kind, err)
+        return res, err
+    }
+
+    w.metrics.transferred(res)
+    return res, nil
+}
+
+func (w *walletRepoWithMetrics) RefundTransfer(ctx context.Context, arg RefundTransferParams) (RefundTransferResult, error) {
+    res, err := w.WalletRepo.RefundTransfer(ctx, arg)
+    if err != nil {
+        w.metrics.transferFailed(arg.Kind, err)
+        return res, err
+    }
+
+    w.metrics.transferred(res.Refund)
+    return res, nil
+}
+
+type paymentRequestRepoWithMetrics struct {
+    PaymentRequestRepo
+    metrics *Metrics
+}
+
+// NewPaymentRequestRepoWithMetrics counts the payment requests entering each status.
+func NewPaymentRequestRepoWithMetrics(paymentRequestRepo PaymentRequestRepo, metrics *Metrics) PaymentRequestRepo {
+    return &paymentRequestRepoWithMetrics{
+        PaymentRequestRepo: paymentRequestRepo,
+        metrics:            metrics,
+    }
+}
+
+func (p *paymentRequestRepoWithMetrics) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (domain.PaymentRequest, error) {
+    payReq, err := p.PaymentRequestRepo.CreatePaymentRequest(ctx, arg)
+    if err != nil {
+        return payReq, err
+    }
+
+    p.metrics.PaymentRequestTransitions.WithLabelValues(string(payReq.Status)).Inc()
+    return payReq, nil
+}
+
+func (p *paymentRequestRepoWithMetrics) UpdatePaymentRequest(ctx context.Context, arg UpdatePaymentRequestParams) (domain.PaymentRequest, error) {
+    payReq, err := p.PaymentRequestRepo.UpdatePaymentRequest(ctx, arg)
+    if err != nil {
+        return payReq, err
+    }
+
+    p.metrics.PaymentRequestTransitions.WithLabelValues(string(payReq.Status)).Inc()
+    return payReq, nil
+}
@@ -0,0 +1,93 @@
+package store_test
+
+import (
+    "context"
+    "fmt"
+    "github.com/golang/mock/gomock"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    mockdb "github.com/pranayhere/simple-wallet/store/mock"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/testutil"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestWalletRepoWithMetrics(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    m := store.NewMetrics(prometheus.NewRegistry())
+    mockWalletRepo := mockdb.NewMockWalletRepo(ctrl)
+    walletRepo := store.NewWalletRepoWithMetrics(mockWalletRepo, m)
+
+    payment := store.WalletTransferResult{
+        Wallet:   domain.Wallet{Currency: "INR"},
+        Transfer: domain.Transfer{Amount: 500, Kind: domain.TransferKindPAYMENT},
+    }
+    refund := store.RefundTransferResult{
+        Refund: store.WalletTransferResult{
+            Wallet:   domain.Wallet{Currency: "INR"},
+            Transfer: domain.Transfer{Amount: 200, Kind: domain.TransferKindREFUND},
+        },
+    }
+
+    gomock.InOrder(
+        mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(2).Return(payment, nil),
+        mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1).Return(store.WalletTransferResult{}, errors.ErrInsufficientBalance),
+        mockWalletRepo.EXPECT().SendMoney(gomock.Any(), gomock.Any()).Times(1).Return(store.WalletTransferResult{}, fmt.Errorf("connection reset")),
+    )
+    mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), gomock.Any()).Times(1).Return(refund, nil)
+    mockWalletRepo.EXPECT().RefundTransfer(gomock.Any(), store.RefundTransferParams{TransferID: 2, Kind: domain.TransferKindREVERSAL}).Times(1).
+        Return(store.RefundTransferResult{}, errors.ErrRefundExceedsTransfer)
+    mockWalletRepo.EXPECT().GetWallet(gomock.Any(), int64(1)).Times(1).Return(domain.Wallet{ID: 1}, nil)
+
+    for i := 0; i < 4; i++ {
+        _, _ = walletRepo.SendMoney(context.Background(), store.SendMoneyParams{})
+    }
+    _, err := walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{TransferID: 1, Kind: domain.TransferKindREFUND})
+    require.NoError(t, err)
+    _, err = walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{TransferID: 2, Kind: domain.TransferKindREVERSAL})
+    require.EqualError(t, err, errors.ErrRefundExceedsTransfer.Error())
+
+    // passed through
+    wallet, err := walletRepo.GetWallet(context.Background(), 1)
+    require.NoError(t, err)
+    require.Equal(t, int64(1), wallet.ID)
+
+    require.Equal(t, 2.0, testutil.ToFloat64(m.Transfers.WithLabelValues("PAYMENT", "INR")))
+    require.Equal(t, 1000.0, testutil.ToFloat64(m.AmountMoved.WithLabelValues("PAYMENT", "INR")))
+    require.Equal(t, 1.0, testutil.ToFloat64(m.Transfers.WithLabelValues("REFUND", "INR")))
+    require.Equal(t, 200.0, testutil.ToFloat64(m.AmountMoved.WithLabelValues("REFUND", "INR")))
+    require.Equal(t, 1.0, testutil.ToFloat64(m.FailedTransfers.WithLabelValues("PAYMENT", "insufficient_balance")))
+    require.Equal(t, 1.0, testutil.ToFloat64(m.FailedTransfers.WithLabelValues("PAYMENT", "other")))
+    require.Equal(t, 1.0, testutil.ToFloat64(m.FailedTransfers.WithLabelValues("REVERSAL", "refund_exceeds_transfer")))
+}
+
+func TestPaymentRequestRepoWithMetrics(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    m := store.NewMetrics(prometheus.NewRegistry())
+    mockPaymentRequestRepo := mockdb.NewMockPaymentRequestRepo(ctrl)
+    paymentRequestRepo := store.NewPaymentRequestRepoWithMetrics(mockPaymentRequestRepo, m)
+
+    mockPaymentRequestRepo.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).
+        Return(domain.PaymentRequest{ID: 1, Status: domain.PaymentRequestStatusWAITINGAPPROVAL}, nil)
+    mockPaymentRequestRepo.EXPECT().UpdatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).
+        Return(domain.PaymentRequest{ID: 1, Status: domain.PaymentRequestStatusAPPROVED}, nil)
+    mockPaymentRequestRepo.EXPECT().UpdatePaymentRequest(gomock.Any(), gomock.Any()).Times(1).
+        Return(domain.PaymentRequest{}, fmt.Errorf("connection reset"))
+
+    _, err := paymentRequestRepo.CreatePaymentRequest(context.Background(), store.CreatePaymentRequestParams{})
+    require.NoError(t, err)
+    _, err = paymentRequestRepo.UpdatePaymentRequest(context.Background(), store.UpdatePaymentRequestParams{ID: 1, Status: domain.PaymentRequestStatusAPPROVED})
+    require.NoError(t, err)
+    _, err = paymentRequestRepo.UpdatePaymentRequest(context.Background(), store.UpdatePaymentRequestParams{ID: 1, Status: domain.PaymentRequestStatusPAYMENTSUCCESS})
+    require.Error(t, err)
+
+    require.Equal(t, 1.0, testutil.ToFloat64(m.PaymentRequestTransitions.WithLabelValues("WAITING_APPROVAL")))
+    require.Equal(t, 1.0, testutil.ToFloat64(m.PaymentRequestTransitions.WithLabelValues("APPROVED")))
+    require.Equal(t, 0.0, testutil.ToFloat64(m.PaymentRequestTransitions.WithLabelValues("PAYMENT_SUCCESS")))
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/bankbranch.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockBankBranchRepo is a mock of BankBranchRepo interface.
+type MockBankBranchRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankBranchRepoMockRecorder
+}
+
+// MockBankBranchRepoMockRecorder is the mock recorder for MockBankBranchRepo.
+type MockBankBranchRepoMockRecorder struct {
+	mock *MockBankBranchRepo
+}
+
+// NewMockBankBranchRepo creates a new mock instance.
+func NewMockBankBranchRepo(ctrl *gomock.Controller) *MockBankBranchRepo {
+	mock := &MockBankBranchRepo{ctrl: ctrl}
+	mock.recorder = &MockBankBranchRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankBranchRepo) EXPECT() *MockBankBranchRepoMockRecorder {
+	return m.recorder
+}
+
+// GetBankBranch mocks base method.
+func (m *MockBankBranchRepo) GetBankBranch(ctx context.Context, ifsc string) (domain.BankBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankBranch", ctx, ifsc)
+	ret0, _ := ret[0].(domain.BankBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankBranch indicates an expected call of GetBankBranch.
+func (mr *MockBankBranchRepoMockRecorder) GetBankBranch(ctx, ifsc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankBranch", reflect.TypeOf((*MockBankBranchRepo)(nil).GetBankBranch), ctx, ifsc)
+}
+
+// UpsertBankBranch mocks base method.
+func (m *MockBankBranchRepo) UpsertBankBranch(ctx context.Context, arg store.UpsertBankBranchParams) (domain.BankBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertBankBranch", ctx, arg)
+	ret0, _ := ret[0].(domain.BankBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertBankBranch indicates an expected call of UpsertBankBranch.
+func (mr *MockBankBranchRepoMockRecorder) UpsertBankBranch(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertBankBranch", reflect.TypeOf((*MockBankBranchRepo)(nil).UpsertBankBranch), ctx, arg)
+}
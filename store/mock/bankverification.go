@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/bankverification.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockBankVerificationRepo is a mock of BankVerificationRepo interface.
+type MockBankVerificationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBankVerificationRepoMockRecorder
+}
+
+// MockBankVerificationRepoMockRecorder is the mock recorder for MockBankVerificationRepo.
+type MockBankVerificationRepoMockRecorder struct {
+	mock *MockBankVerificationRepo
+}
+
+// NewMockBankVerificationRepo creates a new mock instance.
+func NewMockBankVerificationRepo(ctrl *gomock.Controller) *MockBankVerificationRepo {
+	mock := &MockBankVerificationRepo{ctrl: ctrl}
+	mock.recorder = &MockBankVerificationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBankVerificationRepo) EXPECT() *MockBankVerificationRepoMockRecorder {
+	return m.recorder
+}
+
+// CloseBankVerifications mocks base method.
+func (m *MockBankVerificationRepo) CloseBankVerifications(ctx context.Context, arg store.CloseBankVerificationsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseBankVerifications", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseBankVerifications indicates an expected call of CloseBankVerifications.
+func (mr *MockBankVerificationRepoMockRecorder) CloseBankVerifications(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseBankVerifications", reflect.TypeOf((*MockBankVerificationRepo)(nil).CloseBankVerifications), ctx, arg)
+}
+
+// CreateBankVerification mocks base method.
+func (m *MockBankVerificationRepo) CreateBankVerification(ctx context.Context, arg store.CreateBankVerificationParams) (domain.BankVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBankVerification", ctx, arg)
+	ret0, _ := ret[0].(domain.BankVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBankVerification indicates an expected call of CreateBankVerification.
+func (mr *MockBankVerificationRepoMockRecorder) CreateBankVerification(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBankVerification", reflect.TypeOf((*MockBankVerificationRepo)(nil).CreateBankVerification), ctx, arg)
+}
+
+// GetBankVerification mocks base method.
+func (m *MockBankVerificationRepo) GetBankVerification(ctx context.Context, id int64) (domain.BankVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBankVerification", ctx, id)
+	ret0, _ := ret[0].(domain.BankVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBankVerification indicates an expected call of GetBankVerification.
+func (mr *MockBankVerificationRepoMockRecorder) GetBankVerification(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBankVerification", reflect.TypeOf((*MockBankVerificationRepo)(nil).GetBankVerification), ctx, id)
+}
+
+// ListBankVerificationsByStatus mocks base method.
+func (m *MockBankVerificationRepo) ListBankVerificationsByStatus(ctx context.Context, arg store.ListBankVerificationsByStatusParams) ([]domain.BankVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBankVerificationsByStatus", ctx, arg)
+	ret0, _ := ret[0].([]domain.BankVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBankVerificationsByStatus indicates an expected call of ListBankVerificationsByStatus.
+func (mr *MockBankVerificationRepoMockRecorder) ListBankVerificationsByStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBankVerificationsByStatus", reflect.TypeOf((*MockBankVerificationRepo)(nil).ListBankVerificationsByStatus), ctx, arg)
+}
+
+// UpdateBankVerification mocks base method.
+func (m *MockBankVerificationRepo) UpdateBankVerification(ctx context.Context, arg store.UpdateBankVerificationParams) (domain.BankVerification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBankVerification", ctx, arg)
+	ret0, _ := ret[0].(domain.BankVerification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBankVerification indicates an expected call of UpdateBankVerification.
+func (mr *MockBankVerificationRepoMockRecorder) UpdateBankVerification(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBankVerification", reflect.TypeOf((*MockBankVerificationRepo)(nil).UpdateBankVerification), ctx, arg)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/checkoutsession.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockCheckoutSessionRepo is a mock of CheckoutSessionRepo interface.
+type MockCheckoutSessionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckoutSessionRepoMockRecorder
+}
+
+// MockCheckoutSessionRepoMockRecorder is the mock recorder for MockCheckoutSessionRepo.
+type MockCheckoutSessionRepoMockRecorder struct {
+	mock *MockCheckoutSessionRepo
+}
+
+// NewMockCheckoutSessionRepo creates a new mock instance.
+func NewMockCheckoutSessionRepo(ctrl *gomock.Controller) *MockCheckoutSessionRepo {
+	mock := &MockCheckoutSessionRepo{ctrl: ctrl}
+	mock.recorder = &MockCheckoutSessionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckoutSessionRepo) EXPECT() *MockCheckoutSessionRepoMockRecorder {
+	return m.recorder
+}
+
+// CompleteCheckoutSession mocks base method.
+func (m *MockCheckoutSessionRepo) CompleteCheckoutSession(ctx context.Context, arg store.CompleteCheckoutSessionParams) (store.CompleteCheckoutSessionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteCheckoutSession", ctx, arg)
+	ret0, _ := ret[0].(store.CompleteCheckoutSessionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteCheckoutSession indicates an expected call of CompleteCheckoutSession.
+func (mr *MockCheckoutSessionRepoMockRecorder) CompleteCheckoutSession(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteCheckoutSession", reflect.TypeOf((*MockCheckoutSessionRepo)(nil).CompleteCheckoutSession), ctx, arg)
+}
+
+// CreateCheckoutSession mocks base method.
+func (m *MockCheckoutSessionRepo) CreateCheckoutSession(ctx context.Context, arg store.CreateCheckoutSessionParams) (domain.CheckoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckoutSession", ctx, arg)
+	ret0, _ := ret[0].(domain.CheckoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckoutSession indicates an expected call of CreateCheckoutSession.
+func (mr *MockCheckoutSessionRepoMockRecorder) CreateCheckoutSession(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckoutSession", reflect.TypeOf((*MockCheckoutSessionRepo)(nil).CreateCheckoutSession), ctx, arg)
+}
+
+// GetCheckoutSession mocks base method.
+func (m *MockCheckoutSessionRepo) GetCheckoutSession(ctx context.Context, id int64) (domain.CheckoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckoutSession", ctx, id)
+	ret0, _ := ret[0].(domain.CheckoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckoutSession indicates an expected call of GetCheckoutSession.
+func (mr *MockCheckoutSessionRepoMockRecorder) GetCheckoutSession(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckoutSession", reflect.TypeOf((*MockCheckoutSessionRepo)(nil).GetCheckoutSession), ctx, id)
+}
+
+// GetCheckoutSessionForUpdate mocks base method.
+func (m *MockCheckoutSessionRepo) GetCheckoutSessionForUpdate(ctx context.Context, id int64) (domain.CheckoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckoutSessionForUpdate", ctx, id)
+	ret0, _ := ret[0].(domain.CheckoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckoutSessionForUpdate indicates an expected call of GetCheckoutSessionForUpdate.
+func (mr *MockCheckoutSessionRepoMockRecorder) GetCheckoutSessionForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckoutSessionForUpdate", reflect.TypeOf((*MockCheckoutSessionRepo)(nil).GetCheckoutSessionForUpdate), ctx, id)
+}
+
+// UpdateCheckoutSession mocks base method.
+func (m *MockCheckoutSessionRepo) UpdateCheckoutSession(ctx context.Context, arg store.UpdateCheckoutSessionParams) (domain.CheckoutSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheckoutSession", ctx, arg)
+	ret0, _ := ret[0].(domain.CheckoutSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCheckoutSession indicates an expected call of UpdateCheckoutSession.
+func (mr *MockCheckoutSessionRepoMockRecorder) UpdateCheckoutSession(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheckoutSession", reflect.TypeOf((*MockCheckoutSessionRepo)(nil).UpdateCheckoutSession), ctx, arg)
+}
@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/escrow.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockEscrowRepo is a mock of EscrowRepo interface.
+type MockEscrowRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscrowRepoMockRecorder
+}
+
+// MockEscrowRepoMockRecorder is the mock recorder for MockEscrowRepo.
+type MockEscrowRepoMockRecorder struct {
+	mock *MockEscrowRepo
+}
+
+// NewMockEscrowRepo creates a new mock instance.
+func NewMockEscrowRepo(ctrl *gomock.Controller) *MockEscrowRepo {
+	mock := &MockEscrowRepo{ctrl: ctrl}
+	mock.recorder = &MockEscrowRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscrowRepo) EXPECT() *MockEscrowRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateEscrow mocks base method.
+func (m *MockEscrowRepo) CreateEscrow(ctx context.Context, arg store.CreateEscrowParams) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrow", ctx, arg)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrow indicates an expected call of CreateEscrow.
+func (mr *MockEscrowRepoMockRecorder) CreateEscrow(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrow", reflect.TypeOf((*MockEscrowRepo)(nil).CreateEscrow), ctx, arg)
+}
+
+// DisputeEscrow mocks base method.
+func (m *MockEscrowRepo) DisputeEscrow(ctx context.Context, arg store.DisputeEscrowParams) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisputeEscrow", ctx, arg)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisputeEscrow indicates an expected call of DisputeEscrow.
+func (mr *MockEscrowRepoMockRecorder) DisputeEscrow(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisputeEscrow", reflect.TypeOf((*MockEscrowRepo)(nil).DisputeEscrow), ctx, arg)
+}
+
+// FailEscrowRelease mocks base method.
+func (m *MockEscrowRepo) FailEscrowRelease(ctx context.Context, arg store.FailEscrowReleaseParams) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailEscrowRelease", ctx, arg)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailEscrowRelease indicates an expected call of FailEscrowRelease.
+func (mr *MockEscrowRepoMockRecorder) FailEscrowRelease(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailEscrowRelease", reflect.TypeOf((*MockEscrowRepo)(nil).FailEscrowRelease), ctx, arg)
+}
+
+// FundEscrow mocks base method.
+func (m *MockEscrowRepo) FundEscrow(ctx context.Context, arg store.FundEscrowParams) (store.EscrowTransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FundEscrow", ctx, arg)
+	ret0, _ := ret[0].(store.EscrowTransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FundEscrow indicates an expected call of FundEscrow.
+func (mr *MockEscrowRepoMockRecorder) FundEscrow(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FundEscrow", reflect.TypeOf((*MockEscrowRepo)(nil).FundEscrow), ctx, arg)
+}
+
+// GetEscrow mocks base method.
+func (m *MockEscrowRepo) GetEscrow(ctx context.Context, id int64) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", ctx, id)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrow indicates an expected call of GetEscrow.
+func (mr *MockEscrowRepoMockRecorder) GetEscrow(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockEscrowRepo)(nil).GetEscrow), ctx, id)
+}
+
+// GetEscrowForUpdate mocks base method.
+func (m *MockEscrowRepo) GetEscrowForUpdate(ctx context.Context, id int64) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrowForUpdate", ctx, id)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowForUpdate indicates an expected call of GetEscrowForUpdate.
+func (mr *MockEscrowRepoMockRecorder) GetEscrowForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowForUpdate", reflect.TypeOf((*MockEscrowRepo)(nil).GetEscrowForUpdate), ctx, id)
+}
+
+// ListDueEscrows mocks base method.
+func (m *MockEscrowRepo) ListDueEscrows(ctx context.Context, limit int32) ([]domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueEscrows", ctx, limit)
+	ret0, _ := ret[0].([]domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueEscrows indicates an expected call of ListDueEscrows.
+func (mr *MockEscrowRepoMockRecorder) ListDueEscrows(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueEscrows", reflect.TypeOf((*MockEscrowRepo)(nil).ListDueEscrows), ctx, limit)
+}
+
+// ListEscrows mocks base method.
+func (m *MockEscrowRepo) ListEscrows(ctx context.Context, arg store.ListEscrowsParams) ([]domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEscrows", ctx, arg)
+	ret0, _ := ret[0].([]domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEscrows indicates an expected call of ListEscrows.
+func (mr *MockEscrowRepoMockRecorder) ListEscrows(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEscrows", reflect.TypeOf((*MockEscrowRepo)(nil).ListEscrows), ctx, arg)
+}
+
+// SettleEscrow mocks base method.
+func (m *MockEscrowRepo) SettleEscrow(ctx context.Context, arg store.SettleEscrowParams) (store.EscrowTransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleEscrow", ctx, arg)
+	ret0, _ := ret[0].(store.EscrowTransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleEscrow indicates an expected call of SettleEscrow.
+func (mr *MockEscrowRepoMockRecorder) SettleEscrow(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleEscrow", reflect.TypeOf((*MockEscrowRepo)(nil).SettleEscrow), ctx, arg)
+}
+
+// UpdateEscrowStatus mocks base method.
+func (m *MockEscrowRepo) UpdateEscrowStatus(ctx context.Context, arg store.UpdateEscrowStatusParams) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEscrowStatus", ctx, arg)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEscrowStatus indicates an expected call of UpdateEscrowStatus.
+func (mr *MockEscrowRepoMockRecorder) UpdateEscrowStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEscrowStatus", reflect.TypeOf((*MockEscrowRepo)(nil).UpdateEscrowStatus), ctx, arg)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/health.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockHealthRepo is a mock of HealthRepo interface.
+type MockHealthRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHealthRepoMockRecorder
+}
+
+// MockHealthRepoMockRecorder is the mock recorder for MockHealthRepo.
+type MockHealthRepoMockRecorder struct {
+	mock *MockHealthRepo
+}
+
+// NewMockHealthRepo creates a new mock instance.
+func NewMockHealthRepo(ctrl *gomock.Controller) *MockHealthRepo {
+	mock := &MockHealthRepo{ctrl: ctrl}
+	mock.recorder = &MockHealthRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHealthRepo) EXPECT() *MockHealthRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSchemaVersion mocks base method.
+func (m *MockHealthRepo) GetSchemaVersion(ctx context.Context) (store.SchemaVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchemaVersion", ctx)
+	ret0, _ := ret[0].(store.SchemaVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchemaVersion indicates an expected call of GetSchemaVersion.
+func (mr *MockHealthRepoMockRecorder) GetSchemaVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchemaVersion", reflect.TypeOf((*MockHealthRepo)(nil).GetSchemaVersion), ctx)
+}
+
+// ListCurrenciesWithoutOrganizationWallet mocks base method.
+func (m *MockHealthRepo) ListCurrenciesWithoutOrganizationWallet(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrenciesWithoutOrganizationWallet", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrenciesWithoutOrganizationWallet indicates an expected call of ListCurrenciesWithoutOrganizationWallet.
+func (mr *MockHealthRepoMockRecorder) ListCurrenciesWithoutOrganizationWallet(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrenciesWithoutOrganizationWallet", reflect.TypeOf((*MockHealthRepo)(nil).ListCurrenciesWithoutOrganizationWallet), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockHealthRepo) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockHealthRepoMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockHealthRepo)(nil).Ping), ctx)
+}
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/hold.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockHoldRepo is a mock of HoldRepo interface.
+type MockHoldRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepoMockRecorder
+}
+
+// MockHoldRepoMockRecorder is the mock recorder for MockHoldRepo.
+type MockHoldRepoMockRecorder struct {
+	mock *MockHoldRepo
+}
+
+// NewMockHoldRepo creates a new mock instance.
+func NewMockHoldRepo(ctrl *gomock.Controller) *MockHoldRepo {
+	mock := &MockHoldRepo{ctrl: ctrl}
+	mock.recorder = &MockHoldRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepo) EXPECT() *MockHoldRepoMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeHold mocks base method.
+func (m *MockHoldRepo) AuthorizeHold(ctx context.Context, arg store.CreateHoldParams) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeHold", ctx, arg)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeHold indicates an expected call of AuthorizeHold.
+func (mr *MockHoldRepoMockRecorder) AuthorizeHold(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeHold", reflect.TypeOf((*MockHoldRepo)(nil).AuthorizeHold), ctx, arg)
+}
+
+// CaptureHold mocks base method.
+func (m *MockHoldRepo) CaptureHold(ctx context.Context, arg store.CaptureHoldParams) (store.CaptureHoldResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, arg)
+	ret0, _ := ret[0].(store.CaptureHoldResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockHoldRepoMockRecorder) CaptureHold(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockHoldRepo)(nil).CaptureHold), ctx, arg)
+}
+
+// CreateHold mocks base method.
+func (m *MockHoldRepo) CreateHold(ctx context.Context, arg store.CreateHoldParams) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, arg)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockHoldRepoMockRecorder) CreateHold(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockHoldRepo)(nil).CreateHold), ctx, arg)
+}
+
+// GetHold mocks base method.
+func (m *MockHoldRepo) GetHold(ctx context.Context, id int64) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, id)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockHoldRepoMockRecorder) GetHold(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockHoldRepo)(nil).GetHold), ctx, id)
+}
+
+// GetHoldForUpdate mocks base method.
+func (m *MockHoldRepo) GetHoldForUpdate(ctx context.Context, id int64) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldForUpdate", ctx, id)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldForUpdate indicates an expected call of GetHoldForUpdate.
+func (mr *MockHoldRepoMockRecorder) GetHoldForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldForUpdate", reflect.TypeOf((*MockHoldRepo)(nil).GetHoldForUpdate), ctx, id)
+}
+
+// ListExpiredHolds mocks base method.
+func (m *MockHoldRepo) ListExpiredHolds(ctx context.Context, limit int32) ([]domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredHolds", ctx, limit)
+	ret0, _ := ret[0].([]domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredHolds indicates an expected call of ListExpiredHolds.
+func (mr *MockHoldRepoMockRecorder) ListExpiredHolds(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredHolds", reflect.TypeOf((*MockHoldRepo)(nil).ListExpiredHolds), ctx, limit)
+}
+
+// ListHolds mocks base method.
+func (m *MockHoldRepo) ListHolds(ctx context.Context, arg store.ListHoldsParams) ([]domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolds", ctx, arg)
+	ret0, _ := ret[0].([]domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolds indicates an expected call of ListHolds.
+func (mr *MockHoldRepoMockRecorder) ListHolds(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolds", reflect.TypeOf((*MockHoldRepo)(nil).ListHolds), ctx, arg)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockHoldRepo) ReleaseHold(ctx context.Context, arg store.ReleaseHoldParams) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, arg)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockHoldRepoMockRecorder) ReleaseHold(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockHoldRepo)(nil).ReleaseHold), ctx, arg)
+}
+
+// UpdateHold mocks base method.
+func (m *MockHoldRepo) UpdateHold(ctx context.Context, arg store.UpdateHoldParams) (domain.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHold", ctx, arg)
+	ret0, _ := ret[0].(domain.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHold indicates an expected call of UpdateHold.
+func (mr *MockHoldRepoMockRecorder) UpdateHold(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHold", reflect.TypeOf((*MockHoldRepo)(nil).UpdateHold), ctx, arg)
+}
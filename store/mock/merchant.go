@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/merchant.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockMerchantRepo is a mock of MerchantRepo interface.
+type MockMerchantRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantRepoMockRecorder
+}
+
+// MockMerchantRepoMockRecorder is the mock recorder for MockMerchantRepo.
+type MockMerchantRepoMockRecorder struct {
+	mock *MockMerchantRepo
+}
+
+// NewMockMerchantRepo creates a new mock instance.
+func NewMockMerchantRepo(ctrl *gomock.Controller) *MockMerchantRepo {
+	mock := &MockMerchantRepo{ctrl: ctrl}
+	mock.recorder = &MockMerchantRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantRepo) EXPECT() *MockMerchantRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateMerchant mocks base method.
+func (m *MockMerchantRepo) CreateMerchant(ctx context.Context, arg store.CreateMerchantParams) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", ctx, arg)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockMerchantRepoMockRecorder) CreateMerchant(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockMerchantRepo)(nil).CreateMerchant), ctx, arg)
+}
+
+// GetMerchant mocks base method.
+func (m *MockMerchantRepo) GetMerchant(ctx context.Context, id int64) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, id)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockMerchantRepoMockRecorder) GetMerchant(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockMerchantRepo)(nil).GetMerchant), ctx, id)
+}
+
+// GetMerchantByUserID mocks base method.
+func (m *MockMerchantRepo) GetMerchantByUserID(ctx context.Context, userID int64) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantByUserID", ctx, userID)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantByUserID indicates an expected call of GetMerchantByUserID.
+func (mr *MockMerchantRepoMockRecorder) GetMerchantByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantByUserID", reflect.TypeOf((*MockMerchantRepo)(nil).GetMerchantByUserID), ctx, userID)
+}
+
+// UpdateMerchant mocks base method.
+func (m *MockMerchantRepo) UpdateMerchant(ctx context.Context, arg store.UpdateMerchantParams) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMerchant", ctx, arg)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMerchant indicates an expected call of UpdateMerchant.
+func (mr *MockMerchantRepoMockRecorder) UpdateMerchant(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMerchant", reflect.TypeOf((*MockMerchantRepo)(nil).UpdateMerchant), ctx, arg)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/notificationpreference.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockNotificationPreferenceRepo is a mock of NotificationPreferenceRepo interface.
+type MockNotificationPreferenceRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPreferenceRepoMockRecorder
+}
+
+// MockNotificationPreferenceRepoMockRecorder is the mock recorder for MockNotificationPreferenceRepo.
+type MockNotificationPreferenceRepoMockRecorder struct {
+	mock *MockNotificationPreferenceRepo
+}
+
+// NewMockNotificationPreferenceRepo creates a new mock instance.
+func NewMockNotificationPreferenceRepo(ctrl *gomock.Controller) *MockNotificationPreferenceRepo {
+	mock := &MockNotificationPreferenceRepo{ctrl: ctrl}
+	mock.recorder = &MockNotificationPreferenceRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPreferenceRepo) EXPECT() *MockNotificationPreferenceRepoMockRecorder {
+	return m.recorder
+}
+
+// ListNotificationPreferences mocks base method.
+func (m *MockNotificationPreferenceRepo) ListNotificationPreferences(ctx context.Context, userID int64) ([]domain.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationPreferences", ctx, userID)
+	ret0, _ := ret[0].([]domain.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationPreferences indicates an expected call of ListNotificationPreferences.
+func (mr *MockNotificationPreferenceRepoMockRecorder) ListNotificationPreferences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationPreferences", reflect.TypeOf((*MockNotificationPreferenceRepo)(nil).ListNotificationPreferences), ctx, userID)
+}
+
+// UpsertNotificationPreference mocks base method.
+func (m *MockNotificationPreferenceRepo) UpsertNotificationPreference(ctx context.Context, arg store.UpsertNotificationPreferenceParams) (domain.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertNotificationPreference", ctx, arg)
+	ret0, _ := ret[0].(domain.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertNotificationPreference indicates an expected call of UpsertNotificationPreference.
+func (mr *MockNotificationPreferenceRepoMockRecorder) UpsertNotificationPreference(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertNotificationPreference", reflect.TypeOf((*MockNotificationPreferenceRepo)(nil).UpsertNotificationPreference), ctx, arg)
+}
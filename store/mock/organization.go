@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/organization.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockOrganizationRepo is a mock of OrganizationRepo interface.
+type MockOrganizationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepoMockRecorder
+}
+
+// MockOrganizationRepoMockRecorder is the mock recorder for MockOrganizationRepo.
+type MockOrganizationRepoMockRecorder struct {
+	mock *MockOrganizationRepo
+}
+
+// NewMockOrganizationRepo creates a new mock instance.
+func NewMockOrganizationRepo(ctrl *gomock.Controller) *MockOrganizationRepo {
+	mock := &MockOrganizationRepo{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepo) EXPECT() *MockOrganizationRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateCurrencyWithWallets mocks base method.
+func (m *MockOrganizationRepo) CreateCurrencyWithWallets(ctx context.Context, arg store.CreateCurrencyWithWalletsParams) (store.CurrencyWithWalletsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCurrencyWithWallets", ctx, arg)
+	ret0, _ := ret[0].(store.CurrencyWithWalletsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCurrencyWithWallets indicates an expected call of CreateCurrencyWithWallets.
+func (mr *MockOrganizationRepoMockRecorder) CreateCurrencyWithWallets(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCurrencyWithWallets", reflect.TypeOf((*MockOrganizationRepo)(nil).CreateCurrencyWithWallets), ctx, arg)
+}
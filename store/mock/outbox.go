@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/outbox.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockOutboxRepo is a mock of OutboxRepo interface.
+type MockOutboxRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepoMockRecorder
+}
+
+// MockOutboxRepoMockRecorder is the mock recorder for MockOutboxRepo.
+type MockOutboxRepoMockRecorder struct {
+	mock *MockOutboxRepo
+}
+
+// NewMockOutboxRepo creates a new mock instance.
+func NewMockOutboxRepo(ctrl *gomock.Controller) *MockOutboxRepo {
+	mock := &MockOutboxRepo{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepo) EXPECT() *MockOutboxRepoMockRecorder {
+	return m.recorder
+}
+
+// CountDeadOutboxEvents mocks base method.
+func (m *MockOutboxRepo) CountDeadOutboxEvents(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeadOutboxEvents", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeadOutboxEvents indicates an expected call of CountDeadOutboxEvents.
+func (mr *MockOutboxRepoMockRecorder) CountDeadOutboxEvents(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeadOutboxEvents", reflect.TypeOf((*MockOutboxRepo)(nil).CountDeadOutboxEvents), ctx)
+}
+
+// CreateOutboxEvent mocks base method.
+func (m *MockOutboxRepo) CreateOutboxEvent(ctx context.Context, arg store.CreateOutboxEventParams) (domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", ctx, arg)
+	ret0, _ := ret[0].(domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockOutboxRepoMockRecorder) CreateOutboxEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockOutboxRepo)(nil).CreateOutboxEvent), ctx, arg)
+}
+
+// ListDeadOutboxEvents mocks base method.
+func (m *MockOutboxRepo) ListDeadOutboxEvents(ctx context.Context, arg store.ListDeadOutboxEventsParams) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeadOutboxEvents", ctx, arg)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeadOutboxEvents indicates an expected call of ListDeadOutboxEvents.
+func (mr *MockOutboxRepoMockRecorder) ListDeadOutboxEvents(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeadOutboxEvents", reflect.TypeOf((*MockOutboxRepo)(nil).ListDeadOutboxEvents), ctx, arg)
+}
+
+// ListUnpublishedOutboxEvents mocks base method.
+func (m *MockOutboxRepo) ListUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnpublishedOutboxEvents", ctx, limit)
+	ret0, _ := ret[0].([]domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnpublishedOutboxEvents indicates an expected call of ListUnpublishedOutboxEvents.
+func (mr *MockOutboxRepoMockRecorder) ListUnpublishedOutboxEvents(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpublishedOutboxEvents", reflect.TypeOf((*MockOutboxRepo)(nil).ListUnpublishedOutboxEvents), ctx, limit)
+}
+
+// MarkOutboxEventPublished mocks base method.
+func (m *MockOutboxRepo) MarkOutboxEventPublished(ctx context.Context, id int64) (domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventPublished", ctx, id)
+	ret0, _ := ret[0].(domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOutboxEventPublished indicates an expected call of MarkOutboxEventPublished.
+func (mr *MockOutboxRepoMockRecorder) MarkOutboxEventPublished(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventPublished", reflect.TypeOf((*MockOutboxRepo)(nil).MarkOutboxEventPublished), ctx, id)
+}
+
+// RelayOutboxEvents mocks base method.
+func (m *MockOutboxRepo) RelayOutboxEvents(ctx context.Context, arg store.RelayOutboxEventsParams, publish store.PublishOutboxEventFn) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayOutboxEvents", ctx, arg, publish)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayOutboxEvents indicates an expected call of RelayOutboxEvents.
+func (mr *MockOutboxRepoMockRecorder) RelayOutboxEvents(ctx, arg, publish interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayOutboxEvents", reflect.TypeOf((*MockOutboxRepo)(nil).RelayOutboxEvents), ctx, arg, publish)
+}
+
+// RetryOutboxEvent mocks base method.
+func (m *MockOutboxRepo) RetryOutboxEvent(ctx context.Context, id int64) (domain.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryOutboxEvent", ctx, id)
+	ret0, _ := ret[0].(domain.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryOutboxEvent indicates an expected call of RetryOutboxEvent.
+func (mr *MockOutboxRepoMockRecorder) RetryOutboxEvent(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryOutboxEvent", reflect.TypeOf((*MockOutboxRepo)(nil).RetryOutboxEvent), ctx, id)
+}
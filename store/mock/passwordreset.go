@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/passwordreset.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockPasswordResetRepo is a mock of PasswordResetRepo interface.
+type MockPasswordResetRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetRepoMockRecorder
+}
+
+// MockPasswordResetRepoMockRecorder is the mock recorder for MockPasswordResetRepo.
+type MockPasswordResetRepoMockRecorder struct {
+	mock *MockPasswordResetRepo
+}
+
+// NewMockPasswordResetRepo creates a new mock instance.
+func NewMockPasswordResetRepo(ctrl *gomock.Controller) *MockPasswordResetRepo {
+	mock := &MockPasswordResetRepo{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetRepo) EXPECT() *MockPasswordResetRepoMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPasswordResetRepo) ChangePassword(ctx context.Context, arg store.ChangePasswordParams) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, arg)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPasswordResetRepoMockRecorder) ChangePassword(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPasswordResetRepo)(nil).ChangePassword), ctx, arg)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *MockPasswordResetRepo) CreatePasswordReset(ctx context.Context, arg store.CreatePasswordResetParams) (domain.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(domain.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockPasswordResetRepoMockRecorder) CreatePasswordReset(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*MockPasswordResetRepo)(nil).CreatePasswordReset), ctx, arg)
+}
+
+// GetPasswordResetByHashedToken mocks base method.
+func (m *MockPasswordResetRepo) GetPasswordResetByHashedToken(ctx context.Context, hashedToken string) (domain.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordResetByHashedToken", ctx, hashedToken)
+	ret0, _ := ret[0].(domain.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordResetByHashedToken indicates an expected call of GetPasswordResetByHashedToken.
+func (mr *MockPasswordResetRepoMockRecorder) GetPasswordResetByHashedToken(ctx, hashedToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordResetByHashedToken", reflect.TypeOf((*MockPasswordResetRepo)(nil).GetPasswordResetByHashedToken), ctx, hashedToken)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordResetRepo) ResetPassword(ctx context.Context, arg store.ResetPasswordParams) (domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, arg)
+	ret0, _ := ret[0].(domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordResetRepoMockRecorder) ResetPassword(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordResetRepo)(nil).ResetPassword), ctx, arg)
+}
+
+// UsePasswordReset mocks base method.
+func (m *MockPasswordResetRepo) UsePasswordReset(ctx context.Context, arg store.UsePasswordResetParams) (domain.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordReset", ctx, arg)
+	ret0, _ := ret[0].(domain.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsePasswordReset indicates an expected call of UsePasswordReset.
+func (mr *MockPasswordResetRepoMockRecorder) UsePasswordReset(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordReset", reflect.TypeOf((*MockPasswordResetRepo)(nil).UsePasswordReset), ctx, arg)
+}
+
+// UsePasswordResetsOfUser mocks base method.
+func (m *MockPasswordResetRepo) UsePasswordResetsOfUser(ctx context.Context, arg store.UsePasswordResetsOfUserParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsePasswordResetsOfUser", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UsePasswordResetsOfUser indicates an expected call of UsePasswordResetsOfUser.
+func (mr *MockPasswordResetRepoMockRecorder) UsePasswordResetsOfUser(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsePasswordResetsOfUser", reflect.TypeOf((*MockPasswordResetRepo)(nil).UsePasswordResetsOfUser), ctx, arg)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/paymentlink.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockPaymentLinkRepo is a mock of PaymentLinkRepo interface.
+type MockPaymentLinkRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentLinkRepoMockRecorder
+}
+
+// MockPaymentLinkRepoMockRecorder is the mock recorder for MockPaymentLinkRepo.
+type MockPaymentLinkRepoMockRecorder struct {
+	mock *MockPaymentLinkRepo
+}
+
+// NewMockPaymentLinkRepo creates a new mock instance.
+func NewMockPaymentLinkRepo(ctrl *gomock.Controller) *MockPaymentLinkRepo {
+	mock := &MockPaymentLinkRepo{ctrl: ctrl}
+	mock.recorder = &MockPaymentLinkRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentLinkRepo) EXPECT() *MockPaymentLinkRepoMockRecorder {
+	return m.recorder
+}
+
+// AddPaymentLinkUse mocks base method.
+func (m *MockPaymentLinkRepo) AddPaymentLinkUse(ctx context.Context, id int64) (domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPaymentLinkUse", ctx, id)
+	ret0, _ := ret[0].(domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPaymentLinkUse indicates an expected call of AddPaymentLinkUse.
+func (mr *MockPaymentLinkRepoMockRecorder) AddPaymentLinkUse(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPaymentLinkUse", reflect.TypeOf((*MockPaymentLinkRepo)(nil).AddPaymentLinkUse), ctx, id)
+}
+
+// CreatePaymentLink mocks base method.
+func (m *MockPaymentLinkRepo) CreatePaymentLink(ctx context.Context, arg store.CreatePaymentLinkParams) (domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentLink", ctx, arg)
+	ret0, _ := ret[0].(domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentLink indicates an expected call of CreatePaymentLink.
+func (mr *MockPaymentLinkRepoMockRecorder) CreatePaymentLink(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentLink", reflect.TypeOf((*MockPaymentLinkRepo)(nil).CreatePaymentLink), ctx, arg)
+}
+
+// GetPaymentLink mocks base method.
+func (m *MockPaymentLinkRepo) GetPaymentLink(ctx context.Context, id int64) (domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentLink", ctx, id)
+	ret0, _ := ret[0].(domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentLink indicates an expected call of GetPaymentLink.
+func (mr *MockPaymentLinkRepoMockRecorder) GetPaymentLink(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentLink", reflect.TypeOf((*MockPaymentLinkRepo)(nil).GetPaymentLink), ctx, id)
+}
+
+// GetPaymentLinkBySlug mocks base method.
+func (m *MockPaymentLinkRepo) GetPaymentLinkBySlug(ctx context.Context, slug string) (domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentLinkBySlug", ctx, slug)
+	ret0, _ := ret[0].(domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentLinkBySlug indicates an expected call of GetPaymentLinkBySlug.
+func (mr *MockPaymentLinkRepoMockRecorder) GetPaymentLinkBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentLinkBySlug", reflect.TypeOf((*MockPaymentLinkRepo)(nil).GetPaymentLinkBySlug), ctx, slug)
+}
+
+// GetPaymentLinkForUpdate mocks base method.
+func (m *MockPaymentLinkRepo) GetPaymentLinkForUpdate(ctx context.Context, id int64) (domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentLinkForUpdate", ctx, id)
+	ret0, _ := ret[0].(domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentLinkForUpdate indicates an expected call of GetPaymentLinkForUpdate.
+func (mr *MockPaymentLinkRepoMockRecorder) GetPaymentLinkForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentLinkForUpdate", reflect.TypeOf((*MockPaymentLinkRepo)(nil).GetPaymentLinkForUpdate), ctx, id)
+}
+
+// ListPaymentLinks mocks base method.
+func (m *MockPaymentLinkRepo) ListPaymentLinks(ctx context.Context, arg store.ListPaymentLinksParams) ([]domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentLinks", ctx, arg)
+	ret0, _ := ret[0].([]domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentLinks indicates an expected call of ListPaymentLinks.
+func (mr *MockPaymentLinkRepoMockRecorder) ListPaymentLinks(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentLinks", reflect.TypeOf((*MockPaymentLinkRepo)(nil).ListPaymentLinks), ctx, arg)
+}
+
+// UpdatePaymentLinkStatus mocks base method.
+func (m *MockPaymentLinkRepo) UpdatePaymentLinkStatus(ctx context.Context, arg store.UpdatePaymentLinkStatusParams) (domain.PaymentLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentLinkStatus", ctx, arg)
+	ret0, _ := ret[0].(domain.PaymentLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentLinkStatus indicates an expected call of UpdatePaymentLinkStatus.
+func (mr *MockPaymentLinkRepoMockRecorder) UpdatePaymentLinkStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentLinkStatus", reflect.TypeOf((*MockPaymentLinkRepo)(nil).UpdatePaymentLinkStatus), ctx, arg)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/paymentrequest.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockPaymentRequestRepo is a mock of PaymentRequestRepo interface.
+type MockPaymentRequestRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRequestRepoMockRecorder
+}
+
+// MockPaymentRequestRepoMockRecorder is the mock recorder for MockPaymentRequestRepo.
+type MockPaymentRequestRepoMockRecorder struct {
+	mock *MockPaymentRequestRepo
+}
+
+// NewMockPaymentRequestRepo creates a new mock instance.
+func NewMockPaymentRequestRepo(ctrl *gomock.Controller) *MockPaymentRequestRepo {
+	mock := &MockPaymentRequestRepo{ctrl: ctrl}
+	mock.recorder = &MockPaymentRequestRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRequestRepo) EXPECT() *MockPaymentRequestRepoMockRecorder {
+	return m.recorder
+}
+
+// ApprovePaymentRequest mocks base method.
+func (m *MockPaymentRequestRepo) ApprovePaymentRequest(ctx context.Context, id int64) (domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApprovePaymentRequest", ctx, id)
+	ret0, _ := ret[0].(domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApprovePaymentRequest indicates an expected call of ApprovePaymentRequest.
+func (mr *MockPaymentRequestRepoMockRecorder) ApprovePaymentRequest(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApprovePaymentRequest", reflect.TypeOf((*MockPaymentRequestRepo)(nil).ApprovePaymentRequest), ctx, id)
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockPaymentRequestRepo) CreatePaymentRequest(ctx context.Context, arg store.CreatePaymentRequestParams) (domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockPaymentRequestRepoMockRecorder) CreatePaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockPaymentRequestRepo)(nil).CreatePaymentRequest), ctx, arg)
+}
+
+// GetPaymentRequest mocks base method.
+func (m *MockPaymentRequestRepo) GetPaymentRequest(ctx context.Context, id int64) (domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequest", ctx, id)
+	ret0, _ := ret[0].(domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequest indicates an expected call of GetPaymentRequest.
+func (mr *MockPaymentRequestRepoMockRecorder) GetPaymentRequest(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequest", reflect.TypeOf((*MockPaymentRequestRepo)(nil).GetPaymentRequest), ctx, id)
+}
+
+// ListPaymentRequests mocks base method.
+func (m *MockPaymentRequestRepo) ListPaymentRequests(ctx context.Context, arg store.ListPaymentRequestsParams) ([]domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPaymentRequests", ctx, arg)
+	ret0, _ := ret[0].([]domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPaymentRequests indicates an expected call of ListPaymentRequests.
+func (mr *MockPaymentRequestRepoMockRecorder) ListPaymentRequests(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPaymentRequests", reflect.TypeOf((*MockPaymentRequestRepo)(nil).ListPaymentRequests), ctx, arg)
+}
+
+// UpdatePaymentRequest mocks base method.
+func (m *MockPaymentRequestRepo) UpdatePaymentRequest(ctx context.Context, arg store.UpdatePaymentRequestParams) (domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentRequest", ctx, arg)
+	ret0, _ := ret[0].(domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentRequest indicates an expected call of UpdatePaymentRequest.
+func (mr *MockPaymentRequestRepoMockRecorder) UpdatePaymentRequest(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentRequest", reflect.TypeOf((*MockPaymentRequestRepo)(nil).UpdatePaymentRequest), ctx, arg)
+}
@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/reconciliation.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockReconciliationRepo is a mock of ReconciliationRepo interface.
+type MockReconciliationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationRepoMockRecorder
+}
+
+// MockReconciliationRepoMockRecorder is the mock recorder for MockReconciliationRepo.
+type MockReconciliationRepoMockRecorder struct {
+	mock *MockReconciliationRepo
+}
+
+// NewMockReconciliationRepo creates a new mock instance.
+func NewMockReconciliationRepo(ctrl *gomock.Controller) *MockReconciliationRepo {
+	mock := &MockReconciliationRepo{ctrl: ctrl}
+	mock.recorder = &MockReconciliationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationRepo) EXPECT() *MockReconciliationRepoMockRecorder {
+	return m.recorder
+}
+
+// CountTransfers mocks base method.
+func (m *MockReconciliationRepo) CountTransfers(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransfers", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransfers indicates an expected call of CountTransfers.
+func (mr *MockReconciliationRepoMockRecorder) CountTransfers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransfers", reflect.TypeOf((*MockReconciliationRepo)(nil).CountTransfers), ctx)
+}
+
+// CreateReconciliationDiscrepancy mocks base method.
+func (m *MockReconciliationRepo) CreateReconciliationDiscrepancy(ctx context.Context, arg store.CreateReconciliationDiscrepancyParams) (domain.ReconciliationDiscrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReconciliationDiscrepancy", ctx, arg)
+	ret0, _ := ret[0].(domain.ReconciliationDiscrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReconciliationDiscrepancy indicates an expected call of CreateReconciliationDiscrepancy.
+func (mr *MockReconciliationRepoMockRecorder) CreateReconciliationDiscrepancy(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReconciliationDiscrepancy", reflect.TypeOf((*MockReconciliationRepo)(nil).CreateReconciliationDiscrepancy), ctx, arg)
+}
+
+// CreateReconciliationReport mocks base method.
+func (m *MockReconciliationRepo) CreateReconciliationReport(ctx context.Context, arg store.CreateReconciliationReportParams) (domain.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReconciliationReport", ctx, arg)
+	ret0, _ := ret[0].(domain.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReconciliationReport indicates an expected call of CreateReconciliationReport.
+func (mr *MockReconciliationRepoMockRecorder) CreateReconciliationReport(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReconciliationReport", reflect.TypeOf((*MockReconciliationRepo)(nil).CreateReconciliationReport), ctx, arg)
+}
+
+// GetReconciliationReport mocks base method.
+func (m *MockReconciliationRepo) GetReconciliationReport(ctx context.Context, id int64) (domain.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconciliationReport", ctx, id)
+	ret0, _ := ret[0].(domain.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReconciliationReport indicates an expected call of GetReconciliationReport.
+func (mr *MockReconciliationRepoMockRecorder) GetReconciliationReport(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconciliationReport", reflect.TypeOf((*MockReconciliationRepo)(nil).GetReconciliationReport), ctx, id)
+}
+
+// ListCurrencyTotals mocks base method.
+func (m *MockReconciliationRepo) ListCurrencyTotals(ctx context.Context) ([]store.CurrencyTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencyTotals", ctx)
+	ret0, _ := ret[0].([]store.CurrencyTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencyTotals indicates an expected call of ListCurrencyTotals.
+func (mr *MockReconciliationRepoMockRecorder) ListCurrencyTotals(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencyTotals", reflect.TypeOf((*MockReconciliationRepo)(nil).ListCurrencyTotals), ctx)
+}
+
+// ListReconciliationDiscrepancies mocks base method.
+func (m *MockReconciliationRepo) ListReconciliationDiscrepancies(ctx context.Context, reportID int64) ([]domain.ReconciliationDiscrepancy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReconciliationDiscrepancies", ctx, reportID)
+	ret0, _ := ret[0].([]domain.ReconciliationDiscrepancy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReconciliationDiscrepancies indicates an expected call of ListReconciliationDiscrepancies.
+func (mr *MockReconciliationRepoMockRecorder) ListReconciliationDiscrepancies(ctx, reportID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReconciliationDiscrepancies", reflect.TypeOf((*MockReconciliationRepo)(nil).ListReconciliationDiscrepancies), ctx, reportID)
+}
+
+// ListReconciliationReports mocks base method.
+func (m *MockReconciliationRepo) ListReconciliationReports(ctx context.Context, arg store.ListReconciliationReportsParams) ([]domain.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReconciliationReports", ctx, arg)
+	ret0, _ := ret[0].([]domain.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReconciliationReports indicates an expected call of ListReconciliationReports.
+func (mr *MockReconciliationRepoMockRecorder) ListReconciliationReports(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReconciliationReports", reflect.TypeOf((*MockReconciliationRepo)(nil).ListReconciliationReports), ctx, arg)
+}
+
+// ListUnbalancedTransfers mocks base method.
+func (m *MockReconciliationRepo) ListUnbalancedTransfers(ctx context.Context) ([]store.UnbalancedTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnbalancedTransfers", ctx)
+	ret0, _ := ret[0].([]store.UnbalancedTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnbalancedTransfers indicates an expected call of ListUnbalancedTransfers.
+func (mr *MockReconciliationRepoMockRecorder) ListUnbalancedTransfers(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnbalancedTransfers", reflect.TypeOf((*MockReconciliationRepo)(nil).ListUnbalancedTransfers), ctx)
+}
+
+// ListWalletBalanceMismatches mocks base method.
+func (m *MockReconciliationRepo) ListWalletBalanceMismatches(ctx context.Context) ([]store.WalletBalanceMismatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWalletBalanceMismatches", ctx)
+	ret0, _ := ret[0].([]store.WalletBalanceMismatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWalletBalanceMismatches indicates an expected call of ListWalletBalanceMismatches.
+func (mr *MockReconciliationRepoMockRecorder) ListWalletBalanceMismatches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWalletBalanceMismatches", reflect.TypeOf((*MockReconciliationRepo)(nil).ListWalletBalanceMismatches), ctx)
+}
+
+// SaveReconciliationReport mocks base method.
+func (m *MockReconciliationRepo) SaveReconciliationReport(ctx context.Context, arg store.SaveReconciliationReportParams) (store.ReconciliationReportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReconciliationReport", ctx, arg)
+	ret0, _ := ret[0].(store.ReconciliationReportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveReconciliationReport indicates an expected call of SaveReconciliationReport.
+func (mr *MockReconciliationRepoMockRecorder) SaveReconciliationReport(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReconciliationReport", reflect.TypeOf((*MockReconciliationRepo)(nil).SaveReconciliationReport), ctx, arg)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/statement.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockStatementRepo is a mock of StatementRepo interface.
+type MockStatementRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepoMockRecorder
+}
+
+// MockStatementRepoMockRecorder is the mock recorder for MockStatementRepo.
+type MockStatementRepoMockRecorder struct {
+	mock *MockStatementRepo
+}
+
+// NewMockStatementRepo creates a new mock instance.
+func NewMockStatementRepo(ctrl *gomock.Controller) *MockStatementRepo {
+	mock := &MockStatementRepo{ctrl: ctrl}
+	mock.recorder = &MockStatementRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementRepo) EXPECT() *MockStatementRepoMockRecorder {
+	return m.recorder
+}
+
+// GetWalletBalanceAt mocks base method.
+func (m *MockStatementRepo) GetWalletBalanceAt(ctx context.Context, arg store.GetWalletBalanceAtParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalanceAt", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalanceAt indicates an expected call of GetWalletBalanceAt.
+func (mr *MockStatementRepoMockRecorder) GetWalletBalanceAt(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalanceAt", reflect.TypeOf((*MockStatementRepo)(nil).GetWalletBalanceAt), ctx, arg)
+}
+
+// ListStatementEntries mocks base method.
+func (m *MockStatementRepo) ListStatementEntries(ctx context.Context, arg store.ListStatementEntriesParams) ([]store.StatementEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatementEntries", ctx, arg)
+	ret0, _ := ret[0].([]store.StatementEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatementEntries indicates an expected call of ListStatementEntries.
+func (mr *MockStatementRepoMockRecorder) ListStatementEntries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatementEntries", reflect.TypeOf((*MockStatementRepo)(nil).ListStatementEntries), ctx, arg)
+}
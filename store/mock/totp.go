@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/totp.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockTOTPRepo is a mock of TOTPRepo interface.
+type MockTOTPRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTOTPRepoMockRecorder
+}
+
+// MockTOTPRepoMockRecorder is the mock recorder for MockTOTPRepo.
+type MockTOTPRepoMockRecorder struct {
+	mock *MockTOTPRepo
+}
+
+// NewMockTOTPRepo creates a new mock instance.
+func NewMockTOTPRepo(ctrl *gomock.Controller) *MockTOTPRepo {
+	mock := &MockTOTPRepo{ctrl: ctrl}
+	mock.recorder = &MockTOTPRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTOTPRepo) EXPECT() *MockTOTPRepoMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockTOTPRepo) ConfirmTOTP(ctx context.Context, arg store.ConfirmTOTPParams) (domain.TOTPCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, arg)
+	ret0, _ := ret[0].(domain.TOTPCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockTOTPRepoMockRecorder) ConfirmTOTP(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockTOTPRepo)(nil).ConfirmTOTP), ctx, arg)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockTOTPRepo) DisableTOTP(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockTOTPRepoMockRecorder) DisableTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockTOTPRepo)(nil).DisableTOTP), ctx, userID)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockTOTPRepo) EnrollTOTP(ctx context.Context, arg store.EnrollTOTPParams) (domain.TOTPCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, arg)
+	ret0, _ := ret[0].(domain.TOTPCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockTOTPRepoMockRecorder) EnrollTOTP(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockTOTPRepo)(nil).EnrollTOTP), ctx, arg)
+}
+
+// GetTOTPCredential mocks base method.
+func (m *MockTOTPRepo) GetTOTPCredential(ctx context.Context, userID int64) (domain.TOTPCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTPCredential", ctx, userID)
+	ret0, _ := ret[0].(domain.TOTPCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTPCredential indicates an expected call of GetTOTPCredential.
+func (mr *MockTOTPRepoMockRecorder) GetTOTPCredential(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTPCredential", reflect.TypeOf((*MockTOTPRepo)(nil).GetTOTPCredential), ctx, userID)
+}
+
+// RecordTOTPFailure mocks base method.
+func (m *MockTOTPRepo) RecordTOTPFailure(ctx context.Context, arg store.RecordTOTPFailureParams) (domain.TOTPCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTOTPFailure", ctx, arg)
+	ret0, _ := ret[0].(domain.TOTPCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordTOTPFailure indicates an expected call of RecordTOTPFailure.
+func (mr *MockTOTPRepoMockRecorder) RecordTOTPFailure(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTOTPFailure", reflect.TypeOf((*MockTOTPRepo)(nil).RecordTOTPFailure), ctx, arg)
+}
+
+// UseTOTPRecoveryCode mocks base method.
+func (m *MockTOTPRepo) UseTOTPRecoveryCode(ctx context.Context, arg store.UseTOTPRecoveryCodeParams) (domain.TOTPRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(domain.TOTPRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPRecoveryCode indicates an expected call of UseTOTPRecoveryCode.
+func (mr *MockTOTPRepoMockRecorder) UseTOTPRecoveryCode(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPRecoveryCode", reflect.TypeOf((*MockTOTPRepo)(nil).UseTOTPRecoveryCode), ctx, arg)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockTOTPRepo) UseTOTPStep(ctx context.Context, arg store.UseTOTPStepParams) (domain.TOTPCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, arg)
+	ret0, _ := ret[0].(domain.TOTPCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockTOTPRepoMockRecorder) UseTOTPStep(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockTOTPRepo)(nil).UseTOTPStep), ctx, arg)
+}
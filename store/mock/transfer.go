@@ -36,6 +36,21 @@ func (m *MockTransferRepo) EXPECT() *MockTransferRepoMockRecorder {
 	return m.recorder
 }
 
+// AddTransferRefundedAmount mocks base method.
+func (m *MockTransferRepo) AddTransferRefundedAmount(ctx context.Context, arg store.AddTransferRefundedAmountParams) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransferRefundedAmount", ctx, arg)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTransferRefundedAmount indicates an expected call of AddTransferRefundedAmount.
+func (mr *MockTransferRepoMockRecorder) AddTransferRefundedAmount(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransferRefundedAmount", reflect.TypeOf((*MockTransferRepo)(nil).AddTransferRefundedAmount), ctx, arg)
+}
+
 // CreateTransfer mocks base method.
 func (m *MockTransferRepo) CreateTransfer(ctx context.Context, arg store.CreateTransferParams) (domain.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,21 @@ func (mr *MockTransferRepoMockRecorder) GetTransfer(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockTransferRepo)(nil).GetTransfer), ctx, id)
 }
 
+// GetTransferForUpdate mocks base method.
+func (m *MockTransferRepo) GetTransferForUpdate(ctx context.Context, id int64) (domain.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferForUpdate", ctx, id)
+	ret0, _ := ret[0].(domain.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferForUpdate indicates an expected call of GetTransferForUpdate.
+func (mr *MockTransferRepoMockRecorder) GetTransferForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferForUpdate", reflect.TypeOf((*MockTransferRepo)(nil).GetTransferForUpdate), ctx, id)
+}
+
 // ListTransfers mocks base method.
 func (m *MockTransferRepo) ListTransfers(ctx context.Context, arg store.ListTransfersParams) ([]domain.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +51,21 @@ func (mr *MockWalletRepoMockRecorder) AddWalletBalance(ctx, arg interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWalletBalance", reflect.TypeOf((*MockWalletRepo)(nil).AddWalletBalance), ctx, arg)
 }
 
+// AddWalletHeldBalance mocks base method.
+func (m *MockWalletRepo) AddWalletHeldBalance(ctx context.Context, arg store.AddWalletHeldBalanceParams) (domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWalletHeldBalance", ctx, arg)
+	ret0, _ := ret[0].(domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWalletHeldBalance indicates an expected call of AddWalletHeldBalance.
+func (mr *MockWalletRepoMockRecorder) AddWalletHeldBalance(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWalletHeldBalance", reflect.TypeOf((*MockWalletRepo)(nil).AddWalletHeldBalance), ctx, arg)
+}
+
 // CreateWallet mocks base method.
 func (m *MockWalletRepo) CreateWallet(ctx context.Context, arg store.CreateWalletParams) (domain.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +186,21 @@ func (mr *MockWalletRepoMockRecorder) ListWallets(ctx, arg interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWallets", reflect.TypeOf((*MockWalletRepo)(nil).ListWallets), ctx, arg)
 }
 
+// RefundTransfer mocks base method.
+func (m *MockWalletRepo) RefundTransfer(ctx context.Context, arg store.RefundTransferParams) (store.RefundTransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundTransfer", ctx, arg)
+	ret0, _ := ret[0].(store.RefundTransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundTransfer indicates an expected call of RefundTransfer.
+func (mr *MockWalletRepoMockRecorder) RefundTransfer(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundTransfer", reflect.TypeOf((*MockWalletRepo)(nil).RefundTransfer), ctx, arg)
+}
+
 // SendMoney mocks base method.
 func (m *MockWalletRepo) SendMoney(ctx context.Context, arg store.SendMoneyParams) (store.WalletTransferResult, error) {
 	m.ctrl.T.Helper()
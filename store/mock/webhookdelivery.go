@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/webhookdelivery.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockWebhookDeliveryRepo is a mock of WebhookDeliveryRepo interface.
+type MockWebhookDeliveryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryRepoMockRecorder
+}
+
+// MockWebhookDeliveryRepoMockRecorder is the mock recorder for MockWebhookDeliveryRepo.
+type MockWebhookDeliveryRepoMockRecorder struct {
+	mock *MockWebhookDeliveryRepo
+}
+
+// NewMockWebhookDeliveryRepo creates a new mock instance.
+func NewMockWebhookDeliveryRepo(ctrl *gomock.Controller) *MockWebhookDeliveryRepo {
+	mock := &MockWebhookDeliveryRepo{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryRepo) EXPECT() *MockWebhookDeliveryRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueWebhookDeliveries mocks base method.
+func (m *MockWebhookDeliveryRepo) ClaimDueWebhookDeliveries(ctx context.Context, arg store.ClaimDueWebhookDeliveriesParams) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueWebhookDeliveries indicates an expected call of ClaimDueWebhookDeliveries.
+func (mr *MockWebhookDeliveryRepoMockRecorder) ClaimDueWebhookDeliveries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueWebhookDeliveries", reflect.TypeOf((*MockWebhookDeliveryRepo)(nil).ClaimDueWebhookDeliveries), ctx, arg)
+}
+
+// CreateWebhookDelivery mocks base method.
+func (m *MockWebhookDeliveryRepo) CreateWebhookDelivery(ctx context.Context, arg store.CreateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookDelivery indicates an expected call of CreateWebhookDelivery.
+func (mr *MockWebhookDeliveryRepoMockRecorder) CreateWebhookDelivery(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDelivery", reflect.TypeOf((*MockWebhookDeliveryRepo)(nil).CreateWebhookDelivery), ctx, arg)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockWebhookDeliveryRepo) GetWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, id)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockWebhookDeliveryRepoMockRecorder) GetWebhookDelivery(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockWebhookDeliveryRepo)(nil).GetWebhookDelivery), ctx, id)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockWebhookDeliveryRepo) ListWebhookDeliveries(ctx context.Context, arg store.ListWebhookDeliveriesParams) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, arg)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockWebhookDeliveryRepoMockRecorder) ListWebhookDeliveries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockWebhookDeliveryRepo)(nil).ListWebhookDeliveries), ctx, arg)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockWebhookDeliveryRepo) UpdateWebhookDelivery(ctx context.Context, arg store.UpdateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", ctx, arg)
+	ret0, _ := ret[0].(domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockWebhookDeliveryRepoMockRecorder) UpdateWebhookDelivery(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockWebhookDeliveryRepo)(nil).UpdateWebhookDelivery), ctx, arg)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store/webhookendpoint.go
+
+// Package mockdb is a generated GoMock package.
+package mockdb
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/pranayhere/simple-wallet/domain"
+	store "github.com/pranayhere/simple-wallet/store"
+)
+
+// MockWebhookEndpointRepo is a mock of WebhookEndpointRepo interface.
+type MockWebhookEndpointRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookEndpointRepoMockRecorder
+}
+
+// MockWebhookEndpointRepoMockRecorder is the mock recorder for MockWebhookEndpointRepo.
+type MockWebhookEndpointRepoMockRecorder struct {
+	mock *MockWebhookEndpointRepo
+}
+
+// NewMockWebhookEndpointRepo creates a new mock instance.
+func NewMockWebhookEndpointRepo(ctrl *gomock.Controller) *MockWebhookEndpointRepo {
+	mock := &MockWebhookEndpointRepo{ctrl: ctrl}
+	mock.recorder = &MockWebhookEndpointRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookEndpointRepo) EXPECT() *MockWebhookEndpointRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookEndpoint mocks base method.
+func (m *MockWebhookEndpointRepo) CreateWebhookEndpoint(ctx context.Context, arg store.CreateWebhookEndpointParams) (domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEndpoint", ctx, arg)
+	ret0, _ := ret[0].(domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEndpoint indicates an expected call of CreateWebhookEndpoint.
+func (mr *MockWebhookEndpointRepoMockRecorder) CreateWebhookEndpoint(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEndpoint", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).CreateWebhookEndpoint), ctx, arg)
+}
+
+// GetWebhookEndpoint mocks base method.
+func (m *MockWebhookEndpointRepo) GetWebhookEndpoint(ctx context.Context, id int64) (domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookEndpoint", ctx, id)
+	ret0, _ := ret[0].(domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookEndpoint indicates an expected call of GetWebhookEndpoint.
+func (mr *MockWebhookEndpointRepoMockRecorder) GetWebhookEndpoint(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookEndpoint", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).GetWebhookEndpoint), ctx, id)
+}
+
+// ListActiveWebhookEndpointsForEvent mocks base method.
+func (m *MockWebhookEndpointRepo) ListActiveWebhookEndpointsForEvent(ctx context.Context, arg store.ListActiveWebhookEndpointsForEventParams) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveWebhookEndpointsForEvent", ctx, arg)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveWebhookEndpointsForEvent indicates an expected call of ListActiveWebhookEndpointsForEvent.
+func (mr *MockWebhookEndpointRepoMockRecorder) ListActiveWebhookEndpointsForEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveWebhookEndpointsForEvent", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).ListActiveWebhookEndpointsForEvent), ctx, arg)
+}
+
+// ListWebhookEndpoints mocks base method.
+func (m *MockWebhookEndpointRepo) ListWebhookEndpoints(ctx context.Context, userID int64) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookEndpoints", ctx, userID)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookEndpoints indicates an expected call of ListWebhookEndpoints.
+func (mr *MockWebhookEndpointRepoMockRecorder) ListWebhookEndpoints(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookEndpoints", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).ListWebhookEndpoints), ctx, userID)
+}
+
+// UpdateWebhookEndpointStatus mocks base method.
+func (m *MockWebhookEndpointRepo) UpdateWebhookEndpointStatus(ctx context.Context, arg store.UpdateWebhookEndpointStatusParams) (domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookEndpointStatus", ctx, arg)
+	ret0, _ := ret[0].(domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhookEndpointStatus indicates an expected call of UpdateWebhookEndpointStatus.
+func (mr *MockWebhookEndpointRepoMockRecorder) UpdateWebhookEndpointStatus(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookEndpointStatus", reflect.TypeOf((*MockWebhookEndpointRepo)(nil).UpdateWebhookEndpointStatus), ctx, arg)
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type NotificationRepo interface {
+    CreateNotification(ctx context.Context, arg CreateNotificationParams) (domain.Notification, error)
+    GetNotification(ctx context.Context, id int64) (domain.Notification, error)
+    ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]domain.Notification, error)
+    UpdateNotificationRead(ctx context.Context, arg UpdateNotificationReadParams) (domain.Notification, error)
+}
+
+type notificationRepository struct {
+    db *sql.DB
+}
+
+func NewNotificationRepo(client *sql.DB) NotificationRepo {
+    return &notificationRepository{
+        db: client,
+    }
+}
+
+const createNotification = `-- name: CreateNotification :one
+INSERT INTO notifications (user_id,
+                           event_type,
+                           title,
+                           body,
+                           outbox_event_id)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (outbox_event_id, user_id) DO NOTHING
+RETURNING id, user_id, event_type, title, body, read_at, created_at, outbox_event_id
+`
+
+type CreateNotificationParams struct {
+    UserID    int64            `json:"user_id"`
+    EventType domain.EventType `json:"event_type"`
+    Title     string           `json:"title"`
+    Body      string           `json:"body"`
+    // OutboxEventID is the outbox event notified, if any
+    OutboxEventID sql.NullInt64 `json:"outbox_event_id"`
+}
+
+// CreateNotification fails with sql.ErrNoRows when the user already has the notification of the outbox event.
+func (q *notificationRepository) CreateNotification(ctx context.Context, arg CreateNotificationParams) (domain.Notification, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createNotification,
+        arg.UserID,
+        arg.EventType,
+        arg.Title,
+        arg.Body,
+        arg.OutboxEventID,
+    )
+    var i domain.Notification
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.EventType,
+        &i.Title,
+        &i.Body,
+        &i.ReadAt,
+        &i.CreatedAt,
+        &i.OutboxEventID,
+    )
+    return i, err
+}
+
+const getNotification = `-- name: GetNotification :one
+SELECT id, user_id, event_type, title, body, read_at, created_at, outbox_event_id
+FROM notifications
+WHERE id = $1 LIMIT 1
+`
+
+func (q *notificationRepository) GetNotification(ctx context.Context, id int64) (domain.Notification, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getNotification, id)
+    var i domain.Notification
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.EventType,
+        &i.Title,
+        &i.Body,
+        &i.ReadAt,
+        &i.CreatedAt,
+        &i.OutboxEventID,
+    )
+    return i, err
+}
+
+const listNotifications = `-- name: ListNotifications :many
+SELECT id, user_id, event_type, title, body, read_at, created_at, outbox_event_id
+FROM notifications
+WHERE user_id = $1
+  AND (NOT $2::boolean OR read_at IS NULL)
+ORDER BY id DESC LIMIT $3
+OFFSET $4
+`
+
+type ListNotificationsParams struct {
+    UserID     int64 `json:"user_id"`
+    UnreadOnly bool  `json:"unread_only"`
+    Limit      int32 `json:"limit"`
+    Offset     int32 `json:"offset"`
+}
+
+func (q *notificationRepository) ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]domain.Notification, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listNotifications,
+        arg.UserID,
+        arg.UnreadOnly,
+        arg.Limit,
+        arg.Offset,
+    )
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.Notification{}
+    for rows.Next() {
+        var i domain.Notification
+        if err := rows.Scan(
+            &i.ID,
+            &i.UserID,
+            &i.EventType,
+            &i.Title,
+            &i.Body,
+            &i.ReadAt,
+            &i.CreatedAt,
+            &i.OutboxEventID,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const updateNotificationRead = `-- name: UpdateNotificationRead :one
+UPDATE notifications
+SET read_at = CASE WHEN $1::boolean THEN COALESCE(read_at, now()) END
+WHERE id = $2 RETURNING id, user_id, event_type, title, body, read_at, created_at, outbox_event_id
+`
+
+type UpdateNotificationReadParams struct {
+    Read bool  `json:"read"`
+    ID   int64 `json:"id"`
+}
+
+func (q *notificationRepository) UpdateNotificationRead(ctx context.Context, arg UpdateNotificationReadParams) (domain.Notification, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateNotificationRead, arg.Read, arg.ID)
+    var i domain.Notification
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.EventType,
+        &i.Title,
+        &i.Body,
+        &i.ReadAt,
+        &i.CreatedAt,
+        &i.OutboxEventID,
+    )
+    return i, err
+}
@@ -0,0 +1,129 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func createRandomNotification(t *testing.T, userID int64) domain.Notification {
+    notificationRepo := store.NewNotificationRepo(testDb)
+
+    arg := store.CreateNotificationParams{
+        UserID:    userID,
+        EventType: domain.EventTypeTransferReceived,
+        Title:     util.RandomString(12),
+        Body:      util.RandomString(40),
+    }
+
+    notification, err := notificationRepo.CreateNotification(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, notification)
+
+    require.Equal(t, arg.UserID, notification.UserID)
+    require.Equal(t, arg.EventType, notification.EventType)
+    require.Equal(t, arg.Title, notification.Title)
+    require.Equal(t, arg.Body, notification.Body)
+    require.False(t, notification.ReadAt.Valid)
+
+    require.NotZero(t, notification.ID)
+    require.NotZero(t, notification.CreatedAt)
+
+    return notification
+}
+
+func TestCreateNotification(t *testing.T) {
+    user := createRandomUser(t)
+    createRandomNotification(t, user.ID)
+}
+
+func TestCreateNotificationOfOutboxEventOnce(t *testing.T) {
+    notificationRepo := store.NewNotificationRepo(testDb)
+    user := createRandomUser(t)
+    event := createRandomOutboxEvent(t)
+
+    arg := store.CreateNotificationParams{
+        UserID:        user.ID,
+        EventType:     domain.EventTypeTransferReceived,
+        Title:         util.RandomString(12),
+        Body:          util.RandomString(40),
+        OutboxEventID: sql.NullInt64{Int64: event.ID, Valid: true},
+    }
+
+    notification, err := notificationRepo.CreateNotification(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, arg.OutboxEventID, notification.OutboxEventID)
+
+    _, err = notificationRepo.CreateNotification(context.Background(), arg)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestGetNotification(t *testing.T) {
+    notificationRepo := store.NewNotificationRepo(testDb)
+    user := createRandomUser(t)
+    notification1 := createRandomNotification(t, user.ID)
+
+    notification2, err := notificationRepo.GetNotification(context.Background(), notification1.ID)
+    require.NoError(t, err)
+    require.Equal(t, notification1, notification2)
+}
+
+func TestUpdateNotificationRead(t *testing.T) {
+    notificationRepo := store.NewNotificationRepo(testDb)
+    user := createRandomUser(t)
+    notification1 := createRandomNotification(t, user.ID)
+
+    notification2, err := notificationRepo.UpdateNotificationRead(context.Background(), store.UpdateNotificationReadParams{
+        ID:   notification1.ID,
+        Read: true,
+    })
+    require.NoError(t, err)
+    require.True(t, notification2.ReadAt.Valid)
+
+    notification3, err := notificationRepo.UpdateNotificationRead(context.Background(), store.UpdateNotificationReadParams{
+        ID:   notification1.ID,
+        Read: false,
+    })
+    require.NoError(t, err)
+    require.False(t, notification3.ReadAt.Valid)
+}
+
+func TestListNotifications(t *testing.T) {
+    notificationRepo := store.NewNotificationRepo(testDb)
+    user := createRandomUser(t)
+
+    var notifications []domain.Notification
+    for i := 0; i < 4; i++ {
+        notifications = append(notifications, createRandomNotification(t, user.ID))
+    }
+
+    _, err := notificationRepo.UpdateNotificationRead(context.Background(), store.UpdateNotificationReadParams{
+        ID:   notifications[0].ID,
+        Read: true,
+    })
+    require.NoError(t, err)
+
+    all, err := notificationRepo.ListNotifications(context.Background(), store.ListNotificationsParams{
+        UserID: user.ID,
+        Limit:  10,
+    })
+    require.NoError(t, err)
+    require.Len(t, all, 4)
+    require.Equal(t, notifications[3].ID, all[0].ID)
+
+    unread, err := notificationRepo.ListNotifications(context.Background(), store.ListNotificationsParams{
+        UserID:     user.ID,
+        UnreadOnly: true,
+        Limit:      10,
+    })
+    require.NoError(t, err)
+    require.Len(t, unread, 3)
+    for _, n := range unread {
+        require.NotEqual(t, notifications[0].ID, n.ID)
+        require.Equal(t, user.ID, n.UserID)
+    }
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type NotificationPreferenceRepo interface {
+    UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (domain.NotificationPreference, error)
+    ListNotificationPreferences(ctx context.Context, userID int64) ([]domain.NotificationPreference, error)
+}
+
+type notificationPreferenceRepository struct {
+    db *sql.DB
+}
+
+func NewNotificationPreferenceRepo(client *sql.DB) NotificationPreferenceRepo {
+    return &notificationPreferenceRepository{
+        db: client,
+    }
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :one
+INSERT INTO notification_preferences (user_id,
+                                      channel,
+                                      enabled)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, channel) DO UPDATE SET enabled    = $3,
+                                             updated_at = now()
+RETURNING user_id, channel, enabled, updated_at
+`
+
+type UpsertNotificationPreferenceParams struct {
+    UserID  int64                      `json:"user_id"`
+    Channel domain.NotificationChannel `json:"channel"`
+    Enabled bool                       `json:"enabled"`
+}
+
+func (q *notificationPreferenceRepository) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (domain.NotificationPreference, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, upsertNotificationPreference, arg.UserID, arg.Channel, arg.Enabled)
+    var i domain.NotificationPreference
+    err := row.Scan(
+        &i.UserID,
+        &i.Channel,
+        &i.Enabled,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const listNotificationPreferences = `-- name: ListNotificationPreferences :many
+SELECT user_id, channel, enabled, updated_at
+FROM notification_preferences
+WHERE user_id = $1
+ORDER BY channel
+`
+
+func (q *notificationPreferenceRepository) ListNotificationPreferences(ctx context.Context, userID int64) ([]domain.NotificationPreference, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listNotificationPreferences, userID)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.NotificationPreference{}
+    for rows.Next() {
+        var i domain.NotificationPreference
+        if err := rows.Scan(
+            &i.UserID,
+            &i.Channel,
+            &i.Enabled,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
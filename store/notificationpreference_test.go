@@ -0,0 +1,37 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func TestUpsertNotificationPreference(t *testing.T) {
+    preferenceRepo := store.NewNotificationPreferenceRepo(testDb)
+    user := createRandomUser(t)
+
+    arg := store.UpsertNotificationPreferenceParams{
+        UserID:  user.ID,
+        Channel: domain.NotificationChannelEMAIL,
+        Enabled: false,
+    }
+
+    preference1, err := preferenceRepo.UpsertNotificationPreference(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, arg.UserID, preference1.UserID)
+    require.Equal(t, arg.Channel, preference1.Channel)
+    require.False(t, preference1.Enabled)
+    require.NotZero(t, preference1.UpdatedAt)
+
+    arg.Enabled = true
+    preference2, err := preferenceRepo.UpsertNotificationPreference(context.Background(), arg)
+    require.NoError(t, err)
+    require.True(t, preference2.Enabled)
+
+    preferences, err := preferenceRepo.ListNotificationPreferences(context.Background(), user.ID)
+    require.NoError(t, err)
+    require.Len(t, preferences, 1)
+    require.Equal(t, preference2.Enabled, preferences[0].Enabled)
+}
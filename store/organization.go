@@ -0,0 +1,209 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+    "github.com/lib/pq"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "strings"
+)
+
+type OrganizationRepo interface {
+    CreateCurrencyWithWallets(ctx context.Context, arg CreateCurrencyWithWalletsParams) (CurrencyWithWalletsResult, error)
+}
+
+type organizationRepository struct {
+    db              *sql.DB
+    currencyRepo    CurrencyRepo
+    userRepo        UserRepo
+    bankAccountRepo BankAccountRepo
+    walletRepo      WalletRepo
+}
+
+func NewOrganizationRepo(db *sql.DB, currencyRepo CurrencyRepo, userRepo UserRepo, bankAccountRepo BankAccountRepo, walletRepo WalletRepo) OrganizationRepo {
+    return &organizationRepository{
+        db:              db,
+        currencyRepo:    currencyRepo,
+        userRepo:        userRepo,
+        bankAccountRepo: bankAccountRepo,
+        walletRepo:      walletRepo,
+    }
+}
+
+// OrganizationWalletAddress is the address of the organization wallet of the currency, the wallet every wallet of
+// the currency is linked to
+func OrganizationWalletAddress(currency string) string {
+    return fmt.Sprintf("grab%s@my.wallet", strings.ToLower(currency))
+}
+
+const createOrganizationWallet = `-- name: CreateOrganizationWallet :one
+WITH next AS (SELECT nextval(pg_get_serial_sequence('wallets', 'id')) AS id)
+INSERT
+INTO wallets (id,
+              address,
+              status,
+              user_id,
+              bank_account_id,
+              organization_wallet_id,
+              balance,
+              opening_balance,
+              currency)
+SELECT next.id, $1, $2, $3, $4, next.id, 0, 0, $5
+FROM next RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
+`
+
+type CreateOrganizationWalletParams struct {
+    Address       string              `json:"address"`
+    Status        domain.WalletStatus `json:"status"`
+    UserID        int64               `json:"user_id"`
+    BankAccountID int64               `json:"bank_account_id"`
+    Currency      string              `json:"currency"`
+}
+
+// createOrganizationWallet creates a wallet that is its own organization wallet.
+func (q *organizationRepository) createOrganizationWallet(ctx context.Context, arg CreateOrganizationWalletParams) (domain.Wallet, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createOrganizationWallet,
+        arg.Address,
+        arg.Status,
+        arg.UserID,
+        arg.BankAccountID,
+        arg.Currency,
+    )
+    var i domain.Wallet
+    err := row.Scan(
+        &i.ID,
+        &i.Address,
+        &i.Status,
+        &i.UserID,
+        &i.BankAccountID,
+        &i.OrganizationWalletID,
+        &i.Balance,
+        &i.HeldBalance,
+        &i.Currency,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+type OrganizationBankAccountParams struct {
+    AccountNo string `json:"account_no"`
+    Ifsc      string `json:"ifsc"`
+    BankName  string `json:"bank_name"`
+}
+
+type CreateCurrencyWithWalletsParams struct {
+    Code     string `json:"code"`
+    Fraction int64  `json:"fraction"`
+    // HashedPassword is the password of the users owning the wallets, nobody logs in with them
+    HashedPassword      string                        `json:"hashed_password"`
+    OrganizationAccount OrganizationBankAccountParams `json:"organization_account"`
+    EscrowAccount       OrganizationBankAccountParams `json:"escrow_account"`
+}
+
+type CurrencyWithWalletsResult struct {
+    Currency           domain.Currency `json:"currency"`
+    OrganizationWallet domain.Wallet   `json:"organization_wallet"`
+    EscrowWallet       domain.Wallet   `json:"escrow_wallet"`
+}
+
+// CreateCurrencyWithWallets creates the currency with its organization wallet and its escrow wallet, each owned by a
+// user of its own and linked to a verified bank account, like the migrations seed INR, USD and EUR.
+func (q *organizationRepository) CreateCurrencyWithWallets(ctx context.Context, arg CreateCurrencyWithWalletsParams) (CurrencyWithWalletsResult, error) {
+    var result CurrencyWithWalletsResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        var err error
+
+        result.Currency, err = q.currencyRepo.CreateCurrency(ctx, CreateCurrencyParams{
+            Code:     arg.Code,
+            Fraction: arg.Fraction,
+        })
+        if err != nil {
+            return err
+        }
+
+        lowerCode := strings.ToLower(arg.Code)
+
+        orgUser, orgBankAccount, err := q.createOwner(ctx, arg, CreateUserParams{
+            Username: fmt.Sprintf("grab%sUser", arg.Code),
+            FullName: fmt.Sprintf("My Wallet Main Acct %s", arg.Code),
+            Email:    fmt.Sprintf("mywallet%s@gmail.com", lowerCode),
+        }, arg.OrganizationAccount)
+        if err != nil {
+            return err
+        }
+
+        result.OrganizationWallet, err = q.createOrganizationWallet(ctx, CreateOrganizationWalletParams{
+            Address:       OrganizationWalletAddress(arg.Code),
+            Status:        domain.WalletStatusACTIVE,
+            UserID:        orgUser.ID,
+            BankAccountID: orgBankAccount.ID,
+            Currency:      arg.Code,
+        })
+        if err != nil {
+            return organizationError(err)
+        }
+
+        escrowUser, escrowBankAccount, err := q.createOwner(ctx, arg, CreateUserParams{
+            Username: fmt.Sprintf("escrow%sUser", arg.Code),
+            FullName: fmt.Sprintf("My Wallet Escrow Acct %s", arg.Code),
+            Email:    fmt.Sprintf("mywalletescrow%s@gmail.com", lowerCode),
+        }, arg.EscrowAccount)
+        if err != nil {
+            return err
+        }
+
+        result.EscrowWallet, err = q.walletRepo.CreateWallet(ctx, CreateWalletParams{
+            Address:              EscrowWalletAddress(arg.Code),
+            Status:               domain.WalletStatusACTIVE,
+            UserID:               escrowUser.ID,
+            BankAccountID:        escrowBankAccount.ID,
+            OrganizationWalletID: result.OrganizationWallet.ID,
+            Balance:              0,
+            Currency:             arg.Code,
+        })
+        return organizationError(err)
+    })
+
+    return result, err
+}
+
+// createOwner creates the user owning a system wallet and its verified bank account.
+func (q *organizationRepository) createOwner(ctx context.Context, arg CreateCurrencyWithWalletsParams, userArg CreateUserParams,
+    bankAccountArg OrganizationBankAccountParams) (domain.User, domain.BankAccount, error) {
+    userArg.HashedPassword = arg.HashedPassword
+    userArg.Status = domain.UserStatusACTIVE
+
+    user, err := q.userRepo.CreateUser(ctx, userArg)
+    if err != nil {
+        return user, domain.BankAccount{}, organizationError(err)
+    }
+
+    bankAccount, err := q.bankAccountRepo.CreateBankAccount(ctx, CreateBankAccountParams{
+        AccountNo: bankAccountArg.AccountNo,
+        Ifsc:      bankAccountArg.Ifsc,
+        BankName:  bankAccountArg.BankName,
+        Currency:  arg.Code,
+        UserID:    user.ID,
+        Status:    domain.BankAccountStatusVERIFIED,
+    })
+    if err != nil {
+        if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+            return user, bankAccount, errors.ErrBankAccountAlreadyExist
+        }
+        return user, bankAccount, err
+    }
+
+    return user, bankAccount, nil
+}
+
+// organizationError tells the wallets of the currency already exist when their user or address is taken.
+func organizationError(err error) error {
+    if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+        return errors.ErrOrganizationWalletAlreadyExist
+    }
+    return err
+}
@@ -0,0 +1,73 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "strings"
+    "testing"
+)
+
+func InitOrganizationRepo(t *testing.T) store.OrganizationRepo {
+    transferRepo := store.NewTransferRepo(testDb)
+    entryRepo := store.NewEntryRepo(testDb)
+    outboxRepo := store.NewOutboxRepo(testDb)
+    walletRepo := store.NewWalletRepo(testDb, transferRepo, entryRepo, outboxRepo)
+    userRepo := store.NewUserRepo(testDb)
+    bankAcctRepo := store.NewBankAccountRepo(testDb, walletRepo, userRepo, store.NewBankVerificationRepo(testDb), outboxRepo)
+
+    organizationRepo := store.NewOrganizationRepo(testDb, store.NewCurrencyRepo(testDb), userRepo, bankAcctRepo, walletRepo)
+    require.NotEmpty(t, organizationRepo)
+    return organizationRepo
+}
+
+func TestCreateCurrencyWithWallets(t *testing.T) {
+    organizationRepo := InitOrganizationRepo(t)
+    healthRepo := store.NewHealthRepo(testDb)
+
+    arg := store.CreateCurrencyWithWalletsParams{
+        Code:           strings.ToUpper(util.RandomString(3)),
+        Fraction:       2,
+        HashedPassword: util.RandomString(20),
+        OrganizationAccount: store.OrganizationBankAccountParams{
+            AccountNo: util.RandomString(10),
+            Ifsc:      util.RandomString(7),
+            BankName:  util.RandomString(5),
+        },
+        EscrowAccount: store.OrganizationBankAccountParams{
+            AccountNo: util.RandomString(10),
+            Ifsc:      util.RandomString(7),
+            BankName:  util.RandomString(5),
+        },
+    }
+
+    res, err := organizationRepo.CreateCurrencyWithWallets(context.Background(), arg)
+    require.NoError(t, err)
+
+    require.Equal(t, arg.Code, res.Currency.Code)
+
+    orgWallet := res.OrganizationWallet
+    require.Equal(t, store.OrganizationWalletAddress(arg.Code), orgWallet.Address)
+    require.Equal(t, orgWallet.ID, orgWallet.OrganizationWalletID)
+    require.Equal(t, domain.WalletStatusACTIVE, orgWallet.Status)
+    require.Equal(t, arg.Code, orgWallet.Currency)
+
+    escrowWallet := res.EscrowWallet
+    require.Equal(t, store.EscrowWalletAddress(arg.Code), escrowWallet.Address)
+    require.Equal(t, orgWallet.ID, escrowWallet.OrganizationWalletID)
+    require.Equal(t, domain.WalletStatusACTIVE, escrowWallet.Status)
+    require.NotEqual(t, orgWallet.UserID, escrowWallet.UserID)
+
+    missing, err := healthRepo.ListCurrenciesWithoutOrganizationWallet(context.Background())
+    require.NoError(t, err)
+    require.NotContains(t, missing, arg.Code)
+
+    // the wallets of a currency are created once, nothing of the second attempt is kept
+    arg.OrganizationAccount.AccountNo = util.RandomString(10)
+    arg.EscrowAccount.AccountNo = util.RandomString(10)
+    _, err = organizationRepo.CreateCurrencyWithWallets(context.Background(), arg)
+    require.EqualError(t, err, errors.ErrOrganizationWalletAlreadyExist.Error())
+}
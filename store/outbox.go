@@ -0,0 +1,462 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "encoding/json"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    log "github.com/sirupsen/logrus"
+    "time"
+)
+
+type OutboxRepo interface {
+    CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (domain.OutboxEvent, error)
+    ListUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]domain.OutboxEvent, error)
+    MarkOutboxEventPublished(ctx context.Context, id int64) (domain.OutboxEvent, error)
+    RelayOutboxEvents(ctx context.Context, arg RelayOutboxEventsParams, publish PublishOutboxEventFn) (int, error)
+    ListDeadOutboxEvents(ctx context.Context, arg ListDeadOutboxEventsParams) ([]domain.OutboxEvent, error)
+    CountDeadOutboxEvents(ctx context.Context) (int64, error)
+    RetryOutboxEvent(ctx context.Context, id int64) (domain.OutboxEvent, error)
+}
+
+type outboxRepository struct {
+    db *sql.DB
+}
+
+func NewOutboxRepo(client *sql.DB) OutboxRepo {
+    return &outboxRepository{
+        db: client,
+    }
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :one
+INSERT INTO outbox (aggregate_type,
+                    aggregate_id,
+                    event_type,
+                    payload)
+VALUES ($1, $2, $3, $4) RETURNING id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+`
+
+type CreateOutboxEventParams struct {
+    AggregateType domain.AggregateType `json:"aggregate_type"`
+    AggregateID   int64                `json:"aggregate_id"`
+    EventType     domain.EventType     `json:"event_type"`
+    Payload       string               `json:"payload"`
+}
+
+// NewCreateOutboxEventParams marshals the payload of the event to JSON
+func NewCreateOutboxEventParams(aggregateType domain.AggregateType, aggregateID int64, eventType domain.EventType, payload interface{}) (CreateOutboxEventParams, error) {
+    data, err := json.Marshal(payload)
+    if err != nil {
+        return CreateOutboxEventParams{}, err
+    }
+
+    return CreateOutboxEventParams{
+        AggregateType: aggregateType,
+        AggregateID:   aggregateID,
+        EventType:     eventType,
+        Payload:       string(data),
+    }, nil
+}
+
+func (q *outboxRepository) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (domain.OutboxEvent, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createOutboxEvent,
+        arg.AggregateType,
+        arg.AggregateID,
+        arg.EventType,
+        arg.Payload,
+    )
+    var i domain.OutboxEvent
+    err := row.Scan(
+        &i.ID,
+        &i.AggregateType,
+        &i.AggregateID,
+        &i.EventType,
+        &i.Payload,
+        &i.CreatedAt,
+        &i.PublishedAt,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastError,
+    )
+    return i, err
+}
+
+const listUnpublishedOutboxEvents = `-- name: ListUnpublishedOutboxEvents :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+FROM outbox
+WHERE status = 'PENDING'
+ORDER BY id LIMIT $1
+`
+
+func (q *outboxRepository) ListUnpublishedOutboxEvents(ctx context.Context, limit int32) ([]domain.OutboxEvent, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listUnpublishedOutboxEvents, limit)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.OutboxEvent{}
+    for rows.Next() {
+        var i domain.OutboxEvent
+        if err := rows.Scan(
+            &i.ID,
+            &i.AggregateType,
+            &i.AggregateID,
+            &i.EventType,
+            &i.Payload,
+            &i.CreatedAt,
+            &i.PublishedAt,
+            &i.Status,
+            &i.Attempts,
+            &i.NextAttemptAt,
+            &i.LastError,
+            &i.Status,
+            &i.Attempts,
+            &i.NextAttemptAt,
+            &i.LastError,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const markOutboxEventPublished = `-- name: MarkOutboxEventPublished :one
+UPDATE outbox
+SET status       = 'PUBLISHED',
+    published_at = now()
+WHERE id = $1 RETURNING id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+`
+
+func (q *outboxRepository) MarkOutboxEventPublished(ctx context.Context, id int64) (domain.OutboxEvent, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, markOutboxEventPublished, id)
+    var i domain.OutboxEvent
+    err := row.Scan(
+        &i.ID,
+        &i.AggregateType,
+        &i.AggregateID,
+        &i.EventType,
+        &i.Payload,
+        &i.CreatedAt,
+        &i.PublishedAt,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastError,
+    )
+    return i, err
+}
+
+const listUnpublishedOutboxEventsAfter = `-- name: ListUnpublishedOutboxEventsAfter :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+FROM outbox
+WHERE status = 'PENDING'
+  AND id > $1
+ORDER BY id LIMIT $2
+`
+
+func (q *outboxRepository) listUnpublishedOutboxEventsAfter(ctx context.Context, afterID int64, limit int32) ([]domain.OutboxEvent, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listUnpublishedOutboxEventsAfter, afterID, limit)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.OutboxEvent{}
+    for rows.Next() {
+        var i domain.OutboxEvent
+        if err := rows.Scan(
+            &i.ID,
+            &i.AggregateType,
+            &i.AggregateID,
+            &i.EventType,
+            &i.Payload,
+            &i.CreatedAt,
+            &i.PublishedAt,
+            &i.Status,
+            &i.Attempts,
+            &i.NextAttemptAt,
+            &i.LastError,
+            &i.Status,
+            &i.Attempts,
+            &i.NextAttemptAt,
+            &i.LastError,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const tryLockOutboxRelay = `-- name: TryLockOutboxRelay :one
+SELECT pg_try_advisory_lock($1)
+`
+
+const unlockOutboxRelay = `-- name: UnlockOutboxRelay :one
+SELECT pg_advisory_unlock($1)
+`
+
+// outboxRelayLockID is the advisory lock held by the relay that is currently publishing
+const outboxRelayLockID = 7200281
+
+type RelayOutboxEventsParams struct {
+    Limit          int32         `json:"limit"`
+    MaxAttempts    int64         `json:"max_attempts"`
+    RetryBaseDelay time.Duration `json:"retry_base_delay"`
+}
+
+// PublishOutboxEventFn hands an outbox event over to the publisher
+type PublishOutboxEventFn func(ctx context.Context, event domain.OutboxEvent) error
+
+type outboxAggregate struct {
+    aggregateType domain.AggregateType
+    aggregateID   int64
+}
+
+// RelayOutboxEvents publishes up to arg.Limit unpublished events in the order they were written and marks
+// them published. Only one relay publishes at a time, it holds a session lock rather than a transaction so
+// the handlers run their own queries and slow sends outside of it. An event that fails to publish holds back
+// the later events of its aggregate only, the events of a wallet are never delivered out of order but a
+// failing wallet doesn't stop the others. A failed event is published again after a delay doubling with each
+// attempt, after arg.MaxAttempts it is set aside as DEAD and stops holding its aggregate back. The first publish error is returned with the count of the published
+// events. An event is marked only after it was published, a crash in between publishes it again
+// (at-least-once).
+func (q *outboxRepository) RelayOutboxEvents(ctx context.Context, arg RelayOutboxEventsParams, publish PublishOutboxEventFn) (int, error) {
+    // neither the relay queries nor the handlers may join a transaction of the caller
+    ctx = context.WithValue(ctx, TxKey, nil)
+
+    lockConn, err := q.db.Conn(ctx)
+    if err != nil {
+        return 0, err
+    }
+    defer lockConn.Close()
+
+    var locked bool
+    if err := lockConn.QueryRowContext(ctx, tryLockOutboxRelay, outboxRelayLockID).Scan(&locked); err != nil {
+        return 0, err
+    }
+
+    if !locked {
+        return 0, nil
+    }
+    defer lockConn.ExecContext(context.Background(), unlockOutboxRelay, outboxRelayLockID)
+
+    var published int
+    var attempted int32
+    var publishErr error
+    var lastID int64
+    blocked := map[outboxAggregate]bool{}
+    now := time.Now().UTC()
+
+    for attempted < arg.Limit {
+        events, err := q.listUnpublishedOutboxEventsAfter(ctx, lastID, arg.Limit)
+        if err != nil {
+            return published, err
+        }
+
+        if len(events) == 0 {
+            break
+        }
+
+        for _, e := range events {
+            lastID = e.ID
+
+            aggregate := outboxAggregate{aggregateType: e.AggregateType, aggregateID: e.AggregateID}
+            if blocked[aggregate] {
+                continue
+            }
+
+            // a failed event waits for its next attempt, the later events of its aggregate wait with it
+            if e.Attempts > 0 && e.NextAttemptAt.After(now) {
+                blocked[aggregate] = true
+                continue
+            }
+
+            if attempted == arg.Limit {
+                break
+            }
+            attempted++
+
+            if err := publish(ctx, e); err != nil {
+                blocked[aggregate] = true
+                if publishErr == nil {
+                    publishErr = err
+                }
+
+                failed, ferr := q.failOutboxEvent(ctx, failOutboxEventParams{
+                    ID:            e.ID,
+                    LastError:     err.Error(),
+                    NextAttemptAt: now.Add(arg.RetryBaseDelay * time.Duration(int64(1)<<uint(e.Attempts))),
+                    MaxAttempts:   arg.MaxAttempts,
+                })
+                if ferr != nil {
+                    return published, ferr
+                }
+
+                if failed.Status == domain.OutboxStatusDEAD {
+                    logging.FromContext(ctx).WithFields(log.Fields{
+                        "event_id":       failed.ID,
+                        "aggregate_type": failed.AggregateType,
+                        "aggregate_id":   failed.AggregateID,
+                        "event_type":     failed.EventType,
+                        "attempts":       failed.Attempts,
+                    }).Error("outbox event set aside after too many failed attempts: ", err)
+                }
+                continue
+            }
+
+            if _, err := q.MarkOutboxEventPublished(ctx, e.ID); err != nil {
+                return published, err
+            }
+            published++
+        }
+    }
+
+    return published, publishErr
+}
+
+const failOutboxEvent = `-- name: FailOutboxEvent :one
+UPDATE outbox
+SET attempts        = attempts + 1,
+    last_error      = $2,
+    next_attempt_at = $3,
+    status          = CASE WHEN attempts + 1 >= $4 THEN 'DEAD'::outbox_status ELSE status END
+WHERE id = $1 RETURNING id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+`
+
+type failOutboxEventParams struct {
+    ID            int64     `json:"id"`
+    LastError     string    `json:"last_error"`
+    NextAttemptAt time.Time `json:"next_attempt_at"`
+    MaxAttempts   int64     `json:"max_attempts"`
+}
+
+func (q *outboxRepository) failOutboxEvent(ctx context.Context, arg failOutboxEventParams) (domain.OutboxEvent, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, failOutboxEvent,
+        arg.ID,
+        arg.LastError,
+        arg.NextAttemptAt,
+        arg.MaxAttempts,
+    )
+    var i domain.OutboxEvent
+    err := row.Scan(
+        &i.ID,
+        &i.AggregateType,
+        &i.AggregateID,
+        &i.EventType,
+        &i.Payload,
+        &i.CreatedAt,
+        &i.PublishedAt,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastError,
+    )
+    return i, err
+}
+
+const listDeadOutboxEvents = `-- name: ListDeadOutboxEvents :many
+SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+FROM outbox
+WHERE status = 'DEAD'
+ORDER BY id LIMIT $1
+OFFSET $2
+`
+
+type ListDeadOutboxEventsParams struct {
+    Limit  int32 `json:"limit"`
+    Offset int32 `json:"offset"`
+}
+
+func (q *outboxRepository) ListDeadOutboxEvents(ctx context.Context, arg ListDeadOutboxEventsParams) ([]domain.OutboxEvent, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listDeadOutboxEvents, arg.Limit, arg.Offset)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.OutboxEvent{}
+    for rows.Next() {
+        var i domain.OutboxEvent
+        if err := rows.Scan(
+            &i.ID,
+            &i.AggregateType,
+            &i.AggregateID,
+            &i.EventType,
+            &i.Payload,
+            &i.CreatedAt,
+            &i.PublishedAt,
+            &i.Status,
+            &i.Attempts,
+            &i.NextAttemptAt,
+            &i.LastError,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const countDeadOutboxEvents = `-- name: CountDeadOutboxEvents :one
+SELECT count(*)
+FROM outbox
+WHERE status = 'DEAD'
+`
+
+func (q *outboxRepository) CountDeadOutboxEvents(ctx context.Context) (int64, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, countDeadOutboxEvents)
+    var count int64
+    err := row.Scan(&count)
+    return count, err
+}
+
+const retryOutboxEvent = `-- name: RetryOutboxEvent :one
+UPDATE outbox
+SET status          = 'PENDING',
+    attempts        = 0,
+    next_attempt_at = now(),
+    last_error      = ''
+WHERE id = $1
+  AND status = 'DEAD' RETURNING id, aggregate_type, aggregate_id, event_type, payload, created_at, published_at, status, attempts, next_attempt_at, last_error
+`
+
+// RetryOutboxEvent hands a DEAD event back to the relay, sql.ErrNoRows if there is no such dead event
+func (q *outboxRepository) RetryOutboxEvent(ctx context.Context, id int64) (domain.OutboxEvent, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, retryOutboxEvent, id)
+    var i domain.OutboxEvent
+    err := row.Scan(
+        &i.ID,
+        &i.AggregateType,
+        &i.AggregateID,
+        &i.EventType,
+        &i.Payload,
+        &i.CreatedAt,
+        &i.PublishedAt,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastError,
+    )
+    return i, err
+}
@@ -0,0 +1,202 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func createRandomOutboxEvent(t *testing.T) domain.OutboxEvent {
+    outboxRepo := store.NewOutboxRepo(testDb)
+
+    arg, err := store.NewCreateOutboxEventParams(domain.AggregateTypeWALLET, util.RandomInt(1, 1000), domain.EventTypeWalletCreated, map[string]string{"address": util.RandomString(8)})
+    require.NoError(t, err)
+
+    event, err := outboxRepo.CreateOutboxEvent(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, event)
+
+    require.Equal(t, arg.AggregateType, event.AggregateType)
+    require.Equal(t, arg.AggregateID, event.AggregateID)
+    require.Equal(t, arg.EventType, event.EventType)
+    require.JSONEq(t, arg.Payload, event.Payload)
+    require.False(t, event.PublishedAt.Valid)
+
+    require.NotZero(t, event.ID)
+    require.NotZero(t, event.CreatedAt)
+
+    return event
+}
+
+func TestCreateOutboxEvent(t *testing.T) {
+    createRandomOutboxEvent(t)
+}
+
+func TestMarkOutboxEventPublished(t *testing.T) {
+    outboxRepo := store.NewOutboxRepo(testDb)
+    event1 := createRandomOutboxEvent(t)
+
+    event2, err := outboxRepo.MarkOutboxEventPublished(context.Background(), event1.ID)
+    require.NoError(t, err)
+    require.True(t, event2.PublishedAt.Valid)
+
+    events, err := outboxRepo.ListUnpublishedOutboxEvents(context.Background(), 1000)
+    require.NoError(t, err)
+    for _, e := range events {
+        require.NotEqual(t, event1.ID, e.ID)
+    }
+}
+
+func TestOutboxEventRolledBackWithTx(t *testing.T) {
+    outboxRepo := store.NewOutboxRepo(testDb)
+
+    var event domain.OutboxEvent
+    err := store.ExecTx(context.Background(), testDb, func(ctx context.Context) error {
+        arg, err := store.NewCreateOutboxEventParams(domain.AggregateTypeWALLET, 1, domain.EventTypeWalletCreated, nil)
+        require.NoError(t, err)
+
+        event, err = outboxRepo.CreateOutboxEvent(ctx, arg)
+        require.NoError(t, err)
+
+        return sql.ErrTxDone
+    })
+    require.EqualError(t, err, sql.ErrTxDone.Error())
+
+    _, err = outboxRepo.MarkOutboxEventPublished(context.Background(), event.ID)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestRelayOutboxEvents(t *testing.T) {
+    outboxRepo := store.NewOutboxRepo(testDb)
+
+    event1 := createRandomOutboxEvent(t)
+    event2 := createRandomOutboxEvent(t)
+
+    var published []int64
+    publish := func(ctx context.Context, event domain.OutboxEvent) error {
+        published = append(published, event.ID)
+        return nil
+    }
+
+    for {
+        count, err := outboxRepo.RelayOutboxEvents(context.Background(), store.RelayOutboxEventsParams{Limit: 100, MaxAttempts: 10, RetryBaseDelay: time.Minute}, publish)
+        require.NoError(t, err)
+        if count == 0 {
+            break
+        }
+    }
+
+    require.Contains(t, published, event1.ID)
+    require.Contains(t, published, event2.ID)
+    for i := 1; i < len(published); i++ {
+        require.Less(t, published[i-1], published[i])
+    }
+}
+
+func TestRelayOutboxEventsHoldsBackFailedAggregate(t *testing.T) {
+    outboxRepo := store.NewOutboxRepo(testDb)
+
+    failing := createRandomOutboxEvent(t)
+    arg, err := store.NewCreateOutboxEventParams(failing.AggregateType, failing.AggregateID, domain.EventTypeWalletActivated, map[string]string{"address": util.RandomString(8)})
+    require.NoError(t, err)
+    held, err := outboxRepo.CreateOutboxEvent(context.Background(), arg)
+    require.NoError(t, err)
+
+    arg.AggregateID = failing.AggregateID + 1000
+    other, err := outboxRepo.CreateOutboxEvent(context.Background(), arg)
+    require.NoError(t, err)
+
+    var published []int64
+    for {
+        count, err := outboxRepo.RelayOutboxEvents(context.Background(), store.RelayOutboxEventsParams{Limit: 100, MaxAttempts: 10, RetryBaseDelay: time.Minute}, func(ctx context.Context, event domain.OutboxEvent) error {
+            // the handlers run outside of the relay, their queries don't join a transaction
+            _, inTx := ctx.Value(store.TxKey).(*sql.Tx)
+            require.False(t, inTx)
+
+            if event.ID == failing.ID {
+                return sql.ErrConnDone
+            }
+            published = append(published, event.ID)
+            return nil
+        })
+        require.EqualError(t, err, sql.ErrConnDone.Error())
+        if count == 0 {
+            break
+        }
+    }
+
+    require.Contains(t, published, other.ID)
+    require.NotContains(t, published, held.ID)
+
+    events, err := outboxRepo.ListUnpublishedOutboxEvents(context.Background(), 1000)
+    require.NoError(t, err)
+
+    var unpublished []int64
+    for _, e := range events {
+        unpublished = append(unpublished, e.ID)
+    }
+    require.Contains(t, unpublished, failing.ID)
+    require.Contains(t, unpublished, held.ID)
+}
+
+func TestRelayOutboxEventsSetsAsideDeadEvent(t *testing.T) {
+    outboxRepo := store.NewOutboxRepo(testDb)
+
+    failing := createRandomOutboxEvent(t)
+    arg, err := store.NewCreateOutboxEventParams(failing.AggregateType, failing.AggregateID, domain.EventTypeWalletActivated, map[string]string{"address": util.RandomString(8)})
+    require.NoError(t, err)
+    held, err := outboxRepo.CreateOutboxEvent(context.Background(), arg)
+    require.NoError(t, err)
+
+    relayArg := store.RelayOutboxEventsParams{Limit: 100, MaxAttempts: 2}
+    publish := func(ctx context.Context, event domain.OutboxEvent) error {
+        if event.ID == failing.ID {
+            return sql.ErrConnDone
+        }
+        return nil
+    }
+
+    // the event failing twice is set aside, the later event of its aggregate goes out after it
+    for i := 0; i < 2; i++ {
+        _, err = outboxRepo.RelayOutboxEvents(context.Background(), relayArg, publish)
+        require.EqualError(t, err, sql.ErrConnDone.Error())
+    }
+    _, err = outboxRepo.RelayOutboxEvents(context.Background(), relayArg, publish)
+    require.NoError(t, err)
+
+    dead, err := outboxRepo.ListDeadOutboxEvents(context.Background(), store.ListDeadOutboxEventsParams{Limit: 1000})
+    require.NoError(t, err)
+
+    var deadEvent domain.OutboxEvent
+    for _, e := range dead {
+        require.NotEqual(t, held.ID, e.ID)
+        if e.ID == failing.ID {
+            deadEvent = e
+        }
+    }
+    require.Equal(t, domain.OutboxStatusDEAD, deadEvent.Status)
+    require.Equal(t, int64(2), deadEvent.Attempts)
+    require.Equal(t, sql.ErrConnDone.Error(), deadEvent.LastError)
+    require.False(t, deadEvent.PublishedAt.Valid)
+
+    count, err := outboxRepo.CountDeadOutboxEvents(context.Background())
+    require.NoError(t, err)
+    require.GreaterOrEqual(t, count, int64(1))
+
+    retried, err := outboxRepo.RetryOutboxEvent(context.Background(), failing.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.OutboxStatusPENDING, retried.Status)
+    require.Zero(t, retried.Attempts)
+    require.Empty(t, retried.LastError)
+
+    _, err = outboxRepo.RetryOutboxEvent(context.Background(), failing.ID)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+
+    _, err = outboxRepo.RetryOutboxEvent(context.Background(), held.ID)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
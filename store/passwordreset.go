@@ -0,0 +1,172 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "time"
+)
+
+type PasswordResetRepo interface {
+    CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (domain.PasswordReset, error)
+    GetPasswordResetByHashedToken(ctx context.Context, hashedToken string) (domain.PasswordReset, error)
+    UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (domain.PasswordReset, error)
+    UsePasswordResetsOfUser(ctx context.Context, arg UsePasswordResetsOfUserParams) error
+    ResetPassword(ctx context.Context, arg ResetPasswordParams) (domain.User, error)
+    ChangePassword(ctx context.Context, arg ChangePasswordParams) (domain.User, error)
+}
+
+type passwordResetRepository struct {
+    db       *sql.DB
+    userRepo UserRepo
+}
+
+func NewPasswordResetRepo(db *sql.DB, userRepo UserRepo) PasswordResetRepo {
+    return &passwordResetRepository{
+        db:       db,
+        userRepo: userRepo,
+    }
+}
+
+const createPasswordReset = `-- name: CreatePasswordReset :one
+INSERT INTO password_resets (user_id,
+                             hashed_token,
+                             expires_at)
+VALUES ($1, $2, $3) RETURNING id, user_id, hashed_token, expires_at, used_at, created_at
+`
+
+type CreatePasswordResetParams struct {
+    UserID      int64     `json:"user_id"`
+    HashedToken string    `json:"hashed_token"`
+    ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *passwordResetRepository) CreatePasswordReset(ctx context.Context, arg CreatePasswordResetParams) (domain.PasswordReset, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createPasswordReset, arg.UserID, arg.HashedToken, arg.ExpiresAt)
+    return scanPasswordReset(row)
+}
+
+const getPasswordResetByHashedToken = `-- name: GetPasswordResetByHashedToken :one
+SELECT id, user_id, hashed_token, expires_at, used_at, created_at
+FROM password_resets
+WHERE hashed_token = $1 LIMIT 1
+`
+
+func (q *passwordResetRepository) GetPasswordResetByHashedToken(ctx context.Context, hashedToken string) (domain.PasswordReset, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getPasswordResetByHashedToken, hashedToken)
+    return scanPasswordReset(row)
+}
+
+const usePasswordReset = `-- name: UsePasswordReset :one
+UPDATE password_resets
+SET used_at = $2
+WHERE id = $1
+  AND used_at IS NULL RETURNING id, user_id, hashed_token, expires_at, used_at, created_at
+`
+
+type UsePasswordResetParams struct {
+    ID     int64     `json:"id"`
+    UsedAt time.Time `json:"used_at"`
+}
+
+// UsePasswordReset marks the reset used, sql.ErrNoRows when it already is.
+func (q *passwordResetRepository) UsePasswordReset(ctx context.Context, arg UsePasswordResetParams) (domain.PasswordReset, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, usePasswordReset, arg.ID, arg.UsedAt)
+    return scanPasswordReset(row)
+}
+
+const usePasswordResetsOfUser = `-- name: UsePasswordResetsOfUser :exec
+UPDATE password_resets
+SET used_at = $2
+WHERE user_id = $1
+  AND used_at IS NULL
+`
+
+type UsePasswordResetsOfUserParams struct {
+    UserID int64     `json:"user_id"`
+    UsedAt time.Time `json:"used_at"`
+}
+
+// UsePasswordResetsOfUser marks the resets of the user not used yet used, they can't reset the password anymore.
+func (q *passwordResetRepository) UsePasswordResetsOfUser(ctx context.Context, arg UsePasswordResetsOfUserParams) error {
+    _, err := conn(ctx, q.db).ExecContext(ctx, usePasswordResetsOfUser, arg.UserID, arg.UsedAt)
+    return err
+}
+
+type ResetPasswordParams struct {
+    PasswordResetID int64     `json:"password_reset_id"`
+    HashedPassword  string    `json:"hashed_password"`
+    ResetAt         time.Time `json:"reset_at"`
+}
+
+// ResetPassword uses the reset and sets the password of its user in a transaction, the other resets of the user
+// are used with it. The reset used by a concurrent request fails with ErrInvalidPasswordResetToken.
+func (q *passwordResetRepository) ResetPassword(ctx context.Context, arg ResetPasswordParams) (domain.User, error) {
+    var user domain.User
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        reset, err := q.UsePasswordReset(ctx, UsePasswordResetParams{ID: arg.PasswordResetID, UsedAt: arg.ResetAt})
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return errors.ErrInvalidPasswordResetToken
+            }
+            return err
+        }
+
+        err = q.UsePasswordResetsOfUser(ctx, UsePasswordResetsOfUserParams{UserID: reset.UserID, UsedAt: arg.ResetAt})
+        if err != nil {
+            return err
+        }
+
+        user, err = q.userRepo.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+            ID:                reset.UserID,
+            HashedPassword:    arg.HashedPassword,
+            PasswordChangedAt: arg.ResetAt,
+        })
+        return err
+    })
+
+    return user, err
+}
+
+type ChangePasswordParams struct {
+    UserID         int64     `json:"user_id"`
+    HashedPassword string    `json:"hashed_password"`
+    ChangedAt      time.Time `json:"changed_at"`
+}
+
+// ChangePassword sets the password of the user and uses the resets of the user not used yet in a transaction, so
+// that a reset requested before the change can't set the password back.
+func (q *passwordResetRepository) ChangePassword(ctx context.Context, arg ChangePasswordParams) (domain.User, error) {
+    var user domain.User
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        err := q.UsePasswordResetsOfUser(ctx, UsePasswordResetsOfUserParams{UserID: arg.UserID, UsedAt: arg.ChangedAt})
+        if err != nil {
+            return err
+        }
+
+        user, err = q.userRepo.UpdateUserPassword(ctx, UpdateUserPasswordParams{
+            ID:                arg.UserID,
+            HashedPassword:    arg.HashedPassword,
+            PasswordChangedAt: arg.ChangedAt,
+        })
+        return err
+    })
+
+    return user, err
+}
+
+func scanPasswordReset(row *sql.Row) (domain.PasswordReset, error) {
+    var i domain.PasswordReset
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.HashedToken,
+        &i.ExpiresAt,
+        &i.UsedAt,
+        &i.CreatedAt,
+    )
+    return i, err
+}
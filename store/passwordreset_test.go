@@ -0,0 +1,95 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func createRandomPasswordReset(t *testing.T, user domain.User) domain.PasswordReset {
+    token, err := util.GenerateToken()
+    require.NoError(t, err)
+
+    arg := store.CreatePasswordResetParams{
+        UserID:      user.ID,
+        HashedToken: util.HashToken(token),
+        ExpiresAt:   time.Now().UTC().Add(time.Hour),
+    }
+
+    reset, err := store.NewPasswordResetRepo(testDb, store.NewUserRepo(testDb)).CreatePasswordReset(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, reset.ID)
+    require.Equal(t, arg.UserID, reset.UserID)
+    require.Equal(t, arg.HashedToken, reset.HashedToken)
+    require.WithinDuration(t, arg.ExpiresAt, reset.ExpiresAt, time.Second)
+    require.False(t, reset.UsedAt.Valid)
+
+    return reset
+}
+
+func TestGetPasswordResetByHashedToken(t *testing.T) {
+    reset1 := createRandomPasswordReset(t, createRandomUser(t))
+
+    reset2, err := store.NewPasswordResetRepo(testDb, store.NewUserRepo(testDb)).GetPasswordResetByHashedToken(context.Background(), reset1.HashedToken)
+    require.NoError(t, err)
+    require.Equal(t, reset1.ID, reset2.ID)
+    require.Equal(t, reset1.UserID, reset2.UserID)
+}
+
+func TestResetPassword(t *testing.T) {
+    passwordResetRepo := store.NewPasswordResetRepo(testDb, store.NewUserRepo(testDb))
+    user := createRandomUser(t)
+    reset := createRandomPasswordReset(t, user)
+    other := createRandomPasswordReset(t, user)
+
+    hashedPassword, err := util.HashPassword(util.RandomString(6))
+    require.NoError(t, err)
+
+    arg := store.ResetPasswordParams{
+        PasswordResetID: reset.ID,
+        HashedPassword:  hashedPassword,
+        ResetAt:         time.Now().UTC().Truncate(time.Microsecond),
+    }
+    updated, err := passwordResetRepo.ResetPassword(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, user.ID, updated.ID)
+    require.Equal(t, hashedPassword, updated.HashedPassword)
+    require.True(t, arg.ResetAt.Equal(updated.PasswordChangedAt))
+
+    // single use, and the other resets of the user are used with it
+    _, err = passwordResetRepo.ResetPassword(context.Background(), arg)
+    require.ErrorIs(t, err, errors.ErrInvalidPasswordResetToken)
+
+    other, err = passwordResetRepo.GetPasswordResetByHashedToken(context.Background(), other.HashedToken)
+    require.NoError(t, err)
+    require.True(t, other.UsedAt.Valid)
+}
+
+func TestChangePassword(t *testing.T) {
+    passwordResetRepo := store.NewPasswordResetRepo(testDb, store.NewUserRepo(testDb))
+    user := createRandomUser(t)
+    reset := createRandomPasswordReset(t, user)
+
+    hashedPassword, err := util.HashPassword(util.RandomString(6))
+    require.NoError(t, err)
+
+    arg := store.ChangePasswordParams{
+        UserID:         user.ID,
+        HashedPassword: hashedPassword,
+        ChangedAt:      time.Now().UTC().Truncate(time.Microsecond),
+    }
+    updated, err := passwordResetRepo.ChangePassword(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, hashedPassword, updated.HashedPassword)
+    require.True(t, arg.ChangedAt.Equal(updated.PasswordChangedAt))
+
+    // the reset requested before the change can't be used anymore
+    reset, err = passwordResetRepo.GetPasswordResetByHashedToken(context.Background(), reset.HashedToken)
+    require.NoError(t, err)
+    require.True(t, reset.UsedAt.Valid)
+}
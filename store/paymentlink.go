@@ -0,0 +1,203 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type PaymentLinkRepo interface {
+    CreatePaymentLink(ctx context.Context, arg CreatePaymentLinkParams) (domain.PaymentLink, error)
+    GetPaymentLink(ctx context.Context, id int64) (domain.PaymentLink, error)
+    GetPaymentLinkBySlug(ctx context.Context, slug string) (domain.PaymentLink, error)
+    GetPaymentLinkForUpdate(ctx context.Context, id int64) (domain.PaymentLink, error)
+    ListPaymentLinks(ctx context.Context, arg ListPaymentLinksParams) ([]domain.PaymentLink, error)
+    UpdatePaymentLinkStatus(ctx context.Context, arg UpdatePaymentLinkStatusParams) (domain.PaymentLink, error)
+    AddPaymentLinkUse(ctx context.Context, id int64) (domain.PaymentLink, error)
+}
+
+type paymentLinkRepository struct {
+    db *sql.DB
+}
+
+func NewPaymentLinkRepo(client *sql.DB) PaymentLinkRepo {
+    return &paymentLinkRepository{
+        db: client,
+    }
+}
+
+const createPaymentLink = `-- name: CreatePaymentLink :one
+INSERT INTO payment_links (merchant_id,
+                           slug,
+                           description,
+                           amount,
+                           currency,
+                           mode,
+                           status,
+                           success_url,
+                           expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+`
+
+type CreatePaymentLinkParams struct {
+    MerchantID  int64                    `json:"merchant_id"`
+    Slug        string                   `json:"slug"`
+    Description string                   `json:"description"`
+    Amount      int64                    `json:"amount"`
+    Currency    string                   `json:"currency"`
+    Mode        domain.PaymentLinkMode   `json:"mode"`
+    Status      domain.PaymentLinkStatus `json:"status"`
+    SuccessUrl  string                   `json:"success_url"`
+    ExpiresAt   sql.NullTime             `json:"expires_at"`
+}
+
+func (q *paymentLinkRepository) CreatePaymentLink(ctx context.Context, arg CreatePaymentLinkParams) (domain.PaymentLink, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createPaymentLink,
+        arg.MerchantID,
+        arg.Slug,
+        arg.Description,
+        arg.Amount,
+        arg.Currency,
+        arg.Mode,
+        arg.Status,
+        arg.SuccessUrl,
+        arg.ExpiresAt,
+    )
+    return scanPaymentLink(row)
+}
+
+const getPaymentLink = `-- name: GetPaymentLink :one
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE id = $1 LIMIT 1
+`
+
+func (q *paymentLinkRepository) GetPaymentLink(ctx context.Context, id int64) (domain.PaymentLink, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getPaymentLink, id)
+    return scanPaymentLink(row)
+}
+
+const getPaymentLinkBySlug = `-- name: GetPaymentLinkBySlug :one
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE slug = $1 LIMIT 1
+`
+
+func (q *paymentLinkRepository) GetPaymentLinkBySlug(ctx context.Context, slug string) (domain.PaymentLink, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getPaymentLinkBySlug, slug)
+    return scanPaymentLink(row)
+}
+
+const getPaymentLinkForUpdate = `-- name: GetPaymentLinkForUpdate :one
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *paymentLinkRepository) GetPaymentLinkForUpdate(ctx context.Context, id int64) (domain.PaymentLink, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getPaymentLinkForUpdate, id)
+    return scanPaymentLink(row)
+}
+
+const listPaymentLinks = `-- name: ListPaymentLinks :many
+SELECT id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+FROM payment_links
+WHERE merchant_id = $1
+ORDER BY id DESC LIMIT $2
+OFFSET $3
+`
+
+type ListPaymentLinksParams struct {
+    MerchantID int64 `json:"merchant_id"`
+    Limit      int32 `json:"limit"`
+    Offset     int32 `json:"offset"`
+}
+
+func (q *paymentLinkRepository) ListPaymentLinks(ctx context.Context, arg ListPaymentLinksParams) ([]domain.PaymentLink, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listPaymentLinks, arg.MerchantID, arg.Limit, arg.Offset)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.PaymentLink{}
+    for rows.Next() {
+        var i domain.PaymentLink
+        if err := rows.Scan(
+            &i.ID,
+            &i.MerchantID,
+            &i.Slug,
+            &i.Description,
+            &i.Amount,
+            &i.Currency,
+            &i.Mode,
+            &i.Status,
+            &i.SuccessUrl,
+            &i.Uses,
+            &i.ExpiresAt,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const updatePaymentLinkStatus = `-- name: UpdatePaymentLinkStatus :one
+UPDATE payment_links
+SET status     = $2,
+    updated_at = now()
+WHERE id = $1 RETURNING id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+`
+
+type UpdatePaymentLinkStatusParams struct {
+    ID     int64                    `json:"id"`
+    Status domain.PaymentLinkStatus `json:"status"`
+}
+
+func (q *paymentLinkRepository) UpdatePaymentLinkStatus(ctx context.Context, arg UpdatePaymentLinkStatusParams) (domain.PaymentLink, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updatePaymentLinkStatus, arg.ID, arg.Status)
+    return scanPaymentLink(row)
+}
+
+const addPaymentLinkUse = `-- name: AddPaymentLinkUse :one
+UPDATE payment_links
+SET uses       = uses + 1,
+    status     = CASE WHEN mode = 'SINGLE_USE' THEN 'COMPLETED'::payment_link_status ELSE status END,
+    updated_at = now()
+WHERE id = $1 RETURNING id, merchant_id, slug, description, amount, currency, mode, status, success_url, uses, expires_at, created_at, updated_at
+`
+
+// AddPaymentLinkUse counts a completed payment, it completes the single use links.
+func (q *paymentLinkRepository) AddPaymentLinkUse(ctx context.Context, id int64) (domain.PaymentLink, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, addPaymentLinkUse, id)
+    return scanPaymentLink(row)
+}
+
+func scanPaymentLink(row *sql.Row) (domain.PaymentLink, error) {
+    var i domain.PaymentLink
+    err := row.Scan(
+        &i.ID,
+        &i.MerchantID,
+        &i.Slug,
+        &i.Description,
+        &i.Amount,
+        &i.Currency,
+        &i.Mode,
+        &i.Status,
+        &i.SuccessUrl,
+        &i.Uses,
+        &i.ExpiresAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
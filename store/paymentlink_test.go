@@ -0,0 +1,109 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func createRandomPaymentLink(t *testing.T, merchant domain.Merchant, amount int64, mode domain.PaymentLinkMode, expiresAt sql.NullTime) domain.PaymentLink {
+    arg := store.CreatePaymentLinkParams{
+        MerchantID:  merchant.ID,
+        Slug:        util.RandomString(12),
+        Description: util.RandomString(20),
+        Amount:      amount,
+        Currency:    "INR",
+        Mode:        mode,
+        Status:      domain.PaymentLinkStatusACTIVE,
+        SuccessUrl:  "https://" + util.RandomString(8) + ".example.com/thanks",
+        ExpiresAt:   expiresAt,
+    }
+
+    paymentLinkRepo := store.NewPaymentLinkRepo(testDb)
+    link, err := paymentLinkRepo.CreatePaymentLink(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, link.ID)
+    require.Equal(t, arg.MerchantID, link.MerchantID)
+    require.Equal(t, arg.Slug, link.Slug)
+    require.Equal(t, arg.Amount, link.Amount)
+    require.Equal(t, arg.Currency, link.Currency)
+    require.Equal(t, arg.Mode, link.Mode)
+    require.Equal(t, arg.Status, link.Status)
+    require.Equal(t, arg.SuccessUrl, link.SuccessUrl)
+    require.Zero(t, link.Uses)
+    require.Equal(t, arg.ExpiresAt.Valid, link.ExpiresAt.Valid)
+    require.NotZero(t, link.CreatedAt)
+
+    return link
+}
+
+func TestGetPaymentLink(t *testing.T) {
+    paymentLinkRepo := store.NewPaymentLinkRepo(testDb)
+    link := createRandomPaymentLink(t, createRandomMerchant(t), 500, domain.PaymentLinkModeMULTIUSE, sql.NullTime{})
+
+    link1, err := paymentLinkRepo.GetPaymentLink(context.Background(), link.ID)
+    require.NoError(t, err)
+    require.Equal(t, link.Slug, link1.Slug)
+
+    link2, err := paymentLinkRepo.GetPaymentLinkBySlug(context.Background(), link.Slug)
+    require.NoError(t, err)
+    require.Equal(t, link.ID, link2.ID)
+
+    _, err = paymentLinkRepo.GetPaymentLinkBySlug(context.Background(), "missing")
+    require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListPaymentLinks(t *testing.T) {
+    paymentLinkRepo := store.NewPaymentLinkRepo(testDb)
+    merchant := createRandomMerchant(t)
+
+    for i := 0; i < 3; i++ {
+        createRandomPaymentLink(t, merchant, 0, domain.PaymentLinkModeMULTIUSE, sql.NullTime{Time: time.Now().UTC().Add(time.Hour), Valid: true})
+    }
+
+    links, err := paymentLinkRepo.ListPaymentLinks(context.Background(), store.ListPaymentLinksParams{
+        MerchantID: merchant.ID,
+        Limit:      2,
+        Offset:     1,
+    })
+    require.NoError(t, err)
+    require.Len(t, links, 2)
+
+    for _, link := range links {
+        require.Equal(t, merchant.ID, link.MerchantID)
+    }
+}
+
+func TestAddPaymentLinkUse(t *testing.T) {
+    paymentLinkRepo := store.NewPaymentLinkRepo(testDb)
+    merchant := createRandomMerchant(t)
+
+    multiUse := createRandomPaymentLink(t, merchant, 500, domain.PaymentLinkModeMULTIUSE, sql.NullTime{})
+    multiUse, err := paymentLinkRepo.AddPaymentLinkUse(context.Background(), multiUse.ID)
+    require.NoError(t, err)
+    require.Equal(t, int64(1), multiUse.Uses)
+    require.Equal(t, domain.PaymentLinkStatusACTIVE, multiUse.Status)
+
+    singleUse := createRandomPaymentLink(t, merchant, 500, domain.PaymentLinkModeSINGLEUSE, sql.NullTime{})
+    singleUse, err = paymentLinkRepo.AddPaymentLinkUse(context.Background(), singleUse.ID)
+    require.NoError(t, err)
+    require.Equal(t, int64(1), singleUse.Uses)
+    require.Equal(t, domain.PaymentLinkStatusCOMPLETED, singleUse.Status)
+}
+
+func TestUpdatePaymentLinkStatus(t *testing.T) {
+    paymentLinkRepo := store.NewPaymentLinkRepo(testDb)
+    link := createRandomPaymentLink(t, createRandomMerchant(t), 500, domain.PaymentLinkModeMULTIUSE, sql.NullTime{})
+
+    link, err := paymentLinkRepo.UpdatePaymentLinkStatus(context.Background(), store.UpdatePaymentLinkStatusParams{
+        ID:     link.ID,
+        Status: domain.PaymentLinkStatusDISABLED,
+    })
+    require.NoError(t, err)
+    require.Equal(t, domain.PaymentLinkStatusDISABLED, link.Status)
+}
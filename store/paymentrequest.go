@@ -10,19 +10,33 @@ type PaymentRequestRepo interface {
     CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (domain.PaymentRequest, error)
     ListPaymentRequests(ctx context.Context, arg ListPaymentRequestsParams) ([]domain.PaymentRequest, error)
     UpdatePaymentRequest(ctx context.Context, arg UpdatePaymentRequestParams) (domain.PaymentRequest, error)
+    ApprovePaymentRequest(ctx context.Context, id int64) (domain.PaymentRequest, error)
     GetPaymentRequest(ctx context.Context, id int64) (domain.PaymentRequest, error)
 }
 
 type paymentRequestRepository struct {
-    db *sql.DB
+    db         *sql.DB
+    walletRepo WalletRepo
+    outboxRepo OutboxRepo
 }
 
-func NewPaymentRequestRepo(client *sql.DB) PaymentRequestRepo {
+// NewPaymentRequestRepo records a PaymentRequestStatusChanged outbox event with every status a payment request
+// enters, its creation included.
+func NewPaymentRequestRepo(client *sql.DB, walletRepo WalletRepo, outboxRepo OutboxRepo) PaymentRequestRepo {
     return &paymentRequestRepository{
-        db: client,
+        db:         client,
+        walletRepo: walletRepo,
+        outboxRepo: outboxRepo,
     }
 }
 
+// PaymentRequestResult is the payload of the outbox events of a payment request.
+type PaymentRequestResult struct {
+    PaymentRequest domain.PaymentRequest `json:"payment_request"`
+    FromWallet     domain.Wallet         `json:"from_wallet"`
+    ToWallet       domain.Wallet         `json:"to_wallet"`
+}
+
 const createPaymentRequest = `-- name: CreatePaymentRequest :one
 INSERT INTO payment_requests (
                        from_wallet_id,
@@ -40,21 +54,30 @@ type CreatePaymentRequestParams struct {
 }
 
 func (q *paymentRequestRepository) CreatePaymentRequest(ctx context.Context, arg CreatePaymentRequestParams) (domain.PaymentRequest, error) {
-    row := q.db.QueryRowContext(ctx, createPaymentRequest,
-        arg.FromWalletID,
-        arg.ToWalletID,
-        arg.Amount,
-        arg.Status,
-    )
     var i domain.PaymentRequest
-    err := row.Scan(
-        &i.ID,
-        &i.FromWalletID,
-        &i.ToWalletID,
-        &i.Amount,
-        &i.Status,
-        &i.CreatedAt,
-    )
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        row := conn(ctx, q.db).QueryRowContext(ctx, createPaymentRequest,
+            arg.FromWalletID,
+            arg.ToWalletID,
+            arg.Amount,
+            arg.Status,
+        )
+        err := row.Scan(
+            &i.ID,
+            &i.FromWalletID,
+            &i.ToWalletID,
+            &i.Amount,
+            &i.Status,
+            &i.CreatedAt,
+        )
+        if err != nil {
+            return err
+        }
+
+        return q.createStatusChangedEvent(ctx, i)
+    })
+
     return i, err
 }
 
@@ -73,7 +96,7 @@ type ListPaymentRequestsParams struct {
 }
 
 func (q *paymentRequestRepository) ListPaymentRequests(ctx context.Context, arg ListPaymentRequestsParams) ([]domain.PaymentRequest, error) {
-    rows, err := q.db.QueryContext(ctx, listPaymentRequests, arg.FromWalletID, arg.Limit, arg.Offset)
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listPaymentRequests, arg.FromWalletID, arg.Limit, arg.Offset)
     if err != nil {
         return nil, err
     }
@@ -115,16 +138,58 @@ type UpdatePaymentRequestParams struct {
 }
 
 func (q *paymentRequestRepository) UpdatePaymentRequest(ctx context.Context, arg UpdatePaymentRequestParams) (domain.PaymentRequest, error) {
-    row := q.db.QueryRowContext(ctx, updatePaymentRequest, arg.Status, arg.ID)
     var i domain.PaymentRequest
-    err := row.Scan(
-        &i.ID,
-        &i.FromWalletID,
-        &i.ToWalletID,
-        &i.Amount,
-        &i.Status,
-        &i.CreatedAt,
-    )
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        row := conn(ctx, q.db).QueryRowContext(ctx, updatePaymentRequest, arg.Status, arg.ID)
+        err := row.Scan(
+            &i.ID,
+            &i.FromWalletID,
+            &i.ToWalletID,
+            &i.Amount,
+            &i.Status,
+            &i.CreatedAt,
+        )
+        if err != nil {
+            return err
+        }
+
+        return q.createStatusChangedEvent(ctx, i)
+    })
+
+    return i, err
+}
+
+const approvePaymentRequest = `-- name: ApprovePaymentRequest :one
+UPDATE payment_requests
+set Status = 'APPROVED'
+where id = $1
+  and status = 'WAITING_APPROVAL'
+RETURNING id, from_wallet_id, to_wallet_id, amount, status, created_at
+`
+
+// ApprovePaymentRequest moves a request waiting for approval to APPROVED, it fails with sql.ErrNoRows when the
+// request doesn't exist or was approved or refused already, so only one approval pays it.
+func (q *paymentRequestRepository) ApprovePaymentRequest(ctx context.Context, id int64) (domain.PaymentRequest, error) {
+    var i domain.PaymentRequest
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        row := conn(ctx, q.db).QueryRowContext(ctx, approvePaymentRequest, id)
+        err := row.Scan(
+            &i.ID,
+            &i.FromWalletID,
+            &i.ToWalletID,
+            &i.Amount,
+            &i.Status,
+            &i.CreatedAt,
+        )
+        if err != nil {
+            return err
+        }
+
+        return q.createStatusChangedEvent(ctx, i)
+    })
+
     return i, err
 }
 
@@ -135,7 +200,7 @@ where id = $1 LIMIT 1
 `
 
 func (q *paymentRequestRepository) GetPaymentRequest(ctx context.Context, id int64) (domain.PaymentRequest, error) {
-    row := q.db.QueryRowContext(ctx, getPaymentRequest, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getPaymentRequest, id)
     var i domain.PaymentRequest
     err := row.Scan(
         &i.ID,
@@ -147,3 +212,28 @@ func (q *paymentRequestRepository) GetPaymentRequest(ctx context.Context, id int
     )
     return i, err
 }
+
+func (q *paymentRequestRepository) createStatusChangedEvent(ctx context.Context, payReq domain.PaymentRequest) error {
+    res := PaymentRequestResult{
+        PaymentRequest: payReq,
+    }
+
+    var err error
+    res.FromWallet, err = q.walletRepo.GetWallet(ctx, payReq.FromWalletID)
+    if err != nil {
+        return err
+    }
+
+    res.ToWallet, err = q.walletRepo.GetWallet(ctx, payReq.ToWalletID)
+    if err != nil {
+        return err
+    }
+
+    event, err := NewCreateOutboxEventParams(domain.AggregateTypePAYMENTREQUEST, payReq.ID, domain.EventTypePaymentRequestStatusChanged, res)
+    if err != nil {
+        return err
+    }
+
+    _, err = q.outboxRepo.CreateOutboxEvent(ctx, event)
+    return err
+}
@@ -2,6 +2,7 @@ package store_test
 
 import (
     "context"
+    "database/sql"
     "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/store"
     "github.com/pranayhere/simple-wallet/util"
@@ -9,8 +10,15 @@ import (
     "testing"
 )
 
+func InitPaymentRequestRepo(t *testing.T) store.PaymentRequestRepo {
+    payReqRepo := store.NewPaymentRequestRepo(testDb, InitWalletRepo(t), store.NewOutboxRepo(testDb))
+    require.NotEmpty(t, payReqRepo)
+
+    return payReqRepo
+}
+
 func createRandomPaymentRequest(t *testing.T, wallet1, wallet2 domain.Wallet) domain.PaymentRequest {
-    payReqRepo := store.NewPaymentRequestRepo(testDb)
+    payReqRepo := InitPaymentRequestRepo(t)
     arg := store.CreatePaymentRequestParams{
         FromWalletID: wallet1.ID,
         ToWalletID:   wallet2.ID,
@@ -40,7 +48,7 @@ func TestCreatePaymentRequest(t *testing.T) {
 }
 
 func TestListPaymentRequests(t *testing.T) {
-    payReqRepo := store.NewPaymentRequestRepo(testDb)
+    payReqRepo := InitPaymentRequestRepo(t)
 
     var lastPayReq domain.PaymentRequest
     for i := 0; i < 5; i++ {
@@ -66,7 +74,7 @@ func TestListPaymentRequests(t *testing.T) {
 }
 
 func TestPaymentRequestStatus(t *testing.T) {
-    payReqRepo := store.NewPaymentRequestRepo(testDb)
+    payReqRepo := InitPaymentRequestRepo(t)
     wallet1 := createRandomWallet(t)
     wallet2 := createRandomWallet(t)
 
@@ -84,3 +92,15 @@ func TestPaymentRequestStatus(t *testing.T) {
 
     require.Equal(t, domain.PaymentRequestStatusAPPROVED, payReq2.Status)
 }
+
+func TestApprovePaymentRequestOnce(t *testing.T) {
+    payReqRepo := InitPaymentRequestRepo(t)
+    payReq := createRandomPaymentRequest(t, createRandomWallet(t), createRandomWallet(t))
+
+    approved, err := payReqRepo.ApprovePaymentRequest(context.Background(), payReq.ID)
+    require.NoError(t, err)
+    require.Equal(t, domain.PaymentRequestStatusAPPROVED, approved.Status)
+
+    _, err = payReqRepo.ApprovePaymentRequest(context.Background(), payReq.ID)
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
@@ -0,0 +1,438 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type ReconciliationRepo interface {
+    ListWalletBalanceMismatches(ctx context.Context) ([]WalletBalanceMismatch, error)
+    ListUnbalancedTransfers(ctx context.Context) ([]UnbalancedTransfer, error)
+    ListCurrencyTotals(ctx context.Context) ([]CurrencyTotal, error)
+    CountTransfers(ctx context.Context) (int64, error)
+    CreateReconciliationReport(ctx context.Context, arg CreateReconciliationReportParams) (domain.ReconciliationReport, error)
+    CreateReconciliationDiscrepancy(ctx context.Context, arg CreateReconciliationDiscrepancyParams) (domain.ReconciliationDiscrepancy, error)
+    GetReconciliationReport(ctx context.Context, id int64) (domain.ReconciliationReport, error)
+    ListReconciliationReports(ctx context.Context, arg ListReconciliationReportsParams) ([]domain.ReconciliationReport, error)
+    ListReconciliationDiscrepancies(ctx context.Context, reportID int64) ([]domain.ReconciliationDiscrepancy, error)
+    SaveReconciliationReport(ctx context.Context, arg SaveReconciliationReportParams) (ReconciliationReportResult, error)
+}
+
+type reconciliationRepository struct {
+    db *sql.DB
+}
+
+func NewReconciliationRepo(client *sql.DB) ReconciliationRepo {
+    return &reconciliationRepository{
+        db: client,
+    }
+}
+
+const listWalletBalanceMismatches = `-- name: ListWalletBalanceMismatches :many
+SELECT w.id, w.address, w.currency, w.balance, (w.opening_balance + COALESCE(SUM(e.amount), 0))::bigint AS ledger_balance
+FROM wallets w
+         LEFT JOIN entries e ON e.wallet_id = w.id
+GROUP BY w.id
+HAVING w.balance <> w.opening_balance + COALESCE(SUM(e.amount), 0)
+ORDER BY w.id
+`
+
+type WalletBalanceMismatch struct {
+    WalletID      int64  `json:"wallet_id"`
+    Address       string `json:"address"`
+    Currency      string `json:"currency"`
+    Balance       int64  `json:"balance"`
+    LedgerBalance int64  `json:"ledger_balance"`
+}
+
+// ListWalletBalanceMismatches returns the wallets whose balance is not their opening balance plus the sum of their entries.
+func (q *reconciliationRepository) ListWalletBalanceMismatches(ctx context.Context) ([]WalletBalanceMismatch, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listWalletBalanceMismatches)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []WalletBalanceMismatch{}
+    for rows.Next() {
+        var i WalletBalanceMismatch
+        if err := rows.Scan(
+            &i.WalletID,
+            &i.Address,
+            &i.Currency,
+            &i.Balance,
+            &i.LedgerBalance,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const listUnbalancedTransfers = `-- name: ListUnbalancedTransfers :many
+SELECT t.id, t.from_wallet_id, t.to_wallet_id, t.amount, w.currency, COUNT(e.id) AS entry_count, COALESCE(SUM(e.amount), 0)::bigint AS entries_total
+FROM transfers t
+         JOIN wallets w ON w.id = t.from_wallet_id
+         LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id, w.currency
+HAVING COUNT(e.id) <> 2
+    OR COUNT(e.id) FILTER (WHERE e.wallet_id = t.from_wallet_id AND e.amount = -t.amount) <> 1
+    OR COUNT(e.id) FILTER (WHERE e.wallet_id = t.to_wallet_id AND e.amount = t.amount) <> 1
+ORDER BY t.id
+`
+
+type UnbalancedTransfer struct {
+    TransferID   int64  `json:"transfer_id"`
+    FromWalletID int64  `json:"from_wallet_id"`
+    ToWalletID   int64  `json:"to_wallet_id"`
+    Amount       int64  `json:"amount"`
+    Currency     string `json:"currency"`
+    EntryCount   int64  `json:"entry_count"`
+    EntriesTotal int64  `json:"entries_total"`
+}
+
+// ListUnbalancedTransfers returns the transfers that are not recorded by exactly one debit of the sender and one
+// credit of the receiver, both of the transfer amount.
+func (q *reconciliationRepository) ListUnbalancedTransfers(ctx context.Context) ([]UnbalancedTransfer, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listUnbalancedTransfers)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []UnbalancedTransfer{}
+    for rows.Next() {
+        var i UnbalancedTransfer
+        if err := rows.Scan(
+            &i.TransferID,
+            &i.FromWalletID,
+            &i.ToWalletID,
+            &i.Amount,
+            &i.Currency,
+            &i.EntryCount,
+            &i.EntriesTotal,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const listCurrencyTotals = `-- name: ListCurrencyTotals :many
+SELECT w.currency,
+       COUNT(*)                                                                        AS wallet_count,
+       COALESCE(SUM(w.balance) FILTER (WHERE w.id <> w.organization_wallet_id), 0)::bigint AS user_balance,
+       COALESCE(SUM(w.balance) FILTER (WHERE w.id = w.organization_wallet_id), 0)::bigint  AS organization_balance,
+       COALESCE(SUM(w.opening_balance), 0)::bigint                                        AS opening_balance,
+       (SELECT COALESCE(SUM(e.amount), 0)
+        FROM entries e
+                 JOIN wallets ew ON ew.id = e.wallet_id
+        WHERE ew.currency = w.currency)::bigint                                          AS entries_total
+FROM wallets w
+GROUP BY w.currency
+ORDER BY w.currency
+`
+
+// CurrencyTotal sums the wallets of a currency. The money held by the users and the organization wallets
+// together must be the money the wallets were opened with, and the entries must net to zero.
+type CurrencyTotal struct {
+    Currency            string `json:"currency"`
+    WalletCount         int64  `json:"wallet_count"`
+    UserBalance         int64  `json:"user_balance"`
+    OrganizationBalance int64  `json:"organization_balance"`
+    OpeningBalance      int64  `json:"opening_balance"`
+    EntriesTotal        int64  `json:"entries_total"`
+}
+
+func (q *reconciliationRepository) ListCurrencyTotals(ctx context.Context) ([]CurrencyTotal, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listCurrencyTotals)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []CurrencyTotal{}
+    for rows.Next() {
+        var i CurrencyTotal
+        if err := rows.Scan(
+            &i.Currency,
+            &i.WalletCount,
+            &i.UserBalance,
+            &i.OrganizationBalance,
+            &i.OpeningBalance,
+            &i.EntriesTotal,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const countTransfers = `-- name: CountTransfers :one
+SELECT COUNT(*)
+FROM transfers
+`
+
+func (q *reconciliationRepository) CountTransfers(ctx context.Context) (int64, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, countTransfers)
+    var count int64
+    err := row.Scan(&count)
+    return count, err
+}
+
+const createReconciliationReport = `-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (status,
+                                    wallets_checked,
+                                    transfers_checked,
+                                    currencies_checked,
+                                    discrepancy_count,
+                                    started_at)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, status, wallets_checked, transfers_checked, currencies_checked, discrepancy_count, started_at, finished_at
+`
+
+type CreateReconciliationReportParams struct {
+    Status            domain.ReconciliationStatus `json:"status"`
+    WalletsChecked    int64                       `json:"wallets_checked"`
+    TransfersChecked  int64                       `json:"transfers_checked"`
+    CurrenciesChecked int64                       `json:"currencies_checked"`
+    DiscrepancyCount  int64                       `json:"discrepancy_count"`
+    StartedAt         time.Time                   `json:"started_at"`
+}
+
+func (q *reconciliationRepository) CreateReconciliationReport(ctx context.Context, arg CreateReconciliationReportParams) (domain.ReconciliationReport, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createReconciliationReport,
+        arg.Status,
+        arg.WalletsChecked,
+        arg.TransfersChecked,
+        arg.CurrenciesChecked,
+        arg.DiscrepancyCount,
+        arg.StartedAt,
+    )
+    var i domain.ReconciliationReport
+    err := row.Scan(
+        &i.ID,
+        &i.Status,
+        &i.WalletsChecked,
+        &i.TransfersChecked,
+        &i.CurrenciesChecked,
+        &i.DiscrepancyCount,
+        &i.StartedAt,
+        &i.FinishedAt,
+    )
+    return i, err
+}
+
+const createReconciliationDiscrepancy = `-- name: CreateReconciliationDiscrepancy :one
+INSERT INTO reconciliation_discrepancies (report_id,
+                                          kind,
+                                          currency,
+                                          wallet_id,
+                                          transfer_id,
+                                          expected,
+                                          actual,
+                                          detail)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, report_id, kind, currency, wallet_id, transfer_id, expected, actual, detail, created_at
+`
+
+type CreateReconciliationDiscrepancyParams struct {
+    ReportID   int64                  `json:"report_id"`
+    Kind       domain.DiscrepancyKind `json:"kind"`
+    Currency   string                 `json:"currency"`
+    WalletID   sql.NullInt64          `json:"wallet_id"`
+    TransferID sql.NullInt64          `json:"transfer_id"`
+    Expected   int64                  `json:"expected"`
+    Actual     int64                  `json:"actual"`
+    Detail     string                 `json:"detail"`
+}
+
+func (q *reconciliationRepository) CreateReconciliationDiscrepancy(ctx context.Context, arg CreateReconciliationDiscrepancyParams) (domain.ReconciliationDiscrepancy, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createReconciliationDiscrepancy,
+        arg.ReportID,
+        arg.Kind,
+        arg.Currency,
+        arg.WalletID,
+        arg.TransferID,
+        arg.Expected,
+        arg.Actual,
+        arg.Detail,
+    )
+    var i domain.ReconciliationDiscrepancy
+    err := row.Scan(
+        &i.ID,
+        &i.ReportID,
+        &i.Kind,
+        &i.Currency,
+        &i.WalletID,
+        &i.TransferID,
+        &i.Expected,
+        &i.Actual,
+        &i.Detail,
+        &i.CreatedAt,
+    )
+    return i, err
+}
+
+const getReconciliationReport = `-- name: GetReconciliationReport :one
+SELECT id, status, wallets_checked, transfers_checked, currencies_checked, discrepancy_count, started_at, finished_at
+FROM reconciliation_reports
+WHERE id = $1 LIMIT 1
+`
+
+func (q *reconciliationRepository) GetReconciliationReport(ctx context.Context, id int64) (domain.ReconciliationReport, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getReconciliationReport, id)
+    var i domain.ReconciliationReport
+    err := row.Scan(
+        &i.ID,
+        &i.Status,
+        &i.WalletsChecked,
+        &i.TransfersChecked,
+        &i.CurrenciesChecked,
+        &i.DiscrepancyCount,
+        &i.StartedAt,
+        &i.FinishedAt,
+    )
+    return i, err
+}
+
+const listReconciliationReports = `-- name: ListReconciliationReports :many
+SELECT id, status, wallets_checked, transfers_checked, currencies_checked, discrepancy_count, started_at, finished_at
+FROM reconciliation_reports
+ORDER BY id DESC LIMIT $1
+OFFSET $2
+`
+
+type ListReconciliationReportsParams struct {
+    Limit  int32 `json:"limit"`
+    Offset int32 `json:"offset"`
+}
+
+func (q *reconciliationRepository) ListReconciliationReports(ctx context.Context, arg ListReconciliationReportsParams) ([]domain.ReconciliationReport, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listReconciliationReports, arg.Limit, arg.Offset)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.ReconciliationReport{}
+    for rows.Next() {
+        var i domain.ReconciliationReport
+        if err := rows.Scan(
+            &i.ID,
+            &i.Status,
+            &i.WalletsChecked,
+            &i.TransfersChecked,
+            &i.CurrenciesChecked,
+            &i.DiscrepancyCount,
+            &i.StartedAt,
+            &i.FinishedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+const listReconciliationDiscrepancies = `-- name: ListReconciliationDiscrepancies :many
+SELECT id, report_id, kind, currency, wallet_id, transfer_id, expected, actual, detail, created_at
+FROM reconciliation_discrepancies
+WHERE report_id = $1
+ORDER BY id
+`
+
+func (q *reconciliationRepository) ListReconciliationDiscrepancies(ctx context.Context, reportID int64) ([]domain.ReconciliationDiscrepancy, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listReconciliationDiscrepancies, reportID)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []domain.ReconciliationDiscrepancy{}
+    for rows.Next() {
+        var i domain.ReconciliationDiscrepancy
+        if err := rows.Scan(
+            &i.ID,
+            &i.ReportID,
+            &i.Kind,
+            &i.Currency,
+            &i.WalletID,
+            &i.TransferID,
+            &i.Expected,
+            &i.Actual,
+            &i.Detail,
+            &i.CreatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
+
+type SaveReconciliationReportParams struct {
+    Report        CreateReconciliationReportParams        `json:"report"`
+    Discrepancies []CreateReconciliationDiscrepancyParams `json:"discrepancies"`
+}
+
+type ReconciliationReportResult struct {
+    Report        domain.ReconciliationReport        `json:"report"`
+    Discrepancies []domain.ReconciliationDiscrepancy `json:"discrepancies"`
+}
+
+// SaveReconciliationReport writes the report together with its discrepancies.
+func (q *reconciliationRepository) SaveReconciliationReport(ctx context.Context, arg SaveReconciliationReportParams) (ReconciliationReportResult, error) {
+    var res ReconciliationReportResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        var err error
+
+        res.Report, err = q.CreateReconciliationReport(ctx, arg.Report)
+        if err != nil {
+            return err
+        }
+
+        res.Discrepancies = []domain.ReconciliationDiscrepancy{}
+        for _, d := range arg.Discrepancies {
+            d.ReportID = res.Report.ID
+            discrepancy, err := q.CreateReconciliationDiscrepancy(ctx, d)
+            if err != nil {
+                return err
+            }
+            res.Discrepancies = append(res.Discrepancies, discrepancy)
+        }
+
+        return nil
+    })
+
+    return res, err
+}
@@ -0,0 +1,145 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func TestListWalletBalanceMismatches(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    reconciliationRepo := store.NewReconciliationRepo(testDb)
+
+    fromWallet := createRandomWalletWithAmount(t, 100)
+    verifyBankAccount(t, fromWallet.BankAccountID)
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    _, err := walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: fromWallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            40,
+    })
+    require.NoError(t, err)
+
+    mismatches, err := reconciliationRepo.ListWalletBalanceMismatches(context.Background())
+    require.NoError(t, err)
+    for _, m := range mismatches {
+        require.NotEqual(t, fromWallet.ID, m.WalletID)
+        require.NotEqual(t, toWallet.ID, m.WalletID)
+    }
+
+    // a balance update without an entry
+    _, err = walletRepo.AddWalletBalance(context.Background(), store.AddWalletBalanceParams{
+        ID:     fromWallet.ID,
+        Amount: 5,
+    })
+    require.NoError(t, err)
+
+    mismatches, err = reconciliationRepo.ListWalletBalanceMismatches(context.Background())
+    require.NoError(t, err)
+
+    var found bool
+    for _, m := range mismatches {
+        if m.WalletID == fromWallet.ID {
+            found = true
+            require.Equal(t, int64(65), m.Balance)
+            require.Equal(t, int64(60), m.LedgerBalance)
+        }
+    }
+    require.True(t, found)
+}
+
+func TestListUnbalancedTransfers(t *testing.T) {
+    reconciliationRepo := store.NewReconciliationRepo(testDb)
+    wallet1 := createRandomWallet(t)
+    wallet2 := createRandomWallet(t)
+
+    // a transfer without entries
+    transfer := createRandomTransfer(t, wallet1, wallet2)
+
+    transfers, err := reconciliationRepo.ListUnbalancedTransfers(context.Background())
+    require.NoError(t, err)
+
+    var found bool
+    for _, u := range transfers {
+        if u.TransferID == transfer.ID {
+            found = true
+            require.Equal(t, int64(0), u.EntryCount)
+            require.Equal(t, transfer.Amount, u.Amount)
+            require.Equal(t, wallet1.Currency, u.Currency)
+        }
+    }
+    require.True(t, found)
+
+    count, err := reconciliationRepo.CountTransfers(context.Background())
+    require.NoError(t, err)
+    require.GreaterOrEqual(t, count, int64(len(transfers)))
+}
+
+func TestListCurrencyTotals(t *testing.T) {
+    reconciliationRepo := store.NewReconciliationRepo(testDb)
+    createRandomWalletWithAmount(t, 10)
+
+    totals, err := reconciliationRepo.ListCurrencyTotals(context.Background())
+    require.NoError(t, err)
+    require.NotEmpty(t, totals)
+
+    for _, c := range totals {
+        require.NotEmpty(t, c.Currency)
+        require.Positive(t, c.WalletCount)
+    }
+}
+
+func TestSaveReconciliationReport(t *testing.T) {
+    reconciliationRepo := store.NewReconciliationRepo(testDb)
+    wallet := createRandomWallet(t)
+
+    arg := store.SaveReconciliationReportParams{
+        Report: store.CreateReconciliationReportParams{
+            Status:            domain.ReconciliationStatusDISCREPANCIES,
+            WalletsChecked:    10,
+            TransfersChecked:  20,
+            CurrenciesChecked: 1,
+            DiscrepancyCount:  1,
+            StartedAt:         time.Now().UTC(),
+        },
+        Discrepancies: []store.CreateReconciliationDiscrepancyParams{
+            {
+                Kind:     domain.DiscrepancyKindWALLETBALANCE,
+                Currency: wallet.Currency,
+                WalletID: sql.NullInt64{Int64: wallet.ID, Valid: true},
+                Expected: 10,
+                Actual:   15,
+            },
+        },
+    }
+
+    res, err := reconciliationRepo.SaveReconciliationReport(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotZero(t, res.Report.ID)
+    require.Equal(t, arg.Report.Status, res.Report.Status)
+    require.Equal(t, arg.Report.WalletsChecked, res.Report.WalletsChecked)
+    require.Len(t, res.Discrepancies, 1)
+    require.Equal(t, res.Report.ID, res.Discrepancies[0].ReportID)
+
+    report, err := reconciliationRepo.GetReconciliationReport(context.Background(), res.Report.ID)
+    require.NoError(t, err)
+    require.Equal(t, res.Report.ID, report.ID)
+    require.Equal(t, res.Report.DiscrepancyCount, report.DiscrepancyCount)
+
+    discrepancies, err := reconciliationRepo.ListReconciliationDiscrepancies(context.Background(), report.ID)
+    require.NoError(t, err)
+    require.Len(t, discrepancies, 1)
+    require.Equal(t, wallet.ID, discrepancies[0].WalletID.Int64)
+    require.False(t, discrepancies[0].TransferID.Valid)
+
+    reports, err := reconciliationRepo.ListReconciliationReports(context.Background(), store.ListReconciliationReportsParams{Limit: 5})
+    require.NoError(t, err)
+    require.NotEmpty(t, reports)
+    require.Equal(t, report.ID, reports[0].ID)
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "time"
+)
+
+type StatementRepo interface {
+    GetWalletBalanceAt(ctx context.Context, arg GetWalletBalanceAtParams) (int64, error)
+    ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]StatementEntry, error)
+}
+
+type statementRepository struct {
+    db *sql.DB
+}
+
+func NewStatementRepo(client *sql.DB) StatementRepo {
+    return &statementRepository{
+        db: client,
+    }
+}
+
+const getWalletBalanceAt = `-- name: GetWalletBalanceAt :one
+SELECT (w.opening_balance + COALESCE((SELECT SUM(e.amount)
+                                      FROM entries e
+                                      WHERE e.wallet_id = w.id
+                                        AND e.created_at < $2), 0))::bigint
+FROM wallets w
+WHERE w.id = $1
+`
+
+type GetWalletBalanceAtParams struct {
+    WalletID int64     `json:"wallet_id"`
+    At       time.Time `json:"at"`
+}
+
+// GetWalletBalanceAt returns the balance of the wallet from its ledger, counting the entries created before At.
+func (q *statementRepository) GetWalletBalanceAt(ctx context.Context, arg GetWalletBalanceAtParams) (int64, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWalletBalanceAt, arg.WalletID, arg.At)
+    var balance int64
+    err := row.Scan(&balance)
+    return balance, err
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+SELECT e.id, e.transfer_id, e.amount, e.created_at, cw.address AS counterparty
+FROM entries e
+         JOIN transfers t ON t.id = e.transfer_id
+         JOIN wallets cw ON cw.id = CASE WHEN t.from_wallet_id = e.wallet_id THEN t.to_wallet_id ELSE t.from_wallet_id END
+WHERE e.wallet_id = $1
+  AND e.created_at >= $2
+  AND e.created_at < $3
+ORDER BY e.created_at, e.id
+`
+
+type ListStatementEntriesParams struct {
+    WalletID int64     `json:"wallet_id"`
+    From     time.Time `json:"from"`
+    To       time.Time `json:"to"`
+}
+
+// StatementEntry is an entry of the wallet with the address of the other wallet of its transfer.
+type StatementEntry struct {
+    EntryID      int64     `json:"entry_id"`
+    TransferID   int64     `json:"transfer_id"`
+    Amount       int64     `json:"amount"`
+    CreatedAt    time.Time `json:"created_at"`
+    Counterparty string    `json:"counterparty"`
+}
+
+// ListStatementEntries returns the entries of the wallet created in [From, To), oldest first.
+func (q *statementRepository) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]StatementEntry, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listStatementEntries, arg.WalletID, arg.From, arg.To)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+    items := []StatementEntry{}
+    for rows.Next() {
+        var i StatementEntry
+        if err := rows.Scan(
+            &i.EntryID,
+            &i.TransferID,
+            &i.Amount,
+            &i.CreatedAt,
+            &i.Counterparty,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
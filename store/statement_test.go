@@ -0,0 +1,54 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func TestStatement(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+    statementRepo := store.NewStatementRepo(testDb)
+
+    fromWallet := createRandomWalletWithAmount(t, 100)
+    verifyBankAccount(t, fromWallet.BankAccountID)
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    before := time.Now().UTC().Add(-time.Hour)
+    for _, amount := range []int64{30, 20} {
+        _, err := walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+            FromWalletAddress: fromWallet.Address,
+            ToWalletAddress:   toWallet.Address,
+            Amount:            amount,
+        })
+        require.NoError(t, err)
+    }
+    after := time.Now().UTC().Add(time.Hour)
+
+    opening, err := statementRepo.GetWalletBalanceAt(context.Background(), store.GetWalletBalanceAtParams{WalletID: fromWallet.ID, At: before})
+    require.NoError(t, err)
+    require.Equal(t, int64(100), opening)
+
+    closing, err := statementRepo.GetWalletBalanceAt(context.Background(), store.GetWalletBalanceAtParams{WalletID: fromWallet.ID, At: after})
+    require.NoError(t, err)
+    require.Equal(t, int64(50), closing)
+
+    entries, err := statementRepo.ListStatementEntries(context.Background(), store.ListStatementEntriesParams{WalletID: fromWallet.ID, From: before, To: after})
+    require.NoError(t, err)
+    require.Len(t, entries, 2)
+    require.Equal(t, int64(-30), entries[0].Amount)
+    require.Equal(t, int64(-20), entries[1].Amount)
+    for _, e := range entries {
+        require.Equal(t, toWallet.Address, e.Counterparty)
+        require.NotZero(t, e.TransferID)
+    }
+
+    entries, err = statementRepo.ListStatementEntries(context.Background(), store.ListStatementEntriesParams{WalletID: toWallet.ID, From: before, To: after})
+    require.NoError(t, err)
+    require.Len(t, entries, 2)
+    require.Equal(t, fromWallet.Address, entries[0].Counterparty)
+    require.Equal(t, int64(30), entries[0].Amount)
+}
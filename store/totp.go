@@ -0,0 +1,226 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type TOTPRepo interface {
+    EnrollTOTP(ctx context.Context, arg EnrollTOTPParams) (domain.TOTPCredential, error)
+    GetTOTPCredential(ctx context.Context, userID int64) (domain.TOTPCredential, error)
+    UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (domain.TOTPCredential, error)
+    ConfirmTOTP(ctx context.Context, arg ConfirmTOTPParams) (domain.TOTPCredential, error)
+    UseTOTPRecoveryCode(ctx context.Context, arg UseTOTPRecoveryCodeParams) (domain.TOTPRecoveryCode, error)
+    RecordTOTPFailure(ctx context.Context, arg RecordTOTPFailureParams) (domain.TOTPCredential, error)
+    DisableTOTP(ctx context.Context, userID int64) error
+}
+
+type totpRepository struct {
+    db *sql.DB
+}
+
+func NewTOTPRepo(db *sql.DB) TOTPRepo {
+    return &totpRepository{
+        db: db,
+    }
+}
+
+const enrollTOTP = `-- name: EnrollTOTP :one
+INSERT INTO totp_credentials (user_id,
+                              secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+    SET secret          = EXCLUDED.secret,
+        last_used_step  = 0,
+        failed_attempts = 0,
+        locked_until    = NULL,
+        created_at      = now()
+WHERE totp_credentials.confirmed_at IS NULL RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type EnrollTOTPParams struct {
+    UserID int64  `json:"user_id"`
+    Secret string `json:"secret"`
+}
+
+// EnrollTOTP sets the pending secret of the user, replacing a pending one. It fails with sql.ErrNoRows when the
+// user has a confirmed secret already.
+func (q *totpRepository) EnrollTOTP(ctx context.Context, arg EnrollTOTPParams) (domain.TOTPCredential, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, enrollTOTP, arg.UserID, arg.Secret)
+    return scanTOTPCredential(row)
+}
+
+const getTOTPCredential = `-- name: GetTOTPCredential :one
+SELECT user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+FROM totp_credentials
+WHERE user_id = $1 LIMIT 1
+`
+
+func (q *totpRepository) GetTOTPCredential(ctx context.Context, userID int64) (domain.TOTPCredential, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getTOTPCredential, userID)
+    return scanTOTPCredential(row)
+}
+
+const useTOTPStep = `-- name: UseTOTPStep :one
+UPDATE totp_credentials
+SET last_used_step  = $2,
+    failed_attempts = 0
+WHERE user_id = $1
+  AND last_used_step < $2 RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type UseTOTPStepParams struct {
+    UserID int64 `json:"user_id"`
+    Step   int64 `json:"step"`
+}
+
+// UseTOTPStep records the step of the code used and resets the invalid codes counted, sql.ErrNoRows when a code of this step or a later one was used
+// already, e.g. by a concurrent request.
+func (q *totpRepository) UseTOTPStep(ctx context.Context, arg UseTOTPStepParams) (domain.TOTPCredential, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, useTOTPStep, arg.UserID, arg.Step)
+    return scanTOTPCredential(row)
+}
+
+const recordTOTPFailure = `-- name: RecordTOTPFailure :one
+UPDATE totp_credentials
+SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+    locked_until    = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+WHERE user_id = $1 RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+type RecordTOTPFailureParams struct {
+    UserID      int64     `json:"user_id"`
+    MaxAttempts int32     `json:"max_attempts"`
+    LockedUntil time.Time `json:"locked_until"`
+}
+
+// RecordTOTPFailure counts an invalid code of the user. The MaxAttempts-th in a row locks the user out until
+// LockedUntil and starts the count again.
+func (q *totpRepository) RecordTOTPFailure(ctx context.Context, arg RecordTOTPFailureParams) (domain.TOTPCredential, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, recordTOTPFailure, arg.UserID, arg.MaxAttempts, arg.LockedUntil)
+    return scanTOTPCredential(row)
+}
+
+const confirmTOTPCredential = `-- name: ConfirmTOTPCredential :one
+UPDATE totp_credentials
+SET confirmed_at   = $2,
+    last_used_step = $3
+WHERE user_id = $1
+  AND confirmed_at IS NULL RETURNING user_id, secret, confirmed_at, last_used_step, failed_attempts, locked_until, created_at
+`
+
+const createTOTPRecoveryCode = `-- name: CreateTOTPRecoveryCode :exec
+INSERT INTO totp_recovery_codes (user_id,
+                                 hashed_code)
+VALUES ($1, $2)
+`
+
+const deleteTOTPRecoveryCodes = `-- name: DeleteTOTPRecoveryCodes :exec
+DELETE
+FROM totp_recovery_codes
+WHERE user_id = $1
+`
+
+type ConfirmTOTPParams struct {
+    UserID              int64     `json:"user_id"`
+    Step                int64     `json:"step"`
+    ConfirmedAt         time.Time `json:"confirmed_at"`
+    HashedRecoveryCodes []string  `json:"hashed_recovery_codes"`
+}
+
+// ConfirmTOTP confirms the pending secret of the user with the step of its first code, and replaces the recovery
+// codes of the user, in a transaction. It fails with sql.ErrNoRows when there is no pending secret.
+func (q *totpRepository) ConfirmTOTP(ctx context.Context, arg ConfirmTOTPParams) (domain.TOTPCredential, error) {
+    var credential domain.TOTPCredential
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        var err error
+        row := conn(ctx, q.db).QueryRowContext(ctx, confirmTOTPCredential, arg.UserID, arg.ConfirmedAt, arg.Step)
+        credential, err = scanTOTPCredential(row)
+        if err != nil {
+            return err
+        }
+
+        if _, err = conn(ctx, q.db).ExecContext(ctx, deleteTOTPRecoveryCodes, arg.UserID); err != nil {
+            return err
+        }
+
+        for _, hashedCode := range arg.HashedRecoveryCodes {
+            if _, err = conn(ctx, q.db).ExecContext(ctx, createTOTPRecoveryCode, arg.UserID, hashedCode); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+
+    return credential, err
+}
+
+const useTOTPRecoveryCode = `-- name: UseTOTPRecoveryCode :one
+WITH used AS (
+    UPDATE totp_recovery_codes
+        SET used_at = $3
+        WHERE user_id = $1
+            AND hashed_code = $2
+            AND used_at IS NULL RETURNING id, user_id, hashed_code, used_at, created_at),
+     reset AS (
+         UPDATE totp_credentials
+             SET failed_attempts = 0
+             WHERE user_id IN (SELECT user_id FROM used))
+SELECT id, user_id, hashed_code, used_at, created_at
+FROM used
+`
+
+type UseTOTPRecoveryCodeParams struct {
+    UserID     int64     `json:"user_id"`
+    HashedCode string    `json:"hashed_code"`
+    UsedAt     time.Time `json:"used_at"`
+}
+
+// UseTOTPRecoveryCode marks the recovery code used and resets the invalid codes counted of the user, in one
+// statement. It fails with sql.ErrNoRows when it is not one of the user or is used already.
+func (q *totpRepository) UseTOTPRecoveryCode(ctx context.Context, arg UseTOTPRecoveryCodeParams) (domain.TOTPRecoveryCode, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, useTOTPRecoveryCode, arg.UserID, arg.HashedCode, arg.UsedAt)
+    var i domain.TOTPRecoveryCode
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.HashedCode,
+        &i.UsedAt,
+        &i.CreatedAt,
+    )
+    return i, err
+}
+
+const deleteTOTPCredential = `-- name: DeleteTOTPCredential :exec
+DELETE
+FROM totp_credentials
+WHERE user_id = $1
+`
+
+// DisableTOTP deletes the secret and the recovery codes of the user in a transaction.
+func (q *totpRepository) DisableTOTP(ctx context.Context, userID int64) error {
+    return ExecTx(ctx, q.db, func(ctx context.Context) error {
+        if _, err := conn(ctx, q.db).ExecContext(ctx, deleteTOTPRecoveryCodes, userID); err != nil {
+            return err
+        }
+        _, err := conn(ctx, q.db).ExecContext(ctx, deleteTOTPCredential, userID)
+        return err
+    })
+}
+
+func scanTOTPCredential(row *sql.Row) (domain.TOTPCredential, error) {
+    var i domain.TOTPCredential
+    err := row.Scan(
+        &i.UserID,
+        &i.Secret,
+        &i.ConfirmedAt,
+        &i.LastUsedStep,
+        &i.FailedAttempts,
+        &i.LockedUntil,
+        &i.CreatedAt,
+    )
+    return i, err
+}
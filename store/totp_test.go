@@ -0,0 +1,130 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/totp"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func enrollRandomTOTP(t *testing.T, user domain.User) domain.TOTPCredential {
+    secret, err := totp.NewSecret()
+    require.NoError(t, err)
+
+    credential, err := store.NewTOTPRepo(testDb).EnrollTOTP(context.Background(), store.EnrollTOTPParams{UserID: user.ID, Secret: secret})
+    require.NoError(t, err)
+    require.Equal(t, user.ID, credential.UserID)
+    require.Equal(t, secret, credential.Secret)
+    require.False(t, credential.IsConfirmed())
+    require.Zero(t, credential.LastUsedStep)
+
+    return credential
+}
+
+func TestEnrollAndConfirmTOTP(t *testing.T) {
+    totpRepo := store.NewTOTPRepo(testDb)
+    user := createRandomUser(t)
+
+    // a pending secret is replaced
+    enrollRandomTOTP(t, user)
+    credential := enrollRandomTOTP(t, user)
+
+    hashedCode := util.HashToken(util.RandomString(10))
+    confirmed, err := totpRepo.ConfirmTOTP(context.Background(), store.ConfirmTOTPParams{
+        UserID:              user.ID,
+        Step:                totp.Step(time.Now()),
+        ConfirmedAt:         time.Now().UTC(),
+        HashedRecoveryCodes: []string{hashedCode, util.HashToken(util.RandomString(10))},
+    })
+    require.NoError(t, err)
+    require.Equal(t, credential.Secret, confirmed.Secret)
+    require.True(t, confirmed.IsConfirmed())
+    require.Equal(t, totp.Step(time.Now()), confirmed.LastUsedStep)
+
+    // confirmed once, and not replaced
+    _, err = totpRepo.ConfirmTOTP(context.Background(), store.ConfirmTOTPParams{UserID: user.ID, ConfirmedAt: time.Now().UTC()})
+    require.ErrorIs(t, err, sql.ErrNoRows)
+
+    _, err = totpRepo.EnrollTOTP(context.Background(), store.EnrollTOTPParams{UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP"})
+    require.ErrorIs(t, err, sql.ErrNoRows)
+
+    _, err = totpRepo.RecordTOTPFailure(context.Background(), store.RecordTOTPFailureParams{UserID: user.ID, MaxAttempts: 5, LockedUntil: time.Now().UTC()})
+    require.NoError(t, err)
+
+    // a recovery code is used once, and starts the count of the invalid codes again
+    code, err := totpRepo.UseTOTPRecoveryCode(context.Background(), store.UseTOTPRecoveryCodeParams{UserID: user.ID, HashedCode: hashedCode, UsedAt: time.Now().UTC()})
+    require.NoError(t, err)
+    require.True(t, code.UsedAt.Valid)
+
+    credential, err = totpRepo.GetTOTPCredential(context.Background(), user.ID)
+    require.NoError(t, err)
+    require.Zero(t, credential.FailedAttempts)
+
+    _, err = totpRepo.UseTOTPRecoveryCode(context.Background(), store.UseTOTPRecoveryCodeParams{UserID: user.ID, HashedCode: hashedCode, UsedAt: time.Now().UTC()})
+    require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUseTOTPStep(t *testing.T) {
+    totpRepo := store.NewTOTPRepo(testDb)
+    user := createRandomUser(t)
+    enrollRandomTOTP(t, user)
+
+    step := totp.Step(time.Now())
+    credential, err := totpRepo.UseTOTPStep(context.Background(), store.UseTOTPStepParams{UserID: user.ID, Step: step})
+    require.NoError(t, err)
+    require.Equal(t, step, credential.LastUsedStep)
+
+    // the code of a step is used once
+    _, err = totpRepo.UseTOTPStep(context.Background(), store.UseTOTPStepParams{UserID: user.ID, Step: step})
+    require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRecordTOTPFailure(t *testing.T) {
+    totpRepo := store.NewTOTPRepo(testDb)
+    user := createRandomUser(t)
+    enrollRandomTOTP(t, user)
+
+    lockedUntil := time.Now().UTC().Add(time.Minute)
+    arg := store.RecordTOTPFailureParams{UserID: user.ID, MaxAttempts: 3, LockedUntil: lockedUntil}
+
+    credential, err := totpRepo.RecordTOTPFailure(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, int32(1), credential.FailedAttempts)
+    require.False(t, credential.IsLocked(time.Now().UTC()))
+
+    // a valid code starts the count again
+    _, err = totpRepo.UseTOTPStep(context.Background(), store.UseTOTPStepParams{UserID: user.ID, Step: totp.Step(time.Now())})
+    require.NoError(t, err)
+
+    for i := int32(1); i < arg.MaxAttempts; i++ {
+        credential, err = totpRepo.RecordTOTPFailure(context.Background(), arg)
+        require.NoError(t, err)
+        require.Equal(t, i, credential.FailedAttempts)
+        require.False(t, credential.IsLocked(time.Now().UTC()))
+    }
+
+    // the last one locks the user out, until LockedUntil
+    credential, err = totpRepo.RecordTOTPFailure(context.Background(), arg)
+    require.NoError(t, err)
+    require.Zero(t, credential.FailedAttempts)
+    require.True(t, credential.IsLocked(time.Now().UTC()))
+    require.WithinDuration(t, lockedUntil, credential.LockedUntil.Time, time.Second)
+    require.False(t, credential.IsLocked(lockedUntil))
+}
+
+func TestDisableTOTP(t *testing.T) {
+    totpRepo := store.NewTOTPRepo(testDb)
+    user := createRandomUser(t)
+    enrollRandomTOTP(t, user)
+
+    err := totpRepo.DisableTOTP(context.Background(), user.ID)
+    require.NoError(t, err)
+
+    _, err = totpRepo.GetTOTPCredential(context.Background(), user.ID)
+    require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "go.opentelemetry.io/otel/attribute"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "strings"
+)
+
+// tracedDBTX runs every query in a span named after the query, e.g. GetWalletByAddressForUpdate, so that
+// the wait for a row lock shows as the time of the query that takes it. The span of QueryContext ends once
+// the first rows are in, the rows scanned after it are not part of it.
+type tracedDBTX struct {
+    DBTX
+}
+
+func (t tracedDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+    ctx, span := startQuerySpan(ctx, query)
+    defer span.End()
+
+    res, err := t.DBTX.ExecContext(ctx, query, args...)
+    trace.RecordError(span, err)
+    return res, err
+}
+
+func (t tracedDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+    ctx, span := startQuerySpan(ctx, query)
+    defer span.End()
+
+    stmt, err := t.DBTX.PrepareContext(ctx, query)
+    trace.RecordError(span, err)
+    return stmt, err
+}
+
+func (t tracedDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+    ctx, span := startQuerySpan(ctx, query)
+    defer span.End()
+
+    rows, err := t.DBTX.QueryContext(ctx, query, args...)
+    trace.RecordError(span, err)
+    return rows, err
+}
+
+// QueryRowContext records no error, sql.Row only tells it on Scan. Its span still holds the time of the
+// query, database/sql runs it before returning the row.
+func (t tracedDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+    ctx, span := startQuerySpan(ctx, query)
+    defer span.End()
+
+    return t.DBTX.QueryRowContext(ctx, query, args...)
+}
+
+func startQuerySpan(ctx context.Context, query string) (context.Context, oteltrace.Span) {
+    name := queryName(query)
+    return trace.Start(ctx, name, oteltrace.WithSpanKind(oteltrace.SpanKindClient), oteltrace.WithAttributes(
+        attribute.String("db.system", "postgresql"),
+        attribute.String("db.operation", name),
+        attribute.String("db.statement", query),
+    ))
+}
+
+// queryName reads the name of a query from its first line, -- name: GetWallet :one,
+// the queries without one are named after their first keyword, e.g. SELECT.
+func queryName(query string) string {
+    query = strings.TrimSpace(query)
+    if strings.HasPrefix(query, "-- name:") {
+        fields := strings.Fields(strings.TrimPrefix(query, "-- name:"))
+        if len(fields) > 0 {
+            return fields[0]
+        }
+    }
+
+    fields := strings.Fields(query)
+    if len(fields) == 0 {
+        return "query"
+    }
+    return strings.ToUpper(fields[0])
+}
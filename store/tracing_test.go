@@ -0,0 +1,57 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/stretchr/testify/require"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    "go.opentelemetry.io/otel/sdk/trace/tracetest"
+    oteltrace "go.opentelemetry.io/otel/trace"
+    "testing"
+)
+
+func TestSendMoneyTracing(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+
+    fromWallet := createRandomWalletWithAmount(t, 50)
+    verifyBankAccount(t, fromWallet.BankAccountID)
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    rec := tracetest.NewSpanRecorder()
+    previous := otel.GetTracerProvider()
+    otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+    defer otel.SetTracerProvider(previous)
+
+    ctx, span := trace.Start(context.Background(), "WalletSvc.Pay")
+    _, err := walletRepo.SendMoney(ctx, store.SendMoneyParams{
+        FromWalletAddress: fromWallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            10,
+    })
+    require.NoError(t, err)
+    span.End()
+
+    byName := make(map[string][]sdktrace.ReadOnlySpan)
+    for _, s := range rec.Ended() {
+        byName[s.Name()] = append(byName[s.Name()], s)
+    }
+
+    // the transaction is a child of the caller, the queries are children of the transaction
+    require.Len(t, byName["WalletSvc.Pay"], 1)
+    require.Len(t, byName["db.transaction"], 1)
+    txn := byName["db.transaction"][0]
+    require.Equal(t, byName["WalletSvc.Pay"][0].SpanContext().SpanID(), txn.Parent().SpanID())
+
+    require.Len(t, byName["GetWalletByAddressForUpdate"], 2)
+    require.NotEmpty(t, byName["CreateTransfer"])
+    for _, s := range byName["GetWalletByAddressForUpdate"] {
+        require.Equal(t, txn.SpanContext().TraceID(), s.SpanContext().TraceID())
+        require.Equal(t, txn.SpanContext().SpanID(), s.Parent().SpanID())
+        require.Equal(t, oteltrace.SpanKindClient, s.SpanKind())
+        require.Contains(t, s.Attributes(), attribute.String("db.operation", "GetWalletByAddressForUpdate"))
+    }
+}
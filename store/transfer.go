@@ -9,6 +9,8 @@ import (
 type TransferRepo interface {
     CreateTransfer(ctx context.Context, arg CreateTransferParams) (domain.Transfer, error)
     GetTransfer(ctx context.Context, id int64) (domain.Transfer, error)
+    GetTransferForUpdate(ctx context.Context, id int64) (domain.Transfer, error)
+    AddTransferRefundedAmount(ctx context.Context, arg AddTransferRefundedAmountParams) (domain.Transfer, error)
     ListTransfers(ctx context.Context, arg ListTransfersParams) ([]domain.Transfer, error)
 }
 
@@ -25,21 +27,27 @@ func NewTransferRepo(client *sql.DB) TransferRepo {
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (from_wallet_id,
                        to_wallet_id,
-                       amount)
-VALUES ($1, $2, $3) RETURNING id, from_wallet_id, to_wallet_id, amount, created_at
+                       amount,
+                       kind,
+                       original_transfer_id)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, from_wallet_id, to_wallet_id, amount, kind, original_transfer_id, refunded_amount, refund_status, created_at
 `
 
 type CreateTransferParams struct {
-    FromWalletID int64 `json:"from_wallet_id"`
-    ToWalletID   int64 `json:"to_wallet_id"`
-    Amount       int64 `json:"amount"`
+    FromWalletID       int64               `json:"from_wallet_id"`
+    ToWalletID         int64               `json:"to_wallet_id"`
+    Amount             int64               `json:"amount"`
+    Kind               domain.TransferKind `json:"kind"`
+    OriginalTransferID sql.NullInt64       `json:"original_transfer_id"`
 }
 
 func (q *transferRepository) CreateTransfer(ctx context.Context, arg CreateTransferParams) (domain.Transfer, error) {
-    row := q.db.QueryRowContext(ctx, createTransfer,
+    row := conn(ctx, q.db).QueryRowContext(ctx, createTransfer,
         arg.FromWalletID,
         arg.ToWalletID,
         arg.Amount,
+        arg.Kind,
+        arg.OriginalTransferID,
     )
     var i domain.Transfer
     err := row.Scan(
@@ -47,32 +55,93 @@ func (q *transferRepository) CreateTransfer(ctx context.Context, arg CreateTrans
         &i.FromWalletID,
         &i.ToWalletID,
         &i.Amount,
+        &i.Kind,
+        &i.OriginalTransferID,
+        &i.RefundedAmount,
+        &i.RefundStatus,
         &i.CreatedAt,
     )
     return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_wallet_id, to_wallet_id, amount, created_at
+SELECT id, from_wallet_id, to_wallet_id, amount, kind, original_transfer_id, refunded_amount, refund_status, created_at
 FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
 func (q *transferRepository) GetTransfer(ctx context.Context, id int64) (domain.Transfer, error) {
-    row := q.db.QueryRowContext(ctx, getTransfer, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getTransfer, id)
+    var i domain.Transfer
+    err := row.Scan(
+        &i.ID,
+        &i.FromWalletID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.Kind,
+        &i.OriginalTransferID,
+        &i.RefundedAmount,
+        &i.RefundStatus,
+        &i.CreatedAt,
+    )
+    return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_wallet_id, to_wallet_id, amount, kind, original_transfer_id, refunded_amount, refund_status, created_at
+FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *transferRepository) GetTransferForUpdate(ctx context.Context, id int64) (domain.Transfer, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getTransferForUpdate, id)
+    var i domain.Transfer
+    err := row.Scan(
+        &i.ID,
+        &i.FromWalletID,
+        &i.ToWalletID,
+        &i.Amount,
+        &i.Kind,
+        &i.OriginalTransferID,
+        &i.RefundedAmount,
+        &i.RefundStatus,
+        &i.CreatedAt,
+    )
+    return i, err
+}
+
+const addTransferRefundedAmount = `-- name: AddTransferRefundedAmount :one
+UPDATE transfers
+SET refunded_amount = refunded_amount + $1,
+    refund_status   = CASE WHEN refunded_amount + $1 >= amount THEN 'REFUNDED'::refund_status ELSE 'PARTIALLY_REFUNDED'::refund_status END
+WHERE id = $2 RETURNING id, from_wallet_id, to_wallet_id, amount, kind, original_transfer_id, refunded_amount, refund_status, created_at
+`
+
+type AddTransferRefundedAmountParams struct {
+    Amount int64 `json:"amount"`
+    ID     int64 `json:"id"`
+}
+
+func (q *transferRepository) AddTransferRefundedAmount(ctx context.Context, arg AddTransferRefundedAmountParams) (domain.Transfer, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, addTransferRefundedAmount, arg.Amount, arg.ID)
     var i domain.Transfer
     err := row.Scan(
         &i.ID,
         &i.FromWalletID,
         &i.ToWalletID,
         &i.Amount,
+        &i.Kind,
+        &i.OriginalTransferID,
+        &i.RefundedAmount,
+        &i.RefundStatus,
         &i.CreatedAt,
     )
     return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_wallet_id, to_wallet_id, amount, created_at
+SELECT id, from_wallet_id, to_wallet_id, amount, kind, original_transfer_id, refunded_amount, refund_status, created_at
 FROM transfers
 WHERE from_wallet_id = $1
 OR to_wallet_id = $2
@@ -88,7 +157,7 @@ type ListTransfersParams struct {
 }
 
 func (q *transferRepository) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]domain.Transfer, error) {
-    rows, err := q.db.QueryContext(ctx, listTransfers,
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listTransfers,
         arg.FromWalletID,
         arg.ToWalletID,
         arg.Limit,
@@ -106,6 +175,10 @@ func (q *transferRepository) ListTransfers(ctx context.Context, arg ListTransfer
             &i.FromWalletID,
             &i.ToWalletID,
             &i.Amount,
+            &i.Kind,
+            &i.OriginalTransferID,
+            &i.RefundedAmount,
+            &i.RefundStatus,
             &i.CreatedAt,
         ); err != nil {
             return nil, err
@@ -15,6 +15,7 @@ func createRandomTransfer(t *testing.T, wallet1, wallet2 domain.Wallet) domain.T
         FromWalletID: wallet1.ID,
         ToWalletID:   wallet2.ID,
         Amount:       util.RandomMoney(),
+        Kind:         domain.TransferKindPAYMENT,
     }
 
     transfer, err := transferRepo.CreateTransfer(context.Background(), arg)
@@ -24,6 +25,10 @@ func createRandomTransfer(t *testing.T, wallet1, wallet2 domain.Wallet) domain.T
     require.Equal(t, arg.FromWalletID, transfer.FromWalletID)
     require.Equal(t, arg.ToWalletID, transfer.ToWalletID)
     require.Equal(t, arg.Amount, transfer.Amount)
+    require.Equal(t, arg.Kind, transfer.Kind)
+    require.False(t, transfer.OriginalTransferID.Valid)
+    require.Zero(t, transfer.RefundedAmount)
+    require.Equal(t, domain.RefundStatusNONE, transfer.RefundStatus)
 
     require.NotZero(t, transfer.ID)
     require.NotZero(t, transfer.CreatedAt)
@@ -1,24 +1,47 @@
 package store
 
-import "database/sql"
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/pkg/trace"
+    "go.opentelemetry.io/otel/attribute"
+    oteltrace "go.opentelemetry.io/otel/trace"
+)
 
-type Tx interface {
-    Exec(query string, args ...interface{}) (sql.Result, error)
-    Prepare(query string) (*sql.Stmt, error)
-    Query(query string, args ...interface{}) (*sql.Rows, error)
-    QueryRow(query string, args ...interface{}) *sql.Row
+// DBTX is implemented by both *sql.DB and *sql.Tx, repositories run their queries through it.
+type DBTX interface {
+    ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+    PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+    QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+    QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
-var TxKey = struct{}{}
+type txKey struct{}
 
-// TxFn is a function that will be called with an initialized `Tx` object
-// that can be used for executing statements and queries against a database.
-type TxFn func(Tx) error
+// TxKey is the context key holding the transaction started by ExecTx
+var TxKey = txKey{}
+
+// TxFn is a function that will be called with a context carrying the transaction.
+// Every repository call made with that context joins the transaction.
+type TxFn func(ctx context.Context) error
 
 // ExecTx creates a new transaction and handles rollback/commit based on the
-// error object returned by the `TxFn`
-func ExecTx(db *sql.DB, fn TxFn) (err error) {
-    tx, err := db.Begin()
+// error object returned by the `TxFn`. When the context already carries a
+// transaction, the function joins it instead of starting a new one.
+// The transaction is traced as a span, the parent of the spans of its queries.
+func ExecTx(ctx context.Context, db *sql.DB, fn TxFn) (err error) {
+    if _, ok := ctx.Value(TxKey).(*sql.Tx); ok {
+        return fn(ctx)
+    }
+
+    ctx, span := trace.Start(ctx, "db.transaction", oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+        oteltrace.WithAttributes(attribute.String("db.system", "postgresql")))
+    defer func() {
+        trace.RecordError(span, err)
+        span.End()
+    }()
+
+    tx, err := db.BeginTx(ctx, nil)
     if err != nil {
         return
     }
@@ -37,6 +60,16 @@ func ExecTx(db *sql.DB, fn TxFn) (err error) {
         }
     }()
 
-    err = fn(tx)
+    err = fn(context.WithValue(ctx, TxKey, tx))
     return err
 }
+
+// conn returns the transaction carried by the context, or the db outside of a transaction,
+// tracing every query run through it.
+func conn(ctx context.Context, db *sql.DB) DBTX {
+    if tx, ok := ctx.Value(TxKey).(*sql.Tx); ok {
+        return tracedDBTX{tx}
+    }
+
+    return tracedDBTX{db}
+}
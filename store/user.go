@@ -4,13 +4,16 @@ import (
     "context"
     "database/sql"
     "github.com/pranayhere/simple-wallet/domain"
+    "time"
 )
 
 type UserRepo interface {
     CreateUser(ctx context.Context, arg CreateUserParams) (domain.User, error)
     GetUser(ctx context.Context, id int64) (domain.User, error)
     GetUserByUsername(ctx context.Context, username string) (domain.User, error)
+    GetUserByEmail(ctx context.Context, email string) (domain.User, error)
     UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (domain.User, error)
+    UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (domain.User, error)
 }
 
 type userRepository struct {
@@ -32,7 +35,7 @@ INSERT INTO users (
     email
 ) values (
 $1, $2, $3, $4, $5
-) RETURNING id, username, hashed_password, status, full_name, email, password_changed_at, created_at, updated_at
+) RETURNING id, username, hashed_password, status, full_name, email, role, password_changed_at, created_at, updated_at
 `
 
 type CreateUserParams struct {
@@ -44,7 +47,7 @@ type CreateUserParams struct {
 }
 
 func (q *userRepository) CreateUser(ctx context.Context, arg CreateUserParams) (domain.User, error) {
-    row := q.db.QueryRowContext(ctx, createUser,
+    row := conn(ctx, q.db).QueryRowContext(ctx, createUser,
         arg.Username,
         arg.HashedPassword,
         arg.Status,
@@ -59,6 +62,7 @@ func (q *userRepository) CreateUser(ctx context.Context, arg CreateUserParams) (
         &i.Status,
         &i.FullName,
         &i.Email,
+        &i.Role,
         &i.PasswordChangedAt,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -67,12 +71,12 @@ func (q *userRepository) CreateUser(ctx context.Context, arg CreateUserParams) (
 }
 
 const getUserByUsername = `-- name: getUserByUsername :one
-SELECT id, username, hashed_password, status, full_name, email, password_changed_at, created_at, updated_at from users
+SELECT id, username, hashed_password, status, full_name, email, role, password_changed_at, created_at, updated_at from users
 where username = $1 LIMIT 1
 `
 
 func (q *userRepository) GetUserByUsername(ctx context.Context, username string) (domain.User, error) {
-    row := q.db.QueryRowContext(ctx, getUserByUsername, username)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getUserByUsername, username)
     var i domain.User
     err := row.Scan(
         &i.ID,
@@ -81,6 +85,7 @@ func (q *userRepository) GetUserByUsername(ctx context.Context, username string)
         &i.Status,
         &i.FullName,
         &i.Email,
+        &i.Role,
         &i.PasswordChangedAt,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -89,12 +94,12 @@ func (q *userRepository) GetUserByUsername(ctx context.Context, username string)
 }
 
 const getUser = `-- name: getUser :one
-SELECT id, username, hashed_password, status, full_name, email, password_changed_at, created_at, updated_at from users
+SELECT id, username, hashed_password, status, full_name, email, role, password_changed_at, created_at, updated_at from users
 where id = $1 LIMIT 1
 `
 
 func (q *userRepository) GetUser(ctx context.Context, id int64) (domain.User, error) {
-    row := q.db.QueryRowContext(ctx, getUser, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getUser, id)
     var i domain.User
     err := row.Scan(
         &i.ID,
@@ -103,6 +108,7 @@ func (q *userRepository) GetUser(ctx context.Context, id int64) (domain.User, er
         &i.Status,
         &i.FullName,
         &i.Email,
+        &i.Role,
         &i.PasswordChangedAt,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -114,7 +120,7 @@ const updateUserStatus = `-- name: UpdateUserStatus :one
 UPDATE users
 set Status = $1
 where id = $2
-RETURNING id, username, hashed_password, status, full_name, email, password_changed_at, created_at, updated_at
+RETURNING id, username, hashed_password, status, full_name, email, role, password_changed_at, created_at, updated_at
 `
 
 type UpdateUserStatusParams struct {
@@ -123,7 +129,7 @@ type UpdateUserStatusParams struct {
 }
 
 func (q *userRepository) UpdateUserStatus(ctx context.Context, arg UpdateUserStatusParams) (domain.User, error) {
-    row := q.db.QueryRowContext(ctx, updateUserStatus, arg.Status, arg.ID)
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateUserStatus, arg.Status, arg.ID)
     var i domain.User
     err := row.Scan(
         &i.ID,
@@ -132,6 +138,61 @@ func (q *userRepository) UpdateUserStatus(ctx context.Context, arg UpdateUserSta
         &i.Status,
         &i.FullName,
         &i.Email,
+        &i.Role,
+        &i.PasswordChangedAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, username, hashed_password, status, full_name, email, role, password_changed_at, created_at, updated_at from users
+where email = $1 LIMIT 1
+`
+
+func (q *userRepository) GetUserByEmail(ctx context.Context, email string) (domain.User, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getUserByEmail, email)
+    var i domain.User
+    err := row.Scan(
+        &i.ID,
+        &i.Username,
+        &i.HashedPassword,
+        &i.Status,
+        &i.FullName,
+        &i.Email,
+        &i.Role,
+        &i.PasswordChangedAt,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const updateUserPassword = `-- name: UpdateUserPassword :one
+UPDATE users
+set hashed_password = $1, password_changed_at = $2, updated_at = now()
+where id = $3
+RETURNING id, username, hashed_password, status, full_name, email, role, password_changed_at, created_at, updated_at
+`
+
+type UpdateUserPasswordParams struct {
+    HashedPassword    string    `json:"hashed_password"`
+    PasswordChangedAt time.Time `json:"password_changed_at"`
+    ID                int64     `json:"id"`
+}
+
+func (q *userRepository) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (domain.User, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateUserPassword, arg.HashedPassword, arg.PasswordChangedAt, arg.ID)
+    var i domain.User
+    err := row.Scan(
+        &i.ID,
+        &i.Username,
+        &i.HashedPassword,
+        &i.Status,
+        &i.FullName,
+        &i.Email,
+        &i.Role,
         &i.PasswordChangedAt,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -2,6 +2,7 @@ package store_test
 
 import (
     "context"
+    "database/sql"
     "github.com/pranayhere/simple-wallet/domain"
     "github.com/pranayhere/simple-wallet/store"
     "github.com/pranayhere/simple-wallet/util"
@@ -33,6 +34,7 @@ func createRandomUser(t *testing.T) domain.User {
     require.Equal(t, args.Email, user.Email)
     require.Equal(t, args.HashedPassword, user.HashedPassword)
     require.Equal(t, args.FullName, user.FullName)
+    require.Equal(t, domain.UserRoleUSER, user.Role)
 
     require.NotZero(t, user.CreatedAt)
 
@@ -81,3 +83,36 @@ func TestUpdateUserStatus(t *testing.T) {
     require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
     require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
 }
+
+func TestGetUserByEmail(t *testing.T) {
+    userRepo := store.NewUserRepo(testDb)
+    user1 := createRandomUser(t)
+
+    user2, err := userRepo.GetUserByEmail(context.Background(), user1.Email)
+    require.NoError(t, err)
+    require.Equal(t, user1.ID, user2.ID)
+
+    _, err = userRepo.GetUserByEmail(context.Background(), util.RandomEmail())
+    require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateUserPassword(t *testing.T) {
+    userRepo := store.NewUserRepo(testDb)
+    user1 := createRandomUser(t)
+
+    hashedPassword, err := util.HashPassword(util.RandomString(6))
+    require.NoError(t, err)
+
+    args := store.UpdateUserPasswordParams{
+        ID:                user1.ID,
+        HashedPassword:    hashedPassword,
+        PasswordChangedAt: time.Now().UTC().Truncate(time.Microsecond),
+    }
+    user2, err := userRepo.UpdateUserPassword(context.Background(), args)
+    require.NoError(t, err)
+
+    require.Equal(t, hashedPassword, user2.HashedPassword)
+    require.True(t, args.PasswordChangedAt.Equal(user2.PasswordChangedAt))
+    require.True(t, user2.TokenRevoked(args.PasswordChangedAt.Add(-time.Second)))
+    require.False(t, user2.TokenRevoked(args.PasswordChangedAt))
+}
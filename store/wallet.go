@@ -3,12 +3,15 @@ package store
 import (
     "context"
     "database/sql"
-    "fmt"
     "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    log "github.com/sirupsen/logrus"
 )
 
 type WalletRepo interface {
     AddWalletBalance(ctx context.Context, arg AddWalletBalanceParams) (domain.Wallet, error)
+    AddWalletHeldBalance(ctx context.Context, arg AddWalletHeldBalanceParams) (domain.Wallet, error)
     CreateWallet(ctx context.Context, arg CreateWalletParams) (domain.Wallet, error)
     GetWallet(ctx context.Context, id int64) (domain.Wallet, error)
     GetWalletForUpdate(ctx context.Context, id int64) (domain.Wallet, error)
@@ -19,26 +22,29 @@ type WalletRepo interface {
     GetWalletByBankAccountID(ctx context.Context, bankAccountID int64) (domain.Wallet, error)
     GetWalletByBankAccountIDForUpdate(ctx context.Context, bankAccountID int64) (domain.Wallet, error)
     SendMoney(ctx context.Context, arg SendMoneyParams) (WalletTransferResult, error)
+    RefundTransfer(ctx context.Context, arg RefundTransferParams) (RefundTransferResult, error)
 }
 
 type walletRepository struct {
     db           *sql.DB
     transferRepo TransferRepo
     entryRepo    EntryRepo
+    outboxRepo   OutboxRepo
 }
 
-func NewWalletRepo(client *sql.DB, transferRepo TransferRepo, entryRepo EntryRepo) WalletRepo {
+func NewWalletRepo(client *sql.DB, transferRepo TransferRepo, entryRepo EntryRepo, outboxRepo OutboxRepo) WalletRepo {
     return &walletRepository{
         db:           client,
         transferRepo: transferRepo,
         entryRepo:    entryRepo,
+        outboxRepo:   outboxRepo,
     }
 }
 
 const addWalletBalance = `-- name: AddWalletBalance :one
 UPDATE wallets
 SET balance = balance + $1
-WHERE id = $2 RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+WHERE id = $2 RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 `
 
 type AddWalletBalanceParams struct {
@@ -47,7 +53,7 @@ type AddWalletBalanceParams struct {
 }
 
 func (q *walletRepository) AddWalletBalance(ctx context.Context, arg AddWalletBalanceParams) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, addWalletBalance, arg.Amount, arg.ID)
+    row := conn(ctx, q.db).QueryRowContext(ctx, addWalletBalance, arg.Amount, arg.ID)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -57,6 +63,37 @@ func (q *walletRepository) AddWalletBalance(ctx context.Context, arg AddWalletBa
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
+        &i.Currency,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const addWalletHeldBalance = `-- name: AddWalletHeldBalance :one
+UPDATE wallets
+SET held_balance = held_balance + $1
+WHERE id = $2 RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
+`
+
+type AddWalletHeldBalanceParams struct {
+    Amount int64 `json:"amount"`
+    ID     int64 `json:"id"`
+}
+
+func (q *walletRepository) AddWalletHeldBalance(ctx context.Context, arg AddWalletHeldBalanceParams) (domain.Wallet, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, addWalletHeldBalance, arg.Amount, arg.ID)
+    var i domain.Wallet
+    err := row.Scan(
+        &i.ID,
+        &i.Address,
+        &i.Status,
+        &i.UserID,
+        &i.BankAccountID,
+        &i.OrganizationWalletID,
+        &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -71,8 +108,9 @@ INSERT INTO wallets (address,
                      bank_account_id,
                      organization_wallet_id,
                      balance,
+                     opening_balance,
                      currency)
-VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $6, $7) RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 `
 
 type CreateWalletParams struct {
@@ -86,7 +124,7 @@ type CreateWalletParams struct {
 }
 
 func (q *walletRepository) CreateWallet(ctx context.Context, arg CreateWalletParams) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, createWallet,
+    row := conn(ctx, q.db).QueryRowContext(ctx, createWallet,
         arg.Address,
         arg.Status,
         arg.UserID,
@@ -104,6 +142,7 @@ func (q *walletRepository) CreateWallet(ctx context.Context, arg CreateWalletPar
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -112,13 +151,13 @@ func (q *walletRepository) CreateWallet(ctx context.Context, arg CreateWalletPar
 }
 
 const getWallet = `-- name: GetWallet :one
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE id = $1 LIMIT 1
 `
 
 func (q *walletRepository) GetWallet(ctx context.Context, id int64) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, getWallet, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWallet, id)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -128,6 +167,7 @@ func (q *walletRepository) GetWallet(ctx context.Context, id int64) (domain.Wall
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -136,13 +176,13 @@ func (q *walletRepository) GetWallet(ctx context.Context, id int64) (domain.Wall
 }
 
 const getWalletByAddress = `-- name: GetWalletByAddress :one
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE address = $1 LIMIT 1
 `
 
 func (q *walletRepository) GetWalletByAddress(ctx context.Context, address string) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, getWalletByAddress, address)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWalletByAddress, address)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -152,6 +192,7 @@ func (q *walletRepository) GetWalletByAddress(ctx context.Context, address strin
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -160,7 +201,7 @@ func (q *walletRepository) GetWalletByAddress(ctx context.Context, address strin
 }
 
 const getWalletByAddressForUpdate = `-- name: GetWalletByAddressForUpdate :one
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE address = $1 LIMIT 1
 FOR NO KEY
@@ -168,7 +209,7 @@ UPDATE
 `
 
 func (q *walletRepository) GetWalletByAddressForUpdate(ctx context.Context, address string) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, getWalletByAddressForUpdate, address)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWalletByAddressForUpdate, address)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -178,6 +219,7 @@ func (q *walletRepository) GetWalletByAddressForUpdate(ctx context.Context, addr
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -186,7 +228,7 @@ func (q *walletRepository) GetWalletByAddressForUpdate(ctx context.Context, addr
 }
 
 const getWalletForUpdate = `-- name: GetWalletForUpdate :one
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE id = $1 LIMIT 1
 FOR NO KEY
@@ -194,7 +236,7 @@ UPDATE
 `
 
 func (q *walletRepository) GetWalletForUpdate(ctx context.Context, id int64) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, getWalletForUpdate, id)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWalletForUpdate, id)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -204,6 +246,7 @@ func (q *walletRepository) GetWalletForUpdate(ctx context.Context, id int64) (do
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -212,7 +255,7 @@ func (q *walletRepository) GetWalletForUpdate(ctx context.Context, id int64) (do
 }
 
 const listWallets = `-- name: ListWallets :many
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE user_id = $1
 ORDER BY id LIMIT $2
@@ -226,7 +269,7 @@ type ListWalletsParams struct {
 }
 
 func (q *walletRepository) ListWallets(ctx context.Context, arg ListWalletsParams) ([]domain.Wallet, error) {
-    rows, err := q.db.QueryContext(ctx, listWallets, arg.UserID, arg.Limit, arg.Offset)
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listWallets, arg.UserID, arg.Limit, arg.Offset)
     if err != nil {
         return nil, err
     }
@@ -242,6 +285,7 @@ func (q *walletRepository) ListWallets(ctx context.Context, arg ListWalletsParam
             &i.BankAccountID,
             &i.OrganizationWalletID,
             &i.Balance,
+            &i.HeldBalance,
             &i.Currency,
             &i.CreatedAt,
             &i.UpdatedAt,
@@ -263,7 +307,7 @@ const updateWalletStatus = `-- name: UpdateWalletStatus :one
 UPDATE wallets
 set Status = $1
 where id = $2
-RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+RETURNING id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 `
 
 type UpdateWalletStatusParams struct {
@@ -272,7 +316,7 @@ type UpdateWalletStatusParams struct {
 }
 
 func (q *walletRepository) UpdateWalletStatus(ctx context.Context, arg UpdateWalletStatusParams) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, updateWalletStatus, arg.Status, arg.ID)
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateWalletStatus, arg.Status, arg.ID)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -282,6 +326,7 @@ func (q *walletRepository) UpdateWalletStatus(ctx context.Context, arg UpdateWal
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -290,13 +335,13 @@ func (q *walletRepository) UpdateWalletStatus(ctx context.Context, arg UpdateWal
 }
 
 const getWalletByBankAccountID = `-- name: GetWalletByBankAccountID :one
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE bank_account_id = $1 LIMIT 1
 `
 
 func (q *walletRepository) GetWalletByBankAccountID(ctx context.Context, bankAccountID int64) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, getWalletByBankAccountID, bankAccountID)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWalletByBankAccountID, bankAccountID)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -306,6 +351,7 @@ func (q *walletRepository) GetWalletByBankAccountID(ctx context.Context, bankAcc
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -314,7 +360,7 @@ func (q *walletRepository) GetWalletByBankAccountID(ctx context.Context, bankAcc
 }
 
 const getWalletByBankAccountIDForUpdate = `-- name: GetWalletByBankAccountIDForUpdate :one
-SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, currency, created_at, updated_at
+SELECT id, address, status, user_id, bank_account_id, organization_wallet_id, balance, held_balance, currency, created_at, updated_at
 FROM wallets
 WHERE bank_account_id = $1 LIMIT 1
 FOR NO KEY
@@ -322,7 +368,7 @@ UPDATE
 `
 
 func (q *walletRepository) GetWalletByBankAccountIDForUpdate(ctx context.Context, bankAccountID int64) (domain.Wallet, error) {
-    row := q.db.QueryRowContext(ctx, getWalletByBankAccountIDForUpdate, bankAccountID)
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWalletByBankAccountIDForUpdate, bankAccountID)
     var i domain.Wallet
     err := row.Scan(
         &i.ID,
@@ -332,6 +378,7 @@ func (q *walletRepository) GetWalletByBankAccountIDForUpdate(ctx context.Context
         &i.BankAccountID,
         &i.OrganizationWalletID,
         &i.Balance,
+        &i.HeldBalance,
         &i.Currency,
         &i.CreatedAt,
         &i.UpdatedAt,
@@ -341,49 +388,54 @@ func (q *walletRepository) GetWalletByBankAccountIDForUpdate(ctx context.Context
 
 type WalletTransferResult struct {
     Wallet    domain.Wallet   `json:"wallet"`
+    ToWallet  domain.Wallet   `json:"to_wallet"`
     FromEntry domain.Entry    `json:"from_entry"`
     ToEntry   domain.Entry    `json:"to_entry"`
     Transfer  domain.Transfer `json:"transfer"`
 }
 
+// SendMoneyParams moves Amount between two wallets, a transfer of Kind PAYMENT when Kind is empty.
 type SendMoneyParams struct {
-    FromWalletAddress string `json:"from_account_address"`
-    ToWalletAddress   string `json:"to_account_address""`
-    Amount            int64  `json:"amount"`
+    FromWalletAddress string              `json:"from_account_address"`
+    ToWalletAddress   string              `json:"to_account_address"`
+    Amount            int64               `json:"amount"`
+    Kind              domain.TransferKind `json:"kind"`
 }
 
 func (q *walletRepository) SendMoney(ctx context.Context, arg SendMoneyParams) (WalletTransferResult, error) {
     var res WalletTransferResult
 
-    err := ExecTx(q.db, func(tx Tx) error {
+    kind := arg.Kind
+    if kind == "" {
+        kind = domain.TransferKindPAYMENT
+    }
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
         var err error
+        var fromWallet, toWallet domain.Wallet
 
-        fromWallet, err := q.GetWalletByAddressForUpdate(ctx, arg.FromWalletAddress)
+        fromWallet, toWallet, err = lockWallets(ctx, q, arg.FromWalletAddress, arg.ToWalletAddress)
         if err != nil {
             return err
         }
 
         if fromWallet.Status != domain.WalletStatusACTIVE {
-            return fmt.Errorf("inactive wallet")
+            return errors.ErrWalletInactive
         }
 
         if !fromWallet.IsBalanceSufficient(arg.Amount) {
-            return fmt.Errorf("insufficient wallet balance")
-        }
-
-        toWallet, err := q.GetWalletByAddressForUpdate(ctx, arg.ToWalletAddress)
-        if err != nil {
-            return err
+            return errors.ErrInsufficientBalance
         }
 
         if toWallet.Status != domain.WalletStatusACTIVE {
-            return fmt.Errorf("inactive wallet")
+            return errors.ErrWalletInactive
         }
 
         res.Transfer, err = q.transferRepo.CreateTransfer(ctx, CreateTransferParams{
             FromWalletID: fromWallet.ID,
             ToWalletID:   toWallet.ID,
             Amount:       arg.Amount,
+            Kind:         kind,
         })
 
         if err != nil {
@@ -416,14 +468,204 @@ func (q *walletRepository) SendMoney(ctx context.Context, arg SendMoneyParams) (
             toWallet, fromWallet, err = addMoney(ctx, q, toWallet.ID, arg.Amount, fromWallet.ID, -arg.Amount)
         }
 
+        if err != nil {
+            return err
+        }
+
         res.Wallet = fromWallet
-        return err
+        res.ToWallet = toWallet
+
+        return q.createTransferEvents(ctx, res)
     })
+    if err != nil {
+        return res, err
+    }
+
+    logMoneyMoved(ctx, res)
+    return res, nil
+}
+
+type RefundTransferParams struct {
+    TransferID           int64               `json:"transfer_id"`
+    Amount               int64               `json:"amount"`
+    Kind                 domain.TransferKind `json:"kind"`
+    AllowNegativeBalance bool                `json:"allow_negative_balance"`
+}
+
+type RefundTransferResult struct {
+    Refund           WalletTransferResult `json:"refund"`
+    OriginalTransfer domain.Transfer      `json:"original_transfer"`
+}
+
+// RefundTransfer sends Amount of a payment back from its receiver to its sender, a zero Amount refunds
+// what is left. Only a PAYMENT is refunded, the transfers of escrows, holds and checkouts are settled by them. The payment is locked while refunding, so concurrent refunds never add up past its amount.
+// A REFUND needs both wallets active, a REVERSAL is forced by an admin and skips that check. The receiver
+// needs the balance for both, unless AllowNegativeBalance is set.
+func (q *walletRepository) RefundTransfer(ctx context.Context, arg RefundTransferParams) (RefundTransferResult, error) {
+    var res RefundTransferResult
+
+    err := ExecTx(ctx, q.db, func(ctx context.Context) error {
+        original, err := q.transferRepo.GetTransferForUpdate(ctx, arg.TransferID)
+        if err != nil {
+            if err == sql.ErrNoRows {
+                return errors.ErrTransferNotFound
+            }
+            return err
+        }
+
+        if original.Kind != domain.TransferKindPAYMENT {
+            return errors.ErrTransferNotRefundable
+        }
+
+        amount := arg.Amount
+        if amount == 0 {
+            amount = original.RefundableAmount()
+        }
+
+        if amount <= 0 || amount > original.RefundableAmount() {
+            return errors.ErrRefundExceedsTransfer
+        }
+
+        // the money goes back from the receiver of the payment to its sender
+        receiver, err := q.GetWallet(ctx, original.ToWalletID)
+        if err != nil {
+            return err
+        }
+
+        sender, err := q.GetWallet(ctx, original.FromWalletID)
+        if err != nil {
+            return err
+        }
 
-    return res, err
+        sender, receiver, err = lockWallets(ctx, q, sender.Address, receiver.Address)
+        if err != nil {
+            return err
+        }
+
+        if arg.Kind != domain.TransferKindREVERSAL {
+            if receiver.Status != domain.WalletStatusACTIVE || sender.Status != domain.WalletStatusACTIVE {
+                return errors.ErrWalletInactive
+            }
+        }
+
+        if !arg.AllowNegativeBalance && !receiver.IsBalanceSufficient(amount) {
+            return errors.ErrInsufficientBalance
+        }
+
+        res.Refund.Transfer, err = q.transferRepo.CreateTransfer(ctx, CreateTransferParams{
+            FromWalletID:       receiver.ID,
+            ToWalletID:         sender.ID,
+            Amount:             amount,
+            Kind:               arg.Kind,
+            OriginalTransferID: sql.NullInt64{Int64: original.ID, Valid: true},
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Refund.FromEntry, err = q.entryRepo.CreateEntry(ctx, CreateEntryParams{
+            WalletID:   receiver.ID,
+            Amount:     -amount,
+            TransferID: res.Refund.Transfer.ID,
+        })
+        if err != nil {
+            return err
+        }
+
+        res.Refund.ToEntry, err = q.entryRepo.CreateEntry(ctx, CreateEntryParams{
+            WalletID:   sender.ID,
+            Amount:     amount,
+            TransferID: res.Refund.Transfer.ID,
+        })
+        if err != nil {
+            return err
+        }
+
+        if receiver.ID < sender.ID {
+            receiver, sender, err = addMoney(ctx, q, receiver.ID, -amount, sender.ID, amount)
+        } else {
+            sender, receiver, err = addMoney(ctx, q, sender.ID, amount, receiver.ID, -amount)
+        }
+        if err != nil {
+            return err
+        }
+
+        res.Refund.Wallet = receiver
+        res.Refund.ToWallet = sender
+
+        res.OriginalTransfer, err = q.transferRepo.AddTransferRefundedAmount(ctx, AddTransferRefundedAmountParams{
+            Amount: amount,
+            ID:     original.ID,
+        })
+        if err != nil {
+            return err
+        }
+
+        return q.createTransferEvents(ctx, res.Refund)
+    })
+    if err != nil {
+        return res, err
+    }
+
+    logMoneyMoved(ctx, res.Refund)
+    return res, nil
+}
+
+// createTransferEvents records a TransferCreated event for each wallet of the transfer, keyed on that wallet:
+// the events of a wallet are published in order whichever side of the transfer it is on.
+func (q *walletRepository) createTransferEvents(ctx context.Context, res WalletTransferResult) error {
+    for _, walletID := range []int64{res.Wallet.ID, res.ToWallet.ID} {
+        event, err := NewCreateOutboxEventParams(domain.AggregateTypeWALLET, walletID, domain.EventTypeTransferCreated, res)
+        if err != nil {
+            return err
+        }
+
+        _, err = q.outboxRepo.CreateOutboxEvent(ctx, event)
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// logMoneyMoved logs a transfer once it is written. A transfer made within an outer transaction, like the
+// capture of a hold, is logged before that transaction commits.
+func logMoneyMoved(ctx context.Context, res WalletTransferResult) {
+    logging.FromContext(ctx).WithFields(log.Fields{
+        "transfer_id":    res.Transfer.ID,
+        "kind":           res.Transfer.Kind,
+        "amount":         res.Transfer.Amount,
+        "currency":       res.Wallet.Currency,
+        "from_wallet_id": res.Transfer.FromWalletID,
+        "to_wallet_id":   res.Transfer.ToWalletID,
+    }).Info("money moved")
+}
+
+// lockWallets locks the two wallets for update and returns them in the order of their addresses given. Every
+// transaction moving money between two wallets locks them through it, in the order of their addresses whatever the
+// direction, so that opposite transfers, refunds, escrows, checkouts and captures on the same pair wait for each
+// other rather than deadlock, and the balances checked once locked are the ones moved.
+func lockWallets(ctx context.Context, q WalletRepo, address1 string, address2 string) (wallet1 domain.Wallet, wallet2 domain.Wallet, err error) {
+    if address2 < address1 {
+        wallet2, wallet1, err = lockWallets(ctx, q, address2, address1)
+        return
+    }
+
+    wallet1, err = q.GetWalletByAddressForUpdate(ctx, address1)
+    if err != nil {
+        return
+    }
+
+    wallet2, err = q.GetWalletByAddressForUpdate(ctx, address2)
+    if err != nil {
+        return
+    }
+
+    return
 }
 
-func addMoney(ctx context.Context, q *walletRepository, walletID1 int64, amount1 int64, walletID2 int64, amount2 int64, ) (wallet1 domain.Wallet, wallet2 domain.Wallet, err error) {
+func addMoney(ctx context.Context, q *walletRepository, walletID1 int64, amount1 int64, walletID2 int64, amount2 int64) (wallet1 domain.Wallet, wallet2 domain.Wallet, err error) {
     wallet1, err = q.AddWalletBalance(ctx, AddWalletBalanceParams{
         ID:     walletID1,
         Amount: amount1,
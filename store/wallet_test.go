@@ -2,8 +2,10 @@ package store_test
 
 import (
     "context"
+    "encoding/json"
     "fmt"
     "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/pkg/errors"
     "github.com/pranayhere/simple-wallet/store"
     "github.com/stretchr/testify/require"
     "strings"
@@ -13,7 +15,7 @@ import (
 func InitWalletRepo(t *testing.T) store.WalletRepo {
     transferRepo := store.NewTransferRepo(testDb)
     entryRepo := store.NewEntryRepo(testDb)
-    walletRepo := store.NewWalletRepo(testDb, transferRepo, entryRepo)
+    walletRepo := store.NewWalletRepo(testDb, transferRepo, entryRepo, store.NewOutboxRepo(testDb))
 
     require.NotEmpty(t, transferRepo)
     require.NotEmpty(t, entryRepo)
@@ -223,3 +225,166 @@ func TestSendMoney(t *testing.T) {
     require.NotEmpty(t, updatedToWallet)
     require.Equal(t, toWallet.Balance+int64(n)*amount, updatedToWallet.Balance)
 }
+
+func TestSendMoneyOutboxEvents(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+
+    fromWallet := createRandomWalletWithAmount(t, 50)
+    verifyBankAccount(t, fromWallet.BankAccountID)
+
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    res, err := walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: fromWallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            10,
+    })
+    require.NoError(t, err)
+
+    events, err := store.NewOutboxRepo(testDb).ListUnpublishedOutboxEvents(context.Background(), 10000)
+    require.NoError(t, err)
+
+    // the transfer is published in the order of the events of both wallets
+    var walletIDs []int64
+    for _, event := range events {
+        if event.EventType != domain.EventTypeTransferCreated {
+            continue
+        }
+
+        var payload store.WalletTransferResult
+        require.NoError(t, json.Unmarshal([]byte(event.Payload), &payload))
+        if payload.Transfer.ID != res.Transfer.ID {
+            continue
+        }
+
+        require.Equal(t, domain.AggregateTypeWALLET, event.AggregateType)
+        walletIDs = append(walletIDs, event.AggregateID)
+    }
+    require.ElementsMatch(t, []int64{fromWallet.ID, toWallet.ID}, walletIDs)
+}
+
+func TestRefundTransfer(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+
+    fromWallet := createRandomWalletWithAmount(t, 100)
+    verifyBankAccount(t, fromWallet.BankAccountID)
+
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    payment, err := walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: fromWallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            100,
+    })
+    require.NoError(t, err)
+
+    // concurrent partial refunds never add up past the amount of the payment
+    n := 5
+    errs := make(chan error)
+
+    for i := 0; i < n; i++ {
+        go func() {
+            _, err := walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+                TransferID: payment.Transfer.ID,
+                Amount:     30,
+                Kind:       domain.TransferKindREFUND,
+            })
+            errs <- err
+        }()
+    }
+
+    var refunded int
+    for i := 0; i < n; i++ {
+        err := <-errs
+        if err == nil {
+            refunded++
+            continue
+        }
+        require.EqualError(t, err, errors.ErrRefundExceedsTransfer.Error())
+    }
+    require.Equal(t, 3, refunded)
+
+    // a zero amount refunds what is left
+    res, err := walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+        TransferID: payment.Transfer.ID,
+        Kind:       domain.TransferKindREFUND,
+    })
+    require.NoError(t, err)
+    require.Equal(t, int64(10), res.Refund.Transfer.Amount)
+    require.Equal(t, domain.TransferKindREFUND, res.Refund.Transfer.Kind)
+    require.Equal(t, payment.Transfer.ID, res.Refund.Transfer.OriginalTransferID.Int64)
+    require.Equal(t, toWallet.ID, res.Refund.Transfer.FromWalletID)
+    require.Equal(t, fromWallet.ID, res.Refund.Transfer.ToWalletID)
+    require.Equal(t, int64(-10), res.Refund.FromEntry.Amount)
+    require.Equal(t, int64(10), res.Refund.ToEntry.Amount)
+    require.Equal(t, int64(100), res.OriginalTransfer.RefundedAmount)
+    require.Equal(t, domain.RefundStatusREFUNDED, res.OriginalTransfer.RefundStatus)
+
+    require.Equal(t, fromWallet.Balance, res.Refund.ToWallet.Balance)
+    require.Equal(t, toWallet.Balance, res.Refund.Wallet.Balance)
+
+    _, err = walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+        TransferID: payment.Transfer.ID,
+        Kind:       domain.TransferKindREFUND,
+    })
+    require.EqualError(t, err, errors.ErrRefundExceedsTransfer.Error())
+
+    // a refund can't be refunded
+    _, err = walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+        TransferID: res.Refund.Transfer.ID,
+        Kind:       domain.TransferKindREFUND,
+    })
+    require.EqualError(t, err, errors.ErrTransferNotRefundable.Error())
+
+    _, err = walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+        TransferID: -1,
+        Kind:       domain.TransferKindREFUND,
+    })
+    require.EqualError(t, err, errors.ErrTransferNotFound.Error())
+}
+
+func TestReverseTransfer(t *testing.T) {
+    walletRepo := InitWalletRepo(t)
+
+    fromWallet := createRandomWalletWithAmount(t, 100)
+    verifyBankAccount(t, fromWallet.BankAccountID)
+
+    toWallet := createRandomWallet(t)
+    verifyBankAccount(t, toWallet.BankAccountID)
+
+    payment, err := walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: fromWallet.Address,
+        ToWalletAddress:   toWallet.Address,
+        Amount:            100,
+    })
+    require.NoError(t, err)
+
+    // the receiver spent part of the money
+    shopWallet := createRandomWallet(t)
+    verifyBankAccount(t, shopWallet.BankAccountID)
+
+    _, err = walletRepo.SendMoney(context.Background(), store.SendMoneyParams{
+        FromWalletAddress: toWallet.Address,
+        ToWalletAddress:   shopWallet.Address,
+        Amount:            60,
+    })
+    require.NoError(t, err)
+
+    _, err = walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+        TransferID: payment.Transfer.ID,
+        Kind:       domain.TransferKindREVERSAL,
+    })
+    require.EqualError(t, err, errors.ErrInsufficientBalance.Error())
+
+    res, err := walletRepo.RefundTransfer(context.Background(), store.RefundTransferParams{
+        TransferID:           payment.Transfer.ID,
+        Kind:                 domain.TransferKindREVERSAL,
+        AllowNegativeBalance: true,
+    })
+    require.NoError(t, err)
+    require.Equal(t, domain.TransferKindREVERSAL, res.Refund.Transfer.Kind)
+    require.Equal(t, toWallet.Balance-60, res.Refund.Wallet.Balance)
+    require.Equal(t, domain.RefundStatusREFUNDED, res.OriginalTransfer.RefundStatus)
+}
@@ -0,0 +1,226 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "time"
+)
+
+type WebhookDeliveryRepo interface {
+    CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (domain.WebhookDelivery, error)
+    GetWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+    ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]domain.WebhookDelivery, error)
+    ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]domain.WebhookDelivery, error)
+    UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) (domain.WebhookDelivery, error)
+}
+
+type webhookDeliveryRepository struct {
+    db *sql.DB
+}
+
+func NewWebhookDeliveryRepo(client *sql.DB) WebhookDeliveryRepo {
+    return &webhookDeliveryRepository{
+        db: client,
+    }
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (endpoint_id,
+                                event_id,
+                                event_type,
+                                payload,
+                                status)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT (endpoint_id, event_id) DO NOTHING
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_response_code, last_error, created_at, updated_at
+`
+
+type CreateWebhookDeliveryParams struct {
+    EndpointID int64                        `json:"endpoint_id"`
+    EventID    string                       `json:"event_id"`
+    EventType  domain.EventType             `json:"event_type"`
+    Payload    string                       `json:"payload"`
+    Status     domain.WebhookDeliveryStatus `json:"status"`
+}
+
+// CreateWebhookDelivery fails with sql.ErrNoRows when the endpoint already has a delivery of the event.
+func (q *webhookDeliveryRepository) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createWebhookDelivery,
+        arg.EndpointID,
+        arg.EventID,
+        arg.EventType,
+        arg.Payload,
+        arg.Status,
+    )
+    var i domain.WebhookDelivery
+    err := row.Scan(
+        &i.ID,
+        &i.EndpointID,
+        &i.EventID,
+        &i.EventType,
+        &i.Payload,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastResponseCode,
+        &i.LastError,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getWebhookDelivery = `-- name: GetWebhookDelivery :one
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_response_code, last_error, created_at, updated_at
+FROM webhook_deliveries
+WHERE id = $1 LIMIT 1
+`
+
+func (q *webhookDeliveryRepository) GetWebhookDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWebhookDelivery, id)
+    var i domain.WebhookDelivery
+    err := row.Scan(
+        &i.ID,
+        &i.EndpointID,
+        &i.EventID,
+        &i.EventType,
+        &i.Payload,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastResponseCode,
+        &i.LastError,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_response_code, last_error, created_at, updated_at
+FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY id DESC LIMIT $2
+OFFSET $3
+`
+
+type ListWebhookDeliveriesParams struct {
+    EndpointID int64 `json:"endpoint_id"`
+    Limit      int32 `json:"limit"`
+    Offset     int32 `json:"offset"`
+}
+
+func (q *webhookDeliveryRepository) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]domain.WebhookDelivery, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listWebhookDeliveries, arg.EndpointID, arg.Limit, arg.Offset)
+    if err != nil {
+        return nil, err
+    }
+    return scanWebhookDeliveries(rows)
+}
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = now() + make_interval(secs => $2)
+WHERE id IN (SELECT id
+             FROM webhook_deliveries
+             WHERE status = 'PENDING'
+               AND next_attempt_at <= now()
+             ORDER BY next_attempt_at
+             LIMIT $1 FOR UPDATE SKIP LOCKED)
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_response_code, last_error, created_at, updated_at
+`
+
+// ClaimDueWebhookDeliveriesParams pushes the claimed deliveries LeaseSeconds into the future
+// so that concurrent workers don't pick them up while they are being sent.
+type ClaimDueWebhookDeliveriesParams struct {
+    Limit        int32 `json:"limit"`
+    LeaseSeconds int64 `json:"lease_seconds"`
+}
+
+func (q *webhookDeliveryRepository) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]domain.WebhookDelivery, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, claimDueWebhookDeliveries, arg.Limit, arg.LeaseSeconds)
+    if err != nil {
+        return nil, err
+    }
+    return scanWebhookDeliveries(rows)
+}
+
+const updateWebhookDelivery = `-- name: UpdateWebhookDelivery :one
+UPDATE webhook_deliveries
+set status             = $1,
+    attempts           = $2,
+    next_attempt_at    = $3,
+    last_response_code = $4,
+    last_error         = $5,
+    updated_at         = now()
+where id = $6
+RETURNING id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_response_code, last_error, created_at, updated_at
+`
+
+type UpdateWebhookDeliveryParams struct {
+    Status           domain.WebhookDeliveryStatus `json:"status"`
+    Attempts         int64                        `json:"attempts"`
+    NextAttemptAt    time.Time                    `json:"next_attempt_at"`
+    LastResponseCode int64                        `json:"last_response_code"`
+    LastError        string                       `json:"last_error"`
+    ID               int64                        `json:"id"`
+}
+
+func (q *webhookDeliveryRepository) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) (domain.WebhookDelivery, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateWebhookDelivery,
+        arg.Status,
+        arg.Attempts,
+        arg.NextAttemptAt,
+        arg.LastResponseCode,
+        arg.LastError,
+        arg.ID,
+    )
+    var i domain.WebhookDelivery
+    err := row.Scan(
+        &i.ID,
+        &i.EndpointID,
+        &i.EventID,
+        &i.EventType,
+        &i.Payload,
+        &i.Status,
+        &i.Attempts,
+        &i.NextAttemptAt,
+        &i.LastResponseCode,
+        &i.LastError,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+func scanWebhookDeliveries(rows *sql.Rows) ([]domain.WebhookDelivery, error) {
+    defer rows.Close()
+    items := []domain.WebhookDelivery{}
+    for rows.Next() {
+        var i domain.WebhookDelivery
+        if err := rows.Scan(
+            &i.ID,
+            &i.EndpointID,
+            &i.EventID,
+            &i.EventType,
+            &i.Payload,
+            &i.Status,
+            &i.Attempts,
+            &i.NextAttemptAt,
+            &i.LastResponseCode,
+            &i.LastError,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
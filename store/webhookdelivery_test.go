@@ -0,0 +1,141 @@
+package store_test
+
+import (
+    "context"
+    "database/sql"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+    "time"
+)
+
+func createRandomWebhookDelivery(t *testing.T, endpointID int64) domain.WebhookDelivery {
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(testDb)
+
+    arg := store.CreateWebhookDeliveryParams{
+        EndpointID: endpointID,
+        EventID:    util.RandomString(16),
+        EventType:  domain.EventTypeTransferCompleted,
+        Payload:    `{"amount": 10}`,
+        Status:     domain.WebhookDeliveryStatusPENDING,
+    }
+
+    delivery, err := webhookDeliveryRepo.CreateWebhookDelivery(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, delivery)
+
+    require.Equal(t, arg.EndpointID, delivery.EndpointID)
+    require.Equal(t, arg.EventID, delivery.EventID)
+    require.Equal(t, arg.EventType, delivery.EventType)
+    require.JSONEq(t, arg.Payload, delivery.Payload)
+    require.Equal(t, arg.Status, delivery.Status)
+    require.Zero(t, delivery.Attempts)
+
+    require.NotZero(t, delivery.ID)
+    require.NotZero(t, delivery.NextAttemptAt)
+    require.NotZero(t, delivery.CreatedAt)
+
+    return delivery
+}
+
+func TestCreateWebhookDelivery(t *testing.T) {
+    endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).ID, []string{})
+    createRandomWebhookDelivery(t, endpoint.ID)
+}
+
+func TestCreateWebhookDeliveryOfEventOnce(t *testing.T) {
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(testDb)
+    endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).ID, []string{})
+    delivery := createRandomWebhookDelivery(t, endpoint.ID)
+
+    _, err := webhookDeliveryRepo.CreateWebhookDelivery(context.Background(), store.CreateWebhookDeliveryParams{
+        EndpointID: endpoint.ID,
+        EventID:    delivery.EventID,
+        EventType:  delivery.EventType,
+        Payload:    delivery.Payload,
+        Status:     domain.WebhookDeliveryStatusPENDING,
+    })
+    require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestGetWebhookDelivery(t *testing.T) {
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(testDb)
+    endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).ID, []string{})
+
+    delivery1 := createRandomWebhookDelivery(t, endpoint.ID)
+    delivery2, err := webhookDeliveryRepo.GetWebhookDelivery(context.Background(), delivery1.ID)
+    require.NoError(t, err)
+
+    require.Equal(t, delivery1.ID, delivery2.ID)
+    require.Equal(t, delivery1.EventID, delivery2.EventID)
+    require.Equal(t, delivery1.Status, delivery2.Status)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(testDb)
+    endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).ID, []string{})
+
+    for i := 0; i < 5; i++ {
+        createRandomWebhookDelivery(t, endpoint.ID)
+    }
+
+    arg := store.ListWebhookDeliveriesParams{
+        EndpointID: endpoint.ID,
+        Limit:      3,
+        Offset:     1,
+    }
+
+    deliveries, err := webhookDeliveryRepo.ListWebhookDeliveries(context.Background(), arg)
+    require.NoError(t, err)
+    require.Len(t, deliveries, 3)
+
+    for _, delivery := range deliveries {
+        require.Equal(t, endpoint.ID, delivery.EndpointID)
+    }
+}
+
+func TestClaimDueWebhookDeliveries(t *testing.T) {
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(testDb)
+    endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).ID, []string{})
+    createRandomWebhookDelivery(t, endpoint.ID)
+
+    arg := store.ClaimDueWebhookDeliveriesParams{
+        Limit:        1000,
+        LeaseSeconds: 60,
+    }
+
+    deliveries, err := webhookDeliveryRepo.ClaimDueWebhookDeliveries(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, deliveries)
+
+    // claimed deliveries are leased and not handed out again
+    deliveries, err = webhookDeliveryRepo.ClaimDueWebhookDeliveries(context.Background(), arg)
+    require.NoError(t, err)
+    require.Empty(t, deliveries)
+}
+
+func TestUpdateWebhookDelivery(t *testing.T) {
+    webhookDeliveryRepo := store.NewWebhookDeliveryRepo(testDb)
+    endpoint := createRandomWebhookEndpoint(t, createRandomUser(t).ID, []string{})
+    delivery1 := createRandomWebhookDelivery(t, endpoint.ID)
+
+    arg := store.UpdateWebhookDeliveryParams{
+        ID:               delivery1.ID,
+        Status:           domain.WebhookDeliveryStatusPENDING,
+        Attempts:         1,
+        NextAttemptAt:    time.Now().UTC().Add(time.Minute).Truncate(time.Second),
+        LastResponseCode: 500,
+        LastError:        "unexpected response status 500",
+    }
+
+    delivery2, err := webhookDeliveryRepo.UpdateWebhookDelivery(context.Background(), arg)
+    require.NoError(t, err)
+
+    require.Equal(t, arg.Status, delivery2.Status)
+    require.Equal(t, arg.Attempts, delivery2.Attempts)
+    require.WithinDuration(t, arg.NextAttemptAt, delivery2.NextAttemptAt, time.Second)
+    require.Equal(t, arg.LastResponseCode, delivery2.LastResponseCode)
+    require.Equal(t, arg.LastError, delivery2.LastError)
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+    "context"
+    "database/sql"
+    "github.com/lib/pq"
+    "github.com/pranayhere/simple-wallet/domain"
+)
+
+type WebhookEndpointRepo interface {
+    CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (domain.WebhookEndpoint, error)
+    GetWebhookEndpoint(ctx context.Context, id int64) (domain.WebhookEndpoint, error)
+    ListWebhookEndpoints(ctx context.Context, userID int64) ([]domain.WebhookEndpoint, error)
+    ListActiveWebhookEndpointsForEvent(ctx context.Context, arg ListActiveWebhookEndpointsForEventParams) ([]domain.WebhookEndpoint, error)
+    UpdateWebhookEndpointStatus(ctx context.Context, arg UpdateWebhookEndpointStatusParams) (domain.WebhookEndpoint, error)
+}
+
+type webhookEndpointRepository struct {
+    db *sql.DB
+}
+
+func NewWebhookEndpointRepo(client *sql.DB) WebhookEndpointRepo {
+    return &webhookEndpointRepository{
+        db: client,
+    }
+}
+
+const createWebhookEndpoint = `-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (user_id,
+                               url,
+                               secret,
+                               event_types,
+                               status)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, url, secret, event_types, status, created_at, updated_at
+`
+
+type CreateWebhookEndpointParams struct {
+    UserID     int64                        `json:"user_id"`
+    Url        string                       `json:"url"`
+    Secret     string                       `json:"secret"`
+    EventTypes []string                     `json:"event_types"`
+    Status     domain.WebhookEndpointStatus `json:"status"`
+}
+
+func (q *webhookEndpointRepository) CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (domain.WebhookEndpoint, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, createWebhookEndpoint,
+        arg.UserID,
+        arg.Url,
+        arg.Secret,
+        pq.Array(arg.EventTypes),
+        arg.Status,
+    )
+    var i domain.WebhookEndpoint
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.Url,
+        &i.Secret,
+        pq.Array(&i.EventTypes),
+        &i.Status,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const getWebhookEndpoint = `-- name: GetWebhookEndpoint :one
+SELECT id, user_id, url, secret, event_types, status, created_at, updated_at
+FROM webhook_endpoints
+WHERE id = $1 LIMIT 1
+`
+
+func (q *webhookEndpointRepository) GetWebhookEndpoint(ctx context.Context, id int64) (domain.WebhookEndpoint, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, getWebhookEndpoint, id)
+    var i domain.WebhookEndpoint
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.Url,
+        &i.Secret,
+        pq.Array(&i.EventTypes),
+        &i.Status,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+const listWebhookEndpoints = `-- name: ListWebhookEndpoints :many
+SELECT id, user_id, url, secret, event_types, status, created_at, updated_at
+FROM webhook_endpoints
+WHERE user_id = $1
+ORDER BY id
+`
+
+func (q *webhookEndpointRepository) ListWebhookEndpoints(ctx context.Context, userID int64) ([]domain.WebhookEndpoint, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listWebhookEndpoints, userID)
+    if err != nil {
+        return nil, err
+    }
+    return scanWebhookEndpoints(rows)
+}
+
+const listActiveWebhookEndpointsForEvent = `-- name: ListActiveWebhookEndpointsForEvent :many
+SELECT id, user_id, url, secret, event_types, status, created_at, updated_at
+FROM webhook_endpoints
+WHERE user_id = $1
+  AND status = 'ACTIVE'
+  AND (cardinality(event_types) = 0 OR $2 = ANY (event_types))
+ORDER BY id
+`
+
+type ListActiveWebhookEndpointsForEventParams struct {
+    UserID    int64            `json:"user_id"`
+    EventType domain.EventType `json:"event_type"`
+}
+
+func (q *webhookEndpointRepository) ListActiveWebhookEndpointsForEvent(ctx context.Context, arg ListActiveWebhookEndpointsForEventParams) ([]domain.WebhookEndpoint, error) {
+    rows, err := conn(ctx, q.db).QueryContext(ctx, listActiveWebhookEndpointsForEvent, arg.UserID, arg.EventType)
+    if err != nil {
+        return nil, err
+    }
+    return scanWebhookEndpoints(rows)
+}
+
+const updateWebhookEndpointStatus = `-- name: UpdateWebhookEndpointStatus :one
+UPDATE webhook_endpoints
+set status     = $1,
+    updated_at = now()
+where id = $2
+RETURNING id, user_id, url, secret, event_types, status, created_at, updated_at
+`
+
+type UpdateWebhookEndpointStatusParams struct {
+    Status domain.WebhookEndpointStatus `json:"status"`
+    ID     int64                        `json:"id"`
+}
+
+func (q *webhookEndpointRepository) UpdateWebhookEndpointStatus(ctx context.Context, arg UpdateWebhookEndpointStatusParams) (domain.WebhookEndpoint, error) {
+    row := conn(ctx, q.db).QueryRowContext(ctx, updateWebhookEndpointStatus, arg.Status, arg.ID)
+    var i domain.WebhookEndpoint
+    err := row.Scan(
+        &i.ID,
+        &i.UserID,
+        &i.Url,
+        &i.Secret,
+        pq.Array(&i.EventTypes),
+        &i.Status,
+        &i.CreatedAt,
+        &i.UpdatedAt,
+    )
+    return i, err
+}
+
+func scanWebhookEndpoints(rows *sql.Rows) ([]domain.WebhookEndpoint, error) {
+    defer rows.Close()
+    items := []domain.WebhookEndpoint{}
+    for rows.Next() {
+        var i domain.WebhookEndpoint
+        if err := rows.Scan(
+            &i.ID,
+            &i.UserID,
+            &i.Url,
+            &i.Secret,
+            pq.Array(&i.EventTypes),
+            &i.Status,
+            &i.CreatedAt,
+            &i.UpdatedAt,
+        ); err != nil {
+            return nil, err
+        }
+        items = append(items, i)
+    }
+    if err := rows.Close(); err != nil {
+        return nil, err
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
+    }
+    return items, nil
+}
@@ -0,0 +1,117 @@
+package store_test
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/domain"
+    "github.com/pranayhere/simple-wallet/store"
+    "github.com/pranayhere/simple-wallet/util"
+    "github.com/stretchr/testify/require"
+    "testing"
+)
+
+func createRandomWebhookEndpoint(t *testing.T, userID int64, eventTypes []string) domain.WebhookEndpoint {
+    webhookEndpointRepo := store.NewWebhookEndpointRepo(testDb)
+
+    arg := store.CreateWebhookEndpointParams{
+        UserID:     userID,
+        Url:        "https://" + util.RandomString(8) + ".example.com/hooks",
+        Secret:     "whsec_" + util.RandomString(32),
+        EventTypes: eventTypes,
+        Status:     domain.WebhookEndpointStatusACTIVE,
+    }
+
+    endpoint, err := webhookEndpointRepo.CreateWebhookEndpoint(context.Background(), arg)
+    require.NoError(t, err)
+    require.NotEmpty(t, endpoint)
+
+    require.Equal(t, arg.UserID, endpoint.UserID)
+    require.Equal(t, arg.Url, endpoint.Url)
+    require.Equal(t, arg.Secret, endpoint.Secret)
+    require.Equal(t, arg.EventTypes, endpoint.EventTypes)
+    require.Equal(t, arg.Status, endpoint.Status)
+
+    require.NotZero(t, endpoint.ID)
+    require.NotZero(t, endpoint.CreatedAt)
+    require.NotZero(t, endpoint.UpdatedAt)
+
+    return endpoint
+}
+
+func TestCreateWebhookEndpoint(t *testing.T) {
+    user := createRandomUser(t)
+    createRandomWebhookEndpoint(t, user.ID, []string{string(domain.EventTypeTransferCompleted)})
+}
+
+func TestGetWebhookEndpoint(t *testing.T) {
+    webhookEndpointRepo := store.NewWebhookEndpointRepo(testDb)
+    user := createRandomUser(t)
+
+    endpoint1 := createRandomWebhookEndpoint(t, user.ID, []string{})
+    endpoint2, err := webhookEndpointRepo.GetWebhookEndpoint(context.Background(), endpoint1.ID)
+    require.NoError(t, err)
+    require.NotEmpty(t, endpoint2)
+
+    require.Equal(t, endpoint1.ID, endpoint2.ID)
+    require.Equal(t, endpoint1.Url, endpoint2.Url)
+    require.Equal(t, endpoint1.Secret, endpoint2.Secret)
+    require.Empty(t, endpoint2.EventTypes)
+}
+
+func TestListWebhookEndpoints(t *testing.T) {
+    webhookEndpointRepo := store.NewWebhookEndpointRepo(testDb)
+    user := createRandomUser(t)
+
+    for i := 0; i < 3; i++ {
+        createRandomWebhookEndpoint(t, user.ID, []string{})
+    }
+
+    endpoints, err := webhookEndpointRepo.ListWebhookEndpoints(context.Background(), user.ID)
+    require.NoError(t, err)
+    require.Len(t, endpoints, 3)
+
+    for _, endpoint := range endpoints {
+        require.Equal(t, user.ID, endpoint.UserID)
+    }
+}
+
+func TestListActiveWebhookEndpointsForEvent(t *testing.T) {
+    webhookEndpointRepo := store.NewWebhookEndpointRepo(testDb)
+    user := createRandomUser(t)
+
+    all := createRandomWebhookEndpoint(t, user.ID, []string{})
+    transfers := createRandomWebhookEndpoint(t, user.ID, []string{string(domain.EventTypeTransferCompleted)})
+    createRandomWebhookEndpoint(t, user.ID, []string{string(domain.EventTypeBankAccountVerified)})
+    disabled := createRandomWebhookEndpoint(t, user.ID, []string{})
+
+    _, err := webhookEndpointRepo.UpdateWebhookEndpointStatus(context.Background(), store.UpdateWebhookEndpointStatusParams{
+        ID:     disabled.ID,
+        Status: domain.WebhookEndpointStatusDISABLED,
+    })
+    require.NoError(t, err)
+
+    arg := store.ListActiveWebhookEndpointsForEventParams{
+        UserID:    user.ID,
+        EventType: domain.EventTypeTransferCompleted,
+    }
+
+    endpoints, err := webhookEndpointRepo.ListActiveWebhookEndpointsForEvent(context.Background(), arg)
+    require.NoError(t, err)
+    require.Len(t, endpoints, 2)
+    require.Equal(t, all.ID, endpoints[0].ID)
+    require.Equal(t, transfers.ID, endpoints[1].ID)
+}
+
+func TestUpdateWebhookEndpointStatus(t *testing.T) {
+    webhookEndpointRepo := store.NewWebhookEndpointRepo(testDb)
+    user := createRandomUser(t)
+    endpoint1 := createRandomWebhookEndpoint(t, user.ID, []string{})
+
+    arg := store.UpdateWebhookEndpointStatusParams{
+        ID:     endpoint1.ID,
+        Status: domain.WebhookEndpointStatusDISABLED,
+    }
+
+    endpoint2, err := webhookEndpointRepo.UpdateWebhookEndpointStatus(context.Background(), arg)
+    require.NoError(t, err)
+    require.Equal(t, arg.Status, endpoint2.Status)
+}
@@ -22,10 +22,16 @@ func NewJWTMaker(secretKey string) (Maker, error) {
 
 // CreateToken creates a new token for specified username and duration
 func (maker *JWTMaker) CreateToken(userID int64, duration time.Duration) (string, error) {
+    return maker.CreatePurposeToken(userID, "", duration)
+}
+
+// CreatePurposeToken creates a new token for specified username, purpose and duration
+func (maker *JWTMaker) CreatePurposeToken(userID int64, purpose string, duration time.Duration) (string, error) {
     payload, err := NewPayload(userID, duration)
     if err != nil {
         return "", err
     }
+    payload.Purpose = purpose
 
     jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
     return jwtToken.SignedString([]byte(maker.secretKey))
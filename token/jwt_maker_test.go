@@ -31,6 +31,21 @@ func TestJWTMaker(t *testing.T) {
     require.Equal(t, userID, payload.UserID)
     require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
     require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+    require.Empty(t, payload.Purpose)
+}
+
+func TestJWTMakerPurposeToken(t *testing.T) {
+    maker, err := NewJWTMaker(util.RandomString(32))
+    require.NoError(t, err)
+
+    userID := util.RandomInt(1, 1000)
+    token, err := maker.CreatePurposeToken(userID, PurposeTwoFactor, time.Minute)
+    require.NoError(t, err)
+
+    payload, err := maker.VerifyToken(token)
+    require.NoError(t, err)
+    require.Equal(t, userID, payload.UserID)
+    require.Equal(t, PurposeTwoFactor, payload.Purpose)
 }
 
 func TestExpiredJWTTest(t *testing.T) {
@@ -11,10 +11,16 @@ var (
     ErrInvalidToken = errors.New("invalid token")
 )
 
-// Payload contains the payload data of the token
+// PurposeTwoFactor is the purpose of the tokens of a login waiting for its TOTP code, they only prove the
+// password of the user and are exchanged for an access token with the code.
+const PurposeTwoFactor = "two_factor"
+
+// Payload contains the payload data of the token. The access tokens have no Purpose, the tokens of a purpose are
+// not accepted as access tokens.
 type Payload struct {
     ID        uuid.UUID `json:"id"`
     UserID    int64     `json:"userID"`
+    Purpose   string    `json:"purpose,omitempty"`
     IssuedAt  time.Time `json:"issued_at"`
     ExpiredAt time.Time `json:"expire_at"`
 }
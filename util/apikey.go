@@ -0,0 +1,45 @@
+package util
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/subtle"
+    "encoding/hex"
+    "strings"
+)
+
+const apiKeyTag = "sk"
+
+// GenerateAPIKey returns a new key in the form "sk_<prefix>_<secret>". The prefix is stored in
+// clear to look the key up and to tell the keys apart, the whole key is only stored hashed.
+func GenerateAPIKey() (key string, prefix string, err error) {
+    b := make([]byte, 28)
+    if _, err := rand.Read(b); err != nil {
+        return "", "", err
+    }
+
+    prefix = apiKeyTag + "_" + hex.EncodeToString(b[:4])
+    key = prefix + "_" + hex.EncodeToString(b[4:])
+    return key, prefix, nil
+}
+
+// APIKeyPrefix returns the prefix of a key produced by GenerateAPIKey, false if the key is malformed
+func APIKeyPrefix(key string) (string, bool) {
+    parts := strings.Split(key, "_")
+    if len(parts) != 3 || parts[0] != apiKeyTag || len(parts[1]) != 8 || len(parts[2]) != 48 {
+        return "", false
+    }
+
+    return parts[0] + "_" + parts[1], true
+}
+
+// HashAPIKey return the sha256 hash of the key, the keys are random so they don't need a slow hash
+func HashAPIKey(key string) string {
+    sum := sha256.Sum256([]byte(key))
+    return hex.EncodeToString(sum[:])
+}
+
+// CheckAPIKey check if the key matches the hash in constant time
+func CheckAPIKey(key string, hashedKey string) bool {
+    return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(hashedKey)) == 1
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestAPIKey(t *testing.T) {
+    key, prefix, err := GenerateAPIKey()
+    require.NoError(t, err)
+    require.Regexp(t, `^sk_[0-9a-f]{8}_[0-9a-f]{48}$`, key)
+    require.Equal(t, key[:11], prefix)
+
+    parsedPrefix, ok := APIKeyPrefix(key)
+    require.True(t, ok)
+    require.Equal(t, prefix, parsedPrefix)
+
+    hashedKey := HashAPIKey(key)
+    require.NotEqual(t, key, hashedKey)
+    require.True(t, CheckAPIKey(key, hashedKey))
+
+    otherKey, otherPrefix, err := GenerateAPIKey()
+    require.NoError(t, err)
+    require.NotEqual(t, prefix, otherPrefix)
+    require.False(t, CheckAPIKey(otherKey, hashedKey))
+
+    for _, malformed := range []string{"", "sk_", prefix, "pk" + key[2:], key + "0", "whsec_" + RandomString(48)} {
+        _, ok := APIKeyPrefix(malformed)
+        require.False(t, ok, malformed)
+    }
+}
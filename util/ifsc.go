@@ -0,0 +1,13 @@
+package util
+
+import (
+    "regexp"
+)
+
+// an IFSC is the 4 letter bank code, a reserved zero and the 6 character branch code
+var ifscRegex = regexp.MustCompile(`^[A-Z]{4}0[A-Z0-9]{6}$`)
+
+// IsValidIfsc check if the code is a well-formed IFSC
+func IsValidIfsc(code string) bool {
+    return ifscRegex.MatchString(code)
+}
@@ -0,0 +1,19 @@
+package util
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestIsValidIfsc(t *testing.T) {
+    require.True(t, IsValidIfsc("HDFC0000076"))
+    require.True(t, IsValidIfsc("SBIN0A12B34"))
+    require.True(t, IsValidIfsc(RandomIfsc()))
+
+    require.False(t, IsValidIfsc("HDFC000076"))
+    require.False(t, IsValidIfsc("HDFC1000076"))
+    require.False(t, IsValidIfsc("hdfc0000076"))
+    require.False(t, IsValidIfsc("HD1C0000076"))
+    require.False(t, IsValidIfsc(""))
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+    "fmt"
+    "strings"
+)
+
+// FormatAmount formats an amount held in the currency's minor unit, eg. 1050 with fraction 2 is "10.50"
+func FormatAmount(amount int64, fraction int64) string {
+    sign := ""
+    if amount < 0 {
+        sign = "-"
+        amount = -amount
+    }
+
+    digits := fmt.Sprintf("%d", amount)
+    if fraction <= 0 {
+        return sign + digits
+    }
+
+    if n := int(fraction) + 1 - len(digits); n > 0 {
+        digits = strings.Repeat("0", n) + digits
+    }
+
+    split := len(digits) - int(fraction)
+    return sign + digits[:split] + "." + digits[split:]
+}
@@ -0,0 +1,16 @@
+package util
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount(t *testing.T) {
+    require.Equal(t, "10.50", FormatAmount(1050, 2))
+    require.Equal(t, "0.05", FormatAmount(5, 2))
+    require.Equal(t, "0.00", FormatAmount(0, 2))
+    require.Equal(t, "-1.234", FormatAmount(-1234, 3))
+    require.Equal(t, "1050", FormatAmount(1050, 0))
+    require.Equal(t, "105.0", FormatAmount(1050, 1))
+}
@@ -0,0 +1,76 @@
+package util
+
+import (
+    "strings"
+    "unicode"
+)
+
+var nameTitles = map[string]bool{
+    "mr":   true,
+    "mrs":  true,
+    "ms":   true,
+    "miss": true,
+    "dr":   true,
+    "shri": true,
+    "smt":  true,
+}
+
+// NameMatchScore returns how closely two person names match on a scale of 0 to 100.
+// Case, punctuation, titles and word order are ignored, and an initial matches any word starting with it.
+func NameMatchScore(name1 string, name2 string) int64 {
+    tokens1 := nameTokens(name1)
+    tokens2 := nameTokens(name2)
+
+    if len(tokens1) == 0 || len(tokens2) == 0 {
+        return 0
+    }
+
+    used := make([]bool, len(tokens2))
+    matched := 0
+
+    for _, t1 := range tokens1 {
+        for j, t2 := range tokens2 {
+            if used[j] || !nameTokensMatch(t1, t2) {
+                continue
+            }
+
+            used[j] = true
+            matched++
+            break
+        }
+    }
+
+    return int64(200 * matched / (len(tokens1) + len(tokens2)))
+}
+
+func nameTokens(name string) []string {
+    fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+        return !unicode.IsLetter(r)
+    })
+
+    tokens := []string{}
+    for _, f := range fields {
+        if nameTitles[f] {
+            continue
+        }
+        tokens = append(tokens, f)
+    }
+
+    return tokens
+}
+
+func nameTokensMatch(t1 string, t2 string) bool {
+    if t1 == t2 {
+        return true
+    }
+
+    if len(t1) == 1 {
+        return strings.HasPrefix(t2, t1)
+    }
+
+    if len(t2) == 1 {
+        return strings.HasPrefix(t1, t2)
+    }
+
+    return false
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestNameMatchScore(t *testing.T) {
+    testcases := []struct {
+        name  string
+        name1 string
+        name2 string
+        score int64
+    }{
+        {name: "Exact", name1: "Pranay Sharma", name2: "Pranay Sharma", score: 100},
+        {name: "CaseAndPunctuation", name1: "pranay  sharma", name2: "PRANAY SHARMA.", score: 100},
+        {name: "WordOrder", name1: "Sharma Pranay", name2: "Pranay Sharma", score: 100},
+        {name: "Title", name1: "Mr. Pranay Sharma", name2: "PRANAY SHARMA", score: 100},
+        {name: "Initial", name1: "P Sharma", name2: "Pranay Sharma", score: 100},
+        {name: "MiddleNameMissing", name1: "Pranay Kumar Sharma", name2: "Pranay Sharma", score: 80},
+        {name: "Different", name1: "Pranay Sharma", name2: "Walter White", score: 0},
+        {name: "Empty", name1: "", name2: "Pranay Sharma", score: 0},
+    }
+
+    for _, tc := range testcases {
+        t.Run(tc.name, func(t *testing.T) {
+            require.Equal(t, tc.score, NameMatchScore(tc.name1, tc.name2))
+        })
+    }
+}
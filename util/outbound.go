@@ -0,0 +1,74 @@
+package util
+
+import (
+    "errors"
+    "fmt"
+    "net"
+    "net/http"
+    "net/url"
+    "syscall"
+    "time"
+)
+
+var (
+    ErrNonPublicURL     = errors.New("url must be an http or https url of a public host")
+    ErrNonPublicAddress = errors.New("connection to a non public address refused")
+)
+
+// sharedAddressSpace is the carrier grade nat range of RFC 6598, neither private nor public.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// NewOutboundHTTPClient returns the client of the requests to urls given by the users, the webhooks and the
+// checkout callbacks. It connects only to public addresses, checked on the resolved ip so that a dns name can't
+// point it to the internal network, and doesn't follow redirects.
+func NewOutboundHTTPClient(timeout time.Duration) *http.Client {
+    dialer := &net.Dialer{Timeout: timeout, Control: dialPublicOnly}
+
+    transport := http.DefaultTransport.(*http.Transport).Clone()
+    transport.Proxy = nil
+    transport.DialContext = dialer.DialContext
+
+    return &http.Client{
+        Timeout:   timeout,
+        Transport: transport,
+        CheckRedirect: func(req *http.Request, via []*http.Request) error {
+            return http.ErrUseLastResponse
+        },
+    }
+}
+
+// ValidateOutboundURL checks that rawURL is an http or https url and, when its host is an ip, that the ip is
+// public. The names are checked when they are dialed, they may resolve to another ip by then.
+func ValidateOutboundURL(rawURL string) error {
+    u, err := url.Parse(rawURL)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+        return ErrNonPublicURL
+    }
+
+    if ip := net.ParseIP(u.Hostname()); ip != nil && !IsPublicIP(ip) {
+        return ErrNonPublicURL
+    }
+
+    return nil
+}
+
+// IsPublicIP reports whether ip is routable on the internet, not a loopback, private, link-local or otherwise
+// reserved address.
+func IsPublicIP(ip net.IP) bool {
+    return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+        ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip))
+}
+
+func dialPublicOnly(network string, address string, _ syscall.RawConn) error {
+    host, _, err := net.SplitHostPort(address)
+    if err != nil {
+        return err
+    }
+
+    ip := net.ParseIP(host)
+    if ip == nil || !IsPublicIP(ip) {
+        return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+    }
+
+    return nil
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+    "errors"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestValidateOutboundURL(t *testing.T) {
+    require.NoError(t, ValidateOutboundURL("https://merchant.example.com/webhooks"))
+    require.NoError(t, ValidateOutboundURL("http://93.184.216.34:8080/hook"))
+
+    for _, rawURL := range []string{
+        "ftp://merchant.example.com/webhooks",
+        "file:///etc/passwd",
+        "gopher://localhost:6379/_INFO",
+        "https:///webhooks",
+        "http://127.0.0.1:8080/hook",
+        "http://10.0.0.5/hook",
+        "http://192.168.1.1/hook",
+        "http://169.254.169.254/latest/meta-data",
+        "http://100.64.0.1/hook",
+        "http://[::1]/hook",
+        "http://[fe80::1]/hook",
+        "http://0.0.0.0/hook",
+    } {
+        require.ErrorIs(t, ValidateOutboundURL(rawURL), ErrNonPublicURL, rawURL)
+    }
+}
+
+func TestIsPublicIP(t *testing.T) {
+    require.True(t, IsPublicIP(net.ParseIP("93.184.216.34")))
+    require.True(t, IsPublicIP(net.ParseIP("2606:2800:220:1:248:1893:25c8:1946")))
+    require.False(t, IsPublicIP(net.ParseIP("172.16.0.1")))
+    require.False(t, IsPublicIP(net.ParseIP("fd00::1")))
+}
+
+func TestOutboundHTTPClient(t *testing.T) {
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusOK)
+    }))
+    defer server.Close()
+
+    client := NewOutboundHTTPClient(time.Second)
+
+    // the test server listens on the loopback, the client must refuse to connect
+    _, err := client.Get(server.URL)
+    require.Error(t, err)
+    require.True(t, errors.Is(err, ErrNonPublicAddress))
+
+    redirect, err := http.NewRequest(http.MethodGet, "https://merchant.example.com/moved", nil)
+    require.NoError(t, err)
+    require.Equal(t, http.ErrUseLastResponse, client.CheckRedirect(redirect, nil))
+}
@@ -98,6 +98,7 @@ func RandomNewUser(createUserDto dto.CreateUserDto) (user domain.User, password
         FullName:       createUserDto.FullName,
         Status:         domain.UserStatusACTIVE,
         Email:          createUserDto.Email,
+        Role:           domain.UserRoleUSER,
     }
 
     return
@@ -112,20 +113,34 @@ func RandomUserDto(createUserDto dto.CreateUserDto) dto.UserDto {
     }
 }
 
+// RandomIfsc generates a random well-formed IFSC
+func RandomIfsc() string {
+    return fmt.Sprintf("%s0%06d", strings.ToUpper(RandomString(4)), RandomInt(0, 999999))
+}
+
 func RandomCreateBankAccountDto(currencyCode string) dto.CreateBankAccountDto {
     return dto.CreateBankAccountDto{
         AccountNo: RandomString(10),
-        Ifsc:      RandomString(7),
-        BankName:  RandomString(5),
+        Ifsc:      RandomIfsc(),
         UserID:    RandomInt(1, 1000),
         Currency:  currencyCode,
     }
 }
 
+func RandomBankBranch(ifsc string) domain.BankBranch {
+    return domain.BankBranch{
+        Ifsc:     ifsc,
+        BankName: strings.ToUpper(RandomString(5)),
+        Branch:   strings.ToUpper(RandomString(8)),
+        City:     strings.ToUpper(RandomString(6)),
+        State:    strings.ToUpper(RandomString(6)),
+    }
+}
+
 func RandomBankAccount(createBankAcctDto dto.CreateBankAccountDto) domain.BankAccount {
     return domain.BankAccount{
         UserID:    createBankAcctDto.UserID,
-        BankName:  createBankAcctDto.BankName,
+        BankName:  strings.ToUpper(RandomString(5)),
         Ifsc:      createBankAcctDto.Ifsc,
         AccountNo: createBankAcctDto.AccountNo,
         Status:    domain.BankAccountStatusINVERIFICATION,
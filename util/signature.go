@@ -0,0 +1,54 @@
+package util
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// SignWebhookPayload signs "<timestamp>.<payload>" with HMAC-SHA256 and returns the header value
+// in the form "t=<timestamp>,v1=<hex signature>"
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+    return fmt.Sprintf("t=%d,v1=%s", timestamp, webhookMac(secret, timestamp, payload))
+}
+
+// VerifyWebhookSignature check if the header was produced by SignWebhookPayload for the payload
+func VerifyWebhookSignature(secret string, header string, payload []byte) bool {
+    var timestamp int64
+    var signature string
+
+    for _, part := range strings.Split(header, ",") {
+        kv := strings.SplitN(part, "=", 2)
+        if len(kv) != 2 {
+            return false
+        }
+
+        switch kv[0] {
+        case "t":
+            t, err := strconv.ParseInt(kv[1], 10, 64)
+            if err != nil {
+                return false
+            }
+            timestamp = t
+        case "v1":
+            signature = kv[1]
+        }
+    }
+
+    if timestamp == 0 || signature == "" {
+        return false
+    }
+
+    return hmac.Equal([]byte(signature), []byte(webhookMac(secret, timestamp, payload)))
+}
+
+func webhookMac(secret string, timestamp int64, payload []byte) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+    mac.Write([]byte("."))
+    mac.Write(payload)
+    return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,23 @@
+package util
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+    secret := RandomString(32)
+    payload := []byte(`{"id":"evt_1","type":"transfer.completed"}`)
+
+    header := SignWebhookPayload(secret, 1600000000, payload)
+    require.Regexp(t, `^t=1600000000,v1=[0-9a-f]{64}$`, header)
+    require.Equal(t, header, SignWebhookPayload(secret, 1600000000, payload))
+
+    require.True(t, VerifyWebhookSignature(secret, header, payload))
+    require.False(t, VerifyWebhookSignature(RandomString(32), header, payload))
+    require.False(t, VerifyWebhookSignature(secret, header, []byte(`{"id":"evt_2"}`)))
+    require.False(t, VerifyWebhookSignature(secret, SignWebhookPayload(secret, 1600000001, payload)[:12], payload))
+    require.False(t, VerifyWebhookSignature(secret, "", payload))
+    require.False(t, VerifyWebhookSignature(secret, "garbage", payload))
+}
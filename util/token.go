@@ -0,0 +1,22 @@
+package util
+
+import (
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
+)
+
+// GenerateToken returns a random token of 32 bytes, hex encoded, e.g. to reset a password.
+func GenerateToken() (string, error) {
+    b := make([]byte, 32)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(b), nil
+}
+
+// HashToken returns the sha256 hash of the token to store, the tokens are random so they don't need a slow hash
+func HashToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,22 @@
+package util
+
+import (
+    "testing"
+
+    "github.com/stretchr/testify/require"
+)
+
+func TestToken(t *testing.T) {
+    token, err := GenerateToken()
+    require.NoError(t, err)
+    require.Regexp(t, `^[0-9a-f]{64}$`, token)
+
+    other, err := GenerateToken()
+    require.NoError(t, err)
+    require.NotEqual(t, token, other)
+
+    require.Regexp(t, `^[0-9a-f]{64}$`, HashToken(token))
+    require.NotEqual(t, token, HashToken(token))
+    require.Equal(t, HashToken(token), HashToken(token))
+    require.NotEqual(t, HashToken(token), HashToken(other))
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "context"
+    "github.com/pranayhere/simple-wallet/pkg/logging"
+    log "github.com/sirupsen/logrus"
+    "time"
+)
+
+// runEvery calls fn every interval in the background until ctx is cancelled.
+func runEvery(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+    go func() {
+        ctx := logging.WithFields(ctx, log.Fields{"worker": name})
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+
+        for {
+            select {
+            case <-ctx.Done():
+                logging.FromContext(ctx).Info("stopping worker")
+                return
+            case <-ticker.C:
+                if err := fn(ctx); err != nil {
+                    logging.FromContext(ctx).Error(err)
+                }
+            }
+        }
+    }()
+}